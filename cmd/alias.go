@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// aliasArgPattern matches the $1, $2, ... placeholders of an alias
+var aliasArgPattern = regexp.MustCompile(`\$(\d+)`)
+
+// savedAlias is a command shortcut stored under "aliases" in the config file
+type savedAlias struct {
+	Name      string `json:"name"`
+	Expansion string `json:"expansion"`
+	Shell     bool   `json:"shell"`
+}
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Create shortcuts for linctl commands",
+	Long: `Manage command aliases stored under "aliases" in the config file.
+
+An alias expands to a linctl command line. $1, $2, ... in the expansion are
+replaced by the arguments given to the alias; arguments not used by a
+placeholder are appended, so flags can still be added.
+
+An expansion starting with '!' (or set with --shell) is run by sh instead,
+with the alias's arguments as $1, $2, ..., which allows pipes and other
+commands. Shell aliases are not available on Windows.
+
+Quote the expansion so its flags are not read as flags of 'alias set'.
+
+Examples:
+  linctl alias set bugs 'issue list --label bug --state started'
+  linctl bugs --team ENG
+  linctl alias set mine 'issue list --assignee @me --sort updated'
+  linctl alias set start 'issue update $1 --state started --assignee @me'
+  linctl alias set ids '!linctl issue list --json "$@" | jq -r ".[].identifier"'
+  linctl alias list
+  linctl alias delete bugs`,
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:     "set NAME EXPANSION",
+	Aliases: []string{"add"},
+	Short:   "Create or replace an alias",
+	Args:    cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		name := strings.ToLower(args[0])
+		expansion := strings.TrimSpace(strings.Join(args[1:], " "))
+		if shell, _ := cmd.Flags().GetBool("shell"); shell && !strings.HasPrefix(expansion, "!") {
+			expansion = "!" + expansion
+		}
+		if err := validateAlias(name, expansion); err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		path, err := configFilePath()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to locate config file: %v", err), plaintext, jsonOut)
+		}
+		doc, err := loadConfigDocument(path)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		setConfigValue(doc, []string{"aliases", name}, expansion)
+		if err := saveConfigDocument(path, doc); err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		alias := savedAlias{Name: name, Expansion: expansion, Shell: strings.HasPrefix(expansion, "!")}
+		if jsonOut {
+			output.JSON(alias)
+		} else if plaintext {
+			fmt.Printf("Saved alias %s: %s\n", name, expansion)
+		} else {
+			fmt.Printf("%s Saved alias %s: %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(name),
+				expansion)
+			fmt.Printf("  Run it with: linctl %s\n", name)
+		}
+	},
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List aliases",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		aliases := savedAliases(viper.GetStringMapString("aliases"))
+
+		if jsonOut {
+			output.JSON(aliases)
+			return
+		}
+		if len(aliases) == 0 {
+			output.Info("No aliases. Create one with 'linctl alias set NAME EXPANSION'", plaintext, jsonOut)
+			return
+		}
+
+		rows := make([][]string, len(aliases))
+		for i, alias := range aliases {
+			rows[i] = []string{alias.Name, alias.Expansion}
+		}
+
+		if plaintext {
+			fmt.Println("Name\tExpansion")
+			for _, row := range rows {
+				fmt.Printf("%s\t%s\n", row[0], row[1])
+			}
+			return
+		}
+
+		output.Table(output.TableData{
+			Headers: []string{"Name", "Expansion"},
+			Rows:    rows,
+		}, plaintext, jsonOut)
+	},
+}
+
+var aliasDeleteCmd = &cobra.Command{
+	Use:     "delete NAME",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Delete an alias",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		name := strings.ToLower(args[0])
+
+		path, err := configFilePath()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to locate config file: %v", err), plaintext, jsonOut)
+		}
+		doc, err := loadConfigDocument(path)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if !deleteConfigValue(doc, []string{"aliases", name}) {
+			output.Error(fmt.Sprintf("Alias '%s' not found in %s", name, path), plaintext, jsonOut)
+			exit(1)
+		}
+		if err := saveConfigDocument(path, doc); err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"deleted": name})
+		} else {
+			output.Success(fmt.Sprintf("Deleted alias %s", name), plaintext, jsonOut)
+		}
+	},
+}
+
+// savedAliases sorts the aliases of the config file by name
+func savedAliases(config map[string]string) []savedAlias {
+	aliases := []savedAlias{}
+	for name, expansion := range config {
+		aliases = append(aliases, savedAlias{Name: name, Expansion: expansion, Shell: strings.HasPrefix(expansion, "!")})
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+	return aliases
+}
+
+// validateAlias checks that an alias does not shadow a command and that a
+// non-shell expansion starts with a linctl command
+func validateAlias(name, expansion string) error {
+	if name == "" || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid alias name %q", name)
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("invalid alias name %q (use letters, digits, '-' and '_')", name)
+		}
+	}
+	if found, _, err := rootCmd.Find([]string{name}); err == nil && found != rootCmd {
+		return fmt.Errorf("'%s' is already a linctl command", name)
+	}
+
+	if strings.HasPrefix(expansion, "!") {
+		if strings.TrimSpace(expansion[1:]) == "" {
+			return fmt.Errorf("shell alias '%s' is empty", name)
+		}
+		return nil
+	}
+	words, err := splitQuoted(expansion)
+	if err != nil {
+		return fmt.Errorf("invalid expansion: %v", err)
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("alias '%s' is empty", name)
+	}
+	if words[0] == "linctl" {
+		return fmt.Errorf("leave out 'linctl' at the start of the expansion")
+	}
+	if found, _, err := rootCmd.Find(words); err != nil || found == rootCmd {
+		return fmt.Errorf("'%s' is not a linctl command", words[0])
+	}
+	return nil
+}
+
+// expandAlias replaces an alias at the start of args with its expansion.
+// Shell aliases are run here, and linctl exits with their status.
+func expandAlias(args []string) ([]string, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return args, nil
+	}
+	if found, _, err := rootCmd.Find(args[:1]); err == nil && found != rootCmd {
+		// Commands always win over aliases
+		return args, nil
+	}
+
+	aliases, err := loadAliases(args)
+	if err != nil {
+		return nil, err
+	}
+	expansion, ok := aliases[strings.ToLower(args[0])]
+	if !ok {
+		return args, nil
+	}
+
+	if strings.HasPrefix(expansion, "!") {
+		exit(runShellAlias(args[0], expansion[1:], args[1:]))
+	}
+
+	words, err := splitQuoted(expansion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alias '%s': %v", args[0], err)
+	}
+	used := map[int]bool{}
+	for i, word := range words {
+		words[i] = aliasArgPattern.ReplaceAllStringFunc(word, func(match string) string {
+			n, _ := strconv.Atoi(match[1:])
+			if n < 1 || n >= len(args) {
+				return match
+			}
+			used[n] = true
+			return args[n]
+		})
+	}
+	for i, arg := range args[1:] {
+		if !used[i+1] {
+			words = append(words, arg)
+		}
+	}
+	return words, nil
+}
+
+// runShellAlias runs a '!' alias with sh and returns its exit status
+func runShellAlias(name, script string, args []string) int {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Shell alias '%s' needs sh, which was not found\n", name)
+		return 1
+	}
+	command := exec.Command(sh, append([]string{"-c", script, name}, args...)...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	if err := command.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		fmt.Fprintf(os.Stderr, "Failed to run alias '%s': %v\n", name, err)
+		return 1
+	}
+	return 0
+}
+
+// loadAliases reads the aliases of the config file. It runs before cobra
+// parses flags and loads the config, so it finds --config itself.
+func loadAliases(args []string) (map[string]string, error) {
+	path := ""
+	for i, arg := range args {
+		if arg == "--config" && i+1 < len(args) {
+			path = args[i+1]
+		} else if value, ok := strings.CutPrefix(arg, "--config="); ok {
+			path = value
+		}
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil
+		}
+		path = filepath.Join(home, ".linctl.yaml")
+	}
+
+	doc, err := loadConfigDocument(path)
+	if err != nil {
+		return nil, err
+	}
+	node := mappingValue(doc.Content[0], "aliases")
+	if node == nil {
+		return nil, nil
+	}
+	aliases := map[string]string{}
+	if err := node.Decode(&aliases); err != nil {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("aliases in %s must map names to command lines", path)
+		}
+		return nil, err
+	}
+	lowered := make(map[string]string, len(aliases))
+	for name, expansion := range aliases {
+		lowered[strings.ToLower(name)] = expansion
+	}
+	return lowered, nil
+}
+
+func init() {
+	rootCmd.AddCommand(aliasCmd)
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasDeleteCmd)
+
+	aliasSetCmd.Flags().BoolP("shell", "s", false, "Run the expansion with sh (same as starting it with '!')")
+}
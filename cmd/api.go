@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// apiCmd represents the api command
+var apiCmd = &cobra.Command{
+	Use:   "api",
+	Short: "Make raw Linear API requests",
+	Long: `Make raw requests against Linear's GraphQL API for fields linctl doesn't expose yet.
+
+Examples:
+  linctl api graphql --query '{ viewer { id name } }'
+  linctl api graphql --file query.graphql --var teamKey=ENG
+  echo '{ teams { nodes { key } } }' | linctl api graphql`,
+}
+
+var apiGraphqlCmd = &cobra.Command{
+	Use:     "graphql [query]",
+	Aliases: []string{"gql"},
+	Short:   "Execute a raw GraphQL query or mutation",
+	Long: `Execute a raw GraphQL query or mutation and print the JSON response.
+
+The query is read from the first argument, --query, --file, or stdin (in that order).
+Use --file - to read from stdin explicitly.
+
+Variables are passed with --var key=value. Values that parse as JSON (numbers,
+booleans, null, arrays, objects) are sent as JSON; anything else is sent as a string.
+
+Examples:
+  linctl api graphql '{ viewer { id name email } }'
+  linctl api graphql --query 'query($id: String!) { issue(id: $id) { title } }' --var id=LIN-123
+  linctl api graphql --file mutation.graphql --var input='{"title":"Hi","teamId":"..."}'
+  cat query.graphql | linctl api graphql --var first=10`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		query, err := readGraphQLQuery(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		varFlags, _ := cmd.Flags().GetStringArray("var")
+		variables, err := parseGraphQLVariables(varFlags)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		response, err := client.RawQuery(context.Background(), query, variables)
+		if response != nil {
+			// Always print the raw response, even when it carries GraphQL errors
+			output.JSON(response)
+		}
+		if err != nil {
+			if response == nil {
+				output.Error(fmt.Sprintf("Request failed: %v", err), plaintext, jsonOut)
+			}
+			exit(1)
+		}
+	},
+}
+
+// readGraphQLQuery returns the query text from an argument, --query, --file, or stdin
+func readGraphQLQuery(cmd *cobra.Command, args []string) (string, error) {
+	queryFlag, _ := cmd.Flags().GetString("query")
+	fileFlag, _ := cmd.Flags().GetString("file")
+
+	var query string
+	switch {
+	case len(args) == 1:
+		query = args[0]
+	case queryFlag != "":
+		query = queryFlag
+	case fileFlag == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read query from stdin: %w", err)
+		}
+		query = string(data)
+	case fileFlag != "":
+		data, err := os.ReadFile(fileFlag)
+		if err != nil {
+			return "", fmt.Errorf("failed to read query file: %w", err)
+		}
+		query = string(data)
+	default:
+		// Fall back to stdin only when something is piped in
+		stat, err := os.Stdin.Stat()
+		if err == nil && (stat.Mode()&os.ModeCharDevice) == 0 {
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return "", fmt.Errorf("failed to read query from stdin: %w", err)
+			}
+			query = string(data)
+		}
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", fmt.Errorf("a query is required (argument, --query, --file, or stdin)")
+	}
+	return query, nil
+}
+
+// parseGraphQLVariables converts key=value pairs into a variables map
+func parseGraphQLVariables(pairs []string) (map[string]interface{}, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	variables := make(map[string]interface{}, len(pairs))
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid variable %q (expected key=value)", pair)
+		}
+
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+			variables[key] = decoded
+		} else {
+			variables[key] = value
+		}
+	}
+	return variables, nil
+}
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+	apiCmd.AddCommand(apiGraphqlCmd)
+
+	apiGraphqlCmd.Flags().StringP("query", "q", "", "GraphQL query or mutation text")
+	apiGraphqlCmd.Flags().StringP("file", "f", "", "Read the query from a file ('-' for stdin)")
+	apiGraphqlCmd.Flags().StringArray("var", []string{}, "Query variable as key=value (can be used multiple times)")
+}
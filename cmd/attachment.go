@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// attachmentCmd represents the attachment command
+var attachmentCmd = &cobra.Command{
+	Use:     "attachment",
+	Aliases: []string{"attachments", "attach"},
+	Short:   "Manage issue attachments",
+	Long: `Manage attachments (linked URLs and uploaded files) on Linear issues.
+
+Examples:
+  linctl attachment list LIN-123
+  linctl attachment add LIN-123 --url https://github.com/org/repo/pull/42 --title "PR #42"
+  linctl attachment add LIN-123 --file ./crash.log
+  linctl attachment delete <attachment-id>`,
+}
+
+var attachmentListCmd = &cobra.Command{
+	Use:     "list ISSUE-ID",
+	Aliases: []string{"ls"},
+	Short:   "List attachments on an issue",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		issueID := args[0]
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		attachments, err := client.GetIssueAttachments(context.Background(), issueID)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list attachments: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(attachments)
+			return
+		}
+
+		if len(attachments) == 0 {
+			output.Info(fmt.Sprintf("No attachments on %s", issueID), plaintext, jsonOut)
+			return
+		}
+
+		if plaintext {
+			fmt.Println("ID\tTitle\tSubtitle\tURL\tCreator\tCreated")
+			for _, attachment := range attachments {
+				fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\n",
+					attachment.ID,
+					attachment.Title,
+					optionalString(attachment.Subtitle),
+					attachment.URL,
+					attachmentCreator(attachment),
+					attachment.CreatedAt.Format("2006-01-02"),
+				)
+			}
+			return
+		}
+
+		fmt.Printf("\n%s Attachments on %s (%d)\n\n",
+			color.New(color.FgCyan, color.Bold).Sprint("📎"),
+			color.New(color.FgCyan).Sprint(issueID),
+			len(attachments))
+
+		for _, attachment := range attachments {
+			fmt.Printf("%s %s\n",
+				color.New(color.FgWhite, color.Bold).Sprint(attachment.Title),
+				color.New(color.FgWhite, color.Faint).Sprintf("(%s)", attachment.ID))
+			if subtitle := optionalString(attachment.Subtitle); subtitle != "" {
+				fmt.Printf("  %s\n", subtitle)
+			}
+			fmt.Printf("  %s\n", color.New(color.FgBlue, color.Underline).Sprint(attachment.URL))
+			fmt.Printf("  %s\n", color.New(color.FgWhite, color.Faint).Sprintf("Added by %s, %s",
+				attachmentCreator(attachment), formatTimeAgo(attachment.CreatedAt)))
+			if len(attachment.Metadata) > 0 {
+				keys := make([]string, 0, len(attachment.Metadata))
+				for key := range attachment.Metadata {
+					keys = append(keys, key)
+				}
+				fmt.Printf("  %s\n", color.New(color.FgWhite, color.Faint).Sprintf("Metadata: %s", strings.Join(keys, ", ")))
+			}
+			fmt.Println()
+		}
+	},
+}
+
+var attachmentAddCmd = &cobra.Command{
+	Use:     "add ISSUE-ID",
+	Aliases: []string{"create", "new"},
+	Short:   "Attach a URL or file to an issue",
+	Long: `Attach a URL, or upload a local file and attach it, to an issue.
+
+Examples:
+  linctl attachment add LIN-123 --url https://example.com/spec --title "Spec" --subtitle "v2 draft"
+  linctl attachment add LIN-123 --file ./screenshot.png
+  linctl attachment add LIN-123 --file ./trace.bin --content-type application/x-perf-trace
+
+The content type of an uploaded file is detected from its first bytes and its
+extension; --content-type overrides it.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		issueID := args[0]
+
+		attachURL, _ := cmd.Flags().GetString("url")
+		filePath, _ := cmd.Flags().GetString("file")
+		title, _ := cmd.Flags().GetString("title")
+		subtitle, _ := cmd.Flags().GetString("subtitle")
+		contentType, _ := cmd.Flags().GetString("content-type")
+
+		if (attachURL == "") == (filePath == "") {
+			output.Error("Exactly one of --url or --file is required", plaintext, jsonOut)
+			exit(1)
+		}
+		if contentType != "" && filePath == "" {
+			output.Error("--content-type only applies to --file uploads", plaintext, jsonOut)
+			exit(1)
+		}
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+		ctx := context.Background()
+
+		if filePath != "" {
+			if !jsonOut && !plaintext {
+				fmt.Printf("Uploading %s...\n", filepath.Base(filePath))
+			}
+			attachURL, err = uploadFile(ctx, client, filePath, files.UploadOptions{ContentType: contentType})
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to upload %s: %v", filePath, err), plaintext, jsonOut)
+			}
+			if title == "" {
+				title = filepath.Base(filePath)
+			}
+		}
+		if title == "" {
+			title = attachURL
+		}
+
+		input := map[string]interface{}{
+			"issueId": issueID,
+			"url":     attachURL,
+			"title":   title,
+		}
+		if subtitle != "" {
+			input["subtitle"] = subtitle
+		}
+
+		attachment, err := client.CreateAttachment(ctx, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create attachment: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(attachment)
+		} else if plaintext {
+			fmt.Printf("Attached %s to %s\n", attachment.URL, issueID)
+			fmt.Printf("ID: %s\n", attachment.ID)
+		} else {
+			fmt.Printf("%s Attached %s to %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgWhite, color.Bold).Sprint(attachment.Title),
+				color.New(color.FgCyan, color.Bold).Sprint(issueID))
+			fmt.Printf("  %s\n", color.New(color.FgBlue, color.Underline).Sprint(attachment.URL))
+		}
+	},
+}
+
+var attachmentDeleteCmd = &cobra.Command{
+	Use:     "delete ATTACHMENT-ID...",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Delete attachments by ID",
+	Long:    `Delete one or more attachments by ID. Use 'linctl attachment list ISSUE-ID' to find IDs.`,
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		deleted := []string{}
+		var failures []string
+		for _, id := range args {
+			if err := client.DeleteAttachment(context.Background(), id); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", id, err))
+				continue
+			}
+			deleted = append(deleted, id)
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"deleted": deleted,
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			for _, id := range deleted {
+				if plaintext {
+					fmt.Printf("Deleted attachment %s\n", id)
+				} else {
+					fmt.Printf("%s Deleted attachment %s\n", color.New(color.FgGreen).Sprint("✓"), id)
+				}
+			}
+			for _, failure := range failures {
+				output.Error(fmt.Sprintf("Failed to delete attachment %s", failure), plaintext, false)
+			}
+		}
+
+		exitOnFailures(len(deleted), len(failures))
+	},
+}
+
+// uploadFile uploads a file to Linear like UploadFileToLinearWithOptions,
+// showing a progress bar unless opts already reports progress
+func uploadFile(ctx context.Context, client *api.Client, path string, opts files.UploadOptions) (string, error) {
+	if opts.Progress == nil {
+		progress := output.NewByteProgress("Uploading "+filepath.Base(path), 0)
+		defer progress.Done()
+		opts.Progress = progress
+	}
+	return client.UploadFileToLinearWithOptions(ctx, path, opts)
+}
+
+// attachmentCreator returns the creator's name, or "Unknown" for integrations
+func attachmentCreator(attachment api.Attachment) string {
+	if attachment.Creator == nil {
+		return "Unknown"
+	}
+	return attachment.Creator.Name
+}
+
+func init() {
+	rootCmd.AddCommand(attachmentCmd)
+	attachmentCmd.AddCommand(attachmentListCmd)
+	attachmentCmd.AddCommand(attachmentAddCmd)
+	attachmentCmd.AddCommand(attachmentDeleteCmd)
+
+	attachmentAddCmd.Flags().StringP("url", "u", "", "URL to attach")
+	attachmentAddCmd.Flags().StringP("file", "f", "", "Local file to upload and attach")
+	attachmentAddCmd.Flags().StringP("title", "t", "", "Attachment title (default: file name or URL)")
+	attachmentAddCmd.Flags().StringP("subtitle", "s", "", "Attachment subtitle")
+	attachmentAddCmd.Flags().String("content-type", "", "Content type of the uploaded file (default: detected from its contents)")
+}
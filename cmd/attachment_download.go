@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// downloadedFile is one file saved by 'attachment download'
+type downloadedFile struct {
+	URL    string `json:"url"`
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	// DuplicateOf is the path of an identical file already saved; nothing
+	// new was written
+	DuplicateOf string `json:"duplicateOf,omitempty"`
+}
+
+var attachmentDownloadCmd = &cobra.Command{
+	Use:   "download ISSUE-ID",
+	Short: "Download an issue's uploaded files and images",
+	Long: `Download the files uploaded to an issue's attachments and the images embedded
+in its description and comments.
+
+Files are downloaded in parallel and saved under their attachment title or
+image alt text, with an extension added from their contents when the name has
+none. --match keeps only files whose name matches a glob pattern. Identical
+files are saved once, and files already in the output directory with the same
+contents are left alone.
+
+Examples:
+  linctl attachment download ENG-123
+  linctl attachment download ENG-123 --out ./attachments/ --match '*.pdf'
+  linctl attachment download ENG-123 --skip-comments --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		outDir, _ := cmd.Flags().GetString("out")
+		pattern, _ := cmd.Flags().GetString("match")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		skipComments, _ := cmd.Flags().GetBool("skip-comments")
+		if pattern != "" {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				output.Error(fmt.Sprintf("Invalid --match pattern '%s': %v", pattern, err), plaintext, jsonOut)
+				exit(1)
+			}
+		}
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+		client := api.NewClient(authHeader)
+		ctx := context.Background()
+
+		issue, err := client.GetIssue(ctx, args[0])
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
+		}
+		var comments []api.Comment
+		if !skipComments {
+			comments, _, err = api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize}, func(ctx context.Context, first int, after string) ([]api.Comment, api.PageInfo, error) {
+				page, err := client.GetIssueComments(ctx, issue.ID, first, after, "createdAt")
+				if err != nil {
+					return nil, api.PageInfo{}, err
+				}
+				return page.Nodes, page.PageInfo, nil
+			})
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to get comments: %v", err), plaintext, jsonOut)
+			}
+		}
+		attachments, err := client.GetIssueAttachments(ctx, issue.ID)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list attachments: %v", err), plaintext, jsonOut)
+		}
+
+		candidates := issueFiles(issue, comments, attachments, pattern)
+		if len(candidates) == 0 {
+			if jsonOut {
+				output.JSON(map[string]interface{}{"downloaded": []downloadedFile{}})
+				return
+			}
+			output.Info(fmt.Sprintf("No files to download from %s", issue.Identifier), plaintext, jsonOut)
+			return
+		}
+
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create output directory: %v", err), plaintext, jsonOut)
+		}
+		if !jsonOut && !plaintext {
+			fmt.Printf("Downloading %d file(s) from %s...\n", len(candidates), issue.Identifier)
+		}
+		downloaded, failures := downloadIssueFiles(ctx, client.TransferClient(), candidates, outDir, pattern, concurrency)
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"downloaded": downloaded,
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			saved, duplicates := 0, 0
+			for _, file := range downloaded {
+				if file.DuplicateOf != "" {
+					duplicates++
+					if plaintext {
+						fmt.Printf("Duplicate of %s: %s\n", file.DuplicateOf, file.URL)
+					}
+					continue
+				}
+				saved++
+				if plaintext {
+					fmt.Printf("Saved %s\n", file.Path)
+				} else {
+					fmt.Printf("  %s %s\n", color.New(color.FgGreen).Sprint("✓"), file.Path)
+				}
+			}
+			for _, failure := range failures {
+				output.Error(failure, plaintext, false)
+			}
+			if !plaintext {
+				summary := fmt.Sprintf("\n%s Saved %d file(s) to %s", color.New(color.FgGreen).Sprint("✓"), saved, outDir)
+				if duplicates > 0 {
+					summary += color.New(color.FgWhite, color.Faint).Sprintf(" (%d duplicate(s) skipped)", duplicates)
+				}
+				fmt.Println(summary)
+			}
+		}
+
+		exitOnFailures(len(downloaded), len(failures))
+	},
+}
+
+// issueFiles lists the uploaded files of an issue's attachments and the
+// images embedded in its description and comments, once per URL. Files whose
+// name already shows they don't match pattern are left out; names without an
+// extension are matched after download, once their type is known.
+func issueFiles(issue *api.Issue, comments []api.Comment, attachments []api.Attachment, pattern string) []files.ImageInfo {
+	var candidates []files.ImageInfo
+	seen := make(map[string]bool)
+	add := func(file files.ImageInfo) {
+		// Relative paths and data: URIs have nothing to download
+		if seen[file.URL] || !strings.HasPrefix(file.URL, "http") {
+			return
+		}
+		seen[file.URL] = true
+		name := files.ImageFilename(file, len(candidates))
+		if pattern != "" && filepath.Ext(name) != "" && !matchesFilePattern(pattern, name) {
+			return
+		}
+		candidates = append(candidates, file)
+	}
+
+	for _, attachment := range attachments {
+		// Other attachments are links (pull requests, Slack threads, ...), not files
+		if strings.Contains(attachment.URL, "uploads.linear.app") {
+			add(files.ImageInfo{URL: attachment.URL, AltText: attachment.Title, IsLinearURL: true})
+		}
+	}
+	for _, img := range files.ExtractImagesFromMarkdown(issue.Description) {
+		add(img)
+	}
+	for _, comment := range comments {
+		for _, img := range files.ExtractImagesFromMarkdown(comment.Body) {
+			add(img)
+		}
+	}
+	return candidates
+}
+
+// downloadIssueFiles downloads candidates into a staging directory inside
+// outDir, then moves each into outDir under its final name, skipping files
+// that don't match pattern and files whose contents were already saved
+func downloadIssueFiles(ctx context.Context, httpClient *http.Client, candidates []files.ImageInfo, outDir, pattern string, workers int) ([]downloadedFile, []string) {
+	staging, err := os.MkdirTemp(outDir, ".linctl-download-")
+	if err != nil {
+		return nil, []string{fmt.Sprintf("failed to create staging directory: %v", err)}
+	}
+	defer os.RemoveAll(staging)
+
+	bar := output.NewProgress("Downloading...", len(candidates))
+	results, _ := files.DownloadImages(ctx, candidates, staging, files.DownloadOptions{
+		Workers:  workers,
+		Client:   httpClient,
+		Progress: func(files.DownloadResult) { bar.Add(1) },
+	})
+	bar.Done()
+
+	downloaded := []downloadedFile{}
+	var failures []string
+	saved := make(map[string]string)
+	for _, result := range results {
+		if result.Err != nil {
+			failures = append(failures, result.Err.Error())
+			continue
+		}
+
+		name := filepath.Base(result.Path)
+		if filepath.Ext(name) == "" {
+			name += importFileExtension(result.Path, "")
+		}
+		if pattern != "" && !matchesFilePattern(pattern, name) {
+			continue
+		}
+
+		sum, err := fileSHA256(result.Path)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("failed to read %s: %v", result.Image.URL, err))
+			continue
+		}
+		file := downloadedFile{URL: result.Image.URL, SHA256: sum}
+		if existing, ok := saved[sum]; ok {
+			file.DuplicateOf = existing
+			downloaded = append(downloaded, file)
+			continue
+		}
+
+		file.Path, err = placeDownloadedFile(result.Path, outDir, name, sum)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("failed to save %s: %v", result.Image.URL, err))
+			continue
+		}
+		saved[sum] = file.Path
+		downloaded = append(downloaded, file)
+	}
+	return downloaded, failures
+}
+
+// placeDownloadedFile moves a staged download to outDir/name. When a file
+// with that name exists, it is kept if its contents are the same, and the
+// download is saved as name-2, name-3, ... otherwise.
+func placeDownloadedFile(staged, outDir, name, sum string) (string, error) {
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for n := 1; ; n++ {
+		target := filepath.Join(outDir, name)
+		if n > 1 {
+			target = filepath.Join(outDir, fmt.Sprintf("%s-%d%s", stem, n, ext))
+		}
+		existing, err := fileSHA256(target)
+		if os.IsNotExist(err) {
+			return target, os.Rename(staged, target)
+		}
+		if err == nil && existing == sum {
+			return target, nil
+		}
+	}
+}
+
+// matchesFilePattern reports whether a file name matches a glob pattern,
+// ignoring case so '*.pdf' also matches REPORT.PDF
+func matchesFilePattern(pattern, name string) bool {
+	matched, _ := filepath.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return matched
+}
+
+// fileSHA256 returns the hex SHA-256 of a file's contents
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func init() {
+	attachmentCmd.AddCommand(attachmentDownloadCmd)
+
+	attachmentDownloadCmd.Flags().StringP("out", "o", ".", "Directory to save the files to")
+	attachmentDownloadCmd.Flags().StringP("match", "m", "", "Only download files whose name matches this glob pattern (e.g. '*.pdf')")
+	attachmentDownloadCmd.Flags().Int("concurrency", 4, "Maximum number of files to download in parallel")
+	attachmentDownloadCmd.Flags().Bool("skip-comments", false, "Leave out images embedded in comments")
+}
@@ -2,7 +2,7 @@ package cmd
 
 import (
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/dorkitude/linctl/pkg/auth"
 	"github.com/dorkitude/linctl/pkg/output"
@@ -15,11 +15,12 @@ import (
 var authCmd = &cobra.Command{
 	Use:   "auth",
 	Short: "Authenticate with Linear",
-	Long: `Authenticate with Linear using Personal API Key.
+	Long: `Authenticate with Linear using a Personal API Key or OAuth.
 
 Examples:
   linctl auth              # Interactive authentication
   linctl auth login        # Same as above
+  linctl auth login --oauth --client-id <id>  # Browser-based OAuth login
   linctl auth status       # Check authentication status
   linctl auth logout       # Clear stored credentials`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -31,7 +32,15 @@ Examples:
 var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Login to Linear",
-	Long:  `Authenticate with Linear using Personal API Key.`,
+	Long: `Authenticate with Linear using a Personal API Key (default) or OAuth.
+
+With --oauth, linctl opens your browser to authorize a Linear OAuth application
+and listens on http://localhost:<port>/callback for the redirect, so the app's
+redirect URI must match. Access tokens are refreshed automatically when Linear
+issues a refresh token.
+
+The client ID and secret can also be set in the config file as oauth.client_id
+and oauth.client_secret.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
@@ -41,10 +50,23 @@ var loginCmd = &cobra.Command{
 			fmt.Println()
 		}
 
-		err := auth.Login(plaintext, jsonOut)
+		var err error
+		if useOAuth, _ := cmd.Flags().GetBool("oauth"); useOAuth {
+			port, _ := cmd.Flags().GetInt("port")
+			scopes, _ := cmd.Flags().GetString("scopes")
+			noBrowser, _ := cmd.Flags().GetBool("no-browser")
+			err = auth.LoginWithOAuth(auth.OAuthOptions{
+				ClientID:     viper.GetString("oauth.client_id"),
+				ClientSecret: viper.GetString("oauth.client_secret"),
+				Port:         port,
+				Scopes:       scopes,
+				NoBrowser:    noBrowser,
+			}, plaintext, jsonOut)
+		} else {
+			err = auth.Login(plaintext, jsonOut)
+		}
 		if err != nil {
-			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
 		}
 
 		if !plaintext && !jsonOut {
@@ -80,20 +102,50 @@ var statusCmd = &cobra.Command{
 			} else {
 				fmt.Println("Not authenticated")
 			}
-			os.Exit(1)
+			exit(exitAuth)
 		}
 
+		info, _ := auth.GetAuthInfo()
+
 		if jsonOut {
-			output.JSON(map[string]interface{}{
+			result := map[string]interface{}{
 				"authenticated": true,
+				"profile":       auth.Profile(),
 				"user":          user,
-			})
+			}
+			if info != nil {
+				result["auth"] = info
+			}
+			output.JSON(result)
 		} else if plaintext {
 			fmt.Printf("Authenticated as: %s (%s)\n", user.Name, user.Email)
+			fmt.Printf("User ID: %s\n", user.ID)
+			fmt.Printf("Profile: %s\n", auth.Profile())
+			if info != nil {
+				fmt.Printf("Method: %s\n", info.Method)
+				if info.ExpiresAt != nil {
+					fmt.Printf("Token expires: %s\n", info.ExpiresAt.Format(time.RFC3339))
+				}
+			}
 		} else {
 			fmt.Println(color.New(color.FgGreen).Sprint("✅ Authenticated"))
 			fmt.Printf("User: %s\n", color.New(color.FgCyan).Sprint(user.Name))
 			fmt.Printf("Email: %s\n", color.New(color.FgCyan).Sprint(user.Email))
+			fmt.Printf("ID: %s\n", color.New(color.FgCyan).Sprint(user.ID))
+			fmt.Printf("Profile: %s\n", color.New(color.FgCyan).Sprint(auth.Profile()))
+			if info != nil {
+				method := "Personal API Key"
+				if info.Method == "oauth" {
+					method = "OAuth"
+					if info.Scope != "" {
+						method += " (" + info.Scope + ")"
+					}
+				}
+				fmt.Printf("Method: %s\n", color.New(color.FgCyan).Sprint(method))
+				if info.ExpiresAt != nil {
+					fmt.Printf("Token expires: %s\n", color.New(color.FgCyan).Sprint(info.ExpiresAt.Local().Format("2006-01-02 15:04")))
+				}
+			}
 		}
 	},
 }
@@ -108,8 +160,7 @@ var logoutCmd = &cobra.Command{
 
 		err := auth.Logout()
 		if err != nil {
-			output.Error(fmt.Sprintf("Logout failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Logout failed: %v", err), plaintext, jsonOut)
 		}
 
 		if jsonOut {
@@ -128,7 +179,14 @@ var logoutCmd = &cobra.Command{
 var whoamiCmd = &cobra.Command{
 	Use:   "whoami",
 	Short: "Show current user",
-	Long:  `Display information about the currently authenticated user.`,
+	Long: `Display information about the currently authenticated user.
+
+This also refreshes the locally cached viewer that commands use to resolve
+"me" (e.g. --assignee me) without an extra API call.
+
+Examples:
+  linctl whoami
+  linctl whoami --json | jq -r .user.id`,
 	Run: func(cmd *cobra.Command, args []string) {
 		statusCmd.Run(cmd, args)
 	},
@@ -140,6 +198,16 @@ func init() {
 	authCmd.AddCommand(statusCmd)
 	authCmd.AddCommand(logoutCmd)
 
+	// Login flags
+	loginCmd.Flags().Bool("oauth", false, "Authenticate with OAuth in the browser instead of an API key")
+	loginCmd.Flags().String("client-id", "", "OAuth application client ID")
+	loginCmd.Flags().String("client-secret", "", "OAuth application client secret (optional with PKCE)")
+	loginCmd.Flags().Int("port", auth.DefaultOAuthPort, "Local port for the OAuth callback")
+	loginCmd.Flags().String("scopes", auth.DefaultOAuthScopes, "Comma-separated OAuth scopes")
+	loginCmd.Flags().Bool("no-browser", false, "Print the authorization URL instead of opening a browser")
+	_ = viper.BindPFlag("oauth.client_id", loginCmd.Flags().Lookup("client-id"))
+	_ = viper.BindPFlag("oauth.client_secret", loginCmd.Flags().Lookup("client-secret"))
+
 	// Add whoami as a top-level command too
 	rootCmd.AddCommand(whoamiCmd)
 }
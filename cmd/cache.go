@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// defaultCacheTTL is how long API responses stay fresh unless Linear's
+// Cache-Control says otherwise
+const defaultCacheTTL = 5 * time.Minute
+
+// cacheableCommands are the commands, by path below linctl, whose queries may
+// be answered from the response cache: those reading reference data (teams,
+// users, labels, workflow states) that rarely changes. Issues, comments, and
+// the like change under linctl's feet, in Linear or from teammates, so
+// commands reading them always fetch current data.
+var cacheableCommands = map[string]bool{
+	"team list": true, "team get": true, "team members": true, "team states": true, "team labels": true,
+	"user list": true, "user get": true, "user me": true, "whoami": true,
+	"label list": true, "state list": true,
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the API response cache",
+	Long: `linctl caches the responses of queries for reference data under
+~/.linctl/http-cache, so repeating a command such as 'team list' or
+'user list' answers instantly. A response stays fresh for --cache-ttl
+(default 5m, or 'cache-ttl' in the config file) unless Linear's Cache-Control
+header says otherwise; after that it is revalidated with its ETag when Linear
+sent one, or fetched again.
+
+Only commands reading teams, users, labels, and workflow states use the cache
+(team list/get/members/states/labels, user list/get/me, whoami, label list,
+state list), so they can show changes made elsewhere up to --cache-ttl late.
+Issues, comments, projects, and everything else are always fetched, and any
+change made through linctl empties the cache. Use --no-cache to bypass it for
+one command, or set cache-ttl to 0 to turn it off.
+
+This is separate from the offline issue cache managed by 'linctl sync'.
+
+Examples:
+  linctl team list --no-cache
+  linctl cache status
+  linctl cache clear`,
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show what the response cache holds",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		dir, err := responseCacheDir()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		stats, err := (&api.ResponseCache{Dir: dir}).Stats()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to read cache: %v", err), plaintext, jsonOut)
+		}
+
+		enabled := responseCacheEnabled()
+		ttl := viper.GetDuration("cache-ttl")
+		if jsonOut {
+			output.JSON(map[string]interface{}{
+				"path":    stats.Path,
+				"entries": stats.Entries,
+				"fresh":   stats.Fresh,
+				"size":    stats.Size,
+				"enabled": enabled,
+				"ttl":     ttl.String(),
+			})
+			return
+		}
+
+		state := fmt.Sprintf("enabled, %s", ttl)
+		if !enabled {
+			state = "disabled"
+		}
+		if plaintext {
+			fmt.Printf("Path: %s\n", stats.Path)
+			fmt.Printf("Cache: %s\n", state)
+			fmt.Printf("Responses: %d (%d fresh)\n", stats.Entries, stats.Fresh)
+			fmt.Printf("Size: %d bytes\n", stats.Size)
+			return
+		}
+
+		label := color.New(color.FgWhite, color.Faint)
+		fmt.Printf("%s %s\n", label.Sprint("Path:     "), stats.Path)
+		fmt.Printf("%s %s\n", label.Sprint("Cache:    "), state)
+		fmt.Printf("%s %d (%d fresh)\n", label.Sprint("Responses:"), stats.Entries, stats.Fresh)
+		fmt.Printf("%s %.1f KB\n", label.Sprint("Size:     "), float64(stats.Size)/1024)
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete all cached API responses",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		dir, err := responseCacheDir()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		removed, err := (&api.ResponseCache{Dir: dir}).Clear()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to clear cache: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"cleared": removed, "path": dir})
+			return
+		}
+		output.Success(fmt.Sprintf("Cleared %d cached responses", removed), plaintext, jsonOut)
+	},
+}
+
+// responseCacheEnabled reports whether --no-cache and cache-ttl allow cached reads
+func responseCacheEnabled() bool {
+	return viper.GetDuration("cache-ttl") > 0 && !viper.GetBool("no-cache")
+}
+
+// applyResponseCache turns off cached reads for commands that do not just read
+// reference data
+func applyResponseCache(cmd *cobra.Command) {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if api.DefaultResponseCache != nil && !cacheableCommands[path] {
+		api.DefaultResponseCache.Bypass = true
+	}
+}
+
+// responseCacheDir is ~/.linctl/http-cache. Entries are keyed by credentials,
+// so profiles share the directory without seeing each other's responses.
+func responseCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl", "http-cache"), nil
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatusCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
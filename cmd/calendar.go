@@ -0,0 +1,396 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/ical"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// calendarEventKinds are the kinds of events --events can select
+var calendarEventKinds = []string{"issues", "projects", "cycles"}
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Export deadlines and cycles as a calendar",
+	Long:  `Export issue due dates, project target dates, and cycles as an iCalendar file.`,
+}
+
+var calendarExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write due dates, project targets, and cycles to an .ics file",
+	Long: `Write an iCalendar (.ics) file with an all-day event for every issue due
+date, every project target date, and the first and last day of every cycle,
+so deadlines show up in Google Calendar, Apple Calendar, or Outlook.
+
+Completed and canceled issues, projects, and cycles are left out unless
+--include-completed is set. --team limits every kind of event to those teams.
+--assignee limits issues to the ones assigned to a user and projects to the
+ones they lead; cycles are not filtered by it.
+
+Events keep stable IDs, so importing a newer file (or re-running the export
+on a schedule into a subscribed file) updates events instead of duplicating
+them. Use --out - to write the calendar to stdout.
+
+Examples:
+  linctl calendar export --out linear.ics
+  linctl calendar export --team ENG --assignee @me --out my-deadlines.ics
+  linctl calendar export --events cycles --out - > cycles.ics`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		outPath, _ := cmd.Flags().GetString("out")
+		teamFlag, _ := cmd.Flags().GetString("team")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		eventsFlag, _ := cmd.Flags().GetString("events")
+		includeCompleted, _ := cmd.Flags().GetBool("include-completed")
+
+		kinds := map[string]bool{}
+		for _, kind := range strings.Split(eventsFlag, ",") {
+			kind = strings.ToLower(strings.TrimSpace(kind))
+			if !containsString(calendarEventKinds, kind) {
+				output.Error(fmt.Sprintf("Unknown event kind '%s' (expected %s)", kind, strings.Join(calendarEventKinds, ", ")), plaintext, jsonOut)
+				exit(1)
+			}
+			kinds[kind] = true
+		}
+
+		var teams []string
+		for _, value := range strings.Split(teamFlag, ",") {
+			key, err := resolveTeamKey(value)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			if key != "" {
+				teams = append(teams, key)
+			}
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		var userFilterValue map[string]interface{}
+		if assignee != "" {
+			var err error
+			userFilterValue, err = userFilter(ctx, assignee)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+		}
+
+		calendar := &ical.Calendar{
+			Name:   "Linear",
+			ProdID: fmt.Sprintf("-//linctl//linctl %s//EN", version),
+		}
+		if len(teams) > 0 {
+			calendar.Name = "Linear " + strings.Join(teams, ", ")
+		}
+		counts := map[string]int{}
+
+		if kinds["issues"] {
+			issues, err := calendarIssues(ctx, client, teams, userFilterValue, includeCompleted)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to fetch issues: %v", err), plaintext, jsonOut)
+			}
+			for _, issue := range issues {
+				if event, ok := issueCalendarEvent(issue); ok {
+					calendar.Events = append(calendar.Events, event)
+					counts["issues"]++
+				}
+			}
+		}
+
+		if kinds["projects"] {
+			projects, err := calendarProjects(ctx, client, teams, userFilterValue)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to fetch projects: %v", err), plaintext, jsonOut)
+			}
+			for _, project := range projects {
+				if !includeCompleted && (project.State == "completed" || project.State == "canceled") {
+					continue
+				}
+				if event, ok := projectCalendarEvent(project); ok {
+					calendar.Events = append(calendar.Events, event)
+					counts["projects"]++
+				}
+			}
+		}
+
+		if kinds["cycles"] {
+			cycles, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: 100}, func(ctx context.Context, first int, after string) ([]api.Cycle, api.PageInfo, error) {
+				page, err := client.GetCycles(ctx, first, after)
+				if err != nil {
+					return nil, api.PageInfo{}, err
+				}
+				return page.Nodes, page.PageInfo, nil
+			})
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to fetch cycles: %v", err), plaintext, jsonOut)
+			}
+			for _, cycle := range filterExportCycles(cycles, teams) {
+				if !includeCompleted && cycle.CompletedAt != nil {
+					continue
+				}
+				events := cycleCalendarEvents(cycle)
+				calendar.Events = append(calendar.Events, events...)
+				if len(events) > 0 {
+					counts["cycles"]++
+				}
+			}
+		}
+
+		sort.SliceStable(calendar.Events, func(i, j int) bool {
+			return calendar.Events[i].Start.Before(calendar.Events[j].Start)
+		})
+
+		var w io.Writer = os.Stdout
+		var file *os.File
+		if outPath != "-" {
+			var err error
+			file, err = os.Create(outPath)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to create %s: %v", outPath, err), plaintext, jsonOut)
+			}
+			w = file
+		}
+		err := calendar.Write(w, time.Now())
+		if file != nil {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to write calendar: %v", err), plaintext, jsonOut)
+		}
+		if outPath == "-" {
+			return
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{
+				"out":    outPath,
+				"events": len(calendar.Events),
+				"counts": counts,
+			})
+		} else if plaintext {
+			fmt.Printf("Wrote %d events to %s\n", len(calendar.Events), outPath)
+			for _, kind := range calendarEventKinds {
+				if kinds[kind] {
+					fmt.Printf("%s\t%d\n", kind, counts[kind])
+				}
+			}
+		} else {
+			fmt.Printf("%s Wrote %d events to %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				len(calendar.Events),
+				color.New(color.FgCyan, color.Bold).Sprint(outPath))
+			for _, kind := range calendarEventKinds {
+				if kinds[kind] {
+					fmt.Printf("  %-9s %d\n", kind, counts[kind])
+				}
+			}
+		}
+	},
+}
+
+// calendarIssues fetches the issues with a due date
+func calendarIssues(ctx context.Context, client *api.Client, teams []string, assignee map[string]interface{}, includeCompleted bool) ([]api.Issue, error) {
+	filter := map[string]interface{}{
+		"dueDate": map[string]interface{}{"null": false},
+	}
+	if len(teams) > 0 {
+		filter["team"] = map[string]interface{}{"key": map[string]interface{}{"in": teams}}
+	}
+	if assignee != nil {
+		filter["assignee"] = assignee
+	}
+	if !includeCompleted {
+		filter["state"] = map[string]interface{}{"type": map[string]interface{}{"nin": []string{"completed", "canceled"}}}
+	}
+
+	issues, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize}, func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+		page, err := client.GetIssues(ctx, filter, first, after, "")
+		if err != nil {
+			return nil, api.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	return issues, err
+}
+
+// calendarProjects fetches the projects with a target date
+func calendarProjects(ctx context.Context, client *api.Client, teams []string, lead map[string]interface{}) ([]api.Project, error) {
+	filter := map[string]interface{}{
+		"targetDate": map[string]interface{}{"null": false},
+	}
+	if len(teams) > 0 {
+		filter["accessibleTeams"] = map[string]interface{}{
+			"some": map[string]interface{}{"key": map[string]interface{}{"in": teams}},
+		}
+	}
+	if lead != nil {
+		filter["lead"] = lead
+	}
+
+	projects, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: 100}, func(ctx context.Context, first int, after string) ([]api.Project, api.PageInfo, error) {
+		page, err := client.GetProjects(ctx, filter, first, after, "")
+		if err != nil {
+			return nil, api.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	return projects, err
+}
+
+// issueCalendarEvent is an all-day event on an issue's due date
+func issueCalendarEvent(issue api.Issue) (ical.Event, bool) {
+	if issue.DueDate == nil {
+		return ical.Event{}, false
+	}
+	due, err := time.Parse("2006-01-02", *issue.DueDate)
+	if err != nil {
+		return ical.Event{}, false
+	}
+
+	var details []string
+	if issue.State != nil {
+		details = append(details, "State: "+issue.State.Name)
+	}
+	if issue.Assignee != nil {
+		details = append(details, "Assignee: "+issue.Assignee.Name)
+	}
+	if issue.Priority > 0 {
+		details = append(details, "Priority: "+priorityToString(issue.Priority))
+	}
+	if issue.Project != nil {
+		details = append(details, "Project: "+issue.Project.Name)
+	}
+	if issue.URL != "" {
+		details = append(details, issue.URL)
+	}
+
+	var categories []string
+	if issue.Team != nil {
+		categories = append(categories, issue.Team.Key)
+	}
+	if issue.Labels != nil {
+		for _, label := range issue.Labels.Nodes {
+			categories = append(categories, label.Name)
+		}
+	}
+
+	return ical.Event{
+		UID:         "issue-" + issue.ID + "@linctl",
+		Summary:     fmt.Sprintf("%s: %s", issue.Identifier, issue.Title),
+		Description: strings.Join(details, "\n"),
+		URL:         issue.URL,
+		Categories:  categories,
+		Start:       due,
+		AllDay:      true,
+	}, true
+}
+
+// projectCalendarEvent is an all-day event on a project's target date
+func projectCalendarEvent(project api.Project) (ical.Event, bool) {
+	if project.TargetDate == nil {
+		return ical.Event{}, false
+	}
+	target, err := time.Parse("2006-01-02", *project.TargetDate)
+	if err != nil {
+		return ical.Event{}, false
+	}
+
+	details := []string{
+		"State: " + project.State,
+		fmt.Sprintf("Progress: %.0f%%", project.Progress*100),
+	}
+	if project.Lead != nil {
+		details = append(details, "Lead: "+project.Lead.Name)
+	}
+	if project.URL != "" {
+		details = append(details, project.URL)
+	}
+
+	var categories []string
+	if project.Teams != nil {
+		for _, team := range project.Teams.Nodes {
+			categories = append(categories, team.Key)
+		}
+	}
+
+	return ical.Event{
+		UID:         "project-" + project.ID + "@linctl",
+		Summary:     "Target: " + project.Name,
+		Description: strings.Join(details, "\n"),
+		URL:         project.URL,
+		Categories:  categories,
+		Start:       target,
+		AllDay:      true,
+	}, true
+}
+
+// cycleCalendarEvents are all-day events on a cycle's first and last day.
+// endsAt is the instant the cycle closes, so the last day is the day before
+// when it closes at midnight.
+func cycleCalendarEvents(cycle api.Cycle) []ical.Event {
+	starts, err := time.Parse(time.RFC3339, cycle.StartsAt)
+	if err != nil {
+		return nil
+	}
+	ends, err := time.Parse(time.RFC3339, cycle.EndsAt)
+	if err != nil {
+		return nil
+	}
+	starts = starts.Local()
+	ends = ends.Local().Add(-time.Second)
+
+	name := historyCycle(&cycle)
+	var categories []string
+	if cycle.Team != nil {
+		name = cycle.Team.Key + " " + name
+		categories = append(categories, cycle.Team.Key)
+	}
+	description := fmt.Sprintf("%s – %s", starts.Format("Jan 2"), ends.Format("Jan 2, 2006"))
+
+	return []ical.Event{
+		{
+			UID:         "cycle-" + cycle.ID + "-start@linctl",
+			Summary:     name + " starts",
+			Description: description,
+			Categories:  categories,
+			Start:       starts,
+			AllDay:      true,
+		},
+		{
+			UID:         "cycle-" + cycle.ID + "-end@linctl",
+			Summary:     name + " ends",
+			Description: description,
+			Categories:  categories,
+			Start:       ends,
+			AllDay:      true,
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(calendarCmd)
+	calendarCmd.AddCommand(calendarExportCmd)
+
+	calendarExportCmd.Flags().StringP("out", "o", "linear.ics", "File to write the calendar to (- for stdout)")
+	calendarExportCmd.Flags().StringP("team", "t", "", "Only include these teams (comma-separated keys; @any for all)")
+	calendarExportCmd.Flags().StringP("assignee", "a", "", "Only include issues assigned to, and projects led by, this user (email, name, or @me)")
+	calendarExportCmd.Flags().String("events", strings.Join(calendarEventKinds, ","), "Kinds of events to include (issues, projects, cycles)")
+	calendarExportCmd.Flags().Bool("include-completed", false, "Include completed and canceled issues, projects, and cycles")
+}
@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -25,7 +24,12 @@ var commentCmd = &cobra.Command{
 
 Examples:
   linctl comment list LIN-123        # List comments for an issue
-  linctl comment create LIN-123 --body "This is fixed"  # Add a comment`,
+  linctl comment create LIN-123 --body "This is fixed"  # Add a comment
+  linctl comment add LIN-123 -F notes.md                # Body from a markdown file
+  linctl comment reply <comment-id> --body "Agreed"     # Reply in a thread
+  linctl comment edit <comment-id> --body "Updated text"
+  linctl comment delete <comment-id>
+  linctl comment react <comment-id> :+1:`,
 }
 
 var commentListCmd = &cobra.Command{
@@ -42,8 +46,7 @@ var commentListCmd = &cobra.Command{
 		// Get auth header
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
 		}
 
 		// Create API client
@@ -66,15 +69,14 @@ var commentListCmd = &cobra.Command{
 				orderBy = ""
 			default:
 				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 
 		// Get comments
 		comments, err := client.GetIssueComments(context.Background(), issueID, limit, "", orderBy)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to list comments: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to list comments: %v", err), plaintext, jsonOut)
 		}
 
 		// Handle output
@@ -85,9 +87,16 @@ var commentListCmd = &cobra.Command{
 				if i > 0 {
 					fmt.Println("---")
 				}
+				fmt.Printf("ID: %s\n", comment.ID)
+				if comment.Parent != nil {
+					fmt.Printf("Reply to: %s\n", comment.Parent.ID)
+				}
 				fmt.Printf("Author: %s\n", comment.User.Name)
 				fmt.Printf("Date: %s\n", comment.CreatedAt.Format("2006-01-02 15:04:05"))
 				fmt.Printf("Comment:\n%s\n", comment.Body)
+				if summary := reactionSummary(comment.Reactions); summary != "" {
+					fmt.Printf("Reactions: %s\n", summary)
+				}
 			}
 		} else {
 			// Rich display
@@ -110,13 +119,25 @@ var commentListCmd = &cobra.Command{
 
 				// Header with author and time
 				timeAgo := formatTimeAgo(comment.CreatedAt)
-				fmt.Printf("%s %s %s\n",
+				if comment.EditedAt != nil {
+					timeAgo += " (edited)"
+				}
+				replyMarker := ""
+				if comment.Parent != nil {
+					replyMarker = color.New(color.FgWhite, color.Faint).Sprint("↳ ")
+				}
+				fmt.Printf("%s%s %s %s %s\n",
+					replyMarker,
 					color.New(color.FgCyan, color.Bold).Sprint(comment.User.Name),
 					color.New(color.FgWhite, color.Faint).Sprint("•"),
-					color.New(color.FgWhite, color.Faint).Sprint(timeAgo))
+					color.New(color.FgWhite, color.Faint).Sprint(timeAgo),
+					color.New(color.FgWhite, color.Faint).Sprintf("[%s]", comment.ID))
 
 				// Comment body
 				fmt.Printf("\n%s\n\n", comment.Body)
+				if summary := reactionSummary(comment.Reactions); summary != "" {
+					fmt.Printf("%s\n\n", summary)
+				}
 			}
 		}
 	},
@@ -126,60 +147,44 @@ var commentCreateCmd = &cobra.Command{
 	Use:     "create ISSUE-ID",
 	Aliases: []string{"add", "new"},
 	Short:   "Create a comment on an issue",
-	Long:    `Add a new comment to a specific issue.`,
-	Args:    cobra.ExactArgs(1),
+	Long: `Add a new comment to a specific issue.
+
+The body is taken from --body, from a markdown file with --body-file
+('-' reads stdin), or written in your editor with --editor. Images given with
+--image are uploaded and appended.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
-		issueID := args[0]
+		issueID, err := issueFromArgs(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		runCommentEditor(cmd, "", plaintext, jsonOut)
+
+		if queueIfRequested(cmd, []string{issueID}, issueID) {
+			return
+		}
 
 		// Get auth header
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
 		}
 
 		// Create API client
 		client := api.NewClient(authHeader)
 
-		// Get comment body
-		body, _ := cmd.Flags().GetString("body")
-		imagePaths, _ := cmd.Flags().GetStringArray("image")
-
-		if body == "" && len(imagePaths) == 0 {
-			output.Error("Comment body or at least one image is required (--body or --image)", plaintext, jsonOut)
-			os.Exit(1)
-		}
-
-		// Upload images if provided
-		if len(imagePaths) > 0 {
-			if !jsonOut && !plaintext {
-				fmt.Printf("Uploading %d image(s)...\n", len(imagePaths))
-			}
-
-			for _, imagePath := range imagePaths {
-				assetURL, err := client.UploadFileToLinear(context.Background(), imagePath)
-				if err != nil {
-					output.Error(fmt.Sprintf("Failed to upload image %s: %v", imagePath, err), plaintext, jsonOut)
-					os.Exit(1)
-				}
-
-				// Inject image into body
-				altText := filepath.Base(imagePath)
-				body = files.InjectImageIntoMarkdown(body, assetURL, altText)
-
-				if !jsonOut && !plaintext {
-					fmt.Printf("  ✓ Uploaded: %s\n", filepath.Base(imagePath))
-				}
-			}
+		body, err := commentBodyFromFlags(context.Background(), cmd, client, "", plaintext, jsonOut)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
 		}
 
 		// Create comment
 		comment, err := client.CreateComment(context.Background(), issueID, body)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to create comment: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to create comment: %v", err), plaintext, jsonOut)
 		}
 
 		// Handle output
@@ -198,6 +203,223 @@ var commentCreateCmd = &cobra.Command{
 	},
 }
 
+var commentReplyCmd = &cobra.Command{
+	Use:   "reply COMMENT-ID",
+	Short: "Reply to a comment",
+	Long:  `Reply to an existing comment, creating a thread. Use 'linctl comment list ISSUE-ID' to find comment IDs.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		commentID := args[0]
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+		ctx := context.Background()
+
+		parent, err := client.GetComment(ctx, commentID)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get comment: %v", err), plaintext, jsonOut)
+		}
+		if parent.Issue == nil {
+			output.Error("Comment is not attached to an issue", plaintext, jsonOut)
+			exit(1)
+		}
+
+		// Linear threads are one level deep, so replies to a reply go to its parent
+		parentID := parent.ID
+		if parent.Parent != nil {
+			parentID = parent.Parent.ID
+		}
+
+		runCommentEditor(cmd, "", plaintext, jsonOut)
+
+		body, err := commentBodyFromFlags(ctx, cmd, client, "", plaintext, jsonOut)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		comment, err := client.CreateReply(ctx, parent.Issue.ID, parentID, body)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create reply: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(comment)
+		} else if plaintext {
+			fmt.Printf("Replied to comment %s on %s\n", parentID, parent.Issue.Identifier)
+			fmt.Printf("ID: %s\n", comment.ID)
+		} else {
+			fmt.Printf("%s Replied on %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(parent.Issue.Identifier))
+			fmt.Printf("\n%s\n", comment.Body)
+		}
+	},
+}
+
+var commentEditCmd = &cobra.Command{
+	Use:     "edit COMMENT-ID",
+	Aliases: []string{"update"},
+	Short:   "Edit a comment",
+	Long: `Replace the body of a comment. When only --image is given, the images are
+appended to the existing body.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		commentID := args[0]
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+		ctx := context.Background()
+
+		// Images without a new body are appended to the current body
+		existing := ""
+		body, _ := cmd.Flags().GetString("body")
+		bodyFile, _ := cmd.Flags().GetString("body-file")
+		if body == "" && bodyFile == "" {
+			current, err := client.GetComment(ctx, commentID)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to get comment: %v", err), plaintext, jsonOut)
+			}
+			existing = current.Body
+		}
+
+		runCommentEditor(cmd, existing, plaintext, jsonOut)
+
+		newBody, err := commentBodyFromFlags(ctx, cmd, client, existing, plaintext, jsonOut)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		comment, err := client.UpdateComment(ctx, commentID, newBody)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to update comment: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(comment)
+		} else if plaintext {
+			fmt.Printf("Updated comment %s\n", comment.ID)
+		} else {
+			fmt.Printf("%s Updated comment %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan).Sprint(comment.ID))
+			fmt.Printf("\n%s\n", comment.Body)
+		}
+	},
+}
+
+var commentDeleteCmd = &cobra.Command{
+	Use:     "delete COMMENT-ID...",
+	Aliases: []string{"rm"},
+	Short:   "Delete comments",
+	Long:    `Delete one or more comments by ID.`,
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		deleted := []string{}
+		var failures []string
+		for _, id := range args {
+			if err := client.DeleteComment(context.Background(), id); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", id, err))
+				continue
+			}
+			deleted = append(deleted, id)
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"deleted": deleted,
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			for _, id := range deleted {
+				if plaintext {
+					fmt.Printf("Deleted comment %s\n", id)
+				} else {
+					fmt.Printf("%s Deleted comment %s\n", color.New(color.FgGreen).Sprint("✓"), id)
+				}
+			}
+			for _, failure := range failures {
+				output.Error(fmt.Sprintf("Failed to delete comment %s", failure), plaintext, false)
+			}
+		}
+
+		exitOnFailures(len(deleted), len(failures))
+	},
+}
+
+// commentBodyFromFlags builds a comment body from --body or --body-file, then uploads
+// any --image files and appends them. existing is used when neither body flag is set.
+func commentBodyFromFlags(ctx context.Context, cmd *cobra.Command, client *api.Client, existing string, plaintext, jsonOut bool) (string, error) {
+	body, _ := cmd.Flags().GetString("body")
+	bodyFile, _ := cmd.Flags().GetString("body-file")
+	imagePaths, _ := cmd.Flags().GetStringArray("image")
+
+	if body != "" && bodyFile != "" {
+		return "", fmt.Errorf("--body and --body-file cannot be used together")
+	}
+	if bodyFile != "" {
+		content, err := readMarkdownInput(bodyFile)
+		if err != nil {
+			return "", err
+		}
+		body = strings.TrimRight(content, "\n")
+	}
+	if body == "" {
+		body = existing
+	}
+
+	if strings.TrimSpace(body) == "" && len(imagePaths) == 0 {
+		return "", fmt.Errorf("Comment body or at least one image is required (--body, --body-file, or --image)")
+	}
+
+	if len(imagePaths) > 0 {
+		if !jsonOut && !plaintext {
+			fmt.Printf("Uploading %d image(s)...\n", len(imagePaths))
+		}
+
+		for _, imagePath := range imagePaths {
+			assetURL, err := uploadFile(ctx, client, imagePath, files.UploadOptions{})
+			if err != nil {
+				return "", fmt.Errorf("Failed to upload image %s: %v", imagePath, err)
+			}
+
+			// Inject image into body
+			altText := filepath.Base(imagePath)
+			body = files.InjectImageIntoMarkdown(body, assetURL, altText)
+
+			if !jsonOut && !plaintext {
+				fmt.Printf("  ✓ Uploaded: %s\n", filepath.Base(imagePath))
+			}
+		}
+	}
+
+	return body, nil
+}
+
 // formatTimeAgo formats a time as a human-readable "time ago" string
 func formatTimeAgo(t time.Time) string {
 	duration := time.Since(t)
@@ -241,12 +463,18 @@ func init() {
 	rootCmd.AddCommand(commentCmd)
 	commentCmd.AddCommand(commentListCmd)
 	commentCmd.AddCommand(commentCreateCmd)
+	commentCmd.AddCommand(commentReplyCmd)
+	commentCmd.AddCommand(commentEditCmd)
+	commentCmd.AddCommand(commentDeleteCmd)
 
 	// List command flags
 	commentListCmd.Flags().IntP("limit", "l", 50, "Maximum number of comments to return")
 	commentListCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
 
-	// Create command flags
-	commentCreateCmd.Flags().StringP("body", "b", "", "Comment body")
-	commentCreateCmd.Flags().StringArrayP("image", "i", []string{}, "Path to image file(s) to upload and attach (can be used multiple times)")
+	// Create, reply, and edit command flags
+	for _, c := range []*cobra.Command{commentCreateCmd, commentReplyCmd, commentEditCmd} {
+		c.Flags().StringP("body", "b", "", "Comment body (markdown)")
+		c.Flags().StringP("body-file", "F", "", "Read the comment body from a markdown file ('-' for stdin)")
+		c.Flags().StringArrayP("image", "i", []string{}, "Path to image file(s) to upload and attach (can be used multiple times)")
+	}
 }
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var commentReactCmd = &cobra.Command{
+	Use:   "react COMMENT-ID EMOJI...",
+	Short: "React to a comment with emoji",
+	Long: `React to a comment with one or more emoji, given as shortcodes (:+1:, :tada:,
+:eyes:, or without the colons) or as the emoji themselves. --remove takes your
+reactions back. Use 'linctl comment list ISSUE-ID' to find comment IDs.
+
+Examples:
+  linctl comment react <comment-id> :+1:
+  linctl comment react <comment-id> tada rocket
+  linctl comment react <comment-id> :+1: --remove`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		remove, _ := cmd.Flags().GetBool("remove")
+
+		var emoji []string
+		for _, arg := range args[1:] {
+			name, err := utils.EmojiName(arg)
+			if err != nil {
+				output.Error(err.Error(), plaintext, jsonOut)
+				exit(1)
+			}
+			emoji = append(emoji, name)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		comment, err := client.GetComment(ctx, args[0])
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get comment: %v", err), plaintext, jsonOut)
+		}
+		viewer, err := auth.Viewer(ctx)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get current user: %v", err), plaintext, jsonOut)
+		}
+		// mine is the ID of each reaction of mine, by emoji
+		mine := make(map[string]string)
+		for _, reaction := range comment.Reactions {
+			if reaction.User != nil && reaction.User.ID == viewer.ID {
+				mine[reaction.Emoji] = reaction.ID
+			}
+		}
+
+		changed := []string{}
+		var failures []string
+		for _, name := range emoji {
+			id, reacted := mine[name]
+			if reacted != remove {
+				// Already as asked
+				continue
+			}
+			if remove {
+				err = client.DeleteReaction(ctx, id)
+				if err == nil {
+					kept := comment.Reactions[:0]
+					for _, reaction := range comment.Reactions {
+						if reaction.ID != id {
+							kept = append(kept, reaction)
+						}
+					}
+					comment.Reactions = kept
+					delete(mine, name)
+				}
+			} else {
+				var reaction *api.Reaction
+				reaction, err = client.CreateReaction(ctx, comment.ID, name)
+				if err == nil {
+					comment.Reactions = append(comment.Reactions, *reaction)
+					mine[name] = reaction.ID
+				}
+			}
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", utils.EmojiGlyph(name), err))
+				continue
+			}
+			changed = append(changed, name)
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"comment":   comment.ID,
+				"reactions": comment.Reactions,
+			}
+			if remove {
+				result["removed"] = changed
+			} else {
+				result["added"] = changed
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			glyphs := make([]string, len(changed))
+			for i, name := range changed {
+				glyphs[i] = utils.EmojiGlyph(name)
+			}
+			message := fmt.Sprintf("Reacted %s to comment %s", strings.Join(glyphs, " "), comment.ID)
+			if remove {
+				message = fmt.Sprintf("Removed %s from comment %s", strings.Join(glyphs, " "), comment.ID)
+			}
+			if len(changed) == 0 {
+				message = fmt.Sprintf("Nothing to change on comment %s", comment.ID)
+			}
+			summary := reactionSummary(comment.Reactions)
+			if plaintext {
+				fmt.Println(message)
+				if summary != "" {
+					fmt.Printf("Reactions: %s\n", summary)
+				}
+			} else {
+				fmt.Printf("%s %s\n", color.New(color.FgGreen).Sprint("✓"), message)
+				if summary != "" {
+					fmt.Printf("  %s\n", summary)
+				}
+			}
+			for _, failure := range failures {
+				output.Error(failure, plaintext, false)
+			}
+		}
+
+		exitOnFailures(len(changed), len(failures))
+	},
+}
+
+// reactionSummary counts reactions by emoji, in the order each emoji was
+// first used: "👍 3  🎉 1"
+func reactionSummary(reactions []api.Reaction) string {
+	var order []string
+	counts := make(map[string]int)
+	for _, reaction := range reactions {
+		if counts[reaction.Emoji] == 0 {
+			order = append(order, reaction.Emoji)
+		}
+		counts[reaction.Emoji]++
+	}
+	parts := make([]string, len(order))
+	for i, name := range order {
+		parts[i] = fmt.Sprintf("%s %d", utils.EmojiGlyph(name), counts[name])
+	}
+	return strings.Join(parts, "  ")
+}
+
+func init() {
+	commentCmd.AddCommand(commentReactCmd)
+
+	commentReactCmd.Flags().Bool("remove", false, "Take your reactions back instead")
+}
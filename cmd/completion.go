@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	// completionCacheTTL is how long completion values are reused before they
+	// are fetched again
+	completionCacheTTL = 10 * time.Minute
+	// completionTimeout bounds the API call behind a single <TAB>
+	completionTimeout = 5 * time.Second
+	// completionIssueLimit is how many recently updated issues of a team are
+	// offered when the local cache has not been synced
+	completionIssueLimit = 100
+)
+
+// completionStateTypes complete --state when no team is known; matchWorkflowState
+// accepts them for any team
+var completionStateTypes = []string{"triage", "backlog", "unstarted", "started", "completed", "canceled"}
+
+// completionFlags maps flag names to the values they complete. issueOnly flags
+// are completed only under 'issue', where they name Linear states and labels.
+var completionFlags = []struct {
+	names     []string
+	issueOnly bool
+	complete  func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+}{
+	{names: []string{"team"}, complete: completeTeams},
+	{names: []string{"assignee", "creator", "lead", "user", "members"}, complete: completeUsers},
+	{names: []string{"state"}, issueOnly: true, complete: completeStates},
+	{names: []string{"label", "labels"}, issueOnly: true, complete: completeLabels},
+	{names: []string{"parent-issue", "parent", "duplicate-of", "blocks", "related-to"}, issueOnly: true, complete: completeIssueIDs},
+}
+
+// registerCompletions adds dynamic completion to every command: Linear teams,
+// users, states, and labels for their flags, and issue identifiers for
+// commands that take ISSUE-ID arguments
+func registerCompletions(cmd *cobra.Command) {
+	underIssue := false
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == issueCmd {
+			underIssue = true
+		}
+	}
+
+	for _, entry := range completionFlags {
+		if entry.issueOnly && !underIssue {
+			continue
+		}
+		for _, name := range entry.names {
+			if cmd.Flags().Lookup(name) == nil {
+				continue
+			}
+			if _, exists := cmd.GetFlagCompletionFunc(name); exists {
+				continue
+			}
+			_ = cmd.RegisterFlagCompletionFunc(name, entry.complete)
+		}
+	}
+
+	if cmd.ValidArgsFunction == nil && len(cmd.ValidArgs) == 0 && takesIssueArgs(cmd) {
+		cmd.ValidArgsFunction = completeIssueIDs
+	}
+
+	for _, child := range cmd.Commands() {
+		registerCompletions(child)
+	}
+}
+
+// takesIssueArgs reports whether a command's first argument is an issue, going
+// by its usage line (e.g. "update [issue-id]" or "move ISSUE-ID...")
+func takesIssueArgs(cmd *cobra.Command) bool {
+	fields := strings.Fields(cmd.Use)
+	if len(fields) < 2 {
+		return false
+	}
+	arg := strings.ToUpper(strings.Trim(fields[1], "[]<>."))
+	return arg == "ISSUE-ID" || arg == "ISSUE" || strings.HasPrefix(arg, "ISSUE|")
+}
+
+// completeTeams completes team keys, after the last comma for lists of teams
+func completeTeams(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	values := completionValues("teams", func(ctx context.Context, client *api.Client) ([]string, error) {
+		teams, err := client.GetTeams(ctx, 250, "", "")
+		if err != nil {
+			return nil, err
+		}
+		var values []string
+		for _, team := range teams.Nodes {
+			values = append(values, team.Key+"\t"+team.Name)
+		}
+		return values, nil
+	})
+	values = append(values, teamShortcut+"\tThe default team", anyShortcut+"\tEvery team")
+	return filterCompletions(values, toComplete, true), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeUsers completes emails, with names as descriptions
+func completeUsers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	values := completionValues("users", func(ctx context.Context, client *api.Client) ([]string, error) {
+		users, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize}, func(ctx context.Context, first int, after string) ([]api.User, api.PageInfo, error) {
+			page, err := client.GetUsers(ctx, first, after, "")
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		var values []string
+		for _, user := range users {
+			if user.Active {
+				values = append(values, user.Email+"\t"+user.Name)
+			}
+		}
+		return values, nil
+	})
+	values = append(values, meShortcut+"\tYou", noneShortcut+"\tNobody")
+	return filterCompletions(values, toComplete, true), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeStates completes the state names of the team given by --team, the
+// issue argument, or default-team, and the state types every team accepts
+func completeStates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	teamKey := completionTeam(cmd, args)
+	var values []string
+	if teamKey != "" {
+		values = completionValues("states:"+teamKey, func(ctx context.Context, client *api.Client) ([]string, error) {
+			states, err := client.GetTeamStates(ctx, teamKey)
+			if err != nil {
+				return nil, err
+			}
+			sort.SliceStable(states, func(i, j int) bool { return states[i].Position < states[j].Position })
+			var values []string
+			for _, state := range states {
+				values = append(values, state.Name+"\t"+state.Type)
+			}
+			return values, nil
+		})
+	}
+	for _, stateType := range completionStateTypes {
+		values = append(values, stateType+"\tAny "+stateType+" state")
+	}
+	return filterCompletions(values, toComplete, false), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLabels completes label names, after the last comma for lists of labels
+func completeLabels(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	values := completionValues("labels", func(ctx context.Context, client *api.Client) ([]string, error) {
+		labels, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize}, client.GetLabelsPage)
+		if err != nil {
+			return nil, err
+		}
+		seen := map[string]bool{}
+		var values []string
+		for _, label := range labels {
+			if seen[strings.ToLower(label.Name)] {
+				continue
+			}
+			seen[strings.ToLower(label.Name)] = true
+			scope := "Workspace"
+			if label.Team != nil {
+				scope = label.Team.Key
+			}
+			values = append(values, label.Name+"\t"+scope)
+		}
+		return values, nil
+	})
+	return filterCompletions(values, toComplete, true), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeIssueIDs completes issue identifiers. Without a team prefix it
+// offers team keys ("ENG-"); with one it offers that team's issues, from the
+// local cache when it has been synced and from recently updated issues otherwise.
+func completeIssueIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix, current := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, current = toComplete[:i+1], toComplete[i+1:]
+	}
+
+	teamKey := teamKeyFromIdentifier(current)
+	if teamKey == "" && strings.HasSuffix(current, "-") {
+		teamKey = strings.ToUpper(strings.TrimSuffix(current, "-"))
+	}
+	if teamKey == "" {
+		teams, _ := completeTeams(cmd, args, current)
+		var values []string
+		for _, team := range teams {
+			key, name, _ := strings.Cut(team, "\t")
+			if strings.HasPrefix(key, "@") {
+				continue
+			}
+			values = append(values, prefix+key+"-\t"+name)
+		}
+		return values, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+
+	var values []string
+	if store, err := loadCache(); err == nil {
+		issues, _ := store.Issues()
+		store.Close()
+		sort.Slice(issues, func(i, j int) bool { return issues[i].UpdatedAt.After(issues[j].UpdatedAt) })
+		for _, issue := range issues {
+			if issue.Team != nil && strings.EqualFold(issue.Team.Key, teamKey) {
+				values = append(values, issue.Identifier+"\t"+issue.Title)
+			}
+		}
+	}
+	if len(values) == 0 {
+		values = completionValues("issues:"+teamKey, func(ctx context.Context, client *api.Client) ([]string, error) {
+			filter := map[string]interface{}{
+				"team": map[string]interface{}{"key": map[string]interface{}{"eq": teamKey}},
+			}
+			issues, err := client.GetIssues(ctx, filter, completionIssueLimit, "", "updatedAt")
+			if err != nil {
+				return nil, err
+			}
+			var values []string
+			for _, issue := range issues.Nodes {
+				values = append(values, issue.Identifier+"\t"+issue.Title)
+			}
+			return values, nil
+		})
+	}
+
+	// Leave out issues already named earlier on the command line
+	var completions []string
+	for _, value := range filterCompletions(values, current, false) {
+		id, _, _ := strings.Cut(value, "\t")
+		if !containsString(args, id) && !strings.Contains(","+strings.ToUpper(prefix), ","+strings.ToUpper(id)+",") {
+			completions = append(completions, prefix+value)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionTeam is the team a completion applies to: --team, the team of an
+// issue argument, or default-team
+func completionTeam(cmd *cobra.Command, args []string) string {
+	if flag := cmd.Flags().Lookup("team"); flag != nil && flag.Value.String() != "" {
+		if key, err := resolveTeamKey(strings.Split(flag.Value.String(), ",")[0]); err == nil && key != "" {
+			return key
+		}
+	}
+	for _, arg := range args {
+		if key := teamKeyFromIdentifier(arg); key != "" {
+			return key
+		}
+	}
+	if key, err := resolveTeamKey(teamShortcut); err == nil {
+		return key
+	}
+	return ""
+}
+
+// filterCompletions keeps the values starting with toComplete, ignoring case.
+// With lists, only the text after the last comma is completed and the rest is
+// kept as a prefix, so "--labels bug,fr<TAB>" completes to "bug,frontend".
+func filterCompletions(values []string, toComplete string, lists bool) []string {
+	prefix, current := "", toComplete
+	if lists {
+		if i := strings.LastIndex(toComplete, ","); i >= 0 {
+			prefix, current = toComplete[:i+1], toComplete[i+1:]
+		}
+	}
+	current = strings.ToLower(current)
+
+	var matches []string
+	for _, value := range values {
+		if strings.HasPrefix(strings.ToLower(value), current) {
+			matches = append(matches, prefix+value)
+		}
+	}
+	return matches
+}
+
+// completionCacheEntry is one list of completion values
+type completionCacheEntry struct {
+	Values    []string  `json:"values"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// completionCachePath is ~/.linctl/completion/PROFILE.json
+func completionCachePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl", "completion", auth.Profile()+".json"), nil
+}
+
+// completionValues returns the values cached under key, fetching them when
+// they are missing or older than completionCacheTTL. Completion must never
+// fail loudly, so errors fall back to stale values or none at all.
+func completionValues(key string, fetch func(ctx context.Context, client *api.Client) ([]string, error)) []string {
+	path, err := completionCachePath()
+	if err != nil {
+		return nil
+	}
+	entries := map[string]completionCacheEntry{}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &entries)
+	}
+
+	cached, ok := entries[key]
+	if ok && time.Since(cached.FetchedAt) < completionCacheTTL {
+		return cached.Values
+	}
+
+	authHeader, err := auth.GetAuthHeader()
+	if err != nil {
+		return cached.Values
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	values, err := fetch(ctx, api.NewClient(authHeader))
+	if err != nil {
+		return cached.Values
+	}
+
+	entries[key] = completionCacheEntry{Values: values, FetchedAt: time.Now()}
+	if data, err := json.Marshal(entries); err == nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err == nil {
+			_ = os.WriteFile(path, data, 0600)
+		}
+	}
+	return values
+}
+
+// completionRefreshCmd forgets cached completion values
+var completionRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Forget cached completion values so the next <TAB> fetches them",
+	Long: fmt.Sprintf(`Completion of teams, users, states, labels, and issue identifiers queries
+Linear and caches the results for %s. Run this after adding a team, label, or
+user to see it straight away.`, completionCacheTTL),
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		path, err := completionCachePath()
+		if err == nil {
+			err = os.Remove(path)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			exitWithError(err, fmt.Sprintf("Failed to clear completion cache: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"cleared": true, "path": path})
+			return
+		}
+		output.Success("Completion cache cleared", plaintext, jsonOut)
+	},
+}
+
+// setupCompletion adds the 'completion' command and registers dynamic
+// completion on every command. It runs once all commands have been added.
+func setupCompletion() {
+	rootCmd.InitDefaultCompletionCmd()
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "completion" {
+			cmd.AddCommand(completionRefreshCmd)
+		}
+	}
+	registerCompletions(rootCmd)
+}
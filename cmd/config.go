@@ -0,0 +1,629 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configSetting is a setting of the config file
+type configSetting struct {
+	Key string `json:"key"`
+	// Type is string, bool, int, duration, list, or map
+	Type        string `json:"type"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description"`
+	// Values lists what the setting accepts, when only some values are valid
+	Values []string `json:"values,omitempty"`
+	// Local settings may also be set in a repository's .linctl.yml
+	Local bool `json:"local"`
+}
+
+// configSettings are the settings of the config file. Those that are also
+// global flags take their type, default, and description from the flag.
+var configSettings = []configSetting{
+	{Key: "output", Type: "string", Default: "table", Values: []string{"table", "plaintext", "json", "jsonl"}, Local: true,
+		Description: "Default output format; --plaintext, --json, and --jsonl override it"},
+	{Key: "default-team", Type: "string", Local: true,
+		Description: "Team used when a command's --team flag is not given"},
+	{Key: "default-project", Type: "string", Local: true,
+		Description: "Project that issues created in the default team are added to when --project is not given"},
+	{Key: "profile", Local: true},
+	{Key: "credential-store", Type: "string", Default: "auto", Values: []string{"auto", "keychain", "file", "plaintext"},
+		Description: "Where credentials are kept: OS keychain, else encrypted file (auto), keychain, file, or plaintext; without $LINCTL_CREDENTIAL_PASSPHRASE the file's key sits beside it in ~/.linctl-key and protects nothing"},
+	{Key: "editor", Type: "string",
+		Description: "Editor for issues, comments, and config files, after $VISUAL and $EDITOR (default vi)"},
+	{Key: "pager", Type: "string",
+		Description: "Pager for lists, views, and reports (default $PAGER, else less; \"cat\" for none)"},
+	{Key: "no-pager"},
+	{Key: "color", Values: []string{"auto", "always", "never"}},
+	{Key: "theme", Type: "string", Default: output.DefaultTheme, Values: output.ThemeNames(),
+		Description: "Color theme"},
+	{Key: "colors", Type: "map",
+		Description: "Theme colors by role, e.g. colors.state.started: yellow bold"},
+	{Key: "no-truncate"},
+	{Key: "max-width"},
+	{Key: "no-progress"},
+	{Key: "verbose"},
+	{Key: "max-retries"},
+	{Key: "retry-wait"},
+	{Key: "no-cache"},
+	{Key: "cache-ttl"},
+	{Key: "connect-timeout"},
+	{Key: "timeout"},
+	{Key: "proxy"},
+	{Key: "ca-bundle"},
+	{Key: "no-http2"},
+	{Key: "no-keepalive"},
+	{Key: "force-upload"},
+	{Key: "optimize-images"},
+	{Key: "image-max-dimension"},
+	{Key: "image-quality"},
+	{Key: "image-size-threshold"},
+	{Key: "template-dir", Type: "string", Default: "~/.linctl-templates",
+		Description: "Directory of local issue templates"},
+	{Key: "views", Type: "map", Local: true,
+		Description: "Saved issue filters, managed with 'linctl view'"},
+	{Key: "aliases", Type: "map",
+		Description: "Command shortcuts, managed with 'linctl alias'"},
+	{Key: "daemon.interval", Type: "duration", Default: defaultDaemonInterval.String(),
+		Description: "How often 'linctl daemon' syncs and checks for notifications"},
+	{Key: "daemon.notify", Type: "list", Default: strings.Join(defaultDaemonEvents, ","), Values: daemonEventTypes,
+		Description: "Events 'linctl daemon' sends desktop notifications for"},
+	{Key: "slack.webhooks", Type: "map",
+		Description: "Named Slack incoming webhooks for --notify-slack, e.g. slack.webhooks.oncall: https://hooks.slack.com/..."},
+	{Key: "profiles", Type: "map",
+		Description: "Named profiles whose settings override the others: profiles.NAME.SETTING"},
+	{Key: "oauth.client_id", Type: "string",
+		Description: "OAuth application used by 'linctl auth login --oauth'"},
+	{Key: "oauth.client_secret", Type: "string",
+		Description: "Secret of the OAuth application (optional, PKCE is used)"},
+}
+
+// configSettingKeys lists the settings for help text
+func configSettingKeys() []string {
+	keys := make([]string, len(configSettings))
+	for i, setting := range configSettings {
+		keys[i] = setting.Key
+	}
+	return keys
+}
+
+// configSchema returns the settings with those of global flags filled in
+func configSchema() []configSetting {
+	settings := make([]configSetting, len(configSettings))
+	for i, setting := range configSettings {
+		if flag := rootCmd.PersistentFlags().Lookup(setting.Key); flag != nil {
+			setting = flagSetting(setting, flag)
+		}
+		settings[i] = setting
+	}
+	return settings
+}
+
+// flagSetting fills in a setting from the global flag of the same name
+func flagSetting(setting configSetting, flag *pflag.Flag) configSetting {
+	if setting.Type == "" {
+		switch flag.Value.Type() {
+		case "count", "int64":
+			setting.Type = "int"
+		case "stringSlice":
+			setting.Type = "list"
+		default:
+			setting.Type = flag.Value.Type()
+		}
+	}
+	if setting.Default == "" && flag.DefValue != "[]" {
+		setting.Default = flag.DefValue
+	}
+	if setting.Description == "" {
+		setting.Description = flag.Usage
+	}
+	return setting
+}
+
+// lookupConfigSetting finds the setting a key names: a setting, an entry of
+// a map setting (views.NAME), or a profile's setting (profiles.NAME.KEY)
+func lookupConfigSetting(key string) (configSetting, error) {
+	key = strings.ToLower(key)
+	if rest, ok := strings.CutPrefix(key, "profiles."); ok {
+		if _, inner, ok := strings.Cut(rest, "."); ok && !strings.HasPrefix(inner, "profiles") {
+			return lookupConfigSetting(inner)
+		}
+		return configSetting{}, fmt.Errorf("give a profile's setting as profiles.NAME.SETTING")
+	}
+	for _, setting := range configSchema() {
+		if key == setting.Key || setting.Type == "map" && strings.HasPrefix(key, setting.Key+".") {
+			return setting, nil
+		}
+	}
+	return configSetting{}, fmt.Errorf("unknown setting '%s' (see 'linctl config list')", key)
+}
+
+// validate checks that value suits the setting; key is the full key set
+func (s configSetting) validate(key, value string) error {
+	if len(s.Values) > 0 {
+		// Each entry of a list is one of the values
+		entries := []string{value}
+		if s.Type == "list" {
+			entries = strings.Split(value, ",")
+		}
+		for _, entry := range entries {
+			if !containsString(s.Values, strings.TrimSpace(entry)) {
+				return fmt.Errorf("invalid %s '%s' (use %s)", key, value, strings.Join(s.Values, ", "))
+			}
+		}
+		return nil
+	}
+
+	var err error
+	switch s.Type {
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "int":
+		_, err = strconv.Atoi(value)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	case "map":
+		if key == s.Key {
+			return fmt.Errorf("%s is a map; set its entries, e.g. %s.NAME", key, key)
+		}
+		name := strings.TrimPrefix(key, s.Key+".")
+		switch s.Key {
+		case "views":
+			_, err = parseViewFilter(value)
+		case "aliases":
+			err = validateAlias(name, value)
+		case "slack.webhooks":
+			err = validateSlackWebhook(value)
+		}
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s '%s' (expected a %s)", key, value, s.Type)
+	}
+	return nil
+}
+
+// yamlTag is how values of the setting are written to the config file
+func (s configSetting) yamlTag() string {
+	switch s.Type {
+	case "bool":
+		return "!!bool"
+	case "int":
+		return "!!int"
+	}
+	return "!!str"
+}
+
+// configEnvName is the environment variable that overrides a setting
+func configEnvName(key string) string {
+	return "LINCTL_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(key))
+}
+
+var (
+	// projectConfigPath is the repository config file in use, and
+	// projectSettings the settings linctl took from it
+	projectConfigPath string
+	projectSettings   map[string]interface{}
+)
+
+// loadProjectConfig reads the repository config file nearest the working
+// directory, keeping the settings a repository may set and warning about
+// the others
+func loadProjectConfig() (map[string]interface{}, error) {
+	path := findProjectConfig()
+	if path == "" {
+		return nil, nil
+	}
+	doc, err := loadConfigDocument(path)
+	if err != nil {
+		return nil, err
+	}
+	all := map[string]interface{}{}
+	if err := doc.Content[0].Decode(&all); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	settings := map[string]interface{}{}
+	for key, value := range all {
+		setting, err := lookupConfigSetting(key)
+		if err != nil || !setting.Local {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring '%s' in %s; a repository may only set %s\n",
+				key, path, strings.Join(localConfigKeys(), ", "))
+			continue
+		}
+		settings[key] = value
+	}
+	projectConfigPath = path
+	projectSettings = settings
+	return settings, nil
+}
+
+// localConfigKeys lists the settings a repository's config file may set
+func localConfigKeys() []string {
+	var keys []string
+	for _, setting := range configSettings {
+		if setting.Local {
+			keys = append(keys, setting.Key)
+		}
+	}
+	return keys
+}
+
+// nestedValue looks up keys[0].keys[1]... in a decoded config map
+func nestedValue(settings map[string]interface{}, keys []string) (interface{}, bool) {
+	var value interface{} = settings
+	for _, key := range keys {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// userConfigSettings decodes the user's config file, ignoring errors, which
+// were reported when linctl started
+func userConfigSettings() (string, map[string]interface{}) {
+	path, err := configFilePath()
+	if err != nil {
+		return "", nil
+	}
+	doc, err := loadConfigDocument(path)
+	if err != nil {
+		return path, nil
+	}
+	settings := map[string]interface{}{}
+	_ = doc.Content[0].Decode(&settings)
+	return path, settings
+}
+
+// configSource says where the value of a setting comes from: a flag, the
+// environment, the repository, the profile, the config file, or the default
+func configSource(key string, user map[string]interface{}) string {
+	if flag := rootCmd.PersistentFlags().Lookup(key); flag != nil && flag.Changed {
+		return "flag"
+	}
+	if _, ok := os.LookupEnv(configEnvName(key)); ok {
+		return "env " + configEnvName(key)
+	}
+	keys := strings.Split(key, ".")
+	if _, ok := nestedValue(projectSettings, keys); ok {
+		return "repository"
+	}
+	if profile := auth.Profile(); profile != auth.DefaultProfile {
+		if _, ok := nestedValue(user, append([]string{"profiles", profile}, keys...)); ok {
+			return "profile " + profile
+		}
+	}
+	if _, ok := nestedValue(user, keys); ok {
+		return "config"
+	}
+	return "default"
+}
+
+// configValue is the value of a setting linctl uses
+func configValue(setting configSetting, key string) interface{} {
+	value := viper.Get(key)
+	if value == nil && key == setting.Key && setting.Default != "" {
+		return setting.Default
+	}
+	return value
+}
+
+// formatConfigValue prints a value on one line: lists and maps are joined
+// with commas
+func formatConfigValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ",")
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			items[i] = key + "=" + formatConfigValue(v[key])
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show and change settings",
+	Long: `Show and change the settings of the config file (~/.linctl.yaml or --config).
+
+Settings: ` + strings.Join(configSettingKeys(), ", ") + `.
+'linctl config list' describes each one with its value and where it comes from.
+
+Each setting is taken from the first of:
+  1. its global flag (e.g. --timeout), for settings that have one
+  2. the environment: LINCTL_ and the setting in capitals with _ for - and .,
+     e.g. LINCTL_DEFAULT_TEAM=ENG or LINCTL_OUTPUT=json
+  3. the repository's .linctl.yml, the nearest one in the working directory
+     or a parent; it may set ` + strings.Join(localConfigKeys(), ", ") + `
+  4. the profile's section of the config file (profiles.NAME.SETTING)
+  5. the config file
+  6. the default
+
+Examples:
+  linctl config list
+  linctl config get default-team
+  linctl config set default-team ENG
+  linctl config set output json
+  linctl config set profiles.work.default-team WRK
+  linctl config set --local default-team ENG
+  linctl config unset pager
+  linctl config edit`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get KEY",
+	Short: "Print the value of a setting",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		key := strings.ToLower(args[0])
+		setting, err := lookupConfigSetting(key)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		_, user := userConfigSettings()
+		value := configValue(setting, key)
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{
+				"key":    key,
+				"value":  value,
+				"source": configSource(key, user),
+			})
+			return
+		}
+		if m, ok := value.(map[string]interface{}); ok {
+			data, _ := yaml.Marshal(m)
+			fmt.Print(string(data))
+			return
+		}
+		fmt.Println(formatConfigValue(value))
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set KEY VALUE",
+	Short: "Change a setting in the config file",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		key, value := strings.ToLower(args[0]), args[1]
+		setting, err := lookupConfigSetting(key)
+		if err == nil {
+			err = setting.validate(key, value)
+		}
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		path := configTargetPath(cmd, setting, key, plaintext, jsonOut)
+		doc, err := loadConfigDocument(path)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		setConfigScalar(doc, strings.Split(key, "."), value, setting.yamlTag())
+		if err := saveConfigDocument(path, doc); err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"key": key, "value": value, "file": path})
+		} else {
+			output.Success(fmt.Sprintf("Set %s to %s in %s", key, value, path), plaintext, jsonOut)
+		}
+		if env := configEnvName(key); os.Getenv(env) != "" && !jsonOut {
+			fmt.Fprintf(os.Stderr, "Warning: %s is set and overrides this setting\n", env)
+		}
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:     "unset KEY",
+	Aliases: []string{"delete", "rm"},
+	Short:   "Remove a setting from the config file",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		key := strings.ToLower(args[0])
+		setting, err := lookupConfigSetting(key)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		path := configTargetPath(cmd, setting, key, plaintext, jsonOut)
+		doc, err := loadConfigDocument(path)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if !deleteConfigValue(doc, strings.Split(key, ".")) {
+			output.Error(fmt.Sprintf("%s is not set in %s", key, path), plaintext, jsonOut)
+			exit(1)
+		}
+		if err := saveConfigDocument(path, doc); err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"unset": key, "file": path})
+		} else {
+			output.Success(fmt.Sprintf("Removed %s from %s", key, path), plaintext, jsonOut)
+		}
+	},
+}
+
+// configTargetPath is the file config set and unset change: the repository's
+// .linctl.yml with --local, else the config file
+func configTargetPath(cmd *cobra.Command, setting configSetting, key string, plaintext, jsonOut bool) string {
+	if local, _ := cmd.Flags().GetBool("local"); local {
+		if !setting.Local || strings.HasPrefix(key, "profiles.") {
+			output.Error(fmt.Sprintf("%s can't be set per repository; a repository may only set %s",
+				key, strings.Join(localConfigKeys(), ", ")), plaintext, jsonOut)
+			exit(1)
+		}
+		path, err := projectConfigTarget()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to locate repository config file: %v", err), plaintext, jsonOut)
+		}
+		return path
+	}
+	path, err := configFilePath()
+	if err != nil {
+		exitWithError(err, fmt.Sprintf("Failed to locate config file: %v", err), plaintext, jsonOut)
+	}
+	return path
+}
+
+var configListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List every setting with its value and where it comes from",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		path, user := userConfigSettings()
+		schema := configSchema()
+
+		if jsonOut {
+			type listedSetting struct {
+				configSetting
+				Value  interface{} `json:"value"`
+				Source string      `json:"source"`
+			}
+			settings := make([]listedSetting, len(schema))
+			for i, setting := range schema {
+				settings[i] = listedSetting{setting, configValue(setting, setting.Key), configSource(setting.Key, user)}
+			}
+			output.JSON(settings)
+			return
+		}
+
+		rows := make([][]string, len(schema))
+		for i, setting := range schema {
+			source := configSource(setting.Key, user)
+			value := formatConfigValue(configValue(setting, setting.Key))
+			if !plaintext && source == "default" {
+				value = color.New(color.FgWhite, color.Faint).Sprint(value)
+			}
+			rows[i] = []string{setting.Key, value, source, setting.Description}
+		}
+
+		if !plaintext {
+			faint := color.New(color.FgWhite, color.Faint)
+			fmt.Printf("%s %s\n", faint.Sprint("Config file:"), path)
+			if projectConfigPath != "" {
+				fmt.Printf("%s %s\n", faint.Sprint("Repository config:"), projectConfigPath)
+			}
+			fmt.Println()
+		}
+		output.Table(output.TableData{
+			Headers: []string{"Key", "Value", "Source", "Description"},
+			Rows:    rows,
+			Wrap:    []string{"description"},
+		}, plaintext, jsonOut)
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in your editor",
+	Long: `Open the config file, or with --local the repository's .linctl.yml, in your
+editor ($VISUAL, $EDITOR, the editor setting, then vi). The file is checked
+when the editor closes.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		var path string
+		var err error
+		if local, _ := cmd.Flags().GetBool("local"); local {
+			path, err = projectConfigTarget()
+		} else {
+			path, err = configFilePath()
+		}
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to locate config file: %v", err), plaintext, jsonOut)
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			if err := os.WriteFile(path, []byte("# linctl settings; see 'linctl config list'\n"), 0600); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to create %s: %v", path, err), plaintext, jsonOut)
+			}
+		}
+
+		if err := runEditor(path); err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		doc, err := loadConfigDocument(path)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		settings := map[string]interface{}{}
+		_ = doc.Content[0].Decode(&settings)
+		for key := range settings {
+			if _, err := lookupConfigSetting(key); err != nil && key != "oauth" {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"file": path})
+		} else {
+			output.Success(fmt.Sprintf("Saved %s", path), plaintext, jsonOut)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configEditCmd)
+
+	configSetCmd.Flags().Bool("local", false, "Set it in the repository's .linctl.yml instead")
+	configUnsetCmd.Flags().Bool("local", false, "Remove it from the repository's .linctl.yml instead")
+	configEditCmd.Flags().Bool("local", false, "Edit the repository's .linctl.yml instead")
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configFilePath returns the config file linctl reads: --config, the file that
+// was found on startup, or ~/.linctl.yaml
+func configFilePath() (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl.yaml"), nil
+}
+
+// projectConfigNames are the names of a repository's config file
+var projectConfigNames = []string{".linctl.yml", ".linctl.yaml"}
+
+// findProjectConfig returns the repository config file nearest the working
+// directory, looking in it and each parent, or "" when there is none. The
+// user's own config file is never one, even in their home directory.
+func findProjectConfig() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	var userConfig os.FileInfo
+	if path, err := configFilePath(); err == nil {
+		userConfig, _ = os.Stat(path)
+	}
+	for {
+		for _, name := range projectConfigNames {
+			path := filepath.Join(dir, name)
+			info, err := os.Stat(path)
+			if err == nil && !info.IsDir() && (userConfig == nil || !os.SameFile(info, userConfig)) {
+				return path
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// projectConfigTarget is the repository config file to write: the one in
+// use, else a new .linctl.yml at the root of the git repository, else in the
+// working directory
+func projectConfigTarget() (string, error) {
+	if path := findProjectConfig(); path != "" {
+		return path, nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for root := dir; ; {
+		if _, err := os.Stat(filepath.Join(root, ".git")); err == nil {
+			dir = root
+			break
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			break
+		}
+		root = parent
+	}
+	return filepath.Join(dir, projectConfigNames[0]), nil
+}
+
+// loadConfigDocument parses the config file into a YAML node tree, which keeps
+// comments and key order intact when the file is written back. A missing file
+// yields an empty document.
+func loadConfigDocument(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	doc := &yaml.Node{Kind: yaml.DocumentNode}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s must contain a YAML mapping", path)
+	}
+	return doc, nil
+}
+
+// saveConfigDocument writes a document loaded with loadConfigDocument
+func saveConfigDocument(path string, doc *yaml.Node) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// setConfigValue sets keys[0].keys[1]... to value, creating mappings as needed
+func setConfigValue(doc *yaml.Node, keys []string, value string) {
+	setConfigScalar(doc, keys, value, "!!str")
+}
+
+// setConfigScalar sets keys[0].keys[1]... to value written as a YAML tag
+// (!!str, !!bool, !!int)
+func setConfigScalar(doc *yaml.Node, keys []string, value, tag string) {
+	node := doc.Content[0]
+	for i, key := range keys {
+		child := mappingValue(node, key)
+		last := i == len(keys)-1
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if last {
+				child = &yaml.Node{}
+			}
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+		} else if !last && child.Kind != yaml.MappingNode {
+			*child = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		if last {
+			*child = yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value, LineComment: child.LineComment}
+		}
+		node = child
+	}
+}
+
+// deleteConfigValue removes keys[0].keys[1]... and reports whether it existed
+func deleteConfigValue(doc *yaml.Node, keys []string) bool {
+	node := doc.Content[0]
+	for _, key := range keys[:len(keys)-1] {
+		if node = mappingValue(node, key); node == nil {
+			return false
+		}
+	}
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+	last := keys[len(keys)-1]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == last {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// mappingValue returns the value node stored under key in a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,362 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var customerCmd = &cobra.Command{
+	Use:     "customer",
+	Aliases: []string{"customers"},
+	Short:   "Work with customers and customer requests",
+	Long: `List customers and the requests they made, and attach requests to issues,
+for workspaces using Linear's customer requests.
+
+Customers are given by name, domain (acme.com), or ID.
+
+Examples:
+  linctl customer list
+  linctl customer requests Acme
+  linctl customer attach Acme ENG-123 --body "Blocking their rollout" --important
+  linctl issue customers ENG-123`,
+}
+
+var customerListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List customers",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		customers, err := loadCustomers(context.Background(), client)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list customers: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(customers)
+			return
+		}
+		if len(customers) == 0 {
+			output.Info("No customers found", plaintext, jsonOut)
+			return
+		}
+
+		headers := []string{"Name", "Domains", "Tier", "Status", "Requests", "Revenue", "ID"}
+		rows := make([][]string, len(customers))
+		for i, customer := range customers {
+			tier, status := "", ""
+			if customer.Tier != nil {
+				tier = customer.Tier.Name
+			}
+			if customer.Status != nil {
+				status = customer.Status.Name
+			}
+			revenue := ""
+			if customer.Revenue != nil {
+				revenue = fmt.Sprintf("%.0f", *customer.Revenue)
+			}
+			rows[i] = []string{
+				customer.Name,
+				strings.Join(customer.Domains, ", "),
+				tier,
+				status,
+				fmt.Sprintf("%.0f", customer.ApproximateNeedCount),
+				revenue,
+				customer.ID,
+			}
+		}
+
+		output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, jsonOut)
+		if !plaintext {
+			fmt.Printf("\n%s %d customers\n", color.New(color.FgGreen).Sprint("✓"), len(customers))
+		}
+	},
+}
+
+var customerRequestsCmd = &cobra.Command{
+	Use:     "requests [CUSTOMER]",
+	Aliases: []string{"needs"},
+	Short:   "List customer requests",
+	Long:    `List customer requests: every one, or those of a customer.`,
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		filter := map[string]interface{}{}
+		if len(args) == 1 {
+			customer, err := resolveCustomer(ctx, client, args[0])
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			filter["customer"] = map[string]interface{}{"id": map[string]interface{}{"eq": customer.ID}}
+		}
+		if important, _ := cmd.Flags().GetBool("important"); important {
+			filter["priority"] = map[string]interface{}{"eq": 1}
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		needs, err := loadCustomerNeeds(ctx, client, filter, limit)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list customer requests: %v", err), plaintext, jsonOut)
+		}
+		printCustomerNeeds(needs, true, plaintext, jsonOut)
+	},
+}
+
+var customerAttachCmd = &cobra.Command{
+	Use:     "attach CUSTOMER ISSUE-ID",
+	Aliases: []string{"add"},
+	Short:   "Record that a customer asked for an issue",
+	Long: `Attach a customer request to an issue.
+
+Examples:
+  linctl customer attach Acme ENG-123
+  linctl customer attach acme.com ENG-123 --body "Needed for SSO rollout" --important
+  linctl customer attach Acme ENG-123 --url https://support.example.com/tickets/42`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		customer, err := resolveCustomer(ctx, client, args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		issue, err := client.GetIssue(ctx, args[1])
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
+		}
+
+		input := map[string]interface{}{
+			"customerId": customer.ID,
+			"issueId":    issue.ID,
+		}
+		if body, _ := cmd.Flags().GetString("body"); body != "" {
+			input["body"] = body
+		}
+		if important, _ := cmd.Flags().GetBool("important"); important {
+			input["priority"] = 1
+		}
+		if url, _ := cmd.Flags().GetString("url"); url != "" {
+			input["attachmentUrl"] = url
+		}
+
+		need, err := client.CreateCustomerNeed(ctx, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to attach customer request: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(need)
+		} else {
+			output.Success(fmt.Sprintf("Attached a request from %s to %s", customer.Name, issue.Identifier), plaintext, jsonOut)
+		}
+	},
+}
+
+var issueCustomersCmd = &cobra.Command{
+	Use:   "customers ISSUE-ID",
+	Short: "Show which customers asked for an issue",
+	Long: `List the customer requests attached to an issue: the customers, their tier,
+whether the request is important, and what they said.
+
+Examples:
+  linctl issue customers ENG-123
+  linctl issue customers --current --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		issueID, err := issueFromArgs(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		issue, err := client.GetIssue(ctx, issueID)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
+		}
+
+		filter := map[string]interface{}{
+			"issue": map[string]interface{}{"id": map[string]interface{}{"eq": issue.ID}},
+		}
+		needs, err := loadCustomerNeeds(ctx, client, filter, 0)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get customer requests: %v", err), plaintext, jsonOut)
+		}
+
+		if len(needs) == 0 && !jsonOut {
+			output.Info(fmt.Sprintf("No customers have asked for %s", issue.Identifier), plaintext, jsonOut)
+			return
+		}
+		printCustomerNeeds(needs, false, plaintext, jsonOut)
+
+		if !jsonOut && !plaintext {
+			customers := make(map[string]bool)
+			important := 0
+			for _, need := range needs {
+				if need.Customer != nil {
+					customers[need.Customer.ID] = true
+				}
+				if need.Priority > 0 {
+					important++
+				}
+			}
+			fmt.Printf("\n%s %d customers asked for %s (%d important)\n",
+				color.New(color.FgGreen).Sprint("✓"), len(customers), issue.Identifier, important)
+		}
+	},
+}
+
+// loadCustomers fetches every customer
+func loadCustomers(ctx context.Context, client *api.Client) ([]api.Customer, error) {
+	customers, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize}, func(ctx context.Context, first int, after string) ([]api.Customer, api.PageInfo, error) {
+		page, err := client.GetCustomers(ctx, first, after)
+		if err != nil {
+			return nil, api.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	return customers, err
+}
+
+// loadCustomerNeeds fetches the customer requests matching filter, up to limit
+// (0 for all)
+func loadCustomerNeeds(ctx context.Context, client *api.Client, filter map[string]interface{}, limit int) ([]api.CustomerNeed, error) {
+	if len(filter) == 0 {
+		filter = nil
+	}
+	needs, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize, MaxResults: limit}, func(ctx context.Context, first int, after string) ([]api.CustomerNeed, api.PageInfo, error) {
+		page, err := client.GetCustomerNeeds(ctx, filter, first, after)
+		if err != nil {
+			return nil, api.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	return needs, err
+}
+
+// resolveCustomer finds a customer by ID, name, or domain
+func resolveCustomer(ctx context.Context, client *api.Client, ref string) (*api.Customer, error) {
+	customers, err := loadCustomers(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customers: %v", err)
+	}
+
+	ref = strings.TrimSpace(ref)
+	domain := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(ref), "https://"), "http://")
+	domain = strings.TrimPrefix(strings.TrimSuffix(domain, "/"), "www.")
+	for i, customer := range customers {
+		if customer.ID == ref || strings.EqualFold(customer.Name, ref) {
+			return &customers[i], nil
+		}
+		for _, d := range customer.Domains {
+			if strings.EqualFold(d, domain) {
+				return &customers[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("customer not found: %s (see 'linctl customer list')", ref)
+}
+
+// printCustomerNeeds shows customer requests as a table; withIssue adds the
+// issue each one is attached to
+func printCustomerNeeds(needs []api.CustomerNeed, withIssue, plaintext, jsonOut bool) {
+	if jsonOut {
+		output.JSON(needs)
+		return
+	}
+	if len(needs) == 0 {
+		output.Info("No customer requests found", plaintext, jsonOut)
+		return
+	}
+
+	headers := []string{"Customer", "Tier", "Important", "Request", "Requested", "By"}
+	if withIssue {
+		headers = append([]string{"Issue", "Title"}, headers...)
+	}
+	rows := make([][]string, len(needs))
+	for i, need := range needs {
+		customer, tier := "", ""
+		if need.Customer != nil {
+			customer = need.Customer.Name
+			if need.Customer.Tier != nil {
+				tier = need.Customer.Tier.Name
+			}
+		}
+		important := ""
+		if need.Priority > 0 {
+			important = "yes"
+			if !plaintext {
+				important = color.New(color.FgRed, color.Bold).Sprint("★ yes")
+			}
+		}
+		body := ""
+		if need.Body != nil {
+			body = strings.TrimSpace(*need.Body)
+			if !plaintext {
+				body, _, _ = strings.Cut(body, "\n")
+			} else {
+				body = strings.ReplaceAll(body, "\n", " ")
+			}
+		}
+		creator := ""
+		if need.Creator != nil {
+			creator = need.Creator.Name
+		}
+		row := []string{customer, tier, important, body, formatTimeAgo(need.CreatedAt), creator}
+		if withIssue {
+			identifier, title := "", ""
+			if need.Issue != nil {
+				identifier, title = need.Issue.Identifier, need.Issue.Title
+			}
+			row = append([]string{identifier, title}, row...)
+		}
+		rows[i] = row
+	}
+
+	output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, jsonOut)
+	if withIssue && !plaintext {
+		fmt.Printf("\n%s %d customer requests\n", color.New(color.FgGreen).Sprint("✓"), len(needs))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(customerCmd)
+	customerCmd.AddCommand(customerListCmd)
+	customerCmd.AddCommand(customerRequestsCmd)
+	customerCmd.AddCommand(customerAttachCmd)
+	issueCmd.AddCommand(issueCustomersCmd)
+
+	customerRequestsCmd.Flags().Bool("important", false, "Only requests marked important")
+	customerRequestsCmd.Flags().IntP("limit", "l", 50, "Maximum number of requests to list (0 for all)")
+
+	customerAttachCmd.Flags().StringP("body", "b", "", "What the customer asked for")
+	customerAttachCmd.Flags().Bool("important", false, "Mark the request as important")
+	customerAttachCmd.Flags().String("url", "", "Link to the source of the request (support ticket, call notes)")
+
+	issueCustomersCmd.Args = issueArg
+	pickable("issue", issueCustomersCmd)
+	issueCustomersCmd.Flags().Bool("current", false, "Use the issue named by the current git branch (see 'issue current')")
+}
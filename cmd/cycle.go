@@ -0,0 +1,528 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// stateTypeOrder sorts workflow state types from untriaged to done
+var stateTypeOrder = map[string]int{
+	"triage":    0,
+	"backlog":   1,
+	"unstarted": 2,
+	"started":   3,
+	"completed": 4,
+	"canceled":  5,
+}
+
+// cycleCmd represents the cycle command
+var cycleCmd = &cobra.Command{
+	Use:     "cycle",
+	Aliases: []string{"cycles", "sprint"},
+	Short:   "View team cycles (sprints)",
+	Long: `View a team's cycles, their progress, scope changes, and issue breakdowns.
+
+Cycles are selected by number or by 'current', 'next', or 'previous'. The team
+comes from --team or default-team in config.
+
+Examples:
+  linctl cycle list --team ENG
+  linctl cycle current --team ENG
+  linctl cycle next --team ENG
+  linctl cycle view 42 --team ENG
+  linctl issue move ENG-123 --cycle current`,
+}
+
+var cycleListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List a team's cycles",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		teamKey := requireCycleTeam(cmd, plaintext, jsonOut)
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		cycles, err := client.GetTeamCycles(context.Background(), teamKey, 250, nil)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list cycles: %v", err), plaintext, jsonOut)
+		}
+
+		// Most recent first
+		nodes := cycles.Nodes
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Number > nodes[j].Number })
+		if limit > 0 && len(nodes) > limit {
+			nodes = nodes[:limit]
+		}
+
+		if jsonOut {
+			output.JSON(nodes)
+			return
+		}
+		if len(nodes) == 0 {
+			output.Info(fmt.Sprintf("No cycles found for team %s", teamKey), plaintext, jsonOut)
+			return
+		}
+
+		if plaintext {
+			fmt.Println("Number\tName\tStarts\tEnds\tProgress\tStatus")
+			for _, cycle := range nodes {
+				fmt.Printf("%d\t%s\t%s\t%s\t%.0f%%\t%s\n",
+					cycle.Number,
+					cycle.Name,
+					cycleDate(cycle.StartsAt),
+					cycleDate(cycle.EndsAt),
+					cycle.Progress*100,
+					cycleStatus(cycle))
+			}
+			return
+		}
+
+		rows := make([][]string, len(nodes))
+		for i, cycle := range nodes {
+			status := cycleStatus(cycle)
+			switch status {
+			case "current":
+				status = color.New(color.FgGreen, color.Bold).Sprint(status)
+			case "next", "upcoming":
+				status = color.New(color.FgCyan).Sprint(status)
+			default:
+				status = color.New(color.FgWhite, color.Faint).Sprint(status)
+			}
+			rows[i] = []string{
+				strconv.Itoa(cycle.Number),
+				cycle.Name,
+				cycleDate(cycle.StartsAt),
+				cycleDate(cycle.EndsAt),
+				fmt.Sprintf("%.0f%%", cycle.Progress*100),
+				status,
+			}
+		}
+		output.Table(output.TableData{
+			Headers: []string{"#", "Name", "Starts", "Ends", "Progress", "Status"},
+			Rows:    rows,
+		}, plaintext, jsonOut)
+	},
+}
+
+var cycleViewCmd = &cobra.Command{
+	Use:     "view CYCLE",
+	Aliases: []string{"get", "show"},
+	Short:   "Show a cycle's progress, scope changes, and issues",
+	Long: `Show a cycle's progress, scope changes, and a breakdown of its issues by state
+and assignee. CYCLE is a cycle number or 'current', 'next', or 'previous'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runCycleView(cmd, args[0])
+	},
+}
+
+var cycleCurrentCmd = &cobra.Command{
+	Use:     "current",
+	Aliases: []string{"active"},
+	Short:   "Show the team's active cycle",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runCycleView(cmd, "current")
+	},
+}
+
+var cycleNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the team's next cycle",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runCycleView(cmd, "next")
+	},
+}
+
+// runCycleView resolves a cycle selector and renders the cycle
+func runCycleView(cmd *cobra.Command, selector string) {
+	plaintext := viper.GetBool("plaintext")
+	jsonOut := viper.GetBool("json")
+
+	teamKey := requireCycleTeam(cmd, plaintext, jsonOut)
+
+	authHeader, err := auth.GetAuthHeader()
+	if err != nil {
+		exitNotAuthenticated(plaintext, jsonOut)
+	}
+
+	client := api.NewClient(authHeader)
+	ctx := context.Background()
+
+	cycle, err := resolveCycleSelector(ctx, client, teamKey, selector)
+	if err != nil {
+		exitWithError(err, err.Error(), plaintext, jsonOut)
+	}
+
+	cycle, err = client.GetCycle(ctx, cycle.ID)
+	if err != nil {
+		exitWithError(err, fmt.Sprintf("Failed to get cycle: %v", err), plaintext, jsonOut)
+	}
+
+	renderCycle(buildCycleSummary(cycle), plaintext, jsonOut)
+}
+
+// resolveCycleSelector finds a team's cycle by number or as 'current', 'next', or 'previous'
+func resolveCycleSelector(ctx context.Context, client *api.Client, teamKey, selector string) (*api.Cycle, error) {
+	number, err := strconv.Atoi(selector)
+	if err != nil {
+		return findRelativeCycle(ctx, client, teamKey, selector)
+	}
+	cycle, err := client.GetCycleByNumber(ctx, teamKey, number)
+	if err != nil {
+		return nil, err
+	}
+	if cycle == nil {
+		return nil, fmt.Errorf("cycle #%d not found for team %s", number, teamKey)
+	}
+	return cycle, nil
+}
+
+// findRelativeCycle returns the team's current, next, or previous cycle
+func findRelativeCycle(ctx context.Context, client *api.Client, teamKey, selector string) (*api.Cycle, error) {
+	var field string
+	switch strings.ToLower(strings.TrimSpace(selector)) {
+	case "current", "active":
+		field = "isActive"
+	case "next":
+		field = "isNext"
+	case "previous", "last":
+		field = "isPrevious"
+	default:
+		return nil, fmt.Errorf("invalid cycle '%s' (use a number, 'current', 'next', or 'previous')", selector)
+	}
+
+	filter := map[string]interface{}{field: map[string]interface{}{"eq": true}}
+	cycles, err := client.GetTeamCycles(ctx, teamKey, 1, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cycles for team %s: %v", teamKey, err)
+	}
+	if len(cycles.Nodes) == 0 {
+		return nil, fmt.Errorf("team %s has no %s cycle", teamKey, strings.ToLower(selector))
+	}
+	return &cycles.Nodes[0], nil
+}
+
+// requireCycleTeam returns --team, exiting when no team is set
+func requireCycleTeam(cmd *cobra.Command, plaintext, jsonOut bool) string {
+	teamKey, _ := cmd.Flags().GetString("team")
+	if teamKey == "" {
+		output.Error("A team is required (--team, or default-team in config)", plaintext, jsonOut)
+		exit(1)
+	}
+	return teamKey
+}
+
+// cycleSummary is a cycle with its scope changes and issue breakdowns
+type cycleSummary struct {
+	Cycle     *api.Cycle    `json:"cycle"`
+	Scope     cycleScope    `json:"scope"`
+	States    []cycleBucket `json:"states"`
+	Assignees []cycleBucket `json:"assignees"`
+}
+
+// cycleScope compares a cycle's scope at its start with its current scope
+type cycleScope struct {
+	PointsAtStart   float64 `json:"pointsAtStart"`
+	Points          float64 `json:"points"`
+	PointsCompleted float64 `json:"pointsCompleted"`
+	IssuesAtStart   int     `json:"issuesAtStart"`
+	Issues          int     `json:"issues"`
+	IssuesCompleted int     `json:"issuesCompleted"`
+}
+
+// cycleBucket counts the issues and points in one state or for one assignee
+type cycleBucket struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type,omitempty"`
+	Issues int     `json:"issues"`
+	Points float64 `json:"points"`
+}
+
+// buildCycleSummary derives scope changes and breakdowns from a cycle's history and issues
+func buildCycleSummary(cycle *api.Cycle) cycleSummary {
+	first := func(values []float64) float64 {
+		if len(values) == 0 {
+			return 0
+		}
+		return values[0]
+	}
+	last := func(values []float64) float64 {
+		if len(values) == 0 {
+			return 0
+		}
+		return values[len(values)-1]
+	}
+
+	summary := cycleSummary{
+		Cycle: cycle,
+		Scope: cycleScope{
+			PointsAtStart:   first(cycle.ScopeHistory),
+			Points:          last(cycle.ScopeHistory),
+			PointsCompleted: last(cycle.CompletedScopeHistory),
+			IssuesAtStart:   int(first(cycle.IssueCountHistory)),
+			Issues:          int(last(cycle.IssueCountHistory)),
+			IssuesCompleted: int(last(cycle.CompletedIssueCountHistory)),
+		},
+		States:    []cycleBucket{},
+		Assignees: []cycleBucket{},
+	}
+
+	if cycle.Issues == nil {
+		return summary
+	}
+
+	states := make(map[string]*cycleBucket)
+	assignees := make(map[string]*cycleBucket)
+	for _, issue := range cycle.Issues.Nodes {
+		points := 0.0
+		if issue.Estimate != nil {
+			points = *issue.Estimate
+		}
+
+		stateName, stateType := "No state", ""
+		if issue.State != nil {
+			stateName, stateType = issue.State.Name, issue.State.Type
+		}
+		if states[stateName] == nil {
+			states[stateName] = &cycleBucket{Name: stateName, Type: stateType}
+		}
+		states[stateName].Issues++
+		states[stateName].Points += points
+
+		assignee := "Unassigned"
+		if issue.Assignee != nil {
+			assignee = issue.Assignee.Name
+		}
+		if assignees[assignee] == nil {
+			assignees[assignee] = &cycleBucket{Name: assignee}
+		}
+		assignees[assignee].Issues++
+		assignees[assignee].Points += points
+	}
+
+	for _, bucket := range states {
+		summary.States = append(summary.States, *bucket)
+	}
+	sort.Slice(summary.States, func(i, j int) bool {
+		a, b := summary.States[i], summary.States[j]
+		if stateTypeOrder[a.Type] != stateTypeOrder[b.Type] {
+			return stateTypeOrder[a.Type] < stateTypeOrder[b.Type]
+		}
+		return a.Name < b.Name
+	})
+
+	for _, bucket := range assignees {
+		summary.Assignees = append(summary.Assignees, *bucket)
+	}
+	sort.Slice(summary.Assignees, func(i, j int) bool {
+		a, b := summary.Assignees[i], summary.Assignees[j]
+		if a.Issues != b.Issues {
+			return a.Issues > b.Issues
+		}
+		return a.Name < b.Name
+	})
+
+	return summary
+}
+
+func renderCycle(summary cycleSummary, plaintext, jsonOut bool) {
+	if jsonOut {
+		output.JSON(summary)
+		return
+	}
+
+	cycle := summary.Cycle
+	scope := summary.Scope
+	title := fmt.Sprintf("Cycle %d", cycle.Number)
+	if cycle.Name != "" {
+		title += ": " + cycle.Name
+	}
+	team := ""
+	if cycle.Team != nil {
+		team = cycle.Team.Key
+	}
+
+	if plaintext {
+		fmt.Printf("# %s\n\n", title)
+		fmt.Printf("- **Team**: %s\n", team)
+		fmt.Printf("- **Status**: %s\n", cycleStatus(*cycle))
+		fmt.Printf("- **Dates**: %s to %s\n", cycleDate(cycle.StartsAt), cycleDate(cycle.EndsAt))
+		fmt.Printf("- **Progress**: %.0f%%\n", cycle.Progress*100)
+		fmt.Printf("- **Scope**: %s points (%s at start, %s)\n",
+			formatPoints(scope.Points), formatPoints(scope.PointsAtStart), formatChange(scope.Points-scope.PointsAtStart))
+		fmt.Printf("- **Issues**: %d (%d at start, %s)\n",
+			scope.Issues, scope.IssuesAtStart, formatChange(float64(scope.Issues-scope.IssuesAtStart)))
+		fmt.Printf("- **Completed**: %s points, %d issues\n", formatPoints(scope.PointsCompleted), scope.IssuesCompleted)
+
+		fmt.Printf("\n## By State\n")
+		for _, bucket := range summary.States {
+			fmt.Printf("- %s: %d issues, %s points\n", bucket.Name, bucket.Issues, formatPoints(bucket.Points))
+		}
+		fmt.Printf("\n## By Assignee\n")
+		for _, bucket := range summary.Assignees {
+			fmt.Printf("- %s: %d issues, %s points\n", bucket.Name, bucket.Issues, formatPoints(bucket.Points))
+		}
+		if cycle.Issues != nil && len(cycle.Issues.Nodes) > 0 {
+			fmt.Printf("\n## Issues\n")
+			for _, issue := range cycle.Issues.Nodes {
+				state := ""
+				if issue.State != nil {
+					state = issue.State.Name
+				}
+				fmt.Printf("- %s %s [%s]\n", issue.Identifier, issue.Title, state)
+			}
+		}
+		return
+	}
+
+	bold := color.New(color.Bold)
+	faint := color.New(color.FgWhite, color.Faint)
+
+	fmt.Println()
+	fmt.Printf("%s %s\n", color.New(color.FgCyan, color.Bold).Sprint("🔄"), color.New(color.FgCyan, color.Bold).Sprint(title))
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("%s %s   %s %s\n", bold.Sprint("Team:"), team, bold.Sprint("Status:"), cycleStatus(*cycle))
+	fmt.Printf("%s %s → %s", bold.Sprint("Dates:"), cycleDate(cycle.StartsAt), cycleDate(cycle.EndsAt))
+	if cycle.IsActive {
+		if ends, err := time.Parse(time.RFC3339, cycle.EndsAt); err == nil {
+			days := int(math.Ceil(time.Until(ends).Hours() / 24))
+			fmt.Print(faint.Sprintf(" (%d days left)", days))
+		}
+	}
+	fmt.Println()
+
+	fmt.Printf("%s %s %s\n", bold.Sprint("Progress:"), progressBar(cycle.Progress, 30), fmt.Sprintf("%.0f%%", cycle.Progress*100))
+	fmt.Printf("%s %s points, %d issues %s\n",
+		bold.Sprint("Scope:"),
+		formatPoints(scope.Points),
+		scope.Issues,
+		faint.Sprintf("(%s points, %s issues since start)",
+			formatChange(scope.Points-scope.PointsAtStart),
+			formatChange(float64(scope.Issues-scope.IssuesAtStart))))
+	fmt.Printf("%s %s points, %d issues\n", bold.Sprint("Completed:"), formatPoints(scope.PointsCompleted), scope.IssuesCompleted)
+
+	if len(summary.States) > 0 {
+		fmt.Printf("\n%s\n", bold.Sprint("By state:"))
+		for _, bucket := range summary.States {
+			fmt.Printf("  %-20s %3d issues  %s pts\n", bucket.Name, bucket.Issues, formatPoints(bucket.Points))
+		}
+	}
+	if len(summary.Assignees) > 0 {
+		fmt.Printf("\n%s\n", bold.Sprint("By assignee:"))
+		for _, bucket := range summary.Assignees {
+			fmt.Printf("  %-20s %3d issues  %s pts\n", truncateString(bucket.Name, 20), bucket.Issues, formatPoints(bucket.Points))
+		}
+	}
+
+	if cycle.Issues != nil && len(cycle.Issues.Nodes) > 0 {
+		fmt.Printf("\n%s\n", bold.Sprint("Issues:"))
+		issues := append([]api.Issue(nil), cycle.Issues.Nodes...)
+		sort.SliceStable(issues, func(i, j int) bool {
+			return issueStateOrder(issues[i]) < issueStateOrder(issues[j])
+		})
+		for _, issue := range issues {
+			assignee := "Unassigned"
+			if issue.Assignee != nil {
+				assignee = issue.Assignee.Name
+			}
+			fmt.Printf("  %s %s %s %s\n",
+				stateIcon(issue.State),
+				color.New(color.FgCyan).Sprintf("%-10s", issue.Identifier),
+				truncateString(issue.Title, 50),
+				faint.Sprintf("(%s)", assignee))
+		}
+	}
+	fmt.Println()
+}
+
+// issueStateOrder sorts issues by how far along their state is
+func issueStateOrder(issue api.Issue) int {
+	if issue.State == nil {
+		return -1
+	}
+	return stateTypeOrder[issue.State.Type]
+}
+
+// cycleStatus describes where a cycle sits relative to today
+func cycleStatus(cycle api.Cycle) string {
+	switch {
+	case cycle.IsActive:
+		return "current"
+	case cycle.IsNext:
+		return "next"
+	case cycle.IsPrevious:
+		return "previous"
+	case cycle.IsFuture:
+		return "upcoming"
+	default:
+		return "past"
+	}
+}
+
+// cycleDate trims an ISO timestamp to its date
+func cycleDate(timestamp string) string {
+	if len(timestamp) >= 10 {
+		return timestamp[:10]
+	}
+	return timestamp
+}
+
+// formatPoints prints whole numbers without decimals
+func formatPoints(points float64) string {
+	if points == math.Trunc(points) {
+		return strconv.FormatFloat(points, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(points, 'f', 1, 64)
+}
+
+// formatChange prints a signed difference
+func formatChange(delta float64) string {
+	if delta >= 0 {
+		return "+" + formatPoints(delta)
+	}
+	return formatPoints(delta)
+}
+
+// progressBar draws a fraction between 0 and 1 as a bar of the given width
+func progressBar(fraction float64, width int) string {
+	filled := int(math.Round(math.Max(0, math.Min(1, fraction)) * float64(width)))
+	return color.New(color.FgGreen).Sprint(strings.Repeat("█", filled)) +
+		color.New(color.FgWhite, color.Faint).Sprint(strings.Repeat("░", width-filled))
+}
+
+func init() {
+	rootCmd.AddCommand(cycleCmd)
+	cycleCmd.AddCommand(cycleListCmd)
+	cycleCmd.AddCommand(cycleViewCmd)
+	cycleCmd.AddCommand(cycleCurrentCmd)
+	cycleCmd.AddCommand(cycleNextCmd)
+
+	for _, c := range []*cobra.Command{cycleListCmd, cycleViewCmd, cycleCurrentCmd, cycleNextCmd} {
+		c.Flags().StringP("team", "t", "", "Team key (default: default-team from config)")
+	}
+	cycleListCmd.Flags().IntP("limit", "l", 25, "Maximum number of cycles to show")
+}
@@ -0,0 +1,430 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/output"
+	fcolor "github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var cycleBurndownCmd = &cobra.Command{
+	Use:   "burndown [CYCLE]",
+	Short: "Chart a cycle's remaining scope day by day",
+	Long: `Chart the scope left in a cycle on each day so far against an ideal line that
+reaches zero on the cycle's last day. --burnup charts the completed scope
+against the total scope instead. CYCLE is a cycle number or 'current' (the
+default), 'next', or 'previous'.
+
+--png also writes the chart as an image, for sharing.
+
+Examples:
+  linctl cycle burndown --team ENG
+  linctl cycle burndown 41 --team ENG --issues
+  linctl cycle burndown --team ENG --burnup --png burnup.png
+  linctl cycle burndown --team ENG --sparkline`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		teamKey := requireCycleTeam(cmd, plaintext, jsonOut)
+		selector := "current"
+		if len(args) > 0 {
+			selector = args[0]
+		}
+		height, _ := cmd.Flags().GetInt("height")
+		if height < 3 {
+			output.Error("--height must be at least 3", plaintext, jsonOut)
+			exit(1)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		cycle, err := resolveCycleSelector(context.Background(), client, teamKey, selector)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		issues, _ := cmd.Flags().GetBool("issues")
+		burnup, _ := cmd.Flags().GetBool("burnup")
+		scope, completed := cycle.ScopeHistory, cycle.CompletedScopeHistory
+		unit := "points"
+		if issues {
+			scope, completed = cycle.IssueCountHistory, cycle.CompletedIssueCountHistory
+			unit = "issues"
+		}
+		chart := buildBurndownChart(cycle.StartsAt, cycle.EndsAt, scope, completed, burnup)
+		chart.Cycle = cycle.Number
+		chart.Team = teamKey
+		chart.Unit = unit
+
+		if pngPath, _ := cmd.Flags().GetString("png"); pngPath != "" {
+			if err := writeBurndownPNG(pngPath, chart); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write %s: %v", pngPath, err), plaintext, jsonOut)
+			}
+			if !jsonOut {
+				output.Success(fmt.Sprintf("Wrote %s", pngPath), plaintext, jsonOut)
+			}
+		}
+
+		if jsonOut {
+			output.JSON(chart)
+			return
+		}
+		if len(chart.Values) == 0 {
+			output.Info(fmt.Sprintf("Cycle %d has no scope history yet", cycle.Number), plaintext, jsonOut)
+			return
+		}
+
+		title := fmt.Sprintf("Cycle %d burndown", cycle.Number)
+		if burnup {
+			title = fmt.Sprintf("Cycle %d burnup", cycle.Number)
+		}
+		if !plaintext {
+			title = fcolor.New(fcolor.FgCyan, fcolor.Bold).Sprint(title)
+		}
+		fmt.Println(title)
+		if sparkline, _ := cmd.Flags().GetBool("sparkline"); sparkline {
+			fmt.Printf("%s  %s\n", renderSparkline(chart.Values, plaintext), chart.summary())
+			return
+		}
+		fmt.Print(renderBurndown(chart, height, plaintext))
+		fmt.Println(chart.summary())
+	},
+}
+
+// burndownChart is a cycle's daily scope history, ready to draw. Values holds a
+// bar per elapsed day: the remaining scope, or the completed scope for a
+// burnup. Line holds the reference line: the ideal remaining scope on each day
+// of the cycle, or the total scope on each elapsed day for a burnup.
+type burndownChart struct {
+	Team      string    `json:"team"`
+	Cycle     int       `json:"cycle"`
+	Burnup    bool      `json:"burnup"`
+	Unit      string    `json:"unit"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	Days      int       `json:"days"`
+	Scope     []float64 `json:"scope"`
+	Completed []float64 `json:"completed"`
+	Values    []float64 `json:"values"`
+	Line      []float64 `json:"line"`
+}
+
+// buildBurndownChart derives the bars and reference line from a cycle's scope
+// and completed-scope histories
+func buildBurndownChart(startsAt, endsAt string, scope, completed []float64, burnup bool) burndownChart {
+	chart := burndownChart{Burnup: burnup, Scope: scope, Completed: completed, Values: []float64{}, Line: []float64{}}
+	chart.StartsAt, _ = time.Parse(time.RFC3339, startsAt)
+	chart.EndsAt, _ = time.Parse(time.RFC3339, endsAt)
+	chart.Days = int(math.Ceil(chart.EndsAt.Sub(chart.StartsAt).Hours() / 24))
+	if chart.Days < len(scope) {
+		chart.Days = len(scope)
+	}
+
+	for i := range scope {
+		done := 0.0
+		if i < len(completed) {
+			done = completed[i]
+		}
+		if burnup {
+			chart.Values = append(chart.Values, done)
+			chart.Line = append(chart.Line, scope[i])
+		} else {
+			chart.Values = append(chart.Values, math.Max(0, scope[i]-done))
+		}
+	}
+	if !burnup && len(scope) > 0 && chart.Days > 0 {
+		for day := 0; day <= chart.Days; day++ {
+			chart.Line = append(chart.Line, scope[0]*(1-float64(day)/float64(chart.Days)))
+		}
+	}
+	return chart
+}
+
+// maxValue is the top of the chart's scale
+func (c burndownChart) maxValue() float64 {
+	top := 1.0
+	for _, v := range append(append([]float64(nil), c.Values...), c.Line...) {
+		top = math.Max(top, v)
+	}
+	return top
+}
+
+// summary describes where the cycle stands today
+func (c burndownChart) summary() string {
+	if len(c.Values) == 0 {
+		return ""
+	}
+	day := len(c.Values) - 1
+	scope := c.Scope[day]
+	done := 0.0
+	if day < len(c.Completed) {
+		done = c.Completed[day]
+	}
+	percent := 0.0
+	if scope > 0 {
+		percent = done / scope * 100
+	}
+	summary := fmt.Sprintf("Day %d of %d: %s of %s %s done (%.0f%%), %s left",
+		day+1, c.Days, formatPoints(done), formatPoints(scope), c.Unit, percent, formatPoints(math.Max(0, scope-done)))
+	if !c.Burnup && day < len(c.Line) {
+		summary += fmt.Sprintf("; ideal %s left", formatPoints(math.Round(c.Line[day]*10)/10))
+	}
+	return summary
+}
+
+// sparkBlocks are the eighths used for sparklines and bar tops
+var sparkBlocks = []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// renderSparkline draws values on one line, scaled to their maximum
+func renderSparkline(values []float64, plaintext bool) string {
+	top := 0.0
+	for _, v := range values {
+		top = math.Max(top, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if top > 0 {
+			level = int(math.Round(v / top * 8))
+		}
+		if plaintext {
+			b.WriteString(string("_.-=#"[level/2]))
+			continue
+		}
+		if level == 0 && v > 0 {
+			level = 1
+		}
+		b.WriteString(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// renderBurndown draws the chart height rows tall with the scale on the left
+// and the cycle's dates underneath. Plaintext output sticks to ASCII.
+func renderBurndown(c burndownChart, height int, plaintext bool) string {
+	top := c.maxValue()
+	columns := c.Days + 1
+	cellWidth := 60 / columns
+	if cellWidth < 1 {
+		cellWidth = 1
+	}
+	if cellWidth > 3 {
+		cellWidth = 3
+	}
+
+	labelWidth := len(formatPoints(top))
+	axis, corner, rule, marker := "┤", "└", "─", "·"
+	if plaintext {
+		axis, corner, rule, marker = "|", "+", "-", "."
+	}
+	barColor := fcolor.New(fcolor.FgCyan)
+	lineColor := fcolor.New(fcolor.FgWhite, fcolor.Faint)
+
+	var b strings.Builder
+	for row := height; row >= 1; row-- {
+		label := ""
+		switch row {
+		case height:
+			label = formatPoints(top)
+		case (height + 1) / 2:
+			label = formatPoints(math.Round(top / 2))
+		}
+		var line strings.Builder
+		fmt.Fprintf(&line, "%*s %s", labelWidth, label, axis)
+
+		for day := 0; day < columns; day++ {
+			cell := " "
+			if day < len(c.Values) {
+				filled := c.Values[day] / top * float64(height)
+				switch {
+				case filled >= float64(row):
+					cell = "█"
+				case filled > float64(row-1):
+					cell = sparkBlocks[int(math.Round((filled-float64(row-1))*8))]
+				}
+				if plaintext && cell != " " {
+					cell = "#"
+					if filled < float64(row)-0.5 {
+						cell = " "
+					}
+				}
+			}
+			if cell != " " && !plaintext {
+				cell = barColor.Sprint(cell)
+			}
+			if cell == " " && day < len(c.Line) && int(math.Round(c.Line[day]/top*float64(height))) == row {
+				cell = marker
+				if !plaintext {
+					cell = lineColor.Sprint(marker)
+				}
+			}
+			line.WriteString(strings.Repeat(cell, cellWidth))
+		}
+		b.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+
+	width := columns * cellWidth
+	fmt.Fprintf(&b, "%*s %s%s\n", labelWidth, "0", corner, strings.Repeat(rule, width))
+	start, end := c.StartsAt.Format("Jan 2"), c.EndsAt.Format("Jan 2")
+	gap := width - len(start) - len(end)
+	if gap < 1 {
+		gap = 1
+	}
+	fmt.Fprintf(&b, "%*s  %s%s%s\n", labelWidth, "", start, strings.Repeat(" ", gap), end)
+
+	legend := fmt.Sprintf("%s remaining   %s ideal", "█", marker)
+	if c.Burnup {
+		legend = fmt.Sprintf("%s completed   %s scope", "█", marker)
+	}
+	if plaintext {
+		legend = strings.Replace(legend, "█", "#", 1)
+	} else {
+		legend = lineColor.Sprint(legend)
+	}
+	fmt.Fprintf(&b, "%*s  %s\n", labelWidth, "", legend)
+	return b.String()
+}
+
+// writeBurndownPNG draws the chart as a PNG image: bars over a light grid with
+// the reference line, the scale's top and bottom, and the cycle's dates
+func writeBurndownPNG(path string, c burndownChart) error {
+	const (
+		width, height = 800, 400
+		left, right   = 70, 20
+		top, bottom   = 20, 40
+	)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fillRect(img, img.Bounds(), color.RGBA{255, 255, 255, 255})
+
+	plotW, plotH := width-left-right, height-top-bottom
+	grid := color.RGBA{230, 230, 235, 255}
+	ink := color.RGBA{60, 60, 70, 255}
+	for i := 0; i <= 4; i++ {
+		y := top + plotH*i/4
+		fillRect(img, image.Rect(left, y, left+plotW, y+1), grid)
+	}
+
+	maxValue := c.maxValue()
+	columns := c.Days + 1
+	x := func(day float64) int { return left + int(day*float64(plotW)/float64(columns)) }
+	y := func(v float64) int { return top + plotH - int(v/maxValue*float64(plotH)) }
+
+	bar := color.RGBA{94, 106, 210, 255}
+	for day, v := range c.Values {
+		x0, x1 := x(float64(day))+2, x(float64(day+1))-2
+		if x1 <= x0 {
+			x1 = x0 + 1
+		}
+		fillRect(img, image.Rect(x0, y(v), x1, top+plotH), bar)
+	}
+
+	line := color.RGBA{230, 120, 60, 255}
+	for day := 1; day < len(c.Line); day++ {
+		drawLine(img, x(float64(day-1)+0.5), y(c.Line[day-1]), x(float64(day)+0.5), y(c.Line[day]), line)
+	}
+
+	fillRect(img, image.Rect(left, top, left+1, top+plotH+1), ink)
+	fillRect(img, image.Rect(left, top+plotH, left+plotW, top+plotH+1), ink)
+
+	maxLabel := formatPoints(maxValue)
+	drawText(img, left-8-textWidth(maxLabel), top-5, maxLabel, ink)
+	drawText(img, left-8-textWidth("0"), top+plotH-5, "0", ink)
+	drawText(img, left, top+plotH+12, c.StartsAt.Format("2006-01-02"), ink)
+	endLabel := c.EndsAt.Format("2006-01-02")
+	drawText(img, left+plotW-textWidth(endLabel), top+plotH+12, endLabel, ink)
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func fillRect(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	r = r.Intersect(img.Bounds())
+	for py := r.Min.Y; py < r.Max.Y; py++ {
+		for px := r.Min.X; px < r.Max.X; px++ {
+			img.SetRGBA(px, py, c)
+		}
+	}
+}
+
+// drawLine draws a two-pixel-thick line between two points
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	steps := int(math.Max(math.Abs(float64(x1-x0)), math.Abs(float64(y1-y0))))
+	if steps == 0 {
+		steps = 1
+	}
+	for i := 0; i <= steps; i++ {
+		px := x0 + (x1-x0)*i/steps
+		py := y0 + (y1-y0)*i/steps
+		fillRect(img, image.Rect(px, py, px+2, py+2), c)
+	}
+}
+
+// chartGlyphs is a 3x5 pixel font for the digits, dashes, and dots in chart
+// labels; each row is three bits, left to right
+var chartGlyphs = map[rune][5]uint8{
+	'0': {7, 5, 5, 5, 7},
+	'1': {2, 6, 2, 2, 7},
+	'2': {7, 1, 7, 4, 7},
+	'3': {7, 1, 7, 1, 7},
+	'4': {5, 5, 7, 1, 1},
+	'5': {7, 4, 7, 1, 7},
+	'6': {7, 4, 7, 5, 7},
+	'7': {7, 1, 1, 1, 1},
+	'8': {7, 5, 7, 5, 7},
+	'9': {7, 5, 7, 1, 7},
+	'-': {0, 0, 7, 0, 0},
+	'.': {0, 0, 0, 0, 2},
+}
+
+// chartGlyphScale is how many pixels square each font pixel is drawn
+const chartGlyphScale = 2
+
+func textWidth(text string) int {
+	return len(text) * 4 * chartGlyphScale
+}
+
+// drawText draws text with chartGlyphs, its top-left corner at x, y
+func drawText(img *image.RGBA, x, y int, text string, c color.RGBA) {
+	for i, r := range text {
+		glyph := chartGlyphs[r]
+		for row, bits := range glyph {
+			for col := 0; col < 3; col++ {
+				if bits&(4>>col) == 0 {
+					continue
+				}
+				px := x + (i*4+col)*chartGlyphScale
+				py := y + row*chartGlyphScale
+				fillRect(img, image.Rect(px, py, px+chartGlyphScale, py+chartGlyphScale), c)
+			}
+		}
+	}
+}
+
+func init() {
+	cycleCmd.AddCommand(cycleBurndownCmd)
+
+	cycleBurndownCmd.Flags().StringP("team", "t", "", "Team key (default: default-team from config)")
+	cycleBurndownCmd.Flags().Bool("burnup", false, "Chart completed scope against total scope instead")
+	cycleBurndownCmd.Flags().Bool("issues", false, "Count issues instead of estimate points")
+	cycleBurndownCmd.Flags().Int("height", 12, "Height of the chart in rows")
+	cycleBurndownCmd.Flags().Bool("sparkline", false, "Print a one-line sparkline instead of a chart")
+	cycleBurndownCmd.Flags().String("png", "", "Also write the chart as a PNG image to this path")
+}
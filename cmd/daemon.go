@@ -0,0 +1,564 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/cache"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// daemonReminder is the event type for reminders set with 'linctl remind';
+// the other event types are the inbox's notification kinds
+const daemonReminder = "reminder"
+
+const (
+	defaultDaemonInterval = 5 * time.Minute
+	minDaemonInterval     = 30 * time.Second
+)
+
+// daemonEventTypes are the event types the daemon can notify about
+var daemonEventTypes = []string{notificationAssigned, notificationMentioned, notificationComment, notificationStatus, notificationOther, daemonReminder}
+
+// defaultDaemonEvents are notified about unless daemon.notify or --notify
+// says otherwise
+var defaultDaemonEvents = []string{notificationAssigned, notificationMentioned, notificationStatus, daemonReminder}
+
+// daemonState is what the daemon reports about itself to 'daemon status'
+type daemonState struct {
+	PID       int        `json:"pid,omitempty"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	Interval  string     `json:"interval,omitempty"`
+	Notify    []string   `json:"notify,omitempty"`
+	LastRunAt *time.Time `json:"lastRunAt,omitempty"`
+	LastError string     `json:"lastError,omitempty"`
+}
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Sync and notify in the background",
+	Long: `Run linctl in the background: every few minutes it syncs the local cache
+(see 'linctl sync') and sends a desktop notification for each new inbox
+notification and due reminder.
+
+Event types are assigned, mentioned, comment, status (state changes on issues
+you are subscribed to), other, and reminder. Set them with --notify or the
+daemon.notify config key; the default is assigned, mentioned, status, and
+reminder. Notifications that arrive while the daemon is stopped are left to
+'linctl inbox'.
+
+The daemon runs per profile, and logs to ~/.linctl/daemon/PROFILE.log.
+
+Examples:
+  linctl daemon start
+  linctl daemon start --interval 2m --notify assigned,mentioned
+  linctl daemon status
+  linctl daemon stop
+  linctl daemon run              # In the foreground, e.g. under systemd or launchd`,
+}
+
+var daemonStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the daemon in the background",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		interval, events, err := daemonSettings(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		state, err := loadDaemonState()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if state.PID != 0 && processRunning(state.PID) {
+			output.Error(fmt.Sprintf("The daemon is already running (pid %d)", state.PID), plaintext, jsonOut)
+			exit(1)
+		}
+		// Make sure the daemon will be able to authenticate before leaving it
+		// on its own
+		authenticatedClient(plaintext, jsonOut)
+
+		exe, err := os.Executable()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to find the linctl executable: %v", err), plaintext, jsonOut)
+		}
+		logPath, err := daemonPath(".log")
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if err := os.MkdirAll(filepath.Dir(logPath), 0700); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create %s: %v", filepath.Dir(logPath), err), plaintext, jsonOut)
+		}
+		logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to open the daemon log: %v", err), plaintext, jsonOut)
+		}
+		defer logFile.Close()
+
+		daemon := exec.Command(exe, "daemon", "run",
+			"--profile", auth.Profile(),
+			"--interval", interval.String(),
+			"--notify", strings.Join(events, ","))
+		daemon.Stdout = logFile
+		daemon.Stderr = logFile
+		detachDaemon(daemon)
+		if err := daemon.Start(); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to start the daemon: %v", err), plaintext, jsonOut)
+		}
+
+		// A daemon that can't start (a bad config, say) exits at once
+		exited := make(chan error, 1)
+		go func() { exited <- daemon.Wait() }()
+		select {
+		case <-exited:
+			output.Error(fmt.Sprintf("The daemon exited right after starting; see %s", logPath), plaintext, jsonOut)
+			exit(1)
+		case <-time.After(time.Second):
+		}
+
+		pid := daemon.Process.Pid
+		if jsonOut {
+			output.JSON(map[string]interface{}{"pid": pid, "interval": interval.String(), "notify": events, "log": logPath})
+			return
+		}
+		output.Success(fmt.Sprintf("Daemon started (pid %d), syncing every %s; logging to %s", pid, interval, logPath), plaintext, jsonOut)
+	},
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the daemon",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		state, err := loadDaemonState()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if state.PID == 0 || !processRunning(state.PID) {
+			if jsonOut {
+				output.JSON(map[string]interface{}{"stopped": false})
+				return
+			}
+			output.Info("The daemon is not running", plaintext, jsonOut)
+			return
+		}
+
+		pid := state.PID
+		if err := stopProcess(pid); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to stop the daemon (pid %d): %v", pid, err), plaintext, jsonOut)
+		}
+		for deadline := time.Now().Add(5 * time.Second); processRunning(pid); time.Sleep(100 * time.Millisecond) {
+			if time.Now().After(deadline) {
+				output.Error(fmt.Sprintf("The daemon (pid %d) did not stop", pid), plaintext, jsonOut)
+				exit(1)
+			}
+		}
+		// A killed daemon can't clear its pid itself
+		if state, err := loadDaemonState(); err == nil && state.PID == pid {
+			state.PID = 0
+			_ = saveDaemonState(state)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"stopped": true, "pid": pid})
+			return
+		}
+		output.Success(fmt.Sprintf("Daemon stopped (pid %d)", pid), plaintext, jsonOut)
+	},
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the daemon is running",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		state, err := loadDaemonState()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		logPath, err := daemonPath(".log")
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		running := state.PID != 0 && processRunning(state.PID)
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"running": running, "state": state, "log": logPath})
+			return
+		}
+		if !running {
+			output.Info("The daemon is not running. Start it with 'linctl daemon start'.", plaintext, jsonOut)
+			return
+		}
+
+		lastRun := "never"
+		if state.LastRunAt != nil {
+			lastRun = formatTimeAgo(*state.LastRunAt)
+		}
+		rows := [][2]string{
+			{"PID", fmt.Sprint(state.PID)},
+			{"Started", ""},
+			{"Interval", state.Interval},
+			{"Notify", strings.Join(state.Notify, ", ")},
+			{"Last run", lastRun},
+			{"Log", logPath},
+		}
+		if state.StartedAt != nil {
+			rows[1][1] = fmt.Sprintf("%s (%s)", state.StartedAt.Local().Format("2006-01-02 15:04"), formatTimeAgo(*state.StartedAt))
+		}
+		if state.LastError != "" {
+			rows = append(rows, [2]string{"Last error", state.LastError})
+		}
+
+		if plaintext {
+			for _, row := range rows {
+				fmt.Printf("%s: %s\n", row[0], row[1])
+			}
+			return
+		}
+		fmt.Printf("%s Daemon running\n", color.New(color.FgGreen).Sprint("●"))
+		for _, row := range rows {
+			value := row[1]
+			if row[0] == "Last error" {
+				value = color.New(color.FgRed).Sprint(value)
+			}
+			fmt.Printf("%s %s\n", color.New(color.FgWhite, color.Faint).Sprintf("%-11s", row[0]+":"), value)
+		}
+	},
+}
+
+var daemonRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the daemon in the foreground",
+	Long: `Run the daemon in the foreground, logging to stdout, until interrupted.
+'daemon start' runs this in the background; run it directly under a service
+manager such as systemd or launchd.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		interval, events, err := daemonSettings(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		state, err := loadDaemonState()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if state.PID != 0 && state.PID != os.Getpid() && processRunning(state.PID) {
+			output.Error(fmt.Sprintf("The daemon is already running (pid %d)", state.PID), plaintext, jsonOut)
+			exit(1)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		client.SetResponseCache(nil)
+
+		now := time.Now()
+		d := &daemon{
+			client:          client,
+			events:          make(map[string]bool),
+			state:           daemonState{PID: os.Getpid(), StartedAt: &now, Interval: interval.String(), Notify: events},
+			notifiedThrough: now,
+			log:             os.Stdout,
+		}
+		for _, event := range events {
+			d.events[event] = true
+		}
+		if err := saveDaemonState(d.state); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to save daemon state: %v", err), plaintext, jsonOut)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		d.logf("Started (pid %d), syncing every %s, notifying about %s", d.state.PID, interval, strings.Join(events, ", "))
+		d.loop(ctx, interval)
+
+		d.state.PID = 0
+		if err := saveDaemonState(d.state); err != nil {
+			d.logf("Failed to save daemon state: %v", err)
+		}
+		d.logf("Stopped")
+	},
+}
+
+// daemon is the state of a running daemon
+type daemon struct {
+	client *api.Client
+	events map[string]bool
+	state  daemonState
+	// notifiedThrough is when the newest notification seen was created
+	notifiedThrough time.Time
+	log             io.Writer
+}
+
+// logf writes a timestamped line to the daemon's log
+func (d *daemon) logf(format string, args ...interface{}) {
+	fmt.Fprintf(d.log, "%s %s\n", time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf(format, args...))
+}
+
+// loop runs the daemon every interval until ctx is done
+func (d *daemon) loop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		d.run(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run syncs the cache and sends the notifications due, once, and records
+// how it went for 'daemon status'
+func (d *daemon) run(ctx context.Context) {
+	var failures []string
+	fail := func(err error) {
+		d.logf("%v", err)
+		failures = append(failures, err.Error())
+	}
+
+	if fetched, err := d.sync(ctx); err != nil {
+		fail(err)
+	} else if fetched > 0 {
+		d.logf("Synced %d issues", fetched)
+	}
+	if err := d.notifyInbox(ctx); err != nil {
+		fail(err)
+	}
+	if d.events[daemonReminder] {
+		reminders, err := loadReminders()
+		if err == nil {
+			var notified int
+			if notified, err = notifyReminders(reminders, time.Now()); notified > 0 {
+				d.logf("Notified %d reminders", notified)
+			}
+		}
+		if err != nil {
+			fail(err)
+		}
+	}
+
+	now := time.Now()
+	d.state.LastRunAt = &now
+	d.state.LastError = strings.Join(failures, "; ")
+	if err := saveDaemonState(d.state); err != nil {
+		d.logf("Failed to save daemon state: %v", err)
+	}
+}
+
+// sync brings the local cache up to date. The cache is only held open while
+// syncing, so other linctl commands can use it in between.
+func (d *daemon) sync(ctx context.Context) (int, error) {
+	path, err := cache.Path(auth.Profile())
+	if err != nil {
+		return 0, err
+	}
+	store, err := cache.Open(path, false)
+	if err != nil {
+		return 0, err
+	}
+	defer store.Close()
+	fetched, _, err := syncIssues(ctx, d.client, store, api.DefaultPageSize, nil)
+	return fetched, err
+}
+
+// notifyInbox sends a desktop notification for each unread inbox
+// notification of an enabled type that arrived since the last run
+func (d *daemon) notifyInbox(ctx context.Context) error {
+	page, err := d.client.GetNotifications(ctx, api.DefaultPageSize, "", false)
+	if err != nil {
+		return fmt.Errorf("failed to fetch notifications: %w", err)
+	}
+	newest := d.notifiedThrough
+	notified := 0
+	for _, n := range page.Nodes {
+		if !n.CreatedAt.After(d.notifiedThrough) {
+			continue
+		}
+		if n.CreatedAt.After(newest) {
+			newest = n.CreatedAt
+		}
+		if n.ReadAt != nil || n.SnoozedUntilAt != nil || !d.events[notificationKind(n.Type)] {
+			continue
+		}
+		title, message := describeNotification(n)
+		if err := utils.Notify(title, message); err != nil {
+			return fmt.Errorf("failed to send notification: %w", err)
+		}
+		notified++
+	}
+	d.notifiedThrough = newest
+	if notified > 0 {
+		d.logf("Notified %d inbox notifications", notified)
+	}
+	return nil
+}
+
+// describeNotification is the title and message of an inbox notification's
+// desktop notification
+func describeNotification(n api.Notification) (string, string) {
+	actor := "Someone"
+	if n.Actor != nil && n.Actor.Name != "" {
+		actor = n.Actor.Name
+	}
+	identifier, message := "an issue", ""
+	if n.Issue != nil {
+		identifier, message = n.Issue.Identifier, n.Issue.Title
+	}
+
+	var title string
+	switch notificationKind(n.Type) {
+	case notificationAssigned:
+		title = fmt.Sprintf("%s assigned you %s", actor, identifier)
+	case notificationMentioned:
+		title = fmt.Sprintf("%s mentioned you on %s", actor, identifier)
+	case notificationComment:
+		title = fmt.Sprintf("%s commented on %s", actor, identifier)
+	case notificationStatus:
+		title = fmt.Sprintf("%s changed", identifier)
+		if n.Issue != nil && n.Issue.State != nil {
+			title = fmt.Sprintf("%s moved to %s", identifier, n.Issue.State.Name)
+		}
+	default:
+		title = fmt.Sprintf("%s: %s", identifier, n.Type)
+	}
+	if n.Comment != nil && n.Comment.Body != "" {
+		body, _, _ := strings.Cut(strings.TrimSpace(n.Comment.Body), "\n")
+		message += "\n" + output.Truncate(body, 120)
+	}
+	return title, strings.TrimSpace(message)
+}
+
+// daemonSettings is the daemon's interval and event types, from --interval
+// and --notify, else the daemon.interval and daemon.notify config keys
+func daemonSettings(cmd *cobra.Command) (time.Duration, []string, error) {
+	interval := defaultDaemonInterval
+	if cmd.Flags().Changed("interval") {
+		interval, _ = cmd.Flags().GetDuration("interval")
+	} else if value := viper.GetString("daemon.interval"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid daemon.interval '%s': %v", value, err)
+		}
+		interval = parsed
+	}
+	if interval < minDaemonInterval {
+		return 0, nil, fmt.Errorf("the daemon interval must be at least %s", minDaemonInterval)
+	}
+
+	events := defaultDaemonEvents
+	if cmd.Flags().Changed("notify") {
+		events, _ = cmd.Flags().GetStringSlice("notify")
+	} else if viper.IsSet("daemon.notify") {
+		// 'config set' writes lists as one comma-separated string
+		events = nil
+		for _, entry := range viper.GetStringSlice("daemon.notify") {
+			events = append(events, strings.Split(entry, ",")...)
+		}
+	}
+	var valid []string
+	for _, event := range events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if event == "" || isNoneValue(event) {
+			continue
+		}
+		if !containsString(daemonEventTypes, event) {
+			return 0, nil, fmt.Errorf("unknown event type '%s'; use %s", event, strings.Join(daemonEventTypes, ", "))
+		}
+		if !containsString(valid, event) {
+			valid = append(valid, event)
+		}
+	}
+	return interval, valid, nil
+}
+
+// daemonPath is ~/.linctl/daemon/PROFILE plus ext: .json for the daemon's
+// state and .log for its log
+func daemonPath(ext string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl", "daemon", auth.Profile()+ext), nil
+}
+
+// loadDaemonState reads the active profile's daemon state
+func loadDaemonState() (daemonState, error) {
+	var state daemonState
+	path, err := daemonPath(".json")
+	if err != nil {
+		return state, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read daemon state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse daemon state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// saveDaemonState replaces the active profile's daemon state
+func saveDaemonState(state daemonState) error {
+	path, err := daemonPath(".json")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+	daemonCmd.AddCommand(daemonStartCmd)
+	daemonCmd.AddCommand(daemonStopCmd)
+	daemonCmd.AddCommand(daemonStatusCmd)
+	daemonCmd.AddCommand(daemonRunCmd)
+
+	for _, c := range []*cobra.Command{daemonStartCmd, daemonRunCmd} {
+		c.Flags().Duration("interval", defaultDaemonInterval, "How often to sync and check for notifications (default from daemon.interval)")
+		c.Flags().StringSlice("notify", nil, "Event types to notify about: "+strings.Join(daemonEventTypes, ", ")+", or none (default from daemon.notify)")
+	}
+}
@@ -0,0 +1,26 @@
+//go:build !unix && !windows
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+)
+
+// detachDaemon does nothing where processes can't be detached
+func detachDaemon(c *exec.Cmd) {}
+
+// processRunning reports whether a process with the pid can be found
+func processRunning(pid int) bool {
+	_, err := os.FindProcess(pid)
+	return err == nil
+}
+
+// stopProcess ends the process
+func stopProcess(pid int) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Kill()
+}
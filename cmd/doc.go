@@ -0,0 +1,548 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// documentFrontmatter holds the fields of a document written as markdown, the
+// format 'doc export' writes and 'doc create/update --from-file' reads:
+//
+//	---
+//	title: Onboarding guide
+//	project: Billing v2
+//	---
+//
+//	The content, in markdown.
+//
+// Without frontmatter, a leading "# Heading" line is taken as the title.
+type documentFrontmatter struct {
+	Title   string `yaml:"title"`
+	Project string `yaml:"project"`
+}
+
+var docCmd = &cobra.Command{
+	Use:     "doc",
+	Aliases: []string{"document", "documents"},
+	Short:   "Manage Linear documents",
+	Long: `Read and write Linear documents as markdown.
+
+'doc export' writes a document as markdown with its title (and project) as
+frontmatter, and 'doc update --from-file' reads the same file back, so a
+document can be edited locally and pushed again.
+
+Examples:
+  linctl doc list --project "Billing v2"
+  linctl doc view "Onboarding guide"
+  linctl doc create --from-file guide.md --project "Billing v2"
+  linctl doc export "Onboarding guide" --download-assets
+  linctl doc update "Onboarding guide" --from-file Onboarding_guide.md`,
+}
+
+var docListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List documents, most recently updated first",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		var filter map[string]interface{}
+		if project, _ := cmd.Flags().GetString("project"); project != "" {
+			projectID, err := resolveProjectID(ctx, client, project)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			filter = map[string]interface{}{
+				"project": map[string]interface{}{"id": map[string]interface{}{"eq": projectID}},
+			}
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		docs, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize, MaxResults: limit}, func(ctx context.Context, first int, after string) ([]api.Document, api.PageInfo, error) {
+			page, err := client.GetDocuments(ctx, filter, first, after)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			pageInfo := api.PageInfo{}
+			if page.PageInfo != nil {
+				pageInfo = *page.PageInfo
+			}
+			return page.Nodes, pageInfo, nil
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list documents: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(docs)
+			return
+		}
+		if len(docs) == 0 {
+			output.Info("No documents found", plaintext, jsonOut)
+			return
+		}
+
+		headers := []string{"Title", "Project", "Updated", "By", "ID"}
+		rows := make([][]string, len(docs))
+		for i, doc := range docs {
+			project := ""
+			if doc.Project != nil {
+				project = doc.Project.Name
+			}
+			updatedBy := ""
+			if doc.UpdatedBy != nil {
+				updatedBy = doc.UpdatedBy.Name
+			} else if doc.Creator != nil {
+				updatedBy = doc.Creator.Name
+			}
+			title := doc.Title
+			rows[i] = []string{title, project, formatTimeAgo(doc.UpdatedAt), updatedBy, doc.ID}
+		}
+
+		output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, jsonOut)
+		if !plaintext {
+			fmt.Printf("\n%s %d documents\n", color.New(color.FgGreen).Sprint("✓"), len(docs))
+		}
+	},
+}
+
+var docViewCmd = &cobra.Command{
+	Use:     "view DOCUMENT",
+	Aliases: []string{"get", "show", "read"},
+	Short:   "Read a document with its markdown rendered for the terminal",
+	Long:    `Show a document, given by ID, slug ID, URL, or title, with its content rendered as formatted markdown.`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		doc, err := resolveDocument(context.Background(), client, args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if web, _ := cmd.Flags().GetBool("web"); web {
+			if err := utils.OpenBrowser(doc.URL); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to open browser: %v (URL: %s)", err, doc.URL), plaintext, jsonOut)
+			}
+			if jsonOut {
+				output.JSON(map[string]interface{}{"url": doc.URL, "opened": true})
+			} else {
+				fmt.Println(doc.URL)
+			}
+			return
+		}
+
+		if jsonOut {
+			output.JSON(doc)
+			return
+		}
+
+		// Plaintext output is the markdown itself
+		if raw, _ := cmd.Flags().GetBool("raw"); raw || plaintext {
+			fmt.Print(formatDocumentMarkdown(doc))
+			return
+		}
+
+		fmt.Printf("%s\n\n", color.New(color.FgCyan, color.Bold).Sprint(doc.Title))
+		label := color.New(color.FgWhite, color.Faint)
+		field := func(name, value string) {
+			if value != "" {
+				fmt.Printf("  %s %s\n", label.Sprintf("%-8s", name), value)
+			}
+		}
+		if doc.Project != nil {
+			field("Project", doc.Project.Name)
+		}
+		if doc.Creator != nil {
+			field("Author", doc.Creator.Name)
+		}
+		updated := formatTimeAgo(doc.UpdatedAt)
+		if doc.UpdatedBy != nil {
+			updated += " by " + doc.UpdatedBy.Name
+		}
+		field("Updated", updated)
+		field("URL", color.New(color.FgBlue, color.Underline).Sprint(doc.URL))
+
+		fmt.Println()
+		if strings.TrimSpace(doc.Content) == "" {
+			fmt.Println(label.Sprint("Empty document"))
+		} else {
+			fmt.Print(output.RenderMarkdown(doc.Content))
+		}
+	},
+}
+
+var docCreateCmd = &cobra.Command{
+	Use:     "create [TITLE]",
+	Aliases: []string{"new"},
+	Short:   "Create a document",
+	Long: `Create a document from --content or a markdown file. The title is the
+argument, --title, the file's frontmatter, or its leading "# Heading".
+
+Examples:
+  linctl doc create "Release checklist" --content "- [ ] Tag the release"
+  linctl doc create --from-file guide.md --project "Billing v2"
+  cat notes.md | linctl doc create "Meeting notes" -F -`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		if len(args) == 1 {
+			_ = cmd.Flags().Set("title", args[0])
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		input, err := documentInputFromFlags(ctx, cmd, client)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if title, _ := input["title"].(string); strings.TrimSpace(title) == "" {
+			output.Error("A title is required (argument, --title, or the file's frontmatter or heading)", plaintext, jsonOut)
+			exit(1)
+		}
+
+		doc, err := client.CreateDocument(ctx, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create document: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(doc)
+		} else if plaintext {
+			fmt.Printf("Created document %s\n", doc.Title)
+			fmt.Printf("ID: %s\n", doc.ID)
+			fmt.Printf("URL: %s\n", doc.URL)
+		} else {
+			fmt.Printf("%s Created document %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(doc.Title))
+			fmt.Printf("  %s\n", color.New(color.FgBlue, color.Underline).Sprint(doc.URL))
+		}
+	},
+}
+
+var docUpdateCmd = &cobra.Command{
+	Use:     "update DOCUMENT",
+	Aliases: []string{"edit"},
+	Short:   "Update a document",
+	Long: `Update a document's title, content, or project. With --from-file, the file's
+content replaces the document's, and its frontmatter title and project are
+applied unless --title or --project is given.
+
+Examples:
+  linctl doc update "Onboarding guide" --title "Onboarding"
+  linctl doc update DOC-ID --from-file Onboarding_guide.md`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		doc, err := resolveDocument(ctx, client, args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		input, err := documentInputFromFlags(ctx, cmd, client)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if title, ok := input["title"].(string); ok && title == doc.Title {
+			delete(input, "title")
+		}
+		if len(input) == 0 {
+			output.Error("No updates specified. Use flags to specify what to update.", plaintext, jsonOut)
+			exit(1)
+		}
+
+		doc, err = client.UpdateDocument(ctx, doc.ID, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to update document: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(doc)
+		} else {
+			output.Success(fmt.Sprintf("Updated document %s", doc.Title), plaintext, jsonOut)
+		}
+	},
+}
+
+var docExportCmd = &cobra.Command{
+	Use:   "export DOCUMENT",
+	Short: "Export a document to markdown",
+	Long: `Write a document to a markdown file, with its title and project as
+frontmatter, ready to be edited and applied with 'doc update --from-file'.
+
+With --download-assets, images hosted on linear.app are downloaded into an
+assets/ folder next to the exported file and their links are rewritten to
+relative paths, so the markdown renders offline.
+
+Examples:
+  linctl doc export "Onboarding guide"                 # Write Onboarding_guide.md
+  linctl doc export DOC-ID -o docs/guide.md --download-assets
+  linctl doc export DOC-ID -o -                        # Print to stdout`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+		ctx := context.Background()
+
+		doc, err := resolveDocument(ctx, client, args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		markdown := formatDocumentMarkdown(doc)
+
+		outputPath, _ := cmd.Flags().GetString("output")
+		if outputPath == "" {
+			outputPath = files.SanitizeFilename(doc.Title) + ".md"
+		}
+		toStdout := outputPath == "-"
+
+		downloadAssets, _ := cmd.Flags().GetBool("download-assets")
+		var assetErrors []string
+		downloadedAssets := 0
+		if downloadAssets {
+			baseDir := "."
+			if !toStdout {
+				baseDir = filepath.Dir(outputPath)
+			}
+			markdown, downloadedAssets, assetErrors = mirrorLinearImages(ctx, client.TransferClient(), markdown, baseDir)
+		}
+
+		if toStdout {
+			fmt.Print(markdown)
+			for _, e := range assetErrors {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", e)
+			}
+			return
+		}
+
+		if dir := filepath.Dir(outputPath); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to create output directory: %v", err), plaintext, jsonOut)
+			}
+		}
+		if err := os.WriteFile(outputPath, []byte(markdown), 0644); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to write %s: %v", outputPath, err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			summary := map[string]interface{}{
+				"document": doc.ID,
+				"output":   outputPath,
+			}
+			if downloadAssets {
+				summary["assets"] = downloadedAssets
+			}
+			if len(assetErrors) > 0 {
+				summary["errors"] = assetErrors
+			}
+			output.JSON(summary)
+		} else if plaintext {
+			fmt.Printf("Exported %s to %s\n", doc.Title, outputPath)
+			if downloadAssets {
+				fmt.Printf("Downloaded %d asset(s)\n", downloadedAssets)
+			}
+			for _, e := range assetErrors {
+				fmt.Printf("Error: %s\n", e)
+			}
+		} else {
+			fmt.Printf("%s Exported %s to %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(doc.Title),
+				outputPath)
+			if downloadAssets {
+				fmt.Printf("  Downloaded %d asset(s)\n", downloadedAssets)
+			}
+			for _, e := range assetErrors {
+				fmt.Printf("  %s %s\n", color.New(color.FgRed).Sprint("✗"), e)
+			}
+		}
+	},
+}
+
+// resolveDocument finds a document by ID, slug ID, URL, or title
+func resolveDocument(ctx context.Context, client *api.Client, ref string) (*api.Document, error) {
+	if slugID, ok := refFromLinearURL(ref); ok {
+		ref = slugID
+	}
+	if doc, err := client.GetDocument(ctx, ref); err == nil && doc.ID != "" {
+		return doc, nil
+	}
+
+	filter := map[string]interface{}{
+		"title": map[string]interface{}{"eqIgnoreCase": ref},
+	}
+	docs, err := client.GetDocuments(ctx, filter, 2, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up document '%s': %v", ref, err)
+	}
+	switch len(docs.Nodes) {
+	case 0:
+		return nil, fmt.Errorf("document not found: %s", ref)
+	case 1:
+		doc, err := client.GetDocument(ctx, docs.Nodes[0].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get document: %v", err)
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("multiple documents titled '%s'; use the document ID instead", ref)
+	}
+}
+
+// formatDocumentMarkdown writes a document as markdown with frontmatter
+func formatDocumentMarkdown(doc *api.Document) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.WriteString("title: " + yamlScalar(doc.Title) + "\n")
+	if doc.Project != nil {
+		b.WriteString("project: " + yamlScalar(doc.Project.Name) + "\n")
+	}
+	b.WriteString("---\n\n")
+	if content := strings.TrimRight(doc.Content, "\n"); content != "" {
+		b.WriteString(content + "\n")
+	}
+	return b.String()
+}
+
+// parseDocumentMarkdown reads a document written as markdown. Without
+// frontmatter, a leading "# Heading" line becomes the title.
+func parseDocumentMarkdown(content string) (documentFrontmatter, string, error) {
+	var front documentFrontmatter
+	frontText, body, ok := splitFrontmatter(content)
+	if !ok {
+		first, rest, _ := strings.Cut(strings.TrimLeft(content, "\ufeff\n"), "\n")
+		if strings.HasPrefix(first, "# ") {
+			front.Title = strings.TrimSpace(strings.TrimPrefix(first, "# "))
+			return front, strings.TrimSpace(rest), nil
+		}
+		return front, strings.TrimSpace(content), nil
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(frontText)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&front); err != nil && !errors.Is(err, io.EOF) {
+		return front, "", fmt.Errorf("invalid frontmatter: %v", err)
+	}
+	return front, strings.TrimSpace(body), nil
+}
+
+// documentInputFromFlags builds a document create/update input from the flags
+// that were set and the --from-file document
+func documentInputFromFlags(ctx context.Context, cmd *cobra.Command, client *api.Client) (map[string]interface{}, error) {
+	input := make(map[string]interface{})
+
+	if cmd.Flags().Changed("content") && cmd.Flags().Changed("from-file") {
+		return nil, fmt.Errorf("use either --content or --from-file, not both")
+	}
+
+	title, _ := cmd.Flags().GetString("title")
+	project, _ := cmd.Flags().GetString("project")
+
+	if cmd.Flags().Changed("content") {
+		content, _ := cmd.Flags().GetString("content")
+		input["content"] = content
+	}
+	if path, _ := cmd.Flags().GetString("from-file"); path != "" {
+		markdown, err := readMarkdownInput(path)
+		if err != nil {
+			return nil, err
+		}
+		front, body, err := parseDocumentMarkdown(markdown)
+		if err != nil {
+			if path == "-" {
+				path = "stdin"
+			}
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		input["content"] = body
+		if !cmd.Flags().Changed("title") {
+			title = front.Title
+		}
+		if !cmd.Flags().Changed("project") {
+			project = front.Project
+		}
+	}
+
+	if strings.TrimSpace(title) != "" {
+		input["title"] = strings.TrimSpace(title)
+	}
+	if project != "" {
+		projectID, err := resolveProjectID(ctx, client, project)
+		if err != nil {
+			return nil, err
+		}
+		input["projectId"] = projectID
+	}
+	if cmd.Flags().Changed("icon") {
+		icon, _ := cmd.Flags().GetString("icon")
+		input["icon"] = icon
+	}
+
+	return input, nil
+}
+
+func init() {
+	rootCmd.AddCommand(docCmd)
+	docCmd.AddCommand(docListCmd)
+	docCmd.AddCommand(docViewCmd)
+	docCmd.AddCommand(docCreateCmd)
+	docCmd.AddCommand(docUpdateCmd)
+	docCmd.AddCommand(docExportCmd)
+
+	docListCmd.Flags().StringP("project", "P", "", "Only documents of this project (name or ID)")
+	docListCmd.Flags().IntP("limit", "l", 50, "Maximum number of documents to list")
+
+	docViewCmd.Flags().Bool("raw", false, "Print the markdown source instead of rendering it")
+	docViewCmd.Flags().Bool("web", false, "Open the document in the browser instead")
+
+	for _, c := range []*cobra.Command{docCreateCmd, docUpdateCmd} {
+		c.Flags().String("title", "", "Document title")
+		c.Flags().StringP("content", "c", "", "Document content (markdown)")
+		c.Flags().StringP("from-file", "F", "", "Read the content, and any frontmatter title and project, from a markdown file ('-' for stdin)")
+		c.Flags().StringP("project", "P", "", "Project the document belongs to (name or ID)")
+		c.Flags().String("icon", "", "Document icon (emoji or icon name)")
+	}
+
+	docExportCmd.Flags().StringP("output", "o", "", "Output file (default: <title>.md, '-' for stdout)")
+	docExportCmd.Flags().Bool("download-assets", false, "Download linear.app-hosted images into assets/ and rewrite links")
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/dorkitude/linctl/pkg/output"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var readmeContents string
@@ -27,6 +29,12 @@ Examples:
   linctl docs | less            # View with pager
   linctl docs > linctl-docs.md  # Save to file`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if viper.GetBool("json") {
+			output.JSON(map[string]interface{}{
+				"docs": readmeContents,
+			})
+			return
+		}
 		fmt.Print(readmeContents)
 	},
 }
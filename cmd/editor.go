@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// issueEditorHelp heads the frontmatter of an issue opened in the editor
+const issueEditorHelp = `# Edit the fields below and write the description after the closing ---.
+# Leave a field empty to unset it. Lines starting with # are ignored.
+# Save an empty file to abort.
+`
+
+// commentEditorMarker starts the instructions below a comment opened in the
+// editor; it and everything after it are dropped
+const commentEditorMarker = "<!-- linctl: write the comment above this line."
+
+// editorCommand is the user's editor: $VISUAL, $EDITOR, the "editor" setting,
+// then vi (notepad on Windows)
+func editorCommand() string {
+	for _, editor := range []string{os.Getenv("VISUAL"), os.Getenv("EDITOR"), viper.GetString("editor")} {
+		if strings.TrimSpace(editor) != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// editText opens content in the user's editor and returns the saved text.
+// pattern names the temporary file, as for os.CreateTemp.
+func editText(content, pattern string) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %v", err)
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write temporary file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %v", err)
+	}
+
+	if err := runEditor(path); err != nil {
+		return "", err
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %v", err)
+	}
+	return strings.ReplaceAll(string(edited), "\r\n", "\n"), nil
+}
+
+// runEditor opens a file in the user's editor and waits for it to close
+func runEditor(path string) error {
+	editor := editorCommand()
+	var run *exec.Cmd
+	if runtime.GOOS == "windows" {
+		fields := strings.Fields(editor)
+		run = exec.Command(fields[0], append(fields[1:], path)...)
+	} else {
+		// Through the shell, so editors given with arguments ("code --wait") work
+		run = exec.Command("sh", "-c", editor+` "$1"`, "sh", path)
+	}
+	run.Stdin = os.Stdin
+	run.Stdout = os.Stderr
+	run.Stderr = os.Stderr
+	if err := run.Run(); err != nil {
+		return fmt.Errorf("editor '%s' failed: %v", editor, err)
+	}
+	return nil
+}
+
+// editIssueFlags opens the issue in the editor, like 'git commit', and sets
+// the command's flags from the result. issue is nil when creating; when
+// updating, only the fields that were changed are set. Flags already given
+// pre-fill the document.
+func editIssueFlags(cmd *cobra.Command, issue *api.Issue) error {
+	if cmd.Flags().Changed("from-file") {
+		return fmt.Errorf("--editor cannot be used with --from-file")
+	}
+
+	front, description := issueDocumentFromIssue(issue)
+	keys := issueCreateDocumentFields
+	if issue != nil {
+		keys = issueUpdateDocumentFields
+	}
+
+	// Flags given on the command line win over the issue's current values
+	for _, key := range keys {
+		if !cmd.Flags().Changed(issueDocumentFlag(key)) {
+			continue
+		}
+		value := cmd.Flags().Lookup(issueDocumentFlag(key)).Value.String()
+		switch key {
+		case "priority":
+			value = priorityToString(getPriorityFlag(cmd))
+		case "estimate":
+			if clearsEstimate(value) {
+				value = ""
+			}
+		}
+		front.set(key, value)
+	}
+	if issue == nil {
+		if !cmd.Flags().Changed("priority") {
+			front.Priority = priorityToString(3)
+		}
+		if assignMe, _ := cmd.Flags().GetBool("assign-me"); assignMe {
+			front.Assignee = "@me"
+		}
+	}
+	if cmd.Flags().Changed("description") {
+		description, _ = cmd.Flags().GetString("description")
+	}
+
+	original := formatIssueDocument(front, keys, description, issueEditorHelp)
+	edited, err := editText(original, "linctl-issue-*.md")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(edited) == "" {
+		return fmt.Errorf("Aborting: the file was empty")
+	}
+	if issue != nil && edited == original {
+		return fmt.Errorf("Aborting: nothing was changed")
+	}
+
+	result, body, _, ok, err := parseIssueDocument(edited)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("Aborting: the frontmatter between the --- lines is missing")
+	}
+	if issue == nil && strings.TrimSpace(result.Title) == "" {
+		return fmt.Errorf("Aborting: the issue has no title")
+	}
+
+	err = applyIssueDocument(cmd, result, keys, issue == nil, func(key string) bool {
+		return issue != nil && strings.TrimSpace(result.value(key)) == front.value(key)
+	})
+	if err != nil {
+		return err
+	}
+	if issue == nil || body != strings.TrimSpace(description) {
+		return cmd.Flags().Set("description", body)
+	}
+	return nil
+}
+
+// editCommentBody opens a comment in the editor, pre-filled with --body or
+// existing, and sets --body from the result. An empty comment aborts.
+func editCommentBody(cmd *cobra.Command, existing string) error {
+	if cmd.Flags().Changed("body-file") {
+		return fmt.Errorf("--editor cannot be used with --body-file")
+	}
+	body, _ := cmd.Flags().GetString("body")
+	if body == "" {
+		body = existing
+	}
+
+	content := strings.TrimRight(body, "\n") + "\n\n" + commentEditorMarker + "\nEverything from here on is ignored. Save an empty comment to abort. -->\n"
+	edited, err := editText(content, "linctl-comment-*.md")
+	if err != nil {
+		return err
+	}
+	if i := strings.Index(edited, commentEditorMarker); i >= 0 {
+		edited = edited[:i]
+	}
+	edited = strings.TrimSpace(edited)
+	if edited == "" {
+		return fmt.Errorf("Aborting: the comment was empty")
+	}
+	return cmd.Flags().Set("body", edited)
+}
+
+// runIssueEditor handles --editor for issue create (issueID "") and update,
+// exiting on errors and aborts
+func runIssueEditor(cmd *cobra.Command, issueID string, plaintext, jsonOut bool) {
+	if useEditor, _ := cmd.Flags().GetBool("editor"); !useEditor {
+		return
+	}
+
+	var issue *api.Issue
+	if issueID != "" {
+		var err error
+		issue, err = authenticatedClient(plaintext, jsonOut).GetIssue(context.Background(), issueID)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
+		}
+	}
+
+	if err := editIssueFlags(cmd, issue); err != nil {
+		exitWithError(err, err.Error(), plaintext, jsonOut)
+	}
+}
+
+// runCommentEditor handles --editor for comment commands, exiting on errors and aborts
+func runCommentEditor(cmd *cobra.Command, existing string, plaintext, jsonOut bool) {
+	if useEditor, _ := cmd.Flags().GetBool("editor"); !useEditor {
+		return
+	}
+	if err := editCommentBody(cmd, existing); err != nil {
+		exitWithError(err, err.Error(), plaintext, jsonOut)
+	}
+}
+
+func init() {
+	issueCreateCmd.Flags().BoolP("editor", "e", false, "Write the issue in $EDITOR (fields as frontmatter, description as markdown)")
+	issueUpdateCmd.Flags().BoolP("editor", "e", false, "Edit the issue's fields and description in $EDITOR")
+	for _, c := range []*cobra.Command{commentCreateCmd, commentReplyCmd, commentEditCmd} {
+		c.Flags().BoolP("editor", "e", false, "Write the comment in $EDITOR")
+	}
+}
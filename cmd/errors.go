@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Exit codes that let scripts tell failures apart. They are part of linctl's
+// interface, listed in the README: add new ones, never renumber them.
+const (
+	exitError       = 1 // any other failure
+	exitUsage       = 2 // invalid command line: unknown command or flag, wrong arguments
+	exitNotFound    = 3
+	exitAuth        = 4 // not authenticated, or not permitted
+	exitRateLimited = 5
+	exitValidation  = 6 // Linear rejected the input
+	exitPartial     = 7 // a command acting on several items failed for some of them
+	exitUnavailable = 8 // Linear could not be reached, or failed with a server error
+)
+
+// Error codes in --json error objects for failures that aren't API errors;
+// API errors use their api.ErrorKind
+const (
+	errorCodeUsage   = "usage"
+	errorCodePartial = "partial_failure"
+	errorCodeNetwork = "network"
+)
+
+// exitCode is the exit code for err, by the kind of API error it wraps
+func exitCode(err error) int {
+	switch api.ErrorKindOf(err) {
+	case api.KindNotFound:
+		return exitNotFound
+	case api.KindAuthentication, api.KindPermissionDenied:
+		return exitAuth
+	case api.KindRateLimited:
+		return exitRateLimited
+	case api.KindValidation:
+		return exitValidation
+	case api.KindServer:
+		return exitUnavailable
+	}
+	if isNetworkError(err) {
+		return exitUnavailable
+	}
+	return exitError
+}
+
+// exitWithError prints message and exits with the code for err. With --json
+// the error object, written to stderr, also holds the error's code, and its
+// GraphQL path, input field, and HTTP status when known, so scripts can
+// branch on them.
+func exitWithError(err error, message string, plaintext, jsonOut bool) {
+	output.ErrorDetail(message, errorDetails(err), plaintext, jsonOut)
+	exit(exitCode(err))
+}
+
+// exitNotAuthenticated reports that there are no stored credentials and
+// exits with exitAuth
+func exitNotAuthenticated(plaintext, jsonOut bool) {
+	output.ErrorDetail("Not authenticated. Run 'linctl auth' first.", map[string]interface{}{"code": api.KindAuthentication}, plaintext, jsonOut)
+	exit(exitAuth)
+}
+
+// exitOnFailures ends a command that acted on several items when any of them
+// failed: with exitPartial when others succeeded, and exitError when none did.
+// The command has already reported each failure.
+func exitOnFailures(succeeded, failed int) {
+	if failed == 0 {
+		return
+	}
+	code, message := exitError, fmt.Sprintf("all %d item(s) failed", failed)
+	if succeeded > 0 {
+		code, message = exitPartial, fmt.Sprintf("%d of %d item(s) failed", failed, succeeded+failed)
+	}
+	if viper.GetBool("json") {
+		output.ErrorDetail(message, map[string]interface{}{
+			"code":      errorCodePartial,
+			"succeeded": succeeded,
+			"failed":    failed,
+		}, false, true)
+	}
+	exit(code)
+}
+
+// exitUsageError reports an invalid command line and exits with exitUsage.
+// Cobra's own message is silenced so --json gets an error object instead.
+func exitUsageError(cmd *cobra.Command, err error, args []string) {
+	if jsonRequested(args) {
+		output.ErrorDetail(err.Error(), map[string]interface{}{"code": errorCodeUsage}, false, true)
+	} else {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Run '%s --help' for usage.\n", cmd.CommandPath())
+	}
+	exit(exitUsage)
+}
+
+// jsonRequested reports whether JSON output was asked for, also when the
+// command line failed to parse and the flags were never bound
+func jsonRequested(args []string) bool {
+	if viper.GetBool("json") || viper.GetBool("jsonl") {
+		return true
+	}
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "--json", "-j", "--jsonl", "--json=true", "--jsonl=true":
+			return true
+		}
+	}
+	return false
+}
+
+// isNetworkError reports whether err is a failure to reach the server (DNS,
+// connection refused, timeout) rather than a response from it
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	var urlErr *url.Error
+	return errors.As(err, &netErr) || errors.As(err, &urlErr)
+}
+
+// errorDetails describes an error for JSON output: its code, and for API
+// errors the GraphQL path, input field, and HTTP status when known. Other
+// errors get none and are reported with the generic "error" code.
+func errorDetails(err error) map[string]interface{} {
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		if isNetworkError(err) {
+			return map[string]interface{}{"code": errorCodeNetwork}
+		}
+		return nil
+	}
+	details := map[string]interface{}{"code": apiErr.Kind}
+	if len(apiErr.Path) > 0 {
+		details["path"] = apiErr.Path
+	}
+	if apiErr.Field != "" {
+		details["field"] = apiErr.Field
+	}
+	if apiErr.Status != 0 {
+		details["status"] = apiErr.Status
+	}
+	return details
+}
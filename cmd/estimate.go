@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// estimateScale is the points a team's estimation type allows; extended adds
+// the larger values teams can opt into
+type estimateScale struct {
+	points   []int
+	extended []int
+}
+
+// estimateScales are Linear's estimation types. T-shirt sizes are stored as
+// points, XS=1 through XXXL=21.
+var estimateScales = map[string]estimateScale{
+	"exponential": {points: []int{1, 2, 4, 8, 16}, extended: []int{32, 64}},
+	"fibonacci":   {points: []int{1, 2, 3, 5, 8}, extended: []int{13, 21}},
+	"linear":      {points: []int{1, 2, 3, 4, 5}, extended: []int{6, 7}},
+	"tShirt":      {points: []int{1, 2, 3, 5, 8}, extended: []int{13, 21}},
+}
+
+// tShirtSizes name the points of the tShirt scale, in order
+var tShirtSizes = []string{"XS", "S", "M", "L", "XL", "XXL", "XXXL"}
+
+// priorityFlag is a --priority value given as a number (0-4) or a name (none,
+// urgent, high, medium or normal, low). -1 means not set.
+type priorityFlag int
+
+func newPriorityFlag(value int) *priorityFlag {
+	p := priorityFlag(value)
+	return &p
+}
+
+func (p *priorityFlag) String() string {
+	if *p < 0 {
+		return ""
+	}
+	return strings.ToLower(priorityToString(int(*p)))
+}
+
+func (p *priorityFlag) Set(value string) error {
+	priority, err := parsePriority(value)
+	if err != nil {
+		return err
+	}
+	*p = priorityFlag(priority)
+	return nil
+}
+
+func (p *priorityFlag) Type() string {
+	return "priority"
+}
+
+// getPriorityFlag returns the numeric value of a command's --priority flag
+func getPriorityFlag(cmd *cobra.Command) int {
+	if flag := cmd.Flags().Lookup("priority"); flag != nil {
+		if priority, ok := flag.Value.(*priorityFlag); ok {
+			return int(*priority)
+		}
+	}
+	return -1
+}
+
+// clearsEstimate reports whether an --estimate value removes the estimate
+func clearsEstimate(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "0", "none":
+		return true
+	}
+	return false
+}
+
+// resolveEstimate turns an --estimate value into points, checking it against
+// the team's estimation scale. T-shirt teams may give sizes (M) or points.
+func resolveEstimate(team *api.Team, value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if team.IssueEstimationType == "notUsed" {
+		return 0, fmt.Errorf("team %s does not use estimates", team.Key)
+	}
+
+	scale, known := estimateScales[team.IssueEstimationType]
+	allowed := scale.points
+	if team.IssueEstimationExtended {
+		allowed = append(append([]int{}, scale.points...), scale.extended...)
+	}
+
+	points, err := strconv.Atoi(value)
+	if err != nil && team.IssueEstimationType == "tShirt" {
+		for i, size := range tShirtSizes {
+			if strings.EqualFold(value, size) && i < len(allowed) {
+				return allowed[i], nil
+			}
+		}
+	}
+	if err != nil || points < 0 {
+		return 0, fmt.Errorf("invalid estimate '%s' (allowed on team %s: %s)", value, team.Key, describeEstimateScale(team.IssueEstimationType, allowed))
+	}
+	if !known {
+		return points, nil
+	}
+	for _, p := range allowed {
+		if p == points {
+			return points, nil
+		}
+	}
+	return 0, fmt.Errorf("estimate %d is not on team %s's %s scale (allowed: %s)", points, team.Key, team.IssueEstimationType, describeEstimateScale(team.IssueEstimationType, allowed))
+}
+
+// describeEstimateScale lists the allowed estimates, by size for t-shirt teams
+func describeEstimateScale(estimationType string, allowed []int) string {
+	if len(allowed) == 0 {
+		return "any whole number"
+	}
+	names := make([]string, len(allowed))
+	for i, points := range allowed {
+		names[i] = strconv.Itoa(points)
+		if estimationType == "tShirt" && i < len(tShirtSizes) {
+			names[i] = fmt.Sprintf("%s=%d", tShirtSizes[i], points)
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,425 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// exportIssuePageSize keeps each page of issues (with their comments and
+// history) under Linear's query complexity limit
+const exportIssuePageSize = 50
+
+// exportManifest describes a backup; it is written to manifest.json
+type exportManifest struct {
+	ExportedAt      time.Time      `json:"exportedAt"`
+	Version         string         `json:"linctlVersion"`
+	Teams           []string       `json:"teams,omitempty"`
+	IncludeArchived bool           `json:"includeArchived"`
+	Counts          map[string]int `json:"counts"`
+	Errors          []string       `json:"errors,omitempty"`
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Back up the workspace to JSON files",
+	Long: `Export teams, users, labels, projects, cycles, and issues (with their
+comments, history, and attachments) to JSON files in --out:
+
+  manifest.json   When and what was exported, with counts
+  teams.json, users.json, labels.json, projects.json, cycles.json
+  issues.json     Every issue, oldest first
+  issues/*.md     One markdown file per issue (with --markdown)
+  assets/         Files uploaded to Linear, per issue (with --download-assets)
+  assets.json     Original URL -> local path of every downloaded asset
+
+--team limits the issues and cycles exported; the other files always cover the
+whole workspace. Requests are paginated and retried when rate limited (see
+--max-retries), so large workspaces take a while.
+
+Examples:
+  linctl export --out backup/
+  linctl export --out backup/ --markdown --download-assets
+  linctl export --out eng-backup/ --team ENG --include-archived`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{noDefaultTeamAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		outDir, _ := cmd.Flags().GetString("out")
+		teamKeys, _ := cmd.Flags().GetString("team")
+		writeMarkdown, _ := cmd.Flags().GetBool("markdown")
+		downloadAssets, _ := cmd.Flags().GetBool("download-assets")
+		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+		client := api.NewClient(authHeader)
+		// A backup must reflect the workspace now, not cached responses
+		client.SetResponseCache(nil)
+		ctx := context.Background()
+
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create %s: %v", outDir, err), plaintext, jsonOut)
+		}
+
+		manifest := exportManifest{
+			ExportedAt:      time.Now().UTC(),
+			Version:         version,
+			IncludeArchived: includeArchived,
+			Counts:          map[string]int{},
+		}
+		if teamKeys != "" {
+			manifest.Teams = strings.Split(teamKeys, ",")
+		}
+
+		// progress reports each step on stderr so it never mixes with --json output
+		progress := func(format string, a ...interface{}) {
+			if !jsonOut && !plaintext {
+				fmt.Fprintf(os.Stderr, format, a...)
+			}
+		}
+		fail := func(what string, err error) {
+			progress("\n")
+			exitWithError(err, fmt.Sprintf("Failed to export %s: %v", what, err), plaintext, jsonOut)
+		}
+		pageAll := api.PaginateOptions{PageSize: api.MaxPageSize}
+
+		progress("Exporting teams... ")
+		teams, _, err := api.Paginate(ctx, pageAll, func(ctx context.Context, first int, after string) ([]api.Team, api.PageInfo, error) {
+			page, err := client.GetTeams(ctx, first, after, "")
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err == nil {
+			err = writeExportJSON(outDir, "teams.json", teams)
+		}
+		if err != nil {
+			fail("teams", err)
+		}
+		manifest.Counts["teams"] = len(teams)
+		progress("%d\n", len(teams))
+
+		progress("Exporting users... ")
+		users, _, err := api.Paginate(ctx, pageAll, func(ctx context.Context, first int, after string) ([]api.User, api.PageInfo, error) {
+			page, err := client.GetUsers(ctx, first, after, "")
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err == nil {
+			err = writeExportJSON(outDir, "users.json", users)
+		}
+		if err != nil {
+			fail("users", err)
+		}
+		manifest.Counts["users"] = len(users)
+		progress("%d\n", len(users))
+
+		progress("Exporting labels... ")
+		labels, _, err := api.Paginate(ctx, pageAll, client.GetLabelsPage)
+		if err == nil {
+			err = writeExportJSON(outDir, "labels.json", labels)
+		}
+		if err != nil {
+			fail("labels", err)
+		}
+		manifest.Counts["labels"] = len(labels)
+		progress("%d\n", len(labels))
+
+		progress("Exporting projects... ")
+		projects, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: 100}, func(ctx context.Context, first int, after string) ([]api.Project, api.PageInfo, error) {
+			page, err := client.GetProjects(ctx, nil, first, after, "")
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err == nil {
+			err = writeExportJSON(outDir, "projects.json", projects)
+		}
+		if err != nil {
+			fail("projects", err)
+		}
+		manifest.Counts["projects"] = len(projects)
+		progress("%d\n", len(projects))
+
+		progress("Exporting cycles... ")
+		cycles, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: 100}, func(ctx context.Context, first int, after string) ([]api.Cycle, api.PageInfo, error) {
+			page, err := client.GetCycles(ctx, first, after)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err == nil {
+			cycles = filterExportCycles(cycles, manifest.Teams)
+			err = writeExportJSON(outDir, "cycles.json", cycles)
+		}
+		if err != nil {
+			fail("cycles", err)
+		}
+		manifest.Counts["cycles"] = len(cycles)
+		progress("%d\n", len(cycles))
+
+		var issueFilter map[string]interface{}
+		if len(manifest.Teams) > 0 {
+			issueFilter = map[string]interface{}{
+				"team": map[string]interface{}{"key": map[string]interface{}{"in": manifest.Teams}},
+			}
+		}
+		progress("Exporting issues... ")
+		bar := output.NewProgress("Exporting issues...", 0)
+		issues, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: exportIssuePageSize}, func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+			page, err := client.GetExportIssues(ctx, issueFilter, first, after, includeArchived)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			for i := range page.Nodes {
+				if err := completeExportIssue(ctx, client, &page.Nodes[i]); err != nil {
+					return nil, api.PageInfo{}, fmt.Errorf("%s: %v", page.Nodes[i].Identifier, err)
+				}
+			}
+			bar.Add(len(page.Nodes))
+			return page.Nodes, page.PageInfo, nil
+		})
+		bar.Done()
+		if err != nil {
+			fail("issues", err)
+		}
+		progress("\rExporting issues... %d\n", len(issues))
+		manifest.Counts["issues"] = len(issues)
+
+		assets := map[string]string{}
+		if downloadAssets {
+			progress("Downloading assets... ")
+			bar := output.NewProgress("Downloading assets...", len(issues))
+			for _, issue := range issues {
+				downloaded, errs := downloadExportAssets(ctx, client.TransferClient(), issue, outDir, concurrency)
+				for url, path := range downloaded {
+					assets[url] = path
+				}
+				manifest.Errors = append(manifest.Errors, errs...)
+				bar.Add(1)
+			}
+			bar.Done()
+			if err := writeExportJSON(outDir, "assets.json", assets); err != nil {
+				fail("assets", err)
+			}
+			manifest.Counts["assets"] = len(assets)
+			progress("\rDownloading assets... %d\n", len(assets))
+		}
+
+		if err := writeExportJSON(outDir, "issues.json", issues); err != nil {
+			fail("issues", err)
+		}
+
+		if writeMarkdown {
+			progress("Writing markdown... ")
+			issuesDir := filepath.Join(outDir, "issues")
+			if err := os.MkdirAll(issuesDir, 0755); err != nil {
+				fail("markdown", err)
+			}
+			// Asset paths are relative to the export root; markdown lives one level down
+			replacements := make(map[string]string, len(assets))
+			for url, path := range assets {
+				replacements[url] = "../" + path
+			}
+			for i := range issues {
+				var comments []api.Comment
+				if issues[i].Comments != nil {
+					comments = issues[i].Comments.Nodes
+				}
+				markdown := files.RewriteImageURLs(renderIssueMarkdown(&issues[i], comments), replacements)
+				path := filepath.Join(issuesDir, issues[i].Identifier+".md")
+				if err := os.WriteFile(path, []byte(markdown), 0644); err != nil {
+					fail("markdown", err)
+				}
+			}
+			progress("%d\n", len(issues))
+		}
+
+		if err := writeExportJSON(outDir, "manifest.json", manifest); err != nil {
+			fail("manifest", err)
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"out":    outDir,
+				"counts": manifest.Counts,
+			}
+			if len(manifest.Errors) > 0 {
+				result["errors"] = manifest.Errors
+			}
+			output.JSON(result)
+		} else if plaintext {
+			fmt.Printf("Exported to %s\n", outDir)
+			for _, name := range []string{"teams", "users", "labels", "projects", "cycles", "issues", "assets"} {
+				if count, ok := manifest.Counts[name]; ok {
+					fmt.Printf("%s\t%d\n", name, count)
+				}
+			}
+			for _, e := range manifest.Errors {
+				fmt.Printf("Error: %s\n", e)
+			}
+		} else {
+			fmt.Printf("%s Exported %d issues to %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				manifest.Counts["issues"],
+				color.New(color.FgCyan, color.Bold).Sprint(outDir))
+			for _, e := range manifest.Errors {
+				fmt.Printf("  %s %s\n", color.New(color.FgRed).Sprint("✗"), e)
+			}
+		}
+	},
+}
+
+// completeExportIssue pages through comments and history beyond what the
+// issue page included
+func completeExportIssue(ctx context.Context, client *api.Client, issue *api.Issue) error {
+	pageAll := api.PaginateOptions{PageSize: api.MaxPageSize}
+
+	if issue.Comments != nil && issue.Comments.PageInfo.HasNextPage {
+		comments, _, err := api.Paginate(ctx, pageAll, func(ctx context.Context, first int, after string) ([]api.Comment, api.PageInfo, error) {
+			page, err := client.GetIssueComments(ctx, issue.ID, first, after, "createdAt")
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get comments: %v", err)
+		}
+		issue.Comments = &api.Comments{Nodes: comments}
+	}
+
+	if issue.History != nil && issue.History.PageInfo != nil && issue.History.PageInfo.HasNextPage {
+		history, _, err := api.Paginate(ctx, pageAll, func(ctx context.Context, first int, after string) ([]api.IssueHistoryEntry, api.PageInfo, error) {
+			page, err := client.GetIssueHistory(ctx, issue.ID, first, after)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			if page.PageInfo == nil {
+				return page.Nodes, api.PageInfo{}, nil
+			}
+			return page.Nodes, *page.PageInfo, nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get history: %v", err)
+		}
+		issue.History = &api.IssueHistory{Nodes: history}
+	}
+	return nil
+}
+
+// filterExportCycles keeps the cycles of the given teams (all when teams is empty)
+func filterExportCycles(cycles []api.Cycle, teams []string) []api.Cycle {
+	if len(teams) == 0 {
+		return cycles
+	}
+	var kept []api.Cycle
+	for _, cycle := range cycles {
+		for _, key := range teams {
+			if cycle.Team != nil && strings.EqualFold(cycle.Team.Key, key) {
+				kept = append(kept, cycle)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// downloadExportAssets mirrors the files an issue's description, comments, and
+// attachments have uploaded to Linear into assets/IDENTIFIER/. It returns the
+// original URL -> path (relative to outDir) of every file downloaded.
+func downloadExportAssets(ctx context.Context, httpClient *http.Client, issue api.Issue, outDir string, workers int) (map[string]string, []string) {
+	var assets []files.ImageInfo
+	seen := map[string]bool{}
+	add := func(asset files.ImageInfo) {
+		if asset.IsLinearURL && !seen[asset.URL] {
+			seen[asset.URL] = true
+			assets = append(assets, asset)
+		}
+	}
+
+	for _, img := range files.ExtractImagesFromMarkdown(issue.Description) {
+		add(img)
+	}
+	if issue.Comments != nil {
+		for _, comment := range issue.Comments.Nodes {
+			for _, img := range files.ExtractImagesFromMarkdown(comment.Body) {
+				add(img)
+			}
+		}
+	}
+	if issue.Attachments != nil {
+		for _, attachment := range issue.Attachments.Nodes {
+			if strings.Contains(attachment.URL, "uploads.linear.app") {
+				add(files.ImageInfo{URL: attachment.URL, AltText: attachment.Title, IsLinearURL: true})
+			}
+		}
+	}
+	if len(assets) == 0 {
+		return nil, nil
+	}
+
+	results, _ := files.DownloadImages(ctx, assets, filepath.Join(outDir, "assets", issue.Identifier), files.DownloadOptions{
+		Workers: workers,
+		Client:  httpClient,
+	})
+
+	downloaded := map[string]string{}
+	var errs []string
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", issue.Identifier, result.Err))
+			continue
+		}
+		rel, err := filepath.Rel(outDir, result.Path)
+		if err != nil {
+			rel = result.Path
+		}
+		downloaded[result.Image.URL] = filepath.ToSlash(rel)
+	}
+	return downloaded, errs
+}
+
+// writeExportJSON writes v as indented JSON to dir/name
+func writeExportJSON(dir, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name), append(data, '\n'), 0644)
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringP("out", "o", "linctl-export", "Directory to write the backup to")
+	exportCmd.Flags().StringP("team", "t", "", "Only export issues and cycles of these teams (comma-separated keys)")
+	exportCmd.Flags().Bool("markdown", false, "Also write each issue as markdown to issues/IDENTIFIER.md")
+	exportCmd.Flags().Bool("download-assets", false, "Download files uploaded to Linear into assets/")
+	exportCmd.Flags().Bool("include-archived", false, "Include archived issues")
+	exportCmd.Flags().Int("concurrency", 4, "Number of assets downloaded in parallel")
+}
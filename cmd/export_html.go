@@ -0,0 +1,602 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// htmlStateOrder sorts issues on index pages: active work first, closed work last
+var htmlStateOrder = map[string]int{
+	"started":   0,
+	"unstarted": 1,
+	"triage":    2,
+	"backlog":   3,
+	"completed": 4,
+	"canceled":  5,
+}
+
+// htmlSite is everything the page templates render
+type htmlSite struct {
+	Title       string
+	GeneratedAt time.Time
+	Teams       []string
+	Issues      []*api.Issue
+	Projects    []*htmlProject
+	// Lookups for cross-links between pages
+	children map[string][]*api.Issue
+	projects map[string]*htmlProject
+	assets   map[string]string
+}
+
+// htmlProject is a project with the exported issues that belong to it
+type htmlProject struct {
+	*api.Project
+	Issues []*api.Issue
+}
+
+// htmlPage is the data of one page; Root is the relative path back to the site root
+type htmlPage struct {
+	Site    *htmlSite
+	Root    string
+	Title   string
+	Issue   *api.Issue
+	Project *htmlProject
+}
+
+// htmlIssueList is the data of an issue table on any page
+type htmlIssueList struct {
+	Root   string
+	Issues []*api.Issue
+}
+
+// htmlChange is a historyChange the templates can read
+type htmlChange struct {
+	Field, From, To string
+}
+
+var exportHTMLCmd = &cobra.Command{
+	Use:   "html",
+	Short: "Export issues and projects as a static HTML site",
+	Long: `Render issues and projects as a browsable static HTML site in --out:
+
+  index.html            Every issue, with a quick filter
+  projects.html         Every project, with progress and issue counts
+  issues/ID.html        One page per issue: details, description,
+                        attachments, comments, sub-issues, and history
+  projects/ID.html      One page per project, with its issues
+  assets/               Files uploaded to Linear, per issue
+  style.css
+
+The site needs no server and no Linear account to read, so it works as an
+archive or for sharing with people without Linear seats. Files uploaded to
+Linear are downloaded so pages keep working after access is revoked; pass
+--no-assets to link to Linear instead.
+
+Examples:
+  linctl export html --team ENG --out site/
+  linctl export html --out archive/ --include-archived --title "Acme archive"`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{noDefaultTeamAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		outDir, _ := cmd.Flags().GetString("out")
+		teamKeys, _ := cmd.Flags().GetString("team")
+		title, _ := cmd.Flags().GetString("title")
+		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+		noAssets, _ := cmd.Flags().GetBool("no-assets")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+		client := api.NewClient(authHeader)
+		client.SetResponseCache(nil)
+		ctx := context.Background()
+
+		for _, dir := range []string{outDir, filepath.Join(outDir, "issues"), filepath.Join(outDir, "projects")} {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to create %s: %v", dir, err), plaintext, jsonOut)
+			}
+		}
+
+		site := &htmlSite{
+			Title:       title,
+			GeneratedAt: time.Now(),
+			children:    map[string][]*api.Issue{},
+			projects:    map[string]*htmlProject{},
+			assets:      map[string]string{},
+		}
+		if teamKeys != "" {
+			site.Teams = strings.Split(teamKeys, ",")
+		}
+
+		// progress reports each step on stderr so it never mixes with --json output
+		progress := func(format string, a ...interface{}) {
+			if !jsonOut && !plaintext {
+				fmt.Fprintf(os.Stderr, format, a...)
+			}
+		}
+		fail := func(what string, err error) {
+			progress("\n")
+			exitWithError(err, fmt.Sprintf("Failed to export %s: %v", what, err), plaintext, jsonOut)
+		}
+
+		progress("Exporting projects... ")
+		projects, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: 100}, func(ctx context.Context, first int, after string) ([]api.Project, api.PageInfo, error) {
+			page, err := client.GetProjects(ctx, nil, first, after, "")
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err != nil {
+			fail("projects", err)
+		}
+		progress("%d\n", len(projects))
+
+		var issueFilter map[string]interface{}
+		if len(site.Teams) > 0 {
+			issueFilter = map[string]interface{}{
+				"team": map[string]interface{}{"key": map[string]interface{}{"in": site.Teams}},
+			}
+		}
+		progress("Exporting issues... ")
+		bar := output.NewProgress("Exporting issues...", 0)
+		issues, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: exportIssuePageSize}, func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+			page, err := client.GetExportIssues(ctx, issueFilter, first, after, includeArchived)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			for i := range page.Nodes {
+				if err := completeExportIssue(ctx, client, &page.Nodes[i]); err != nil {
+					return nil, api.PageInfo{}, fmt.Errorf("%s: %v", page.Nodes[i].Identifier, err)
+				}
+			}
+			bar.Add(len(page.Nodes))
+			return page.Nodes, page.PageInfo, nil
+		})
+		bar.Done()
+		if err != nil {
+			fail("issues", err)
+		}
+		progress("\rExporting issues... %d\n", len(issues))
+
+		var errs []string
+		if !noAssets {
+			progress("Downloading assets... ")
+			bar := output.NewProgress("Downloading assets...", len(issues))
+			for _, issue := range issues {
+				downloaded, downloadErrs := downloadExportAssets(ctx, client.TransferClient(), issue, outDir, concurrency)
+				for url, path := range downloaded {
+					site.assets[url] = path
+				}
+				errs = append(errs, downloadErrs...)
+				bar.Add(1)
+			}
+			bar.Done()
+			progress("\rDownloading assets... %d\n", len(site.assets))
+		}
+
+		site.index(issues, projects)
+
+		progress("Writing pages... ")
+		tmpl := site.templates()
+
+		pages := 0
+		write := func(path, name string, page htmlPage) {
+			file, err := os.Create(filepath.Join(outDir, path))
+			if err == nil {
+				err = tmpl.ExecuteTemplate(file, name, page)
+				if closeErr := file.Close(); err == nil {
+					err = closeErr
+				}
+			}
+			if err != nil {
+				fail(path, err)
+			}
+			pages++
+		}
+
+		if err := os.WriteFile(filepath.Join(outDir, "style.css"), []byte(htmlStyle), 0644); err != nil {
+			fail("style.css", err)
+		}
+		write("index.html", "index", htmlPage{Site: site})
+		write("projects.html", "projects", htmlPage{Site: site})
+		for _, issue := range site.Issues {
+			write(filepath.Join("issues", issue.Identifier+".html"), "issue", htmlPage{Site: site, Root: "../", Issue: issue})
+		}
+		for _, project := range site.Projects {
+			write(filepath.Join("projects", project.ID+".html"), "project", htmlPage{Site: site, Root: "../", Project: project})
+		}
+		progress("%d\n", pages)
+
+		counts := map[string]int{
+			"issues":   len(site.Issues),
+			"projects": len(site.Projects),
+			"assets":   len(site.assets),
+			"pages":    pages,
+		}
+		index := filepath.Join(outDir, "index.html")
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"out":    outDir,
+				"index":  index,
+				"counts": counts,
+			}
+			if len(errs) > 0 {
+				result["errors"] = errs
+			}
+			output.JSON(result)
+		} else if plaintext {
+			fmt.Printf("Exported to %s\n", outDir)
+			for _, name := range []string{"issues", "projects", "assets", "pages"} {
+				fmt.Printf("%s\t%d\n", name, counts[name])
+			}
+			for _, e := range errs {
+				fmt.Printf("Error: %s\n", e)
+			}
+		} else {
+			fmt.Printf("%s Exported %d issues and %d projects to %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				counts["issues"], counts["projects"],
+				color.New(color.FgCyan, color.Bold).Sprint(outDir))
+			for _, e := range errs {
+				fmt.Printf("  %s %s\n", color.New(color.FgRed).Sprint("✗"), e)
+			}
+			fmt.Printf("  Open %s in a browser\n", index)
+		}
+	},
+}
+
+// index sorts the issues and links them to their projects and parents. With
+// --team, only projects of those teams or with exported issues are kept.
+func (s *htmlSite) index(issues []api.Issue, projects []api.Project) {
+	for i := range issues {
+		s.Issues = append(s.Issues, &issues[i])
+	}
+	sort.SliceStable(s.Issues, func(i, j int) bool {
+		a, b := s.Issues[i], s.Issues[j]
+		if rankA, rankB := htmlStateRank(a), htmlStateRank(b); rankA != rankB {
+			return rankA < rankB
+		}
+		if a.Priority != b.Priority {
+			// No priority (0) sorts after Low (4)
+			return (a.Priority+4)%5 < (b.Priority+4)%5
+		}
+		return a.UpdatedAt.After(b.UpdatedAt)
+	})
+
+	for i := range projects {
+		project := &htmlProject{Project: &projects[i]}
+		s.projects[project.ID] = project
+	}
+	for _, issue := range s.Issues {
+		if issue.Parent != nil {
+			s.children[issue.Parent.ID] = append(s.children[issue.Parent.ID], issue)
+		}
+		if issue.Project != nil {
+			if project := s.projects[issue.Project.ID]; project != nil {
+				project.Issues = append(project.Issues, issue)
+			}
+		}
+	}
+
+	for i := range projects {
+		project := s.projects[projects[i].ID]
+		if len(s.Teams) > 0 && len(project.Issues) == 0 && !htmlProjectInTeams(project.Project, s.Teams) {
+			delete(s.projects, project.ID)
+			continue
+		}
+		s.Projects = append(s.Projects, project)
+	}
+	sort.SliceStable(s.Projects, func(i, j int) bool {
+		return strings.ToLower(s.Projects[i].Name) < strings.ToLower(s.Projects[j].Name)
+	})
+}
+
+// templates parses the page templates with the helpers they call
+func (s *htmlSite) templates() *template.Template {
+	return template.Must(template.New("site").Funcs(template.FuncMap{
+		"markdown":  s.markdown,
+		"priority":  priorityToString,
+		"date":      htmlDate,
+		"changes":   htmlChanges,
+		"children":  func(issue *api.Issue) []*api.Issue { return s.children[issue.ID] },
+		"project":   func(id string) *htmlProject { return s.projects[id] },
+		"assetURL":  s.assetURL,
+		"teamNames": htmlTeamNames,
+		"pageTitle": func(page htmlPage, title string) htmlPage {
+			page.Title = title + " · " + s.Title
+			return page
+		},
+		"issueList": func(root string, issues []*api.Issue) htmlIssueList {
+			return htmlIssueList{Root: root, Issues: issues}
+		},
+	}).Parse(htmlTemplates))
+}
+
+// markdown renders markdown from a page root/levels deep, pointing downloaded
+// assets at their local copies
+func (s *htmlSite) markdown(root, markdown string) template.HTML {
+	if len(s.assets) > 0 {
+		replacements := make(map[string]string, len(s.assets))
+		for url, path := range s.assets {
+			replacements[url] = root + path
+		}
+		markdown = files.RewriteImageURLs(markdown, replacements)
+	}
+	// MarkdownToHTML escapes all text, so its output is safe to embed
+	return template.HTML(output.MarkdownToHTML(markdown))
+}
+
+// assetURL is the local copy of a downloaded file, or its original URL
+func (s *htmlSite) assetURL(root, url string) string {
+	if path, ok := s.assets[url]; ok {
+		return root + path
+	}
+	return url
+}
+
+func htmlChanges(entry api.IssueHistoryEntry) []htmlChange {
+	var changes []htmlChange
+	for _, change := range historyChanges(entry, nil) {
+		changes = append(changes, htmlChange{Field: change.field, From: change.from, To: change.to})
+	}
+	return changes
+}
+
+func htmlStateRank(issue *api.Issue) int {
+	if issue.State == nil {
+		return len(htmlStateOrder)
+	}
+	if rank, ok := htmlStateOrder[issue.State.Type]; ok {
+		return rank
+	}
+	return len(htmlStateOrder)
+}
+
+func htmlProjectInTeams(project *api.Project, teams []string) bool {
+	if project.Teams == nil {
+		return false
+	}
+	for _, team := range project.Teams.Nodes {
+		for _, key := range teams {
+			if strings.EqualFold(team.Key, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func htmlTeamNames(project *api.Project) string {
+	if project.Teams == nil {
+		return ""
+	}
+	keys := make([]string, len(project.Teams.Nodes))
+	for i, team := range project.Teams.Nodes {
+		keys[i] = team.Key
+	}
+	return strings.Join(keys, ", ")
+}
+
+// htmlDate formats a time or a date string from the API for display
+func htmlDate(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Local().Format("2006-01-02 15:04")
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Local().Format("2006-01-02 15:04")
+	case *string:
+		return optionalString(v)
+	case string:
+		return v
+	}
+	return ""
+}
+
+// htmlTemplates are the site's pages; every page shares the header and footer
+const htmlTemplates = `
+{{define "header"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="{{.Root}}style.css">
+</head>
+<body>
+<nav><a class="brand" href="{{.Root}}index.html">{{.Site.Title}}</a> <a href="{{.Root}}index.html">Issues</a> <a href="{{.Root}}projects.html">Projects</a></nav>
+<main>
+{{end}}
+
+{{define "footer"}}</main>
+<footer>Exported {{date .Site.GeneratedAt}}{{with .Site.Teams}} · Teams: {{range $i, $t := .}}{{if $i}}, {{end}}{{$t}}{{end}}{{end}}</footer>
+</body>
+</html>
+{{end}}
+
+{{define "issueRows"}}{{$root := .Root}}<table class="issues">
+<thead><tr><th>ID</th><th>Title</th><th>State</th><th>Priority</th><th>Assignee</th><th>Project</th><th>Updated</th></tr></thead>
+<tbody>
+{{range .Issues}}<tr>
+<td class="id"><a href="{{$root}}issues/{{.Identifier}}.html">{{.Identifier}}</a></td>
+<td><a href="{{$root}}issues/{{.Identifier}}.html">{{.Title}}</a>{{if .Labels}}{{range .Labels.Nodes}} <span class="label">{{.Name}}</span>{{end}}{{end}}</td>
+<td>{{with .State}}<span class="state state-{{.Type}}">{{.Name}}</span>{{end}}</td>
+<td>{{priority .Priority}}</td>
+<td>{{with .Assignee}}{{.Name}}{{end}}</td>
+<td>{{with .Project}}{{with project .ID}}<a href="{{$root}}projects/{{.ID}}.html">{{.Name}}</a>{{else}}{{.Name}}{{end}}{{end}}</td>
+<td class="date">{{date .UpdatedAt}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+{{end}}
+
+{{define "index"}}{{template "header" (pageTitle . "Issues")}}
+<h1>Issues <span class="count">{{len .Site.Issues}}</span></h1>
+<input id="filter" type="search" placeholder="Filter issues…" autofocus>
+{{template "issueRows" (issueList .Root .Site.Issues)}}
+<script>
+document.getElementById("filter").addEventListener("input", function () {
+  var query = this.value.toLowerCase();
+  document.querySelectorAll("table.issues tbody tr").forEach(function (row) {
+    row.hidden = query !== "" && row.textContent.toLowerCase().indexOf(query) < 0;
+  });
+});
+</script>
+{{template "footer" .}}{{end}}
+
+{{define "projects"}}{{template "header" (pageTitle . "Projects")}}
+<h1>Projects <span class="count">{{len .Site.Projects}}</span></h1>
+<table>
+<thead><tr><th>Name</th><th>State</th><th>Progress</th><th>Lead</th><th>Teams</th><th>Target</th><th>Issues</th></tr></thead>
+<tbody>
+{{range .Site.Projects}}<tr>
+<td><a href="projects/{{.ID}}.html">{{.Name}}</a></td>
+<td>{{.State}}</td>
+<td><progress max="1" value="{{.Progress}}"></progress></td>
+<td>{{with .Lead}}{{.Name}}{{end}}</td>
+<td>{{teamNames .Project}}</td>
+<td class="date">{{date .TargetDate}}</td>
+<td>{{len .Issues}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+{{template "footer" .}}{{end}}
+
+{{define "project"}}{{$root := .Root}}{{with .Project}}{{template "header" (pageTitle $ .Name)}}
+<h1>{{.Name}}</h1>
+<dl class="meta">
+<dt>State</dt><dd>{{.State}}</dd>
+<dt>Progress</dt><dd><progress max="1" value="{{.Progress}}"></progress></dd>
+{{with .Lead}}<dt>Lead</dt><dd>{{.Name}}</dd>{{end}}
+{{with teamNames .Project}}<dt>Teams</dt><dd>{{.}}</dd>{{end}}
+{{with date .StartDate}}<dt>Start</dt><dd>{{.}}</dd>{{end}}
+{{with date .TargetDate}}<dt>Target</dt><dd>{{.}}</dd>{{end}}
+{{with .URL}}<dt>Linear</dt><dd><a href="{{.}}">{{.}}</a></dd>{{end}}
+</dl>
+{{with .Description}}<section class="markdown">{{markdown $root .}}</section>{{end}}
+<h2>Issues <span class="count">{{len .Issues}}</span></h2>
+{{template "issueRows" (issueList $root .Issues)}}
+{{end}}{{template "footer" .}}{{end}}
+
+{{define "issue"}}{{$root := .Root}}{{with .Issue}}{{template "header" (pageTitle $ (printf "%s: %s" .Identifier .Title))}}
+<p class="id">{{with .Team}}{{.Name}} · {{end}}{{.Identifier}}{{with .Parent}} · Sub-issue of <a href="{{$root}}issues/{{.Identifier}}.html">{{.Identifier}}</a>{{end}}</p>
+<h1>{{.Title}}</h1>
+<dl class="meta">
+<dt>State</dt><dd>{{with .State}}<span class="state state-{{.Type}}">{{.Name}}</span>{{end}}</dd>
+<dt>Priority</dt><dd>{{priority .Priority}}</dd>
+<dt>Assignee</dt><dd>{{with .Assignee}}{{.Name}}{{else}}Unassigned{{end}}</dd>
+{{with .Creator}}<dt>Creator</dt><dd>{{.Name}}</dd>{{end}}
+{{with .Project}}<dt>Project</dt><dd>{{with project .ID}}<a href="{{$root}}projects/{{.ID}}.html">{{.Name}}</a>{{else}}{{.Name}}{{end}}</dd>{{end}}
+{{with .Cycle}}<dt>Cycle</dt><dd>{{if .Name}}{{.Name}}{{else}}Cycle {{.Number}}{{end}}</dd>{{end}}
+{{if .Labels}}{{with .Labels.Nodes}}<dt>Labels</dt><dd>{{range .}}<span class="label">{{.Name}}</span> {{end}}</dd>{{end}}{{end}}
+{{with .Estimate}}<dt>Estimate</dt><dd>{{.}}</dd>{{end}}
+{{with date .DueDate}}<dt>Due</dt><dd>{{.}}</dd>{{end}}
+<dt>Created</dt><dd>{{date .CreatedAt}}</dd>
+<dt>Updated</dt><dd>{{date .UpdatedAt}}</dd>
+{{with date .CompletedAt}}<dt>Completed</dt><dd>{{.}}</dd>{{end}}
+{{with date .CanceledAt}}<dt>Canceled</dt><dd>{{.}}</dd>{{end}}
+{{with date .ArchivedAt}}<dt>Archived</dt><dd>{{.}}</dd>{{end}}
+{{with .URL}}<dt>Linear</dt><dd><a href="{{.}}">{{.}}</a></dd>{{end}}
+</dl>
+<section class="markdown">{{with .Description}}{{markdown $root .}}{{else}}<p class="empty">No description</p>{{end}}</section>
+{{with children .}}<h2>Sub-issues <span class="count">{{len .}}</span></h2>
+{{template "issueRows" (issueList $root .)}}{{end}}
+{{if .Attachments}}{{with .Attachments.Nodes}}<h2>Attachments</h2>
+<ul class="attachments">
+{{range .}}<li><a href="{{assetURL $root .URL}}">{{if .Title}}{{.Title}}{{else}}{{.URL}}{{end}}</a>{{with .Subtitle}} <span class="muted">{{.}}</span>{{end}}</li>
+{{end}}</ul>{{end}}{{end}}
+{{if .Comments}}{{with .Comments.Nodes}}<h2>Comments <span class="count">{{len .}}</span></h2>
+{{range .}}<article class="comment{{if .Parent}} reply{{end}}">
+<header><strong>{{with .User}}{{.Name}}{{else}}Unknown{{end}}</strong> <span class="muted">{{date .CreatedAt}}{{if .EditedAt}} (edited){{end}}</span></header>
+<div class="markdown">{{markdown $root .Body}}</div>
+</article>
+{{end}}{{end}}{{end}}
+{{if .History}}{{with .History.Nodes}}<h2>History</h2>
+<table class="history">
+<thead><tr><th>When</th><th>Actor</th><th>Field</th><th>From</th><th>To</th></tr></thead>
+<tbody>
+{{range .}}{{$entry := .}}{{range changes .}}<tr><td class="date">{{date $entry.CreatedAt}}</td><td>{{with $entry.Actor}}{{.Name}}{{end}}</td><td>{{.Field}}</td><td>{{.From}}</td><td>{{.To}}</td></tr>
+{{end}}{{end}}</tbody>
+</table>{{end}}{{end}}
+{{end}}{{template "footer" .}}{{end}}
+`
+
+// htmlStyle is written to style.css
+const htmlStyle = `:root {
+  --fg: #1f2328; --muted: #656d76; --border: #d0d7de; --bg: #ffffff; --subtle: #f6f8fa; --accent: #5e6ad2;
+}
+@media (prefers-color-scheme: dark) {
+  :root { --fg: #e6edf3; --muted: #8d96a0; --border: #30363d; --bg: #0d1117; --subtle: #161b22; --accent: #8b93f8; }
+}
+* { box-sizing: border-box; }
+body { margin: 0; font: 14px/1.5 -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: var(--fg); background: var(--bg); }
+a { color: var(--accent); text-decoration: none; }
+a:hover { text-decoration: underline; }
+nav { display: flex; gap: 1.5em; padding: 0.75em 2em; border-bottom: 1px solid var(--border); background: var(--subtle); }
+nav .brand { font-weight: 600; color: var(--fg); margin-right: auto; }
+main { max-width: 1100px; margin: 0 auto; padding: 1.5em 2em; }
+footer { max-width: 1100px; margin: 0 auto; padding: 1em 2em 2em; color: var(--muted); font-size: 12px; }
+h1 { margin: 0.2em 0 0.6em; }
+.count { color: var(--muted); font-weight: normal; font-size: 0.7em; }
+.muted, .empty, p.id { color: var(--muted); }
+table { width: 100%; border-collapse: collapse; margin: 1em 0; }
+th, td { text-align: left; padding: 0.4em 0.6em; border-bottom: 1px solid var(--border); vertical-align: top; }
+th { background: var(--subtle); font-weight: 600; }
+td.id, td.date { white-space: nowrap; }
+#filter { width: 100%; padding: 0.5em 0.75em; font-size: 14px; border: 1px solid var(--border); border-radius: 6px; background: var(--bg); color: var(--fg); }
+.label { display: inline-block; padding: 0 0.5em; border: 1px solid var(--border); border-radius: 1em; font-size: 12px; color: var(--muted); }
+.state { white-space: nowrap; }
+.state::before { content: "●"; margin-right: 0.3em; }
+.state-started::before { color: #f2c94c; }
+.state-unstarted::before, .state-backlog::before, .state-triage::before { color: var(--muted); }
+.state-completed::before { color: #5e6ad2; }
+.state-canceled::before { color: #95a2b3; }
+dl.meta { display: grid; grid-template-columns: max-content 1fr; gap: 0.3em 1.5em; padding: 1em; background: var(--subtle); border: 1px solid var(--border); border-radius: 6px; }
+dl.meta dt { color: var(--muted); }
+dl.meta dd { margin: 0; }
+.markdown img { max-width: 100%; }
+.markdown pre { padding: 1em; overflow-x: auto; background: var(--subtle); border-radius: 6px; }
+.markdown code { font: 12px ui-monospace, SFMono-Regular, Menlo, monospace; }
+.markdown blockquote { margin: 0; padding-left: 1em; border-left: 3px solid var(--border); color: var(--muted); }
+.comment { border: 1px solid var(--border); border-radius: 6px; padding: 0.5em 1em; margin: 1em 0; }
+.comment.reply { margin-left: 2em; }
+.comment header { border-bottom: 1px solid var(--border); padding-bottom: 0.4em; }
+ul.attachments { padding-left: 1.2em; }
+`
+
+func init() {
+	exportCmd.AddCommand(exportHTMLCmd)
+
+	exportHTMLCmd.Flags().StringP("out", "o", "linctl-site", "Directory to write the site to")
+	exportHTMLCmd.Flags().StringP("team", "t", "", "Only export issues of these teams (comma-separated keys)")
+	exportHTMLCmd.Flags().String("title", "Linear", "Site title shown on every page")
+	exportHTMLCmd.Flags().Bool("include-archived", false, "Include archived issues")
+	exportHTMLCmd.Flags().Bool("no-assets", false, "Link to files uploaded to Linear instead of downloading them")
+	exportHTMLCmd.Flags().Int("concurrency", 4, "Number of assets downloaded in parallel")
+}
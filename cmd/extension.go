@@ -0,0 +1,461 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// extensionPrefix starts the name of every extension executable and repository
+const extensionPrefix = "linctl-"
+
+// githubRepoPattern matches an OWNER/REPO shorthand for a GitHub repository
+var githubRepoPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+// extension is an installed extension or a linctl-NAME executable on PATH
+type extension struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Source string `json:"source"`
+	// Installed is false for executables found on PATH, which remove leaves alone
+	Installed bool `json:"installed"`
+}
+
+var extensionCmd = &cobra.Command{
+	Use:     "extension",
+	Aliases: []string{"extensions", "ext"},
+	Short:   "Manage linctl extensions",
+	Long: `Extensions add commands to linctl without forking it. An extension named
+NAME is an executable called linctl-NAME, run as 'linctl NAME ARGS...'. It is
+found in ~/.linctl/extensions (see 'extension install') or anywhere on PATH.
+Built-in commands and aliases take precedence over extensions.
+
+Extensions run with the authentication and settings of the linctl that
+started them, passed in environment variables:
+
+  LINCTL_AUTH_HEADER    Value for the Authorization header of API requests
+                        (empty when linctl is not authenticated)
+  LINCTL_API_URL        Linear GraphQL endpoint
+  LINCTL_PROFILE        Active profile
+  LINCTL_CONFIG         Config file in use
+  LINCTL_DEFAULT_TEAM   default-team setting, if any
+  LINCTL_BIN            Path of the linctl executable, to call back into it
+  LINCTL_VERSION        linctl version
+
+An extension can be a script in any language or a compiled program; the
+repository or directory must contain an executable named after it, such as
+linctl-standup-bot in a repository called linctl-standup-bot.
+
+Examples:
+  linctl extension install acme/linctl-triage
+  linctl extension install https://git.example.com/tools/linctl-oncall.git
+  linctl extension install .        # Link the extension in this directory
+  linctl extension list
+  linctl triage --team ENG
+  linctl extension remove triage`,
+}
+
+var extensionInstallCmd = &cobra.Command{
+	Use:   "install OWNER/REPO|URL|PATH",
+	Short: "Install an extension from GitHub, a git URL, or a local directory",
+	Long: `Install an extension into ~/.linctl/extensions.
+
+OWNER/REPO and git URLs are cloned with git; 'extension upgrade' pulls them
+again later. A local directory is linked rather than copied, so changes to it
+take effect immediately, which is handy while developing an extension.
+
+The repository or directory name must start with 'linctl-', and it must hold
+an executable of the same name.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		dir, err := extensionsDir()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create %s: %v", dir, err), plaintext, jsonOut)
+		}
+
+		source := args[0]
+		local := false
+		if info, err := os.Stat(source); err == nil && info.IsDir() {
+			local = true
+			if source, err = filepath.Abs(source); err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+		} else if githubRepoPattern.MatchString(source) {
+			source = "https://github.com/" + source + ".git"
+		}
+
+		dirName := strings.TrimSuffix(filepath.Base(strings.TrimRight(source, "/")), ".git")
+		if !strings.HasPrefix(dirName, extensionPrefix) || dirName == extensionPrefix {
+			output.Error(fmt.Sprintf("Extension repositories must be named %sNAME, not %s", extensionPrefix, dirName), plaintext, jsonOut)
+			exit(1)
+		}
+		name := strings.TrimPrefix(dirName, extensionPrefix)
+		if found, _, err := rootCmd.Find([]string{name}); err == nil && found != rootCmd {
+			output.Error(fmt.Sprintf("'%s' is a built-in command, so the extension could never run", name), plaintext, jsonOut)
+			exit(1)
+		}
+
+		target := filepath.Join(dir, dirName)
+		if _, err := os.Lstat(target); err == nil {
+			output.Error(fmt.Sprintf("Extension '%s' is already installed; remove it first", name), plaintext, jsonOut)
+			exit(1)
+		}
+
+		if local {
+			err = os.Symlink(source, target)
+		} else {
+			if !jsonOut && !plaintext {
+				fmt.Fprintf(os.Stderr, "Cloning %s...\n", source)
+			}
+			clone := exec.Command("git", "clone", "--quiet", "--depth", "1", source, target)
+			clone.Stderr = os.Stderr
+			err = clone.Run()
+		}
+		if err != nil {
+			os.RemoveAll(target)
+			exitWithError(err, fmt.Sprintf("Failed to install %s: %v", args[0], err), plaintext, jsonOut)
+		}
+
+		executable := extensionExecutable(target, dirName)
+		if executable == "" {
+			os.RemoveAll(target)
+			output.Error(fmt.Sprintf("%s has no executable named %s", args[0], dirName), plaintext, jsonOut)
+			exit(1)
+		}
+
+		ext := extension{Name: name, Path: executable, Source: extensionSource(target), Installed: true}
+		if jsonOut {
+			output.JSON(ext)
+		} else if plaintext {
+			fmt.Printf("Installed extension %s from %s\n", name, ext.Source)
+		} else {
+			fmt.Printf("%s Installed extension %s from %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(name),
+				ext.Source)
+			fmt.Printf("  Run it with: linctl %s\n", name)
+		}
+	},
+}
+
+var extensionListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List installed extensions and linctl-* executables on PATH",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		extensions := findExtensions()
+
+		if jsonOut {
+			output.JSON(extensions)
+			return
+		}
+		if len(extensions) == 0 {
+			output.Info("No extensions. Install one with 'linctl extension install OWNER/REPO'", plaintext, jsonOut)
+			return
+		}
+
+		rows := make([][]string, len(extensions))
+		for i, ext := range extensions {
+			rows[i] = []string{ext.Name, ext.Source, ext.Path}
+		}
+		output.Table(output.TableData{
+			Headers: []string{"Name", "Source", "Path"},
+			Rows:    rows,
+		}, plaintext, jsonOut)
+	},
+}
+
+var extensionUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [NAME...]",
+	Short: "Pull the latest version of installed extensions",
+	Long:  `Pull the latest version of the named extensions, or of every extension installed from git. Linked directories are skipped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		var targets []extension
+		for _, ext := range findExtensions() {
+			if ext.Installed && (len(args) == 0 || containsString(args, ext.Name)) {
+				targets = append(targets, ext)
+			}
+		}
+		if len(args) > len(targets) {
+			output.Error("Unknown extension; see 'linctl extension list'", plaintext, jsonOut)
+			exit(1)
+		}
+
+		results := []map[string]string{}
+		failed := false
+		for _, ext := range targets {
+			dir := filepath.Dir(ext.Path)
+			status := "upgraded"
+			if info, err := os.Lstat(dir); err == nil && info.Mode()&os.ModeSymlink != 0 {
+				status = "skipped (linked directory)"
+			} else if out, err := exec.Command("git", "-C", dir, "pull", "--quiet", "--ff-only").CombinedOutput(); err != nil {
+				status = "failed: " + strings.TrimSpace(firstNonEmpty(string(out), err.Error()))
+				failed = true
+			}
+			results = append(results, map[string]string{"name": ext.Name, "status": status})
+		}
+
+		if jsonOut {
+			output.JSON(results)
+		} else {
+			for _, result := range results {
+				if plaintext {
+					fmt.Printf("%s\t%s\n", result["name"], result["status"])
+				} else if strings.HasPrefix(result["status"], "failed") {
+					fmt.Printf("%s %s: %s\n", color.New(color.FgRed).Sprint("✗"), result["name"], result["status"])
+				} else {
+					fmt.Printf("%s %s: %s\n", color.New(color.FgGreen).Sprint("✓"), result["name"], result["status"])
+				}
+			}
+		}
+		if failed {
+			exit(1)
+		}
+	},
+}
+
+var extensionRemoveCmd = &cobra.Command{
+	Use:     "remove NAME",
+	Aliases: []string{"rm", "uninstall"},
+	Short:   "Remove an installed extension",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		name := strings.TrimPrefix(args[0], extensionPrefix)
+
+		dir, err := extensionsDir()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		target := filepath.Join(dir, extensionPrefix+name)
+		if _, err := os.Lstat(target); err != nil {
+			if path, err := exec.LookPath(extensionPrefix + name); err == nil {
+				output.Error(fmt.Sprintf("Extension '%s' was not installed by linctl; delete %s yourself", name, path), plaintext, jsonOut)
+			} else {
+				output.Error(fmt.Sprintf("Extension '%s' is not installed", name), plaintext, jsonOut)
+			}
+			exit(1)
+		}
+
+		// A linked directory is unlinked; its contents stay where they are
+		if err := os.RemoveAll(target); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to remove %s: %v", target, err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"removed": name})
+		} else {
+			output.Success(fmt.Sprintf("Removed extension %s", name), plaintext, jsonOut)
+		}
+	},
+}
+
+// extensionsDir is ~/.linctl/extensions
+func extensionsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl", "extensions"), nil
+}
+
+// extensionExecutable is the executable named name in dir, or ""
+func extensionExecutable(dir, name string) string {
+	candidates := []string{name}
+	if runtime.GOOS == "windows" {
+		candidates = []string{name + ".exe", name + ".bat", name + ".cmd"}
+	}
+	for _, candidate := range candidates {
+		path := filepath.Join(dir, candidate)
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if runtime.GOOS == "windows" || info.Mode()&0111 != 0 {
+			return path
+		}
+	}
+	return ""
+}
+
+// extensionSource describes where an installed extension came from: the
+// directory a link points to, or the git remote it was cloned from
+func extensionSource(dir string) string {
+	if link, err := os.Readlink(dir); err == nil {
+		return link
+	}
+	out, err := exec.Command("git", "-C", dir, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return dir
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// findExtensions lists installed extensions, then linctl-* executables on PATH
+// that no installed extension shadows, sorted by name
+func findExtensions() []extension {
+	extensions := []extension{}
+	seen := map[string]bool{}
+
+	if dir, err := extensionsDir(); err == nil {
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.Name(), extensionPrefix) {
+				continue
+			}
+			target := filepath.Join(dir, entry.Name())
+			executable := extensionExecutable(target, entry.Name())
+			if executable == "" {
+				continue
+			}
+			name := strings.TrimPrefix(entry.Name(), extensionPrefix)
+			seen[name] = true
+			extensions = append(extensions, extension{Name: name, Path: executable, Source: extensionSource(target), Installed: true})
+		}
+	}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			name := strings.TrimPrefix(entry.Name(), extensionPrefix)
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			if !strings.HasPrefix(entry.Name(), extensionPrefix) || name == "" || seen[name] {
+				continue
+			}
+			if executable := extensionExecutable(dir, extensionPrefix+name); executable != "" {
+				seen[name] = true
+				extensions = append(extensions, extension{Name: name, Path: executable, Source: "PATH"})
+			}
+		}
+	}
+
+	sort.Slice(extensions, func(i, j int) bool { return extensions[i].Name < extensions[j].Name })
+	return extensions
+}
+
+// splitRootFlags splits args at the first argument that is not a root flag
+// or a root flag's value: "--profile work deploy --prod" is "--profile work"
+// and "deploy --prod"
+func splitRootFlags(args []string) (flags, rest []string) {
+	rootFlags := rootCmd.PersistentFlags()
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "-" || arg == "--" || !strings.HasPrefix(arg, "-") {
+			return args[:i], args[i:]
+		}
+		if strings.Contains(arg, "=") {
+			continue
+		}
+		var flag *pflag.Flag
+		if strings.HasPrefix(arg, "--") {
+			flag = rootFlags.Lookup(arg[2:])
+		} else {
+			flag = rootFlags.ShorthandLookup(arg[len(arg)-1:])
+		}
+		if flag != nil && flag.NoOptDefVal == "" {
+			i++
+		}
+	}
+	return args, nil
+}
+
+// runExtension runs the extension named by the first argument after the root
+// flags, if there is one, and exits with its status. Built-in commands win,
+// so it only looks for extensions when that argument is not a command.
+func runExtension(args []string) {
+	flags, rest := splitRootFlags(args)
+	if len(rest) == 0 || strings.HasPrefix(rest[0], "-") {
+		return
+	}
+	if found, _, err := rootCmd.Find(rest[:1]); err == nil && found != rootCmd {
+		return
+	}
+
+	var path string
+	for _, ext := range findExtensions() {
+		if ext.Name == rest[0] {
+			path = ext.Path
+			break
+		}
+	}
+	if path == "" {
+		return
+	}
+
+	// Apply --profile, --config, and the other root flags, then load config
+	// and the profile the way a built-in command would. The config banner
+	// is left out so it doesn't end up in the extension's stderr.
+	if err := rootCmd.PersistentFlags().Parse(flags); err != nil {
+		exitUsageError(rootCmd, err, flags)
+	}
+	quietConfig = true
+	initConfig()
+	env := os.Environ()
+	authHeader, _ := auth.GetAuthHeader()
+	self, _ := os.Executable()
+	configPath, _ := configFilePath()
+	for key, value := range map[string]string{
+		"LINCTL_AUTH_HEADER":  authHeader,
+		"LINCTL_API_URL":      api.BaseURL,
+		"LINCTL_PROFILE":      auth.Profile(),
+		"LINCTL_CONFIG":       configPath,
+		"LINCTL_DEFAULT_TEAM": viper.GetString("default-team"),
+		"LINCTL_BIN":          self,
+		"LINCTL_VERSION":      version,
+	} {
+		env = append(env, key+"="+value)
+	}
+
+	command := exec.Command(path, rest[1:]...)
+	command.Env = env
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	if err := command.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exit(exitErr.ExitCode())
+		}
+		output.Error(fmt.Sprintf("Failed to run extension '%s': %v", rest[0], err), false, false)
+		exit(1)
+	}
+	exit(0)
+}
+
+func init() {
+	rootCmd.AddCommand(extensionCmd)
+	extensionCmd.AddCommand(extensionInstallCmd)
+	extensionCmd.AddCommand(extensionListCmd)
+	extensionCmd.AddCommand(extensionUpgradeCmd)
+	extensionCmd.AddCommand(extensionRemoveCmd)
+}
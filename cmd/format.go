@@ -0,0 +1,442 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// tableColumn is a field that can be selected with --columns
+type tableColumn[T any] struct {
+	Name  string
+	Value func(T) string
+}
+
+// delimitedFormat holds the parsed --format/--columns/--no-header flags
+type delimitedFormat struct {
+	Separator rune
+	Columns   []string
+	Header    bool
+}
+
+// addFormatFlags registers --format, --columns, and --no-header on a list command
+func addFormatFlags(cmd *cobra.Command, columnNames []string, defaultColumns []string) {
+	cmd.Flags().String("format", "table", "Output format: table, csv, tsv")
+	cmd.Flags().String("columns", strings.Join(defaultColumns, ","),
+		fmt.Sprintf("Comma-separated columns for csv/tsv output (available: %s)", strings.Join(columnNames, ", ")))
+	cmd.Flags().Bool("no-header", false, "Omit the header row in csv/tsv output")
+}
+
+// parseDelimitedFormat validates the format flags. It returns nil when table output was requested.
+func parseDelimitedFormat[T any](cmd *cobra.Command, columns []tableColumn[T]) (*delimitedFormat, error) {
+	format, _ := cmd.Flags().GetString("format")
+
+	var separator rune
+	switch strings.ToLower(format) {
+	case "", "table":
+		return nil, nil
+	case "csv":
+		separator = ','
+	case "tsv":
+		separator = '\t'
+	default:
+		return nil, fmt.Errorf("invalid format: %s. Valid options are: table, csv, tsv", format)
+	}
+
+	available := make(map[string]bool, len(columns))
+	var names []string
+	for _, column := range columns {
+		available[column.Name] = true
+		names = append(names, column.Name)
+	}
+
+	columnsFlag, _ := cmd.Flags().GetString("columns")
+	var selected []string
+	for _, name := range strings.Split(columnsFlag, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !available[name] {
+			return nil, fmt.Errorf("unknown column '%s'. Available columns: %s", name, strings.Join(names, ", "))
+		}
+		selected = append(selected, name)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("at least one column is required")
+	}
+
+	noHeader, _ := cmd.Flags().GetBool("no-header")
+	return &delimitedFormat{
+		Separator: separator,
+		Columns:   selected,
+		Header:    !noHeader,
+	}, nil
+}
+
+// writeDelimited writes items as CSV/TSV using the selected columns
+func writeDelimited[T any](format *delimitedFormat, items []T, columns []tableColumn[T]) error {
+	byName := make(map[string]tableColumn[T], len(columns))
+	for _, column := range columns {
+		byName[column.Name] = column
+	}
+
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		row := make([]string, len(format.Columns))
+		for j, name := range format.Columns {
+			row[j] = byName[name].Value(item)
+		}
+		rows[i] = row
+	}
+
+	var headers []string
+	if format.Header {
+		headers = format.Columns
+	}
+	return output.Delimited(os.Stdout, headers, rows, format.Separator)
+}
+
+// columnNames lists the names of the given columns in order
+func columnNames[T any](columns []tableColumn[T]) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// optionalString dereferences an optional string field
+func optionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// formatSLA describes when an open issue's SLA breaches, or when it did; ""
+// when the issue has no SLA
+func formatSLA(issue *api.Issue) string {
+	if issue.SLABreachesAt == nil {
+		return ""
+	}
+	if issue.State != nil && (issue.State.Type == "completed" || issue.State.Type == "canceled") {
+		return ""
+	}
+	at := issue.SLABreachesAt.Local().Format("2006-01-02 15:04")
+	if slaBreached(issue) {
+		return "breached " + at
+	}
+	return "breaches " + at
+}
+
+// slaBreached reports whether an issue's SLA breach time has passed
+func slaBreached(issue *api.Issue) bool {
+	return issue.SLABreachesAt != nil && time.Now().After(*issue.SLABreachesAt)
+}
+
+// issueDueColor is the theme's color for an issue's due date: overdue or due
+// soon unless the issue is done
+func issueDueColor(issue *api.Issue) *color.Color {
+	done := issue.State != nil && (issue.State.Type == "completed" || issue.State.Type == "canceled")
+	return output.DueColor(optionalString(issue.DueDate), done, time.Now())
+}
+
+// issueFieldColor is the theme's color for a field of an issue in tables, or
+// nil for fields shown plain
+func issueFieldColor(issue *api.Issue, field string) *color.Color {
+	switch field {
+	case "state":
+		if issue.State != nil {
+			return stateTypeColor(issue.State.Type, issue.State.Color)
+		}
+	case "priority":
+		return output.PriorityColor(issue.Priority)
+	case "due":
+		return issueDueColor(issue)
+	case "sla":
+		if slaBreached(issue) {
+			return output.ThemeColor("due.overdue")
+		}
+	}
+	return nil
+}
+
+// issueColumns are the columns available to issue list and issue search
+var issueColumns = []tableColumn[api.Issue]{
+	{"identifier", func(i api.Issue) string { return i.Identifier }},
+	{"id", func(i api.Issue) string { return i.ID }},
+	{"title", func(i api.Issue) string { return i.Title }},
+	{"description", func(i api.Issue) string { return i.Description }},
+	{"state", func(i api.Issue) string {
+		if i.State == nil {
+			return ""
+		}
+		return i.State.Name
+	}},
+	{"assignee", func(i api.Issue) string {
+		if i.Assignee == nil {
+			return ""
+		}
+		return i.Assignee.Name
+	}},
+	{"team", func(i api.Issue) string {
+		if i.Team == nil {
+			return ""
+		}
+		return i.Team.Key
+	}},
+	{"priority", func(i api.Issue) string { return priorityToString(i.Priority) }},
+	{"estimate", func(i api.Issue) string {
+		if i.Estimate == nil {
+			return ""
+		}
+		return fmt.Sprintf("%g", *i.Estimate)
+	}},
+	{"labels", func(i api.Issue) string {
+		if i.Labels == nil {
+			return ""
+		}
+		names := make([]string, len(i.Labels.Nodes))
+		for j, label := range i.Labels.Nodes {
+			names[j] = label.Name
+		}
+		return strings.Join(names, ", ")
+	}},
+	{"project", func(i api.Issue) string {
+		if i.Project == nil {
+			return ""
+		}
+		return i.Project.Name
+	}},
+	{"cycle", func(i api.Issue) string {
+		if i.Cycle == nil {
+			return ""
+		}
+		return fmt.Sprintf("%d", i.Cycle.Number)
+	}},
+	{"parent", func(i api.Issue) string {
+		if i.Parent == nil {
+			return ""
+		}
+		return i.Parent.Identifier
+	}},
+	{"due", func(i api.Issue) string { return optionalString(i.DueDate) }},
+	{"sla", func(i api.Issue) string { return formatSLA(&i) }},
+	{"created", func(i api.Issue) string { return i.CreatedAt.Format("2006-01-02") }},
+	{"updated", func(i api.Issue) string { return i.UpdatedAt.Format("2006-01-02") }},
+	{"archived", func(i api.Issue) string {
+		if i.ArchivedAt == nil {
+			return ""
+		}
+		return i.ArchivedAt.Format("2006-01-02")
+	}},
+	{"url", func(i api.Issue) string { return i.URL }},
+}
+
+var defaultIssueColumns = []string{"identifier", "title", "state", "assignee", "team", "priority", "created", "url"}
+
+// addIssueFieldsFlag registers --fields on issue list and search
+func addIssueFieldsFlag(cmd *cobra.Command) {
+	cmd.Flags().String("fields", "",
+		fmt.Sprintf("Comma-separated fields to fetch and show, for smaller, faster responses (available: %s)", strings.Join(columnNames(issueColumns), ", ")))
+}
+
+// parseIssueFields reads --fields: the issue fields to fetch, and the
+// selection set that fetches them. Without --fields, csv/tsv output fetches
+// just its columns, and other output the full default selection (nil fields
+// and ""). With --fields, csv/tsv columns default to the fields.
+func parseIssueFields(cmd *cobra.Command, format *delimitedFormat) ([]string, string, error) {
+	fieldsFlag, _ := cmd.Flags().GetString("fields")
+	var fields []string
+	for _, name := range strings.Split(fieldsFlag, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := api.IssueFieldSelections[name]; !ok {
+			return nil, "", fmt.Errorf("unknown field '%s'. Available fields: %s", name, strings.Join(columnNames(issueColumns), ", "))
+		}
+		fields = append(fields, name)
+	}
+
+	if format != nil {
+		if len(fields) == 0 {
+			selection, err := api.IssueSelection(format.Columns)
+			return nil, selection, err
+		}
+		if !cmd.Flags().Changed("columns") {
+			format.Columns = fields
+		}
+		for _, column := range format.Columns {
+			if !containsString(fields, column) {
+				return nil, "", fmt.Errorf("column '%s' is not among --fields", column)
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil, "", nil
+	}
+	selection, err := api.IssueSelection(fields)
+	return fields, selection, err
+}
+
+// renderIssueList prints list or search results: only the selected fields
+// when --fields was given, else the usual issue output
+func renderIssueList(issues *api.Issues, fields []string, plaintext, jsonOut bool, emptyMessage, summaryLabel, plaintextTitle string) {
+	if fields == nil {
+		renderIssueCollection(issues, plaintext, jsonOut, emptyMessage, summaryLabel, plaintextTitle)
+		return
+	}
+
+	if jsonOut {
+		output.JSON(issueFieldsJSON(issues.Nodes, fields))
+		return
+	}
+
+	if len(issues.Nodes) == 0 {
+		output.Info(emptyMessage, plaintext, jsonOut)
+		return
+	}
+
+	output.Table(issueFieldsTable(issues.Nodes, fields), plaintext, jsonOut)
+
+	if !plaintext {
+		fmt.Printf("\n%s %d %s\n", color.New(color.FgGreen).Sprint("✓"), len(issues.Nodes), summaryLabel)
+	}
+}
+
+// issueFieldsJSON keeps only the JSON keys the fields select
+func issueFieldsJSON(issues []api.Issue, fields []string) []map[string]interface{} {
+	var keys []string
+	for _, field := range fields {
+		keys = append(keys, selectionKeys(api.IssueFieldSelections[field])...)
+	}
+	items := make([]map[string]interface{}, len(issues))
+	for i, issue := range issues {
+		data, _ := json.Marshal(issue)
+		var all map[string]interface{}
+		_ = json.Unmarshal(data, &all)
+		item := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			item[key] = all[key]
+		}
+		items[i] = item
+	}
+	return items
+}
+
+// issueFieldsTable lays out issues as a table of the selected fields
+func issueFieldsTable(issues []api.Issue, fields []string) output.TableData {
+	byName := make(map[string]tableColumn[api.Issue], len(issueColumns))
+	for _, column := range issueColumns {
+		byName[column.Name] = column
+	}
+	headers := make([]string, len(fields))
+	for i, field := range fields {
+		switch field {
+		case "id", "url", "sla":
+			headers[i] = strings.ToUpper(field)
+		default:
+			headers[i] = strings.ToUpper(field[:1]) + field[1:]
+		}
+	}
+	rows := make([][]string, len(issues))
+	for i, issue := range issues {
+		row := make([]string, len(fields))
+		for j, field := range fields {
+			row[j] = byName[field].Value(issue)
+			if c := issueFieldColor(&issue, field); c != nil && row[j] != "" {
+				row[j] = c.Sprint(row[j])
+			}
+		}
+		rows[i] = row
+	}
+	return output.TableData{Headers: headers, Rows: rows}
+}
+
+// selectionKeys lists the top-level field names of a GraphQL selection set
+func selectionKeys(selection string) []string {
+	var keys []string
+	depth := 0
+	for _, word := range strings.Fields(strings.NewReplacer("{", " { ", "}", " } ").Replace(selection)) {
+		switch word {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		default:
+			if depth == 0 {
+				keys = append(keys, word)
+			}
+		}
+	}
+	return keys
+}
+
+// projectColumns are the columns available to project list
+var projectColumns = []tableColumn[api.Project]{
+	{"id", func(p api.Project) string { return p.ID }},
+	{"name", func(p api.Project) string { return p.Name }},
+	{"state", func(p api.Project) string { return p.State }},
+	{"progress", func(p api.Project) string { return fmt.Sprintf("%.0f%%", p.Progress*100) }},
+	{"lead", func(p api.Project) string {
+		if p.Lead == nil {
+			return ""
+		}
+		return p.Lead.Name
+	}},
+	{"teams", func(p api.Project) string {
+		if p.Teams == nil {
+			return ""
+		}
+		keys := make([]string, len(p.Teams.Nodes))
+		for i, team := range p.Teams.Nodes {
+			keys[i] = team.Key
+		}
+		return strings.Join(keys, ", ")
+	}},
+	{"start", func(p api.Project) string { return optionalString(p.StartDate) }},
+	{"target", func(p api.Project) string { return optionalString(p.TargetDate) }},
+	{"created", func(p api.Project) string { return p.CreatedAt.Format("2006-01-02") }},
+	{"updated", func(p api.Project) string { return p.UpdatedAt.Format("2006-01-02") }},
+	{"url", func(p api.Project) string { return constructProjectURL(p.ID, p.URL) }},
+}
+
+var defaultProjectColumns = []string{"name", "state", "lead", "teams", "created", "updated", "url"}
+
+// teamColumns are the columns available to team list
+var teamColumns = []tableColumn[api.Team]{
+	{"id", func(t api.Team) string { return t.ID }},
+	{"key", func(t api.Team) string { return t.Key }},
+	{"name", func(t api.Team) string { return t.Name }},
+	{"description", func(t api.Team) string { return t.Description }},
+	{"private", func(t api.Team) string { return fmt.Sprintf("%v", t.Private) }},
+	{"issues", func(t api.Team) string { return fmt.Sprintf("%d", t.IssueCount) }},
+}
+
+var defaultTeamColumns = []string{"key", "name", "description", "private", "issues"}
+
+// userColumns are the columns available to user list
+var userColumns = []tableColumn[api.User]{
+	{"id", func(u api.User) string { return u.ID }},
+	{"name", func(u api.User) string { return u.Name }},
+	{"displayname", func(u api.User) string { return u.DisplayName }},
+	{"email", func(u api.User) string { return u.Email }},
+	{"role", func(u api.User) string {
+		if u.Admin {
+			return "Admin"
+		}
+		return "Member"
+	}},
+	{"active", func(u api.User) string { return fmt.Sprintf("%v", u.Active) }},
+}
+
+var defaultUserColumns = []string{"name", "email", "role", "active"}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// hookMarker identifies hooks written by linctl, so they can be replaced or removed safely
+const hookMarker = "# Installed by 'linctl hook install'"
+
+// prepareCommitMsgHook adds the issue identifier from the branch name to the
+// commit message. It is plain sh so it runs without linctl on PATH. %s is the
+// command that edits the message file ($1) to include $id.
+const prepareCommitMsgHook = `#!/bin/sh
+` + hookMarker + `
+# Adds the Linear issue identifier in the branch name to commit messages.
+
+# Leave merges, squashes, and amended or reused messages alone
+case "$2" in
+merge|squash|commit) exit 0 ;;
+esac
+
+branch=$(git symbolic-ref --short HEAD 2>/dev/null) || exit 0
+
+# Prefer a path segment that starts with the identifier (kyle/eng-123-fix-login)
+id=$(printf '%%s\n' "$branch" | tr '/' '\n' | sed -n '1!G;h;$p' |
+	grep -oiE '^[a-z][a-z0-9_]*-[0-9]+' | head -n 1 | tr '[:lower:]' '[:upper:]')
+if [ -z "$id" ]; then
+	id=$(printf '%%s\n' "$branch" | grep -oiE '[a-z][a-z0-9_]*-[0-9]+' | head -n 1 | tr '[:lower:]' '[:upper:]')
+fi
+[ -n "$id" ] || exit 0
+
+# Skip messages that already mention the issue
+grep -qiF "$id" "$1" && exit 0
+
+%s
+`
+
+// hookPrefixCommand puts "ENG-123: " in front of the subject line
+const hookPrefixCommand = `{ printf '%s: ' "$id"; cat "$1"; } > "$1.linctl" && mv "$1.linctl" "$1"`
+
+// hookTrailerCommand adds a "Refs: ENG-123" trailer
+const hookTrailerCommand = `git interpret-trailers --in-place --trailer "Refs: $id" "$1"`
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Manage git hooks that link commits to issues",
+	Long: `Install a prepare-commit-msg git hook that adds the issue identifier from the
+branch name (e.g. ENG-123 in "kyle/eng-123-fix-login") to commit messages, so
+Linear links the commits to the issue.
+
+Examples:
+  linctl hook install
+  linctl hook install --trailer   # "Refs: ENG-123" instead of an "ENG-123: " prefix
+  linctl hook uninstall`,
+}
+
+var hookInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the prepare-commit-msg hook in the current repository",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		path, err := prepareCommitMsgHookPath()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if existing, err := os.ReadFile(path); err == nil && !strings.Contains(string(existing), hookMarker) && !force {
+			output.Error(fmt.Sprintf("%s already exists and was not installed by linctl (use --force to replace it)", path), plaintext, jsonOut)
+			exit(1)
+		}
+
+		edit := hookPrefixCommand
+		if trailer, _ := cmd.Flags().GetBool("trailer"); trailer {
+			edit = hookTrailerCommand
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create hooks directory: %v", err), plaintext, jsonOut)
+		}
+		if err := os.WriteFile(path, []byte(fmt.Sprintf(prepareCommitMsgHook, edit)), 0755); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to write hook: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"installed": true, "path": path})
+			return
+		}
+		output.Success(fmt.Sprintf("Installed prepare-commit-msg hook at %s", path), plaintext, jsonOut)
+	},
+}
+
+var hookUninstallCmd = &cobra.Command{
+	Use:     "uninstall",
+	Aliases: []string{"remove"},
+	Short:   "Remove the prepare-commit-msg hook installed by linctl",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		path, err := prepareCommitMsgHookPath()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		existing, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			output.Info("No prepare-commit-msg hook is installed", plaintext, jsonOut)
+			return
+		}
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to read hook: %v", err), plaintext, jsonOut)
+		}
+		if !strings.Contains(string(existing), hookMarker) {
+			output.Error(fmt.Sprintf("%s was not installed by linctl; leaving it alone", path), plaintext, jsonOut)
+			exit(1)
+		}
+		if err := os.Remove(path); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to remove hook: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"removed": true, "path": path})
+			return
+		}
+		output.Success(fmt.Sprintf("Removed %s", path), plaintext, jsonOut)
+	},
+}
+
+// prepareCommitMsgHookPath is where git looks for the hook, honoring core.hooksPath
+func prepareCommitMsgHookPath() (string, error) {
+	dir, err := runGit("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "prepare-commit-msg"), nil
+}
+
+func init() {
+	rootCmd.AddCommand(hookCmd)
+	hookCmd.AddCommand(hookInstallCmd)
+	hookCmd.AddCommand(hookUninstallCmd)
+
+	hookInstallCmd.Flags().Bool("trailer", false, "Add a 'Refs: ENG-123' trailer instead of prefixing the subject")
+	hookInstallCmd.Flags().Bool("force", false, "Replace an existing hook that linctl did not install")
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Identity shortcuts accepted by user and team flags
+const (
+	// meShortcut is the authenticated user
+	meShortcut = "@me"
+	// noneShortcut matches issues with no user set (e.g. unassigned)
+	noneShortcut = "@none"
+	// anyShortcut matches any value, including none for teams
+	anyShortcut = "@any"
+	// teamShortcut is the default-team from config
+	teamShortcut = "@team"
+)
+
+// teamPrefixPattern matches a team key followed by '-' and an optional issue
+// number, so "ENG-" and "ENG-123" both name team ENG
+var teamPrefixPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*)-(\d*)$`)
+
+// userShortcut maps the accepted spellings of a user shortcut to its canonical
+// form: "me" and "@me"; "@none", "none" and "unassigned"; "@any". Other values
+// return "".
+func userShortcut(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "@me", "me":
+		return meShortcut
+	case "@none", "none", "unassigned":
+		return noneShortcut
+	case "@any", "any":
+		return anyShortcut
+	}
+	return ""
+}
+
+// userFilter builds the filter for a user flag such as --assignee or
+// --creator. @me is resolved through the cached viewer; anything else
+// matches an email, name, or display name.
+func userFilter(ctx context.Context, value string) (map[string]interface{}, error) {
+	switch userShortcut(value) {
+	case meShortcut:
+		viewer, err := auth.Viewer(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current user: %v", err)
+		}
+		return map[string]interface{}{"id": map[string]interface{}{"eq": viewer.ID}}, nil
+	case noneShortcut:
+		return map[string]interface{}{"null": true}, nil
+	case anyShortcut:
+		return map[string]interface{}{"null": false}, nil
+	}
+
+	match := map[string]interface{}{"eqIgnoreCase": strings.TrimSpace(value)}
+	return map[string]interface{}{
+		"or": []interface{}{
+			map[string]interface{}{"email": match},
+			map[string]interface{}{"name": match},
+			map[string]interface{}{"displayName": match},
+		},
+	}, nil
+}
+
+// resolveTeamKey expands team shorthands: "ENG-" and "ENG-123" become "ENG",
+// @team becomes default-team, and @any becomes "" (no team). Keys are upper-cased.
+func resolveTeamKey(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "", anyShortcut:
+		return "", nil
+	case teamShortcut:
+		defaultTeam := viper.GetString("default-team")
+		if defaultTeam == "" {
+			return "", fmt.Errorf("%s needs default-team to be set in config", teamShortcut)
+		}
+		return strings.ToUpper(defaultTeam), nil
+	}
+	if match := teamPrefixPattern.FindStringSubmatch(value); match != nil {
+		return strings.ToUpper(match[1]), nil
+	}
+	return strings.ToUpper(value), nil
+}
+
+// teamKeyFromIdentifier returns the team key of an issue identifier such as
+// ENG-123, or "" when the value is not an identifier
+func teamKeyFromIdentifier(identifier string) string {
+	match := teamPrefixPattern.FindStringSubmatch(strings.TrimSpace(identifier))
+	if match == nil || match[2] == "" {
+		return ""
+	}
+	return strings.ToUpper(match[1])
+}
+
+// normalizeTeamFlag expands the shorthands of a command's --team flag (which
+// may be a comma-separated list) so every command accepts the same spellings
+func normalizeTeamFlag(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("team")
+	if flag == nil || flag.Value.String() == "" {
+		return nil
+	}
+
+	var keys []string
+	for _, part := range strings.Split(flag.Value.String(), ",") {
+		key, err := resolveTeamKey(part)
+		if err != nil {
+			return err
+		}
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return flag.Value.Set(strings.Join(keys, ","))
+}
@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import issues from other trackers",
+	Long: `Import issues from other trackers into Linear.
+
+Every importer remembers which Linear issue each source issue became, in
+~/.linctl/imports/PROFILE/SOURCE.json (or --store), so running it again updates
+the issues it created and adds new comments instead of duplicating them.
+
+To create issues from a CSV or YAML manifest, see 'linctl issue import'.`,
+}
+
+// importRecord is the Linear issue a source issue was imported as
+type importRecord struct {
+	ID              string            `json:"id"`
+	Identifier      string            `json:"identifier"`
+	Comments        map[string]string `json:"comments,omitempty"` // source comment ID -> Linear comment ID
+	SourceUpdatedAt time.Time         `json:"sourceUpdatedAt"`
+	ImportedAt      time.Time         `json:"importedAt"`
+}
+
+// importStore maps source issues (by a key such as "owner/repo#12") to the
+// Linear issues they were imported as, and containers such as Jira epics to
+// the Linear projects they became
+type importStore struct {
+	Issues   map[string]*importRecord `json:"issues"`
+	Projects map[string]*importRecord `json:"projects,omitempty"`
+	path     string
+}
+
+// importStorePath is ~/.linctl/imports/PROFILE/SOURCE.json unless override is set
+func importStorePath(source, override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl", "imports", auth.Profile(), source+".json"), nil
+}
+
+// loadImportStore reads a mapping store (empty when there is none yet)
+func loadImportStore(path string) (*importStore, error) {
+	store := &importStore{Issues: map[string]*importRecord{}, Projects: map[string]*importRecord{}, path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import store: %w", err)
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse import store %s: %w", path, err)
+	}
+	if store.Issues == nil {
+		store.Issues = map[string]*importRecord{}
+	}
+	if store.Projects == nil {
+		store.Projects = map[string]*importRecord{}
+	}
+	return store, nil
+}
+
+// save writes the store, so an interrupted import keeps what it created
+func (s *importStore) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0600)
+}
+
+// upsertIssue creates the Linear issue for a source issue seen for the first
+// time, or updates the one an earlier run created, and saves the store before
+// anything else (such as comments) can fail. It returns the record and
+// whether the issue was "created" or "updated".
+func (s *importStore) upsertIssue(ctx context.Context, client *api.Client, key string, input map[string]interface{}) (*importRecord, string, error) {
+	record := s.Issues[key]
+	status := "updated"
+	if record == nil {
+		created, err := client.CreateIssue(ctx, input)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create issue: %v", err)
+		}
+		record = &importRecord{ID: created.ID, Identifier: created.Identifier}
+		s.Issues[key] = record
+		status = "created"
+	} else {
+		update := make(map[string]interface{}, len(input))
+		for field, value := range input {
+			if field != "teamId" {
+				update[field] = value
+			}
+		}
+		if _, err := client.UpdateIssue(ctx, record.ID, update); err != nil {
+			return nil, "", fmt.Errorf("failed to update %s: %v", record.Identifier, err)
+		}
+	}
+
+	record.ImportedAt = time.Now().UTC()
+	if err := s.save(); err != nil {
+		return nil, "", fmt.Errorf("failed to save import store: %v", err)
+	}
+	return record, status, nil
+}
+
+// sourceImportResult records the outcome for one source issue
+type sourceImportResult struct {
+	Source     string   `json:"source"`
+	URL        string   `json:"url,omitempty"`
+	Title      string   `json:"title"`
+	Identifier string   `json:"identifier,omitempty"`
+	Status     string   `json:"status"` // created, updated, unchanged, planned, or failed
+	Comments   int      `json:"comments"`
+	Images     int      `json:"images"`
+	Warnings   []string `json:"warnings,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// printImportProgress prints one line per imported issue, followed by its warnings
+func printImportProgress(done, total int, result sourceImportResult) {
+	progress := color.New(color.FgWhite, color.Faint).Sprintf("[%d/%d]", done, total)
+	if result.Error != "" {
+		fmt.Printf("%s %s %s: %s\n", progress, color.New(color.FgRed).Sprint("✗"), result.Source, result.Error)
+		return
+	}
+
+	icon := color.New(color.FgGreen).Sprint("✓")
+	identifier := result.Identifier
+	switch result.Status {
+	case "planned":
+		icon = color.New(color.FgYellow).Sprint("~")
+		identifier = result.Source
+	case "unchanged":
+		icon = color.New(color.FgWhite, color.Faint).Sprint("=")
+	}
+	details := ""
+	if result.Comments > 0 || result.Images > 0 {
+		details = color.New(color.FgWhite, color.Faint).Sprintf(" (%d comments, %d images)", result.Comments, result.Images)
+	}
+	fmt.Printf("%s %s %s %s%s\n", progress, icon, color.New(color.FgCyan, color.Bold).Sprint(identifier), result.Title, details)
+	for _, warning := range result.Warnings {
+		fmt.Printf("    %s %s\n", color.New(color.FgYellow).Sprint("!"), warning)
+	}
+}
+
+// printImportSummary prints the totals of an import, in the style of 'issue import'
+func printImportSummary(results []sourceImportResult, dryRun, plaintext, jsonOut bool) {
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+
+	if jsonOut {
+		if results == nil {
+			results = []sourceImportResult{}
+		}
+		summary := map[string]interface{}{
+			"total":     len(results),
+			"created":   counts["created"],
+			"updated":   counts["updated"],
+			"unchanged": counts["unchanged"],
+			"failed":    counts["failed"],
+			"dryRun":    dryRun,
+			"results":   results,
+		}
+		output.JSON(summary)
+		return
+	}
+	if plaintext {
+		for _, result := range results {
+			fmt.Printf("%s\t%s\t%s\t%s\n", result.Source, result.Identifier, result.Status, firstNonEmpty(result.Error, result.Title))
+		}
+		fmt.Printf("\nTotal: %d, Created: %d, Updated: %d, Unchanged: %d, Failed: %d\n",
+			len(results), counts["created"], counts["updated"], counts["unchanged"], counts["failed"])
+		return
+	}
+
+	if dryRun {
+		fmt.Printf("\n%s Would import %d/%d issues", color.New(color.FgGreen).Sprint("✓"), counts["planned"], len(results))
+	} else {
+		fmt.Printf("\n%s Created %d, updated %d, unchanged %d",
+			color.New(color.FgGreen).Sprint("✓"), counts["created"], counts["updated"], counts["unchanged"])
+	}
+	if counts["failed"] > 0 {
+		fmt.Printf(", %s", color.New(color.FgRed).Sprintf("%d failed", counts["failed"]))
+	}
+	fmt.Println()
+}
+
+// importLabelIDs resolves label names against the team's labels, returning
+// the IDs found and the names that don't exist (which importers skip rather
+// than fail on)
+func importLabelIDs(ctx context.Context, resolver *bulkResolver, teamKey string, names []string) ([]string, []string, error) {
+	value, err := resolver.memo("teamlabels:"+teamKey, func() (interface{}, error) {
+		labels, err := resolver.client.GetTeamLabels(ctx, teamKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch team labels: %v", err)
+		}
+		byName := make(map[string]string, len(labels))
+		for _, label := range labels {
+			byName[strings.ToLower(label.Name)] = label.ID
+		}
+		return byName, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	byName := value.(map[string]string)
+
+	ids := []string{}
+	var missing []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		id, ok := byName[strings.ToLower(strings.TrimSpace(name))]
+		switch {
+		case !ok:
+			missing = append(missing, name)
+		case !seen[id]:
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, missing, nil
+}
+
+// importFileExtensions names downloaded files whose URL has no extension, so
+// the upload gets the right content type
+var importFileExtensions = map[string]string{
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"image/svg+xml":   ".svg",
+	"image/bmp":       ".bmp",
+	"video/mp4":       ".mp4",
+	"video/webm":      ".webm",
+	"video/quicktime": ".mov",
+	"application/pdf": ".pdf",
+}
+
+// reuploadImages downloads the images in markdown whose URL matches isSource,
+// uploads them to Linear, and rewrites their links to the Linear asset URLs.
+// Images that fail keep their original links; their errors are returned.
+func reuploadImages(ctx context.Context, client *api.Client, markdown string, isSource func(string) bool, download func(ctx context.Context, url, path string) (string, error)) (string, int, []string) {
+	var images []files.ImageInfo
+	seen := make(map[string]bool)
+	for _, img := range files.ExtractImagesFromMarkdown(markdown) {
+		if isSource(img.URL) && !seen[img.URL] {
+			seen[img.URL] = true
+			images = append(images, img)
+		}
+	}
+	if len(images) == 0 {
+		return markdown, 0, nil
+	}
+
+	dir, err := os.MkdirTemp("", "linctl-import-")
+	if err != nil {
+		return markdown, 0, []string{fmt.Sprintf("failed to create temp dir: %v", err)}
+	}
+	defer os.RemoveAll(dir)
+
+	var errs []string
+	replacements := make(map[string]string)
+	for i, img := range images {
+		// One folder per image, so images with the same alt text don't collide
+		path := filepath.Join(dir, strconv.Itoa(i), files.ImageFilename(img, i))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		contentType, err := download(ctx, img.URL, path)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if filepath.Ext(path) == "" {
+			renamed := path + importFileExtension(path, contentType)
+			if err := os.Rename(path, renamed); err == nil {
+				path = renamed
+			}
+		}
+
+		assetURL, err := client.UploadFileToLinear(ctx, path)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to upload image %s: %v", img.URL, err))
+			continue
+		}
+		replacements[img.URL] = assetURL
+	}
+	return files.RewriteImageURLs(markdown, replacements), len(replacements), errs
+}
+
+// importFileExtension picks an extension from the served content type, or
+// from the file's first bytes when the server didn't send a useful one
+func importFileExtension(path, contentType string) string {
+	contentType, _, _ = strings.Cut(contentType, ";")
+	if ext, ok := importFileExtensions[strings.TrimSpace(contentType)]; ok {
+		return ext
+	}
+	if file, err := os.Open(path); err == nil {
+		defer file.Close()
+		head := make([]byte, 512)
+		n, _ := file.Read(head)
+		sniffed, _, _ := strings.Cut(http.DetectContentType(head[:n]), ";")
+		return importFileExtensions[sniffed]
+	}
+	return ""
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
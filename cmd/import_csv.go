@@ -0,0 +1,562 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// csvImportMapping says which CSV column holds each issue field, what to use
+// when a cell is empty, and how to translate values (e.g. a "P1" priority to
+// "urgent", or an "Open" status to "Todo")
+type csvImportMapping struct {
+	Columns  map[string]string            `yaml:"columns"`            // field -> column
+	Defaults map[string]string            `yaml:"defaults,omitempty"` // field -> value
+	Values   map[string]map[string]string `yaml:"values,omitempty"`   // field -> CSV value -> Linear value
+}
+
+// importProblem is a value that can't be resolved, and the rows that use it
+type importProblem struct {
+	Field   string `json:"field"`
+	Value   string `json:"value"`
+	Problem string `json:"problem"`
+	Rows    []int  `json:"rows"`
+}
+
+var importCsvCmd = &cobra.Command{
+	Use:   "csv FILE.csv",
+	Short: "Import issues from any CSV file",
+	Long: `Create issues from a CSV file exported by another tool, whatever its columns
+are called.
+
+In a terminal, each column is shown with a sample value and you pick the issue
+field it holds (` + strings.Join(importFields, ", ") + `, or skip);
+the mapping can be saved for next time. A --mapping file sets it up front:
+
+  columns:          # field: column
+    title: Summary
+    description: Details
+    assignee: Owner
+    labels: Tags
+  defaults:         # used when a cell is empty
+    team: ENG
+    state: Todo
+  values:           # translate CSV values to Linear names
+    priority:
+      P1: urgent
+      P2: high
+    state:
+      Open: Todo
+      Closed: Done
+
+Before anything is created, the first rows are previewed as they'll be
+imported and every team, state, user, label, project, and parent the file
+names is looked up, listing the ones that don't exist. Rows that can't be
+created are written, with an "error" column, to FILE-errors.csv.
+
+Examples:
+  linctl import csv tickets.csv
+  linctl import csv tickets.csv --mapping tickets.yaml --yes
+  linctl import csv tickets.csv --mapping tickets.yaml --check
+  linctl import csv tickets.csv --column title=Summary --team ENG --dry-run`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{noDefaultTeamAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		path := args[0]
+		mappingPath, _ := cmd.Flags().GetString("mapping")
+		columns, _ := cmd.Flags().GetStringToString("column")
+		teamKey, _ := cmd.Flags().GetString("team")
+		previewRows, _ := cmd.Flags().GetInt("preview")
+		check, _ := cmd.Flags().GetBool("check")
+		yes, _ := cmd.Flags().GetBool("yes")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		errorFile, _ := cmd.Flags().GetString("error-file")
+
+		header, records, err := readCSVRecords(path)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if len(records) == 0 {
+			output.Error("The CSV file has no rows", plaintext, jsonOut)
+			exit(1)
+		}
+
+		interactive := mappingPath == "" && !yes && !jsonOut && !plaintext && isInteractive()
+		reader := bufio.NewReader(os.Stdin)
+		mapping := csvImportMapping{}
+		switch {
+		case mappingPath != "":
+			data, err := os.ReadFile(mappingPath)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to read mapping: %v", err), plaintext, jsonOut)
+			}
+			if err := yaml.Unmarshal(data, &mapping); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to parse mapping %s: %v", mappingPath, err), plaintext, jsonOut)
+			}
+		case interactive:
+			mapping, err = promptCSVMapping(reader, header, records, columns)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+		default:
+			mapping.Columns = autoCSVColumns(header)
+		}
+		for field, column := range columns {
+			if mapping.Columns == nil {
+				mapping.Columns = map[string]string{}
+			}
+			mapping.Columns[importFieldName(field)] = column
+		}
+
+		manifest, err := buildCSVManifest(header, records, mapping)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if _, ok := mapping.Columns["title"]; !ok {
+			output.Error("No column is mapped to title", plaintext, jsonOut)
+			exit(1)
+		}
+		order, err := importOrder(manifest.Rows)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+		resolver := &bulkResolver{client: client, cache: make(map[string]*bulkCacheEntry)}
+
+		if interactive && teamKey == "" && mapping.Columns["team"] == "" && mapping.Defaults["team"] == "" && viper.GetString("default-team") == "" {
+			teamKey, err = promptForTeam(ctx, client, reader)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+		}
+
+		problems := validateImportRows(ctx, resolver, manifest.Rows, teamKey)
+		if check && jsonOut {
+			if problems == nil {
+				problems = []importProblem{}
+			}
+			output.JSON(map[string]interface{}{
+				"rows":     len(manifest.Rows),
+				"columns":  mapping.Columns,
+				"problems": problems,
+			})
+			if len(problems) > 0 {
+				exit(1)
+			}
+			return
+		}
+
+		if !jsonOut {
+			printCSVPreview(manifest, previewRows, plaintext)
+			printImportProblems(problems, plaintext)
+		}
+		if check {
+			if len(problems) > 0 {
+				exit(1)
+			}
+			return
+		}
+
+		if interactive && !dryRun {
+			failing := map[int]bool{}
+			for _, problem := range problems {
+				for _, row := range problem.Rows {
+					failing[row] = true
+				}
+			}
+			question := fmt.Sprintf("Create %d issues", len(manifest.Rows))
+			if len(failing) > 0 {
+				question += fmt.Sprintf(" (%d rows will fail)", len(failing))
+			}
+			answer, err := promptString(reader, question+"? [y/N]", "")
+			if err != nil || !strings.HasPrefix(strings.ToLower(answer), "y") {
+				fmt.Println("Import canceled")
+				return
+			}
+			fmt.Println()
+		}
+
+		failed := createImportRows(ctx, resolver, manifest, order, path, teamKey, errorFile, dryRun, plaintext, jsonOut)
+		exitOnFailures(len(order)-failed, failed)
+	},
+}
+
+// readCSVRecords reads a CSV file's header and records
+func readCSVRecords(path string) ([]string, [][]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("the CSV file is empty")
+	}
+	header := records[0]
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff") // Excel's byte order mark
+	}
+	return header, records[1:], nil
+}
+
+// autoCSVColumns maps the columns named after an import field
+func autoCSVColumns(header []string) map[string]string {
+	columns := map[string]string{}
+	for _, column := range header {
+		if field := importFieldName(column); isImportField(field) {
+			if _, taken := columns[field]; !taken {
+				columns[field] = column
+			}
+		}
+	}
+	return columns
+}
+
+// promptCSVMapping asks which field each column holds, suggesting the field a
+// column is named after, and offers to save the answers as a mapping file
+func promptCSVMapping(reader *bufio.Reader, header []string, records [][]string, preset map[string]string) (csvImportMapping, error) {
+	mapping := csvImportMapping{Columns: map[string]string{}}
+	suggested := autoCSVColumns(header)
+	presetColumns := map[string]string{} // column -> field
+	for field, column := range preset {
+		presetColumns[column] = importFieldName(field)
+	}
+
+	fmt.Println(color.New(color.FgYellow).Sprint("Map each column to an issue field:"))
+	fmt.Printf("  %s, or skip\n\n", strings.Join(importFields, ", "))
+	for i, column := range header {
+		suggestion := "skip"
+		if field, ok := presetColumns[column]; ok {
+			suggestion = field
+		} else {
+			for field, suggestedColumn := range suggested {
+				if suggestedColumn == column {
+					suggestion = field
+				}
+			}
+		}
+
+		sample := ""
+		for _, record := range records {
+			if i < len(record) && strings.TrimSpace(record[i]) != "" {
+				sample = truncateString(strings.TrimSpace(record[i]), 40)
+				break
+			}
+		}
+		label := fmt.Sprintf("%s %s", color.New(color.FgCyan, color.Bold).Sprint(column),
+			color.New(color.FgWhite, color.Faint).Sprintf("(e.g. %q)", sample))
+
+		for {
+			answer, err := promptString(reader, label, suggestion)
+			if err != nil {
+				return mapping, fmt.Errorf("mapping canceled")
+			}
+			field := importFieldName(answer)
+			if field == "skip" || field == "-" || field == "" {
+				break
+			}
+			if !isImportField(field) {
+				fmt.Printf("  Unknown field '%s'\n", answer)
+				continue
+			}
+			if other, taken := mapping.Columns[field]; taken {
+				fmt.Printf("  %s is already mapped to '%s'\n", field, other)
+				continue
+			}
+			mapping.Columns[field] = column
+			break
+		}
+	}
+	fmt.Println()
+
+	savePath, err := promptString(reader, "Save this mapping to a file (blank to skip)", "")
+	if err == nil && savePath != "" {
+		data, err := yaml.Marshal(mapping)
+		if err == nil {
+			err = os.WriteFile(savePath, data, 0644)
+		}
+		if err != nil {
+			return mapping, fmt.Errorf("failed to save mapping: %v", err)
+		}
+		fmt.Printf("Saved; reuse it with --mapping %s\n", savePath)
+	}
+	fmt.Println()
+	return mapping, nil
+}
+
+// buildCSVManifest turns CSV records into manifest rows using only the mapped
+// columns, then fills in defaults and translates values
+func buildCSVManifest(header []string, records [][]string, mapping csvImportMapping) (*importManifest, error) {
+	index := map[string]int{} // field -> column index
+	mapped := map[int]bool{}
+	for field, column := range mapping.Columns {
+		name := importFieldName(field)
+		if !isImportField(name) {
+			return nil, fmt.Errorf("unknown field '%s' in mapping (valid: %s)", field, strings.Join(importFields, ", "))
+		}
+		found := false
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(column)) {
+				index[name] = i
+				mapped[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("column '%s' (for %s) is not in the CSV header", column, name)
+		}
+	}
+	for field := range mapping.Defaults {
+		if !isImportField(importFieldName(field)) {
+			return nil, fmt.Errorf("unknown field '%s' in defaults", field)
+		}
+	}
+
+	manifest := &importManifest{Format: "csv", Header: header}
+	for i, column := range header {
+		if !mapped[i] {
+			manifest.Ignored = append(manifest.Ignored, column)
+		}
+	}
+	for i, record := range records {
+		row := importRow{Number: i + 1, Fields: map[string]string{}, record: record}
+		for field, j := range index {
+			if j < len(record) {
+				row.Fields[field] = strings.TrimSpace(record[j])
+			}
+		}
+		for field, value := range mapping.Defaults {
+			if name := importFieldName(field); row.Fields[name] == "" {
+				row.Fields[name] = value
+			}
+		}
+		for field, translations := range mapping.Values {
+			name := importFieldName(field)
+			if name == "labels" {
+				labels := strings.Split(row.Fields[name], ",")
+				for k, label := range labels {
+					labels[k] = translateCSVValue(translations, strings.TrimSpace(label))
+				}
+				row.Fields[name] = strings.Join(labels, ",")
+			} else {
+				row.Fields[name] = translateCSVValue(translations, row.Fields[name])
+			}
+		}
+		manifest.Rows = append(manifest.Rows, row)
+	}
+	return manifest, nil
+}
+
+// translateCSVValue looks a value up in a translation table, ignoring case
+func translateCSVValue(translations map[string]string, value string) string {
+	for from, to := range translations {
+		if strings.EqualFold(from, value) {
+			return to
+		}
+	}
+	return value
+}
+
+// validateImportRows looks up every reference the rows make, the way
+// createImportRow will, and returns the ones that fail
+func validateImportRows(ctx context.Context, resolver *bulkResolver, rows []importRow, teamFlag string) []importProblem {
+	byKey := map[string]*importProblem{}
+	var keys []string
+	add := func(field, value string, err error, row int) {
+		key := field + "\x00" + value + "\x00" + err.Error()
+		problem, ok := byKey[key]
+		if !ok {
+			problem = &importProblem{Field: field, Value: value, Problem: err.Error()}
+			byKey[key] = problem
+			keys = append(keys, key)
+		}
+		problem.Rows = append(problem.Rows, row)
+	}
+
+	refs := map[string]bool{}
+	for _, row := range rows {
+		if ref := row.Fields["ref"]; ref != "" {
+			refs[ref] = true
+		}
+	}
+
+	for _, row := range rows {
+		fields := row.Fields
+		if fields["title"] == "" {
+			add("title", "", fmt.Errorf("title is required"), row.Number)
+		}
+
+		teamKey, err := resolveTeamKey(firstNonEmpty(fields["team"], teamFlag, viper.GetString("default-team")))
+		if err != nil {
+			add("team", fields["team"], err, row.Number)
+		} else if teamKey == "" && fields["parent"] == "" {
+			add("team", "", fmt.Errorf("no team (add a team column, a default, or --team)"), row.Number)
+		} else if teamKey != "" {
+			if _, err := resolver.teamID(ctx, teamKey); err != nil {
+				add("team", teamKey, err, row.Number)
+				teamKey = ""
+			}
+		}
+
+		if state := fields["state"]; state != "" && teamKey != "" {
+			if _, err := resolver.stateID(ctx, teamKey, state); err != nil {
+				add("state", state, err, row.Number)
+			}
+		}
+		if assignee := fields["assignee"]; assignee != "" {
+			if _, err := resolver.assigneeID(ctx, assignee); err != nil {
+				add("assignee", assignee, err, row.Number)
+			}
+		}
+		if labels := fields["labels"]; labels != "" && teamKey != "" {
+			_, missing, err := importLabelIDs(ctx, resolver, teamKey, strings.Split(labels, ","))
+			if err != nil {
+				add("labels", labels, err, row.Number)
+			}
+			for _, name := range missing {
+				if name = strings.TrimSpace(name); name != "" {
+					add("labels", name, fmt.Errorf("no such label in %s", teamKey), row.Number)
+				}
+			}
+		}
+		if project := fields["project"]; project != "" {
+			if _, err := resolver.projectID(ctx, project); err != nil {
+				add("project", project, err, row.Number)
+			}
+		}
+		if parent := fields["parent"]; parent != "" && !refs[parent] {
+			if _, err := resolver.issueID(ctx, parent); err != nil {
+				add("parent", parent, err, row.Number)
+			}
+		}
+		if value := fields["priority"]; value != "" {
+			if _, err := parsePriority(value); err != nil {
+				add("priority", value, err, row.Number)
+			}
+		}
+		if value := fields["estimate"]; value != "" {
+			if estimate, err := strconv.Atoi(value); err != nil || estimate < 0 {
+				add("estimate", value, fmt.Errorf("not a whole number"), row.Number)
+			}
+		}
+		if value := fields["due-date"]; value != "" {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				add("due-date", value, fmt.Errorf("expected YYYY-MM-DD"), row.Number)
+			}
+		}
+	}
+
+	problems := make([]importProblem, 0, len(keys))
+	for _, key := range keys {
+		problems = append(problems, *byKey[key])
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Rows[0] < problems[j].Rows[0] })
+	return problems
+}
+
+// printCSVPreview shows the first rows as they'll be imported
+func printCSVPreview(manifest *importManifest, n int, plaintext bool) {
+	if n <= 0 {
+		return
+	}
+	var fields []string
+	for _, field := range importFields {
+		for _, row := range manifest.Rows {
+			if row.Fields[field] != "" {
+				fields = append(fields, field)
+				break
+			}
+		}
+	}
+
+	headers := append([]string{"Row"}, fields...)
+	var rows [][]string
+	for _, row := range manifest.Rows {
+		if len(rows) == n {
+			break
+		}
+		values := []string{strconv.Itoa(row.Number)}
+		for _, field := range fields {
+			values = append(values, truncateString(strings.ReplaceAll(row.Fields[field], "\n", " "), 30))
+		}
+		rows = append(rows, values)
+	}
+
+	if !plaintext {
+		fmt.Printf("%s first %d of %d rows\n", color.New(color.FgYellow).Sprint("Preview:"), len(rows), len(manifest.Rows))
+	}
+	output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, false)
+	if len(manifest.Ignored) > 0 {
+		fmt.Printf("Skipping columns: %s\n", strings.Join(manifest.Ignored, ", "))
+	}
+	fmt.Println()
+}
+
+// printImportProblems lists the references that can't be resolved
+func printImportProblems(problems []importProblem, plaintext bool) {
+	if len(problems) == 0 {
+		if !plaintext {
+			fmt.Printf("%s Every team, state, user, label, project, and parent was found\n\n", color.New(color.FgGreen).Sprint("✓"))
+		}
+		return
+	}
+
+	rows := make([][]string, len(problems))
+	for i, problem := range problems {
+		numbers := make([]string, 0, 5)
+		for _, row := range problem.Rows {
+			if len(numbers) == 5 {
+				numbers = append(numbers, fmt.Sprintf("+%d more", len(problem.Rows)-5))
+				break
+			}
+			numbers = append(numbers, strconv.Itoa(row))
+		}
+		rows[i] = []string{problem.Field, problem.Value, strings.Join(numbers, ", "), problem.Problem}
+	}
+	if !plaintext {
+		noun := "values"
+		if len(problems) == 1 {
+			noun = "value"
+		}
+		fmt.Printf("%s %d %s can't be resolved; their rows will fail\n", color.New(color.FgRed).Sprint("✗"), len(problems), noun)
+	}
+	output.Table(output.TableData{Headers: []string{"Field", "Value", "Rows", "Problem"}, Rows: rows}, plaintext, false)
+	fmt.Println()
+}
+
+func init() {
+	importCmd.AddCommand(importCsvCmd)
+
+	importCsvCmd.Flags().String("mapping", "", "YAML file mapping fields to columns, with defaults and value translations")
+	importCsvCmd.Flags().StringToString("column", nil, "Map a field to a column, as FIELD=COLUMN (repeatable)")
+	importCsvCmd.Flags().StringP("team", "t", "", "Team for rows without a team")
+	importCsvCmd.Flags().Int("preview", 5, "Rows to preview before importing (0 for none)")
+	importCsvCmd.Flags().Bool("check", false, "Preview and validate without creating anything")
+	importCsvCmd.Flags().BoolP("yes", "y", false, "Don't prompt: map columns by name and import without confirming")
+	importCsvCmd.Flags().Bool("dry-run", false, "Resolve every row without creating issues")
+	importCsvCmd.Flags().String("error-file", "", "Where to write failed rows (default: FILE-errors.csv)")
+}
@@ -0,0 +1,411 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/github"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// githubMapping translates GitHub names to Linear ones. Labels mapped to ""
+// are dropped; anything not listed is looked up under its own name.
+type githubMapping struct {
+	Team       string            `yaml:"team"`
+	States     map[string]string `yaml:"states"` // open, closed, not_planned -> state name or type
+	Labels     map[string]string `yaml:"labels"`
+	Assignees  map[string]string `yaml:"assignees"`  // login -> email, name, or @me
+	Milestones map[string]string `yaml:"milestones"` // title -> project
+}
+
+var importGithubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Import issues from a GitHub repository",
+	Long: `Import a GitHub repository's issues, with their comments, into a Linear team.
+Pull requests are skipped. The token is read from GITHUB_TOKEN or GH_TOKEN;
+set GITHUB_API_URL for GitHub Enterprise.
+
+Labels, assignees, and milestones are matched to Linear labels, users, and
+projects of the same name, unless a --mapping file says otherwise:
+
+  team: ENG
+  states:
+    open: Todo            # default: the team's default state
+    closed: Done          # default: the first completed state
+    not_planned: Canceled # default: the first canceled state
+  labels:
+    bug: Bug
+    wontfix: ""           # don't import this label
+  assignees:
+    octocat: jane@example.com
+  milestones:
+    v2.0: Q3 Launch
+
+Names that can't be matched are skipped with a warning. Images uploaded to
+GitHub are downloaded and re-uploaded to Linear. Re-running the import updates
+issues changed on GitHub since the last run and adds new comments.
+
+Examples:
+  linctl import github --repo acme/api --team ENG --dry-run
+  linctl import github --repo acme/api --mapping github.yaml --state all
+  linctl import github --repo acme/api --label bug --limit 20 --json`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{noDefaultTeamAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		repo, _ := cmd.Flags().GetString("repo")
+		if strings.Count(repo, "/") != 1 || strings.HasPrefix(repo, "/") || strings.HasSuffix(repo, "/") {
+			output.Error("--repo must be OWNER/NAME", plaintext, jsonOut)
+			exit(1)
+		}
+
+		mapping := githubMapping{}
+		if path, _ := cmd.Flags().GetString("mapping"); path != "" {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to read mapping: %v", err), plaintext, jsonOut)
+			}
+			if err := yaml.Unmarshal(data, &mapping); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to parse mapping %s: %v", path, err), plaintext, jsonOut)
+			}
+		}
+
+		teamFlag, _ := cmd.Flags().GetString("team")
+		teamKey, err := resolveTeamKey(firstNonEmpty(teamFlag, mapping.Team, viper.GetString("default-team")))
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if teamKey == "" {
+			output.Error("A team is required (use --team, the mapping's team, or default-team)", plaintext, jsonOut)
+			exit(1)
+		}
+
+		state, _ := cmd.Flags().GetString("state")
+		if state != "open" && state != "closed" && state != "all" {
+			output.Error("--state must be open, closed, or all", plaintext, jsonOut)
+			exit(1)
+		}
+		opts := github.IssueOptions{State: state}
+		opts.Labels, _ = cmd.Flags().GetStringSlice("label")
+		opts.Limit, _ = cmd.Flags().GetInt("limit")
+		if since, _ := cmd.Flags().GetString("since"); since != "" {
+			parsed, err := utils.ParseTimeExpression(since)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Invalid since value: %v", err), plaintext, jsonOut)
+			}
+			if t, err := time.Parse(time.RFC3339, parsed); err == nil {
+				opts.Since = &t
+			}
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		noComments, _ := cmd.Flags().GetBool("no-comments")
+		noImages, _ := cmd.Flags().GetBool("no-images")
+		storeFlag, _ := cmd.Flags().GetString("store")
+
+		storePath, err := importStorePath("github", storeFlag)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		store, err := loadImportStore(storePath)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		token := github.TokenFromEnv()
+		if token == "" && !jsonOut && !plaintext {
+			fmt.Fprintln(os.Stderr, "No GITHUB_TOKEN or GH_TOKEN set; only public repositories can be read, at a low rate limit")
+		}
+		gh := github.NewClient(token)
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+		resolver := &bulkResolver{client: client, cache: make(map[string]*bulkCacheEntry)}
+
+		issues, err := gh.ListIssues(ctx, repo, opts)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list GitHub issues: %v", err), plaintext, jsonOut)
+		}
+		if len(issues) == 0 {
+			output.Info(fmt.Sprintf("No issues to import from %s", repo), plaintext, jsonOut)
+			return
+		}
+
+		importer := &githubImporter{
+			gh:       gh,
+			resolver: resolver,
+			store:    store,
+			mapping:  mapping,
+			repo:     repo,
+			teamKey:  teamKey,
+			dryRun:   dryRun,
+			comments: !noComments,
+			images:   !noImages,
+		}
+		results := make([]sourceImportResult, 0, len(issues))
+		for i, issue := range issues {
+			result := importer.importIssue(ctx, issue)
+			results = append(results, result)
+			if !jsonOut && !plaintext {
+				printImportProgress(i+1, len(issues), result)
+			}
+		}
+
+		printImportSummary(results, dryRun, plaintext, jsonOut)
+		for _, result := range results {
+			if result.Status == "failed" {
+				exit(1)
+			}
+		}
+	},
+}
+
+// githubImporter creates or updates the Linear issue for each GitHub issue
+type githubImporter struct {
+	gh       *github.Client
+	resolver *bulkResolver
+	store    *importStore
+	mapping  githubMapping
+	repo     string
+	teamKey  string
+	dryRun   bool
+	comments bool
+	images   bool
+}
+
+// importIssue imports one GitHub issue, skipping it when it hasn't changed
+// since the last import
+func (g *githubImporter) importIssue(ctx context.Context, issue github.Issue) sourceImportResult {
+	key := fmt.Sprintf("%s#%d", g.repo, issue.Number)
+	result := sourceImportResult{Source: key, URL: issue.HTMLURL, Title: issue.Title}
+	fail := func(err error) sourceImportResult {
+		result.Status = "failed"
+		result.Error = err.Error()
+		return result
+	}
+
+	record := g.store.Issues[key]
+	if record != nil {
+		result.Identifier = record.Identifier
+		if !issue.UpdatedAt.After(record.SourceUpdatedAt) {
+			result.Status = "unchanged"
+			return result
+		}
+	}
+
+	input, warnings, err := g.issueInput(ctx, issue)
+	if err != nil {
+		return fail(err)
+	}
+	result.Warnings = warnings
+
+	if g.dryRun {
+		result.Status = "planned"
+		result.Comments = issue.Comments
+		return result
+	}
+
+	description := githubDescription(issue)
+	if g.images {
+		var uploaded int
+		var errs []string
+		description, uploaded, errs = reuploadImages(ctx, g.resolver.client, description, github.IsAssetURL, g.gh.Download)
+		result.Images += uploaded
+		result.Warnings = append(result.Warnings, errs...)
+	}
+	input["description"] = description
+
+	record, result.Status, err = g.store.upsertIssue(ctx, g.resolver.client, key, input)
+	if err != nil {
+		return fail(err)
+	}
+	result.Identifier = record.Identifier
+
+	if g.comments && issue.Comments > 0 {
+		added, images, errs := g.importComments(ctx, issue, record)
+		result.Comments = added
+		result.Images += images
+		result.Warnings = append(result.Warnings, errs...)
+		if len(errs) > 0 {
+			// Leave SourceUpdatedAt alone so the next run retries the missing comments
+			if err := g.store.save(); err != nil {
+				return fail(fmt.Errorf("failed to save import store: %v", err))
+			}
+			return result
+		}
+	}
+
+	record.SourceUpdatedAt = issue.UpdatedAt
+	if err := g.store.save(); err != nil {
+		return fail(fmt.Errorf("failed to save import store: %v", err))
+	}
+	return result
+}
+
+// issueInput maps the GitHub issue's title, state, labels, assignee, and
+// milestone to an issue input, warning about names it can't match
+func (g *githubImporter) issueInput(ctx context.Context, issue github.Issue) (map[string]interface{}, []string, error) {
+	var warnings []string
+	teamID, err := g.resolver.teamID(ctx, g.teamKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	input := map[string]interface{}{
+		"title":  issue.Title,
+		"teamId": teamID,
+	}
+
+	if stateName := g.stateName(issue); stateName != "" {
+		stateID, err := g.resolver.stateID(ctx, g.teamKey, stateName)
+		if err != nil {
+			return nil, nil, err
+		}
+		input["stateId"] = stateID
+	}
+
+	var labelNames []string
+	for _, label := range issue.Labels {
+		name := label.Name
+		if mapped, ok := g.mapping.Labels[label.Name]; ok {
+			if mapped == "" {
+				continue
+			}
+			name = mapped
+		}
+		labelNames = append(labelNames, name)
+	}
+	if len(labelNames) > 0 {
+		labelIDs, missing, err := importLabelIDs(ctx, g.resolver, g.teamKey, labelNames)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, name := range missing {
+			warnings = append(warnings, fmt.Sprintf("no label '%s' in %s", name, g.teamKey))
+		}
+		input["labelIds"] = labelIDs
+	}
+
+	if len(issue.Assignees) > 0 {
+		login := issue.Assignees[0].Login
+		assignee := firstNonEmpty(g.mapping.Assignees[login], login)
+		if assigneeID, err := g.resolver.assigneeID(ctx, assignee); err != nil {
+			warnings = append(warnings, fmt.Sprintf("assignee @%s not found, leaving unassigned", login))
+		} else if assigneeID != nil {
+			input["assigneeId"] = *assigneeID
+		}
+	}
+
+	if issue.Milestone != nil {
+		project := firstNonEmpty(g.mapping.Milestones[issue.Milestone.Title], issue.Milestone.Title)
+		if projectID, err := g.resolver.projectID(ctx, project); err != nil {
+			warnings = append(warnings, fmt.Sprintf("no project for milestone '%s'", issue.Milestone.Title))
+		} else {
+			input["projectId"] = projectID
+		}
+	}
+	return input, warnings, nil
+}
+
+// stateName is the Linear state for the issue's GitHub state, or "" for the
+// team's default
+func (g *githubImporter) stateName(issue github.Issue) string {
+	if issue.State != "closed" {
+		return g.mapping.States["open"]
+	}
+	if issue.StateReason == "not_planned" {
+		return firstNonEmpty(g.mapping.States["not_planned"], "canceled")
+	}
+	return firstNonEmpty(g.mapping.States["closed"], "completed")
+}
+
+// importComments adds the comments not imported yet, returning how many were
+// added, the images re-uploaded, and any errors
+func (g *githubImporter) importComments(ctx context.Context, issue github.Issue, record *importRecord) (int, int, []string) {
+	comments, err := g.gh.ListComments(ctx, g.repo, issue.Number)
+	if err != nil {
+		return 0, 0, []string{fmt.Sprintf("failed to list comments: %v", err)}
+	}
+	if record.Comments == nil {
+		record.Comments = map[string]string{}
+	}
+
+	added, images := 0, 0
+	var errs []string
+	for _, comment := range comments {
+		id := strconv.FormatInt(comment.ID, 10)
+		if _, done := record.Comments[id]; done {
+			continue
+		}
+
+		body := githubCommentBody(comment)
+		if g.images {
+			var uploaded int
+			var imageErrs []string
+			body, uploaded, imageErrs = reuploadImages(ctx, g.resolver.client, body, github.IsAssetURL, g.gh.Download)
+			images += uploaded
+			errs = append(errs, imageErrs...)
+		}
+
+		created, err := g.resolver.client.CreateComment(ctx, record.ID, body)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to add comment %s: %v", comment.HTMLURL, err))
+			continue
+		}
+		record.Comments[id] = created.ID
+		added++
+	}
+	return added, images, errs
+}
+
+// githubDescription is the issue body, with a line linking back to GitHub
+func githubDescription(issue github.Issue) string {
+	author := "ghost"
+	if issue.User != nil {
+		author = issue.User.Login
+	}
+	header := fmt.Sprintf("_Imported from GitHub: [#%d](%s), opened by @%s on %s_",
+		issue.Number, issue.HTMLURL, author, issue.CreatedAt.Format("2006-01-02"))
+	body := strings.TrimSpace(github.ConvertHTMLImages(issue.Body))
+	if body == "" {
+		return header
+	}
+	return header + "\n\n" + body
+}
+
+// githubCommentBody credits the comment's GitHub author, since comments are
+// posted as the importing user
+func githubCommentBody(comment github.Comment) string {
+	author := "ghost"
+	if comment.User != nil {
+		author = comment.User.Login
+	}
+	return fmt.Sprintf("**@%s** commented on GitHub on %s:\n\n%s",
+		author, comment.CreatedAt.Format("2006-01-02"), strings.TrimSpace(github.ConvertHTMLImages(comment.Body)))
+}
+
+func init() {
+	importCmd.AddCommand(importGithubCmd)
+
+	importGithubCmd.Flags().String("repo", "", "Repository to import from, as OWNER/NAME (required)")
+	importGithubCmd.Flags().String("mapping", "", "YAML file mapping GitHub states, labels, assignees, and milestones to Linear")
+	importGithubCmd.Flags().StringP("team", "t", "", "Team to import into (default: the mapping's team, then default-team)")
+	importGithubCmd.Flags().String("state", "open", "GitHub issues to import: open, closed, or all")
+	importGithubCmd.Flags().StringSlice("label", nil, "Only issues with all of these GitHub labels")
+	importGithubCmd.Flags().String("since", "", "Only issues updated since this time (e.g. 2_weeks_ago, 2025-01-15)")
+	importGithubCmd.Flags().Int("limit", 0, "Import at most this many issues (0 for all)")
+	importGithubCmd.Flags().Bool("dry-run", false, "Resolve every issue without creating anything")
+	importGithubCmd.Flags().Bool("no-comments", false, "Don't import comments")
+	importGithubCmd.Flags().Bool("no-images", false, "Leave images linked to GitHub instead of re-uploading them")
+	importGithubCmd.Flags().String("store", "", "Mapping store file (default: ~/.linctl/imports/PROFILE/github.json)")
+	_ = importGithubCmd.MarkFlagRequired("repo")
+}
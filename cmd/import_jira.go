@@ -0,0 +1,609 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/jira"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// jiraMapping translates Jira names to Linear ones. Labels mapped to "" are
+// dropped; anything not listed is looked up under its own name.
+type jiraMapping struct {
+	Team       string            `yaml:"team"`
+	States     map[string]string `yaml:"states"`     // Jira status -> state name or type
+	Priorities map[string]string `yaml:"priorities"` // Jira priority -> none, urgent, high, normal, low, or 0-4
+	Labels     map[string]string `yaml:"labels"`
+	Assignees  map[string]string `yaml:"assignees"` // display name or username -> email, name, or @me
+	Epics      map[string]string `yaml:"epics"`     // epic key -> existing project
+	Sprints    map[string]int    `yaml:"sprints"`   // sprint name -> cycle number
+}
+
+// jiraPriorities are the Linear priorities of Jira's default priority schemes
+var jiraPriorities = map[string]int{
+	"highest":  1,
+	"blocker":  1,
+	"critical": 1,
+	"high":     2,
+	"major":    2,
+	"medium":   3,
+	"low":      4,
+	"lowest":   4,
+	"minor":    4,
+	"trivial":  4,
+}
+
+// jiraCanceledResolutions are resolutions that mean the work wasn't done
+var jiraCanceledResolutions = []string{
+	"won't do", "won't fix", "duplicate", "cannot reproduce", "declined", "incomplete", "obsolete",
+}
+
+var importJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Import issues from a Jira XML export",
+	Long: `Import Jira issues, with their comments, into a Linear team from the XML that
+Jira's search view exports (Export > XML).
+
+Read the export from a file with --file, or fetch it with --url: either the
+export's own URL, or the Jira site with --jql to choose the issues. Fetching
+uses JIRA_EMAIL and JIRA_API_TOKEN (Jira Cloud) or JIRA_TOKEN (a personal
+access token), which are also used to download attached images.
+
+Epics become projects and each issue joins its epic's project. Sprints become
+the cycle with the same name (or the cycle number given in the mapping).
+Sub-tasks become sub-issues. Descriptions and comments are converted from
+Jira wiki markup (or rendered HTML) to markdown, and attached images are
+re-uploaded to Linear. Statuses, priorities, labels, assignees, epics, and
+sprints are matched by name unless a --mapping file says otherwise:
+
+  team: ENG
+  states:
+    In Review: In Review
+    Won't Do: canceled
+  priorities:
+    Blocker: urgent
+  labels:
+    tech-debt: Tech Debt
+    triaged: ""           # don't import this label
+  assignees:
+    Jane Doe: jane@example.com
+  epics:
+    PROJ-5: Existing Project
+  sprints:
+    Sprint 12: 42
+
+Re-running the import updates issues and projects changed in Jira since the
+last run and adds new comments.
+
+Examples:
+  linctl import jira --file export.xml --team ENG --dry-run
+  linctl import jira --url https://acme.atlassian.net --jql 'project = PROJ' --mapping jira.yaml
+  linctl import jira --file export.xml --no-comments --json`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{noDefaultTeamAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		file, _ := cmd.Flags().GetString("file")
+		exportURL, _ := cmd.Flags().GetString("url")
+		jql, _ := cmd.Flags().GetString("jql")
+		if (file == "") == (exportURL == "") {
+			output.Error("Use one of --file or --url", plaintext, jsonOut)
+			exit(1)
+		}
+		if jql != "" && exportURL == "" {
+			output.Error("--jql needs --url", plaintext, jsonOut)
+			exit(1)
+		}
+
+		mapping := jiraMapping{}
+		if path, _ := cmd.Flags().GetString("mapping"); path != "" {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to read mapping: %v", err), plaintext, jsonOut)
+			}
+			if err := yaml.Unmarshal(data, &mapping); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to parse mapping %s: %v", path, err), plaintext, jsonOut)
+			}
+		}
+		for name, value := range mapping.Priorities {
+			if _, err := parsePriority(value); err != nil {
+				exitWithError(err, fmt.Sprintf("Invalid priority for '%s' in mapping: %v", name, err), plaintext, jsonOut)
+			}
+		}
+
+		teamFlag, _ := cmd.Flags().GetString("team")
+		teamKey, err := resolveTeamKey(firstNonEmpty(teamFlag, mapping.Team, viper.GetString("default-team")))
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if teamKey == "" {
+			output.Error("A team is required (use --team, the mapping's team, or default-team)", plaintext, jsonOut)
+			exit(1)
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		noComments, _ := cmd.Flags().GetBool("no-comments")
+		noImages, _ := cmd.Flags().GetBool("no-images")
+		limit, _ := cmd.Flags().GetInt("limit")
+		storeFlag, _ := cmd.Flags().GetString("store")
+
+		storePath, err := importStorePath("jira", storeFlag)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		store, err := loadImportStore(storePath)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		ctx := context.Background()
+		jiraClient := jira.NewClientFromEnv()
+		var issues []jira.Issue
+		if file != "" {
+			f, err := os.Open(file)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to open %s: %v", file, err), plaintext, jsonOut)
+			}
+			issues, err = jira.ParseXML(f)
+			f.Close()
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+		} else {
+			if jql != "" {
+				exportURL = jira.SearchURL(exportURL, jql, 1000)
+			}
+			issues, err = jiraClient.FetchXML(ctx, exportURL)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to fetch Jira issues: %v", err), plaintext, jsonOut)
+			}
+		}
+		if limit > 0 && len(issues) > limit {
+			issues = issues[:limit]
+		}
+		if len(issues) == 0 {
+			output.Info("No issues to import", plaintext, jsonOut)
+			return
+		}
+		sortJiraIssues(issues)
+
+		client := authenticatedClient(plaintext, jsonOut)
+		importer := &jiraImporter{
+			jira:     jiraClient,
+			resolver: &bulkResolver{client: client, cache: make(map[string]*bulkCacheEntry)},
+			store:    store,
+			mapping:  mapping,
+			teamKey:  teamKey,
+			dryRun:   dryRun,
+			comments: !noComments,
+			images:   !noImages,
+			planned:  make(map[string]bool),
+		}
+		results := make([]sourceImportResult, 0, len(issues))
+		for i, issue := range issues {
+			var result sourceImportResult
+			if issue.IsEpic() {
+				result = importer.importEpic(ctx, issue)
+			} else {
+				result = importer.importIssue(ctx, issue)
+			}
+			results = append(results, result)
+			if !jsonOut && !plaintext {
+				printImportProgress(i+1, len(issues), result)
+			}
+		}
+
+		printImportSummary(results, dryRun, plaintext, jsonOut)
+		for _, result := range results {
+			if result.Status == "failed" {
+				exit(1)
+			}
+		}
+	},
+}
+
+// sortJiraIssues puts epics first, so issues can join their projects, and
+// sub-tasks last, so their parents exist
+func sortJiraIssues(issues []jira.Issue) {
+	epics := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.IsEpic() {
+			epics[issue.Key] = true
+		}
+	}
+	rank := func(issue jira.Issue) int {
+		switch {
+		case issue.IsEpic():
+			return 0
+		case issue.Parent == "" || epics[issue.Parent]:
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return rank(issues[i]) < rank(issues[j]) })
+}
+
+// jiraImporter creates or updates the Linear project for each epic and the
+// Linear issue for each other Jira issue
+type jiraImporter struct {
+	jira     *jira.Client
+	resolver *bulkResolver
+	store    *importStore
+	mapping  jiraMapping
+	teamKey  string
+	dryRun   bool
+	comments bool
+	images   bool
+	planned  map[string]bool // keys a dry run would have imported
+}
+
+// importEpic creates or updates the project for an epic, or links the epic
+// to an existing project named in the mapping or with the same name
+func (j *jiraImporter) importEpic(ctx context.Context, epic jira.Issue) sourceImportResult {
+	result := sourceImportResult{Source: epic.Key, URL: epic.Link, Title: epic.Summary}
+	fail := func(err error) sourceImportResult {
+		result.Status = "failed"
+		result.Error = err.Error()
+		return result
+	}
+
+	record := j.store.Projects[epic.Key]
+	if record != nil {
+		result.Identifier = record.Identifier
+		if !epic.Updated.After(record.SourceUpdatedAt) {
+			result.Status = "unchanged"
+			return result
+		}
+	} else {
+		name := firstNonEmpty(j.mapping.Epics[epic.Key], epic.Summary)
+		if projectID, err := j.resolver.projectID(ctx, name); err == nil {
+			// An existing project is linked to, not changed
+			j.store.Projects[epic.Key] = &importRecord{ID: projectID, Identifier: name, SourceUpdatedAt: epic.Updated, ImportedAt: time.Now().UTC()}
+			result.Identifier = name
+			result.Status = "unchanged"
+			if !j.dryRun {
+				if err := j.store.save(); err != nil {
+					return fail(fmt.Errorf("failed to save import store: %v", err))
+				}
+			}
+			return result
+		} else if _, mapped := j.mapping.Epics[epic.Key]; mapped {
+			return fail(err)
+		}
+	}
+
+	if j.dryRun {
+		j.planned[epic.Key] = true
+		result.Status = "planned"
+		return result
+	}
+
+	teamID, err := j.resolver.teamID(ctx, j.teamKey)
+	if err != nil {
+		return fail(err)
+	}
+	content, images, errs := j.markdown(ctx, epic, epic.Description)
+	result.Images = images
+	result.Warnings = errs
+	input := map[string]interface{}{
+		"name":        epic.Summary,
+		"description": "Imported from Jira " + epic.Key,
+		"content":     jiraHeader(epic) + "\n\n" + content,
+	}
+
+	if record == nil {
+		input["teamIds"] = []string{teamID}
+		project, err := j.resolver.client.CreateProject(ctx, input)
+		if err != nil {
+			return fail(fmt.Errorf("failed to create project: %v", err))
+		}
+		record = &importRecord{ID: project.ID, Identifier: project.Name}
+		j.store.Projects[epic.Key] = record
+		result.Status = "created"
+	} else {
+		if _, err := j.resolver.client.UpdateProject(ctx, record.ID, input); err != nil {
+			return fail(fmt.Errorf("failed to update project %s: %v", record.Identifier, err))
+		}
+		result.Status = "updated"
+	}
+	result.Identifier = record.Identifier
+	record.SourceUpdatedAt = epic.Updated
+	record.ImportedAt = time.Now().UTC()
+	if err := j.store.save(); err != nil {
+		return fail(fmt.Errorf("failed to save import store: %v", err))
+	}
+	return result
+}
+
+// importIssue imports one Jira issue, skipping it when it hasn't changed
+// since the last import
+func (j *jiraImporter) importIssue(ctx context.Context, issue jira.Issue) sourceImportResult {
+	result := sourceImportResult{Source: issue.Key, URL: issue.Link, Title: issue.Summary}
+	fail := func(err error) sourceImportResult {
+		result.Status = "failed"
+		result.Error = err.Error()
+		return result
+	}
+
+	record := j.store.Issues[issue.Key]
+	if record != nil {
+		result.Identifier = record.Identifier
+		if !issue.Updated.After(record.SourceUpdatedAt) {
+			result.Status = "unchanged"
+			return result
+		}
+	}
+
+	input, warnings, err := j.issueInput(ctx, issue)
+	if err != nil {
+		return fail(err)
+	}
+	result.Warnings = warnings
+
+	if j.dryRun {
+		j.planned[issue.Key] = true
+		result.Status = "planned"
+		result.Comments = len(issue.Comments)
+		return result
+	}
+
+	description, images, errs := j.markdown(ctx, issue, issue.Description)
+	result.Images = images
+	result.Warnings = append(result.Warnings, errs...)
+	input["description"] = jiraHeader(issue) + "\n\n" + description
+
+	record, result.Status, err = j.store.upsertIssue(ctx, j.resolver.client, issue.Key, input)
+	if err != nil {
+		return fail(err)
+	}
+	result.Identifier = record.Identifier
+
+	if j.comments && len(issue.Comments) > 0 {
+		if record.Comments == nil {
+			record.Comments = map[string]string{}
+		}
+		var commentErrs []string
+		for _, comment := range issue.Comments {
+			if _, done := record.Comments[comment.ID]; done {
+				continue
+			}
+			body, images, errs := j.markdown(ctx, issue, comment.Body)
+			result.Images += images
+			result.Warnings = append(result.Warnings, errs...)
+
+			header := fmt.Sprintf("**%s** commented on Jira on %s:", firstNonEmpty(comment.Author, "Unknown"), comment.Created.Format("2006-01-02"))
+			created, err := j.resolver.client.CreateComment(ctx, record.ID, header+"\n\n"+body)
+			if err != nil {
+				commentErrs = append(commentErrs, fmt.Sprintf("failed to add comment %s: %v", comment.ID, err))
+				continue
+			}
+			record.Comments[comment.ID] = created.ID
+			result.Comments++
+		}
+		result.Warnings = append(result.Warnings, commentErrs...)
+		if len(commentErrs) > 0 {
+			// Leave SourceUpdatedAt alone so the next run retries the missing comments
+			if err := j.store.save(); err != nil {
+				return fail(fmt.Errorf("failed to save import store: %v", err))
+			}
+			return result
+		}
+	}
+
+	record.SourceUpdatedAt = issue.Updated
+	if err := j.store.save(); err != nil {
+		return fail(fmt.Errorf("failed to save import store: %v", err))
+	}
+	return result
+}
+
+// issueInput maps the Jira issue's fields to an issue input, warning about
+// names it can't match
+func (j *jiraImporter) issueInput(ctx context.Context, issue jira.Issue) (map[string]interface{}, []string, error) {
+	var warnings []string
+	teamID, err := j.resolver.teamID(ctx, j.teamKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	input := map[string]interface{}{
+		"title":  issue.Summary,
+		"teamId": teamID,
+	}
+
+	if stateID, err := j.stateID(ctx, issue); err != nil {
+		return nil, nil, err
+	} else if stateID != "" {
+		input["stateId"] = stateID
+	}
+
+	if issue.Priority != "" {
+		if value, ok := j.mapping.Priorities[issue.Priority]; ok {
+			input["priority"], _ = parsePriority(value)
+		} else if priority, ok := jiraPriorities[strings.ToLower(issue.Priority)]; ok {
+			input["priority"] = priority
+		}
+	}
+	if issue.StoryPoints != nil {
+		input["estimate"] = int(math.Round(*issue.StoryPoints))
+	}
+	if issue.Due != nil {
+		input["dueDate"] = issue.Due.Format("2006-01-02")
+	}
+
+	var labelNames []string
+	for _, label := range issue.Labels {
+		name := label
+		if mapped, ok := j.mapping.Labels[label]; ok {
+			if mapped == "" {
+				continue
+			}
+			name = mapped
+		}
+		labelNames = append(labelNames, name)
+	}
+	if len(labelNames) > 0 {
+		labelIDs, missing, err := importLabelIDs(ctx, j.resolver, j.teamKey, labelNames)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, name := range missing {
+			warnings = append(warnings, fmt.Sprintf("no label '%s' in %s", name, j.teamKey))
+		}
+		input["labelIds"] = labelIDs
+	}
+
+	if issue.Assignee != nil {
+		assignee := firstNonEmpty(j.mapping.Assignees[issue.Assignee.Name], j.mapping.Assignees[issue.Assignee.Username], issue.Assignee.Name)
+		if assigneeID, err := j.resolver.assigneeID(ctx, assignee); err != nil {
+			warnings = append(warnings, fmt.Sprintf("assignee '%s' not found, leaving unassigned", issue.Assignee.Name))
+		} else if assigneeID != nil {
+			input["assigneeId"] = *assigneeID
+		}
+	}
+
+	// The epic is the Epic Link, or the parent when the parent is an epic
+	epic := issue.EpicLink
+	if epic == "" && j.store.Projects[issue.Parent] != nil {
+		epic = issue.Parent
+	}
+	if project := j.store.Projects[epic]; project != nil {
+		input["projectId"] = project.ID
+	} else if epic != "" && !j.planned[epic] {
+		warnings = append(warnings, fmt.Sprintf("epic %s hasn't been imported; not adding to a project", epic))
+	}
+
+	if issue.Parent != "" && issue.Parent != epic {
+		if parent := j.store.Issues[issue.Parent]; parent != nil {
+			input["parentId"] = parent.ID
+		} else if !j.planned[issue.Parent] {
+			warnings = append(warnings, fmt.Sprintf("parent %s hasn't been imported; importing as a top-level issue", issue.Parent))
+		}
+	}
+
+	if len(issue.Sprints) > 0 {
+		// An issue carried over between sprints lists all of them; the last is current
+		sprint := issue.Sprints[len(issue.Sprints)-1]
+		if cycleID, err := j.cycleID(ctx, sprint); err != nil {
+			warnings = append(warnings, err.Error())
+		} else {
+			input["cycleId"] = cycleID
+		}
+	}
+	return input, warnings, nil
+}
+
+// stateID picks the state from the mapping, then a state with the Jira
+// status's name, then the status category (and resolution, for done issues).
+// It returns "" for new issues with no matching state, for the team default.
+func (j *jiraImporter) stateID(ctx context.Context, issue jira.Issue) (string, error) {
+	if mapped := j.mapping.States[issue.Status]; mapped != "" {
+		return j.resolver.stateID(ctx, j.teamKey, mapped)
+	}
+	if issue.Status != "" {
+		if stateID, err := j.resolver.stateID(ctx, j.teamKey, issue.Status); err == nil {
+			return stateID, nil
+		}
+	}
+
+	switch issue.StatusCategory {
+	case "indeterminate":
+		return j.resolver.stateID(ctx, j.teamKey, "started")
+	case "done":
+		if containsString(jiraCanceledResolutions, strings.ToLower(issue.Resolution)) {
+			return j.resolver.stateID(ctx, j.teamKey, "canceled")
+		}
+		return j.resolver.stateID(ctx, j.teamKey, "completed")
+	}
+	return "", nil
+}
+
+// cycleID finds the team's cycle for a sprint: the cycle number in the
+// mapping, or the cycle with the sprint's name
+func (j *jiraImporter) cycleID(ctx context.Context, sprint string) (string, error) {
+	value, err := j.resolver.memo("cycle:"+j.teamKey+":"+strings.ToLower(sprint), func() (interface{}, error) {
+		if number, ok := j.mapping.Sprints[sprint]; ok {
+			cycle, err := j.resolver.client.GetCycleByNumber(ctx, j.teamKey, number)
+			if err != nil || cycle == nil {
+				return nil, fmt.Errorf("cycle #%d for sprint '%s' not found in %s", number, sprint, j.teamKey)
+			}
+			return cycle.ID, nil
+		}
+		filter := map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": sprint}}
+		cycles, err := j.resolver.client.GetTeamCycles(ctx, j.teamKey, 1, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up cycle for sprint '%s': %v", sprint, err)
+		}
+		if len(cycles.Nodes) == 0 {
+			return nil, fmt.Errorf("no cycle named '%s' in %s (map it to a cycle number under sprints)", sprint, j.teamKey)
+		}
+		return cycles.Nodes[0].ID, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+// markdown points the images in converted markdown at the issue's
+// attachments and, unless --no-images, re-uploads them to Linear
+func (j *jiraImporter) markdown(ctx context.Context, issue jira.Issue, markdown string) (string, int, []string) {
+	base := issue.BaseURL()
+	replacements := make(map[string]string)
+	for _, img := range files.ExtractImagesFromMarkdown(markdown) {
+		switch {
+		case strings.HasPrefix(img.URL, "/") && base != "":
+			replacements[img.URL] = base + img.URL
+		case !strings.Contains(img.URL, "://"):
+			if attachmentURL := issue.AttachmentURL(img.URL); attachmentURL != "" {
+				replacements[img.URL] = attachmentURL
+			}
+		}
+	}
+	markdown = files.RewriteImageURLs(markdown, replacements)
+
+	if !j.images || base == "" {
+		return markdown, 0, nil
+	}
+	isAttachment := func(u string) bool {
+		return strings.HasPrefix(u, base+"/secure/attachment/") || strings.HasPrefix(u, base+"/rest/api/")
+	}
+	return reuploadImages(ctx, j.resolver.client, markdown, isAttachment, j.jira.Download)
+}
+
+// jiraHeader links back to the Jira issue
+func jiraHeader(issue jira.Issue) string {
+	reporter := "unknown"
+	if issue.Reporter != nil {
+		reporter = issue.Reporter.Name
+	}
+	return fmt.Sprintf("_Imported from Jira: [%s](%s), reported by %s on %s_",
+		issue.Key, issue.Link, reporter, issue.Created.Format("2006-01-02"))
+}
+
+func init() {
+	importCmd.AddCommand(importJiraCmd)
+
+	importJiraCmd.Flags().String("file", "", "Jira XML export to import")
+	importJiraCmd.Flags().String("url", "", "URL of a Jira XML export, or of the Jira site with --jql")
+	importJiraCmd.Flags().String("jql", "", "Issues to fetch from the site given by --url")
+	importJiraCmd.Flags().String("mapping", "", "YAML file mapping Jira statuses, priorities, labels, assignees, epics, and sprints to Linear")
+	importJiraCmd.Flags().StringP("team", "t", "", "Team to import into (default: the mapping's team, then default-team)")
+	importJiraCmd.Flags().Int("limit", 0, "Import at most this many issues (0 for all)")
+	importJiraCmd.Flags().Bool("dry-run", false, "Resolve every issue without creating anything")
+	importJiraCmd.Flags().Bool("no-comments", false, "Don't import comments")
+	importJiraCmd.Flags().Bool("no-images", false, "Leave images linked to Jira instead of re-uploading them")
+	importJiraCmd.Flags().String("store", "", "Mapping store file (default: ~/.linctl/imports/PROFILE/jira.json)")
+}
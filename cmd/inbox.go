@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Notification kinds accepted by --type, grouping Linear's notification types
+const (
+	notificationAssigned  = "assigned"
+	notificationMentioned = "mentioned"
+	notificationComment   = "comment"
+	notificationStatus    = "status"
+	notificationOther     = "other"
+)
+
+var inboxCmd = &cobra.Command{
+	Use:     "inbox",
+	Aliases: []string{"notifications"},
+	Short:   "Triage your Linear inbox",
+	Long: `List your Linear notifications and mark them read, unread, or archived.
+
+Notifications are picked by ID or by issue identifier (every notification about
+that issue). --type narrows them to assigned, mentioned, comment, status, or
+other notifications, or to a Linear notification type such as issueNewComment.
+
+Examples:
+  linctl inbox list --unread-only
+  linctl inbox list --type mentioned,comment
+  linctl inbox read ENG-123
+  linctl inbox read --all --type status
+  linctl inbox archive --all --read`,
+}
+
+var inboxListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List notifications, newest first",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+		notifications, err := loadInbox(context.Background(), client, includeArchived)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list notifications: %v", err), plaintext, jsonOut)
+		}
+
+		notifications = filterNotifications(cmd, notifications)
+		if limit, _ := cmd.Flags().GetInt("limit"); limit > 0 && len(notifications) > limit {
+			notifications = notifications[:limit]
+		}
+
+		if jsonOut {
+			output.JSON(notifications)
+			return
+		}
+		if len(notifications) == 0 {
+			output.Info("Inbox zero: no notifications found", plaintext, jsonOut)
+			return
+		}
+
+		headers := []string{"ID", "Type", "Issue", "Title", "From", "When", "Read"}
+		rows := make([][]string, len(notifications))
+		unread := 0
+		for i, n := range notifications {
+			identifier, title := "", ""
+			if n.Issue != nil {
+				identifier, title = n.Issue.Identifier, n.Issue.Title
+			}
+			actor := ""
+			if n.Actor != nil {
+				actor = n.Actor.Name
+			}
+			read := "yes"
+			if n.ReadAt == nil {
+				read = "no"
+				unread++
+			}
+			rows[i] = []string{n.ID, notificationKind(n.Type), identifier, title, actor, formatTimeAgo(n.CreatedAt), read}
+		}
+
+		if plaintext {
+			fmt.Println(strings.Join(headers, "\t"))
+			for _, row := range rows {
+				fmt.Println(strings.Join(row, "\t"))
+			}
+			return
+		}
+
+		for _, row := range rows {
+			if row[6] == "no" {
+				row[6] = color.New(color.FgCyan, color.Bold).Sprint("● unread")
+				row[2] = color.New(color.FgCyan, color.Bold).Sprint(row[2])
+			} else {
+				row[6] = color.New(color.FgWhite, color.Faint).Sprint("read")
+			}
+		}
+		output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, jsonOut)
+		fmt.Printf("\n%s %d notifications (%d unread)\n", color.New(color.FgGreen).Sprint("✓"), len(notifications), unread)
+	},
+}
+
+var inboxReadCmd = &cobra.Command{
+	Use:   "read [ID|ISSUE-ID...]",
+	Short: "Mark notifications as read",
+	Long: `Mark notifications as read, given by ID or issue identifier. With --all, every
+unread notification (narrowed by --type) is marked read.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		updateNotifications(cmd, args, "read", func(ctx context.Context, client *api.Client, n api.Notification) error {
+			return client.UpdateNotification(ctx, n.ID, map[string]interface{}{"readAt": time.Now().UTC().Format(time.RFC3339)})
+		})
+	},
+}
+
+var inboxUnreadCmd = &cobra.Command{
+	Use:   "unread ID|ISSUE-ID...",
+	Short: "Mark notifications as unread",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		updateNotifications(cmd, args, "unread", func(ctx context.Context, client *api.Client, n api.Notification) error {
+			return client.UpdateNotification(ctx, n.ID, map[string]interface{}{"readAt": nil})
+		})
+	},
+}
+
+var inboxArchiveCmd = &cobra.Command{
+	Use:   "archive [ID|ISSUE-ID...]",
+	Short: "Archive notifications, removing them from the inbox",
+	Long: `Archive notifications given by ID or issue identifier. With --all, every
+notification (narrowed by --type, or to read ones with --read) is archived.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		updateNotifications(cmd, args, "archived", func(ctx context.Context, client *api.Client, n api.Notification) error {
+			return client.ArchiveNotification(ctx, n.ID)
+		})
+	},
+}
+
+// updateNotifications applies change to the notifications named by args, or
+// to every matching one with --all, and reports how many became state
+func updateNotifications(cmd *cobra.Command, args []string, state string, change func(context.Context, *api.Client, api.Notification) error) {
+	plaintext := viper.GetBool("plaintext")
+	jsonOut := viper.GetBool("json")
+
+	all, _ := cmd.Flags().GetBool("all")
+	if all == (len(args) > 0) {
+		output.Error("Give notification IDs or issue identifiers, or --all", plaintext, jsonOut)
+		exit(1)
+	}
+
+	client := authenticatedClient(plaintext, jsonOut)
+	ctx := context.Background()
+
+	notifications, err := loadInbox(ctx, client, false)
+	if err != nil {
+		exitWithError(err, fmt.Sprintf("Failed to list notifications: %v", err), plaintext, jsonOut)
+	}
+
+	var selected []api.Notification
+	if all {
+		selected = filterNotifications(cmd, notifications)
+	} else {
+		selected, err = selectNotifications(notifications, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		selected = filterNotifications(cmd, selected)
+	}
+
+	// Skip notifications already in the requested state
+	pending := []api.Notification{}
+	for _, n := range selected {
+		if (state == "read" && n.ReadAt != nil) || (state == "unread" && n.ReadAt == nil) {
+			continue
+		}
+		pending = append(pending, n)
+	}
+
+	changed := []string{}
+	failures := []string{}
+	var undoChanges []undoChange
+	for _, n := range pending {
+		if err := change(ctx, client, n); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", n.ID, err))
+			continue
+		}
+		changed = append(changed, n.ID)
+		if state == "archived" {
+			label := n.ID
+			if n.Issue != nil {
+				label = n.Issue.Identifier
+			}
+			undoChanges = append(undoChanges, undoChange{
+				Kind:    undoNotificationArchive,
+				Target:  n.ID,
+				Label:   label,
+				Summary: "notification archived",
+			})
+		}
+	}
+	recordUndo(cmd, args, undoChanges)
+
+	if jsonOut {
+		result := map[string]interface{}{state: len(changed), "ids": changed}
+		if len(failures) > 0 {
+			result["errors"] = failures
+		}
+		output.JSON(result)
+	} else {
+		for _, failure := range failures {
+			fmt.Fprintf(os.Stderr, "%s %s\n", color.New(color.FgRed).Sprint("✗"), failure)
+		}
+		if len(pending) == 0 {
+			output.Info(fmt.Sprintf("No notifications to mark %s", state), plaintext, jsonOut)
+		} else {
+			output.Success(fmt.Sprintf("%d notification(s) %s", len(changed), markedAs(state)), plaintext, jsonOut)
+		}
+	}
+	exitOnFailures(len(changed), len(failures))
+}
+
+// markedAs phrases a notification state for the summary line
+func markedAs(state string) string {
+	if state == "archived" {
+		return state
+	}
+	return "marked " + state
+}
+
+// loadInbox fetches every notification, newest first
+func loadInbox(ctx context.Context, client *api.Client, includeArchived bool) ([]api.Notification, error) {
+	notifications, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize}, func(ctx context.Context, first int, after string) ([]api.Notification, api.PageInfo, error) {
+		page, err := client.GetNotifications(ctx, first, after, includeArchived)
+		if err != nil {
+			return nil, api.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(notifications, func(i, j int) bool {
+		return notifications[i].CreatedAt.After(notifications[j].CreatedAt)
+	})
+	return notifications, nil
+}
+
+// filterNotifications keeps the notifications matching --type, --unread-only,
+// and --read
+func filterNotifications(cmd *cobra.Command, notifications []api.Notification) []api.Notification {
+	types, _ := cmd.Flags().GetStringSlice("type")
+	unreadOnly, _ := cmd.Flags().GetBool("unread-only")
+	readOnly, _ := cmd.Flags().GetBool("read")
+
+	filtered := []api.Notification{}
+	for _, n := range notifications {
+		if unreadOnly && n.ReadAt != nil {
+			continue
+		}
+		if readOnly && n.ReadAt == nil {
+			continue
+		}
+		if len(types) > 0 {
+			matched := false
+			for _, t := range types {
+				t = strings.TrimSpace(t)
+				if strings.EqualFold(t, notificationKind(n.Type)) || strings.EqualFold(t, n.Type) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		filtered = append(filtered, n)
+	}
+	return filtered
+}
+
+// selectNotifications picks notifications by ID, or every notification about
+// an issue given by identifier
+func selectNotifications(notifications []api.Notification, refs []string) ([]api.Notification, error) {
+	selected := []api.Notification{}
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		found := false
+		for _, n := range notifications {
+			matches := n.ID == ref
+			if issueIdentifierPattern.MatchString(ref) {
+				matches = n.Issue != nil && strings.EqualFold(n.Issue.Identifier, ref)
+			}
+			if !matches {
+				continue
+			}
+			found = true
+			if !seen[n.ID] {
+				seen[n.ID] = true
+				selected = append(selected, n)
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no notification in your inbox matches '%s'", ref)
+		}
+	}
+	return selected, nil
+}
+
+// notificationKind groups a Linear notification type (issueAssignedToYou,
+// issueCommentMention, ...) under the names --type accepts
+func notificationKind(notificationType string) string {
+	switch {
+	case notificationType == "issueAssignedToYou":
+		return notificationAssigned
+	case strings.Contains(notificationType, "Mention"):
+		return notificationMentioned
+	case strings.Contains(notificationType, "Comment"):
+		return notificationComment
+	case strings.Contains(notificationType, "Status"), notificationType == "issueCompleted", notificationType == "issueBlocking":
+		return notificationStatus
+	}
+	return notificationOther
+}
+
+func init() {
+	rootCmd.AddCommand(inboxCmd)
+	inboxCmd.AddCommand(inboxListCmd)
+	inboxCmd.AddCommand(inboxReadCmd)
+	inboxCmd.AddCommand(inboxUnreadCmd)
+	inboxCmd.AddCommand(inboxArchiveCmd)
+
+	for _, c := range []*cobra.Command{inboxListCmd, inboxReadCmd, inboxUnreadCmd, inboxArchiveCmd} {
+		c.Flags().StringSlice("type", nil, "Only notifications of these kinds: assigned, mentioned, comment, status, other, or a Linear notification type")
+	}
+	inboxListCmd.Flags().Bool("unread-only", false, "Only unread notifications")
+	inboxListCmd.Flags().IntP("limit", "l", 50, "Maximum number of notifications to show (0 for all)")
+	inboxListCmd.Flags().Bool("include-archived", false, "Include archived notifications")
+	inboxReadCmd.Flags().Bool("all", false, "Mark every unread notification as read")
+	inboxArchiveCmd.Flags().Bool("all", false, "Archive every notification")
+	inboxArchiveCmd.Flags().Bool("read", false, "Only archive notifications that have been read")
+}
@@ -0,0 +1,507 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// initiativeStatuses are the statuses Linear gives initiatives, in order
+var initiativeStatuses = []string{"Planned", "Active", "Completed"}
+
+var initiativeCmd = &cobra.Command{
+	Use:     "initiative",
+	Aliases: []string{"initiatives", "init"},
+	Short:   "Manage Linear initiatives",
+	Long: `Manage initiatives, the company-level goals that group projects.
+
+Examples:
+  linctl initiative list
+  linctl initiative view "Self-serve onboarding"
+  linctl initiative create "Self-serve onboarding" --owner me --target-date 2025-12-31
+  linctl initiative update "Self-serve onboarding" --status active --project "Billing v2"
+  linctl roadmap --gantt`,
+}
+
+var initiativeListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List initiatives",
+	Long:    `List initiatives with their status, health, owner, target date, and projects. Completed initiatives are hidden unless --include-completed or --status is given.`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		initiatives, err := loadInitiatives(context.Background(), client)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list initiatives: %v", err), plaintext, jsonOut)
+		}
+
+		status, _ := cmd.Flags().GetString("status")
+		includeCompleted, _ := cmd.Flags().GetBool("include-completed")
+		initiatives, err = filterInitiatives(initiatives, status, includeCompleted)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(initiatives)
+			return
+		}
+		if len(initiatives) == 0 {
+			output.Info("No initiatives found", plaintext, jsonOut)
+			return
+		}
+
+		headers := []string{"Name", "Status", "Health", "Owner", "Target", "Projects", "ID"}
+		rows := make([][]string, len(initiatives))
+		for i, initiative := range initiatives {
+			owner := ""
+			if initiative.Owner != nil {
+				owner = initiative.Owner.Name
+			}
+			target := ""
+			if initiative.TargetDate != nil {
+				target = *initiative.TargetDate
+			}
+			projects := 0
+			if initiative.Projects != nil {
+				projects = len(initiative.Projects.Nodes)
+			}
+			health := formatHealth(initiative.Health)
+			if !plaintext {
+				health = healthColor(initiative.Health).Sprint(health)
+			}
+			rows[i] = []string{initiative.Name, initiative.Status, health, owner, target, fmt.Sprintf("%d", projects), initiative.ID}
+		}
+
+		output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, jsonOut)
+		if !plaintext {
+			fmt.Printf("\n%s %d initiatives\n", color.New(color.FgGreen).Sprint("✓"), len(initiatives))
+		}
+	},
+}
+
+var initiativeViewCmd = &cobra.Command{
+	Use:     "view INITIATIVE",
+	Aliases: []string{"get", "show"},
+	Short:   "Show an initiative and its projects",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		initiative, err := resolveInitiative(context.Background(), client, args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(initiative)
+			return
+		}
+
+		var projects []api.Project
+		if initiative.Projects != nil {
+			projects = initiative.Projects.Nodes
+		}
+
+		if plaintext {
+			fmt.Printf("# %s\n\n", initiative.Name)
+			if initiative.Description != "" {
+				fmt.Printf("%s\n\n", initiative.Description)
+			}
+			fmt.Printf("- **ID**: %s\n", initiative.ID)
+			fmt.Printf("- **Status**: %s\n", initiative.Status)
+			if initiative.Health != "" {
+				fmt.Printf("- **Health**: %s\n", formatHealth(initiative.Health))
+			}
+			if initiative.Owner != nil {
+				fmt.Printf("- **Owner**: %s\n", initiative.Owner.Name)
+			}
+			if initiative.TargetDate != nil {
+				fmt.Printf("- **Target Date**: %s\n", *initiative.TargetDate)
+			}
+			fmt.Printf("- **URL**: %s\n", initiative.URL)
+			if initiative.Content != "" {
+				fmt.Printf("\n## Content\n%s\n", initiative.Content)
+			}
+			fmt.Printf("\n## Projects\n")
+			for _, project := range projects {
+				fmt.Printf("- %s (%s, %.0f%%", project.Name, project.State, project.Progress*100)
+				if project.TargetDate != nil {
+					fmt.Printf(", target %s", *project.TargetDate)
+				}
+				if project.Health != "" {
+					fmt.Printf(", %s", formatHealth(project.Health))
+				}
+				fmt.Println(")")
+			}
+			return
+		}
+
+		fmt.Println()
+		fmt.Printf("%s %s\n", color.New(color.FgCyan, color.Bold).Sprint("🎯 Initiative:"), initiative.Name)
+		fmt.Println(strings.Repeat("─", 50))
+		if initiative.Description != "" {
+			fmt.Printf("%s\n\n", initiative.Description)
+		}
+
+		label := color.New(color.Bold)
+		fmt.Printf("%s %s\n", label.Sprint("Status:"), initiative.Status)
+		if initiative.Health != "" {
+			fmt.Printf("%s %s\n", label.Sprint("Health:"), healthColor(initiative.Health).Sprint(formatHealth(initiative.Health)))
+		}
+		if initiative.Owner != nil {
+			fmt.Printf("%s %s\n", label.Sprint("Owner:"), initiative.Owner.Name)
+		}
+		if initiative.TargetDate != nil {
+			fmt.Printf("%s %s\n", label.Sprint("Target Date:"), *initiative.TargetDate)
+		}
+
+		if strings.TrimSpace(initiative.Content) != "" {
+			fmt.Println()
+			fmt.Print(output.RenderMarkdown(initiative.Content))
+		}
+
+		fmt.Printf("\n%s\n", label.Sprintf("Projects (%d):", len(projects)))
+		if len(projects) == 0 {
+			fmt.Printf("  %s\n", color.New(color.FgWhite, color.Faint).Sprint("No projects yet; add one with 'initiative update --project'"))
+		}
+		for _, project := range projects {
+			target := ""
+			if project.TargetDate != nil {
+				target = " → " + *project.TargetDate
+			}
+			health := ""
+			if project.Health != "" {
+				health = " " + healthColor(project.Health).Sprint(formatHealth(project.Health))
+			}
+			fmt.Printf("  • %s %s%s%s\n",
+				color.New(color.FgCyan).Sprint(project.Name),
+				color.New(color.FgWhite, color.Faint).Sprintf("(%s, %.0f%%)", project.State, project.Progress*100),
+				target, health)
+		}
+
+		if initiative.URL != "" {
+			fmt.Printf("\n%s\n", color.New(color.FgBlue, color.Underline).Sprint(initiative.URL))
+		}
+		fmt.Println()
+	},
+}
+
+var initiativeCreateCmd = &cobra.Command{
+	Use:     "create NAME",
+	Aliases: []string{"new"},
+	Short:   "Create an initiative",
+	Long: `Create an initiative, optionally adding existing projects to it.
+
+Examples:
+  linctl initiative create "Self-serve onboarding" --owner me --target-date 2025-12-31
+  linctl initiative create "Enterprise" --status active --project "SSO,Audit log"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		input, err := initiativeInputFromFlags(ctx, cmd, client)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		input["name"] = args[0]
+
+		initiative, err := client.CreateInitiative(ctx, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create initiative: %v", err), plaintext, jsonOut)
+		}
+		initiative = addInitiativeProjects(ctx, cmd, client, initiative, plaintext, jsonOut)
+
+		if jsonOut {
+			output.JSON(initiative)
+		} else if plaintext {
+			fmt.Printf("Created initiative %s\n", initiative.Name)
+			fmt.Printf("ID: %s\n", initiative.ID)
+			fmt.Printf("URL: %s\n", initiative.URL)
+		} else {
+			fmt.Printf("%s Created initiative %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(initiative.Name))
+			if initiative.Projects != nil && len(initiative.Projects.Nodes) > 0 {
+				fmt.Printf("  Projects: %d\n", len(initiative.Projects.Nodes))
+			}
+			fmt.Printf("  %s\n", color.New(color.FgBlue, color.Underline).Sprint(initiative.URL))
+		}
+	},
+}
+
+var initiativeUpdateCmd = &cobra.Command{
+	Use:     "update INITIATIVE",
+	Aliases: []string{"edit"},
+	Short:   "Update an initiative",
+	Long: `Update an initiative's name, summary, description, owner, status, or target
+date, or add projects to it. Use 'none' to clear the owner or target date.
+
+Examples:
+  linctl initiative update "Self-serve onboarding" --status completed
+  linctl initiative update INITIATIVE-ID --target-date 2026-03-31 --owner jane@example.com
+  linctl initiative update "Enterprise" --project "SCIM provisioning"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		initiative, err := resolveInitiative(ctx, client, args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		input, err := initiativeInputFromFlags(ctx, cmd, client)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if cmd.Flags().Changed("name") {
+			name, _ := cmd.Flags().GetString("name")
+			input["name"] = name
+		}
+		if len(input) == 0 && !cmd.Flags().Changed("project") {
+			output.Error("No updates specified. Use flags to specify what to update.", plaintext, jsonOut)
+			exit(1)
+		}
+
+		if len(input) > 0 {
+			initiative, err = client.UpdateInitiative(ctx, initiative.ID, input)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to update initiative: %v", err), plaintext, jsonOut)
+			}
+		}
+		initiative = addInitiativeProjects(ctx, cmd, client, initiative, plaintext, jsonOut)
+
+		if jsonOut {
+			output.JSON(initiative)
+		} else {
+			output.Success(fmt.Sprintf("Updated initiative %s", initiative.Name), plaintext, jsonOut)
+		}
+	},
+}
+
+// loadInitiatives fetches every initiative with its projects
+func loadInitiatives(ctx context.Context, client *api.Client) ([]api.Initiative, error) {
+	initiatives, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: 50}, func(ctx context.Context, first int, after string) ([]api.Initiative, api.PageInfo, error) {
+		page, err := client.GetInitiatives(ctx, nil, first, after)
+		if err != nil {
+			return nil, api.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	return initiatives, err
+}
+
+// filterInitiatives keeps initiatives with the given status, or those not yet
+// completed unless includeCompleted
+func filterInitiatives(initiatives []api.Initiative, status string, includeCompleted bool) ([]api.Initiative, error) {
+	if status != "" {
+		normalized, err := normalizeInitiativeStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		status = normalized
+	}
+
+	filtered := []api.Initiative{}
+	for _, initiative := range initiatives {
+		if status != "" && !strings.EqualFold(initiative.Status, status) {
+			continue
+		}
+		if status == "" && !includeCompleted && strings.EqualFold(initiative.Status, "Completed") {
+			continue
+		}
+		filtered = append(filtered, initiative)
+	}
+	return filtered, nil
+}
+
+// resolveInitiative finds an initiative by ID or by name
+func resolveInitiative(ctx context.Context, client *api.Client, ref string) (*api.Initiative, error) {
+	if uuidPattern.MatchString(ref) {
+		initiative, err := client.GetInitiative(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get initiative: %v", err)
+		}
+		return initiative, nil
+	}
+
+	filter := map[string]interface{}{
+		"name": map[string]interface{}{"eqIgnoreCase": ref},
+	}
+	initiatives, err := client.GetInitiatives(ctx, filter, 2, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up initiative '%s': %v", ref, err)
+	}
+	switch len(initiatives.Nodes) {
+	case 0:
+		return nil, fmt.Errorf("initiative not found: %s", ref)
+	case 1:
+		return &initiatives.Nodes[0], nil
+	default:
+		return nil, fmt.Errorf("multiple initiatives named '%s'; use the initiative ID instead", ref)
+	}
+}
+
+// normalizeInitiativeStatus matches a status name regardless of case
+func normalizeInitiativeStatus(status string) (string, error) {
+	for _, s := range initiativeStatuses {
+		if strings.EqualFold(s, strings.TrimSpace(status)) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unknown initiative status '%s' (available: %s)", status, strings.Join(initiativeStatuses, ", "))
+}
+
+// initiativeInputFromFlags builds an initiative create/update input from the flags that were set
+func initiativeInputFromFlags(ctx context.Context, cmd *cobra.Command, client *api.Client) (map[string]interface{}, error) {
+	input := make(map[string]interface{})
+
+	if cmd.Flags().Changed("summary") {
+		summary, _ := cmd.Flags().GetString("summary")
+		input["description"] = summary
+	}
+
+	if cmd.Flags().Changed("description") && cmd.Flags().Changed("description-file") {
+		return nil, fmt.Errorf("use either --description or --description-file, not both")
+	}
+	if cmd.Flags().Changed("description") {
+		description, _ := cmd.Flags().GetString("description")
+		input["content"] = description
+	}
+	if path, _ := cmd.Flags().GetString("description-file"); path != "" {
+		content, err := readMarkdownInput(path)
+		if err != nil {
+			return nil, err
+		}
+		input["content"] = content
+	}
+
+	if cmd.Flags().Changed("owner") {
+		owner, _ := cmd.Flags().GetString("owner")
+		if strings.EqualFold(owner, "none") {
+			owner = "unassigned"
+		}
+		ownerID, err := resolveAssigneeID(ctx, client, owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve owner: %v", err)
+		}
+		input["ownerId"] = ownerID
+	}
+
+	if status, _ := cmd.Flags().GetString("status"); status != "" {
+		normalized, err := normalizeInitiativeStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		input["status"] = normalized
+	}
+
+	if cmd.Flags().Changed("target-date") {
+		date, _ := cmd.Flags().GetString("target-date")
+		if strings.EqualFold(date, "none") || date == "" {
+			input["targetDate"] = nil
+		} else if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("invalid target-date '%s' (expected YYYY-MM-DD)", date)
+		} else {
+			input["targetDate"] = date
+		}
+	}
+
+	return input, nil
+}
+
+// addInitiativeProjects adds the --project projects to an initiative and
+// returns it refetched, exiting when a project cannot be added
+func addInitiativeProjects(ctx context.Context, cmd *cobra.Command, client *api.Client, initiative *api.Initiative, plaintext, jsonOut bool) *api.Initiative {
+	projects, _ := cmd.Flags().GetStringSlice("project")
+	if len(projects) == 0 {
+		return initiative
+	}
+
+	for _, project := range projects {
+		if project = strings.TrimSpace(project); project == "" {
+			continue
+		}
+		projectID, err := resolveProjectID(ctx, client, project)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if err := client.AddProjectToInitiative(ctx, initiative.ID, projectID); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to add project '%s' to initiative: %v", project, err), plaintext, jsonOut)
+		}
+	}
+
+	if refreshed, err := client.GetInitiative(ctx, initiative.ID); err == nil {
+		return refreshed
+	}
+	return initiative
+}
+
+// formatHealth names a project or initiative health (onTrack, atRisk, offTrack)
+func formatHealth(health string) string {
+	switch health {
+	case "onTrack":
+		return "On track"
+	case "atRisk":
+		return "At risk"
+	case "offTrack":
+		return "Off track"
+	}
+	return health
+}
+
+// healthColor is the color a health is shown in
+func healthColor(health string) *color.Color {
+	switch health {
+	case "onTrack":
+		return color.New(color.FgGreen)
+	case "atRisk":
+		return color.New(color.FgYellow)
+	case "offTrack":
+		return color.New(color.FgRed)
+	}
+	return color.New(color.FgWhite, color.Faint)
+}
+
+func init() {
+	rootCmd.AddCommand(initiativeCmd)
+	initiativeCmd.AddCommand(initiativeListCmd)
+	initiativeCmd.AddCommand(initiativeViewCmd)
+	initiativeCmd.AddCommand(initiativeCreateCmd)
+	initiativeCmd.AddCommand(initiativeUpdateCmd)
+
+	initiativeListCmd.Flags().StringP("status", "s", "", "Only initiatives with this status (planned, active, completed)")
+	initiativeListCmd.Flags().BoolP("include-completed", "c", false, "Include completed initiatives")
+
+	for _, c := range []*cobra.Command{initiativeCreateCmd, initiativeUpdateCmd} {
+		c.Flags().String("summary", "", "Short initiative summary")
+		c.Flags().StringP("description", "d", "", "Initiative description (markdown)")
+		c.Flags().StringP("description-file", "F", "", "Read the description from a markdown file ('-' for stdin)")
+		c.Flags().String("owner", "", "Initiative owner (email, name, or 'me')")
+		c.Flags().StringP("status", "s", "", "Status: planned, active, or completed")
+		c.Flags().String("target-date", "", "Target date (YYYY-MM-DD)")
+		c.Flags().StringSlice("project", nil, "Add projects to the initiative (names or IDs, comma-separated)")
+	}
+	initiativeUpdateCmd.Flags().String("name", "", "New initiative name")
+}
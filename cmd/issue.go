@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dorkitude/linctl/pkg/api"
 	"github.com/dorkitude/linctl/pkg/auth"
@@ -38,18 +40,34 @@ var issueListCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Short:   "List issues",
-	Long:    `List Linear issues with optional filtering.`,
+	Long: `List Linear issues with optional filtering.
+
+With --watch the list is polled every --interval and new, changed, and closed
+issues are printed as they happen (one JSON event per line with --json).
+--notify-slack also posts each poll's changes to a Slack incoming webhook.
+
+` + issueFilterHelp + `
+
+` + issueOrderHelp + `
+
+Examples:
+  linctl issue list --assignee me --state started
+  linctl issue list --team ENG --sort priority,-updated --group-by assignee
+  linctl issue list --filter 'state:started AND (label:bug OR priority:>=high) AND updated:>-7d'
+  linctl issue list --team ENG --watch --interval 1m
+  linctl issue list --team SUP --priority urgent --watch --notify-slack oncall`,
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 
-		authHeader, err := auth.GetAuthHeader()
+		format, err := parseDelimitedFormat(cmd, issueColumns)
 		if err != nil {
-			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		fields, selection, err := parseIssueFields(cmd, format)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
 		}
-
-		client := api.NewClient(authHeader)
 
 		// Build filter from flags
 		filter := buildIssueFilter(cmd)
@@ -59,79 +77,197 @@ var issueListCmd = &cobra.Command{
 			limit = 50
 		}
 
-		// Get sort option
-		sortBy, _ := cmd.Flags().GetString("sort")
-		orderBy := ""
-		if sortBy != "" {
-			switch sortBy {
-			case "created", "createdAt":
-				orderBy = "createdAt"
-			case "updated", "updatedAt":
-				orderBy = "updatedAt"
-			case "linear":
-				// Use empty string for Linear's default sort
-				orderBy = ""
-			default:
-				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
+		// Sorting Linear can't do, and grouping, happen after fetching
+		order, err := parseIssueOrder(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		orderBy := order.OrderBy
+		if selection, err = order.selection(selection, shownIssueFields(fields, format)); err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if watch, _ := cmd.Flags().GetBool("watch"); !watch && cmd.Flags().Changed("notify-slack") {
+			output.Error("--notify-slack requires --watch", plaintext, jsonOut)
+			exit(1)
+		}
+
+		includeArchived, _ := cmd.Flags().GetBool("archived")
+		if cached, _ := cmd.Flags().GetBool("cached"); cached {
+			if watch, _ := cmd.Flags().GetBool("watch"); watch {
+				output.Error("--watch cannot be combined with --cached", plaintext, jsonOut)
+				exit(1)
+			}
+			if includeArchived {
+				output.Error("--archived cannot be combined with --cached (archived issues are not cached)", plaintext, jsonOut)
+				exit(1)
+			}
+			issues, syncedAt, err := cachedIssues(cmd, filter, "", orderBy, limit)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			printCacheNotice(syncedAt, plaintext, jsonOut)
+			if format != nil {
+				order.apply(issues)
+				if err := writeDelimited(format, issues.Nodes, issueColumns); err != nil {
+					exitWithError(err, fmt.Sprintf("Failed to write output: %v", err), plaintext, jsonOut)
+				}
+				return
+			}
+			renderOrderedIssues(issues, order, fields, plaintext, jsonOut, "No issues found", "issues", "# Issues")
+			return
+		}
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		fetch := func() (*api.Issues, error) {
+			return fetchIssuePages(cmd, limit, func(ctx context.Context, first int, after string) (*api.Issues, error) {
+				return client.GetIssuesWithFields(ctx, selection, filter, first, after, orderBy, includeArchived)
+			})
+		}
+
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			if format != nil {
+				output.Error("--watch cannot be combined with --format csv/tsv", plaintext, jsonOut)
+				exit(1)
+			}
+			if order.GroupBy != "" {
+				output.Error("--watch cannot be combined with --group-by", plaintext, jsonOut)
+				exit(1)
+			}
+			interval, _ := cmd.Flags().GetDuration("interval")
+			webhookURL, err := slackWebhook(cmd)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			if err := watchIssues(client, interval, fetch, webhookURL, plaintext, jsonOut); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to watch issues: %v", err), plaintext, jsonOut)
 			}
+			return
 		}
 
-		issues, err := client.GetIssues(context.Background(), filter, limit, "", orderBy)
+		issues, err := fetch()
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to fetch issues: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to fetch issues: %v", err), plaintext, jsonOut)
+		}
+
+		if format != nil {
+			order.apply(issues)
+			if err := writeDelimited(format, issues.Nodes, issueColumns); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write output: %v", err), plaintext, jsonOut)
+			}
+			return
 		}
 
-		renderIssueCollection(issues, plaintext, jsonOut, "No issues found", "issues", "# Issues")
+		renderOrderedIssues(issues, order, fields, plaintext, jsonOut, "No issues found", "issues", "# Issues")
 	},
 }
 
-func renderIssueCollection(issues *api.Issues, plaintext, jsonOut bool, emptyMessage, summaryLabel, plaintextTitle string) {
-	if len(issues.Nodes) == 0 {
-		output.Info(emptyMessage, plaintext, jsonOut)
-		return
+// fetchIssuePages fetches a single page of issues, or every page when --all is set.
+// With --all, --limit only caps the total if it was given explicitly.
+func fetchIssuePages(cmd *cobra.Command, limit int, fetch func(ctx context.Context, first int, after string) (*api.Issues, error)) (*api.Issues, error) {
+	all, _ := cmd.Flags().GetBool("all")
+	if !all {
+		return fetch(context.Background(), limit, "")
+	}
+
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+	maxResults := 0
+	if cmd.Flags().Changed("limit") {
+		maxResults = limit
+	}
+
+	nodes, pageInfo, err := api.Paginate(context.Background(), api.PaginateOptions{
+		PageSize:   pageSize,
+		MaxResults: maxResults,
+	}, func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+		page, err := fetch(ctx, first, after)
+		if err != nil {
+			return nil, api.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	return &api.Issues{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+func renderIssueCollection(issues *api.Issues, plaintext, jsonOut bool, emptyMessage, summaryLabel, plaintextTitle string) {
+	// JSON output is always an array, even when empty
 	if jsonOut {
 		output.JSON(issues.Nodes)
 		return
 	}
 
+	if len(issues.Nodes) == 0 {
+		output.Info(emptyMessage, plaintext, jsonOut)
+		return
+	}
+
 	if plaintext {
 		fmt.Println(plaintextTitle)
 		for _, issue := range issues.Nodes {
-			fmt.Printf("## %s\n", issue.Title)
-			fmt.Printf("- **ID**: %s\n", issue.Identifier)
-			if issue.State != nil {
-				fmt.Printf("- **State**: %s\n", issue.State.Name)
-			}
-			if issue.Assignee != nil {
-				fmt.Printf("- **Assignee**: %s\n", issue.Assignee.Name)
-			} else {
-				fmt.Printf("- **Assignee**: Unassigned\n")
-			}
-			if issue.Team != nil {
-				fmt.Printf("- **Team**: %s\n", issue.Team.Key)
-			}
-			if issue.Parent != nil {
-				fmt.Printf("- **Parent**: %s\n", issue.Parent.Identifier)
-			}
-			fmt.Printf("- **Created**: %s\n", issue.CreatedAt.Format("2006-01-02"))
-			fmt.Printf("- **URL**: %s\n", issue.URL)
-			if issue.Description != "" {
-				fmt.Printf("- **Description**: %s\n", issue.Description)
-			}
-			fmt.Println()
+			printIssueMarkdown(issue, "##")
 		}
 		fmt.Printf("\nTotal: %d %s\n", len(issues.Nodes), summaryLabel)
 		return
 	}
 
+	output.Table(issueTable(issues.Nodes), false, false)
+
+	fmt.Printf("\n%s %d %s\n",
+		color.New(color.FgGreen).Sprint("✓"),
+		len(issues.Nodes),
+		summaryLabel)
+
+	if issues.PageInfo.HasNextPage {
+		fmt.Printf("%s Use --limit or --all to see more results\n",
+			color.New(color.FgYellow).Sprint("ℹ️"))
+	}
+}
+
+// printIssueMarkdown prints an issue of a plaintext list under a heading of the given level
+func printIssueMarkdown(issue api.Issue, heading string) {
+	fmt.Printf("%s %s\n", heading, issue.Title)
+	fmt.Printf("- **ID**: %s\n", issue.Identifier)
+	if issue.State != nil {
+		fmt.Printf("- **State**: %s\n", issue.State.Name)
+	}
+	if issue.Assignee != nil {
+		fmt.Printf("- **Assignee**: %s\n", issue.Assignee.Name)
+	} else {
+		fmt.Printf("- **Assignee**: Unassigned\n")
+	}
+	if issue.Team != nil {
+		fmt.Printf("- **Team**: %s\n", issue.Team.Key)
+	}
+	if issue.Parent != nil {
+		fmt.Printf("- **Parent**: %s\n", issue.Parent.Identifier)
+	}
+	fmt.Printf("- **Created**: %s\n", issue.CreatedAt.Format("2006-01-02"))
+	if issue.ArchivedAt != nil {
+		fmt.Printf("- **Archived**: %s\n", issue.ArchivedAt.Format("2006-01-02"))
+	}
+	fmt.Printf("- **URL**: %s\n", issue.URL)
+	if issue.Description != "" {
+		fmt.Printf("- **Description**: %s\n", issue.Description)
+	}
+	fmt.Println()
+}
+
+// issueTable lays out issues as the default list table
+func issueTable(issues []api.Issue) output.TableData {
 	headers := []string{"Title", "State", "Assignee", "Team", "Parent", "Created", "URL"}
-	rows := make([][]string, len(issues.Nodes))
+	rows := make([][]string, len(issues))
 
-	for i, issue := range issues.Nodes {
+	for i, issue := range issues {
 		assignee := "Unassigned"
 		if issue.Assignee != nil {
 			assignee = issue.Assignee.Name
@@ -144,25 +280,10 @@ func renderIssueCollection(issues *api.Issues, plaintext, jsonOut bool, emptyMes
 
 		state := ""
 		if issue.State != nil {
-			state = issue.State.Name
-			var stateColor *color.Color
-			switch issue.State.Type {
-			case "triage":
-				stateColor = color.New(color.FgMagenta)
-			case "backlog":
-				stateColor = color.New(color.FgCyan)
-			case "unstarted":
-				stateColor = color.New(color.FgWhite)
-			case "started":
-				stateColor = color.New(color.FgBlue)
-			case "completed":
-				stateColor = color.New(color.FgGreen)
-			case "canceled":
-				stateColor = color.New(color.FgRed)
-			default:
-				stateColor = color.New(color.FgWhite)
-			}
-			state = stateColor.Sprint(state)
+			state = stateTypeColor(issue.State.Type, issue.State.Color).Sprint(issue.State.Name)
+		}
+		if issue.ArchivedAt != nil {
+			state += color.New(color.FgWhite, color.Faint).Sprint(" (archived)")
 		}
 
 		if issue.Assignee == nil {
@@ -175,7 +296,7 @@ func renderIssueCollection(issues *api.Issues, plaintext, jsonOut bool, emptyMes
 		}
 
 		rows[i] = []string{
-			truncateString(issue.Title, 40),
+			issue.Title,
 			state,
 			assignee,
 			team,
@@ -185,22 +306,10 @@ func renderIssueCollection(issues *api.Issues, plaintext, jsonOut bool, emptyMes
 		}
 	}
 
-	tableData := output.TableData{
+	return output.TableData{
 		Headers: headers,
 		Rows:    rows,
 	}
-
-	output.Table(tableData, false, false)
-
-	fmt.Printf("\n%s %d %s\n",
-		color.New(color.FgGreen).Sprint("✓"),
-		len(issues.Nodes),
-		summaryLabel)
-
-	if issues.PageInfo.HasNextPage {
-		fmt.Printf("%s Use --limit to see more results\n",
-			color.New(color.FgYellow).Sprint("ℹ️"))
-	}
 }
 
 var issueSearchCmd = &cobra.Command{
@@ -209,28 +318,34 @@ var issueSearchCmd = &cobra.Command{
 	Short:   "Search issues by keyword",
 	Long: `Perform a full-text search across Linear issues.
 
+` + issueOrderHelp + `
+
 Examples:
   linctl issue search "payment outage"
   linctl issue search "auth token" --team ENG --include-completed
+  linctl issue search "timeout" --label bug --state "In Progress" --assignee me
+  linctl issue search "checkout" --updated-after 3_days_ago --sort updated --all
+  linctl issue search "timeout" --sort priority --group-by state
   linctl issue search "customer:" --json`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 
-		query := strings.TrimSpace(strings.Join(args, " "))
-		if query == "" {
-			output.Error("Search query is required", plaintext, jsonOut)
-			os.Exit(1)
+		format, err := parseDelimitedFormat(cmd, issueColumns)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
 		}
-
-		authHeader, err := auth.GetAuthHeader()
+		fields, selection, err := parseIssueFields(cmd, format)
 		if err != nil {
-			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, err.Error(), plaintext, jsonOut)
 		}
 
-		client := api.NewClient(authHeader)
+		query := strings.TrimSpace(strings.Join(args, " "))
+		if query == "" {
+			output.Error("Search query is required", plaintext, jsonOut)
+			exit(1)
+		}
 
 		filter := buildIssueFilter(cmd)
 
@@ -239,32 +354,62 @@ Examples:
 			limit = 50
 		}
 
-		sortBy, _ := cmd.Flags().GetString("sort")
-		orderBy := ""
-		if sortBy != "" {
-			switch sortBy {
-			case "created", "createdAt":
-				orderBy = "createdAt"
-			case "updated", "updatedAt":
-				orderBy = "updatedAt"
-			case "linear":
-				orderBy = ""
-			default:
-				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
-			}
+		order, err := parseIssueOrder(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		orderBy := order.OrderBy
+		if selection, err = order.selection(selection, shownIssueFields(fields, format)); err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
 		}
 
 		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+		emptyMsg := fmt.Sprintf("No matches found for %q", query)
+
+		if cached, _ := cmd.Flags().GetBool("cached"); cached {
+			if includeArchived {
+				output.Error("--include-archived cannot be combined with --cached (archived issues are not cached)", plaintext, jsonOut)
+				exit(1)
+			}
+			issues, syncedAt, err := cachedIssues(cmd, filter, query, orderBy, limit)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			printCacheNotice(syncedAt, plaintext, jsonOut)
+			if format != nil {
+				order.apply(issues)
+				if err := writeDelimited(format, issues.Nodes, issueColumns); err != nil {
+					exitWithError(err, fmt.Sprintf("Failed to write output: %v", err), plaintext, jsonOut)
+				}
+				return
+			}
+			renderOrderedIssues(issues, order, fields, plaintext, jsonOut, emptyMsg, "matches", "# Search Results")
+			return
+		}
 
-		issues, err := client.IssueSearch(context.Background(), query, filter, limit, "", orderBy, includeArchived)
+		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to search issues: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitNotAuthenticated(plaintext, jsonOut)
 		}
 
-		emptyMsg := fmt.Sprintf("No matches found for %q", query)
-		renderIssueCollection(issues, plaintext, jsonOut, emptyMsg, "matches", "# Search Results")
+		client := api.NewClient(authHeader)
+
+		issues, err := fetchIssuePages(cmd, limit, func(ctx context.Context, first int, after string) (*api.Issues, error) {
+			return client.IssueSearchWithFields(ctx, selection, query, filter, first, after, orderBy, includeArchived)
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to search issues: %v", err), plaintext, jsonOut)
+		}
+
+		if format != nil {
+			order.apply(issues)
+			if err := writeDelimited(format, issues.Nodes, issueColumns); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write output: %v", err), plaintext, jsonOut)
+			}
+			return
+		}
+
+		renderOrderedIssues(issues, order, fields, plaintext, jsonOut, emptyMsg, "matches", "# Search Results")
 	},
 }
 
@@ -272,7 +417,7 @@ var issueGetCmd = &cobra.Command{
 	Use:     "get [issue-id]",
 	Aliases: []string{"show"},
 	Short:   "Get issue details",
-	Long:    `Get detailed information about a specific issue.`,
+	Long:    `Get detailed information about a specific issue, or one of yours picked from a list when none is given.`,
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
@@ -280,15 +425,18 @@ var issueGetCmd = &cobra.Command{
 
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		issueID, err := issueFromArgs(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
 		}
 
 		client := api.NewClient(authHeader)
-		issue, err := client.GetIssue(context.Background(), args[0])
+		issue, err := client.GetIssue(context.Background(), issueID)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to fetch issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to fetch issue: %v", err), plaintext, jsonOut)
 		}
 
 		if jsonOut {
@@ -355,6 +503,9 @@ var issueGetCmd = &cobra.Command{
 			if issue.DueDate != nil && *issue.DueDate != "" {
 				fmt.Printf("- **Due Date**: %s\n", *issue.DueDate)
 			}
+			if sla := formatSLA(issue); sla != "" {
+				fmt.Printf("- **SLA**: %s\n", sla)
+			}
 			if issue.SnoozedUntilAt != nil {
 				fmt.Printf("- **Snoozed Until**: %s\n", issue.SnoozedUntilAt.Format("2006-01-02 15:04:05"))
 			}
@@ -388,6 +539,9 @@ var issueGetCmd = &cobra.Command{
 				if issue.Project.Health != "" {
 					fmt.Printf("- **Health**: %s\n", issue.Project.Health)
 				}
+				if issue.ProjectMilestone != nil {
+					fmt.Printf("- **Milestone**: %s\n", issue.ProjectMilestone.Name)
+				}
 				if issue.Project.Description != "" {
 					fmt.Printf("- **Description**: %s\n", issue.Project.Description)
 				}
@@ -459,7 +613,7 @@ var issueGetCmd = &cobra.Command{
 					reactionMap[reaction.Emoji] = append(reactionMap[reaction.Emoji], reaction.User.Name)
 				}
 				for emoji, users := range reactionMap {
-					fmt.Printf("- %s: %s\n", emoji, strings.Join(users, ", "))
+					fmt.Printf("- %s: %s\n", utils.EmojiGlyph(emoji), strings.Join(users, ", "))
 				}
 			}
 
@@ -587,7 +741,7 @@ var issueGetCmd = &cobra.Command{
 				stateStr += fmt.Sprintf(" (%s)", issue.CompletedAt.Format("2006-01-02"))
 			}
 			fmt.Printf("State: %s\n",
-				color.New(color.FgGreen).Sprint(stateStr))
+				stateTypeColor(issue.State.Type, issue.State.Color).Sprint(stateStr))
 		}
 
 		if issue.Assignee != nil {
@@ -603,7 +757,7 @@ var issueGetCmd = &cobra.Command{
 				color.New(color.FgMagenta).Sprint(issue.Team.Name))
 		}
 
-		fmt.Printf("Priority: %s\n", priorityToString(issue.Priority))
+		fmt.Printf("Priority: %s\n", output.PriorityColor(issue.Priority).Sprint(priorityToString(issue.Priority)))
 
 		// Show project and cycle info
 		if issue.Project != nil {
@@ -622,7 +776,15 @@ var issueGetCmd = &cobra.Command{
 
 		if issue.DueDate != nil && *issue.DueDate != "" {
 			fmt.Printf("Due Date: %s\n",
-				color.New(color.FgYellow).Sprint(*issue.DueDate))
+				issueDueColor(issue).Sprint(*issue.DueDate))
+		}
+
+		if sla := formatSLA(issue); sla != "" {
+			slaColor := output.ThemeColor("due.soon")
+			if slaBreached(issue) {
+				slaColor = output.ThemeColor("due.overdue")
+			}
+			fmt.Printf("SLA: %s\n", slaColor.Sprint(sla))
 		}
 
 		if issue.SnoozedUntilAt != nil {
@@ -654,25 +816,13 @@ var issueGetCmd = &cobra.Command{
 		if issue.Children != nil && len(issue.Children.Nodes) > 0 {
 			fmt.Printf("\n%s\n", color.New(color.FgYellow).Sprint("Sub-issues:"))
 			for _, child := range issue.Children.Nodes {
-				stateIcon := "○"
-				if child.State != nil {
-					switch child.State.Type {
-					case "completed", "done":
-						stateIcon = color.New(color.FgGreen).Sprint("✓")
-					case "started", "in_progress":
-						stateIcon = color.New(color.FgBlue).Sprint("◐")
-					case "canceled":
-						stateIcon = color.New(color.FgRed).Sprint("✗")
-					}
-				}
-
 				assignee := "Unassigned"
 				if child.Assignee != nil {
 					assignee = child.Assignee.Name
 				}
 
 				fmt.Printf("  %s %s %s (%s)\n",
-					stateIcon,
+					stateIcon(child.State),
 					color.New(color.FgCyan).Sprint(child.Identifier),
 					child.Title,
 					color.New(color.FgWhite, color.Faint).Sprint(assignee))
@@ -718,20 +868,50 @@ func buildIssueFilter(cmd *cobra.Command) map[string]interface{} {
 	plaintext := viper.GetBool("plaintext")
 	jsonOut := viper.GetBool("json")
 
-	if assignee, _ := cmd.Flags().GetString("assignee"); assignee != "" {
-		if assignee == "me" {
-			// We'll need to get the current user's ID
-			// For now, we'll use a special marker
-			filter["assignee"] = map[string]interface{}{"isMe": map[string]interface{}{"eq": true}}
-		} else {
-			filter["assignee"] = map[string]interface{}{"email": map[string]interface{}{"eq": assignee}}
+	// A --filter expression is combined with the other flags; the fields it
+	// names replace the defaults for state and creation date
+	var expression map[string]interface{}
+	expressionFields := map[string]bool{}
+	if cmd.Flags().Lookup("filter") != nil {
+		if text, _ := cmd.Flags().GetString("filter"); strings.TrimSpace(text) != "" {
+			node, err := parseIssueFilterExpr(text)
+			if err == nil {
+				expression, err = compileIssueFilter(context.Background(), node)
+			}
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Invalid filter: %v", err), plaintext, jsonOut)
+			}
+			expressionFields = filterFields(node)
+		}
+	}
+
+	// User filters accept @me, @none, @any, or an email, name, or display name
+	for _, field := range []string{"assignee", "creator"} {
+		value, _ := cmd.Flags().GetString(field)
+		if value == "" {
+			continue
+		}
+		userFilter, err := userFilter(context.Background(), value)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
 		}
+		filter[field] = userFilter
 	}
 
 	state, _ := cmd.Flags().GetString("state")
 	if state != "" {
-		filter["state"] = map[string]interface{}{"name": map[string]interface{}{"eq": state}}
-	} else {
+		if isStateType(state) {
+			// A state type such as "started" also matches every state of that type
+			filter["state"] = map[string]interface{}{
+				"or": []map[string]interface{}{
+					{"name": map[string]interface{}{"eq": state}},
+					{"type": map[string]interface{}{"eq": strings.ToLower(state)}},
+				},
+			}
+		} else {
+			filter["state"] = map[string]interface{}{"name": map[string]interface{}{"eq": state}}
+		}
+	} else if !expressionFields["state"] && !expressionFields["completed"] && !expressionFields["canceled"] {
 		// Only filter out completed issues if no specific state is requested
 		includeCompleted, _ := cmd.Flags().GetBool("include-completed")
 		if !includeCompleted {
@@ -748,19 +928,85 @@ func buildIssueFilter(cmd *cobra.Command) map[string]interface{} {
 		filter["team"] = map[string]interface{}{"key": map[string]interface{}{"eq": team}}
 	}
 
-	if priority, _ := cmd.Flags().GetInt("priority"); priority != -1 {
+	if priority := getPriorityFlag(cmd); priority != -1 {
 		filter["priority"] = map[string]interface{}{"eq": priority}
 	}
 
-	// Handle newer-than filter
-	newerThan, _ := cmd.Flags().GetString("newer-than")
-	createdAt, err := utils.ParseTimeExpression(newerThan)
-	if err != nil {
-		output.Error(fmt.Sprintf("Invalid newer-than value: %v", err), plaintext, jsonOut)
-		os.Exit(1)
+	// Issues must carry every requested label
+	if labels, _ := cmd.Flags().GetStringSlice("label"); len(labels) > 0 {
+		var labelFilters []map[string]interface{}
+		for _, label := range labels {
+			if label = strings.TrimSpace(label); label != "" {
+				labelFilters = append(labelFilters, map[string]interface{}{
+					"labels": map[string]interface{}{
+						"some": map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": label}},
+					},
+				})
+			}
+		}
+		if len(labelFilters) == 1 {
+			filter["labels"] = labelFilters[0]["labels"]
+		} else if len(labelFilters) > 1 {
+			filter["and"] = labelFilters
+		}
+	}
+
+	// Handle newer-than filter; an explicit --created-after takes precedence
+	createdAfter, _ := cmd.Flags().GetString("created-after")
+	if createdAfter != "" {
+		createdAt, err := utils.ParseTimeExpression(createdAfter)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Invalid created-after value: %v", err), plaintext, jsonOut)
+		}
+		if createdAt != "" {
+			filter["createdAt"] = map[string]interface{}{"gte": createdAt}
+		}
+	} else {
+		newerThan, _ := cmd.Flags().GetString("newer-than")
+		if newerThan == "" && expressionFields["created"] {
+			// The expression's created condition replaces the 6 month default
+			newerThan = "all_time"
+		}
+		createdAt, err := utils.ParseTimeExpression(newerThan)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Invalid newer-than value: %v", err), plaintext, jsonOut)
+		}
+		if createdAt != "" {
+			filter["createdAt"] = map[string]interface{}{"gte": createdAt}
+		}
+	}
+
+	// Due date filters: --overdue is due before today, --due-within is due from
+	// today up to the offset (or before it, with --overdue)
+	overdue, _ := cmd.Flags().GetBool("overdue")
+	dueWithin, _ := cmd.Flags().GetString("due-within")
+	if overdue || dueWithin != "" {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		dueDate := map[string]interface{}{}
+		if dueWithin != "" {
+			offset, err := utils.ParseDayOffset(dueWithin)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Invalid due-within value: %v", err), plaintext, jsonOut)
+			}
+			dueDate["lte"] = offset(today).Format("2006-01-02")
+			if !overdue {
+				dueDate["gte"] = today.Format("2006-01-02")
+			}
+		} else {
+			dueDate["lt"] = today.Format("2006-01-02")
+		}
+		filter["dueDate"] = dueDate
 	}
-	if createdAt != "" {
-		filter["createdAt"] = map[string]interface{}{"gte": createdAt}
+
+	if updatedAfter, _ := cmd.Flags().GetString("updated-after"); updatedAfter != "" {
+		updatedAt, err := utils.ParseTimeExpression(updatedAfter)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Invalid updated-after value: %v", err), plaintext, jsonOut)
+		}
+		if updatedAt != "" {
+			filter["updatedAt"] = map[string]interface{}{"gte": updatedAt}
+		}
 	}
 
 	// Handle parent filtering
@@ -782,7 +1028,7 @@ func buildIssueFilter(cmd *cobra.Command) map[string]interface{} {
 
 	if flagCount > 1 {
 		output.Error("Cannot use --has-parent, --no-parent, and --parent-issue together. Choose only one.", plaintext, jsonOut)
-		os.Exit(1)
+		exit(1)
 	}
 
 	if hasParent {
@@ -795,23 +1041,35 @@ func buildIssueFilter(cmd *cobra.Command) map[string]interface{} {
 		// Filter for sub-issues of a specific parent
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			exitNotAuthenticated(plaintext, jsonOut)
 		}
 
 		client := api.NewClient(authHeader)
 		parent, err := client.GetIssue(context.Background(), parentIssue)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to find parent issue '%s': %v", parentIssue, err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to find parent issue '%s': %v", parentIssue, err), plaintext, jsonOut)
 		}
 
 		filter["parent"] = map[string]interface{}{"id": map[string]interface{}{"eq": parent.ID}}
 	}
 
+	if expression != nil {
+		conditions := filterList(filter["and"])
+		filter["and"] = append(conditions, expression)
+	}
+
 	return filter
 }
 
+// isStateType reports whether name is a workflow state type rather than a state name
+func isStateType(name string) bool {
+	switch strings.ToLower(name) {
+	case "triage", "backlog", "unstarted", "started", "completed", "canceled":
+		return true
+	}
+	return false
+}
+
 func priorityToString(priority int) string {
 	switch priority {
 	case 0:
@@ -829,11 +1087,9 @@ func priorityToString(priority int) string {
 	}
 }
 
+// truncateString shortens s to maxLen terminal columns, never splitting a character
 func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
+	return output.Truncate(s, maxLen)
 }
 
 // resolveCycleID resolves a cycle string (number or special value) to a cycle ID
@@ -843,12 +1099,18 @@ func resolveCycleID(ctx context.Context, client *api.Client, teamKey string, cyc
 	switch strings.ToLower(strings.TrimSpace(cycleStr)) {
 	case "unassigned", "none", "":
 		return nil, nil
+	case "current", "active", "next", "previous":
+		cycle, err := findRelativeCycle(ctx, client, teamKey, cycleStr)
+		if err != nil {
+			return nil, err
+		}
+		return &cycle.ID, nil
 	}
 
 	// Parse as cycle number
 	cycleNumber, err := strconv.Atoi(cycleStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid cycle value: %s. Expected a cycle number (e.g., '5') or 'unassigned'", cycleStr)
+		return nil, fmt.Errorf("invalid cycle value: %s. Expected a cycle number (e.g., '5'), 'current', 'next', 'previous', or 'unassigned'", cycleStr)
 	}
 
 	// Get the cycle by number
@@ -867,31 +1129,55 @@ func resolveCycleID(ctx context.Context, client *api.Client, teamKey string, cyc
 // resolveLabelIDs takes comma-separated label names and returns their IDs
 // Searches team labels first, then organization labels
 func resolveLabelIDs(ctx context.Context, client *api.Client, teamKey string, labelNames string) ([]string, error) {
-	// Parse comma-separated input and trim whitespace
-	names := strings.Split(labelNames, ",")
+	if len(splitLabelNames(labelNames)) == 0 {
+		return []string{}, nil
+	}
+
+	// Team and organization labels come back in one request
+	var teamLabels, orgLabels []api.Label
+	batch := client.Batch()
+	teamLookup := batch.TeamLabels(teamKey, &teamLabels)
+	orgLookup := batch.OrganizationLabels(&orgLabels)
+	_ = batch.Execute(ctx)
+	if teamLookup.Err != nil {
+		return nil, fmt.Errorf("failed to fetch team labels: %w", teamLookup.Err)
+	}
+	if orgLookup.Err != nil {
+		return nil, fmt.Errorf("failed to fetch organization labels: %w", orgLookup.Err)
+	}
+
+	return matchLabelIDs(labelNames, teamLabels, orgLabels)
+}
+
+// splitLabelNames parses comma-separated label names, dropping blanks
+func splitLabelNames(labelNames string) []string {
 	var trimmedNames []string
-	for _, name := range names {
+	for _, name := range strings.Split(labelNames, ",") {
 		trimmed := strings.TrimSpace(name)
 		if trimmed != "" {
 			trimmedNames = append(trimmedNames, trimmed)
 		}
 	}
+	return trimmedNames
+}
 
+// matchLabelIDs returns the IDs of comma-separated label names, matching
+// team labels first, then organization labels (case-insensitive)
+func matchLabelIDs(labelNames string, teamLabels, orgLabels []api.Label) ([]string, error) {
+	trimmedNames := splitLabelNames(labelNames)
 	if len(trimmedNames) == 0 {
 		return []string{}, nil
 	}
 
-	// Query team labels
-	teamLabels, err := client.GetTeamLabels(ctx, teamKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch team labels: %w", err)
-	}
-
-	// Create map for quick lookup (case-insensitive)
+	// Create maps for quick lookup (case-insensitive)
 	labelMap := make(map[string]string) // lowercase name -> ID
 	for _, label := range teamLabels {
 		labelMap[strings.ToLower(label.Name)] = label.ID
 	}
+	orgLabelMap := make(map[string]string)
+	for _, label := range orgLabels {
+		orgLabelMap[strings.ToLower(label.Name)] = label.ID
+	}
 
 	// Resolve label IDs
 	var labelIDs []string
@@ -901,170 +1187,227 @@ func resolveLabelIDs(ctx context.Context, client *api.Client, teamKey string, la
 		lowerName := strings.ToLower(name)
 		if id, found := labelMap[lowerName]; found {
 			labelIDs = append(labelIDs, id)
+		} else if id, found := orgLabelMap[lowerName]; found {
+			labelIDs = append(labelIDs, id)
 		} else {
 			unmatched = append(unmatched, name)
 		}
 	}
 
-	// If there are unmatched labels, try organization labels
+	// If any labels are unmatched, return error with helpful message
 	if len(unmatched) > 0 {
-		orgLabels, err := client.GetOrganizationLabels(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch organization labels: %w", err)
+		// Collect all available label names for error message
+		var availableLabels []string
+		for _, label := range teamLabels {
+			availableLabels = append(availableLabels, label.Name)
 		}
-
-		// Create map for org labels
-		orgLabelMap := make(map[string]string)
 		for _, label := range orgLabels {
-			orgLabelMap[strings.ToLower(label.Name)] = label.ID
-		}
-
-		// Try to match unmatched labels
-		var stillUnmatched []string
-		for _, name := range unmatched {
-			lowerName := strings.ToLower(name)
-			if id, found := orgLabelMap[lowerName]; found {
-				labelIDs = append(labelIDs, id)
-			} else {
-				stillUnmatched = append(stillUnmatched, name)
-			}
+			availableLabels = append(availableLabels, label.Name)
 		}
 
-		// If any labels still unmatched, return error with helpful message
-		if len(stillUnmatched) > 0 {
-			// Collect all available label names for error message
-			var availableLabels []string
-			for _, label := range teamLabels {
-				availableLabels = append(availableLabels, label.Name)
-			}
-			for _, label := range orgLabels {
-				availableLabels = append(availableLabels, label.Name)
-			}
-
-			return nil, fmt.Errorf("label(s) not found: %s\nAvailable labels: %s",
-				strings.Join(stillUnmatched, ", "),
-				strings.Join(availableLabels, ", "))
-		}
+		return nil, fmt.Errorf("label(s) not found: %s\nAvailable labels: %s",
+			strings.Join(unmatched, ", "),
+			strings.Join(availableLabels, ", "))
 	}
 
 	return labelIDs, nil
 }
 
-var issueAssignCmd = &cobra.Command{
-	Use:   "assign [issue-id]",
-	Short: "Assign issue to yourself",
-	Long:  `Assign an issue to yourself.`,
-	Args:  cobra.ExactArgs(1),
+var issueCreateCmd = &cobra.Command{
+	Use:     "create",
+	Aliases: []string{"new"},
+	Short:   "Create a new issue",
+	Long: `Create a new issue in Linear.
+
+When --title or --team is missing and linctl is running in a terminal, you are
+prompted for them interactively. The description can be given inline, read from
+a markdown file with --from-file (-F), or piped in with --from-file -. --editor
+opens $EDITOR with the fields as YAML frontmatter and the description below
+them, like 'git commit'.
+
+A --from-file document may start with the same frontmatter (title, team, state,
+assignee, labels, priority, estimate, project, due-date, parent); flags given
+on the command line win over it. 'issue export --as markdown' writes this format.
+
+--template pre-fills the title, description, labels, estimate, and priority
+from a local or Linear template (see 'linctl template'). Flags override it.
+
+The team is --team, else the parent issue's team, else the template's team,
+else default-team.
+
+Examples:
+  linctl issue create --title "Bug fix" --team ENG
+  linctl issue create --title "Login fails" --team ENG --assignee me --labels Bug --priority 2
+  linctl issue create --team ENG --title "Spec" --from-file spec.md --project "Q3 Launch"
+  linctl issue create --team ENG --title "Release" --due-date 2024-12-31 --cycle 12
+  linctl issue create --template bug-report --title "Login fails"
+  linctl issue create --team ENG --editor
+  linctl issue create -F issue.md
+  linctl issue create                     # Prompt for title and team`,
+	Annotations: map[string]string{noDefaultTeamAnnotation: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 
+		applyIssueDocumentFlags(cmd, "", plaintext, jsonOut)
+
+		if queueIfRequested(cmd, args, "") {
+			return
+		}
+
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			exitNotAuthenticated(plaintext, jsonOut)
 		}
 
 		client := api.NewClient(authHeader)
 
-		// Get current user
-		viewer, err := client.GetViewer(context.Background())
-		if err != nil {
-			output.Error(fmt.Sprintf("Failed to get current user: %v", err), plaintext, jsonOut)
-			os.Exit(1)
-		}
+		// Get flags
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		fromFile, _ := cmd.Flags().GetString("from-file")
+		teamKey, _ := cmd.Flags().GetString("team")
+		priority := getPriorityFlag(cmd)
+		assignToMe, _ := cmd.Flags().GetBool("assign-me")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		estimate, _ := cmd.Flags().GetString("estimate")
+		imagePaths, _ := cmd.Flags().GetStringArray("image")
 
-		// Update issue with assignee
-		input := map[string]interface{}{
-			"assigneeId": viewer.ID,
+		if description != "" && fromFile != "" {
+			output.Error("Cannot use --description and --from-file together", plaintext, jsonOut)
+			exit(1)
 		}
 
-		issue, err := client.UpdateIssue(context.Background(), args[0], input)
-		if err != nil {
-			output.Error(fmt.Sprintf("Failed to assign issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+		if assignToMe && assignee != "" {
+			output.Error("Cannot use --assign-me and --assignee together", plaintext, jsonOut)
+			exit(1)
 		}
 
-		if jsonOut {
-			output.JSON(issue)
-		} else if plaintext {
-			fmt.Printf("Assigned %s to %s\n", issue.Identifier, viewer.Name)
-		} else {
-			fmt.Printf("%s Assigned %s to %s\n",
-				color.New(color.FgGreen).Sprint("✓"),
-				color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
-				color.New(color.FgCyan).Sprint(viewer.Name))
+		if fromFile != "" {
+			content, err := readMarkdownInput(fromFile)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			description = issueFileDescription(content)
 		}
-	},
-}
-
-var issueCreateCmd = &cobra.Command{
-	Use:     "create",
-	Aliases: []string{"new"},
-	Short:   "Create a new issue",
-	Long:    `Create a new issue in Linear.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		plaintext := viper.GetBool("plaintext")
-		jsonOut := viper.GetBool("json")
 
-		authHeader, err := auth.GetAuthHeader()
-		if err != nil {
-			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+		// A sub-issue goes to its parent's team unless --team says otherwise
+		if teamKey == "" {
+			parentIssue, _ := cmd.Flags().GetString("parent-issue")
+			teamKey = teamKeyFromIdentifier(parentIssue)
 		}
 
-		client := api.NewClient(authHeader)
-
-		// Get flags
-		title, _ := cmd.Flags().GetString("title")
-		description, _ := cmd.Flags().GetString("description")
-		teamKey, _ := cmd.Flags().GetString("team")
-		priority, _ := cmd.Flags().GetInt("priority")
-		assignToMe, _ := cmd.Flags().GetBool("assign-me")
-		estimate, _ := cmd.Flags().GetInt("estimate")
-		imagePaths, _ := cmd.Flags().GetStringArray("image")
-
-		if title == "" {
-			output.Error("Title is required (--title)", plaintext, jsonOut)
-			os.Exit(1)
+		var tmpl *issueTemplate
+		if templateRef, _ := cmd.Flags().GetString("template"); templateRef != "" {
+			tmpl, err = findIssueTemplate(context.Background(), client, teamKey, templateRef)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			if teamKey == "" {
+				teamKey = tmpl.Team
+			}
+			if description == "" && fromFile == "" {
+				description = tmpl.Description
+			}
+			title = tmpl.expandTitle(title)
+			if !cmd.Flags().Changed("priority") {
+				if tmpl.Priority != nil {
+					priority = *tmpl.Priority
+				} else if tmpl.ID != "" {
+					// Leave the priority to the Linear template
+					priority = -1
+				}
+			}
+			if !cmd.Flags().Changed("estimate") && tmpl.Estimate != nil {
+				estimate = strconv.Itoa(*tmpl.Estimate)
+			}
 		}
-
 		if teamKey == "" {
-			output.Error("Team is required (--team)", plaintext, jsonOut)
-			os.Exit(1)
+			teamKey = strings.ToUpper(viper.GetString("default-team"))
 		}
 
-		// Get team ID from key
-		team, err := client.GetTeam(context.Background(), teamKey)
-		if err != nil {
-			output.Error(fmt.Sprintf("Failed to find team '%s': %v", teamKey, err), plaintext, jsonOut)
-			os.Exit(1)
-		}
+		// Prompt for missing required fields when we can talk to a user
+		if title == "" || teamKey == "" {
+			if fromFile == "-" || !isInteractive() || plaintext || jsonOut {
+				if title == "" {
+					output.Error("Title is required (--title)", plaintext, jsonOut)
+				} else {
+					output.Error("Team is required (--team)", plaintext, jsonOut)
+				}
+				exit(1)
+			}
 
+			reader := bufio.NewReader(os.Stdin)
+			if teamKey == "" {
+				teamKey, err = promptForTeam(context.Background(), client, reader)
+				if err != nil {
+					exitWithError(err, err.Error(), plaintext, jsonOut)
+				}
+			}
+			if title == "" {
+				title, err = promptString(reader, "Title", "")
+				if err != nil || title == "" {
+					output.Error("Title is required", plaintext, jsonOut)
+					exit(1)
+				}
+				if tmpl != nil {
+					title = tmpl.expandTitle(title)
+				}
+			}
+			if description == "" && tmpl == nil {
+				description, _ = promptString(reader, "Description (optional)", "")
+			}
+		}
 
-	// Upload images if provided
-	if len(imagePaths) > 0 {
-		if !jsonOut && !plaintext {
-			fmt.Printf("Uploading %d image(s)...\n", len(imagePaths))
+		// Look up the team, and the states and labels the flags name, in one request
+		stateName, _ := cmd.Flags().GetString("state")
+		labelNames, _ := cmd.Flags().GetString("labels")
+		if !cmd.Flags().Changed("labels") && tmpl != nil {
+			labelNames = strings.Join(tmpl.Labels, ",")
+		}
+		team := &api.Team{}
+		var states []api.WorkflowState
+		var teamLabels, orgLabels []api.Label
+		batch := client.Batch()
+		teamLookup := batch.Team(teamKey, team)
+		statesLookup := &api.BatchField{}
+		if stateName != "" {
+			statesLookup = batch.TeamStates(teamKey, &states)
+		}
+		labelsLookup, orgLabelsLookup := &api.BatchField{}, &api.BatchField{}
+		if len(splitLabelNames(labelNames)) > 0 {
+			labelsLookup = batch.TeamLabels(teamKey, &teamLabels)
+			orgLabelsLookup = batch.OrganizationLabels(&orgLabels)
+		}
+		_ = batch.Execute(context.Background())
+		if teamLookup.Err != nil {
+			output.Error(fmt.Sprintf("Failed to find team '%s': %v", teamKey, teamLookup.Err), plaintext, jsonOut)
+			exit(1)
 		}
 
-		for _, imagePath := range imagePaths {
-			assetURL, err := client.UploadFileToLinear(context.Background(), imagePath)
-			if err != nil {
-				output.Error(fmt.Sprintf("Failed to upload image %s: %v", imagePath, err), plaintext, jsonOut)
-				os.Exit(1)
+		// Upload images if provided
+		if len(imagePaths) > 0 {
+			if !jsonOut && !plaintext {
+				fmt.Printf("Uploading %d image(s)...\n", len(imagePaths))
 			}
 
-			// Inject image into description
-			altText := filepath.Base(imagePath)
-			description = files.InjectImageIntoMarkdown(description, assetURL, altText)
+			for _, imagePath := range imagePaths {
+				assetURL, err := uploadFile(context.Background(), client, imagePath, files.UploadOptions{})
+				if err != nil {
+					exitWithError(err, fmt.Sprintf("Failed to upload image %s: %v", imagePath, err), plaintext, jsonOut)
+				}
 
-			if !jsonOut && !plaintext {
-				fmt.Printf("  ✓ Uploaded: %s\n", filepath.Base(imagePath))
+				// Inject image into description
+				altText := filepath.Base(imagePath)
+				description = files.InjectImageIntoMarkdown(description, assetURL, altText)
+
+				if !jsonOut && !plaintext {
+					fmt.Printf("  ✓ Uploaded: %s\n", filepath.Base(imagePath))
+				}
 			}
 		}
-	}
-	// Build input
+
+		// Build input
 		input := map[string]interface{}{
 			"title":  title,
 			"teamId": team.ID,
@@ -1079,12 +1422,59 @@ var issueCreateCmd = &cobra.Command{
 		}
 
 		if assignToMe {
-			viewer, err := client.GetViewer(context.Background())
+			assignee = "me"
+		}
+		if assignee != "" {
+			assigneeID, err := resolveAssigneeID(context.Background(), client, assignee)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			if assigneeID != nil {
+				input["assigneeId"] = *assigneeID
+			}
+		}
+		if subscribeMe, _ := cmd.Flags().GetBool("subscribe-me"); subscribeMe {
+			viewerID, err := resolveAssigneeID(context.Background(), client, meShortcut)
 			if err != nil {
-				output.Error(fmt.Sprintf("Failed to get current user: %v", err), plaintext, jsonOut)
-				os.Exit(1)
+				exitWithError(err, err.Error(), plaintext, jsonOut)
 			}
-			input["assigneeId"] = viewer.ID
+			input["subscriberIds"] = []string{*viewerID}
+		}
+
+		// Handle project assignment (default: default-project, for issues in
+		// the default team)
+		project, _ := cmd.Flags().GetString("project")
+		if !cmd.Flags().Changed("project") {
+			project = defaultProject(teamKey)
+		}
+		if project != "" {
+			projectID, err := resolveProjectID(context.Background(), client, project)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			input["projectId"] = projectID
+		}
+
+		// Handle due date
+		if dueDate, _ := cmd.Flags().GetString("due-date"); dueDate != "" {
+			date, err := utils.ParseDueDate(dueDate, time.Now())
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Invalid due date: %v", err), plaintext, jsonOut)
+			}
+			input["dueDate"] = date
+		}
+
+		// Handle workflow state (default: the team's default state)
+		if stateName != "" {
+			if statesLookup.Err != nil {
+				output.Error(fmt.Sprintf("Failed to get team states: %v", statesLookup.Err), plaintext, jsonOut)
+				exit(1)
+			}
+			state, err := matchWorkflowState(states, team.Key, stateName)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			input["stateId"] = state.ID
 		}
 
 		// Handle cycle assignment
@@ -1092,8 +1482,7 @@ var issueCreateCmd = &cobra.Command{
 			cycleStr, _ := cmd.Flags().GetString("cycle")
 			cycleID, err := resolveCycleID(context.Background(), client, team.Key, cycleStr, plaintext, jsonOut)
 			if err != nil {
-				output.Error(err.Error(), plaintext, jsonOut)
-				os.Exit(1)
+				exitWithError(err, err.Error(), plaintext, jsonOut)
 			}
 			if cycleID != nil {
 				input["cycleId"] = *cycleID
@@ -1102,17 +1491,28 @@ var issueCreateCmd = &cobra.Command{
 			}
 		}
 
-		// Handle labels if provided
-		if cmd.Flags().Changed("labels") {
-			labelsStr, _ := cmd.Flags().GetString("labels")
-			if labelsStr != "" {
-				labelIDs, err := resolveLabelIDs(context.Background(), client, teamKey, labelsStr)
-				if err != nil {
+		// Handle labels if provided, else the template's
+		if len(splitLabelNames(labelNames)) > 0 {
+			labelIDs, err := matchLabelIDs(labelNames, teamLabels, orgLabels)
+			if labelsLookup.Err != nil {
+				err = fmt.Errorf("failed to fetch team labels: %w", labelsLookup.Err)
+			} else if orgLabelsLookup.Err != nil {
+				err = fmt.Errorf("failed to fetch organization labels: %w", orgLabelsLookup.Err)
+			}
+			if err != nil {
+				if cmd.Flags().Changed("labels") {
 					output.Error(fmt.Sprintf("Failed to resolve labels: %v", err), plaintext, jsonOut)
-					os.Exit(1)
+				} else {
+					output.Error(fmt.Sprintf("Failed to resolve labels of template %s: %v", tmpl.Name, err), plaintext, jsonOut)
 				}
-				input["labelIds"] = labelIDs
+				exit(1)
 			}
+			input["labelIds"] = labelIDs
+		}
+
+		// A Linear template fills in everything not set above
+		if tmpl != nil && tmpl.ID != "" {
+			input["templateId"] = tmpl.ID
 		}
 
 		// Handle parent issue (if specified)
@@ -1123,23 +1523,64 @@ var issueCreateCmd = &cobra.Command{
 				parentIssueDetails, err := client.GetIssue(context.Background(), parentIssue)
 				if err != nil {
 					output.Error(fmt.Sprintf("Parent issue not found: %s", parentIssue), plaintext, jsonOut)
-					os.Exit(1)
+					exit(1)
 				}
 				// Use the UUID instead of the identifier
 				input["parentId"] = parentIssueDetails.ID
 			}
 		}
 
-		// Handle estimate
-		if estimate >= 0 && estimate != 0 {
-			input["estimate"] = estimate
+		// Handle estimate, checked against the team's estimation scale
+		if estimate != "" && !clearsEstimate(estimate) {
+			points, err := resolveEstimate(team, estimate)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			input["estimate"] = points
+		}
+
+		// Look for likely duplicates first; the new issue can be related to
+		// one of them, or not created at all
+		var duplicateOf *api.Issue
+		duplicateRelation := ""
+		if checkDuplicates, _ := cmd.Flags().GetBool("check-duplicates"); checkDuplicates {
+			compared := ""
+			if withDescription, _ := cmd.Flags().GetBool("check-description"); withDescription {
+				compared = description
+			}
+			matches, err := findDuplicates(context.Background(), client, team.Key, title, compared)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to search for duplicates: %v", err), plaintext, jsonOut)
+			}
+			if len(matches) > 0 {
+				if !isInteractive() || plaintext || jsonOut {
+					output.ErrorDetail(fmt.Sprintf("Found %d possible duplicates; not creating the issue (run without --check-duplicates to create it anyway)", len(matches)),
+						map[string]interface{}{"duplicates": matches}, plaintext, jsonOut)
+					if !jsonOut {
+						for _, match := range matches {
+							fmt.Fprintf(os.Stderr, "  %s\t%.0f%%\t%s\n", match.Issue.Identifier, match.Score*100, match.Issue.Title)
+						}
+					}
+					exit(1)
+				}
+				create, match, relation := promptDuplicates(title, matches)
+				if !create {
+					output.Info("Not created", plaintext, jsonOut)
+					return
+				}
+				duplicateOf, duplicateRelation = match, relation
+			}
 		}
 
 		// Create issue
 		issue, err := client.CreateIssue(context.Background(), input)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to create issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to create issue: %v", err), plaintext, jsonOut)
+		}
+		if duplicateOf != nil {
+			if _, err := client.CreateIssueRelation(context.Background(), issue.ID, duplicateOf.ID, duplicateRelation); err != nil {
+				output.Error(fmt.Sprintf("Created %s, but failed to relate it to %s: %v", issue.Identifier, duplicateOf.Identifier, err), plaintext, jsonOut)
+			}
 		}
 
 		if jsonOut {
@@ -1154,10 +1595,116 @@ var issueCreateCmd = &cobra.Command{
 			if issue.Assignee != nil {
 				fmt.Printf("  Assigned to: %s\n", color.New(color.FgCyan).Sprint(issue.Assignee.Name))
 			}
+			if duplicateOf != nil {
+				label := relationLabel(duplicateRelation, false)
+				fmt.Printf("  %s: %s\n", strings.ToUpper(label[:1])+label[1:], color.New(color.FgCyan).Sprint(duplicateOf.Identifier))
+			}
 		}
 	},
 }
 
+// resolveAssigneeID resolves "me", "unassigned", an email, or a display name to a user ID.
+// Returns nil when the issue should be unassigned.
+func resolveAssigneeID(ctx context.Context, client *api.Client, assignee string) (*string, error) {
+	if assignee == "" {
+		return nil, nil
+	}
+	switch userShortcut(assignee) {
+	case meShortcut:
+		// The viewer is cached locally, so @me costs no API call
+		viewer, err := auth.Viewer(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current user: %v", err)
+		}
+		return &viewer.ID, nil
+	case noneShortcut:
+		return nil, nil
+	case anyShortcut:
+		return nil, fmt.Errorf("%s can only be used to filter issues", anyShortcut)
+	}
+
+	// Look up user by email, name, display name, or ID
+	user, err := resolveUser(ctx, client, assignee)
+	if err != nil {
+		return nil, err
+	}
+	return &user.ID, nil
+}
+
+// resolveProjectID resolves a project ID, slug ID, or name to a project ID
+func resolveProjectID(ctx context.Context, client *api.Client, project string) (string, error) {
+	if p, err := client.GetProject(ctx, project); err == nil && p.ID != "" {
+		return p.ID, nil
+	}
+
+	filter := map[string]interface{}{
+		"name": map[string]interface{}{"eqIgnoreCase": project},
+	}
+	projects, err := client.GetProjects(ctx, filter, 2, "", "")
+	if err != nil {
+		return "", fmt.Errorf("failed to look up project '%s': %v", project, err)
+	}
+	switch len(projects.Nodes) {
+	case 0:
+		return "", fmt.Errorf("project not found: %s", project)
+	case 1:
+		return projects.Nodes[0].ID, nil
+	default:
+		return "", fmt.Errorf("multiple projects named '%s'; use the project ID instead", project)
+	}
+}
+
+// isInteractive reports whether stdin is attached to a terminal
+func isInteractive() bool {
+	stat, err := os.Stdin.Stat()
+	return err == nil && (stat.Mode()&os.ModeCharDevice) != 0
+}
+
+// promptString asks for a single line of input, returning defaultValue when left blank
+func promptString(reader *bufio.Reader, label, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return defaultValue, nil
+	}
+	return line, nil
+}
+
+// promptForTeam lists the available teams and asks the user to choose one by key
+func promptForTeam(ctx context.Context, client *api.Client, reader *bufio.Reader) (string, error) {
+	teams, err := client.GetTeams(ctx, 100, "", "")
+	if err != nil {
+		return "", fmt.Errorf("failed to list teams: %v", err)
+	}
+	if len(teams.Nodes) == 0 {
+		return "", fmt.Errorf("no teams available")
+	}
+
+	defaultKey := ""
+	fmt.Println(color.New(color.FgYellow).Sprint("Teams:"))
+	for _, team := range teams.Nodes {
+		fmt.Printf("  %s  %s\n", color.New(color.FgCyan, color.Bold).Sprint(team.Key), team.Name)
+	}
+	if len(teams.Nodes) == 1 {
+		defaultKey = teams.Nodes[0].Key
+	}
+
+	key, err := promptString(reader, "Team key", defaultKey)
+	if err != nil || key == "" {
+		return "", fmt.Errorf("team is required")
+	}
+	return strings.ToUpper(key), nil
+}
+
 var issueUpdateCmd = &cobra.Command{
 	Use:   "update [issue-id]",
 	Short: "Update an issue",
@@ -1170,18 +1717,37 @@ Examples:
   linctl issue update LIN-123 --state "In Progress"
   linctl issue update LIN-123 --priority 1
   linctl issue update LIN-123 --due-date "2024-12-31"
+  linctl issue update LIN-123 --due "next friday"
+  linctl issue update LIN-123 --due +3d
   linctl issue update LIN-123 --parent-issue LIN-456
   linctl issue update LIN-123 --parent-issue unassigned
-  linctl issue update LIN-123 --title "New title" --assignee me --priority 2`,
+  linctl issue update LIN-123 --title "New title" --assignee me --priority 2
+  linctl issue update --state Done         # Pick one of your issues
+  linctl issue update LIN-123 --editor     # Edit fields and description in $EDITOR
+  linctl issue update LIN-123 -F LIN-123.md  # Apply an edited 'issue export --as markdown'
+
+With --from-file, fields in the document's frontmatter are updated too; fields
+left out of it are untouched and empty ones are cleared.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 
+		issueID, err := issueFromArgs(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		args = []string{issueID}
+
+		applyIssueDocumentFlags(cmd, issueID, plaintext, jsonOut)
+
+		if queueIfRequested(cmd, args, issueID) {
+			return
+		}
+
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			exitNotAuthenticated(plaintext, jsonOut)
 		}
 
 		client := api.NewClient(authHeader)
@@ -1195,82 +1761,84 @@ Examples:
 			input["title"] = title
 		}
 
-		// Handle description update
-		if cmd.Flags().Changed("description") {
-			description, _ := cmd.Flags().GetString("description")
-			input["description"] = description
+		// Handle description update, from a flag or a markdown file
+		if cmd.Flags().Changed("description") && cmd.Flags().Changed("from-file") {
+			output.Error("Cannot use --description and --from-file together", plaintext, jsonOut)
+			exit(1)
 		}
 
-		// Handle assignee update
-		if cmd.Flags().Changed("assignee") {
-			assignee, _ := cmd.Flags().GetString("assignee")
-			switch assignee {
-			case "me":
-				// Get current user
-				viewer, err := client.GetViewer(context.Background())
-				if err != nil {
-					output.Error(fmt.Sprintf("Failed to get current user: %v", err), plaintext, jsonOut)
-					os.Exit(1)
-				}
-				input["assigneeId"] = viewer.ID
-			case "unassigned", "":
-				input["assigneeId"] = nil
-			default:
-				// Look up user by email
-				users, err := client.GetUsers(context.Background(), 100, "", "")
-				if err != nil {
-					output.Error(fmt.Sprintf("Failed to get users: %v", err), plaintext, jsonOut)
-					os.Exit(1)
-				}
-
-				var foundUser *api.User
-				for _, user := range users.Nodes {
-					if user.Email == assignee || user.Name == assignee {
-						foundUser = &user
-						break
-					}
-
-	// Handle image uploads
-	imagePaths, _ := cmd.Flags().GetStringArray("image")
-	description := ""
-	if cmd.Flags().Changed("description") {
-		description, _ = cmd.Flags().GetString("description")
-	}
+		descriptionChanged := cmd.Flags().Changed("description")
+		description, _ := cmd.Flags().GetString("description")
+		baseDir := "."
 
-	if len(imagePaths) > 0 {
-		if !jsonOut && !plaintext {
-			fmt.Printf("Uploading %d image(s)...\n", len(imagePaths))
+		if cmd.Flags().Changed("from-file") {
+			path, _ := cmd.Flags().GetString("from-file")
+			content, err := readMarkdownInput(path)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			description = stripExportHeading(issueFileDescription(content), args[0])
+			descriptionChanged = true
+			if path != "-" {
+				baseDir = filepath.Dir(path)
+			}
 		}
 
-		for _, imagePath := range imagePaths {
-			assetURL, err := client.UploadFileToLinear(context.Background(), imagePath)
+		// Upload images referenced by local path and point the markdown at the uploaded assets
+		if uploadLocal, _ := cmd.Flags().GetBool("upload-local-images"); uploadLocal && descriptionChanged {
+			description, err = uploadLocalImages(context.Background(), client, description, baseDir, plaintext, jsonOut)
 			if err != nil {
-				output.Error(fmt.Sprintf("Failed to upload image %s: %v", imagePath, err), plaintext, jsonOut)
-				os.Exit(1)
+				exitWithError(err, err.Error(), plaintext, jsonOut)
 			}
+		}
 
-			// Inject image into description
-			altText := filepath.Base(imagePath)
-			description = files.InjectImageIntoMarkdown(description, assetURL, altText)
+		// Handle image uploads (appended to the new or existing description)
+		imagePaths, _ := cmd.Flags().GetStringArray("image")
+		if len(imagePaths) > 0 {
+			if !descriptionChanged {
+				existing, err := client.GetIssue(context.Background(), args[0])
+				if err != nil {
+					exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
+				}
+				description = existing.Description
+			}
 
 			if !jsonOut && !plaintext {
-				fmt.Printf("  ✓ Uploaded: %s\n", filepath.Base(imagePath))
+				fmt.Printf("Uploading %d image(s)...\n", len(imagePaths))
 			}
-		}
-	}
 
-	// Set description if it was changed or if images were uploaded
-	if cmd.Flags().Changed("description") || len(imagePaths) > 0 {
-		input["description"] = description
-	}
+			for _, imagePath := range imagePaths {
+				assetURL, err := uploadFile(context.Background(), client, imagePath, files.UploadOptions{})
+				if err != nil {
+					exitWithError(err, fmt.Sprintf("Failed to upload image %s: %v", imagePath, err), plaintext, jsonOut)
 				}
 
-				if foundUser == nil {
-					output.Error(fmt.Sprintf("User not found: %s", assignee), plaintext, jsonOut)
-					os.Exit(1)
+				// Inject image into description
+				altText := filepath.Base(imagePath)
+				description = files.InjectImageIntoMarkdown(description, assetURL, altText)
+
+				if !jsonOut && !plaintext {
+					fmt.Printf("  ✓ Uploaded: %s\n", filepath.Base(imagePath))
 				}
+			}
+		}
+
+		// Set description if it was changed or if images were uploaded
+		if descriptionChanged || len(imagePaths) > 0 {
+			input["description"] = description
+		}
 
-				input["assigneeId"] = foundUser.ID
+		// Handle assignee update
+		if cmd.Flags().Changed("assignee") {
+			assignee, _ := cmd.Flags().GetString("assignee")
+			assigneeID, err := resolveAssigneeID(context.Background(), client, assignee)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			if assigneeID != nil {
+				input["assigneeId"] = *assigneeID
+			} else {
+				input["assigneeId"] = nil
 			}
 		}
 
@@ -1281,52 +1849,40 @@ Examples:
 			// First, get the issue to know which team it belongs to
 			issue, err := client.GetIssue(context.Background(), args[0])
 			if err != nil {
-				output.Error(fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
-				os.Exit(1)
+				exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
 			}
 
 			// Get available states for the team
 			states, err := client.GetTeamStates(context.Background(), issue.Team.Key)
 			if err != nil {
-				output.Error(fmt.Sprintf("Failed to get team states: %v", err), plaintext, jsonOut)
-				os.Exit(1)
-			}
-
-			// Find the state by name (case-insensitive)
-			var stateID string
-			for _, state := range states {
-				if strings.EqualFold(state.Name, stateName) {
-					stateID = state.ID
-					break
-				}
+				exitWithError(err, fmt.Sprintf("Failed to get team states: %v", err), plaintext, jsonOut)
 			}
 
-			if stateID == "" {
-				// Show available states
-				var stateNames []string
-				for _, state := range states {
-					stateNames = append(stateNames, state.Name)
-				}
-				output.Error(fmt.Sprintf("State '%s' not found. Available states: %s", stateName, strings.Join(stateNames, ", ")), plaintext, jsonOut)
-				os.Exit(1)
+			// Match the state against the team's workflow (fuzzy)
+			state, err := matchWorkflowState(states, issue.Team.Key, stateName)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
 			}
 
-			input["stateId"] = stateID
+			input["stateId"] = state.ID
 		}
 
 		// Handle priority update
 		if cmd.Flags().Changed("priority") {
-			priority, _ := cmd.Flags().GetInt("priority")
-			input["priority"] = priority
+			input["priority"] = getPriorityFlag(cmd)
 		}
 
 		// Handle due date update
 		if cmd.Flags().Changed("due-date") {
 			dueDate, _ := cmd.Flags().GetString("due-date")
-			if dueDate == "" {
+			if dueDate == "" || strings.EqualFold(dueDate, "none") {
 				input["dueDate"] = nil
 			} else {
-				input["dueDate"] = dueDate
+				date, err := utils.ParseDueDate(dueDate, time.Now())
+				if err != nil {
+					exitWithError(err, fmt.Sprintf("Invalid due date: %v", err), plaintext, jsonOut)
+				}
+				input["dueDate"] = date
 			}
 		}
 
@@ -1341,12 +1897,12 @@ Examples:
 				parentIssueDetails, err := client.GetIssue(context.Background(), parentIssue)
 				if err != nil {
 					output.Error(fmt.Sprintf("Parent issue not found: %s", parentIssue), plaintext, jsonOut)
-					os.Exit(1)
+					exit(1)
 				}
 				// Prevent self-reference
 				if parentIssue == args[0] {
 					output.Error("Issue cannot be its own parent", plaintext, jsonOut)
-					os.Exit(1)
+					exit(1)
 				}
 				// Use the UUID instead of the identifier
 				input["parentId"] = parentIssueDetails.ID
@@ -1358,8 +1914,7 @@ Examples:
 		// Get the issue first to determine the team
 		issue, err := client.GetIssue(context.Background(), args[0])
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
 		}
 
 		// Handle cycle update
@@ -1367,8 +1922,7 @@ Examples:
 			cycleStr, _ := cmd.Flags().GetString("cycle")
 			cycleID, err := resolveCycleID(context.Background(), client, issue.Team.Key, cycleStr, plaintext, jsonOut)
 			if err != nil {
-				output.Error(err.Error(), plaintext, jsonOut)
-				os.Exit(1)
+				exitWithError(err, err.Error(), plaintext, jsonOut)
 			}
 			if cycleID != nil {
 				input["cycleId"] = *cycleID
@@ -1386,8 +1940,7 @@ Examples:
 			} else {
 				labelIDs, err := resolveLabelIDs(context.Background(), client, issue.Team.Key, labelsStr)
 				if err != nil {
-					output.Error(fmt.Sprintf("Failed to resolve labels: %v", err), plaintext, jsonOut)
-					os.Exit(1)
+					exitWithError(err, fmt.Sprintf("Failed to resolve labels: %v", err), plaintext, jsonOut)
 				}
 				input["labelIds"] = labelIDs
 			}
@@ -1396,27 +1949,57 @@ Examples:
 
 	// Handle estimate update
 	if cmd.Flags().Changed("estimate") {
-		estimate, _ := cmd.Flags().GetInt("estimate")
-		if estimate == 0 {
+		estimate, _ := cmd.Flags().GetString("estimate")
+		if clearsEstimate(estimate) {
 			// Setting to 0 means clear the estimate
 			input["estimate"] = nil
 		} else {
-			input["estimate"] = estimate
+			// Check the value against the scale of the issue's team
+			issue, err := client.GetIssue(context.Background(), args[0])
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
+			}
+			team, err := client.GetTeam(context.Background(), issue.Team.Key)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to get team: %v", err), plaintext, jsonOut)
+			}
+			points, err := resolveEstimate(team, estimate)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			input["estimate"] = points
 		}
 	}
 
+	// Handle milestone update, within the issue's project
+	if cmd.Flags().Changed("milestone") {
+		milestone, _ := cmd.Flags().GetString("milestone")
+		milestoneID, err := resolveIssueMilestoneID(context.Background(), client, args[0], milestone)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		input["projectMilestoneId"] = milestoneID
+	}
+
 
 		// Check if any updates were specified
 		if len(input) == 0 {
 			output.Error("No updates specified. Use flags to specify what to update.", plaintext, jsonOut)
-			os.Exit(1)
+			exit(1)
 		}
 
+		// Keep the previous values for 'linctl undo'
+		before, beforeErr := client.GetIssue(context.Background(), args[0])
+
 		// Update the issue
 		issue, err := client.UpdateIssue(context.Background(), args[0], input)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to update issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to update issue: %v", err), plaintext, jsonOut)
+		}
+		if beforeErr == nil {
+			if change, ok := issueUndoChange(before, issue, input); ok {
+				recordUndo(cmd, args, []undoChange{change})
+			}
 		}
 
 		if jsonOut {
@@ -1429,6 +2012,95 @@ Examples:
 	},
 }
 
+var issueMoveCmd = &cobra.Command{
+	Use:   "move ISSUE-ID...",
+	Short: "Move issues into a cycle",
+	Long: `Move one or more issues into a cycle of their team.
+
+Examples:
+  linctl issue move ENG-123 --cycle current
+  linctl issue move ENG-123 ENG-124 --cycle next
+  linctl issue move ENG-123 --cycle 42
+  linctl issue move ENG-123 --cycle none     # Remove from its cycle`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		cycleStr, _ := cmd.Flags().GetString("cycle")
+		if !cmd.Flags().Changed("cycle") {
+			output.Error("--cycle is required", plaintext, jsonOut)
+			exit(1)
+		}
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+		ctx := context.Background()
+
+		// Cycles belong to teams, so resolve once per team
+		cycleIDs := make(map[string]*string)
+		moved := []string{}
+		var undoChanges []undoChange
+		var failures []string
+		for _, id := range args {
+			issue, err := client.GetIssue(ctx, id)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", id, err))
+				continue
+			}
+
+			cycleID, ok := cycleIDs[issue.Team.Key]
+			if !ok {
+				cycleID, err = resolveCycleID(ctx, client, issue.Team.Key, cycleStr, plaintext, jsonOut)
+				if err != nil {
+					failures = append(failures, fmt.Sprintf("%s: %v", id, err))
+					continue
+				}
+				cycleIDs[issue.Team.Key] = cycleID
+			}
+
+			input := map[string]interface{}{"cycleId": nil}
+			if cycleID != nil {
+				input["cycleId"] = *cycleID
+			}
+			updated, err := client.UpdateIssue(ctx, issue.ID, input)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", id, err))
+				continue
+			}
+			moved = append(moved, issue.Identifier)
+			if change, ok := issueUndoChange(issue, updated, input); ok {
+				undoChanges = append(undoChanges, change)
+			}
+		}
+		recordUndo(cmd, args, undoChanges)
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"moved": moved,
+				"cycle": cycleStr,
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			for _, id := range moved {
+				output.Success(fmt.Sprintf("Moved %s to cycle %s", id, cycleStr), plaintext, jsonOut)
+			}
+			for _, failure := range failures {
+				output.Error(fmt.Sprintf("Failed to move %s", failure), plaintext, false)
+			}
+		}
+
+		exitOnFailures(len(moved), len(failures))
+	},
+}
+
 var issueDownloadImagesCmd = &cobra.Command{
 	Use:   "download-images <issue-id>",
 	Short: "Download images from an issue's description and optionally comments",
@@ -1440,8 +2112,7 @@ var issueDownloadImagesCmd = &cobra.Command{
 
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			exitNotAuthenticated(plaintext, jsonOut)
 		}
 
 		client := api.NewClient(authHeader)
@@ -1450,8 +2121,7 @@ var issueDownloadImagesCmd = &cobra.Command{
 		// Get the issue
 		issue, err := client.GetIssue(context.Background(), issueID)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
 		}
 
 		// Extract images from description
@@ -1467,7 +2137,14 @@ var issueDownloadImagesCmd = &cobra.Command{
 		}
 
 		if len(images) == 0 {
-			if !jsonOut {
+			if jsonOut {
+				output.JSON(map[string]interface{}{
+					"issue":      issue.Identifier,
+					"total":      0,
+					"downloaded": 0,
+					"failed":     0,
+				})
+			} else {
 				fmt.Println("No images found in issue description")
 			}
 			return
@@ -1479,29 +2156,25 @@ var issueDownloadImagesCmd = &cobra.Command{
 			outputDir = fmt.Sprintf("./linear-images-%s", issue.Identifier)
 		}
 
-		// Download each image
+		// Download images concurrently
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		results, _ := files.DownloadImages(context.Background(), images, outputDir, files.DownloadOptions{
+			Workers: concurrency,
+			Client:  client.TransferClient(),
+			Progress: func(result files.DownloadResult) {
+				if result.Err == nil && !jsonOut && !plaintext {
+					fmt.Printf("Downloaded: %s -> %s\n", result.Image.URL, result.Path)
+				}
+			},
+		})
+
 		downloaded := 0
 		errors := []string{}
-
-		for i, img := range images {
-			// Generate filename
-			filename := fmt.Sprintf("image-%d%s", i+1, filepath.Ext(img.URL))
-			if img.AltText != "" {
-				filename = files.SanitizeFilename(img.AltText) + filepath.Ext(img.URL)
-			}
-
-			outputPath := filepath.Join(outputDir, filename)
-
-			// Download image
-			err := files.DownloadImage(context.Background(), img.URL, outputPath, authHeader)
-			if err != nil {
-				errors = append(errors, fmt.Sprintf("Failed to download %s: %v", img.URL, err))
+		for _, result := range results {
+			if result.Err != nil {
+				errors = append(errors, result.Err.Error())
 				continue
 			}
-
-			if !jsonOut && !plaintext {
-				fmt.Printf("Downloaded: %s -> %s\n", img.URL, outputPath)
-			}
 			downloaded++
 		}
 
@@ -1535,66 +2208,111 @@ func init() {
 	issueCmd.AddCommand(issueListCmd)
 	issueCmd.AddCommand(issueSearchCmd)
 	issueCmd.AddCommand(issueGetCmd)
-	issueCmd.AddCommand(issueAssignCmd)
 	issueCmd.AddCommand(issueCreateCmd)
 	issueCmd.AddCommand(issueUpdateCmd)
+	issueCmd.AddCommand(issueMoveCmd)
 	issueCmd.AddCommand(issueDownloadImagesCmd)
 
 	// Issue list flags
-	issueListCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (email or 'me')")
-	issueListCmd.Flags().StringP("state", "s", "", "Filter by state name")
-	issueListCmd.Flags().StringP("team", "t", "", "Filter by team key")
-	issueListCmd.Flags().IntP("priority", "r", -1, "Filter by priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low)")
+	issueListCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (email, name, @me, @none, or @any)")
+	issueListCmd.Flags().String("creator", "", "Filter by creator (email, name, or @me)")
+	issueListCmd.Flags().StringP("state", "s", "", "Filter by state name or type (e.g. started)")
+	issueListCmd.Flags().StringP("team", "t", "", "Filter by team key (ENG, ENG-, @team, or @any)")
+	issueListCmd.Flags().VarP(newPriorityFlag(-1), "priority", "r", "Filter by priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low, or the name)")
 	issueListCmd.Flags().IntP("limit", "l", 50, "Maximum number of issues to fetch")
+	issueListCmd.Flags().Bool("all", false, "Fetch all pages of results (--limit caps the total when given)")
+	issueListCmd.Flags().Int("page-size", api.DefaultPageSize, "Number of issues to request per page when using --all (max 250)")
 	issueListCmd.Flags().BoolP("include-completed", "c", false, "Include completed and canceled issues")
-	issueListCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
+	addIssueOrderFlags(issueListCmd)
 	issueListCmd.Flags().StringP("newer-than", "n", "", "Show issues created after this time (default: 6_months_ago, use 'all_time' for no filter)")
 	issueListCmd.Flags().Bool("has-parent", false, "Filter for issues that have a parent (sub-issues only)")
 	issueListCmd.Flags().Bool("no-parent", false, "Filter for issues that don't have a parent (top-level issues only)")
 	issueListCmd.Flags().String("parent-issue", "", "Filter for sub-issues of a specific parent issue (e.g., 'LIN-123')")
+	markIssueRefFlags(issueListCmd.Flags(), "parent-issue")
+	issueListCmd.Flags().StringSlice("label", []string{}, "Filter by label name (repeat or comma-separate to require several)")
+	issueListCmd.Flags().String("view", "", "Apply a saved filter (see 'linctl view')")
+	issueListCmd.Flags().String("filter", "", "Filter expression, e.g. 'state:started AND (label:bug OR priority:>=high)' (see --help)")
+	issueListCmd.Flags().BoolP("watch", "w", false, "Keep polling and print new, changed, and closed issues")
+	issueListCmd.Flags().Duration("interval", 30*time.Second, "Polling interval for --watch")
+	issueListCmd.Flags().String("notify-slack", "", "With --watch, post changes to a Slack incoming webhook: its URL, or a name from the slack.webhooks setting")
+	issueListCmd.Flags().String("created-after", "", "Show issues created after a date (YYYY-MM-DD) or time expression (e.g. 2_weeks_ago); overrides --newer-than")
+	issueListCmd.Flags().String("updated-after", "", "Show issues updated after a date (YYYY-MM-DD) or time expression (e.g. 3_days_ago)")
+	issueListCmd.Flags().Bool("overdue", false, "Only issues whose due date has passed")
+	issueListCmd.Flags().String("due-within", "", "Only issues due between today and an offset like 7d or 2w (with --overdue, overdue ones too)")
+	issueListCmd.Flags().Bool("archived", false, "Include archived and trashed issues")
+	issueListCmd.Flags().Bool("cached", false, "Answer from the local cache (see 'linctl sync') instead of the API")
+	addFormatFlags(issueListCmd, columnNames(issueColumns), defaultIssueColumns)
+	addIssueFieldsFlag(issueListCmd)
 
 	// Issue search flags
-	issueSearchCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (email or 'me')")
-	issueSearchCmd.Flags().StringP("state", "s", "", "Filter by state name")
-	issueSearchCmd.Flags().StringP("team", "t", "", "Filter by team key")
-	issueSearchCmd.Flags().IntP("priority", "r", -1, "Filter by priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low)")
+	issueSearchCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (email, name, @me, @none, or @any)")
+	issueSearchCmd.Flags().String("creator", "", "Filter by creator (email, name, or @me)")
+	issueSearchCmd.Flags().StringP("state", "s", "", "Filter by state name or type (e.g. started)")
+	issueSearchCmd.Flags().StringP("team", "t", "", "Filter by team key (ENG, ENG-, @team, or @any)")
+	issueSearchCmd.Flags().VarP(newPriorityFlag(-1), "priority", "r", "Filter by priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low, or the name)")
 	issueSearchCmd.Flags().IntP("limit", "l", 50, "Maximum number of issues to fetch")
+	issueSearchCmd.Flags().Bool("all", false, "Fetch all pages of results (--limit caps the total when given)")
+	issueSearchCmd.Flags().Int("page-size", api.DefaultPageSize, "Number of issues to request per page when using --all (max 250)")
 	issueSearchCmd.Flags().BoolP("include-completed", "c", false, "Include completed and canceled issues")
 	issueSearchCmd.Flags().Bool("include-archived", false, "Include archived issues in results")
-	issueSearchCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
+	addIssueOrderFlags(issueSearchCmd)
 	issueSearchCmd.Flags().StringP("newer-than", "n", "", "Show issues created after this time (default: 6_months_ago, use 'all_time' for no filter)")
 	issueSearchCmd.Flags().Bool("has-parent", false, "Filter for issues that have a parent (sub-issues only)")
 	issueSearchCmd.Flags().Bool("no-parent", false, "Filter for issues that don't have a parent (top-level issues only)")
 	issueSearchCmd.Flags().String("parent-issue", "", "Filter for sub-issues of a specific parent issue (e.g., 'LIN-123')")
+	markIssueRefFlags(issueSearchCmd.Flags(), "parent-issue")
+	issueSearchCmd.Flags().StringSlice("label", []string{}, "Filter by label name (repeat or comma-separate to require several)")
+	issueSearchCmd.Flags().String("view", "", "Apply a saved filter (see 'linctl view')")
+	issueSearchCmd.Flags().String("filter", "", "Filter expression, e.g. 'state:started AND (label:bug OR priority:>=high)' (see --help)")
+	issueSearchCmd.Flags().String("created-after", "", "Show issues created after a date (YYYY-MM-DD) or time expression (e.g. 2_weeks_ago); overrides --newer-than")
+	issueSearchCmd.Flags().String("updated-after", "", "Show issues updated after a date (YYYY-MM-DD) or time expression (e.g. 3_days_ago)")
+	issueSearchCmd.Flags().Bool("overdue", false, "Only issues whose due date has passed")
+	issueSearchCmd.Flags().String("due-within", "", "Only issues due between today and an offset like 7d or 2w (with --overdue, overdue ones too)")
+	issueSearchCmd.Flags().Bool("cached", false, "Answer from the local cache (see 'linctl sync') instead of the API")
+	addFormatFlags(issueSearchCmd, columnNames(issueColumns), defaultIssueColumns)
+	addIssueFieldsFlag(issueSearchCmd)
 
 	// Issue create flags
-	issueCreateCmd.Flags().StringP("title", "", "", "Issue title (required)")
+	issueCreateCmd.Flags().StringP("title", "", "", "Issue title (required; prompted for in a terminal)")
 	issueCreateCmd.Flags().StringP("description", "d", "", "Issue description")
-	issueCreateCmd.Flags().StringP("team", "t", "", "Team key (required)")
-	issueCreateCmd.Flags().Int("priority", 3, "Priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low)")
+	issueCreateCmd.Flags().StringP("from-file", "F", "", "Read the issue from a markdown file, with optional frontmatter fields ('-' for stdin)")
+	issueCreateCmd.Flags().StringP("team", "t", "", "Team key (default: the parent issue's team, the template's team, then default-team; prompted for in a terminal)")
+	issueCreateCmd.Flags().Var(newPriorityFlag(3), "priority", "Priority: urgent, high, medium, low, none, or 0-4")
 	issueCreateCmd.Flags().BoolP("assign-me", "m", false, "Assign to yourself")
-	issueCreateCmd.Flags().String("cycle", "", "Cycle number to assign (e.g., '5', or 'unassigned' to remove)")
+	issueCreateCmd.Flags().StringP("assignee", "a", "", "Assignee (email, name, or @me)")
+	issueCreateCmd.Flags().Bool("subscribe-me", false, "Subscribe yourself to the issue's notifications")
+	issueCreateCmd.Flags().String("project", "", "Project ID, slug, or name (default: default-project, with the default team)")
+	issueCreateCmd.Flags().String("due-date", "", "Due date: YYYY-MM-DD, +3d, 2w, tomorrow, friday, next week, end of month (--due also works)")
+	issueCreateCmd.Flags().StringP("state", "s", "", "Workflow state name (default: the team's default state)")
+	issueCreateCmd.Flags().String("cycle", "", "Cycle to assign: a number (e.g., '5'), 'current', 'next', or 'unassigned' to remove")
 	issueCreateCmd.Flags().String("labels", "", "Comma-separated label names (e.g., \"Bug,High Priority,Backend\")")
 	issueCreateCmd.Flags().String("parent-issue", "", "Parent issue ID/identifier")
-	issueCreateCmd.Flags().Int("estimate", -1, "Estimate (story points, use 0 to leave unset)")
+	markIssueRefFlags(issueCreateCmd.Flags(), "parent-issue")
+	issueCreateCmd.Flags().String("estimate", "", "Estimate in points, or a size (M) for t-shirt teams; checked against the team's scale")
 	issueCreateCmd.Flags().StringArrayP("image", "i", []string{}, "Path to image file(s) to upload and attach (can be used multiple times)")
-	_ = issueCreateCmd.MarkFlagRequired("title")
-	_ = issueCreateCmd.MarkFlagRequired("team")
+	issueCreateCmd.Flags().String("template", "", "Local or Linear template to pre-fill the issue from (see 'linctl template list')")
+	issueCreateCmd.Flags().Bool("check-duplicates", false, "Search the team for similar issues first, and offer to relate or skip instead of creating")
+	issueCreateCmd.Flags().Bool("check-description", false, "With --check-duplicates, also compare the description")
 
 	// Issue update flags
 	issueUpdateCmd.Flags().String("title", "", "New title for the issue")
 	issueUpdateCmd.Flags().StringP("description", "d", "", "New description for the issue")
-	issueUpdateCmd.Flags().StringP("assignee", "a", "", "Assignee (email, name, 'me', or 'unassigned')")
-	issueUpdateCmd.Flags().StringP("state", "s", "", "State name (e.g., 'Todo', 'In Progress', 'Done')")
-	issueUpdateCmd.Flags().Int("priority", -1, "Priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low)")
-	issueUpdateCmd.Flags().String("due-date", "", "Due date (YYYY-MM-DD format, or empty to remove)")
+	issueUpdateCmd.Flags().StringP("assignee", "a", "", "Assignee (email, name, @me, or @none to unassign)")
+	issueUpdateCmd.Flags().StringP("state", "s", "", "State name (e.g., 'Todo', 'In Progress', 'Done'); partial names like 'in prog' work")
+	issueUpdateCmd.Flags().Var(newPriorityFlag(-1), "priority", "Priority: urgent, high, medium, low, none, or 0-4")
+	issueUpdateCmd.Flags().String("due-date", "", "Due date: YYYY-MM-DD, +3d, 2w, tomorrow, friday, next week, end of month, or empty/'none' to remove (--due also works)")
 	issueUpdateCmd.Flags().String("parent-issue", "", "Parent issue ID/identifier (or 'unassigned' to remove parent)")
-	issueUpdateCmd.Flags().String("cycle", "", "Cycle number to assign (e.g., '5', or 'unassigned' to remove)")
+	markIssueRefFlags(issueUpdateCmd.Flags(), "parent-issue")
+	issueMoveCmd.Flags().String("cycle", "", "Cycle to move to: a number, 'current', 'next', 'previous', or 'none'")
+	issueUpdateCmd.Flags().String("cycle", "", "Cycle to assign: a number (e.g., '5'), 'current', 'next', or 'unassigned' to remove")
 	issueUpdateCmd.Flags().String("labels", "", "Comma-separated label names (replaces existing labels, use empty string to remove all)")
-	issueUpdateCmd.Flags().Int("estimate", -1, "Estimate (story points, use 0 to clear)")
+	issueUpdateCmd.Flags().String("estimate", "", "Estimate in points, or a size (M) for t-shirt teams; 0 or 'none' clears it")
 	issueUpdateCmd.Flags().StringArrayP("image", "i", []string{}, "Path to image file(s) to upload and attach (can be used multiple times)")
+	issueUpdateCmd.Flags().StringP("from-file", "F", "", "Read the new description, and any frontmatter fields, from a markdown file ('-' for stdin)")
+	issueUpdateCmd.Flags().Bool("upload-local-images", false, "Upload images referenced by local path in the new description and rewrite their links")
 
 	// Issue download-images flags
 	issueDownloadImagesCmd.Flags().StringP("output-dir", "o", "", "Output directory for downloaded images (default: ./linear-images-<issue-id>)")
 	issueDownloadImagesCmd.Flags().BoolP("include-comments", "c", false, "Include images from comments in addition to issue description")
+	issueDownloadImagesCmd.Flags().Int("concurrency", 4, "Maximum number of images to download in parallel")
 }
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// issueLifecycleAction is what one of the archive and trash commands does to
+// each issue
+type issueLifecycleAction struct {
+	// done is the past tense, used in messages and as the JSON key
+	done string
+	// doing labels the progress bar
+	doing string
+	apply func(client *api.Client, ctx context.Context, id string) error
+	// undoKind is the undo journal change that reverts it
+	undoKind string
+}
+
+var issueArchiveCmd = &cobra.Command{
+	Use:   "archive [ISSUE-ID...]",
+	Short: "Archive issues",
+	Long: `Archive issues, hiding them from lists (see 'issue list --archived').
+
+Issue identifiers are read from stdin when none are given.
+
+Examples:
+  linctl issue archive ENG-123 ENG-124
+  linctl issue list --team ENG --state Canceled --json | jq -r '.[].identifier' | linctl issue archive
+  linctl issue unarchive ENG-123`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runIssueLifecycle(cmd, args, issueLifecycleAction{
+			done:     "archived",
+			doing:    "Archiving",
+			apply:    (*api.Client).ArchiveIssue,
+			undoKind: undoIssueArchive,
+		})
+	},
+}
+
+var issueUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive [ISSUE-ID...]",
+	Short: "Restore archived issues",
+	Long:  `Restore archived issues. Issue identifiers are read from stdin when none are given.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runIssueLifecycle(cmd, args, issueLifecycleAction{
+			done:     "unarchived",
+			doing:    "Unarchiving",
+			apply:    (*api.Client).UnarchiveIssue,
+			undoKind: undoIssueUnarchive,
+		})
+	},
+}
+
+var issueDeleteCmd = &cobra.Command{
+	Use:     "delete [ISSUE-ID...]",
+	Aliases: []string{"rm", "trash"},
+	Short:   "Move issues to the trash",
+	Long: `Move issues to the trash. Linear keeps trashed issues for 30 days before
+deleting them for good; until then 'issue restore' brings them back.
+
+Issue identifiers are read from stdin when none are given.
+
+Examples:
+  linctl issue delete ENG-123
+  linctl issue restore ENG-123`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runIssueLifecycle(cmd, args, issueLifecycleAction{
+			done:     "deleted",
+			doing:    "Deleting",
+			apply:    (*api.Client).DeleteIssue,
+			undoKind: undoIssueDelete,
+		})
+	},
+}
+
+var issueRestoreCmd = &cobra.Command{
+	Use:   "restore [ISSUE-ID...]",
+	Short: "Restore issues from the trash",
+	Long: `Restore issues moved to the trash with 'issue delete'. Issue identifiers are
+read from stdin when none are given.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runIssueLifecycle(cmd, args, issueLifecycleAction{
+			done:     "restored",
+			doing:    "Restoring",
+			apply:    (*api.Client).UnarchiveIssue,
+			undoKind: undoIssueRestore,
+		})
+	},
+}
+
+// runIssueLifecycle applies action to the issues named by args, or read
+// from stdin, and records the changes for 'linctl undo'
+func runIssueLifecycle(cmd *cobra.Command, args []string, action issueLifecycleAction) {
+	plaintext := viper.GetBool("plaintext")
+	jsonOut := viper.GetBool("json")
+
+	ids := args
+	if len(ids) == 0 {
+		changes, err := readBulkChanges("", "ids")
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		for _, change := range changes {
+			ids = append(ids, change.ID)
+		}
+	}
+	if len(ids) == 0 {
+		output.Error("No issues provided (give issue IDs or pipe them to stdin)", plaintext, jsonOut)
+		exit(1)
+	}
+
+	client := authenticatedClient(plaintext, jsonOut)
+	ctx := context.Background()
+
+	changed := []string{}
+	var failures []string
+	var undoChanges []undoChange
+	bar := output.NewProgress(action.doing+" issues...", len(ids))
+	for _, id := range ids {
+		id = strings.ToUpper(id)
+		err := action.apply(client, ctx, id)
+		bar.Add(1)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		changed = append(changed, id)
+		undoChanges = append(undoChanges, undoChange{
+			Kind:    action.undoKind,
+			Target:  id,
+			Label:   id,
+			Summary: action.done,
+		})
+	}
+	bar.Done()
+	recordUndo(cmd, args, undoChanges)
+
+	if jsonOut {
+		result := map[string]interface{}{
+			action.done: changed,
+		}
+		if len(failures) > 0 {
+			result["errors"] = failures
+		}
+		output.JSON(result)
+	} else {
+		for _, id := range changed {
+			output.Success(fmt.Sprintf("%s %s", strings.ToUpper(action.done[:1])+action.done[1:], id), plaintext, jsonOut)
+		}
+		for _, failure := range failures {
+			output.Error(fmt.Sprintf("Failed to %s %s", strings.TrimSuffix(action.done, "d"), failure), plaintext, false)
+		}
+	}
+
+	exitOnFailures(len(changed), len(failures))
+}
+
+func init() {
+	issueCmd.AddCommand(issueArchiveCmd)
+	issueCmd.AddCommand(issueUnarchiveCmd)
+	issueCmd.AddCommand(issueDeleteCmd)
+	issueCmd.AddCommand(issueRestoreCmd)
+}
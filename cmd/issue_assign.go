@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var issueAssignCmd = &cobra.Command{
+	Use:   "assign ISSUE-ID [USER]",
+	Short: "Assign an issue, with workload hints",
+	Long: `Assign an issue to a user (email, name, display name, 'me', or 'none').
+
+With no user given, a picker lists the members of the issue's team with how
+many open issues each has and how many are in progress, least loaded first.
+Outside a terminal, the issue is assigned to you.
+
+--round-robin assigns to the team's active members in turn, each time to the
+member after the one it last picked for that team, for triage automation.
+
+Examples:
+  linctl issue assign ENG-123                  # Pick a team member
+  linctl issue assign ENG-123 jane@example.com
+  linctl issue assign ENG-123 me
+  linctl issue assign ENG-123 --round-robin`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		roundRobin, _ := cmd.Flags().GetBool("round-robin")
+		if roundRobin && len(args) == 2 {
+			output.Error("Give a user or --round-robin, not both", plaintext, jsonOut)
+			exit(1)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		before, err := client.GetIssue(ctx, args[0])
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to fetch issue: %v", err), plaintext, jsonOut)
+		}
+
+		var assigneeID *string
+		switch {
+		case len(args) == 2:
+			assigneeID, err = resolveAssigneeID(ctx, client, args[1])
+		case roundRobin:
+			assigneeID, err = nextRoundRobinAssignee(ctx, client, before.Team)
+		case canPick():
+			assigneeID, err = pickAssignee(ctx, client, before.Team)
+		default:
+			assigneeID, err = resolveAssigneeID(ctx, client, meShortcut)
+		}
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		input := map[string]interface{}{"assigneeId": nil}
+		if assigneeID != nil {
+			input["assigneeId"] = *assigneeID
+		}
+		issue, err := client.UpdateIssue(ctx, before.ID, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to assign issue: %v", err), plaintext, jsonOut)
+		}
+		if change, ok := issueUndoChange(before, issue, input); ok {
+			recordUndo(cmd, args, []undoChange{change})
+		}
+
+		assignee := "no one"
+		if issue.Assignee != nil {
+			assignee = issue.Assignee.Name
+		}
+		if jsonOut {
+			output.JSON(issue)
+		} else if plaintext {
+			fmt.Printf("Assigned %s to %s\n", issue.Identifier, assignee)
+		} else {
+			fmt.Printf("%s Assigned %s to %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
+				color.New(color.FgCyan).Sprint(assignee))
+		}
+	},
+}
+
+// memberLoad is how many open issues a team member is assigned, and how many
+// of them are in progress
+type memberLoad struct {
+	User       api.User
+	Open       int
+	InProgress int
+}
+
+// teamWorkload is the load of each active member of a team, least loaded
+// first (fewest in progress, then fewest open)
+func teamWorkload(ctx context.Context, client *api.Client, team *api.Team) ([]memberLoad, error) {
+	if team == nil {
+		return nil, fmt.Errorf("the issue has no team")
+	}
+	members, err := client.GetTeamMembers(ctx, team.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of %s: %v", team.Key, err)
+	}
+
+	selection, err := api.IssueSelection([]string{"state", "assignee"})
+	if err != nil {
+		return nil, err
+	}
+	filter := map[string]interface{}{
+		"team":     map[string]interface{}{"key": map[string]interface{}{"eq": team.Key}},
+		"state":    map[string]interface{}{"type": map[string]interface{}{"nin": []string{"completed", "canceled"}}},
+		"assignee": map[string]interface{}{"null": false},
+	}
+	issues, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize},
+		func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+			page, err := client.GetIssuesWithFields(ctx, selection, filter, first, after, "", false)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch open issues of %s: %v", team.Key, err)
+	}
+
+	loads := make(map[string]*memberLoad)
+	var result []memberLoad
+	for _, user := range members.Nodes {
+		if user.Active {
+			loads[user.ID] = &memberLoad{User: user}
+		}
+	}
+	for _, issue := range issues {
+		if issue.Assignee == nil || loads[issue.Assignee.ID] == nil {
+			continue
+		}
+		load := loads[issue.Assignee.ID]
+		load.Open++
+		if issue.State != nil && issue.State.Type == "started" {
+			load.InProgress++
+		}
+	}
+	for _, load := range loads {
+		result = append(result, *load)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.InProgress != b.InProgress {
+			return a.InProgress < b.InProgress
+		}
+		if a.Open != b.Open {
+			return a.Open < b.Open
+		}
+		return strings.ToLower(a.User.Name) < strings.ToLower(b.User.Name)
+	})
+	return result, nil
+}
+
+// pickAssignee asks which team member to assign, showing each one's load
+func pickAssignee(ctx context.Context, client *api.Client, team *api.Team) (*string, error) {
+	loads, err := teamWorkload(ctx, client, team)
+	if err != nil {
+		return nil, err
+	}
+	if len(loads) == 0 {
+		return nil, fmt.Errorf("%s has no active members", team.Key)
+	}
+
+	items := make([]output.PickerItem, len(loads))
+	for i, load := range loads {
+		label := load.User.Name
+		if load.User.IsMe {
+			label += " (you)"
+		}
+		items[i] = output.PickerItem{
+			Label:  label,
+			Detail: fmt.Sprintf("%d open, %d in progress", load.Open, load.InProgress),
+		}
+	}
+	index, err := output.Pick("assignee", items)
+	if errors.Is(err, output.ErrPickCanceled) {
+		return nil, fmt.Errorf("no assignee picked")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &loads[index].User.ID, nil
+}
+
+// nextRoundRobinAssignee is the team's active member after the one last
+// assigned with --round-robin, in name order, and remembers the choice
+func nextRoundRobinAssignee(ctx context.Context, client *api.Client, team *api.Team) (*string, error) {
+	if team == nil {
+		return nil, fmt.Errorf("the issue has no team")
+	}
+	members, err := client.GetTeamMembers(ctx, team.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of %s: %v", team.Key, err)
+	}
+	var active []api.User
+	for _, user := range members.Nodes {
+		if user.Active {
+			active = append(active, user)
+		}
+	}
+	if len(active) == 0 {
+		return nil, fmt.Errorf("%s has no active members", team.Key)
+	}
+	sort.Slice(active, func(i, j int) bool { return strings.ToLower(active[i].Name) < strings.ToLower(active[j].Name) })
+
+	state, err := loadRoundRobin()
+	if err != nil {
+		return nil, err
+	}
+	next := 0
+	for i, user := range active {
+		if user.ID == state[team.Key] {
+			next = (i + 1) % len(active)
+			break
+		}
+	}
+	state[team.Key] = active[next].ID
+	if err := saveRoundRobin(state); err != nil {
+		return nil, fmt.Errorf("failed to save round-robin state: %v", err)
+	}
+	return &active[next].ID, nil
+}
+
+// roundRobinPath is ~/.linctl/round-robin/PROFILE.json, which maps each team
+// key to the user --round-robin last assigned
+func roundRobinPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl", "round-robin", auth.Profile()+".json"), nil
+}
+
+// loadRoundRobin reads the active profile's round-robin state
+func loadRoundRobin() (map[string]string, error) {
+	path, err := roundRobinPath()
+	if err != nil {
+		return nil, err
+	}
+	state := map[string]string{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read round-robin state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse round-robin state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// saveRoundRobin replaces the active profile's round-robin state
+func saveRoundRobin(state map[string]string) error {
+	path, err := roundRobinPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func init() {
+	issueCmd.AddCommand(issueAssignCmd)
+
+	issueAssignCmd.Flags().Bool("round-robin", false, "Assign to the team's members in turn")
+}
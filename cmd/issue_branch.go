@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// branchSlugPattern matches the runs of characters replaced by '-' in branch names
+var branchSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+var issueBranchCmd = &cobra.Command{
+	Use:   "branch ISSUE-ID",
+	Short: "Create or check out the git branch for an issue",
+	Long: `Create a git branch named the way Linear names it for the issue (the
+"Copy git branch name" format), or check it out if it already exists.
+
+Examples:
+  linctl issue branch ENG-123
+  linctl issue branch ENG-123 --base main
+  linctl issue branch ENG-123 --print   # only print the branch name`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		issue, err := client.GetIssue(context.Background(), args[0])
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to fetch issue: %v", err), plaintext, jsonOut)
+		}
+
+		branch := issue.BranchName
+		if branch == "" {
+			branch = fallbackBranchName(issue.Identifier, issue.Title)
+		}
+
+		if printOnly, _ := cmd.Flags().GetBool("print"); printOnly {
+			if jsonOut {
+				output.JSON(map[string]interface{}{"issue": issue.Identifier, "branch": branch})
+				return
+			}
+			fmt.Println(branch)
+			return
+		}
+
+		base, _ := cmd.Flags().GetString("base")
+		noCheckout, _ := cmd.Flags().GetBool("no-checkout")
+
+		exists := gitBranchExists(branch)
+		created := false
+		switch {
+		case exists && noCheckout:
+			// Nothing to do
+		case exists:
+			_, err = runGit("checkout", branch)
+		case noCheckout:
+			_, err = runGit(append([]string{"branch", branch}, nonEmpty(base)...)...)
+			created = true
+		default:
+			_, err = runGit(append([]string{"checkout", "-b", branch}, nonEmpty(base)...)...)
+			created = true
+		}
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{
+				"issue":      issue.Identifier,
+				"branch":     branch,
+				"created":    created,
+				"checkedOut": !noCheckout,
+			})
+			return
+		}
+
+		verb := "Checked out existing branch"
+		switch {
+		case created && noCheckout:
+			verb = "Created branch"
+		case created:
+			verb = "Created and checked out branch"
+		case noCheckout:
+			verb = "Branch already exists:"
+		}
+		if plaintext {
+			fmt.Printf("%s %s\n", verb, branch)
+			return
+		}
+		fmt.Printf("%s %s %s (%s)\n",
+			color.New(color.FgGreen).Sprint("✓"),
+			verb,
+			color.New(color.FgGreen).Sprint(branch),
+			color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier))
+	},
+}
+
+var issueCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Show the issue for the current git branch",
+	Long: `Find the issue identifier in the current git branch name (e.g. ENG-123 in
+"kyle/eng-123-fix-login") and show the issue.
+
+Commands that take an issue ID also accept --current to use this issue, e.g.
+'linctl comment add --current --body "..."'.
+
+Examples:
+  linctl issue current
+  linctl issue current --id-only`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		identifier, err := currentBranchIssue()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if idOnly, _ := cmd.Flags().GetBool("id-only"); idOnly {
+			if jsonOut {
+				output.JSON(map[string]interface{}{"identifier": identifier})
+				return
+			}
+			fmt.Println(identifier)
+			return
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		issue, err := client.GetIssue(context.Background(), identifier)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to fetch issue %s: %v", identifier, err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(issue)
+			return
+		}
+
+		state := ""
+		if issue.State != nil {
+			state = issue.State.Name
+		}
+		if plaintext {
+			fmt.Printf("%s\t%s\t%s\t%s\n", issue.Identifier, issue.Title, state, issue.URL)
+			return
+		}
+		fmt.Printf("%s %s\n",
+			color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
+			color.New(color.FgWhite, color.Bold).Sprint(issue.Title))
+		if state != "" {
+			fmt.Printf("  State: %s\n", state)
+		}
+		fmt.Printf("  %s\n", color.New(color.FgBlue, color.Underline).Sprint(issue.URL))
+	},
+}
+
+// pullRequestPatterns recognize GitHub, GitLab, and Bitbucket pull request URLs;
+// each captures the repository and the number
+var pullRequestPatterns = []struct {
+	pattern *regexp.Regexp
+	kind    string
+	prefix  string
+}{
+	{regexp.MustCompile(`^https?://github\.com/([^/]+/[^/]+)/pull/(\d+)`), "Pull request", "#"},
+	{regexp.MustCompile(`^https?://[^/]*gitlab[^/]*/(.+?)/-/merge_requests/(\d+)`), "Merge request", "!"},
+	{regexp.MustCompile(`^https?://bitbucket\.org/([^/]+/[^/]+)/pull-requests/(\d+)`), "Pull request", "#"},
+}
+
+var issueLinkPRCmd = &cobra.Command{
+	Use:     "link-pr [ISSUE-ID] PR-URL",
+	Aliases: []string{"link-mr"},
+	Short:   "Link a pull request to an issue",
+	Long: `Attach a GitHub pull request (or GitLab merge request, or Bitbucket pull
+request) to an issue. With --current the issue comes from the git branch name.
+
+Examples:
+  linctl issue link-pr ENG-123 https://github.com/acme/api/pull/42
+  linctl issue link-pr --current https://gitlab.com/acme/api/-/merge_requests/7`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		want := 2
+		if current, _ := cmd.Flags().GetBool("current"); current {
+			want = 1
+		}
+		if len(args) != want {
+			return fmt.Errorf("expected an issue ID (or --current) and a pull request URL")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		prURL := strings.TrimSpace(args[len(args)-1])
+		title, subtitle, ok := describePullRequest(prURL)
+		if !ok {
+			output.Error(fmt.Sprintf("'%s' is not a GitHub, GitLab, or Bitbucket pull request URL (use 'linctl attachment add --url' for other links)", prURL), plaintext, jsonOut)
+			exit(1)
+		}
+		if custom, _ := cmd.Flags().GetString("title"); custom != "" {
+			title = custom
+		}
+
+		issueID, err := issueFromArgs(cmd, args[:len(args)-1])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		attachment, err := client.CreateAttachment(context.Background(), map[string]interface{}{
+			"issueId":  issueID,
+			"url":      prURL,
+			"title":    title,
+			"subtitle": subtitle,
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to link pull request: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(attachment)
+		} else if plaintext {
+			fmt.Printf("Linked %s to %s\n", attachment.URL, issueID)
+		} else {
+			fmt.Printf("%s Linked %s to %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgWhite, color.Bold).Sprint(attachment.Title),
+				color.New(color.FgCyan, color.Bold).Sprint(issueID))
+			fmt.Printf("  %s\n", color.New(color.FgBlue, color.Underline).Sprint(attachment.URL))
+		}
+	},
+}
+
+// describePullRequest returns an attachment title ("acme/api#42") and
+// subtitle ("Pull request") for a pull request URL
+func describePullRequest(prURL string) (title, subtitle string, ok bool) {
+	for _, p := range pullRequestPatterns {
+		if match := p.pattern.FindStringSubmatch(prURL); match != nil {
+			return match[1] + p.prefix + match[2], p.kind, true
+		}
+	}
+	return "", "", false
+}
+
+// issueArg accepts exactly one issue ID, or none when --current is given or
+// the issue can be picked
+func issueArg(cmd *cobra.Command, args []string) error {
+	if current, _ := cmd.Flags().GetBool("current"); current {
+		if len(args) > 0 {
+			return fmt.Errorf("give an issue ID or --current, not both")
+		}
+		return nil
+	}
+	if len(args) == 0 && canPick() {
+		return nil
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("accepts 1 issue ID (or --current), received %d", len(args))
+	}
+	return nil
+}
+
+// issueFromArgs returns the issue ID argument, with --current the issue
+// named by the current git branch, or else one picked from my issues
+func issueFromArgs(cmd *cobra.Command, args []string) (string, error) {
+	if current, _ := cmd.Flags().GetBool("current"); current {
+		return currentBranchIssue()
+	}
+	return argFromPick(cmd, args)
+}
+
+// currentBranchIssue returns the issue identifier in the checked-out branch's
+// name, or of the issue Linear links to the branch
+func currentBranchIssue() (string, error) {
+	branch, err := runGit("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if branch == "HEAD" {
+		return "", fmt.Errorf("not on a branch (detached HEAD)")
+	}
+	if identifier := api.IdentifierFromBranch(branch); identifier != "" {
+		return identifier, nil
+	}
+	// Linear also links branches it didn't name, such as ones given in a PR
+	client := authenticatedClient(viper.GetBool("plaintext"), viper.GetBool("json"))
+	issue, err := client.ResolveIssue(context.Background(), branch)
+	if errors.Is(err, api.ErrNotFound) {
+		return "", fmt.Errorf("no issue identifier in branch name '%s'", branch)
+	}
+	if err != nil {
+		return "", err
+	}
+	return issue.Identifier, nil
+}
+
+// fallbackBranchName builds a Linear-style branch name when the API has none
+func fallbackBranchName(identifier, title string) string {
+	slug := strings.Trim(branchSlugPattern.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(slug) > 50 {
+		slug = strings.TrimRight(slug[:50], "-")
+	}
+	if slug == "" {
+		return strings.ToLower(identifier)
+	}
+	return strings.ToLower(identifier) + "-" + slug
+}
+
+// gitBranchExists reports whether a local branch exists
+func gitBranchExists(branch string) bool {
+	_, err := runGit("rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// runGit runs git and returns its trimmed stdout, or an error with git's message
+func runGit(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	git := exec.Command("git", args...)
+	git.Stdout = &stdout
+	git.Stderr = &stderr
+	if err := git.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], message)
+		}
+		return "", fmt.Errorf("git %s: %v", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// nonEmpty returns value as a one-element list, or nothing when it is empty
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+func init() {
+	issueCmd.AddCommand(issueBranchCmd)
+	issueCmd.AddCommand(issueCurrentCmd)
+	issueCmd.AddCommand(issueLinkPRCmd)
+
+	issueBranchCmd.Flags().String("base", "", "Start the new branch from this ref (default: HEAD)")
+	issueBranchCmd.Flags().Bool("no-checkout", false, "Create the branch without checking it out")
+	issueBranchCmd.Flags().Bool("print", false, "Only print the branch name")
+	issueCurrentCmd.Flags().Bool("id-only", false, "Only print the identifier (no API request)")
+	issueLinkPRCmd.Flags().String("title", "", "Attachment title (default: owner/repo#number)")
+
+	for _, c := range []*cobra.Command{issueGetCmd, issueUpdateCmd, commentCreateCmd} {
+		c.Args = issueArg
+	}
+	pickable("issue", issueGetCmd, issueUpdateCmd, commentCreateCmd)
+	for _, c := range []*cobra.Command{issueGetCmd, issueUpdateCmd, commentCreateCmd, issueLinkPRCmd} {
+		c.Flags().Bool("current", false, "Use the issue named by the current git branch (see 'issue current')")
+	}
+}
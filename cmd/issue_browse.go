@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const browseHelp = `Commands:
+  <n>                 View issue n
+  /<text>             Search issues (a bare / clears the search)
+  s <n> <state>       Set the state of issue n
+  a <n> <assignee>    Set the assignee of issue n (email, name, 'me', or 'unassigned')
+  l <n> <labels>      Replace the labels of issue n (comma-separated)
+  o <n>               Open issue n in the browser
+  r                   Refresh the list
+  h, ?                Show this help
+  q                   Quit`
+
+const (
+	// browseListPrefixWidth is the width of a list row before its title:
+	// number, identifier, state, and assignee columns and their spaces
+	browseListPrefixWidth = 3 + 1 + 10 + 1 + 14 + 1 + 16 + 1
+	// minBrowseTitleWidth keeps titles readable on very narrow terminals
+	minBrowseTitleWidth = 20
+)
+
+var issueBrowseCmd = &cobra.Command{
+	Use:     "browse",
+	Aliases: []string{"tui"},
+	Short:   "Browse and triage issues interactively",
+	Long: `Browse issues in an interactive terminal session: filter and search,
+view details, change state/assignee/labels inline, and open issues in the browser.
+
+The list accepts the same filters as 'issue list'.
+
+` + browseHelp + `
+
+Examples:
+  linctl issue browse
+  linctl issue browse --team ENG --assignee me
+  linctl tui --state "In Review"`,
+	Args: cobra.NoArgs,
+	Run:  runIssueBrowse,
+}
+
+// tuiCmd is a top-level shortcut for 'issue browse'
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse and triage issues interactively (same as 'issue browse')",
+	Long:  issueBrowseCmd.Long,
+	Args:  cobra.NoArgs,
+	Run:   runIssueBrowse,
+}
+
+// issueBrowser holds the state of an interactive browse session
+type issueBrowser struct {
+	client   *api.Client
+	resolver *bulkResolver
+	filter   map[string]interface{}
+	limit    int
+	search   string
+	issues   []api.Issue
+	out      io.Writer
+}
+
+func runIssueBrowse(cmd *cobra.Command, args []string) {
+	plaintext := viper.GetBool("plaintext")
+	jsonOut := viper.GetBool("json")
+
+	if plaintext || jsonOut || !isInteractive() {
+		output.Error("Interactive browsing requires a terminal; use 'linctl issue list' for scripting", plaintext, jsonOut)
+		exit(1)
+	}
+
+	authHeader, err := auth.GetAuthHeader()
+	if err != nil {
+		exitNotAuthenticated(plaintext, jsonOut)
+	}
+
+	client := api.NewClient(authHeader)
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	browser := &issueBrowser{
+		client:   client,
+		resolver: &bulkResolver{client: client, cache: make(map[string]*bulkCacheEntry)},
+		filter:   buildIssueFilter(cmd),
+		limit:    limit,
+		out:      os.Stdout,
+	}
+
+	ctx := context.Background()
+	if err := browser.refresh(ctx); err != nil {
+		exitWithError(err, fmt.Sprintf("Failed to fetch issues: %v", err), plaintext, jsonOut)
+	}
+	browser.renderList()
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Fprint(browser.out, color.New(color.FgCyan, color.Bold).Sprint("browse> "))
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Fprintln(browser.out)
+			return
+		}
+		if quit := browser.handle(ctx, strings.TrimSpace(line)); quit {
+			return
+		}
+	}
+}
+
+// refresh reloads the issue list using the current filter and search text
+func (b *issueBrowser) refresh(ctx context.Context) error {
+	var (
+		issues *api.Issues
+		err    error
+	)
+	if b.search != "" {
+		issues, err = b.client.IssueSearch(ctx, b.search, b.filter, b.limit, "", "", false)
+	} else {
+		issues, err = b.client.GetIssues(ctx, b.filter, b.limit, "", "")
+	}
+	if err != nil {
+		return err
+	}
+	b.issues = issues.Nodes
+	return nil
+}
+
+// handle runs a single command line and reports whether the session should end
+func (b *issueBrowser) handle(ctx context.Context, line string) bool {
+	if line == "" {
+		return false
+	}
+
+	if strings.HasPrefix(line, "/") {
+		b.search = strings.TrimSpace(strings.TrimPrefix(line, "/"))
+		b.reload(ctx)
+		return false
+	}
+
+	fields := strings.Fields(line)
+	command := strings.ToLower(fields[0])
+
+	switch command {
+	case "q", "quit", "exit":
+		return true
+	case "h", "help", "?":
+		fmt.Fprintln(b.out, browseHelp)
+	case "r", "refresh":
+		b.reload(ctx)
+	case "o", "open":
+		issue, ok := b.pick(fields, 2)
+		if !ok {
+			return false
+		}
+		if err := utils.OpenBrowser(issue.URL); err != nil {
+			b.fail(fmt.Errorf("failed to open browser: %v (URL: %s)", err, issue.URL))
+		}
+	case "s", "a", "l":
+		issue, ok := b.pick(fields, 3)
+		if !ok {
+			return false
+		}
+		value := strings.Join(fields[2:], " ")
+
+		change := bulkChange{ID: issue.Identifier}
+		switch command {
+		case "s":
+			change.State = value
+		case "a":
+			change.Assignee = value
+		case "l":
+			change.Labels = labelList(value)
+		}
+
+		result := applyBulkChange(ctx, b.resolver, change, false)
+		if result.Error != "" {
+			b.fail(fmt.Errorf("%s", result.Error))
+			return false
+		}
+		fmt.Fprintf(b.out, "%s %s %s\n",
+			color.New(color.FgGreen).Sprint("✓"),
+			color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
+			describeBulkChanges(result.Changes))
+		b.reload(ctx)
+	default:
+		issue, ok := b.pick([]string{"v", fields[0]}, 2)
+		if !ok {
+			return false
+		}
+		b.renderIssue(ctx, issue.Identifier)
+	}
+
+	return false
+}
+
+// pick resolves the 1-based issue number in fields[1], requiring at least minFields fields
+func (b *issueBrowser) pick(fields []string, minFields int) (api.Issue, bool) {
+	if len(fields) < minFields {
+		b.fail(fmt.Errorf("missing arguments (type ? for help)"))
+		return api.Issue{}, false
+	}
+	n, err := strconv.Atoi(fields[1])
+	if err != nil || n < 1 || n > len(b.issues) {
+		b.fail(fmt.Errorf("unknown command or issue number %q (type ? for help)", fields[1]))
+		return api.Issue{}, false
+	}
+	return b.issues[n-1], true
+}
+
+func (b *issueBrowser) reload(ctx context.Context) {
+	if err := b.refresh(ctx); err != nil {
+		b.fail(fmt.Errorf("failed to fetch issues: %v", err))
+		return
+	}
+	b.renderList()
+}
+
+func (b *issueBrowser) fail(err error) {
+	fmt.Fprintf(b.out, "%s %v\n", color.New(color.FgRed).Sprint("✗"), err)
+}
+
+func (b *issueBrowser) renderList() {
+	title := "Issues"
+	if b.search != "" {
+		title = fmt.Sprintf("Search: %q", b.search)
+	}
+	fmt.Fprintf(b.out, "\n%s (%d)\n\n", color.New(color.FgCyan, color.Bold).Sprint(title), len(b.issues))
+
+	if len(b.issues) == 0 {
+		fmt.Fprintln(b.out, color.New(color.FgWhite, color.Faint).Sprint("  No issues found"))
+	}
+
+	// The title takes what the other columns leave of the terminal's width
+	titleWidth := 0
+	if width := output.TerminalWidth(); width > 0 {
+		titleWidth = width - browseListPrefixWidth
+		if titleWidth < minBrowseTitleWidth {
+			titleWidth = minBrowseTitleWidth
+		}
+	}
+
+	for i, issue := range b.issues {
+		state := ""
+		stateColor := stateTypeColor("", "")
+		if issue.State != nil {
+			state = issue.State.Name
+			stateColor = stateTypeColor(issue.State.Type, issue.State.Color)
+		}
+		assignee := "Unassigned"
+		if issue.Assignee != nil {
+			assignee = issue.Assignee.Name
+		}
+		fmt.Fprintf(b.out, "%s %s %s %s %s\n",
+			color.New(color.FgWhite, color.Faint).Sprintf("%3d", i+1),
+			color.New(color.FgCyan).Sprintf("%-10s", issue.Identifier),
+			stateColor.Sprintf("%-14s", truncateString(state, 14)),
+			color.New(color.FgWhite, color.Faint).Sprintf("%-16s", truncateString(assignee, 16)),
+			browseTitle(issue.Title, titleWidth))
+	}
+	fmt.Fprintf(b.out, "\n%s\n", color.New(color.FgWhite, color.Faint).Sprint("Type a number to view an issue, ? for help, q to quit"))
+}
+
+// browseTitle fits a title to width columns, or leaves it whole without a width
+func browseTitle(title string, width int) string {
+	if width <= 0 {
+		return title
+	}
+	return output.Truncate(title, width)
+}
+
+func (b *issueBrowser) renderIssue(ctx context.Context, id string) {
+	issue, err := b.client.GetIssue(ctx, id)
+	if err != nil {
+		b.fail(fmt.Errorf("failed to fetch issue: %v", err))
+		return
+	}
+
+	fmt.Fprintf(b.out, "\n%s %s\n",
+		color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
+		color.New(color.FgWhite, color.Bold).Sprint(issue.Title))
+
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(b.out, "  %s %s\n", color.New(color.FgWhite, color.Faint).Sprintf("%-10s", name+":"), value)
+		}
+	}
+	if issue.State != nil {
+		field("State", issue.State.Name)
+	}
+	if issue.Assignee != nil {
+		field("Assignee", issue.Assignee.Name)
+	} else {
+		field("Assignee", "Unassigned")
+	}
+	field("Priority", priorityToString(issue.Priority))
+	if issue.Labels != nil && len(issue.Labels.Nodes) > 0 {
+		names := make([]string, len(issue.Labels.Nodes))
+		for i, label := range issue.Labels.Nodes {
+			names[i] = label.Name
+		}
+		field("Labels", strings.Join(names, ", "))
+	}
+	if issue.Project != nil {
+		field("Project", issue.Project.Name)
+	}
+	if issue.DueDate != nil {
+		field("Due", *issue.DueDate)
+	}
+	field("URL", issue.URL)
+
+	if issue.Description != "" {
+		fmt.Fprintf(b.out, "\n%s", output.RenderMarkdown(issue.Description))
+	}
+	fmt.Fprintln(b.out)
+}
+
+func init() {
+	issueCmd.AddCommand(issueBrowseCmd)
+	rootCmd.AddCommand(tuiCmd)
+
+	for _, c := range []*cobra.Command{issueBrowseCmd, tuiCmd} {
+		c.Flags().StringP("assignee", "a", "", "Filter by assignee (email, name, @me, @none, or @any)")
+		c.Flags().String("creator", "", "Filter by creator (email, name, or @me)")
+		c.Flags().StringP("state", "s", "", "Filter by state name or type (e.g. started)")
+		c.Flags().StringP("team", "t", "", "Filter by team key (ENG, ENG-, @team, or @any)")
+		c.Flags().VarP(newPriorityFlag(-1), "priority", "r", "Filter by priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low, or the name)")
+		c.Flags().IntP("limit", "l", 50, "Maximum number of issues to show")
+		c.Flags().BoolP("include-completed", "c", false, "Include completed and canceled issues")
+		c.Flags().StringP("newer-than", "n", "", "Show issues created after this time (default: 6_months_ago, use 'all_time' for no filter)")
+		c.Flags().Bool("has-parent", false, "Filter for issues that have a parent (sub-issues only)")
+		c.Flags().Bool("no-parent", false, "Filter for issues that don't have a parent (top-level issues only)")
+		c.Flags().String("parent-issue", "", "Filter for sub-issues of a specific parent issue (e.g., 'LIN-123')")
+		markIssueRefFlags(c.Flags(), "parent-issue")
+		c.Flags().String("view", "", "Apply a saved filter (see 'linctl view')")
+		c.Flags().String("filter", "", "Filter expression, e.g. 'state:started AND (label:bug OR priority:>=high)' (see 'linctl issue list --help')")
+	}
+}
@@ -0,0 +1,542 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// bulkChange is one row of a bulk-update manifest. Empty fields fall back to the flag values.
+type bulkChange struct {
+	ID       string    `json:"id"`
+	State    string    `json:"state,omitempty"`
+	Assignee string    `json:"assignee,omitempty"`
+	Labels   labelList `json:"labels,omitempty"`
+	Project  string    `json:"project,omitempty"`
+}
+
+// labelList accepts either a comma-separated string or an array of label names in JSON
+type labelList string
+
+func (l *labelList) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		*l = labelList(strings.Join(names, ","))
+		return nil
+	}
+	var joined string
+	if err := json.Unmarshal(data, &joined); err != nil {
+		return fmt.Errorf("labels must be a string or an array of strings")
+	}
+	*l = labelList(joined)
+	return nil
+}
+
+// bulkResult records the outcome for a single issue
+type bulkResult struct {
+	ID      string                 `json:"id"`
+	Status  string                 `json:"status"`
+	Changes map[string]interface{} `json:"changes,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+	// undo reverts the change, for 'linctl undo'
+	undo *undoChange
+}
+
+var issueBulkUpdateCmd = &cobra.Command{
+	Use:   "bulk-update",
+	Short: "Apply the same changes to many issues",
+	Long: `Apply state, assignee, label, and project changes to many issues at once.
+
+Issues are read from --file or stdin as one of:
+  - issue identifiers, one per line (or separated by whitespace)
+  - a JSON array (or JSON lines) of objects: {"id", "state", "assignee", "labels", "project"}
+  - a CSV file with a header row containing "id" and any of state, assignee, labels, project
+
+Values in a JSON/CSV manifest override the flag values for that issue.
+
+Examples:
+  linctl issue list --json | jq -r '.[].identifier' | linctl issue bulk-update --state Done
+  linctl issue bulk-update --file triage.csv --dry-run
+  echo "LIN-1 LIN-2 LIN-3" | linctl issue bulk-update --assignee me --labels Bug --concurrency 8`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		path, _ := cmd.Flags().GetString("file")
+		format, _ := cmd.Flags().GetString("format")
+		changes, err := readBulkChanges(path, format)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if len(changes) == 0 {
+			output.Error("No issues provided (use --file or pipe identifiers to stdin)", plaintext, jsonOut)
+			exit(1)
+		}
+
+		defaults := bulkChange{}
+		defaults.State, _ = cmd.Flags().GetString("state")
+		defaults.Assignee, _ = cmd.Flags().GetString("assignee")
+		labels, _ := cmd.Flags().GetString("labels")
+		defaults.Labels = labelList(labels)
+		defaults.Project, _ = cmd.Flags().GetString("project")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		if concurrency <= 0 {
+			concurrency = 1
+		}
+
+		resolver := &bulkResolver{client: client, cache: make(map[string]*bulkCacheEntry)}
+		results := make([]bulkResult, len(changes))
+
+		bar := output.NewProgress("Updating issues...", len(changes))
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < concurrency && w < len(changes); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range jobs {
+					results[i] = applyBulkChange(context.Background(), resolver, mergeBulkChange(changes[i], defaults), dryRun)
+					bar.Add(1)
+				}
+			}()
+		}
+		for i := range changes {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+		bar.Done()
+
+		succeeded, failed := 0, 0
+		var undoChanges []undoChange
+		for _, result := range results {
+			if result.Error != "" {
+				failed++
+			} else {
+				succeeded++
+			}
+			if result.undo != nil {
+				undoChanges = append(undoChanges, *result.undo)
+			}
+		}
+		recordUndo(cmd, args, undoChanges)
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{
+				"total":     len(results),
+				"succeeded": succeeded,
+				"failed":    failed,
+				"dryRun":    dryRun,
+				"results":   results,
+			})
+		} else if plaintext {
+			for _, result := range results {
+				if result.Error != "" {
+					fmt.Printf("%s\tfailed\t%s\n", result.ID, result.Error)
+				} else {
+					fmt.Printf("%s\t%s\t%s\n", result.ID, result.Status, describeBulkChanges(result.Changes))
+				}
+			}
+			fmt.Printf("\nTotal: %d, Succeeded: %d, Failed: %d\n", len(results), succeeded, failed)
+		} else {
+			for _, result := range results {
+				if result.Error != "" {
+					fmt.Printf("%s %s %s\n",
+						color.New(color.FgRed).Sprint("✗"),
+						color.New(color.FgCyan, color.Bold).Sprint(result.ID),
+						result.Error)
+					continue
+				}
+				icon := color.New(color.FgGreen).Sprint("✓")
+				if dryRun {
+					icon = color.New(color.FgYellow).Sprint("~")
+				}
+				fmt.Printf("%s %s %s\n",
+					icon,
+					color.New(color.FgCyan, color.Bold).Sprint(result.ID),
+					color.New(color.FgWhite, color.Faint).Sprint(describeBulkChanges(result.Changes)))
+			}
+
+			verb := "Updated"
+			if dryRun {
+				verb = "Would update"
+			}
+			fmt.Printf("\n%s %s %d/%d issues",
+				color.New(color.FgGreen).Sprint("✓"), verb, succeeded, len(results))
+			if failed > 0 {
+				fmt.Printf(", %s", color.New(color.FgRed).Sprintf("%d failed", failed))
+			}
+			fmt.Println()
+		}
+
+		exitOnFailures(succeeded, failed)
+	},
+}
+
+// readBulkChanges parses issue identifiers or a JSON/CSV manifest from a file or stdin
+func readBulkChanges(path, format string) ([]bulkChange, error) {
+	var reader io.Reader = os.Stdin
+	if path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close()
+		reader = f
+	} else if isInteractive() {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return nil, nil
+	}
+
+	if format == "" || format == "auto" {
+		firstLine, _, _ := strings.Cut(text, "\n")
+		switch {
+		case strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{"):
+			format = "json"
+		case strings.Contains(firstLine, ",") && strings.EqualFold(strings.TrimSpace(strings.Split(firstLine, ",")[0]), "id"):
+			format = "csv"
+		default:
+			format = "ids"
+		}
+	}
+
+	var changes []bulkChange
+	switch format {
+	case "ids":
+		for _, id := range strings.Fields(text) {
+			changes = append(changes, bulkChange{ID: id})
+		}
+	case "json":
+		if strings.HasPrefix(text, "[") {
+			if err := json.Unmarshal([]byte(text), &changes); err != nil {
+				return nil, fmt.Errorf("invalid JSON manifest: %w", err)
+			}
+		} else {
+			// JSON lines: one object per line
+			scanner := bufio.NewScanner(strings.NewReader(text))
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if line == "" {
+					continue
+				}
+				var change bulkChange
+				if err := json.Unmarshal([]byte(line), &change); err != nil {
+					return nil, fmt.Errorf("invalid JSON line %q: %w", line, err)
+				}
+				changes = append(changes, change)
+			}
+		}
+	case "csv":
+		records, err := csv.NewReader(strings.NewReader(text)).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV manifest: %w", err)
+		}
+		if len(records) == 0 {
+			return nil, nil
+		}
+		columns := make(map[string]int)
+		for i, name := range records[0] {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		if _, ok := columns["id"]; !ok {
+			return nil, fmt.Errorf("CSV manifest must have an 'id' column")
+		}
+		field := func(record []string, name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		for _, record := range records[1:] {
+			changes = append(changes, bulkChange{
+				ID:       field(record, "id"),
+				State:    field(record, "state"),
+				Assignee: field(record, "assignee"),
+				Labels:   labelList(field(record, "labels")),
+				Project:  field(record, "project"),
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unknown input format '%s' (valid: auto, ids, json, csv)", format)
+	}
+
+	// Drop rows without an identifier
+	valid := changes[:0]
+	for _, change := range changes {
+		if strings.TrimSpace(change.ID) != "" {
+			change.ID = strings.TrimSpace(change.ID)
+			valid = append(valid, change)
+		}
+	}
+	return valid, nil
+}
+
+// mergeBulkChange fills empty manifest fields from the flag defaults
+func mergeBulkChange(change, defaults bulkChange) bulkChange {
+	if change.State == "" {
+		change.State = defaults.State
+	}
+	if change.Assignee == "" {
+		change.Assignee = defaults.Assignee
+	}
+	if change.Labels == "" {
+		change.Labels = defaults.Labels
+	}
+	if change.Project == "" {
+		change.Project = defaults.Project
+	}
+	return change
+}
+
+// applyBulkChange resolves names to IDs and updates a single issue (unless dryRun)
+func applyBulkChange(ctx context.Context, resolver *bulkResolver, change bulkChange, dryRun bool) bulkResult {
+	result := bulkResult{ID: change.ID, Changes: make(map[string]interface{})}
+	fail := func(err error) bulkResult {
+		result.Status = "failed"
+		result.Error = err.Error()
+		return result
+	}
+
+	if change.State == "" && change.Assignee == "" && change.Labels == "" && change.Project == "" {
+		return fail(fmt.Errorf("no changes specified"))
+	}
+
+	input := make(map[string]interface{})
+
+	// State and labels are scoped to the issue's team, and an update keeps
+	// the previous values for 'linctl undo'
+	var issue *api.Issue
+	if change.State != "" || change.Labels != "" || !dryRun {
+		var err error
+		issue, err = resolver.client.GetIssue(ctx, change.ID)
+		if err != nil {
+			return fail(fmt.Errorf("failed to get issue: %v", err))
+		}
+	}
+	if change.State != "" || change.Labels != "" {
+		if issue.Team == nil {
+			return fail(fmt.Errorf("issue has no team"))
+		}
+
+		if change.State != "" {
+			stateID, err := resolver.stateID(ctx, issue.Team.Key, change.State)
+			if err != nil {
+				return fail(err)
+			}
+			input["stateId"] = stateID
+			result.Changes["state"] = change.State
+		}
+
+		if change.Labels != "" {
+			labelIDs, err := resolver.labelIDs(ctx, issue.Team.Key, string(change.Labels))
+			if err != nil {
+				return fail(err)
+			}
+			input["labelIds"] = labelIDs
+			result.Changes["labels"] = string(change.Labels)
+		}
+	}
+
+	if change.Assignee != "" {
+		assigneeID, err := resolver.assigneeID(ctx, change.Assignee)
+		if err != nil {
+			return fail(err)
+		}
+		if assigneeID != nil {
+			input["assigneeId"] = *assigneeID
+		} else {
+			input["assigneeId"] = nil
+		}
+		result.Changes["assignee"] = change.Assignee
+	}
+
+	if change.Project != "" {
+		projectID, err := resolver.projectID(ctx, change.Project)
+		if err != nil {
+			return fail(err)
+		}
+		input["projectId"] = projectID
+		result.Changes["project"] = change.Project
+	}
+
+	if dryRun {
+		result.Status = "planned"
+		return result
+	}
+
+	updated, err := resolver.client.UpdateIssue(ctx, change.ID, input)
+	if err != nil {
+		return fail(fmt.Errorf("failed to update issue: %v", err))
+	}
+	result.Status = "updated"
+	if undo, ok := issueUndoChange(issue, updated, input); ok {
+		result.undo = &undo
+	}
+	return result
+}
+
+// describeBulkChanges formats a change set as "field=value" pairs in a stable order
+func describeBulkChanges(changes map[string]interface{}) string {
+	var parts []string
+	for _, key := range []string{"state", "assignee", "labels", "project"} {
+		if value, ok := changes[key]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%v", key, value))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// bulkCacheEntry is a memoized lookup result; once guards the lookup so
+// workers wanting the same key wait for one fetch
+type bulkCacheEntry struct {
+	once  sync.Once
+	value interface{}
+	err   error
+}
+
+// bulkResolver memoizes name -> ID lookups shared by all workers
+type bulkResolver struct {
+	client *api.Client
+	mu     sync.Mutex
+	cache  map[string]*bulkCacheEntry
+}
+
+// memo runs fn once per key. The lock only guards the map, so lookups of
+// different keys run concurrently.
+func (r *bulkResolver) memo(key string, fn func() (interface{}, error)) (interface{}, error) {
+	r.mu.Lock()
+	entry, ok := r.cache[key]
+	if !ok {
+		entry = &bulkCacheEntry{}
+		r.cache[key] = entry
+	}
+	r.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.value, entry.err = fn()
+	})
+	return entry.value, entry.err
+}
+
+func (r *bulkResolver) stateID(ctx context.Context, teamKey, stateName string) (string, error) {
+	value, err := r.memo("state:"+teamKey+":"+strings.ToLower(stateName), func() (interface{}, error) {
+		states, err := r.client.GetTeamStates(ctx, teamKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team states: %v", err)
+		}
+		state, err := matchWorkflowState(states, teamKey, stateName)
+		if err != nil {
+			return nil, err
+		}
+		return state.ID, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+func (r *bulkResolver) labelIDs(ctx context.Context, teamKey, labels string) ([]string, error) {
+	value, err := r.memo("labels:"+teamKey+":"+strings.ToLower(labels), func() (interface{}, error) {
+		ids, err := resolveLabelIDs(ctx, r.client, teamKey, labels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve labels: %v", err)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]string), nil
+}
+
+func (r *bulkResolver) assigneeID(ctx context.Context, assignee string) (*string, error) {
+	value, err := r.memo("assignee:"+assignee, func() (interface{}, error) {
+		return resolveAssigneeID(ctx, r.client, assignee)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*string), nil
+}
+
+func (r *bulkResolver) projectID(ctx context.Context, project string) (string, error) {
+	value, err := r.memo("project:"+project, func() (interface{}, error) {
+		return resolveProjectID(ctx, r.client, project)
+	})
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+func (r *bulkResolver) teamID(ctx context.Context, teamKey string) (string, error) {
+	value, err := r.memo("team:"+strings.ToUpper(teamKey), func() (interface{}, error) {
+		team, err := r.client.GetTeam(ctx, teamKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find team '%s': %v", teamKey, err)
+		}
+		return team.ID, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+func (r *bulkResolver) issueID(ctx context.Context, ref string) (string, error) {
+	value, err := r.memo("issue:"+strings.ToUpper(ref), func() (interface{}, error) {
+		issue, err := r.client.GetIssue(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("issue not found: %s", ref)
+		}
+		return issue.ID, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+func init() {
+	issueCmd.AddCommand(issueBulkUpdateCmd)
+
+	issueBulkUpdateCmd.Flags().StringP("file", "f", "", "Read issues from a file instead of stdin")
+	issueBulkUpdateCmd.Flags().String("format", "auto", "Input format: auto, ids, json, csv")
+	issueBulkUpdateCmd.Flags().StringP("state", "s", "", "State name to set (e.g., 'Todo', 'Done')")
+	issueBulkUpdateCmd.Flags().StringP("assignee", "a", "", "Assignee (email, name, @me, or @none to unassign)")
+	issueBulkUpdateCmd.Flags().String("labels", "", "Comma-separated label names (replaces existing labels)")
+	issueBulkUpdateCmd.Flags().String("project", "", "Project ID, slug, or name")
+	issueBulkUpdateCmd.Flags().Int("concurrency", 4, "Number of issues to update in parallel")
+	issueBulkUpdateCmd.Flags().Bool("dry-run", false, "Show what would change without updating anything")
+}
@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// issueFrontmatter holds the fields of an issue written as markdown with YAML
+// frontmatter, the format read by 'issue create/update --from-file' and
+// --editor and written by 'issue export --as markdown':
+//
+//	---
+//	title: Login fails on Safari
+//	team: ENG
+//	state: In Progress
+//	assignee: jane@example.com
+//	labels: [Bug, Frontend]
+//	priority: High
+//	estimate: 3
+//	---
+//
+//	The description, in markdown.
+//
+// Priority is a name (High) or a number (2).
+type issueFrontmatter struct {
+	Title    string         `yaml:"title"`
+	Team     string         `yaml:"team"`
+	State    string         `yaml:"state"`
+	Assignee string         `yaml:"assignee"`
+	Labels   templateLabels `yaml:"labels"`
+	Priority string         `yaml:"priority"`
+	Estimate string         `yaml:"estimate"`
+	Project  string         `yaml:"project"`
+	DueDate  string         `yaml:"due-date"`
+	Parent   string         `yaml:"parent"`
+}
+
+// The frontmatter keys read when creating and updating an issue (an issue's
+// team and project are not changed by update), and the flags that differ
+// from their key
+var (
+	issueDocumentFields       = []string{"title", "team", "state", "assignee", "labels", "priority", "estimate", "project", "due-date", "parent"}
+	issueCreateDocumentFields = issueDocumentFields
+	issueUpdateDocumentFields = []string{"title", "state", "assignee", "labels", "priority", "estimate", "due-date", "parent"}
+	issueDocumentFlags        = map[string]string{"parent": "parent-issue"}
+)
+
+// issueDocumentFlag is the flag a frontmatter key sets
+func issueDocumentFlag(key string) string {
+	if flag, ok := issueDocumentFlags[key]; ok {
+		return flag
+	}
+	return key
+}
+
+// value returns a frontmatter field by key, with labels comma-separated
+func (f issueFrontmatter) value(key string) string {
+	switch key {
+	case "title":
+		return f.Title
+	case "team":
+		return f.Team
+	case "state":
+		return f.State
+	case "assignee":
+		return f.Assignee
+	case "labels":
+		return strings.Join(f.Labels, ", ")
+	case "priority":
+		return f.Priority
+	case "estimate":
+		return f.Estimate
+	case "project":
+		return f.Project
+	case "due-date":
+		return f.DueDate
+	case "parent":
+		return f.Parent
+	}
+	return ""
+}
+
+// set sets a frontmatter field by key; labels are comma-separated
+func (f *issueFrontmatter) set(key, value string) {
+	switch key {
+	case "title":
+		f.Title = value
+	case "team":
+		f.Team = value
+	case "state":
+		f.State = value
+	case "assignee":
+		f.Assignee = value
+	case "labels":
+		f.Labels = nil
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				f.Labels = append(f.Labels, name)
+			}
+		}
+	case "priority":
+		f.Priority = value
+	case "estimate":
+		f.Estimate = value
+	case "project":
+		f.Project = value
+	case "due-date":
+		f.DueDate = value
+	case "parent":
+		f.Parent = value
+	}
+}
+
+// formatIssueDocument writes the given frontmatter keys and the description
+// as a markdown document. Every key is written, empty or not, so it can be
+// filled in; help is written above the keys as YAML comments.
+func formatIssueDocument(front issueFrontmatter, keys []string, description, help string) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.WriteString(help)
+	for _, key := range keys {
+		value := front.value(key)
+		switch {
+		case key == "labels" && len(front.Labels) > 0:
+			quoted := make([]string, len(front.Labels))
+			for i, label := range front.Labels {
+				quoted[i] = yamlScalar(label)
+			}
+			value = "[" + strings.Join(quoted, ", ") + "]"
+		case key == "estimate" || key == "due-date":
+			// Numbers and dates read back as the same strings unquoted
+		case value != "":
+			value = yamlScalar(value)
+		}
+		b.WriteString(strings.TrimRight(key+": "+value, " ") + "\n")
+	}
+	b.WriteString("---\n\n")
+	if description != "" {
+		b.WriteString(strings.TrimRight(description, "\n") + "\n")
+	}
+	return b.String()
+}
+
+// parseIssueDocument reads a markdown document with issue frontmatter. present
+// holds the keys the frontmatter sets, even to nothing; ok is false when the
+// document has no frontmatter, in which case it is all description.
+func parseIssueDocument(content string) (front issueFrontmatter, body string, present map[string]bool, ok bool, err error) {
+	frontText, body, ok := splitFrontmatter(content)
+	body = strings.TrimSpace(body)
+	if !ok {
+		return front, body, nil, false, nil
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(frontText)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&front); err != nil && !errors.Is(err, io.EOF) {
+		return front, "", nil, true, fmt.Errorf("invalid frontmatter: %v", err)
+	}
+
+	var keys map[string]interface{}
+	_ = yaml.Unmarshal([]byte(frontText), &keys)
+	present = make(map[string]bool, len(keys))
+	for key := range keys {
+		present[key] = true
+	}
+	return front, body, present, true, nil
+}
+
+// yamlScalar formats a value for a YAML frontmatter line, quoting it when needed
+func yamlScalar(value string) string {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return strconv.Quote(value)
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+// issueDocumentFromIssue fills issue frontmatter from an issue's current values
+func issueDocumentFromIssue(issue *api.Issue) (issueFrontmatter, string) {
+	var front issueFrontmatter
+	if issue == nil {
+		return front, ""
+	}
+	front.Title = issue.Title
+	if issue.Team != nil {
+		front.Team = issue.Team.Key
+	}
+	if issue.State != nil {
+		front.State = issue.State.Name
+	}
+	if issue.Assignee != nil {
+		front.Assignee = firstNonEmpty(issue.Assignee.Email, issue.Assignee.Name)
+	}
+	if issue.Labels != nil {
+		for _, label := range issue.Labels.Nodes {
+			front.Labels = append(front.Labels, label.Name)
+		}
+	}
+	front.Priority = priorityToString(issue.Priority)
+	if issue.Estimate != nil {
+		front.Estimate = strconv.FormatFloat(*issue.Estimate, 'f', -1, 64)
+	}
+	if issue.Project != nil {
+		front.Project = issue.Project.Name
+	}
+	if issue.DueDate != nil {
+		front.DueDate = *issue.DueDate
+	}
+	if issue.Parent != nil {
+		front.Parent = issue.Parent.Identifier
+	}
+	return front, issue.Description
+}
+
+// applyIssueDocument sets the command's flags from frontmatter keys, except
+// those skip rejects. When creating, empty values are left out; when
+// updating, they unset the field.
+func applyIssueDocument(cmd *cobra.Command, front issueFrontmatter, keys []string, creating bool, skip func(key string) bool) error {
+	set := func(flag, value string) error {
+		if err := cmd.Flags().Set(flag, value); err != nil {
+			return fmt.Errorf("invalid %s '%s'", flag, value)
+		}
+		return nil
+	}
+
+	for _, key := range keys {
+		if skip != nil && skip(key) {
+			continue
+		}
+		value := strings.TrimSpace(front.value(key))
+		if creating && value == "" {
+			continue
+		}
+
+		switch key {
+		case "priority":
+			if value == "" {
+				value = "0"
+			}
+			priority, err := parsePriority(value)
+			if err != nil {
+				return err
+			}
+			value = strconv.Itoa(priority)
+		case "estimate":
+			if value == "" {
+				// 0 clears the estimate on update
+				value = "0"
+			}
+		case "assignee":
+			if value == "" {
+				value = "@none"
+			}
+			if creating {
+				if err := set("assign-me", "false"); err != nil {
+					return err
+				}
+			}
+		case "parent":
+			if value == "" {
+				value = "unassigned"
+			}
+		}
+		if err := set(issueDocumentFlag(key), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyIssueFile sets the flags of 'issue create/update --from-file' from the
+// file's frontmatter, if it has any. Flags given on the command line win, and
+// keys missing from the frontmatter are left alone.
+func applyIssueFile(cmd *cobra.Command, creating bool) error {
+	if !cmd.Flags().Changed("from-file") {
+		return nil
+	}
+	path, _ := cmd.Flags().GetString("from-file")
+	content, err := readMarkdownInput(path)
+	if err != nil {
+		return err
+	}
+	front, _, present, ok, err := parseIssueDocument(content)
+	if err != nil {
+		if path == "-" {
+			path = "stdin"
+		}
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	if !ok {
+		return nil
+	}
+
+	keys := issueCreateDocumentFields
+	if !creating {
+		keys = issueUpdateDocumentFields
+	}
+	return applyIssueDocument(cmd, front, keys, creating, func(key string) bool {
+		if key == "assignee" && cmd.Flags().Changed("assign-me") {
+			return true
+		}
+		return !present[key] || cmd.Flags().Changed(issueDocumentFlag(key))
+	})
+}
+
+// applyIssueDocumentFlags fills the flags of 'issue create' (issueID "") and
+// 'issue update' from --editor or a --from-file document, exiting on errors
+func applyIssueDocumentFlags(cmd *cobra.Command, issueID string, plaintext, jsonOut bool) {
+	runIssueEditor(cmd, issueID, plaintext, jsonOut)
+	if err := applyIssueFile(cmd, issueID == ""); err != nil {
+		exitWithError(err, err.Error(), plaintext, jsonOut)
+	}
+
+	// Values from the document get the same treatment as flags on the command line
+	normalizeLinearURLs(cmd, nil)
+	if err := normalizeTeamFlag(cmd); err != nil {
+		exitWithError(err, err.Error(), plaintext, jsonOut)
+	}
+
+	// Pin relative due dates ("friday", "+3d") to today, so a queued change
+	// means the same date when it is flushed
+	if due, _ := cmd.Flags().GetString("due-date"); due != "" && !strings.EqualFold(due, "none") {
+		date, err := utils.ParseDueDate(due, time.Now())
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Invalid due date: %v", err), plaintext, jsonOut)
+		}
+		_ = cmd.Flags().Set("due-date", date)
+	}
+}
+
+// issueFileDescription is the description in a --from-file document: the
+// body after the frontmatter, or the whole file when it has none
+func issueFileDescription(content string) string {
+	if _, body, ok := splitFrontmatter(content); ok {
+		return strings.TrimSpace(body)
+	}
+	return content
+}
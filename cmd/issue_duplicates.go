@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	// duplicateThreshold is the similarity from which issue create
+	// --check-duplicates reports a match
+	duplicateThreshold = 0.5
+	// duplicateMatches is how many matches issue create shows at most
+	duplicateMatches = 5
+)
+
+// duplicateStopWords carry no meaning when comparing titles
+var duplicateStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "in": true, "is": true, "it": true, "of": true,
+	"on": true, "or": true, "the": true, "to": true, "when": true, "with": true,
+}
+
+// duplicateMatch is an existing issue similar to a new one
+type duplicateMatch struct {
+	Issue api.Issue `json:"issue"`
+	Score float64   `json:"score"`
+}
+
+// duplicatePair is two existing issues that are likely duplicates
+type duplicatePair struct {
+	Score     float64   `json:"score"`
+	Issue     api.Issue `json:"issue"`
+	Duplicate api.Issue `json:"duplicate"`
+}
+
+// issueText is an issue prepared for comparison
+type issueText struct {
+	titleGrams map[string]bool
+	words      map[string]bool
+}
+
+var issueDedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Find likely duplicate issues in a team",
+	Long: `Compare the titles of a team's open issues and list pairs that are likely
+duplicates, most similar first. Each pair lists the older issue first, so the
+second is usually the one to mark with 'issue relate --duplicate-of'.
+
+Similarity runs from 0 to 1 and compares letter trigrams of the titles'
+words, leaving out words like "the", so small rewordings and typos still
+match. With --description, shared words in the descriptions count too.
+
+Examples:
+  linctl issue dedupe --team ENG
+  linctl issue dedupe --team ENG --threshold 0.8 --include-completed
+  linctl issue relate ENG-142 --duplicate-of ENG-97`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		teamFlag, _ := cmd.Flags().GetString("team")
+		teamKey, err := resolveTeamKey(firstNonEmpty(teamFlag, viper.GetString("default-team")))
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if teamKey == "" {
+			output.Error("A team is required (--team, or default-team in config)", plaintext, jsonOut)
+			exit(1)
+		}
+		threshold, _ := cmd.Flags().GetFloat64("threshold")
+		limit, _ := cmd.Flags().GetInt("limit")
+		withDescription, _ := cmd.Flags().GetBool("description")
+
+		filter := map[string]interface{}{
+			"team": map[string]interface{}{"key": map[string]interface{}{"eq": teamKey}},
+		}
+		if includeCompleted, _ := cmd.Flags().GetBool("include-completed"); !includeCompleted {
+			filter["state"] = map[string]interface{}{
+				"type": map[string]interface{}{"nin": []string{"completed", "canceled"}},
+			}
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		selection, err := api.IssueSelection([]string{"identifier", "title", "description", "state", "created", "url"})
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		issues, _, err := api.Paginate(context.Background(), api.PaginateOptions{
+			PageSize:   api.MaxPageSize,
+			MaxResults: limit,
+		}, func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+			page, err := client.GetIssuesWithFields(ctx, selection, filter, first, after, "createdAt", false)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to fetch issues: %v", err), plaintext, jsonOut)
+		}
+
+		pairs := findDuplicatePairs(issues, threshold, withDescription)
+
+		if jsonOut {
+			output.JSON(pairs)
+			return
+		}
+		if len(pairs) == 0 {
+			output.Info(fmt.Sprintf("No likely duplicates among %d issues in %s", len(issues), teamKey), plaintext, jsonOut)
+			return
+		}
+
+		if plaintext {
+			for _, pair := range pairs {
+				fmt.Printf("%.2f\t%s\t%s\t%s\t%s\n", pair.Score,
+					pair.Issue.Identifier, pair.Issue.Title, pair.Duplicate.Identifier, pair.Duplicate.Title)
+			}
+			return
+		}
+
+		rows := make([][]string, len(pairs))
+		for i, pair := range pairs {
+			rows[i] = []string{
+				fmt.Sprintf("%.0f%%", pair.Score*100),
+				color.New(color.FgCyan, color.Bold).Sprint(pair.Issue.Identifier),
+				pair.Issue.Title,
+				color.New(color.FgCyan, color.Bold).Sprint(pair.Duplicate.Identifier),
+				pair.Duplicate.Title,
+			}
+		}
+		output.Table(output.TableData{
+			Headers: []string{"Similarity", "Issue", "Title", "Likely Duplicate", "Title"},
+			Rows:    rows,
+		}, false, false)
+		fmt.Printf("\n%s %d likely duplicate pairs among %d issues in %s\n",
+			color.New(color.FgGreen).Sprint("✓"), len(pairs), len(issues), teamKey)
+	},
+}
+
+// findDuplicates searches a team for issues similar to a new issue's title
+// (and description, when given), most similar first
+func findDuplicates(ctx context.Context, client *api.Client, teamKey, title, description string) ([]duplicateMatch, error) {
+	words := significantWords(title)
+	if len(words) == 0 {
+		return nil, nil
+	}
+	filter := map[string]interface{}{
+		"team": map[string]interface{}{"key": map[string]interface{}{"eq": teamKey}},
+	}
+	results, err := client.IssueSearch(ctx, strings.Join(words, " "), filter, 25, "", "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := newIssueText(title, description)
+	var matches []duplicateMatch
+	for _, issue := range results.Nodes {
+		other := newIssueText(issue.Title, issue.Description)
+		score := candidate.similarity(other, description != "")
+		if score >= duplicateThreshold {
+			matches = append(matches, duplicateMatch{Issue: issue, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if len(matches) > duplicateMatches {
+		matches = matches[:duplicateMatches]
+	}
+	return matches, nil
+}
+
+// findDuplicatePairs compares every pair of issues and returns those at
+// least threshold similar, most similar first, each with the older issue first
+func findDuplicatePairs(issues []api.Issue, threshold float64, withDescription bool) []duplicatePair {
+	issues = append([]api.Issue{}, issues...)
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].CreatedAt.Before(issues[j].CreatedAt) })
+
+	texts := make([]issueText, len(issues))
+	for i, issue := range issues {
+		description := ""
+		if withDescription {
+			description = issue.Description
+		}
+		texts[i] = newIssueText(issue.Title, description)
+	}
+
+	pairs := []duplicatePair{}
+	for i := range issues {
+		for j := i + 1; j < len(issues); j++ {
+			score := texts[i].similarity(texts[j], withDescription)
+			if score >= threshold {
+				pairs = append(pairs, duplicatePair{Score: score, Issue: issues[i], Duplicate: issues[j]})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Score > pairs[j].Score })
+	return pairs
+}
+
+// promptDuplicates shows the matches for a new issue and asks what to do.
+// It returns whether to create the issue, and the match to relate it to with
+// relation ("duplicate" or "related"), if any.
+func promptDuplicates(title string, matches []duplicateMatch) (bool, *api.Issue, string) {
+	fmt.Printf("%s Possible duplicates of %q:\n", color.New(color.FgYellow).Sprint("⚠"), title)
+	for i, match := range matches {
+		state := ""
+		if match.Issue.State != nil {
+			state = match.Issue.State.Name
+		}
+		fmt.Printf("  %d. %s  %s  %s\n", i+1,
+			color.New(color.FgCyan, color.Bold).Sprint(match.Issue.Identifier),
+			match.Issue.Title,
+			color.New(color.FgWhite, color.Faint).Sprintf("(%s, %.0f%% similar)", state, match.Score*100))
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		answer, err := promptString(reader, "[c]reate anyway, mark as [d]uplicate of N, [r]elate to N, or [q]uit", "q")
+		if err != nil {
+			return false, nil, ""
+		}
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		action, number := answer, ""
+		if answer != "" {
+			action, number = answer[:1], strings.TrimSpace(answer[1:])
+		}
+
+		switch action {
+		case "c":
+			return true, nil, ""
+		case "q", "n":
+			return false, nil, ""
+		case "d", "r":
+			index := 1
+			if number != "" {
+				index, err = strconv.Atoi(number)
+			} else if len(matches) > 1 {
+				err = fmt.Errorf("which match")
+			}
+			if err != nil || index < 1 || index > len(matches) {
+				fmt.Printf("Give the match number, 1 to %d (e.g. %s 1)\n", len(matches), action)
+				continue
+			}
+			relation := "duplicate"
+			if action == "r" {
+				relation = "related"
+			}
+			return true, &matches[index-1].Issue, relation
+		}
+	}
+}
+
+// newIssueText prepares a title and an optional description for comparison
+func newIssueText(title, description string) issueText {
+	return issueText{
+		titleGrams: trigrams(strings.Join(significantWords(title), " ")),
+		words:      wordSet(significantWords(description)),
+	}
+}
+
+// similarity scores two issues from 0 to 1 by their titles' trigrams and, with
+// withDescription, their descriptions' words
+func (t issueText) similarity(other issueText, withDescription bool) float64 {
+	score := dice(t.titleGrams, other.titleGrams)
+	if withDescription && len(t.words) > 0 && len(other.words) > 0 {
+		score = 0.7*score + 0.3*dice(t.words, other.words)
+	}
+	return score
+}
+
+// significantWords lowercases text and splits it into words, without stop words
+func significantWords(text string) []string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !duplicateStopWords[word] {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// trigrams are the three-letter windows of text, padded so short words count
+func trigrams(text string) map[string]bool {
+	grams := make(map[string]bool)
+	runes := []rune("  " + text + " ")
+	for i := 0; i+3 <= len(runes); i++ {
+		grams[string(runes[i:i+3])] = true
+	}
+	return grams
+}
+
+func wordSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// dice is the Sørensen–Dice coefficient of two sets
+func dice(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for item := range a {
+		if b[item] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
+
+func init() {
+	issueCmd.AddCommand(issueDedupeCmd)
+
+	issueDedupeCmd.Flags().StringP("team", "t", "", "Team key (default: default-team from config)")
+	issueDedupeCmd.Flags().Float64("threshold", 0.6, "Minimum similarity, from 0 to 1, for a pair to be listed")
+	issueDedupeCmd.Flags().IntP("limit", "l", 1000, "Maximum number of issues to compare (0 for all)")
+	issueDedupeCmd.Flags().BoolP("include-completed", "c", false, "Also compare completed and canceled issues")
+	issueDedupeCmd.Flags().Bool("description", false, "Also compare descriptions")
+}
@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var issueExportCmd = &cobra.Command{
+	Use:   "export <issue-id>",
+	Short: "Export an issue to markdown",
+	Long: `Export an issue's title and description (and optionally comments) to a markdown file.
+
+With --download-assets, images hosted on linear.app are downloaded into an
+assets/ folder next to the exported file and their links are rewritten to
+relative paths, so the markdown renders offline.
+
+--as markdown writes the fields (title, team, state, assignee, labels, priority,
+estimate, project, due-date, parent) as YAML frontmatter above the description,
+the format 'issue create -F' and 'issue update -F' read back. Edit the file, or
+review it in git, and apply it with 'linctl issue update LIN-123 -F LIN-123.md'.
+
+Examples:
+  linctl issue export LIN-123                       # Write LIN-123.md
+  linctl issue export LIN-123 --as markdown         # With frontmatter fields
+  linctl issue export LIN-123 -o notes/bug.md --download-assets
+  linctl issue export LIN-123 -o - --include-comments  # Print to stdout`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		includeComments, _ := cmd.Flags().GetBool("include-comments")
+		format, _ := cmd.Flags().GetString("as")
+		switch format {
+		case "", "heading":
+		case "markdown":
+			if includeComments {
+				// They would be read back as part of the description
+				output.Error("--include-comments cannot be used with --as markdown", plaintext, jsonOut)
+				exit(1)
+			}
+		default:
+			output.Error(fmt.Sprintf("Unknown format '%s' (valid: heading, markdown)", format), plaintext, jsonOut)
+			exit(1)
+		}
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+		ctx := context.Background()
+
+		issue, err := client.GetIssue(ctx, args[0])
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
+		}
+
+		var comments []api.Comment
+		if includeComments {
+			comments, _, err = api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize}, func(ctx context.Context, first int, after string) ([]api.Comment, api.PageInfo, error) {
+				page, err := client.GetIssueComments(ctx, issue.ID, first, after, "createdAt")
+				if err != nil {
+					return nil, api.PageInfo{}, err
+				}
+				return page.Nodes, page.PageInfo, nil
+			})
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to get comments: %v", err), plaintext, jsonOut)
+			}
+		}
+
+		markdown := renderIssueMarkdown(issue, comments)
+		if format == "markdown" {
+			front, description := issueDocumentFromIssue(issue)
+			markdown = formatIssueDocument(front, issueDocumentFields, description, "")
+		}
+
+		outputPath, _ := cmd.Flags().GetString("output")
+		if outputPath == "" {
+			outputPath = issue.Identifier + ".md"
+		}
+		toStdout := outputPath == "-"
+
+		// Mirror linear.app-hosted images next to the exported file
+		downloadAssets, _ := cmd.Flags().GetBool("download-assets")
+		var assetErrors []string
+		downloadedAssets := 0
+		if downloadAssets {
+			baseDir := "."
+			if !toStdout {
+				baseDir = filepath.Dir(outputPath)
+			}
+			markdown, downloadedAssets, assetErrors = mirrorLinearImages(ctx, client.TransferClient(), markdown, baseDir)
+		}
+
+		if toStdout {
+			fmt.Print(markdown)
+			for _, e := range assetErrors {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", e)
+			}
+			return
+		}
+
+		if dir := filepath.Dir(outputPath); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to create output directory: %v", err), plaintext, jsonOut)
+			}
+		}
+		if err := os.WriteFile(outputPath, []byte(markdown), 0644); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to write %s: %v", outputPath, err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			summary := map[string]interface{}{
+				"issue":  issue.Identifier,
+				"output": outputPath,
+			}
+			if downloadAssets {
+				summary["assets"] = downloadedAssets
+			}
+			if len(assetErrors) > 0 {
+				summary["errors"] = assetErrors
+			}
+			output.JSON(summary)
+		} else if plaintext {
+			fmt.Printf("Exported %s to %s\n", issue.Identifier, outputPath)
+			if downloadAssets {
+				fmt.Printf("Downloaded %d asset(s)\n", downloadedAssets)
+			}
+			for _, e := range assetErrors {
+				fmt.Printf("Error: %s\n", e)
+			}
+		} else {
+			fmt.Printf("%s Exported %s to %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
+				outputPath)
+			if downloadAssets {
+				fmt.Printf("  Downloaded %d asset(s)\n", downloadedAssets)
+			}
+			for _, e := range assetErrors {
+				fmt.Printf("  %s %s\n", color.New(color.FgRed).Sprint("✗"), e)
+			}
+		}
+	},
+}
+
+// mirrorLinearImages downloads the linear.app-hosted images in markdown into
+// an assets/ folder under baseDir and rewrites their links to relative paths.
+// It returns the rewritten markdown, the number of images downloaded, and the
+// errors of those that failed, whose links are left alone.
+func mirrorLinearImages(ctx context.Context, httpClient *http.Client, markdown, baseDir string) (string, int, []string) {
+	var linearImages []files.ImageInfo
+	seen := make(map[string]bool)
+	for _, img := range files.ExtractImagesFromMarkdown(markdown) {
+		if img.IsLinearURL && !seen[img.URL] {
+			seen[img.URL] = true
+			linearImages = append(linearImages, img)
+		}
+	}
+	if len(linearImages) == 0 {
+		return markdown, 0, nil
+	}
+
+	results, _ := files.DownloadImages(ctx, linearImages, filepath.Join(baseDir, "assets"), files.DownloadOptions{
+		Client: httpClient,
+	})
+
+	var errs []string
+	downloaded := 0
+	replacements := make(map[string]string)
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err.Error())
+			continue
+		}
+		rel, err := filepath.Rel(baseDir, result.Path)
+		if err != nil {
+			rel = result.Path
+		}
+		replacements[result.Image.URL] = filepath.ToSlash(rel)
+		downloaded++
+	}
+	return files.RewriteImageURLs(markdown, replacements), downloaded, errs
+}
+
+// renderIssueMarkdown renders an issue as a markdown document
+func renderIssueMarkdown(issue *api.Issue, comments []api.Comment) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s: %s\n\n", issue.Identifier, issue.Title)
+	if issue.Description != "" {
+		b.WriteString(strings.TrimRight(issue.Description, "\n"))
+		b.WriteString("\n")
+	}
+
+	if len(comments) > 0 {
+		b.WriteString("\n## Comments\n")
+		for _, comment := range comments {
+			author := "Unknown"
+			if comment.User != nil {
+				author = comment.User.Name
+			}
+			fmt.Fprintf(&b, "\n### %s - %s\n\n", author, comment.CreatedAt.Format("2006-01-02 15:04"))
+			b.WriteString(strings.TrimRight(comment.Body, "\n"))
+			b.WriteString("\n")
+			if summary := reactionSummary(comment.Reactions); summary != "" {
+				fmt.Fprintf(&b, "\n%s\n", summary)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// stdinMarkdown keeps what readMarkdownInput read from stdin, which can only be read once
+var stdinMarkdown *string
+
+// readMarkdownInput reads markdown from a file path, or from stdin when path is "-"
+func readMarkdownInput(path string) (string, error) {
+	if path == "-" && stdinMarkdown != nil {
+		return *stdinMarkdown, nil
+	}
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	content := string(data)
+	if path == "-" {
+		stdinMarkdown = &content
+	}
+	return content, nil
+}
+
+// stripExportHeading removes the "# ID: Title" heading written by 'issue export'
+// so an exported file can be fed back in as the issue description
+func stripExportHeading(markdown, issueID string) string {
+	firstLine, rest, _ := strings.Cut(markdown, "\n")
+	prefix := "# " + strings.ToUpper(issueID) + ":"
+	if !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(firstLine)), prefix) {
+		return markdown
+	}
+	return strings.TrimLeft(rest, "\n")
+}
+
+// uploadLocalImages uploads every image referenced by a local path (relative to baseDir)
+// and rewrites the markdown to use the returned Linear asset URLs
+func uploadLocalImages(ctx context.Context, client *api.Client, markdown, baseDir string, plaintext, jsonOut bool) (string, error) {
+	replacements := make(map[string]string)
+	for _, img := range files.ExtractImagesFromMarkdown(markdown) {
+		if !files.IsLocalImagePath(img.URL) {
+			continue
+		}
+		if _, done := replacements[img.URL]; done {
+			continue
+		}
+
+		path := strings.TrimPrefix(img.URL, "file://")
+		if unescaped, err := url.PathUnescape(path); err == nil {
+			path = unescaped
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+
+		assetURL, err := client.UploadFileToLinear(ctx, path)
+		if err != nil {
+			return "", fmt.Errorf("failed to upload image %s: %w", img.URL, err)
+		}
+		replacements[img.URL] = assetURL
+
+		if !jsonOut && !plaintext {
+			fmt.Printf("  ✓ Uploaded: %s\n", img.URL)
+		}
+	}
+
+	return files.RewriteImageURLs(markdown, replacements), nil
+}
+
+func init() {
+	issueCmd.AddCommand(issueExportCmd)
+
+	issueExportCmd.Flags().StringP("output", "o", "", "Output markdown file (default: <issue-id>.md, '-' for stdout)")
+	issueExportCmd.Flags().Bool("download-assets", false, "Download linear.app images into assets/ and rewrite links to local paths")
+	issueExportCmd.Flags().BoolP("include-comments", "c", false, "Include comments in the export")
+	issueExportCmd.Flags().String("as", "heading", "Format: 'heading' (a '# ID: Title' heading) or 'markdown' (frontmatter fields that 'issue create/update -F' read back)")
+}
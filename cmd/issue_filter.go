@@ -0,0 +1,831 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/utils"
+)
+
+// issueFilterHelp documents the --filter language; it is shown in the help
+// of every command that takes --filter
+const issueFilterHelp = `Filter expressions (--filter):
+  Terms are field:value, combined with AND (or just a space), OR, NOT (or a
+  leading -), and parentheses. Quote values with spaces; separate several
+  values with commas to match any of them.
+
+    state:started AND (label:bug OR priority:>=high) AND updated:>-7d
+    assignee:me -label:wontfix,duplicate
+    team:ENG project:"Mobile App" due:<+14d
+    title:"login" created:2024-06-01
+
+  Fields:
+    state, status          state name or type (triage, backlog, unstarted, started, completed, canceled)
+    assignee, creator      me, none, any, or an email, name, or display name
+    team                   team key
+    label                  label name
+    project                project name, none, or any
+    cycle                  cycle number, current, next, previous, none, or any
+    parent                 an issue ID such as ENG-123, none, or any
+    id                     an issue ID such as ENG-123
+    priority               urgent, high, normal, low, none, or 0-4; >=high means urgent or high
+    estimate, number       numbers, or none for estimate
+    created, updated, started, completed, canceled, due
+                           YYYY-MM-DD, today, yesterday, friday, -7d (ago), +2w (from now),
+                           or none/any; 7d means -7d, except for due, where it means +7d.
+                           Without an operator, a date is that day and an offset is the
+                           time between it and now (updated:-7d, due:+3d)
+    title, description     text the field contains (a bare word searches titles)
+
+  Operators: > >= < <= for priorities, numbers, and dates; ! (or !=) negates.`
+
+// filterNode is a parsed filter expression: an and/or of its children, a
+// negated child, or a single term
+type filterNode struct {
+	kind     string // "and", "or", "not", or "term"
+	children []*filterNode
+	term     filterTerm
+}
+
+// filterTerm is one field:value comparison
+type filterTerm struct {
+	field  string
+	op     string // "", ">", ">=", "<", "<=", or "!"
+	values []string
+	raw    string
+}
+
+// filterToken is one token of a filter expression
+type filterToken struct {
+	text   string // the token with quotes removed
+	raw    string // the token as written
+	quoted bool   // the whole token was a quoted string
+	pos    int
+}
+
+// parseIssueFilterExpr parses a --filter expression
+func parseIssueFilterExpr(expr string) (*filterNode, error) {
+	tokens, err := lexFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("filter is empty")
+	}
+	p := &filterParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", p.tokens[p.pos].raw, p.tokens[p.pos].pos+1)
+	}
+	return node, nil
+}
+
+// lexFilter splits an expression into words, quoted strings, and parentheses.
+// Quotes may also appear inside a word, as in label:"needs review".
+func lexFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n':
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, filterToken{text: string(r), raw: string(r), pos: i})
+			i++
+		default:
+			start := i
+			var text strings.Builder
+			quotedOnly := r == '"' || r == '\''
+			for i < len(runes) && runes[i] != ' ' && runes[i] != '\t' && runes[i] != '\n' && runes[i] != '(' && runes[i] != ')' {
+				if quote := runes[i]; quote == '"' || quote == '\'' {
+					end := i + 1
+					for end < len(runes) && runes[end] != quote {
+						end++
+					}
+					if end == len(runes) {
+						return nil, fmt.Errorf("unterminated quote at position %d", i+1)
+					}
+					text.WriteString(string(runes[i+1 : end]))
+					i = end + 1
+					if i < len(runes) && runes[i] != ' ' && runes[i] != ')' {
+						quotedOnly = false
+					}
+					continue
+				}
+				text.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, filterToken{text: text.String(), raw: string(runes[start:i]), quoted: quotedOnly, pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+// filterParser is a recursive descent parser over filter tokens:
+//
+//	or   = and { "OR" and }
+//	and  = not { ["AND"] not }
+//	not  = ("NOT" | "-") not | "(" or ")" | term
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// keyword reports whether the next token is the unquoted keyword word
+func (p *filterParser) keyword(word string) bool {
+	token, ok := p.peek()
+	return ok && !token.quoted && strings.EqualFold(token.raw, word)
+}
+
+func (p *filterParser) parseOr() (*filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	node := &filterNode{kind: "or", children: []*filterNode{left}}
+	for p.keyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, right)
+	}
+	if len(node.children) == 1 {
+		return left, nil
+	}
+	return node, nil
+}
+
+func (p *filterParser) parseAnd() (*filterNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	node := &filterNode{kind: "and", children: []*filterNode{left}}
+	for {
+		if p.keyword("and") {
+			p.pos++
+		} else if token, ok := p.peek(); !ok || token.raw == ")" || p.keyword("or") {
+			break
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, right)
+	}
+	if len(node.children) == 1 {
+		return left, nil
+	}
+	return node, nil
+}
+
+func (p *filterParser) parseNot() (*filterNode, error) {
+	token, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("filter ends too early")
+	}
+
+	switch {
+	case p.keyword("not"):
+		p.pos++
+		child, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNode{kind: "not", children: []*filterNode{child}}, nil
+	case token.raw == "(":
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if next, ok := p.peek(); !ok || next.raw != ")" {
+			return nil, fmt.Errorf("missing ')' for '(' at position %d", token.pos+1)
+		}
+		p.pos++
+		return node, nil
+	case token.raw == ")":
+		return nil, fmt.Errorf("unexpected ')' at position %d", token.pos+1)
+	case p.keyword("and") || p.keyword("or"):
+		return nil, fmt.Errorf("'%s' at position %d needs a term before it", token.raw, token.pos+1)
+	}
+
+	p.pos++
+	negated := false
+	raw := token.raw
+	if strings.HasPrefix(raw, "-") && len(raw) > 1 && !token.quoted {
+		negated = true
+		raw = raw[1:]
+	}
+	term, err := parseFilterTerm(raw, token.quoted)
+	if err != nil {
+		return nil, fmt.Errorf("%v (at position %d)", err, token.pos+1)
+	}
+	node := &filterNode{kind: "term", term: term}
+	if negated {
+		return &filterNode{kind: "not", children: []*filterNode{node}}, nil
+	}
+	return node, nil
+}
+
+// filterOperators are the comparison prefixes of a value, longest first
+var filterOperators = []string{">=", "<=", "!=", ">", "<", "!", "="}
+
+// parseFilterTerm splits field:op value,value. A word without a field (or a
+// quoted phrase) searches titles.
+func parseFilterTerm(raw string, quoted bool) (filterTerm, error) {
+	field, rest, found := cutUnquoted(raw, ':')
+	if quoted || !found {
+		values := splitUnquoted(raw, 0)
+		return filterTerm{field: "title", values: values, raw: raw}, nil
+	}
+
+	term := filterTerm{field: strings.ToLower(field), raw: raw}
+	for _, op := range filterOperators {
+		if strings.HasPrefix(rest, op) {
+			term.op = op
+			rest = rest[len(op):]
+			break
+		}
+	}
+	switch term.op {
+	case "=":
+		term.op = ""
+	case "!=":
+		term.op = "!"
+	}
+
+	term.values = splitUnquoted(rest, ',')
+	if len(term.values) == 0 {
+		return term, fmt.Errorf("'%s' has no value", raw)
+	}
+	if len(term.values) > 1 && term.op != "" && term.op != "!" {
+		return term, fmt.Errorf("'%s': a list of values can't be compared with %s", raw, term.op)
+	}
+	return term, nil
+}
+
+// cutUnquoted is strings.Cut, ignoring separators inside quotes
+func cutUnquoted(s string, sep rune) (string, string, bool) {
+	var quote rune
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == sep:
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+// splitUnquoted splits s at sep outside quotes (0 for no splitting) and
+// removes the quotes, dropping empty values
+func splitUnquoted(s string, sep rune) []string {
+	var values []string
+	var current strings.Builder
+	var quote rune
+	flush := func() {
+		if value := strings.TrimSpace(current.String()); value != "" {
+			values = append(values, value)
+		}
+		current.Reset()
+	}
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case sep != 0 && r == sep:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return values
+}
+
+// filterFields lists the fields of every term in a filter expression
+func filterFields(node *filterNode) map[string]bool {
+	fields := make(map[string]bool)
+	var walk func(*filterNode)
+	walk = func(n *filterNode) {
+		if n.kind == "term" {
+			fields[canonicalFilterField(n.term.field)] = true
+		}
+		for _, child := range n.children {
+			walk(child)
+		}
+	}
+	walk(node)
+	return fields
+}
+
+// canonicalFilterField resolves field aliases
+func canonicalFilterField(field string) string {
+	switch field {
+	case "status":
+		return "state"
+	case "labels":
+		return "label"
+	case "identifier", "issue":
+		return "id"
+	case "due-date", "duedate":
+		return "due"
+	}
+	return field
+}
+
+// compileIssueFilter turns a parsed expression into a Linear IssueFilter
+func compileIssueFilter(ctx context.Context, node *filterNode) (map[string]interface{}, error) {
+	switch node.kind {
+	case "and", "or":
+		parts := make([]interface{}, 0, len(node.children))
+		for _, child := range node.children {
+			compiled, err := compileIssueFilter(ctx, child)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, compiled)
+		}
+		return map[string]interface{}{node.kind: parts}, nil
+	case "not":
+		compiled, err := compileIssueFilter(ctx, node.children[0])
+		if err != nil {
+			return nil, err
+		}
+		return negateIssueFilter(compiled), nil
+	}
+
+	term := node.term
+	negate := term.op == "!"
+	if negate {
+		term.op = ""
+	}
+
+	// Several values match any of them
+	var alternatives []interface{}
+	for _, value := range term.values {
+		compiled, err := compileFilterTerm(ctx, term, value)
+		if err != nil {
+			return nil, fmt.Errorf("'%s': %v", term.raw, err)
+		}
+		alternatives = append(alternatives, compiled)
+	}
+	var compiled map[string]interface{}
+	if len(alternatives) == 1 {
+		compiled = alternatives[0].(map[string]interface{})
+	} else {
+		compiled = map[string]interface{}{"or": alternatives}
+	}
+	if negate {
+		return negateIssueFilter(compiled), nil
+	}
+	return compiled, nil
+}
+
+// filterDateFields maps date fields to the IssueFilter fields they compare
+var filterDateFields = map[string]string{
+	"created":   "createdAt",
+	"updated":   "updatedAt",
+	"started":   "startedAt",
+	"completed": "completedAt",
+	"canceled":  "canceledAt",
+	"due":       "dueDate",
+}
+
+// compileFilterTerm compiles one field:value comparison
+func compileFilterTerm(ctx context.Context, term filterTerm, value string) (map[string]interface{}, error) {
+	field := canonicalFilterField(term.field)
+	lower := strings.ToLower(value)
+	equalityOnly := func() error {
+		if term.op != "" {
+			return fmt.Errorf("%s can't be compared with %s", field, term.op)
+		}
+		return nil
+	}
+
+	if name, ok := filterDateFields[field]; ok {
+		return compileDateTerm(name, term.op, value)
+	}
+
+	switch field {
+	case "state":
+		if err := equalityOnly(); err != nil {
+			return nil, err
+		}
+		if isStateType(value) {
+			return map[string]interface{}{"state": map[string]interface{}{
+				"or": []interface{}{
+					map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": value}},
+					map[string]interface{}{"type": map[string]interface{}{"eq": lower}},
+				},
+			}}, nil
+		}
+		return map[string]interface{}{"state": map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": value}}}, nil
+
+	case "assignee", "creator":
+		if err := equalityOnly(); err != nil {
+			return nil, err
+		}
+		users, err := userFilter(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{field: users}, nil
+
+	case "team":
+		if err := equalityOnly(); err != nil {
+			return nil, err
+		}
+		key, err := resolveTeamKey(value)
+		if err != nil {
+			return nil, err
+		}
+		if key == "" {
+			return map[string]interface{}{}, nil
+		}
+		return map[string]interface{}{"team": map[string]interface{}{"key": map[string]interface{}{"eq": key}}}, nil
+
+	case "label":
+		if err := equalityOnly(); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"labels": map[string]interface{}{
+			"some": map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": value}},
+		}}, nil
+
+	case "project":
+		if err := equalityOnly(); err != nil {
+			return nil, err
+		}
+		if presence, ok := filterPresence(lower); ok {
+			return map[string]interface{}{"project": presence}, nil
+		}
+		return map[string]interface{}{"project": map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": value}}}, nil
+
+	case "cycle":
+		if presence, ok := filterPresence(lower); ok && term.op == "" {
+			return map[string]interface{}{"cycle": presence}, nil
+		}
+		switch lower {
+		case "current", "active":
+			return map[string]interface{}{"cycle": map[string]interface{}{"isActive": map[string]interface{}{"eq": true}}}, equalityOnly()
+		case "next":
+			return map[string]interface{}{"cycle": map[string]interface{}{"isNext": map[string]interface{}{"eq": true}}}, equalityOnly()
+		case "previous", "last":
+			return map[string]interface{}{"cycle": map[string]interface{}{"isPrevious": map[string]interface{}{"eq": true}}}, equalityOnly()
+		}
+		number, err := compileNumberTerm(term.op, value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"cycle": map[string]interface{}{"number": number}}, nil
+
+	case "parent":
+		if err := equalityOnly(); err != nil {
+			return nil, err
+		}
+		if presence, ok := filterPresence(lower); ok {
+			return map[string]interface{}{"parent": presence}, nil
+		}
+		issue, err := issueIdentifierFilter(value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"parent": issue}, nil
+
+	case "id":
+		if err := equalityOnly(); err != nil {
+			return nil, err
+		}
+		return issueIdentifierFilter(value)
+
+	case "priority":
+		return compilePriorityTerm(term.op, value)
+
+	case "estimate", "number":
+		if presence, ok := filterPresence(lower); ok && field == "estimate" && term.op == "" {
+			return map[string]interface{}{"estimate": presence}, nil
+		}
+		number, err := compileNumberTerm(term.op, value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{field: number}, nil
+
+	case "title", "description":
+		if err := equalityOnly(); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{field: map[string]interface{}{"containsIgnoreCase": value}}, nil
+	}
+
+	return nil, fmt.Errorf("unknown field '%s' (see 'linctl issue list --help')", term.field)
+}
+
+// filterPresence handles "none" and "any" for optional fields
+func filterPresence(value string) (map[string]interface{}, bool) {
+	switch value {
+	case "none", "@none":
+		return map[string]interface{}{"null": true}, true
+	case "any", "@any":
+		return map[string]interface{}{"null": false}, true
+	}
+	return nil, false
+}
+
+// filterComparators maps operators to Linear comparators
+var filterComparators = map[string]string{"": "eq", ">": "gt", ">=": "gte", "<": "lt", "<=": "lte"}
+
+// compileNumberTerm compiles a numeric comparison
+func compileNumberTerm(op, value string) (map[string]interface{}, error) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a number", value)
+	}
+	return map[string]interface{}{filterComparators[op]: number}, nil
+}
+
+// priorityImportance orders priorities from least to most important; 0 (no
+// priority) ranks below low, so >=high means urgent or high
+var priorityImportance = map[int]int{0: 0, 4: 1, 3: 2, 2: 3, 1: 4}
+
+// compilePriorityTerm compiles a priority comparison to the set of
+// priorities that satisfy it
+func compilePriorityTerm(op, value string) (map[string]interface{}, error) {
+	priority, err := parsePriority(value)
+	if err != nil {
+		return nil, err
+	}
+	if op == "" {
+		return map[string]interface{}{"priority": map[string]interface{}{"eq": priority}}, nil
+	}
+
+	target := priorityImportance[priority]
+	var matching []int
+	for candidate, importance := range priorityImportance {
+		var ok bool
+		switch op {
+		case ">":
+			ok = importance > target
+		case ">=":
+			ok = importance >= target
+		case "<":
+			ok = importance < target
+		case "<=":
+			ok = importance <= target
+		}
+		if ok {
+			matching = append(matching, candidate)
+		}
+	}
+	sort.Ints(matching)
+	return map[string]interface{}{"priority": map[string]interface{}{"in": matching}}, nil
+}
+
+// relativeFilterDate matches offsets from now such as -7d or +2w. Without a
+// sign an offset is in the past, like --newer-than 7d, except for due dates,
+// where it is in the future, like --due-within 7d.
+var relativeFilterDate = regexp.MustCompile(`^([+-]?)(\d+)\s*([hdwmy])$`)
+
+// compileDateTerm compiles a date comparison for an IssueFilter date field
+func compileDateTerm(name, op, value string) (map[string]interface{}, error) {
+	lower := strings.ToLower(value)
+	if presence, ok := filterPresence(lower); ok {
+		if op != "" {
+			return nil, fmt.Errorf("'%s' can't be compared with %s", value, op)
+		}
+		return map[string]interface{}{name: presence}, nil
+	}
+
+	now := time.Now()
+	dateOnly := name == "dueDate"
+	format := func(t time.Time) string {
+		if dateOnly {
+			return t.Format("2006-01-02")
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	var when time.Time
+	relative := 0 // -1 in the past, 1 in the future, 0 for a day
+	if match := relativeFilterDate.FindStringSubmatch(lower); match != nil {
+		n, _ := strconv.Atoi(match[2])
+		if match[1] == "-" || (match[1] == "" && !dateOnly) {
+			n = -n
+			relative = -1
+		} else {
+			relative = 1
+		}
+		switch match[3] {
+		case "h":
+			when = now.Add(time.Duration(n) * time.Hour)
+		case "d":
+			when = now.AddDate(0, 0, n)
+		case "w":
+			when = now.AddDate(0, 0, n*7)
+		case "m":
+			when = now.AddDate(0, n, 0)
+		case "y":
+			when = now.AddDate(n, 0, 0)
+		}
+	} else if lower == "yesterday" {
+		when = time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, now.Location())
+	} else if day, err := utils.ParseDueDate(lower, now); err == nil {
+		when, _ = time.ParseInLocation("2006-01-02", day, now.Location())
+	} else if expr, err := utils.ParseTimeExpression(lower); err == nil && expr != "" {
+		when, _ = time.Parse(time.RFC3339, expr)
+		relative = -1
+	} else {
+		return nil, fmt.Errorf("invalid date '%s' (use YYYY-MM-DD, today, -7d, +2w, or 3_weeks_ago)", value)
+	}
+
+	if op != "" {
+		return map[string]interface{}{name: map[string]interface{}{filterComparators[op]: format(when)}}, nil
+	}
+	switch relative {
+	case -1:
+		// Since then: updated:-7d is updated in the last week
+		return map[string]interface{}{name: map[string]interface{}{"gte": format(when), "lte": format(now)}}, nil
+	case 1:
+		// From now until then: due:+3d is due in the next three days
+		return map[string]interface{}{name: map[string]interface{}{"gte": format(now), "lte": format(when)}}, nil
+	}
+	// That whole day
+	start := time.Date(when.Year(), when.Month(), when.Day(), 0, 0, 0, 0, when.Location())
+	if dateOnly {
+		return map[string]interface{}{name: map[string]interface{}{"eq": format(start)}}, nil
+	}
+	return map[string]interface{}{name: map[string]interface{}{"gte": format(start), "lt": format(start.AddDate(0, 0, 1))}}, nil
+}
+
+// issueIdentifierFilter matches one issue by its identifier, such as ENG-123
+func issueIdentifierFilter(value string) (map[string]interface{}, error) {
+	team, number, found := strings.Cut(strings.TrimSpace(value), "-")
+	n, err := strconv.Atoi(number)
+	if !found || team == "" || err != nil {
+		return nil, fmt.Errorf("'%s' is not an issue ID like ENG-123", value)
+	}
+	return map[string]interface{}{
+		"team":   map[string]interface{}{"key": map[string]interface{}{"eq": strings.ToUpper(team)}},
+		"number": map[string]interface{}{"eq": n},
+	}, nil
+}
+
+// negatedComparators pairs each comparator with its opposite
+var negatedComparators = map[string]string{
+	"eq": "neq", "neq": "eq",
+	"eqIgnoreCase": "neqIgnoreCase", "neqIgnoreCase": "eqIgnoreCase",
+	"in": "nin", "nin": "in",
+	"gt": "lte", "lte": "gt", "gte": "lt", "lt": "gte",
+	"contains": "notContains", "notContains": "contains",
+	"containsIgnoreCase": "notContainsIgnoreCase", "notContainsIgnoreCase": "containsIgnoreCase",
+}
+
+// optionalRelations are the to-one relations an issue may lack, and
+// optionalFields the fields that may be empty; negating a condition on one
+// also matches issues without it
+var (
+	optionalRelations = map[string]bool{
+		"assignee": true, "creator": true, "project": true, "cycle": true, "parent": true,
+	}
+	optionalFields = map[string]bool{
+		"estimate": true, "dueDate": true, "startedAt": true, "completedAt": true, "canceledAt": true,
+	}
+)
+
+// negateIssueFilter returns the filter matching exactly what f doesn't.
+// Linear filters have no "not", so the negation is pushed down to the
+// comparators: and/or swap, eq becomes neq, "some" becomes "every", and so on.
+func negateIssueFilter(f map[string]interface{}) map[string]interface{} {
+	// Several keys are a conjunction, so the negation is a disjunction
+	if len(f) > 1 {
+		var parts []interface{}
+		for _, key := range sortedFilterKeys(f) {
+			parts = append(parts, negateIssueFilter(map[string]interface{}{key: f[key]}))
+		}
+		return map[string]interface{}{"or": parts}
+	}
+
+	for key, value := range f {
+		switch key {
+		case "and", "or":
+			opposite := map[string]string{"and": "or", "or": "and"}[key]
+			var parts []interface{}
+			for _, part := range filterList(value) {
+				if m, ok := part.(map[string]interface{}); ok {
+					parts = append(parts, negateIssueFilter(m))
+				}
+			}
+			return map[string]interface{}{opposite: parts}
+		case "some":
+			return map[string]interface{}{"every": negateIssueFilter(value.(map[string]interface{}))}
+		case "every":
+			return map[string]interface{}{"some": negateIssueFilter(value.(map[string]interface{}))}
+		}
+
+		inner, ok := value.(map[string]interface{})
+		if !ok {
+			return f
+		}
+		_, isNull := inner["null"]
+
+		// Comparators on a field: field comparators can't be combined with
+		// "or", so a range such as {gte, lte} becomes an or of fields
+		if isComparison(inner) {
+			var alternatives []interface{}
+			for _, comparator := range sortedFilterKeys(inner) {
+				negated := map[string]interface{}{}
+				if comparator == "null" {
+					negated["null"] = inner["null"] != true
+				} else {
+					negated[negatedComparators[comparator]] = inner[comparator]
+				}
+				alternatives = append(alternatives, map[string]interface{}{key: negated})
+			}
+			if optionalFields[key] && !isNull {
+				alternatives = append(alternatives, map[string]interface{}{key: map[string]interface{}{"null": true}})
+			}
+			if len(alternatives) == 1 {
+				return alternatives[0].(map[string]interface{})
+			}
+			return map[string]interface{}{"or": alternatives}
+		}
+
+		// A condition on a missing relation is false, so its negation holds
+		// for issues without one
+		negated := map[string]interface{}{key: negateIssueFilter(inner)}
+		if optionalRelations[key] && !isNull {
+			return map[string]interface{}{"or": []interface{}{
+				negated,
+				map[string]interface{}{key: map[string]interface{}{"null": true}},
+			}}
+		}
+		return negated
+	}
+	return f
+}
+
+// isComparison reports whether every key of f is a comparator
+func isComparison(f map[string]interface{}) bool {
+	for key := range f {
+		if _, ok := negatedComparators[key]; !ok && key != "null" {
+			return false
+		}
+	}
+	return len(f) > 0
+}
+
+// sortedFilterKeys returns the keys of f in order, so compiled filters are stable
+func sortedFilterKeys(f map[string]interface{}) []string {
+	keys := make([]string, 0, len(f))
+	for key := range f {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// filterList returns the parts of an and/or, whichever slice type holds them
+func filterList(value interface{}) []interface{} {
+	switch parts := value.(type) {
+	case []interface{}:
+		return parts
+	case []map[string]interface{}:
+		list := make([]interface{}, len(parts))
+		for i, part := range parts {
+			list[i] = part
+		}
+		return list
+	}
+	return nil
+}
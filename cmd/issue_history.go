@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// historyFieldNames are the kinds of change 'issue history --field' can select
+var historyFieldNames = []string{
+	"state", "assignee", "priority", "title", "labels", "cycle",
+	"project", "estimate", "due-date", "parent", "description", "archived",
+}
+
+var issueHistoryCmd = &cobra.Command{
+	Use:     "history ISSUE-ID",
+	Aliases: []string{"audit"},
+	Short:   "Show an issue's change history",
+	Long: `Show who changed what on an issue and when: state, assignee, priority, title,
+labels, cycle, project, estimate, due date, parent, description, and archiving,
+oldest first. --json prints the raw history events, for audit and cycle-time
+analysis.
+
+Examples:
+  linctl issue history ENG-123
+  linctl issue history ENG-123 --since 2_weeks_ago --field state
+  linctl issue history --current --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		issueID, err := issueFromArgs(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		var since time.Time
+		if expr, _ := cmd.Flags().GetString("since"); expr != "" {
+			parsed, err := utils.ParseTimeExpression(expr)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Invalid since value: %v", err), plaintext, jsonOut)
+			}
+			if parsed != "" {
+				since, _ = time.Parse(time.RFC3339, parsed)
+			}
+		}
+
+		fields, _ := cmd.Flags().GetStringSlice("field")
+		for i, field := range fields {
+			fields[i] = strings.ToLower(strings.TrimSpace(field))
+			if !containsString(historyFieldNames, fields[i]) {
+				output.Error(fmt.Sprintf("Invalid field '%s' (expected one of: %s)", field, strings.Join(historyFieldNames, ", ")), plaintext, jsonOut)
+				exit(1)
+			}
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		issue, err := client.GetIssue(ctx, issueID)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
+		}
+
+		history, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize}, func(ctx context.Context, first int, after string) ([]api.IssueHistoryEntry, api.PageInfo, error) {
+			page, err := client.GetIssueHistory(ctx, issue.ID, first, after)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			if page.PageInfo == nil {
+				return page.Nodes, api.PageInfo{}, nil
+			}
+			return page.Nodes, *page.PageInfo, nil
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get history: %v", err), plaintext, jsonOut)
+		}
+		sort.SliceStable(history, func(i, j int) bool {
+			return history[i].CreatedAt.Before(history[j].CreatedAt)
+		})
+
+		var events []api.IssueHistoryEntry
+		var rows [][]string
+		for _, entry := range history {
+			if entry.CreatedAt.Before(since) {
+				continue
+			}
+			changes := historyChanges(entry, fields)
+			if len(changes) == 0 {
+				continue
+			}
+			events = append(events, entry)
+
+			actor := "Linear"
+			if entry.Actor != nil {
+				actor = entry.Actor.Name
+			}
+			when := entry.CreatedAt.Local().Format("2006-01-02 15:04")
+			if plaintext {
+				when = entry.CreatedAt.Format(time.RFC3339)
+			}
+			for _, change := range changes {
+				rows = append(rows, []string{when, actor, change.field, change.from, change.to})
+			}
+		}
+
+		if jsonOut {
+			if events == nil {
+				events = []api.IssueHistoryEntry{}
+			}
+			output.JSON(events)
+			return
+		}
+		if len(rows) == 0 {
+			output.Info(fmt.Sprintf("No history found for %s", issue.Identifier), plaintext, jsonOut)
+			return
+		}
+
+		headers := []string{"When", "Actor", "Field", "From", "To"}
+		output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, jsonOut)
+		if !plaintext {
+			fmt.Printf("\n%s %d changes to %s\n", color.New(color.FgGreen).Sprint("✓"), len(rows), issue.Identifier)
+		}
+	},
+}
+
+// historyChange is one field changed by a history event
+type historyChange struct {
+	field string
+	from  string
+	to    string
+}
+
+// historyChanges lists the changes a history event made, keeping only the
+// given fields (all when fields is empty)
+func historyChanges(entry api.IssueHistoryEntry, fields []string) []historyChange {
+	var changes []historyChange
+	add := func(field, from, to string) {
+		if len(fields) == 0 || containsString(fields, field) {
+			changes = append(changes, historyChange{field: field, from: from, to: to})
+		}
+	}
+
+	if entry.FromState != nil || entry.ToState != nil {
+		add("state", historyState(entry.FromState), historyState(entry.ToState))
+	}
+	if entry.FromAssignee != nil || entry.ToAssignee != nil {
+		add("assignee", historyUser(entry.FromAssignee), historyUser(entry.ToAssignee))
+	}
+	if entry.FromPriority != nil || entry.ToPriority != nil {
+		add("priority", historyPriority(entry.FromPriority), historyPriority(entry.ToPriority))
+	}
+	if entry.FromTitle != nil || entry.ToTitle != nil {
+		add("title", optionalString(entry.FromTitle), optionalString(entry.ToTitle))
+	}
+	if len(entry.AddedLabels) > 0 || len(entry.RemovedLabels) > 0 {
+		add("labels", historyLabels(entry.RemovedLabels, "-"), historyLabels(entry.AddedLabels, "+"))
+	} else if len(entry.AddedLabelIds) > 0 || len(entry.RemovedLabelIds) > 0 {
+		add("labels", fmt.Sprintf("%d removed", len(entry.RemovedLabelIds)), fmt.Sprintf("%d added", len(entry.AddedLabelIds)))
+	}
+	if entry.FromCycle != nil || entry.ToCycle != nil {
+		add("cycle", historyCycle(entry.FromCycle), historyCycle(entry.ToCycle))
+	}
+	if entry.FromProject != nil || entry.ToProject != nil {
+		add("project", historyProject(entry.FromProject), historyProject(entry.ToProject))
+	}
+	if entry.FromEstimate != nil || entry.ToEstimate != nil {
+		add("estimate", historyEstimate(entry.FromEstimate), historyEstimate(entry.ToEstimate))
+	}
+	if entry.FromDueDate != nil || entry.ToDueDate != nil {
+		add("due-date", optionalString(entry.FromDueDate), optionalString(entry.ToDueDate))
+	}
+	if entry.FromParent != nil || entry.ToParent != nil {
+		add("parent", historyParent(entry.FromParent), historyParent(entry.ToParent))
+	}
+	if entry.UpdatedDescription {
+		add("description", "", "edited")
+	}
+	if entry.Archived != nil {
+		if *entry.Archived {
+			add("archived", "", "archived")
+		} else {
+			add("archived", "archived", "restored")
+		}
+	}
+	return changes
+}
+
+func historyState(state *api.State) string {
+	if state == nil {
+		return ""
+	}
+	return state.Name
+}
+
+func historyUser(user *api.User) string {
+	if user == nil {
+		return "Unassigned"
+	}
+	return user.Name
+}
+
+func historyPriority(priority *int) string {
+	if priority == nil {
+		return ""
+	}
+	return priorityToString(*priority)
+}
+
+func historyLabels(labels []api.Label, sign string) string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = sign + label.Name
+	}
+	return strings.Join(names, " ")
+}
+
+func historyCycle(cycle *api.Cycle) string {
+	if cycle == nil {
+		return ""
+	}
+	if cycle.Name != "" {
+		return cycle.Name
+	}
+	return fmt.Sprintf("Cycle %d", cycle.Number)
+}
+
+func historyProject(project *api.Project) string {
+	if project == nil {
+		return ""
+	}
+	return project.Name
+}
+
+func historyEstimate(estimate *float64) string {
+	if estimate == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*estimate, 'f', -1, 64)
+}
+
+func historyParent(parent *api.Issue) string {
+	if parent == nil {
+		return ""
+	}
+	return parent.Identifier
+}
+
+func init() {
+	issueCmd.AddCommand(issueHistoryCmd)
+
+	issueHistoryCmd.Args = issueArg
+	pickable("issue", issueHistoryCmd)
+	issueHistoryCmd.Flags().Bool("current", false, "Use the issue named by the current git branch (see 'issue current')")
+	issueHistoryCmd.Flags().String("since", "", "Only changes since this time (e.g. 2_weeks_ago, 2025-01-15)")
+	issueHistoryCmd.Flags().StringSlice("field", nil, "Only these kinds of change (comma-separated: "+strings.Join(historyFieldNames, ", ")+")")
+}
@@ -0,0 +1,633 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// importFields are the issue fields a manifest row may set
+var importFields = []string{
+	"ref", "title", "description", "team", "state", "assignee", "labels",
+	"priority", "estimate", "project", "due-date", "parent",
+}
+
+// importFieldAliases maps common alternative column names to import fields
+var importFieldAliases = map[string]string{
+	"id":           "ref",
+	"label":        "labels",
+	"due":          "due-date",
+	"parent-issue": "parent",
+}
+
+// importRow is one issue of a manifest, with its fields keyed by import field
+type importRow struct {
+	Number int
+	Fields map[string]string
+	record []string               // CSV record, for the error report
+	item   map[string]interface{} // YAML item, for the error report
+}
+
+// importManifest is a parsed CSV or YAML manifest
+type importManifest struct {
+	Format  string
+	Header  []string
+	Rows    []importRow
+	Ignored []string
+}
+
+// importResult records the outcome for a single manifest row
+type importResult struct {
+	Row        int    `json:"row"`
+	Ref        string `json:"ref,omitempty"`
+	Title      string `json:"title"`
+	Identifier string `json:"identifier,omitempty"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	teamKey    string
+}
+
+var issueImportCmd = &cobra.Command{
+	Use:   "import MANIFEST",
+	Short: "Create many issues from a CSV or YAML manifest",
+	Long: `Create one issue per row of a CSV file (with a header row) or per item of a
+YAML list (optionally under an "issues" key). Use '-' to read stdin, with --format.
+
+Columns map to issue fields by name: ` + strings.Join(importFields, ", ") + `.
+Map other column names with --column FIELD=COLUMN; unknown columns are ignored.
+States, labels, users, and projects are resolved by name, and title is required.
+Rows without a team use --team, their parent's team, or default-team.
+
+A row's "parent" is either an existing issue (ENG-123) or the "ref" of another
+row, which is then created first. Rows that cannot be created are written, with
+an "error" column, to an error report (MANIFEST-errors.csv/.yaml by default)
+so they can be fixed and imported again.
+
+Examples:
+  linctl issue import backlog.csv --team ENG
+  linctl issue import backlog.csv --column title=Summary --column description=Details
+  linctl issue import plan.yaml --dry-run
+  cat issues.csv | linctl issue import - --format csv --error-file failed.csv`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{noDefaultTeamAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		path := args[0]
+		format, _ := cmd.Flags().GetString("format")
+		columns, _ := cmd.Flags().GetStringToString("column")
+		teamKey, _ := cmd.Flags().GetString("team")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		errorFile, _ := cmd.Flags().GetString("error-file")
+
+		manifest, err := readImportManifest(path, format, columns)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if len(manifest.Rows) == 0 {
+			output.Error("The manifest has no rows", plaintext, jsonOut)
+			exit(1)
+		}
+		order, err := importOrder(manifest.Rows)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if len(manifest.Ignored) > 0 && !jsonOut && !plaintext {
+			fmt.Fprintf(os.Stderr, "Ignoring columns: %s\n", strings.Join(manifest.Ignored, ", "))
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+		resolver := &bulkResolver{client: client, cache: make(map[string]*bulkCacheEntry)}
+
+		failed := createImportRows(ctx, resolver, manifest, order, path, teamKey, errorFile, dryRun, plaintext, jsonOut)
+		exitOnFailures(len(order)-failed, failed)
+	},
+}
+
+// createImportRows creates the manifest's rows in the given order, printing
+// progress, then writes the failed rows to an error report (errorFile, or
+// one next to path) and prints a summary. It returns the number of failed rows.
+func createImportRows(ctx context.Context, resolver *bulkResolver, manifest *importManifest, order []int, path, teamKey, errorFile string, dryRun, plaintext, jsonOut bool) int {
+	results := make([]importResult, len(manifest.Rows))
+	created := map[string]importResult{} // ref -> result of the row with that ref
+	for done, i := range order {
+		row := manifest.Rows[i]
+		result := createImportRow(ctx, resolver, row, teamKey, created, dryRun)
+		results[i] = result
+		if result.Ref != "" {
+			created[result.Ref] = result
+		}
+
+		if jsonOut || plaintext {
+			continue
+		}
+		progress := fmt.Sprintf("[%d/%d]", done+1, len(order))
+		if result.Error != "" {
+			fmt.Printf("%s %s row %d: %s\n",
+				color.New(color.FgWhite, color.Faint).Sprint(progress),
+				color.New(color.FgRed).Sprint("✗"),
+				result.Row, result.Error)
+			continue
+		}
+		icon := color.New(color.FgGreen).Sprint("✓")
+		identifier := result.Identifier
+		if dryRun {
+			icon = color.New(color.FgYellow).Sprint("~")
+			identifier = fmt.Sprintf("row %d", result.Row)
+		}
+		fmt.Printf("%s %s %s %s\n",
+			color.New(color.FgWhite, color.Faint).Sprint(progress),
+			icon,
+			color.New(color.FgCyan, color.Bold).Sprint(identifier),
+			result.Title)
+	}
+
+	var failedRows []importRow
+	var failedErrors []string
+	for i, result := range results {
+		if result.Error != "" {
+			failedRows = append(failedRows, manifest.Rows[i])
+			failedErrors = append(failedErrors, result.Error)
+		}
+	}
+	failed := len(failedRows)
+
+	reportPath := ""
+	if failed > 0 && !dryRun {
+		if errorFile == "" && path != "-" {
+			errorFile = importErrorFilePath(path, manifest.Format)
+		}
+		if errorFile != "" {
+			if err := writeImportErrors(errorFile, manifest, failedRows, failedErrors); err != nil {
+				output.Error(fmt.Sprintf("Failed to write error report: %v", err), plaintext, jsonOut)
+			} else {
+				reportPath = errorFile
+			}
+		}
+	}
+
+	if jsonOut {
+		result := map[string]interface{}{
+			"total":   len(results),
+			"created": len(results) - failed,
+			"failed":  failed,
+			"dryRun":  dryRun,
+			"results": results,
+		}
+		if reportPath != "" {
+			result["errorFile"] = reportPath
+		}
+		output.JSON(result)
+	} else if plaintext {
+		for _, result := range results {
+			if result.Error != "" {
+				fmt.Printf("%d\t\tfailed\t%s\n", result.Row, result.Error)
+			} else {
+				fmt.Printf("%d\t%s\t%s\t%s\n", result.Row, result.Identifier, result.Status, result.Title)
+			}
+		}
+		fmt.Printf("\nTotal: %d, Created: %d, Failed: %d\n", len(results), len(results)-failed, failed)
+		if reportPath != "" {
+			fmt.Printf("Failed rows written to %s\n", reportPath)
+		}
+	} else {
+		verb := "Created"
+		if dryRun {
+			verb = "Would create"
+		}
+		fmt.Printf("\n%s %s %d/%d issues",
+			color.New(color.FgGreen).Sprint("✓"), verb, len(results)-failed, len(results))
+		if failed > 0 {
+			fmt.Printf(", %s", color.New(color.FgRed).Sprintf("%d failed", failed))
+		}
+		fmt.Println()
+		if reportPath != "" {
+			fmt.Printf("  Failed rows written to %s\n", reportPath)
+		}
+	}
+
+	return failed
+}
+
+// readImportManifest reads a CSV or YAML manifest from a file or stdin ("-")
+// and maps its columns to import fields. columns maps a field to the column
+// that holds it, for columns not named after their field.
+func readImportManifest(path, format string, columns map[string]string) (*importManifest, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if format == "" || format == "auto" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			format = "csv"
+		case ".yaml", ".yml", ".json":
+			format = "yaml"
+		default:
+			return nil, fmt.Errorf("cannot tell the format of %s; use --format csv or --format yaml", path)
+		}
+	}
+
+	// Column name -> field, from --column first and then the column's own name
+	mapping := map[string]string{}
+	for field, column := range columns {
+		name := importFieldName(field)
+		if !isImportField(name) {
+			return nil, fmt.Errorf("unknown field '%s' in --column (valid: %s)", field, strings.Join(importFields, ", "))
+		}
+		mapping[strings.ToLower(strings.TrimSpace(column))] = name
+	}
+	fieldFor := func(column string) string {
+		if field, ok := mapping[strings.ToLower(strings.TrimSpace(column))]; ok {
+			return field
+		}
+		if name := importFieldName(column); isImportField(name) {
+			return name
+		}
+		return ""
+	}
+
+	manifest := &importManifest{Format: format}
+	ignored := map[string]bool{}
+	switch format {
+	case "csv":
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV manifest: %w", err)
+		}
+		if len(records) == 0 {
+			return manifest, nil
+		}
+		manifest.Header = records[0]
+		for i, record := range records[1:] {
+			row := importRow{Number: i + 1, Fields: map[string]string{}, record: record}
+			for j, column := range manifest.Header {
+				field := fieldFor(column)
+				if field == "" {
+					ignored[column] = true
+					continue
+				}
+				if j < len(record) {
+					row.Fields[field] = strings.TrimSpace(record[j])
+				}
+			}
+			manifest.Rows = append(manifest.Rows, row)
+		}
+	case "yaml":
+		var items []map[string]interface{}
+		if err := yaml.Unmarshal(data, &items); err != nil {
+			var wrapped struct {
+				Issues []map[string]interface{} `yaml:"issues"`
+			}
+			if wrappedErr := yaml.Unmarshal(data, &wrapped); wrappedErr != nil {
+				return nil, fmt.Errorf("invalid YAML manifest (expected a list of issues): %w", err)
+			}
+			items = wrapped.Issues
+		}
+		for i, item := range items {
+			row := importRow{Number: i + 1, Fields: map[string]string{}, item: item}
+			for key, value := range item {
+				field := fieldFor(key)
+				if field == "" {
+					ignored[key] = true
+					continue
+				}
+				row.Fields[field] = importValue(value)
+			}
+			manifest.Rows = append(manifest.Rows, row)
+		}
+	default:
+		return nil, fmt.Errorf("unknown manifest format '%s' (valid: auto, csv, yaml)", format)
+	}
+
+	for column := range ignored {
+		manifest.Ignored = append(manifest.Ignored, column)
+	}
+	sort.Strings(manifest.Ignored)
+	return manifest, nil
+}
+
+// importFieldName normalizes a column name: "Due Date" and "due_date" are "due-date"
+func importFieldName(column string) string {
+	name := strings.ToLower(strings.TrimSpace(column))
+	name = strings.NewReplacer(" ", "-", "_", "-").Replace(name)
+	if alias, ok := importFieldAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+func isImportField(name string) bool {
+	for _, field := range importFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// importValue formats a YAML value as a field value; lists become comma-separated
+func importValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, part := range v {
+			parts = append(parts, importValue(part))
+		}
+		return strings.Join(parts, ",")
+	case time.Time:
+		return v.Format("2006-01-02")
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
+
+// importOrder returns the row indexes ordered so every row comes after the
+// row its parent refers to
+func importOrder(rows []importRow) ([]int, error) {
+	byRef := map[string]int{}
+	for i, row := range rows {
+		ref := row.Fields["ref"]
+		if ref == "" {
+			continue
+		}
+		if first, ok := byRef[ref]; ok {
+			return nil, fmt.Errorf("rows %d and %d have the same ref '%s'", rows[first].Number, row.Number, ref)
+		}
+		byRef[ref] = i
+	}
+
+	order := make([]int, 0, len(rows))
+	state := make([]int, len(rows)) // 0 = unvisited, 1 = visiting, 2 = done
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case 1:
+			return fmt.Errorf("row %d is its own ancestor through the parent column", rows[i].Number)
+		case 2:
+			return nil
+		}
+		state[i] = 1
+		if parent, ok := byRef[rows[i].Fields["parent"]]; ok {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		state[i] = 2
+		order = append(order, i)
+		return nil
+	}
+	for i := range rows {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// createImportRow resolves a row's names to IDs and creates its issue (unless
+// dryRun). created holds the results of rows already processed, by ref.
+func createImportRow(ctx context.Context, resolver *bulkResolver, row importRow, teamFlag string, created map[string]importResult, dryRun bool) importResult {
+	fields := row.Fields
+	result := importResult{Row: row.Number, Ref: fields["ref"], Title: fields["title"]}
+	fail := func(err error) importResult {
+		result.Status = "failed"
+		result.Error = err.Error()
+		return result
+	}
+
+	if result.Title == "" {
+		return fail(fmt.Errorf("title is required"))
+	}
+
+	// A parent in the manifest must have been created already
+	parentRef := fields["parent"]
+	parentRow, parentInManifest := created[parentRef]
+	if parentRef != "" && parentInManifest && parentRow.Error != "" {
+		return fail(fmt.Errorf("parent row %d failed", parentRow.Row))
+	}
+
+	// The row's team, then --team, then the parent's team, then default-team
+	teamKey := firstNonEmpty(fields["team"], teamFlag)
+	if teamKey == "" && parentRef != "" {
+		if parentInManifest {
+			teamKey = parentRow.teamKey
+		} else {
+			teamKey = teamKeyFromIdentifier(parentRef)
+		}
+	}
+	teamKey, err := resolveTeamKey(firstNonEmpty(teamKey, viper.GetString("default-team")))
+	if err != nil {
+		return fail(err)
+	}
+	if teamKey == "" {
+		return fail(fmt.Errorf("team is required (add a team column or use --team)"))
+	}
+	result.teamKey = teamKey
+
+	teamID, err := resolver.teamID(ctx, teamKey)
+	if err != nil {
+		return fail(err)
+	}
+	input := map[string]interface{}{
+		"title":  result.Title,
+		"teamId": teamID,
+	}
+
+	if description := fields["description"]; description != "" {
+		input["description"] = description
+	}
+	if state := fields["state"]; state != "" {
+		stateID, err := resolver.stateID(ctx, teamKey, state)
+		if err != nil {
+			return fail(err)
+		}
+		input["stateId"] = stateID
+	}
+	if assignee := fields["assignee"]; assignee != "" {
+		assigneeID, err := resolver.assigneeID(ctx, assignee)
+		if err != nil {
+			return fail(err)
+		}
+		if assigneeID != nil {
+			input["assigneeId"] = *assigneeID
+		}
+	}
+	if labels := fields["labels"]; labels != "" {
+		labelIDs, err := resolver.labelIDs(ctx, teamKey, labels)
+		if err != nil {
+			return fail(err)
+		}
+		input["labelIds"] = labelIDs
+	}
+	if value := fields["priority"]; value != "" {
+		priority, err := parsePriority(value)
+		if err != nil {
+			return fail(err)
+		}
+		input["priority"] = priority
+	}
+	if value := fields["estimate"]; value != "" {
+		estimate, err := strconv.Atoi(value)
+		if err != nil || estimate < 0 {
+			return fail(fmt.Errorf("invalid estimate '%s'", value))
+		}
+		input["estimate"] = estimate
+	}
+	if project := fields["project"]; project != "" {
+		projectID, err := resolver.projectID(ctx, project)
+		if err != nil {
+			return fail(err)
+		}
+		input["projectId"] = projectID
+	}
+	if dueDate := fields["due-date"]; dueDate != "" {
+		if _, err := time.Parse("2006-01-02", dueDate); err != nil {
+			return fail(fmt.Errorf("invalid due date '%s' (expected YYYY-MM-DD)", dueDate))
+		}
+		input["dueDate"] = dueDate
+	}
+
+	if parentRef != "" && !(parentInManifest && dryRun) {
+		parentID := ""
+		if parentInManifest {
+			parentID = parentRow.Identifier
+		}
+		parentID, err = resolver.issueID(ctx, firstNonEmpty(parentID, parentRef))
+		if err != nil {
+			return fail(err)
+		}
+		input["parentId"] = parentID
+	}
+
+	if dryRun {
+		result.Status = "planned"
+		return result
+	}
+
+	issue, err := resolver.client.CreateIssue(ctx, input)
+	if err != nil {
+		return fail(fmt.Errorf("failed to create issue: %v", err))
+	}
+	result.Identifier = issue.Identifier
+	result.Status = "created"
+	return result
+}
+
+// parsePriority accepts a priority number (0-4) or name (none, urgent, high,
+// normal/medium, low)
+func parsePriority(value string) (int, error) {
+	if priority, err := strconv.Atoi(value); err == nil && priority >= 0 && priority <= 4 {
+		return priority, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "none", "no priority":
+		return 0, nil
+	case "urgent":
+		return 1, nil
+	case "high":
+		return 2, nil
+	case "normal", "medium":
+		return 3, nil
+	case "low":
+		return 4, nil
+	}
+	return 0, fmt.Errorf("invalid priority '%s' (use 0-4 or none, urgent, high, normal, low)", value)
+}
+
+// firstNonEmpty returns the first of its arguments that is not ""
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// importErrorFilePath is the default error report next to the manifest:
+// backlog.csv -> backlog-errors.csv
+func importErrorFilePath(path, format string) string {
+	ext := filepath.Ext(path)
+	if ext == "" {
+		ext = "." + format
+	}
+	return strings.TrimSuffix(path, filepath.Ext(path)) + "-errors" + ext
+}
+
+// writeImportErrors writes the failed rows in the manifest's own format, with
+// an added "error" column, so they can be fixed and imported again
+func writeImportErrors(path string, manifest *importManifest, rows []importRow, errs []string) error {
+	var buf bytes.Buffer
+	if manifest.Format == "csv" {
+		writer := csv.NewWriter(&buf)
+		header := append(append([]string{}, manifest.Header...), "error")
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+		for i, row := range rows {
+			record := make([]string, len(manifest.Header))
+			copy(record, row.record)
+			if err := writer.Write(append(record, errs[i])); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+	} else {
+		items := make([]map[string]interface{}, len(rows))
+		for i, row := range rows {
+			item := make(map[string]interface{}, len(row.item)+1)
+			for key, value := range row.item {
+				item[key] = value
+			}
+			item["error"] = errs[i]
+			items[i] = item
+		}
+		data, err := yaml.Marshal(items)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func init() {
+	issueCmd.AddCommand(issueImportCmd)
+
+	issueImportCmd.Flags().String("format", "auto", "Manifest format: auto (from the file extension), csv, yaml")
+	issueImportCmd.Flags().StringToString("column", nil, "Map a field to a differently named column, as FIELD=COLUMN (repeatable)")
+	issueImportCmd.Flags().StringP("team", "t", "", "Team for rows without a team column")
+	issueImportCmd.Flags().Bool("dry-run", false, "Resolve every row without creating issues")
+	issueImportCmd.Flags().String("error-file", "", "Where to write failed rows (default: MANIFEST-errors.csv/.yaml)")
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// issueRefAnnotation marks a flag whose value names an issue
+const issueRefAnnotation = "linctl/issue-ref"
+
+// issuePlaceholders are the argument names in a command's Use line that
+// stand for an issue
+var issuePlaceholders = map[string]bool{"ISSUE-ID": true, "OTHER-ID": true}
+
+// markIssueRefFlags marks flags whose values name issues, so they take every
+// format an issue argument does
+func markIssueRefFlags(flags *pflag.FlagSet, names ...string) {
+	for _, name := range names {
+		_ = flags.SetAnnotation(name, issueRefAnnotation, []string{"true"})
+	}
+}
+
+// placeholderPositions reports which of n arguments stand for one of names,
+// going by the placeholders in the command's Use line ("move ISSUE-ID...",
+// "attach CUSTOMER ISSUE-ID"). Optional placeholders the arguments leave out
+// are dropped from the left, so "link-pr [ISSUE-ID] PR-URL" with one argument
+// takes a PR URL. nil when the arguments don't fit the placeholders.
+func placeholderPositions(cmd *cobra.Command, n int, names map[string]bool) []bool {
+	type placeholder struct{ match, optional, variadic bool }
+	var placeholders []placeholder
+	for _, token := range strings.Fields(cmd.Use)[1:] {
+		if strings.HasPrefix(token, "-") {
+			break
+		}
+		placeholders = append(placeholders, placeholder{
+			match:    names[strings.ToUpper(strings.Trim(token, "[]<>."))],
+			optional: strings.HasPrefix(token, "["),
+			variadic: strings.Contains(token, "..."),
+		})
+	}
+
+	for len(placeholders) > n {
+		dropped := false
+		for i, p := range placeholders {
+			if p.optional {
+				placeholders = append(placeholders[:i], placeholders[i+1:]...)
+				dropped = true
+				break
+			}
+		}
+		if !dropped {
+			return nil
+		}
+	}
+	if len(placeholders) == 0 || (len(placeholders) < n && !placeholders[len(placeholders)-1].variadic) {
+		return nil
+	}
+
+	positions := make([]bool, n)
+	for i := range positions {
+		p := placeholders[len(placeholders)-1]
+		if i < len(placeholders) {
+			p = placeholders[i]
+		}
+		positions[i] = p.match
+	}
+	return positions
+}
+
+// normalizeIssueRefs lets issue arguments and flags be given in any format
+// ResolveIssue accepts: identifiers, Linear URLs, UUIDs, and branch names
+// all become identifiers (or stay UUIDs) before the command runs. Only
+// branch names need Linear; the rest are rewritten locally. Like
+// normalizeLinearURLs, args is rewritten in place.
+func normalizeIssueRefs(cmd *cobra.Command, args []string) error {
+	var client *api.Client
+	resolve := func(ref string) (string, error) {
+		ref = strings.TrimSpace(ref)
+		if ref == "" || isNoneValue(ref) {
+			return ref, nil
+		}
+		if id, ok := api.ParseIssueRef(ref); ok {
+			return id, nil
+		}
+		if client == nil {
+			client = authenticatedClient(viper.GetBool("plaintext"), viper.GetBool("json"))
+		}
+		issue, err := client.ResolveIssue(context.Background(), ref)
+		if err != nil {
+			return "", err
+		}
+		return issue.Identifier, nil
+	}
+
+	for i, isIssue := range placeholderPositions(cmd, len(args), issuePlaceholders) {
+		if !isIssue {
+			continue
+		}
+		ref, err := resolve(args[i])
+		if err != nil {
+			return err
+		}
+		args[i] = ref
+	}
+
+	var flagErr error
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if flagErr != nil || f.Annotations[issueRefAnnotation] == nil {
+			return
+		}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			refs := slice.GetSlice()
+			for i := range refs {
+				if refs[i], flagErr = resolve(refs[i]); flagErr != nil {
+					return
+				}
+			}
+			flagErr = slice.Replace(refs)
+			return
+		}
+		ref, err := resolve(f.Value.String())
+		if err != nil {
+			flagErr = err
+			return
+		}
+		flagErr = f.Value.Set(ref)
+	})
+	return flagErr
+}
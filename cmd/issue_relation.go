@@ -0,0 +1,475 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// maxBlockingWalk bounds how many issues cycle detection visits
+const maxBlockingWalk = 200
+
+// issueRelationView is a relation seen from one issue: Relation reads as
+// "<issue> <relation> <Issue>", e.g. "ENG-1 blocked by ENG-2"
+type issueRelationView struct {
+	ID       string     `json:"id"`
+	Type     string     `json:"type"`
+	Relation string     `json:"relation"`
+	Issue    *api.Issue `json:"issue"`
+}
+
+// plannedRelation is a relation 'issue relate' was asked to create
+type plannedRelation struct {
+	Source string
+	Target string
+	Type   string
+}
+
+var issueRelateCmd = &cobra.Command{
+	Use:   "relate ISSUE-ID",
+	Short: "Relate an issue to other issues",
+	Long: `Mark an issue as blocking, blocked by, a duplicate of, or related to other issues.
+
+Relations that already exist are skipped. When a new blocking relation closes a
+loop (A blocks B blocks ... blocks A), a warning shows the cycle.
+
+Examples:
+  linctl issue relate ENG-1 --blocks ENG-2,ENG-3
+  linctl issue relate ENG-4 --blocked-by ENG-1
+  linctl issue relate ENG-5 --duplicate-of ENG-2
+  linctl issue relate ENG-6 --related-to ENG-7`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		blocks, _ := cmd.Flags().GetStringSlice("blocks")
+		blockedBy, _ := cmd.Flags().GetStringSlice("blocked-by")
+		duplicateOf, _ := cmd.Flags().GetString("duplicate-of")
+		relatedTo, _ := cmd.Flags().GetStringSlice("related-to")
+
+		subject := strings.ToUpper(args[0])
+		var planned []plannedRelation
+		for _, id := range blocks {
+			planned = append(planned, plannedRelation{Source: subject, Target: strings.ToUpper(id), Type: "blocks"})
+		}
+		for _, id := range blockedBy {
+			planned = append(planned, plannedRelation{Source: strings.ToUpper(id), Target: subject, Type: "blocks"})
+		}
+		if duplicateOf != "" {
+			planned = append(planned, plannedRelation{Source: subject, Target: strings.ToUpper(duplicateOf), Type: "duplicate"})
+		}
+		for _, id := range relatedTo {
+			planned = append(planned, plannedRelation{Source: subject, Target: strings.ToUpper(id), Type: "related"})
+		}
+		if len(planned) == 0 {
+			output.Error("Nothing to relate. Use --blocks, --blocked-by, --duplicate-of, or --related-to.", plaintext, jsonOut)
+			exit(1)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		created := []issueRelationView{}
+		var skipped, warnings, failures []string
+		for _, plan := range planned {
+			if plan.Source == plan.Target {
+				failures = append(failures, fmt.Sprintf("%s: an issue cannot be related to itself", plan.Source))
+				continue
+			}
+
+			source, _, err := client.GetIssueRelations(ctx, plan.Source)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", plan.Source, err))
+				continue
+			}
+			target, _, err := client.GetIssueRelations(ctx, plan.Target)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", plan.Target, err))
+				continue
+			}
+
+			label := relationLabel(plan.Type, false)
+			if hasRelation(source, plan.Type, target.ID) {
+				skipped = append(skipped, fmt.Sprintf("%s already %s %s", source.Identifier, label, target.Identifier))
+				continue
+			}
+
+			if plan.Type == "blocks" {
+				if path := findBlockingPath(ctx, client, target.Identifier, source.Identifier); path != nil {
+					cycle := append([]string{source.Identifier}, path...)
+					warnings = append(warnings, fmt.Sprintf("blocking cycle: %s", strings.Join(cycle, " → ")))
+				}
+			}
+
+			relation, err := client.CreateIssueRelation(ctx, source.ID, target.ID, plan.Type)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s %s %s: %v", source.Identifier, label, target.Identifier, err))
+				continue
+			}
+			// Report the relation from the point of view of the issue being related
+			view := issueRelationView{ID: relation.ID, Type: relation.Type, Relation: label, Issue: target}
+			if plan.Source != subject {
+				view.Relation, view.Issue = relationLabel(plan.Type, true), source
+			}
+			created = append(created, view)
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"issue":   subject,
+				"created": created,
+			}
+			if len(skipped) > 0 {
+				result["skipped"] = skipped
+			}
+			if len(warnings) > 0 {
+				result["warnings"] = warnings
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			for _, relation := range created {
+				message := fmt.Sprintf("%s %s %s", subject, relation.Relation, relation.Issue.Identifier)
+				if plaintext {
+					fmt.Printf("Related: %s\n", message)
+				} else {
+					fmt.Printf("%s %s\n", color.New(color.FgGreen).Sprint("✓"), message)
+				}
+			}
+			for _, message := range skipped {
+				output.Info(fmt.Sprintf("Skipped: %s", message), plaintext, false)
+			}
+			for _, warning := range warnings {
+				if plaintext {
+					fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+				} else {
+					fmt.Fprintf(os.Stderr, "%s %s\n", color.New(color.FgYellow).Sprint("⚠ Warning:"), warning)
+				}
+			}
+			for _, failure := range failures {
+				output.Error(fmt.Sprintf("Failed: %s", failure), plaintext, false)
+			}
+		}
+
+		exitOnFailures(len(created), len(failures))
+	},
+}
+
+var issueRelationsCmd = &cobra.Command{
+	Use:     "relations ISSUE-ID",
+	Aliases: []string{"deps"},
+	Short:   "List an issue's relations",
+	Long:    `List the issues an issue blocks, is blocked by, duplicates, or is related to, and warn about blocking cycles.`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		issue, inverse, err := client.GetIssueRelations(ctx, args[0])
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issue relations: %v", err), plaintext, jsonOut)
+		}
+		views := relationViews(issue, inverse)
+
+		// A cycle through this issue always leaves via one of its "blocks" relations
+		var cycle []string
+		for _, view := range views {
+			if view.Relation == "blocks" {
+				if path := findBlockingPath(ctx, client, view.Issue.Identifier, issue.Identifier); path != nil {
+					cycle = append([]string{issue.Identifier}, path...)
+					break
+				}
+			}
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"issue":     issue.Identifier,
+				"relations": views,
+			}
+			if cycle != nil {
+				result["cycle"] = cycle
+			}
+			output.JSON(result)
+			return
+		}
+
+		if len(views) == 0 {
+			output.Info(fmt.Sprintf("%s has no relations", issue.Identifier), plaintext, jsonOut)
+			return
+		}
+
+		if plaintext {
+			fmt.Println("Relation\tIssue\tTitle\tState\tRelation ID")
+			for _, view := range views {
+				fmt.Printf("%s\t%s\t%s\t%s\t%s\n", view.Relation, view.Issue.Identifier, view.Issue.Title, relationStateName(view.Issue), view.ID)
+			}
+		} else {
+			rows := make([][]string, len(views))
+			for i, view := range views {
+				relation := view.Relation
+				if view.Relation == "blocked by" && !relationIssueDone(view.Issue) {
+					relation = color.New(color.FgRed).Sprint(relation)
+				}
+				rows[i] = []string{
+					relation,
+					color.New(color.FgCyan).Sprint(view.Issue.Identifier),
+					view.Issue.Title,
+					relationStateName(view.Issue),
+				}
+			}
+			output.Table(output.TableData{
+				Headers: []string{"Relation", "Issue", "Title", "State"},
+				Rows:    rows,
+			}, plaintext, jsonOut)
+		}
+
+		if cycle != nil {
+			message := fmt.Sprintf("blocking cycle: %s", strings.Join(cycle, " → "))
+			if plaintext {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", message)
+			} else {
+				fmt.Fprintf(os.Stderr, "\n%s %s\n", color.New(color.FgYellow).Sprint("⚠ Warning:"), message)
+			}
+		}
+	},
+}
+
+var issueUnrelateCmd = &cobra.Command{
+	Use:   "unrelate ISSUE-ID OTHER-ID...",
+	Short: "Remove relations between issues",
+	Long: `Remove the relations between an issue and other issues, in either direction.
+
+Examples:
+  linctl issue unrelate ENG-1 ENG-2
+  linctl issue unrelate ENG-1 ENG-2 ENG-3 --type blocks`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		relationType, _ := cmd.Flags().GetString("type")
+		relationType = strings.ToLower(relationType)
+		switch relationType {
+		case "", "blocks", "duplicate", "related", "similar":
+		default:
+			output.Error(fmt.Sprintf("Invalid relation type: %s. Valid types are: blocks, duplicate, related, similar", relationType), plaintext, jsonOut)
+			exit(1)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		issue, inverse, err := client.GetIssueRelations(ctx, args[0])
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issue relations: %v", err), plaintext, jsonOut)
+		}
+		views := relationViews(issue, inverse)
+
+		removed := []issueRelationView{}
+		var failures []string
+		var undoChanges []undoChange
+		for _, other := range args[1:] {
+			found := false
+			for _, view := range views {
+				if !strings.EqualFold(view.Issue.Identifier, other) && view.Issue.ID != other {
+					continue
+				}
+				if relationType != "" && view.Type != relationType {
+					continue
+				}
+				found = true
+				if err := client.DeleteIssueRelation(ctx, view.ID); err != nil {
+					failures = append(failures, fmt.Sprintf("%s %s %s: %v", issue.Identifier, view.Relation, view.Issue.Identifier, err))
+					continue
+				}
+				removed = append(removed, view)
+
+				// Inverse relations were created from the other issue
+				source, target := issue.ID, view.Issue.ID
+				if view.Relation != relationLabel(view.Type, false) {
+					source, target = target, source
+				}
+				undoChanges = append(undoChanges, undoChange{
+					Kind:    undoRelationDelete,
+					Target:  view.ID,
+					Label:   issue.Identifier,
+					Summary: fmt.Sprintf("no longer %s %s", view.Relation, view.Issue.Identifier),
+					Restore: map[string]interface{}{"issueId": source, "relatedIssueId": target, "type": view.Type},
+				})
+			}
+			if !found {
+				failures = append(failures, fmt.Sprintf("%s has no relation to %s", issue.Identifier, strings.ToUpper(other)))
+			}
+		}
+		recordUndo(cmd, args, undoChanges)
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"issue":   issue.Identifier,
+				"removed": removed,
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			for _, view := range removed {
+				message := fmt.Sprintf("%s no longer %s %s", issue.Identifier, view.Relation, view.Issue.Identifier)
+				if plaintext {
+					fmt.Println(message)
+				} else {
+					fmt.Printf("%s %s\n", color.New(color.FgGreen).Sprint("✓"), message)
+				}
+			}
+			for _, failure := range failures {
+				output.Error(failure, plaintext, false)
+			}
+		}
+
+		exitOnFailures(len(removed), len(failures))
+	},
+}
+
+// relationViews merges an issue's relations and inverse relations into one
+// list read from the issue's point of view
+func relationViews(issue *api.Issue, inverse []api.IssueRelation) []issueRelationView {
+	views := []issueRelationView{}
+	if issue.Relations != nil {
+		for _, relation := range issue.Relations.Nodes {
+			if relation.RelatedIssue == nil {
+				continue
+			}
+			views = append(views, issueRelationView{
+				ID:       relation.ID,
+				Type:     relation.Type,
+				Relation: relationLabel(relation.Type, false),
+				Issue:    relation.RelatedIssue,
+			})
+		}
+	}
+	for _, relation := range inverse {
+		if relation.Issue == nil {
+			continue
+		}
+		views = append(views, issueRelationView{
+			ID:       relation.ID,
+			Type:     relation.Type,
+			Relation: relationLabel(relation.Type, true),
+			Issue:    relation.Issue,
+		})
+	}
+	return views
+}
+
+// relationLabel phrases a relation type from the source's point of view, or
+// from the target's when inverse is set
+func relationLabel(relationType string, inverse bool) string {
+	switch relationType {
+	case "blocks":
+		if inverse {
+			return "blocked by"
+		}
+		return "blocks"
+	case "duplicate":
+		if inverse {
+			return "duplicated by"
+		}
+		return "duplicate of"
+	case "similar":
+		return "similar to"
+	default:
+		return "related to"
+	}
+}
+
+// hasRelation reports whether issue already has an outgoing relation of
+// relationType to the issue with ID targetID
+func hasRelation(issue *api.Issue, relationType, targetID string) bool {
+	if issue.Relations == nil {
+		return false
+	}
+	for _, relation := range issue.Relations.Nodes {
+		if relation.Type == relationType && relation.RelatedIssue != nil && relation.RelatedIssue.ID == targetID {
+			return true
+		}
+	}
+	return false
+}
+
+// findBlockingPath follows "blocks" relations breadth-first from one issue and
+// returns the chain of identifiers that reaches target, or nil when there is none
+func findBlockingPath(ctx context.Context, client *api.Client, from, target string) []string {
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+
+	for len(queue) > 0 && len(previous) <= maxBlockingWalk {
+		current := queue[0]
+		queue = queue[1:]
+
+		if strings.EqualFold(current, target) {
+			var path []string
+			for id := current; id != ""; id = previous[id] {
+				path = append([]string{id}, path...)
+			}
+			return path
+		}
+
+		issue, _, err := client.GetIssueRelations(ctx, current)
+		if err != nil || issue.Relations == nil {
+			continue
+		}
+		for _, relation := range issue.Relations.Nodes {
+			if relation.Type != "blocks" || relation.RelatedIssue == nil {
+				continue
+			}
+			next := relation.RelatedIssue.Identifier
+			if _, seen := previous[next]; seen {
+				continue
+			}
+			previous[next] = current
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// relationStateName is the state of a related issue, or "-"
+func relationStateName(issue *api.Issue) string {
+	if issue.State == nil {
+		return "-"
+	}
+	return issue.State.Name
+}
+
+// relationIssueDone reports whether a related issue is completed or canceled
+func relationIssueDone(issue *api.Issue) bool {
+	return issue.State != nil && (issue.State.Type == "completed" || issue.State.Type == "canceled")
+}
+
+func init() {
+	issueCmd.AddCommand(issueRelateCmd)
+	issueCmd.AddCommand(issueRelationsCmd)
+	issueCmd.AddCommand(issueUnrelateCmd)
+
+	issueRelateCmd.Flags().StringSlice("blocks", nil, "Issues this issue blocks (comma-separated)")
+	issueRelateCmd.Flags().StringSlice("blocked-by", nil, "Issues that block this issue (comma-separated)")
+	issueRelateCmd.Flags().String("duplicate-of", "", "Issue this issue duplicates")
+	issueRelateCmd.Flags().StringSlice("related-to", nil, "Related issues (comma-separated)")
+	markIssueRefFlags(issueRelateCmd.Flags(), "blocks", "blocked-by", "duplicate-of", "related-to")
+
+	issueUnrelateCmd.Flags().String("type", "", "Only remove relations of this type: blocks, duplicate, related, similar")
+}
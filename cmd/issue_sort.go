@@ -0,0 +1,413 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// issueOrderHelp documents --sort and --group-by in the help of issue list
+// and issue search
+const issueOrderHelp = `Sorting and grouping (--sort, --group-by):
+  --sort takes linear (the default), or a comma-separated list of keys, each
+  sorting ascending, or descending with a leading -:
+  priority (urgent first), created, updated, due, sla, state, assignee,
+  project, team, cycle, estimate, title, identifier. Issues without a value sort last.
+  A lone created or updated is sorted by Linear, newest first; other keys are
+  sorted after fetching, so only the fetched issues (see --limit and --all) are
+  in order.
+
+  --group-by state, assignee, project, team, priority, or cycle prints a table
+  per group with its count (and, with --json, a list of groups).`
+
+// issueSortKey is one key of --sort
+type issueSortKey struct {
+	Field      string // a column name, see issueColumns
+	Descending bool
+}
+
+// issueOrder is how issue list and search results are ordered: by Linear
+// (OrderBy), then by Keys here, and grouped by GroupBy
+type issueOrder struct {
+	OrderBy string
+	Keys    []issueSortKey
+	GroupBy string
+}
+
+// issueSortFields maps the keys --sort accepts to the columns they compare
+var issueSortFields = map[string]string{
+	"priority":   "priority",
+	"created":    "created",
+	"createdat":  "created",
+	"updated":    "updated",
+	"updatedat":  "updated",
+	"due":        "due",
+	"duedate":    "due",
+	"sla":        "sla",
+	"state":      "state",
+	"status":     "state",
+	"assignee":   "assignee",
+	"project":    "project",
+	"team":       "team",
+	"cycle":      "cycle",
+	"estimate":   "estimate",
+	"title":      "title",
+	"identifier": "identifier",
+	"id":         "identifier",
+}
+
+// issueGroupFields are the columns --group-by accepts
+var issueGroupFields = []string{"state", "assignee", "project", "team", "priority", "cycle"}
+
+// addIssueOrderFlags registers --sort and --group-by
+func addIssueOrderFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated, or keys like priority,-updated (see --help)")
+	cmd.Flags().String("group-by", "", "Group results by "+strings.Join(issueGroupFields, ", "))
+}
+
+// parseIssueOrder reads --sort and --group-by
+func parseIssueOrder(cmd *cobra.Command) (*issueOrder, error) {
+	order := &issueOrder{}
+	sortBy, _ := cmd.Flags().GetString("sort")
+	switch sortBy {
+	case "", "linear":
+	case "created", "createdAt":
+		order.OrderBy = "createdAt"
+	case "updated", "updatedAt":
+		order.OrderBy = "updatedAt"
+	default:
+		keys, err := parseIssueSortKeys(sortBy)
+		if err != nil {
+			return nil, fmt.Errorf("%v. Valid keys are: linear, %s", err, issueSortKeyNames)
+		}
+		order.Keys = keys
+		// Linear sorts by a single date, newest first
+		if len(order.Keys) == 1 && order.Keys[0].Descending && (order.Keys[0].Field == "created" || order.Keys[0].Field == "updated") {
+			order.OrderBy = order.Keys[0].Field + "At"
+			order.Keys = nil
+		}
+	}
+
+	if groupBy, _ := cmd.Flags().GetString("group-by"); groupBy != "" {
+		order.GroupBy = strings.ToLower(groupBy)
+		if order.GroupBy == "status" {
+			order.GroupBy = "state"
+		}
+		if !containsString(issueGroupFields, order.GroupBy) {
+			return nil, fmt.Errorf("invalid group-by '%s'. Valid options are: %s", groupBy, strings.Join(issueGroupFields, ", "))
+		}
+	}
+	return order, nil
+}
+
+// issueSortKeyNames lists the keys --sort accepts besides linear
+const issueSortKeyNames = "priority, created, updated, due, sla, state, assignee, project, team, cycle, estimate, title, identifier"
+
+// parseIssueSortKeys reads a comma-separated list of sort keys, each
+// descending with a leading -
+func parseIssueSortKeys(sortBy string) ([]issueSortKey, error) {
+	var keys []issueSortKey
+	for _, key := range strings.Split(sortBy, ",") {
+		key = strings.TrimSpace(key)
+		descending := strings.HasPrefix(key, "-")
+		key = strings.TrimLeft(key, "+-")
+		field, ok := issueSortFields[strings.ToLower(key)]
+		if !ok {
+			return nil, fmt.Errorf("invalid sort key '%s'", key)
+		}
+		keys = append(keys, issueSortKey{Field: field, Descending: descending})
+	}
+	return keys, nil
+}
+
+// selection widens a --fields or csv/tsv selection with the fields sorting
+// and grouping read. shown are the fields selected for output; an empty
+// selection already fetches every field.
+func (o *issueOrder) selection(selection string, shown []string) (string, error) {
+	if selection == "" || (len(o.Keys) == 0 && o.GroupBy == "") {
+		return selection, nil
+	}
+	fields := append([]string{}, shown...)
+	for _, key := range o.Keys {
+		fields = append(fields, key.Field)
+	}
+	if o.GroupBy != "" {
+		fields = append(fields, o.GroupBy)
+	}
+	return api.IssueSelection(fields)
+}
+
+// shownIssueFields returns the fields list output shows: --fields, or the
+// csv/tsv columns
+func shownIssueFields(fields []string, format *delimitedFormat) []string {
+	if fields == nil && format != nil {
+		return format.Columns
+	}
+	return fields
+}
+
+// apply sorts issues by the sort keys and then, keeping that order within
+// each group, by group
+func (o *issueOrder) apply(issues *api.Issues) {
+	if len(o.Keys) > 0 {
+		sort.SliceStable(issues.Nodes, func(i, j int) bool {
+			for _, key := range o.Keys {
+				if c := compareIssueField(&issues.Nodes[i], &issues.Nodes[j], key.Field, key.Descending); c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
+	if o.GroupBy != "" {
+		sort.SliceStable(issues.Nodes, func(i, j int) bool {
+			return compareIssueField(&issues.Nodes[i], &issues.Nodes[j], o.GroupBy, false) < 0
+		})
+	}
+}
+
+// compareIssueField compares two issues by a column, returning -1, 0, or 1.
+// Issues without a value sort last in either direction.
+func compareIssueField(a, b *api.Issue, field string, descending bool) int {
+	aValue, aOK := issueSortValue(a, field)
+	bValue, bOK := issueSortValue(b, field)
+	switch {
+	case !aOK && !bOK:
+		return 0
+	case !aOK:
+		return 1
+	case !bOK:
+		return -1
+	}
+
+	c := 0
+	switch x := aValue.(type) {
+	case float64:
+		y := bValue.(float64)
+		if x < y {
+			c = -1
+		} else if x > y {
+			c = 1
+		}
+	case string:
+		c = strings.Compare(strings.ToLower(x), strings.ToLower(bValue.(string)))
+	case [2]interface{}:
+		// A rank, then a name
+		y := bValue.([2]interface{})
+		if c = compareIssueValues(x[0], y[0]); c == 0 {
+			c = compareIssueValues(x[1], y[1])
+		}
+	}
+	if descending {
+		return -c
+	}
+	return c
+}
+
+// compareIssueValues compares two floats or two strings
+func compareIssueValues(a, b interface{}) int {
+	if x, ok := a.(float64); ok {
+		y := b.(float64)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a.(string)), strings.ToLower(b.(string)))
+}
+
+// issueSortValue returns the value an issue is sorted by: a float64, a
+// string, or a rank and a name; false when the issue has none
+func issueSortValue(issue *api.Issue, field string) (interface{}, bool) {
+	switch field {
+	case "priority":
+		// 1 (urgent) to 4 (low); 0 is no priority
+		return float64(issue.Priority), issue.Priority != 0
+	case "created":
+		return float64(issue.CreatedAt.UnixNano()), !issue.CreatedAt.IsZero()
+	case "updated":
+		return float64(issue.UpdatedAt.UnixNano()), !issue.UpdatedAt.IsZero()
+	case "due":
+		return optionalString(issue.DueDate), issue.DueDate != nil
+	case "sla":
+		// When the SLA breaches, soonest first
+		if issue.SLABreachesAt == nil {
+			return nil, false
+		}
+		return float64(issue.SLABreachesAt.UnixNano()), true
+	case "estimate":
+		if issue.Estimate == nil {
+			return nil, false
+		}
+		return *issue.Estimate, true
+	case "cycle":
+		if issue.Cycle == nil {
+			return nil, false
+		}
+		return float64(issue.Cycle.Number), true
+	case "state":
+		if issue.State == nil {
+			return nil, false
+		}
+		rank, ok := stateTypeOrder[issue.State.Type]
+		if !ok {
+			rank = len(stateTypeOrder)
+		}
+		return [2]interface{}{float64(rank), issue.State.Name}, true
+	case "identifier":
+		// By team, then number, so ENG-9 comes before ENG-10
+		team, number, _ := strings.Cut(issue.Identifier, "-")
+		n, _ := strconv.Atoi(number)
+		return [2]interface{}{team, float64(n)}, issue.Identifier != ""
+	}
+
+	for _, column := range issueColumns {
+		if column.Name == field {
+			value := column.Value(*issue)
+			return value, value != ""
+		}
+	}
+	return nil, false
+}
+
+// issueGroup is a group of --group-by
+type issueGroup struct {
+	Name   string
+	Issues []api.Issue
+}
+
+// groupIssues splits issues, already in group order (see issueOrder.apply),
+// into groups
+func groupIssues(issues []api.Issue, groupBy string) []issueGroup {
+	var groups []issueGroup
+	for _, issue := range issues {
+		name := issueGroupName(&issue, groupBy)
+		if len(groups) == 0 || groups[len(groups)-1].Name != name {
+			groups = append(groups, issueGroup{Name: name})
+		}
+		groups[len(groups)-1].Issues = append(groups[len(groups)-1].Issues, issue)
+	}
+	return groups
+}
+
+// issueGroupName names the group of an issue
+func issueGroupName(issue *api.Issue, groupBy string) string {
+	switch groupBy {
+	case "assignee":
+		if issue.Assignee == nil {
+			return "Unassigned"
+		}
+	case "priority":
+		if issue.Priority == 0 {
+			return "No priority"
+		}
+	case "cycle":
+		if issue.Cycle == nil {
+			return "No cycle"
+		}
+		if issue.Cycle.Name != "" {
+			return fmt.Sprintf("Cycle %d: %s", issue.Cycle.Number, issue.Cycle.Name)
+		}
+		return fmt.Sprintf("Cycle %d", issue.Cycle.Number)
+	}
+	if value, ok := issueSortValue(issue, groupBy); ok {
+		if pair, ok := value.([2]interface{}); ok {
+			return fmt.Sprint(pair[1])
+		}
+	}
+	for _, column := range issueColumns {
+		if column.Name == groupBy {
+			if value := column.Value(*issue); value != "" {
+				return value
+			}
+		}
+	}
+	return "No " + groupBy
+}
+
+// renderOrderedIssues sorts issues as ordered and prints them like
+// renderIssueList, as a table per group with --group-by
+func renderOrderedIssues(issues *api.Issues, order *issueOrder, fields []string, plaintext, jsonOut bool, emptyMessage, summaryLabel, plaintextTitle string) {
+	order.apply(issues)
+	if order.GroupBy == "" {
+		renderIssueList(issues, fields, plaintext, jsonOut, emptyMessage, summaryLabel, plaintextTitle)
+		return
+	}
+
+	groups := groupIssues(issues.Nodes, order.GroupBy)
+	if jsonOut {
+		type jsonGroup struct {
+			Group  string      `json:"group"`
+			Count  int         `json:"count"`
+			Issues interface{} `json:"issues"`
+		}
+		// JSON output is always an array, even when empty
+		items := make([]jsonGroup, len(groups))
+		for i, group := range groups {
+			items[i] = jsonGroup{Group: group.Name, Count: len(group.Issues), Issues: group.Issues}
+			if fields != nil {
+				items[i].Issues = issueFieldsJSON(group.Issues, fields)
+			}
+		}
+		output.JSON(items)
+		return
+	}
+
+	if len(issues.Nodes) == 0 {
+		output.Info(emptyMessage, plaintext, jsonOut)
+		return
+	}
+
+	table := func(nodes []api.Issue) output.TableData {
+		if fields == nil {
+			return issueTable(nodes)
+		}
+		return issueFieldsTable(nodes, fields)
+	}
+
+	if plaintext {
+		fmt.Println(plaintextTitle)
+		for _, group := range groups {
+			fmt.Printf("\n## %s (%d)\n\n", group.Name, len(group.Issues))
+			if fields != nil {
+				output.Table(table(group.Issues), true, false)
+				continue
+			}
+			for _, issue := range group.Issues {
+				printIssueMarkdown(issue, "###")
+			}
+		}
+		fmt.Printf("\nTotal: %d %s in %d groups\n", len(issues.Nodes), summaryLabel, len(groups))
+		return
+	}
+
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s %s\n",
+			color.New(color.Bold).Sprint(group.Name),
+			color.New(color.FgWhite, color.Faint).Sprintf("(%d)", len(group.Issues)))
+		output.Table(table(group.Issues), false, false)
+	}
+
+	fmt.Printf("\n%s %d %s in %d groups\n",
+		color.New(color.FgGreen).Sprint("✓"),
+		len(issues.Nodes),
+		summaryLabel,
+		len(groups))
+
+	if issues.PageInfo.HasNextPage {
+		fmt.Printf("%s Use --limit or --all to see more results\n",
+			color.New(color.FgYellow).Sprint("ℹ️"))
+	}
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var issueSubscribeCmd = &cobra.Command{
+	Use:     "subscribe ISSUE-ID [USER...]",
+	Aliases: []string{"sub"},
+	Short:   "Subscribe to an issue's notifications",
+	Long: `Subscribe yourself, or the given users (email, name, display name, or 'me'),
+to an issue's notifications.
+
+Examples:
+  linctl issue subscribe ENG-123
+  linctl issue subscribe ENG-123 jane@example.com bob@example.com
+  linctl issue unsubscribe ENG-123
+  linctl issue subscribers ENG-123`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runIssueSubscription(cmd, args, true)
+	},
+}
+
+var issueUnsubscribeCmd = &cobra.Command{
+	Use:     "unsubscribe ISSUE-ID [USER...]",
+	Aliases: []string{"unsub"},
+	Short:   "Unsubscribe from an issue's notifications",
+	Long:    `Unsubscribe yourself, or the given users, from an issue's notifications.`,
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runIssueSubscription(cmd, args, false)
+	},
+}
+
+var issueSubscribersCmd = &cobra.Command{
+	Use:   "subscribers ISSUE-ID",
+	Short: "List who is subscribed to an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		issue, err := client.GetIssueSubscribers(context.Background(), args[0])
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issue subscribers: %v", err), plaintext, jsonOut)
+		}
+		subscribers := []api.User{}
+		if issue.Subscribers != nil {
+			subscribers = issue.Subscribers.Nodes
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{
+				"issue":       issue.Identifier,
+				"subscribers": subscribers,
+			})
+			return
+		}
+		if len(subscribers) == 0 {
+			output.Info(fmt.Sprintf("No one is subscribed to %s", issue.Identifier), plaintext, jsonOut)
+			return
+		}
+
+		if plaintext {
+			fmt.Println("Name\tEmail\tYou")
+			for _, user := range subscribers {
+				fmt.Printf("%s\t%s\t%t\n", user.Name, user.Email, user.IsMe)
+			}
+			return
+		}
+		rows := make([][]string, len(subscribers))
+		for i, user := range subscribers {
+			name := user.Name
+			if user.IsMe {
+				name += color.New(color.FgWhite, color.Faint).Sprint(" (you)")
+			}
+			rows[i] = []string{name, user.Email}
+		}
+		output.Table(output.TableData{
+			Headers: []string{"Name", "Email"},
+			Rows:    rows,
+		}, plaintext, jsonOut)
+		fmt.Printf("\n%s %d subscribers to %s\n", color.New(color.FgGreen).Sprint("✓"), len(subscribers), issue.Identifier)
+	},
+}
+
+// runIssueSubscription subscribes or unsubscribes the users named after the
+// issue in args (you, when none are), and records the changes for undo
+func runIssueSubscription(cmd *cobra.Command, args []string, subscribe bool) {
+	plaintext := viper.GetBool("plaintext")
+	jsonOut := viper.GetBool("json")
+
+	client := authenticatedClient(plaintext, jsonOut)
+	ctx := context.Background()
+
+	issue, err := client.GetIssueSubscribers(ctx, args[0])
+	if err != nil {
+		exitWithError(err, fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
+	}
+	subscribed := make(map[string]bool)
+	if issue.Subscribers != nil {
+		for _, user := range issue.Subscribers.Nodes {
+			subscribed[user.ID] = true
+		}
+	}
+
+	refs := args[1:]
+	if len(refs) == 0 {
+		refs = []string{meShortcut}
+	}
+	verb, undoKind := "unsubscribed", undoIssueUnsubscribe
+	if subscribe {
+		verb, undoKind = "subscribed", undoIssueSubscribe
+	}
+
+	changed := []string{}
+	var unchanged, failures []string
+	var undoChanges []undoChange
+	for _, ref := range refs {
+		user, err := subscriptionUser(ctx, client, ref)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		if subscribed[user.ID] == subscribe {
+			unchanged = append(unchanged, user.Name)
+			continue
+		}
+
+		if subscribe {
+			err = client.SubscribeToIssue(ctx, issue.ID, user.ID)
+		} else {
+			err = client.UnsubscribeFromIssue(ctx, issue.ID, user.ID)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s %s: %v", issue.Identifier, user.Name, err))
+			continue
+		}
+		subscribed[user.ID] = subscribe
+		changed = append(changed, user.Name)
+		who := user.Name
+		if user.IsMe {
+			who = "you"
+		}
+		undoChanges = append(undoChanges, undoChange{
+			Kind:    undoKind,
+			Target:  issue.ID,
+			Label:   issue.Identifier,
+			Summary: fmt.Sprintf("%s %s", verb, who),
+			Restore: map[string]interface{}{"userId": user.ID},
+		})
+	}
+	recordUndo(cmd, args, undoChanges)
+
+	if jsonOut {
+		result := map[string]interface{}{
+			"issue": issue.Identifier,
+			verb:    changed,
+		}
+		if len(unchanged) > 0 {
+			result["unchanged"] = unchanged
+		}
+		if len(failures) > 0 {
+			result["errors"] = failures
+		}
+		output.JSON(result)
+	} else {
+		if len(changed) > 0 {
+			message := fmt.Sprintf("%s %s to %s", strings.Join(changed, ", "), verb, issue.Identifier)
+			if !subscribe {
+				message = fmt.Sprintf("%s %s from %s", strings.Join(changed, ", "), verb, issue.Identifier)
+			}
+			if plaintext {
+				fmt.Println(message)
+			} else {
+				fmt.Printf("%s %s\n", color.New(color.FgGreen).Sprint("✓"), message)
+			}
+		}
+		if len(unchanged) > 0 {
+			state := "Already subscribed to"
+			if !subscribe {
+				state = "Not subscribed to"
+			}
+			output.Info(fmt.Sprintf("%s %s: %s", state, issue.Identifier, strings.Join(unchanged, ", ")), plaintext, false)
+		}
+		for _, failure := range failures {
+			output.Error(failure, plaintext, false)
+		}
+	}
+
+	exitOnFailures(len(changed), len(failures))
+}
+
+// subscriptionUser looks up a user to subscribe or unsubscribe; 'me' is the
+// cached viewer, shown as "You"
+func subscriptionUser(ctx context.Context, client *api.Client, ref string) (*api.User, error) {
+	switch userShortcut(ref) {
+	case meShortcut:
+		viewer, err := auth.Viewer(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current user: %v", err)
+		}
+		return &api.User{ID: viewer.ID, Name: "You", Email: viewer.Email, IsMe: true}, nil
+	case noneShortcut, anyShortcut:
+		return nil, fmt.Errorf("'%s' is not a user", ref)
+	}
+	return resolveUser(ctx, client, ref)
+}
+
+func init() {
+	issueCmd.AddCommand(issueSubscribeCmd)
+	issueCmd.AddCommand(issueUnsubscribeCmd)
+	issueCmd.AddCommand(issueSubscribersCmd)
+}
@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// maxParentWalk bounds how far 'issue reparent' climbs when checking for cycles
+const maxParentWalk = 50
+
+// issueTreeNode is an issue and its sub-issues, with progress rolled up from
+// all of its descendants
+type issueTreeNode struct {
+	ID         string           `json:"id"`
+	Identifier string           `json:"identifier"`
+	Title      string           `json:"title"`
+	State      *api.State       `json:"state"`
+	Assignee   *api.User        `json:"assignee"`
+	Estimate   *float64         `json:"estimate"`
+	URL        string           `json:"url"`
+	Progress   issueProgress    `json:"progress"`
+	Children   []*issueTreeNode `json:"children"`
+	// Truncated is set when the node has sub-issues below --depth that were not fetched
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// issueProgress counts completed descendants; canceled ones are left out of
+// the total, as Linear does
+type issueProgress struct {
+	Completed int     `json:"completed"`
+	Total     int     `json:"total"`
+	Percent   float64 `json:"percent"`
+}
+
+var issueChildrenCmd = &cobra.Command{
+	Use:     "children ISSUE-ID",
+	Aliases: []string{"subissues", "sub-issues"},
+	Short:   "List an issue's sub-issues",
+	Long: `List an issue's sub-issues, or with --tree the whole hierarchy below it.
+
+Each issue with sub-issues shows how many of its descendants are completed.
+
+Examples:
+  linctl issue children ENG-1
+  linctl issue children ENG-1 --tree
+  linctl issue children ENG-1 --tree --depth 2 --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		tree, _ := cmd.Flags().GetBool("tree")
+		depth, _ := cmd.Flags().GetInt("depth")
+		if !tree {
+			depth = 1
+		}
+		if depth < 1 {
+			output.Error("--depth must be at least 1", plaintext, jsonOut)
+			exit(1)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+
+		root, err := buildIssueTree(context.Background(), client, args[0], depth)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get sub-issues: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(root)
+			return
+		}
+		if len(root.Children) == 0 {
+			output.Info(fmt.Sprintf("%s has no sub-issues", root.Identifier), plaintext, jsonOut)
+			return
+		}
+
+		if tree {
+			renderIssueTree(root, plaintext)
+			return
+		}
+
+		if plaintext {
+			fmt.Println("Identifier\tTitle\tState\tAssignee\tSub-issues")
+			for _, child := range root.Children {
+				fmt.Printf("%s\t%s\t%s\t%s\t%s\n", child.Identifier, child.Title, treeStateName(child), treeAssigneeName(child), treeProgressText(child))
+			}
+			fmt.Printf("\n%s\n", treeProgressText(root))
+			return
+		}
+
+		rows := make([][]string, len(root.Children))
+		for i, child := range root.Children {
+			rows[i] = []string{
+				color.New(color.FgCyan).Sprint(child.Identifier),
+				child.Title,
+				treeStateName(child),
+				treeAssigneeName(child),
+				treeProgressText(child),
+			}
+		}
+		output.Table(output.TableData{
+			Headers: []string{"Identifier", "Title", "State", "Assignee", "Sub-issues"},
+			Rows:    rows,
+		}, plaintext, jsonOut)
+		fmt.Printf("\n%s %s %s\n",
+			color.New(color.FgGreen).Sprint("✓"),
+			progressBar(root.Progress.Percent/100, 20),
+			treeProgressText(root))
+	},
+}
+
+var issueReparentCmd = &cobra.Command{
+	Use:   "reparent ISSUE-ID... --parent PARENT-ID",
+	Short: "Move issues under a new parent issue",
+	Long: `Make issues sub-issues of another issue, or top-level issues with --parent none.
+
+An issue cannot be moved under itself or one of its own sub-issues.
+
+Examples:
+  linctl issue reparent ENG-4 --parent ENG-1
+  linctl issue reparent ENG-4 ENG-5 --parent ENG-2
+  linctl issue reparent ENG-4 --parent none`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		parentRef, _ := cmd.Flags().GetString("parent")
+		if parentRef == "" {
+			output.Error("--parent is required (use 'none' to detach from the current parent)", plaintext, jsonOut)
+			exit(1)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		// The new parent and its ancestors; none of them may be moved under it
+		var parent *api.Issue
+		lineage := map[string]string{}
+		if !isNoneValue(parentRef) {
+			var err error
+			parent, err = client.GetIssueTreeNode(ctx, parentRef)
+			if err != nil {
+				output.Error(fmt.Sprintf("Parent issue not found: %s", parentRef), plaintext, jsonOut)
+				exit(1)
+			}
+			lineage, err = issueLineage(ctx, client, parent)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to check %s's parents: %v", parent.Identifier, err), plaintext, jsonOut)
+			}
+		}
+
+		moved := []string{}
+		var failures []string
+		for _, ref := range args {
+			child, err := client.GetIssueTreeNode(ctx, ref)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", ref, err))
+				continue
+			}
+			if _, ok := lineage[child.ID]; ok {
+				failures = append(failures, fmt.Sprintf("%s: cannot move an issue under itself or its own sub-issue %s", child.Identifier, parent.Identifier))
+				continue
+			}
+
+			input := map[string]interface{}{"parentId": nil}
+			if parent != nil {
+				input["parentId"] = parent.ID
+			}
+			if _, err := client.UpdateIssue(ctx, child.ID, input); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", child.Identifier, err))
+				continue
+			}
+			moved = append(moved, child.Identifier)
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"parent": nil,
+				"moved":  moved,
+			}
+			if parent != nil {
+				result["parent"] = parent.Identifier
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			for _, identifier := range moved {
+				message := fmt.Sprintf("%s is now a top-level issue", identifier)
+				if parent != nil {
+					message = fmt.Sprintf("%s is now a sub-issue of %s", identifier, parent.Identifier)
+				}
+				if plaintext {
+					fmt.Println(message)
+				} else {
+					fmt.Printf("%s %s\n", color.New(color.FgGreen).Sprint("✓"), message)
+				}
+			}
+			for _, failure := range failures {
+				output.Error(fmt.Sprintf("Failed to reparent %s", failure), plaintext, false)
+			}
+		}
+
+		exitOnFailures(len(moved), len(failures))
+	},
+}
+
+// buildIssueTree fetches an issue and its sub-issues down to maxDepth levels
+// and rolls progress up from the leaves
+func buildIssueTree(ctx context.Context, client *api.Client, id string, maxDepth int) (*issueTreeNode, error) {
+	issue, err := client.GetIssueTreeNode(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	node := newIssueTreeNode(*issue)
+	if issue.Children == nil {
+		return node, nil
+	}
+
+	children := issue.Children.Nodes
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].SubIssueSortOrder < children[j].SubIssueSortOrder
+	})
+
+	for _, child := range children {
+		hasChildren := child.Children != nil && len(child.Children.Nodes) > 0
+		var childNode *issueTreeNode
+		switch {
+		case hasChildren && maxDepth > 1:
+			childNode, err = buildIssueTree(ctx, client, child.ID, maxDepth-1)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			childNode = newIssueTreeNode(child)
+			childNode.Truncated = hasChildren
+		}
+		node.Children = append(node.Children, childNode)
+	}
+
+	node.rollUp()
+	return node, nil
+}
+
+func newIssueTreeNode(issue api.Issue) *issueTreeNode {
+	return &issueTreeNode{
+		ID:         issue.ID,
+		Identifier: issue.Identifier,
+		Title:      issue.Title,
+		State:      issue.State,
+		Assignee:   issue.Assignee,
+		Estimate:   issue.Estimate,
+		URL:        issue.URL,
+		Children:   []*issueTreeNode{},
+	}
+}
+
+// rollUp computes a node's progress from its children's states and their own rollups
+func (n *issueTreeNode) rollUp() {
+	progress := issueProgress{}
+	for _, child := range n.Children {
+		progress.Completed += child.Progress.Completed
+		progress.Total += child.Progress.Total
+		if child.State == nil || child.State.Type != "canceled" {
+			progress.Total++
+			if child.State != nil && child.State.Type == "completed" {
+				progress.Completed++
+			}
+		}
+	}
+	if progress.Total > 0 {
+		progress.Percent = float64(progress.Completed) / float64(progress.Total) * 100
+	}
+	n.Progress = progress
+}
+
+// renderIssueTree draws the hierarchy with box-drawing branches
+func renderIssueTree(root *issueTreeNode, plaintext bool) {
+	fmt.Println(treeNodeLine(root, plaintext))
+
+	var walk func(nodes []*issueTreeNode, prefix string)
+	walk = func(nodes []*issueTreeNode, prefix string) {
+		for i, node := range nodes {
+			branch, indent := "├── ", "│   "
+			if i == len(nodes)-1 {
+				branch, indent = "└── ", "    "
+			}
+			fmt.Printf("%s%s%s\n", prefix, branch, treeNodeLine(node, plaintext))
+			walk(node.Children, prefix+indent)
+		}
+	}
+	walk(root.Children, "")
+}
+
+// treeNodeLine is one issue in the tree: identifier, title, state, and rollup
+func treeNodeLine(node *issueTreeNode, plaintext bool) string {
+	rollup := ""
+	if node.Progress.Total > 0 || node.Truncated {
+		rollup = "  " + treeProgressText(node)
+	}
+
+	if plaintext {
+		return fmt.Sprintf("%s %s [%s]%s", node.Identifier, node.Title, treeStateName(node), rollup)
+	}
+
+	state := treeStateName(node)
+	if node.State != nil {
+		state = stateTypeColor(node.State.Type, node.State.Color).Sprint(state)
+	}
+	if node.Progress.Total > 0 {
+		rollup = "  " + progressBar(node.Progress.Percent/100, 10) + rollup
+	}
+	return fmt.Sprintf("%s %s %s%s",
+		color.New(color.FgCyan).Sprint(node.Identifier),
+		truncateString(node.Title, 60),
+		color.New(color.FgWhite, color.Faint).Sprint("[")+state+color.New(color.FgWhite, color.Faint).Sprint("]"),
+		rollup)
+}
+
+// treeProgressText summarizes a node's rollup, e.g. "3/5 done (60%)"
+func treeProgressText(node *issueTreeNode) string {
+	switch {
+	case node.Truncated:
+		return "has sub-issues (see --tree)"
+	case node.Progress.Total == 0:
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d done (%.0f%%)", node.Progress.Completed, node.Progress.Total, node.Progress.Percent)
+}
+
+func treeStateName(node *issueTreeNode) string {
+	if node.State == nil {
+		return "-"
+	}
+	return node.State.Name
+}
+
+func treeAssigneeName(node *issueTreeNode) string {
+	if node.Assignee == nil {
+		return "Unassigned"
+	}
+	return node.Assignee.Name
+}
+
+// issueLineage returns the IDs of an issue and all of its ancestors
+func issueLineage(ctx context.Context, client *api.Client, issue *api.Issue) (map[string]string, error) {
+	lineage := map[string]string{issue.ID: issue.Identifier}
+	current := issue
+	for i := 0; current.Parent != nil && i < maxParentWalk; i++ {
+		if _, seen := lineage[current.Parent.ID]; seen {
+			break
+		}
+		lineage[current.Parent.ID] = current.Parent.Identifier
+		next, err := client.GetIssueTreeNode(ctx, current.Parent.ID)
+		if err != nil {
+			return nil, err
+		}
+		current = next
+	}
+	return lineage, nil
+}
+
+// isNoneValue reports whether a flag value asks to clear a field
+func isNoneValue(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "none", "unassigned", noneShortcut:
+		return true
+	}
+	return false
+}
+
+// issueFlagAlias lets --parent stand in for --parent-issue and --due for --due-date
+func issueFlagAlias(f *pflag.FlagSet, name string) pflag.NormalizedName {
+	switch name {
+	case "parent":
+		name = "parent-issue"
+	case "due":
+		name = "due-date"
+	}
+	return pflag.NormalizedName(name)
+}
+
+func init() {
+	issueCmd.AddCommand(issueChildrenCmd)
+	issueCmd.AddCommand(issueReparentCmd)
+
+	issueChildrenCmd.Flags().Bool("tree", false, "Show the whole sub-issue hierarchy")
+	issueChildrenCmd.Flags().Int("depth", 5, "Levels of sub-issues to fetch with --tree")
+
+	// 'issue create --parent ENG-1' reads the same as 'issue reparent --parent ENG-1'
+	issueCreateCmd.Flags().SetNormalizeFunc(issueFlagAlias)
+	issueUpdateCmd.Flags().SetNormalizeFunc(issueFlagAlias)
+
+	issueReparentCmd.Flags().String("parent", "", "New parent issue ID/identifier, or 'none' to make top-level issues")
+	markIssueRefFlags(issueReparentCmd.Flags(), "parent")
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var issueViewCmd = &cobra.Command{
+	Use:     "view ISSUE-ID",
+	Aliases: []string{"read"},
+	Short:   "Read an issue with its description rendered for the terminal",
+	Long: `Show an issue's details and its description rendered as formatted markdown
+(headings, lists, code blocks, links), optionally followed by its comments.
+
+Examples:
+  linctl issue view ENG-123
+  linctl issue view ENG-123 --comments
+  linctl issue view ENG-123 --raw | less
+  linctl issue view --current --web`,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		issueID, err := issueFromArgs(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		issue, err := client.GetIssue(ctx, issueID)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to fetch issue: %v", err), plaintext, jsonOut)
+		}
+
+		if web, _ := cmd.Flags().GetBool("web"); web {
+			if err := utils.OpenBrowser(issue.URL); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to open browser: %v (URL: %s)", err, issue.URL), plaintext, jsonOut)
+			}
+			if jsonOut {
+				output.JSON(map[string]interface{}{"url": issue.URL, "opened": true})
+			} else {
+				fmt.Println(issue.URL)
+			}
+			return
+		}
+
+		var comments []api.Comment
+		if withComments, _ := cmd.Flags().GetBool("comments"); withComments {
+			comments, _, err = api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize}, func(ctx context.Context, first int, after string) ([]api.Comment, api.PageInfo, error) {
+				page, err := client.GetIssueComments(ctx, issue.ID, first, after, "createdAt")
+				if err != nil {
+					return nil, api.PageInfo{}, err
+				}
+				return page.Nodes, page.PageInfo, nil
+			})
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to get comments: %v", err), plaintext, jsonOut)
+			}
+			issue.Comments = &api.Comments{Nodes: comments}
+		}
+
+		if jsonOut {
+			output.JSON(issue)
+			return
+		}
+
+		// Plaintext output is the markdown itself
+		if raw, _ := cmd.Flags().GetBool("raw"); raw || plaintext {
+			fmt.Print(renderIssueMarkdown(issue, comments))
+			return
+		}
+
+		fmt.Printf("%s %s\n\n",
+			color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
+			color.New(color.FgWhite, color.Bold).Sprint(issue.Title))
+
+		label := color.New(color.FgWhite, color.Faint)
+		field := func(name, value string) {
+			if value != "" {
+				fmt.Printf("  %s %s\n", label.Sprintf("%-9s", name), value)
+			}
+		}
+		if issue.State != nil {
+			field("State", stateTypeColor(issue.State.Type, issue.State.Color).Sprint(issue.State.Name))
+		}
+		assignee := color.New(color.FgYellow).Sprint("Unassigned")
+		if issue.Assignee != nil {
+			assignee = issue.Assignee.Name
+		}
+		field("Assignee", assignee)
+		field("Priority", output.PriorityColor(issue.Priority).Sprint(priorityToString(issue.Priority)))
+		if issue.Estimate != nil {
+			field("Estimate", fmt.Sprintf("%g", *issue.Estimate))
+		}
+		if issue.Labels != nil && len(issue.Labels.Nodes) > 0 {
+			names := make([]string, len(issue.Labels.Nodes))
+			for i, l := range issue.Labels.Nodes {
+				names[i] = l.Name
+			}
+			field("Labels", strings.Join(names, ", "))
+		}
+		if issue.Project != nil {
+			field("Project", issue.Project.Name)
+		}
+		if issue.ProjectMilestone != nil {
+			field("Milestone", issue.ProjectMilestone.Name)
+		}
+		if issue.Cycle != nil {
+			field("Cycle", fmt.Sprintf("%d", issue.Cycle.Number))
+		}
+		if issue.DueDate != nil {
+			field("Due", issueDueColor(issue).Sprint(*issue.DueDate))
+		}
+		if issue.Parent != nil {
+			field("Parent", issue.Parent.Identifier+" "+issue.Parent.Title)
+		}
+		field("URL", color.New(color.FgBlue, color.Underline).Sprint(issue.URL))
+
+		fmt.Println()
+		if strings.TrimSpace(issue.Description) == "" {
+			fmt.Println(label.Sprint("No description"))
+		} else {
+			fmt.Print(output.RenderMarkdown(issue.Description))
+		}
+
+		if comments != nil {
+			fmt.Printf("\n%s\n", color.New(color.Bold).Sprintf("Comments (%d)", len(comments)))
+			for _, comment := range comments {
+				author := "Unknown"
+				if comment.User != nil {
+					author = comment.User.Name
+				}
+				fmt.Printf("\n%s %s\n",
+					color.New(color.FgCyan).Sprint(author),
+					label.Sprint("· "+formatTimeAgo(comment.CreatedAt)))
+				for _, line := range strings.Split(strings.TrimRight(output.RenderMarkdown(comment.Body), "\n"), "\n") {
+					fmt.Printf("  %s\n", line)
+				}
+				if summary := reactionSummary(comment.Reactions); summary != "" {
+					fmt.Printf("  %s\n", summary)
+				}
+			}
+		}
+	},
+}
+
+func init() {
+	issueCmd.AddCommand(issueViewCmd)
+
+	issueViewCmd.Args = issueArg
+	pickable("issue", issueViewCmd)
+	issueViewCmd.Flags().Bool("current", false, "Use the issue named by the current git branch (see 'issue current')")
+	issueViewCmd.Flags().Bool("comments", false, "Also show the issue's comments")
+	issueViewCmd.Flags().Bool("raw", false, "Print the markdown source instead of rendering it")
+	issueViewCmd.Flags().Bool("web", false, "Open the issue in the browser instead")
+}
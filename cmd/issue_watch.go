@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/slack"
+	"github.com/fatih/color"
+)
+
+// minWatchInterval keeps --watch from hammering the API
+const minWatchInterval = 5 * time.Second
+
+// issueWatchEvent describes how an issue changed between two polls
+type issueWatchEvent struct {
+	// Type is snapshot (first poll, JSON only), new, changed, closed, or removed
+	Type    string    `json:"type"`
+	Time    time.Time `json:"time"`
+	Issue   api.Issue `json:"issue"`
+	Changes []string  `json:"changes,omitempty"`
+}
+
+// watchIssues polls fetch every interval until interrupted and prints the
+// issues that appeared, changed, closed, or dropped out of the result set.
+// With a webhook URL, each poll's changes are also posted to Slack.
+func watchIssues(client *api.Client, interval time.Duration, fetch func() (*api.Issues, error), webhookURL string, plaintext, jsonOut bool) error {
+	if interval < minWatchInterval {
+		return fmt.Errorf("watch interval must be at least %s", minWatchInterval)
+	}
+
+	// Every poll must reach Linear, or changes would hide behind the cache
+	client.SetResponseCache(nil)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	issues, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		now := time.Now()
+		events := make([]issueWatchEvent, len(issues.Nodes))
+		for i, issue := range issues.Nodes {
+			events[i] = issueWatchEvent{Type: "snapshot", Time: now, Issue: issue}
+		}
+		output.JSONLines(events)
+	} else {
+		renderIssueCollection(issues, plaintext, jsonOut, "No issues found", "issues", "# Issues")
+		message := fmt.Sprintf("Watching for changes every %s (Ctrl+C to stop)", interval)
+		if plaintext {
+			fmt.Println(message)
+		} else {
+			fmt.Printf("\n%s\n", color.New(color.FgWhite, color.Faint).Sprint(message))
+		}
+	}
+
+	previous := issueSnapshot(issues.Nodes)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		issues, err := fetch()
+		if err != nil {
+			// Keep watching through transient failures
+			fmt.Fprintf(os.Stderr, "%s Failed to fetch issues: %v\n", time.Now().Format("15:04:05"), err)
+			continue
+		}
+
+		current := issueSnapshot(issues.Nodes)
+		events := diffIssueSnapshots(ctx, client, previous, current, issues.Nodes)
+		previous = current
+
+		if jsonOut {
+			output.JSONLines(events)
+		} else {
+			renderWatchEvents(events, plaintext)
+		}
+
+		if webhookURL != "" && len(events) > 0 {
+			// A message Slack turns down shouldn't end the watch
+			if err := slack.Post(ctx, webhookURL, watchEventsMessage(events)); err != nil && ctx.Err() == nil {
+				fmt.Fprintf(os.Stderr, "%s Failed to post to Slack: %v\n", time.Now().Format("15:04:05"), err)
+			}
+		}
+	}
+}
+
+// issueSnapshot indexes issues by ID
+func issueSnapshot(issues []api.Issue) map[string]api.Issue {
+	snapshot := make(map[string]api.Issue, len(issues))
+	for _, issue := range issues {
+		snapshot[issue.ID] = issue
+	}
+	return snapshot
+}
+
+// diffIssueSnapshots compares two polls. Issues that left the result set are
+// looked up to tell closed issues apart from ones that no longer match the filter.
+func diffIssueSnapshots(ctx context.Context, client *api.Client, previous, current map[string]api.Issue, order []api.Issue) []issueWatchEvent {
+	now := time.Now()
+	var events []issueWatchEvent
+
+	for _, issue := range order {
+		before, ok := previous[issue.ID]
+		if !ok {
+			events = append(events, issueWatchEvent{Type: "new", Time: now, Issue: issue})
+			continue
+		}
+		if !issue.UpdatedAt.After(before.UpdatedAt) {
+			continue
+		}
+		changes := issueChanges(before, issue)
+		eventType := "changed"
+		if issue.State != nil && (issue.State.Type == "completed" || issue.State.Type == "canceled") &&
+			(before.State == nil || before.State.Type != issue.State.Type) {
+			eventType = "closed"
+		}
+		events = append(events, issueWatchEvent{Type: eventType, Time: now, Issue: issue, Changes: changes})
+	}
+
+	var gone []api.Issue
+	for id, issue := range previous {
+		if _, ok := current[id]; !ok {
+			gone = append(gone, issue)
+		}
+	}
+	sort.Slice(gone, func(i, j int) bool { return gone[i].Identifier < gone[j].Identifier })
+
+	for _, issue := range gone {
+		event := issueWatchEvent{Type: "removed", Time: now, Issue: issue}
+		if latest, err := client.GetIssue(ctx, issue.ID); err == nil {
+			event.Changes = issueChanges(issue, *latest)
+			event.Issue = *latest
+			if latest.State != nil && (latest.State.Type == "completed" || latest.State.Type == "canceled") {
+				event.Type = "closed"
+			}
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// issueChanges lists the user-visible fields that differ between two versions of an issue
+func issueChanges(before, after api.Issue) []string {
+	var changes []string
+	change := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", field, from, to))
+		}
+	}
+
+	stateName := func(issue api.Issue) string {
+		if issue.State == nil {
+			return "-"
+		}
+		return issue.State.Name
+	}
+	assigneeName := func(issue api.Issue) string {
+		if issue.Assignee == nil {
+			return "Unassigned"
+		}
+		return issue.Assignee.Name
+	}
+	labelNames := func(issue api.Issue) string {
+		if issue.Labels == nil || len(issue.Labels.Nodes) == 0 {
+			return "-"
+		}
+		names := make([]string, len(issue.Labels.Nodes))
+		for i, label := range issue.Labels.Nodes {
+			names[i] = label.Name
+		}
+		sort.Strings(names)
+		return strings.Join(names, ", ")
+	}
+
+	change("state", stateName(before), stateName(after))
+	change("assignee", assigneeName(before), assigneeName(after))
+	change("priority", priorityToString(before.Priority), priorityToString(after.Priority))
+	change("labels", labelNames(before), labelNames(after))
+	if before.Title != after.Title {
+		changes = append(changes, "title")
+	}
+	if before.Description != after.Description {
+		changes = append(changes, "description")
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "updated")
+	}
+	return changes
+}
+
+// renderWatchEvents prints the changes found by one poll
+func renderWatchEvents(events []issueWatchEvent, plaintext bool) {
+	if len(events) == 0 {
+		return
+	}
+
+	timestamp := events[0].Time.Format("15:04:05")
+	if plaintext {
+		fmt.Printf("\n[%s]\n", timestamp)
+	} else {
+		fmt.Printf("\n%s\n", color.New(color.FgWhite, color.Faint).Sprintf("── %s ──", timestamp))
+	}
+
+	for _, event := range events {
+		detail := strings.Join(event.Changes, "; ")
+		switch event.Type {
+		case "new":
+			state := ""
+			if event.Issue.State != nil {
+				state = event.Issue.State.Name
+			}
+			detail = state
+		case "removed":
+			detail = "no longer matches the filter"
+		}
+
+		label := strings.ToUpper(event.Type)
+		if plaintext {
+			fmt.Printf("%-8s %s\t%s\t%s\n", label, event.Issue.Identifier, event.Issue.Title, detail)
+			continue
+		}
+
+		var marker *color.Color
+		symbol := "~"
+		switch event.Type {
+		case "new":
+			marker, symbol = color.New(color.FgGreen, color.Bold), "+"
+		case "closed":
+			marker, symbol = color.New(color.FgBlue, color.Bold), "✓"
+		case "removed":
+			marker, symbol = color.New(color.FgWhite, color.Faint), "-"
+		default:
+			marker = color.New(color.FgYellow, color.Bold)
+		}
+		fmt.Printf("%s %s %s %s\n",
+			marker.Sprintf("%s %-8s", symbol, label),
+			color.New(color.FgCyan).Sprintf("%-10s", event.Issue.Identifier),
+			truncateString(event.Issue.Title, 50),
+			color.New(color.FgWhite, color.Faint).Sprint(detail))
+	}
+}
+
+// watchEventsMessage is the Slack message for one poll's changes: a section
+// per issue with what happened to it
+func watchEventsMessage(events []issueWatchEvent) slack.Message {
+	message := slack.Message{Text: fmt.Sprintf("%d issue %s", len(events), pluralize("change", "changes", len(events)))}
+	for _, event := range events {
+		detail := slack.Escape(strings.Join(event.Changes, "; "))
+		symbol := "✏️"
+		switch event.Type {
+		case "new":
+			symbol, detail = "🆕", ""
+			if event.Issue.State != nil {
+				detail = slack.Escape(event.Issue.State.Name)
+			}
+		case "closed":
+			symbol = "✅"
+		case "removed":
+			symbol, detail = "➖", "no longer matches the filter"
+		}
+		text := fmt.Sprintf("%s *%s* %s %s", symbol, strings.ToUpper(event.Type[:1])+event.Type[1:],
+			slack.Link(event.Issue.URL, event.Issue.Identifier), slack.Escape(event.Issue.Title))
+		if detail != "" {
+			text += "\n" + detail
+		}
+		message.Blocks = append(message.Blocks, slack.Section(text))
+	}
+	message.Blocks = append(message.Blocks, slack.Context(events[0].Time.Format("15:04:05")+" · `linctl issue list --watch`"))
+	return message
+}
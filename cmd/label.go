@@ -0,0 +1,590 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	// uuidPattern matches Linear's UUID identifiers
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	// labelColorPattern matches hex colors with or without the leading #
+	labelColorPattern = regexp.MustCompile(`^#?[0-9a-fA-F]{6}$`)
+)
+
+// labelCmd represents the label command
+var labelCmd = &cobra.Command{
+	Use:     "label",
+	Aliases: []string{"labels"},
+	Short:   "Manage issue labels",
+	Long: `Manage workspace and team issue labels.
+
+Labels can be referenced by ID, by name, or as "Group/Name" for labels inside a
+label group. When a name exists in several teams, --team picks which one.
+
+Examples:
+  linctl label list --team ENG
+  linctl label create "Tech Debt" --team ENG --color "#f2994a"
+  linctl label update "Tech Debt" --name "Tech debt"
+  linctl label merge "Bugfix" "Bug" --dry-run
+  linctl label delete "Obsolete"`,
+}
+
+var labelListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List labels",
+	Long:    `List workspace labels, plus the labels of --team when given (or --all for every team).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		teamKey, _ := cmd.Flags().GetString("team")
+		all, _ := cmd.Flags().GetBool("all")
+
+		client := authenticatedClient(plaintext, jsonOut)
+
+		var filter map[string]interface{}
+		if !all {
+			scopes := []interface{}{
+				map[string]interface{}{"team": map[string]interface{}{"null": true}},
+			}
+			if teamKey != "" {
+				scopes = append(scopes, map[string]interface{}{
+					"team": map[string]interface{}{"key": map[string]interface{}{"eqIgnoreCase": teamKey}},
+				})
+			}
+			filter = map[string]interface{}{"or": scopes}
+		}
+
+		labels, err := client.GetLabels(context.Background(), filter)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list labels: %v", err), plaintext, jsonOut)
+		}
+		sort.Slice(labels, func(i, j int) bool {
+			if labelScope(labels[i]) != labelScope(labels[j]) {
+				return labelScope(labels[i]) < labelScope(labels[j])
+			}
+			return strings.ToLower(labelPath(labels[i])) < strings.ToLower(labelPath(labels[j]))
+		})
+
+		if jsonOut {
+			output.JSON(labels)
+			return
+		}
+		if len(labels) == 0 {
+			output.Info("No labels found", plaintext, jsonOut)
+			return
+		}
+
+		if plaintext {
+			fmt.Println("Name\tScope\tColor\tDescription\tID")
+			for _, label := range labels {
+				fmt.Printf("%s\t%s\t%s\t%s\t%s\n", labelPath(label), labelScope(label), label.Color, optionalString(label.Description), label.ID)
+			}
+			return
+		}
+
+		rows := make([][]string, len(labels))
+		for i, label := range labels {
+			rows[i] = []string{
+				labelPath(label),
+				labelScope(label),
+				label.Color,
+				optionalString(label.Description),
+				label.ID,
+			}
+		}
+		output.Table(output.TableData{
+			Headers: []string{"Name", "Scope", "Color", "Description", "ID"},
+			Rows:    rows,
+		}, plaintext, jsonOut)
+		fmt.Printf("\n%s %d labels\n", color.New(color.FgGreen).Sprint("✓"), len(labels))
+	},
+}
+
+var labelCreateCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Create a label",
+	Long: `Create a label in a team, or in the workspace with --workspace.
+
+Examples:
+  linctl label create "Tech Debt" --team ENG --color "#f2994a"
+  linctl label create "Customer" --workspace --description "Reported by a customer"
+  linctl label create "iOS" --team ENG --parent "Platform"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		teamKey, _ := cmd.Flags().GetString("team")
+		workspace, _ := cmd.Flags().GetBool("workspace")
+		if workspace {
+			teamKey = ""
+		} else if teamKey == "" {
+			output.Error("A team is required: use --team TEAM-KEY, or --workspace for a workspace label", plaintext, jsonOut)
+			exit(1)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		input, err := labelInputFromFlags(ctx, client, cmd, teamKey)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		input["name"] = args[0]
+		if teamKey != "" {
+			team, err := client.GetTeam(ctx, teamKey)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to find team '%s': %v", teamKey, err), plaintext, jsonOut)
+			}
+			input["teamId"] = team.ID
+		}
+
+		label, err := client.CreateLabel(ctx, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create label: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(label)
+		} else if plaintext {
+			fmt.Printf("Created label %s (%s)\n", labelPath(*label), label.ID)
+		} else {
+			fmt.Printf("%s Created label %s in %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(labelPath(*label)),
+				labelScope(*label))
+		}
+	},
+}
+
+var labelUpdateCmd = &cobra.Command{
+	Use:   "update LABEL",
+	Short: "Update a label",
+	Long: `Update a label's name, color, description, or group.
+
+Examples:
+  linctl label update "Tech Debt" --name "Tech debt" --color "#eb5757"
+  linctl label update "iOS" --team ENG --parent none`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		teamKey, _ := cmd.Flags().GetString("team")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		label, err := resolveLabel(ctx, client, teamKey, args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		labelTeam := ""
+		if label.Team != nil {
+			labelTeam = label.Team.Key
+		}
+		input, err := labelInputFromFlags(ctx, client, cmd, labelTeam)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if cmd.Flags().Changed("name") {
+			name, _ := cmd.Flags().GetString("name")
+			input["name"] = name
+		}
+		if len(input) == 0 {
+			output.Error("No updates specified. Use flags to specify what to update.", plaintext, jsonOut)
+			exit(1)
+		}
+
+		updated, err := client.UpdateLabel(ctx, label.ID, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to update label: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(updated)
+		} else if plaintext {
+			fmt.Printf("Updated label %s (%s)\n", labelPath(*updated), updated.ID)
+		} else {
+			fmt.Printf("%s Updated label %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(labelPath(*updated)))
+		}
+	},
+}
+
+var labelDeleteCmd = &cobra.Command{
+	Use:     "delete LABEL...",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Delete labels",
+	Long:    `Delete labels. Deleting a label removes it from every issue that has it.`,
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		teamKey, _ := cmd.Flags().GetString("team")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		deleted := []string{}
+		var failures []string
+		for _, ref := range args {
+			label, err := resolveLabel(ctx, client, teamKey, ref)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", ref, err))
+				continue
+			}
+			if err := client.DeleteLabel(ctx, label.ID); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", ref, err))
+				continue
+			}
+			deleted = append(deleted, labelPath(*label))
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"deleted": deleted,
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			for _, name := range deleted {
+				if plaintext {
+					fmt.Printf("Deleted label %s\n", name)
+				} else {
+					fmt.Printf("%s Deleted label %s\n", color.New(color.FgGreen).Sprint("✓"), name)
+				}
+			}
+			for _, failure := range failures {
+				output.Error(fmt.Sprintf("Failed to delete label %s", failure), plaintext, false)
+			}
+		}
+
+		exitOnFailures(len(deleted), len(failures))
+	},
+}
+
+// labelMergeResult reports what 'label merge' did (or would do with --dry-run)
+type labelMergeResult struct {
+	From         api.Label `json:"from"`
+	Into         api.Label `json:"into"`
+	DryRun       bool      `json:"dryRun"`
+	Issues       []string  `json:"issues"`
+	Relabeled    []string  `json:"relabeled"`
+	Errors       []string  `json:"errors,omitempty"`
+	DeletedLabel bool      `json:"deletedLabel"`
+	// KeptReason explains why the old label was not deleted
+	KeptReason string `json:"keptReason,omitempty"`
+}
+
+var labelMergeCmd = &cobra.Command{
+	Use:   "merge OLD NEW",
+	Short: "Move issues from one label to another and delete the old label",
+	Long: `Relabel every issue that has OLD so it has NEW instead, then delete OLD.
+
+With --team only that team's issues are relabeled; a workspace label is then
+kept, since other teams may still use it. Use --dry-run to list the affected
+issues without changing anything, and --keep to leave OLD in place.
+
+Examples:
+  linctl label merge "Bugfix" "Bug" --dry-run
+  linctl label merge "Bugfix" "Bug"
+  linctl label merge "UI" "Frontend" --team ENG --keep`,
+	Args: cobra.ExactArgs(2),
+	Annotations: map[string]string{
+		// Merges span the workspace unless a team is asked for explicitly
+		noDefaultTeamAnnotation: "true",
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		teamKey, _ := cmd.Flags().GetString("team")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		keep, _ := cmd.Flags().GetBool("keep")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		from, err := resolveLabel(ctx, client, teamKey, args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		into, err := resolveLabel(ctx, client, teamKey, args[1])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if from.ID == into.ID {
+			output.Error("Cannot merge a label into itself", plaintext, jsonOut)
+			exit(1)
+		}
+
+		filter := map[string]interface{}{
+			"labels": map[string]interface{}{"id": map[string]interface{}{"eq": from.ID}},
+		}
+		if teamKey != "" {
+			filter["team"] = map[string]interface{}{"key": map[string]interface{}{"eqIgnoreCase": teamKey}}
+		}
+		issues, _, err := api.Paginate(ctx, api.PaginateOptions{}, func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+			page, err := client.GetIssues(ctx, filter, first, after, "")
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to find issues labeled %s: %v", labelPath(*from), err), plaintext, jsonOut)
+		}
+
+		result := labelMergeResult{
+			From:      *from,
+			Into:      *into,
+			DryRun:    dryRun,
+			Issues:    make([]string, len(issues)),
+			Relabeled: []string{},
+		}
+		for i, issue := range issues {
+			result.Issues[i] = issue.Identifier
+		}
+
+		switch {
+		case keep:
+			result.KeptReason = "--keep was given"
+		case teamKey != "" && (from.Team == nil || !strings.EqualFold(from.Team.Key, teamKey)):
+			result.KeptReason = fmt.Sprintf("%s is used outside team %s", labelPath(*from), teamKey)
+		}
+
+		if !dryRun {
+			for _, issue := range issues {
+				input := map[string]interface{}{"labelIds": mergedLabelIDs(issue, from.ID, into.ID)}
+				if _, err := client.UpdateIssue(ctx, issue.ID, input); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", issue.Identifier, err))
+					continue
+				}
+				result.Relabeled = append(result.Relabeled, issue.Identifier)
+			}
+
+			switch {
+			case result.KeptReason != "":
+			case len(result.Errors) > 0:
+				result.KeptReason = "some issues could not be relabeled"
+			default:
+				if err := client.DeleteLabel(ctx, from.ID); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("delete %s: %v", labelPath(*from), err))
+				} else {
+					result.DeletedLabel = true
+				}
+			}
+		}
+
+		if jsonOut {
+			output.JSON(result)
+		} else {
+			renderLabelMerge(result, plaintext)
+		}
+
+		if len(result.Errors) > 0 {
+			exit(1)
+		}
+	},
+}
+
+// renderLabelMerge prints the plan or outcome of a label merge
+func renderLabelMerge(result labelMergeResult, plaintext bool) {
+	from, into := labelPath(result.From), labelPath(result.Into)
+
+	if plaintext {
+		verb := "Relabeled"
+		if result.DryRun {
+			verb = "Would relabel"
+		}
+		fmt.Printf("%s %d issues from %s to %s\n", verb, len(result.Issues), from, into)
+		for _, identifier := range result.Issues {
+			fmt.Printf("  %s\n", identifier)
+		}
+	} else {
+		verb := "Relabeling"
+		if result.DryRun {
+			verb = "Would relabel"
+		}
+		fmt.Printf("%s %d issues from %s to %s\n",
+			verb,
+			len(result.Issues),
+			color.New(color.FgRed).Sprint(from),
+			color.New(color.FgGreen).Sprint(into))
+		for _, identifier := range result.Issues {
+			fmt.Printf("  %s\n", color.New(color.FgCyan).Sprint(identifier))
+		}
+	}
+
+	for _, failure := range result.Errors {
+		output.Error(fmt.Sprintf("Failed: %s", failure), plaintext, false)
+	}
+
+	switch {
+	case result.DryRun && result.KeptReason == "":
+		output.Info(fmt.Sprintf("Dry run: %s would then be deleted", from), plaintext, false)
+	case result.DryRun:
+		output.Info(fmt.Sprintf("Dry run: %s would be kept (%s)", from, result.KeptReason), plaintext, false)
+	case result.DeletedLabel:
+		output.Success(fmt.Sprintf("Merged %s into %s and deleted %s", from, into, from), plaintext, false)
+	case result.KeptReason != "":
+		output.Info(fmt.Sprintf("Kept %s: %s", from, result.KeptReason), plaintext, false)
+	}
+}
+
+// mergedLabelIDs swaps fromID for intoID in an issue's labels
+func mergedLabelIDs(issue api.Issue, fromID, intoID string) []string {
+	ids := []string{intoID}
+	if issue.Labels == nil {
+		return ids
+	}
+	for _, label := range issue.Labels.Nodes {
+		if label.ID != fromID && label.ID != intoID {
+			ids = append(ids, label.ID)
+		}
+	}
+	return ids
+}
+
+// resolveLabel finds a label by ID, name, or "Group/Name". Labels of teamKey
+// win over workspace labels, which win over other teams' labels.
+func resolveLabel(ctx context.Context, client *api.Client, teamKey, ref string) (*api.Label, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("label name is empty")
+	}
+
+	var filter map[string]interface{}
+	if uuidPattern.MatchString(ref) {
+		filter = map[string]interface{}{"id": map[string]interface{}{"eq": ref}}
+	} else {
+		name, parent := ref, ""
+		if i := strings.LastIndex(ref, "/"); i > 0 && i < len(ref)-1 {
+			parent, name = ref[:i], ref[i+1:]
+		}
+		filter = map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": name}}
+		if parent != "" {
+			filter["parent"] = map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": parent}}
+		}
+	}
+
+	labels, err := client.GetLabels(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up label '%s': %w", ref, err)
+	}
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("label '%s' not found", ref)
+	}
+	if len(labels) == 1 {
+		return &labels[0], nil
+	}
+
+	var teamMatches, workspaceMatches []api.Label
+	for _, label := range labels {
+		switch {
+		case label.Team == nil:
+			workspaceMatches = append(workspaceMatches, label)
+		case teamKey != "" && strings.EqualFold(label.Team.Key, teamKey):
+			teamMatches = append(teamMatches, label)
+		}
+	}
+	for _, matches := range [][]api.Label{teamMatches, workspaceMatches} {
+		if len(matches) == 1 {
+			return &matches[0], nil
+		}
+	}
+
+	scopes := make([]string, len(labels))
+	for i, label := range labels {
+		scopes[i] = fmt.Sprintf("%s (%s)", labelPath(label), labelScope(label))
+	}
+	return nil, fmt.Errorf("label '%s' is ambiguous: %s; use --team or the label ID", ref, strings.Join(scopes, ", "))
+}
+
+// labelInputFromFlags builds the color, description, and parent fields shared
+// by 'label create' and 'label update'
+func labelInputFromFlags(ctx context.Context, client *api.Client, cmd *cobra.Command, teamKey string) (map[string]interface{}, error) {
+	input := map[string]interface{}{}
+
+	if cmd.Flags().Changed("color") {
+		value, _ := cmd.Flags().GetString("color")
+		if !labelColorPattern.MatchString(value) {
+			return nil, fmt.Errorf("invalid color '%s': use a hex color like #5e6ad2", value)
+		}
+		input["color"] = "#" + strings.TrimPrefix(value, "#")
+	}
+	if cmd.Flags().Changed("description") {
+		value, _ := cmd.Flags().GetString("description")
+		input["description"] = value
+	}
+	if cmd.Flags().Changed("parent") {
+		value, _ := cmd.Flags().GetString("parent")
+		if value == "" || strings.EqualFold(value, "none") {
+			input["parentId"] = nil
+		} else {
+			parent, err := resolveLabel(ctx, client, teamKey, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid parent: %w", err)
+			}
+			input["parentId"] = parent.ID
+		}
+	}
+
+	return input, nil
+}
+
+// labelScope is the team key of a team label, or "workspace"
+func labelScope(label api.Label) string {
+	if label.Team == nil {
+		return "workspace"
+	}
+	return label.Team.Key
+}
+
+func init() {
+	rootCmd.AddCommand(labelCmd)
+	labelCmd.AddCommand(labelListCmd)
+	labelCmd.AddCommand(labelCreateCmd)
+	labelCmd.AddCommand(labelUpdateCmd)
+	labelCmd.AddCommand(labelDeleteCmd)
+	labelCmd.AddCommand(labelMergeCmd)
+
+	labelListCmd.Flags().StringP("team", "t", "", "Include this team's labels")
+	labelListCmd.Flags().BoolP("all", "a", false, "List the labels of every team")
+
+	labelCreateCmd.Flags().StringP("team", "t", "", "Team key for a team label")
+	labelCreateCmd.Flags().Bool("workspace", false, "Create a workspace label shared by all teams")
+	labelCreateCmd.Flags().StringP("color", "c", "", "Label color as hex (e.g. #5e6ad2)")
+	labelCreateCmd.Flags().StringP("description", "d", "", "Label description")
+	labelCreateCmd.Flags().String("parent", "", "Label group to create the label in")
+
+	labelUpdateCmd.Flags().StringP("team", "t", "", "Team used to disambiguate label names")
+	labelUpdateCmd.Flags().StringP("name", "n", "", "New label name")
+	labelUpdateCmd.Flags().StringP("color", "c", "", "Label color as hex (e.g. #5e6ad2)")
+	labelUpdateCmd.Flags().StringP("description", "d", "", "Label description")
+	labelUpdateCmd.Flags().String("parent", "", "Label group to move the label into ('none' to ungroup)")
+
+	labelDeleteCmd.Flags().StringP("team", "t", "", "Team used to disambiguate label names")
+
+	labelMergeCmd.Flags().StringP("team", "t", "", "Only relabel this team's issues")
+	labelMergeCmd.Flags().Bool("dry-run", false, "Show the issues that would be relabeled without changing them")
+	labelMergeCmd.Flags().Bool("keep", false, "Keep the old label after relabeling")
+}
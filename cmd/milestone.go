@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var milestoneCmd = &cobra.Command{
+	Use:     "milestone",
+	Aliases: []string{"milestones", "ms"},
+	Short:   "Manage project milestones",
+	Long: `Manage the milestones of a project. Issues are put under a milestone with
+'issue update --milestone'.
+
+Examples:
+  linctl milestone list --project "Billing v2"
+  linctl milestone create "Beta" --project "Billing v2" --target-date 2025-11-01
+  linctl milestone update "Beta" --project "Billing v2" --target-date 2025-11-15
+  linctl issue update ENG-123 --milestone Beta`,
+}
+
+var milestoneListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List a project's milestones with their progress",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		projectID := milestoneProjectID(ctx, cmd, client, plaintext, jsonOut)
+		milestones, err := client.GetProjectMilestones(ctx, projectID)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list milestones: %v", err), plaintext, jsonOut)
+		}
+		sortMilestones(milestones)
+
+		if jsonOut {
+			output.JSON(milestones)
+			return
+		}
+		if len(milestones) == 0 {
+			output.Info("No milestones found", plaintext, jsonOut)
+			return
+		}
+
+		headers := []string{"Name", "Target", "Progress", "ID"}
+		rows := make([][]string, len(milestones))
+		for i, milestone := range milestones {
+			progress := fmt.Sprintf("%.0f%%", milestone.Progress*100)
+			if !plaintext {
+				progress = formatMilestoneProgress(milestone.Progress)
+			}
+			rows[i] = []string{milestone.Name, optionalString(milestone.TargetDate), progress, milestone.ID}
+		}
+
+		output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, jsonOut)
+		if !plaintext {
+			fmt.Printf("\n%s %d milestones\n", color.New(color.FgGreen).Sprint("✓"), len(milestones))
+		}
+	},
+}
+
+var milestoneCreateCmd = &cobra.Command{
+	Use:     "create NAME",
+	Aliases: []string{"new"},
+	Short:   "Create a milestone in a project",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		input, err := milestoneInputFromFlags(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		input["projectId"] = milestoneProjectID(ctx, cmd, client, plaintext, jsonOut)
+		input["name"] = args[0]
+
+		milestone, err := client.CreateMilestone(ctx, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create milestone: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(milestone)
+		} else if plaintext {
+			fmt.Printf("Created milestone %s\n", milestone.Name)
+			fmt.Printf("ID: %s\n", milestone.ID)
+		} else {
+			project := ""
+			if milestone.Project != nil {
+				project = " in " + milestone.Project.Name
+			}
+			fmt.Printf("%s Created milestone %s%s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(milestone.Name),
+				project)
+		}
+	},
+}
+
+var milestoneUpdateCmd = &cobra.Command{
+	Use:     "update MILESTONE",
+	Aliases: []string{"edit"},
+	Short:   "Update a milestone",
+	Long: `Update a milestone, given by name within --project or by ID. Use 'none' to
+clear the target date.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		projectID := milestoneProjectID(ctx, cmd, client, plaintext, jsonOut)
+		milestone, err := resolveMilestone(ctx, client, projectID, args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		input, err := milestoneInputFromFlags(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if cmd.Flags().Changed("name") {
+			name, _ := cmd.Flags().GetString("name")
+			input["name"] = name
+		}
+		if len(input) == 0 {
+			output.Error("No updates specified. Use flags to specify what to update.", plaintext, jsonOut)
+			exit(1)
+		}
+
+		milestone, err = client.UpdateMilestone(ctx, milestone.ID, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to update milestone: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(milestone)
+		} else {
+			output.Success(fmt.Sprintf("Updated milestone %s", milestone.Name), plaintext, jsonOut)
+		}
+	},
+}
+
+// milestoneProjectID resolves the required --project flag, exiting on errors
+func milestoneProjectID(ctx context.Context, cmd *cobra.Command, client *api.Client, plaintext, jsonOut bool) string {
+	project, _ := cmd.Flags().GetString("project")
+	if project == "" {
+		output.Error("--project is required", plaintext, jsonOut)
+		exit(1)
+	}
+	projectID, err := resolveProjectID(ctx, client, project)
+	if err != nil {
+		exitWithError(err, err.Error(), plaintext, jsonOut)
+	}
+	return projectID
+}
+
+// milestoneInputFromFlags builds a milestone create/update input from the flags that were set
+func milestoneInputFromFlags(cmd *cobra.Command) (map[string]interface{}, error) {
+	input := make(map[string]interface{})
+
+	if cmd.Flags().Changed("description") {
+		description, _ := cmd.Flags().GetString("description")
+		input["description"] = description
+	}
+
+	if cmd.Flags().Changed("target-date") {
+		date, _ := cmd.Flags().GetString("target-date")
+		if strings.EqualFold(date, "none") || date == "" {
+			input["targetDate"] = nil
+		} else if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("invalid target-date '%s' (expected YYYY-MM-DD)", date)
+		} else {
+			input["targetDate"] = date
+		}
+	}
+
+	return input, nil
+}
+
+// resolveMilestone finds a project's milestone by ID or by name
+func resolveMilestone(ctx context.Context, client *api.Client, projectID, ref string) (*api.Milestone, error) {
+	milestones, err := client.GetProjectMilestones(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get milestones: %v", err)
+	}
+	for i := range milestones {
+		if milestones[i].ID == ref || strings.EqualFold(milestones[i].Name, ref) {
+			return &milestones[i], nil
+		}
+	}
+
+	names := make([]string, len(milestones))
+	for i, milestone := range milestones {
+		names[i] = milestone.Name
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("milestone not found: %s (the project has no milestones)", ref)
+	}
+	return nil, fmt.Errorf("milestone not found: %s (available: %s)", ref, strings.Join(names, ", "))
+}
+
+// resolveIssueMilestoneID resolves 'issue update --milestone' within the
+// issue's project; 'none' removes the issue from its milestone (nil)
+func resolveIssueMilestoneID(ctx context.Context, client *api.Client, issueID, milestone string) (interface{}, error) {
+	if milestone == "" || strings.EqualFold(milestone, "none") || milestone == "unassigned" {
+		return nil, nil
+	}
+
+	issue, err := client.GetIssue(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %v", err)
+	}
+	if issue.Project == nil {
+		return nil, fmt.Errorf("issue %s is not in a project, so it cannot have a milestone", issue.Identifier)
+	}
+	m, err := resolveMilestone(ctx, client, issue.Project.ID, milestone)
+	if err != nil {
+		return nil, fmt.Errorf("%v in project %s", err, issue.Project.Name)
+	}
+	return m.ID, nil
+}
+
+// sortMilestones orders milestones as Linear shows them
+func sortMilestones(milestones []api.Milestone) {
+	sort.SliceStable(milestones, func(i, j int) bool {
+		return milestones[i].SortOrder < milestones[j].SortOrder
+	})
+}
+
+// formatMilestoneProgress shows a progress fraction as a colored percentage
+func formatMilestoneProgress(progress float64) string {
+	progressColor := color.New(color.FgRed)
+	if progress >= 0.75 {
+		progressColor = color.New(color.FgGreen)
+	} else if progress >= 0.5 {
+		progressColor = color.New(color.FgYellow)
+	}
+	return progressColor.Sprintf("%.0f%%", progress*100)
+}
+
+func init() {
+	rootCmd.AddCommand(milestoneCmd)
+	milestoneCmd.AddCommand(milestoneListCmd)
+	milestoneCmd.AddCommand(milestoneCreateCmd)
+	milestoneCmd.AddCommand(milestoneUpdateCmd)
+
+	for _, c := range []*cobra.Command{milestoneListCmd, milestoneCreateCmd, milestoneUpdateCmd} {
+		c.Flags().StringP("project", "P", "", "Project name or ID (required)")
+	}
+	for _, c := range []*cobra.Command{milestoneCreateCmd, milestoneUpdateCmd} {
+		c.Flags().StringP("description", "d", "", "Milestone description")
+		c.Flags().String("target-date", "", "Target date (YYYY-MM-DD)")
+	}
+	milestoneUpdateCmd.Flags().String("name", "", "New milestone name")
+
+	issueUpdateCmd.Flags().String("milestone", "", "Milestone of the issue's project (name or ID), or 'none' to remove it")
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// linearURLPattern matches links copied from Linear, capturing the kind of
+// page (issue, project, team) and the identifier that follows it
+var linearURLPattern = regexp.MustCompile(`^https?://linear\.app/[^/]+/(issue|project|team|document)/([^/?#]+)`)
+
+// issueIdentifierPattern matches an issue identifier such as ENG-123
+var issueIdentifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*-\d+$`)
+
+// linearURLPlaceholders are the argument names in a command's Use line that
+// may be given as Linear URLs
+var linearURLPlaceholders = map[string]bool{
+	"ISSUE-ID": true, "OTHER-ID": true, "ID|ISSUE-ID": true, "PROJECT": true,
+	"TEAM-KEY": true, "DOCUMENT": true, "ISSUE|PROJECT|TEAM": true,
+}
+
+// linearURLFlags are the flags whose values may be given as Linear URLs
+var linearURLFlags = map[string]bool{
+	"parent-issue": true, "parent": true, "project": true, "team": true,
+}
+
+var openCmd = &cobra.Command{
+	Use:   "open [ISSUE|PROJECT|TEAM]",
+	Short: "Open an issue, project, or team in the browser",
+	Long: `Open the Linear page for an issue identifier, project (ID, slug, or name), or
+team key in the default browser. With no argument, opens the issue named by the
+current git branch.
+
+Examples:
+  linctl open ENG-123
+  linctl open ENG                      # the team's active issues
+  linctl open "Checkout revamp"        # a project by name
+  linctl open ENG-123 --url-only       # print the URL instead`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		target := ""
+		if len(args) == 1 {
+			target = strings.TrimSpace(args[0])
+		} else {
+			identifier, err := currentBranchIssue()
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("No target given and %v", err), plaintext, jsonOut)
+			}
+			target = identifier
+		}
+
+		kind, _ := cmd.Flags().GetString("type")
+		client := authenticatedClient(plaintext, jsonOut)
+		url, err := resolveLinearURL(context.Background(), client, target, kind)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if urlOnly, _ := cmd.Flags().GetBool("url-only"); urlOnly {
+			if jsonOut {
+				output.JSON(map[string]interface{}{"url": url})
+				return
+			}
+			fmt.Println(url)
+			return
+		}
+
+		if err := utils.OpenBrowser(url); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to open browser: %v (URL: %s)", err, url), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"url": url, "opened": true})
+		} else if plaintext {
+			fmt.Println(url)
+		} else {
+			fmt.Printf("%s Opened %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgBlue, color.Underline).Sprint(url))
+		}
+	},
+}
+
+// resolveLinearURL finds the Linear URL of an issue, team, or project. kind
+// ("issue", "team", "project") skips guessing what target names.
+func resolveLinearURL(ctx context.Context, client *api.Client, target, kind string) (string, error) {
+	if kind == "" {
+		switch {
+		case issueIdentifierPattern.MatchString(target):
+			kind = "issue"
+		case uuidPattern.MatchString(target):
+			// Issue and project IDs look alike; try the issue first
+			if issue, err := client.GetIssue(ctx, target); err == nil {
+				return issue.URL, nil
+			}
+			kind = "project"
+		default:
+			if _, err := client.GetTeam(ctx, target); err == nil {
+				kind = "team"
+			} else {
+				kind = "project"
+			}
+		}
+	}
+
+	switch kind {
+	case "issue":
+		issue, err := client.GetIssue(ctx, target)
+		if err != nil {
+			return "", fmt.Errorf("issue not found: %s", target)
+		}
+		return issue.URL, nil
+	case "team":
+		team, err := client.GetTeam(ctx, target)
+		if err != nil {
+			return "", fmt.Errorf("team not found: %s", target)
+		}
+		org, err := client.GetOrganization(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get workspace: %v", err)
+		}
+		return fmt.Sprintf("https://linear.app/%s/team/%s/active", org.URLKey, team.Key), nil
+	case "project":
+		projectID, err := resolveProjectID(ctx, client, target)
+		if err != nil {
+			return "", fmt.Errorf("no issue, team, or project matches '%s'", target)
+		}
+		project, err := client.GetProject(ctx, projectID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get project: %v", err)
+		}
+		return project.URL, nil
+	}
+	return "", fmt.Errorf("unknown type '%s' (valid: issue, project, team)", kind)
+}
+
+// refFromLinearURL turns a link copied from Linear into the identifier
+// commands accept: ENG-123 for issues, the slug ID for projects and
+// documents, and the key for teams. ok is false for anything else.
+func refFromLinearURL(value string) (ref string, ok bool) {
+	match := linearURLPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return "", false
+	}
+	switch match[1] {
+	case "issue", "team":
+		return strings.ToUpper(match[2]), true
+	default:
+		// Project and document URLs end in "<name>-<slugId>"
+		slug := match[2]
+		return slug[strings.LastIndex(slug, "-")+1:], true
+	}
+}
+
+// normalizeLinearURLs replaces Linear URLs given for issue, project, team,
+// and document arguments or flags with the identifiers they point to, so a
+// pasted link works anywhere an identifier does. Other arguments, such as a
+// comment body, are left as typed. Cobra hands the same args slice to
+// PersistentPreRun and Run, so rewriting it in place reaches the command.
+func normalizeLinearURLs(cmd *cobra.Command, args []string) {
+	for i, isRef := range placeholderPositions(cmd, len(args), linearURLPlaceholders) {
+		if !isRef {
+			continue
+		}
+		if ref, ok := refFromLinearURL(args[i]); ok {
+			args[i] = ref
+		}
+	}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if !linearURLFlags[f.Name] || f.Value.Type() != "string" {
+			return
+		}
+		if ref, ok := refFromLinearURL(f.Value.String()); ok {
+			_ = f.Value.Set(ref)
+		}
+	})
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+
+	openCmd.Flags().Bool("url-only", false, "Print the URL instead of opening it")
+	openCmd.Flags().String("type", "", "What the argument names: issue, project, or team (default: guess)")
+}
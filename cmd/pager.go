@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"github.com/dorkitude/linctl/pkg/debug"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// pagerAnnotation set to "true" pages a command's output, or "false" never
+// does, whatever its name
+const pagerAnnotation = "linctl/pager"
+
+// pagedCommands are the names of the commands whose output is paged: lists
+// and detailed views, which can run to many screens
+var pagedCommands = map[string]bool{
+	"list":      true,
+	"search":    true,
+	"get":       true,
+	"view":      true,
+	"show":      true,
+	"history":   true,
+	"relations": true,
+	"requests":  true,
+	"states":    true,
+	"children":  true,
+}
+
+// startPager sends the command's output through $PAGER (less -FRX by
+// default) when it is a terminal, unless --no-pager or no-pager: true. Only
+// commands with long output are paged, and never while they --watch.
+func startPager(cmd *cobra.Command) {
+	if viper.GetBool("no-pager") {
+		return
+	}
+	switch cmd.Annotations[pagerAnnotation] {
+	case "false":
+		return
+	case "":
+		if !pagedCommands[cmd.Name()] {
+			return
+		}
+	}
+	if watch, _ := cmd.Flags().GetBool("watch"); watch {
+		return
+	}
+	if err := output.StartPager(output.PagerCommand(viper.GetString("pager"))); err != nil {
+		debug.Logf(1, "pager: not paging: %v", err)
+	}
+}
+
+// stopPager waits for the user to quit the pager, if output is paged
+func stopPager() {
+	output.StopPager()
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// issueImage is an image added to an issue by paste-image or screenshot
+type issueImage struct {
+	Issue     string `json:"issue"`
+	URL       string `json:"url"`
+	CommentID string `json:"commentId,omitempty"`
+}
+
+var pasteImageCmd = &cobra.Command{
+	Use:   "paste-image",
+	Short: "Upload the image on the clipboard to an issue",
+	Long: `Upload the image on the system clipboard and add it to an issue: appended to
+the description, or posted as a new comment with --comment.
+
+The issue defaults to the one named by the current git branch. Reading the
+clipboard uses osascript on macOS, PowerShell on Windows, and wl-paste
+(wl-clipboard) or xclip on Linux.
+
+Examples:
+  linctl paste-image --issue ENG-123
+  linctl paste-image --issue ENG-123 --comment --alt "Error dialog"
+  linctl paste-image                      # the current branch's issue`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		issueID, err := issueFlagOrBranch(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		client := authenticatedClient(plaintext, jsonOut)
+		result, err := pasteClipboardImage(cmd, client, issueID)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		printIssueImage(result, plaintext, jsonOut)
+	},
+}
+
+// pasteClipboardImage saves the clipboard's image to a temporary file and
+// adds it to the issue
+func pasteClipboardImage(cmd *cobra.Command, client *api.Client, issueID string) (issueImage, error) {
+	name, _ := cmd.Flags().GetString("name")
+	if name == "" {
+		name = fmt.Sprintf("clipboard-%s.png", time.Now().Format("20060102-150405"))
+	}
+
+	dir, err := os.MkdirTemp("", "linctl-clipboard-")
+	if err != nil {
+		return issueImage{}, fmt.Errorf("Failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, files.SanitizeFilename(name))
+	if err := utils.SaveClipboardImage(path); err != nil {
+		if errors.Is(err, utils.ErrNoClipboardImage) {
+			return issueImage{}, fmt.Errorf("The clipboard does not contain an image")
+		}
+		return issueImage{}, err
+	}
+	return addImageToIssue(cmd, client, issueID, path)
+}
+
+// issueFlagOrBranch returns the --issue flag, or the issue named by the
+// current git branch when it is not given
+func issueFlagOrBranch(cmd *cobra.Command) (string, error) {
+	if issueID, _ := cmd.Flags().GetString("issue"); issueID != "" {
+		return issueID, nil
+	}
+	identifier, err := currentBranchIssue()
+	if err != nil {
+		return "", fmt.Errorf("No --issue given and %v", err)
+	}
+	return identifier, nil
+}
+
+// addImageToIssue uploads the image at path and appends it to the issue's
+// description, or posts it as a new comment with --comment. A description
+// change is recorded for 'linctl undo'.
+func addImageToIssue(cmd *cobra.Command, client *api.Client, issueID, path string) (issueImage, error) {
+	ctx := context.Background()
+	asComment, _ := cmd.Flags().GetBool("comment")
+	altText, _ := cmd.Flags().GetString("alt")
+	if altText == "" {
+		altText = filepath.Base(path)
+	}
+
+	// Look the issue up before uploading, so a typo doesn't leave an orphaned upload
+	before, err := client.GetIssue(ctx, issueID)
+	if err != nil {
+		return issueImage{}, fmt.Errorf("Failed to get issue %s: %w", issueID, err)
+	}
+	result := issueImage{Issue: before.Identifier}
+
+	if !viper.GetBool("json") && !viper.GetBool("plaintext") {
+		fmt.Printf("Uploading %s...\n", filepath.Base(path))
+	}
+	result.URL, err = uploadFile(ctx, client, path, files.UploadOptions{})
+	if err != nil {
+		return issueImage{}, fmt.Errorf("Failed to upload image: %w", err)
+	}
+
+	if asComment {
+		comment, err := client.CreateComment(ctx, before.ID, files.InjectImageIntoMarkdown("", result.URL, altText))
+		if err != nil {
+			return issueImage{}, fmt.Errorf("Failed to create comment: %w", err)
+		}
+		result.CommentID = comment.ID
+		return result, nil
+	}
+
+	input := map[string]interface{}{
+		"description": files.InjectImageIntoMarkdown(before.Description, result.URL, altText),
+	}
+	updated, err := client.UpdateIssue(ctx, before.ID, input)
+	if err != nil {
+		return issueImage{}, fmt.Errorf("Failed to update issue: %w", err)
+	}
+	if change, ok := issueUndoChange(before, updated, input); ok {
+		recordUndo(cmd, nil, []undoChange{change})
+	}
+	return result, nil
+}
+
+// printIssueImage reports an image added by addImageToIssue
+func printIssueImage(result issueImage, plaintext, jsonOut bool) {
+	if jsonOut {
+		output.JSON(result)
+		return
+	}
+	where := "description"
+	if result.CommentID != "" {
+		where = "a new comment"
+	}
+	if plaintext {
+		fmt.Printf("Added image to %s (%s)\n", result.Issue, where)
+		fmt.Printf("URL: %s\n", result.URL)
+		return
+	}
+	fmt.Printf("%s Added image to %s %s\n",
+		color.New(color.FgGreen).Sprint("✓"),
+		color.New(color.FgCyan, color.Bold).Sprint(result.Issue),
+		color.New(color.FgWhite, color.Faint).Sprintf("(%s)", where))
+	fmt.Printf("  %s\n", color.New(color.FgBlue, color.Underline).Sprint(result.URL))
+}
+
+func init() {
+	rootCmd.AddCommand(pasteImageCmd)
+
+	pasteImageCmd.Flags().StringP("issue", "i", "", "Issue to add the image to (default: from the current git branch)")
+	markIssueRefFlags(pasteImageCmd.Flags(), "issue")
+	pasteImageCmd.Flags().BoolP("comment", "c", false, "Post the image as a new comment instead of appending it to the description")
+	pasteImageCmd.Flags().String("alt", "", "Alt text for the image (default: the file name)")
+	pasteImageCmd.Flags().String("name", "", "File name for the upload (default: clipboard-<time>.png)")
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// pickAnnotation names what a command's one argument is ("issue", "project",
+// or "user") when it may be left out to pick it interactively
+const pickAnnotation = "linctl/pick"
+
+// pickLimit is how many issues, projects, or users are offered to pick from
+const pickLimit = 100
+
+// canPick reports whether an argument left out can be picked interactively:
+// only in a terminal, and not for --plaintext or --json output
+func canPick() bool {
+	return isInteractive() && !viper.GetBool("plaintext") && !viper.GetBool("json")
+}
+
+// pickPending reports whether the command is about to ask for its argument.
+// The pager waits until it has been picked.
+func pickPending(cmd *cobra.Command, args []string) bool {
+	if cmd.Annotations[pickAnnotation] == "" || len(args) > 0 || !canPick() {
+		return false
+	}
+	current, _ := cmd.Flags().GetBool("current")
+	return !current
+}
+
+// argOrPick accepts one argument, or none when it can be picked
+func argOrPick(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && canPick() {
+		return nil
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// argFromPick returns the command's argument, or asks for it with the
+// picker for what the command's pickAnnotation names
+func argFromPick(cmd *cobra.Command, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if !canPick() {
+		return "", fmt.Errorf("no %s given", cmd.Annotations[pickAnnotation])
+	}
+
+	client := authenticatedClient(viper.GetBool("plaintext"), viper.GetBool("json"))
+	ctx := context.Background()
+	var (
+		values []string
+		items  []output.PickerItem
+		err    error
+	)
+	kind := cmd.Annotations[pickAnnotation]
+	switch kind {
+	case "issue":
+		values, items, err = issueChoices(ctx, client)
+	case "project":
+		values, items, err = projectChoices(ctx, client)
+	case "user":
+		values, items, err = userChoices(ctx, client)
+	default:
+		return "", fmt.Errorf("nothing to pick for '%s'", kind)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		return "", fmt.Errorf("no %s given, and none to pick from", kind)
+	}
+
+	index, err := output.Pick(kind, items)
+	if errors.Is(err, output.ErrPickCanceled) {
+		return "", fmt.Errorf("no %s picked", kind)
+	}
+	if err != nil {
+		return "", err
+	}
+	startPager(cmd)
+	return values[index], nil
+}
+
+// issueChoices are my most recently updated issues, by identifier
+func issueChoices(ctx context.Context, client *api.Client) ([]string, []output.PickerItem, error) {
+	filter, err := userFilter(ctx, meShortcut)
+	if err != nil {
+		return nil, nil, err
+	}
+	issues, err := client.GetIssues(ctx, map[string]interface{}{"assignee": filter}, pickLimit, "", "updatedAt")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list your issues: %v", err)
+	}
+	values := make([]string, 0, len(issues.Nodes))
+	items := make([]output.PickerItem, 0, len(issues.Nodes))
+	for _, issue := range issues.Nodes {
+		item := output.PickerItem{Label: issue.Identifier + "  " + issue.Title}
+		if issue.State != nil {
+			item.Detail = issue.State.Name
+		}
+		values = append(values, issue.Identifier)
+		items = append(items, item)
+	}
+	return values, items, nil
+}
+
+// projectChoices are the most recently updated projects, by ID
+func projectChoices(ctx context.Context, client *api.Client) ([]string, []output.PickerItem, error) {
+	projects, err := client.GetProjects(ctx, nil, pickLimit, "", "updatedAt")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list projects: %v", err)
+	}
+	values := make([]string, 0, len(projects.Nodes))
+	items := make([]output.PickerItem, 0, len(projects.Nodes))
+	for _, project := range projects.Nodes {
+		values = append(values, project.ID)
+		items = append(items, output.PickerItem{Label: project.Name, Detail: project.State})
+	}
+	return values, items, nil
+}
+
+// userChoices are the workspace's active users, by ID
+func userChoices(ctx context.Context, client *api.Client) ([]string, []output.PickerItem, error) {
+	users, err := client.GetUsers(ctx, pickLimit, "", "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list users: %v", err)
+	}
+	var values []string
+	var items []output.PickerItem
+	for _, user := range users.Nodes {
+		if !user.Active {
+			continue
+		}
+		values = append(values, user.ID)
+		items = append(items, output.PickerItem{Label: user.Name, Detail: user.Email})
+	}
+	return values, items, nil
+}
+
+// pickable lets the commands' one argument be left out and picked as kind
+func pickable(kind string, cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		if c.Annotations == nil {
+			c.Annotations = map[string]string{}
+		}
+		c.Annotations[pickAnnotation] = kind
+	}
+}
@@ -3,8 +3,8 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/dorkitude/linctl/pkg/api"
 	"github.com/dorkitude/linctl/pkg/auth"
@@ -37,14 +37,19 @@ func constructProjectURL(projectID string, originalURL string) string {
 var projectCmd = &cobra.Command{
 	Use:   "project",
 	Short: "Manage Linear projects",
-	Long: `Manage Linear projects including listing, viewing, and creating projects.
+	Long: `Manage Linear projects including listing, viewing, creating, updating, and archiving projects.
+
+Projects can be referred to by ID, slug ID, or name.
 
 Examples:
   linctl project list                      # List active projects
   linctl project list --include-completed  # List all projects including completed
   linctl project list --newer-than 1_month_ago  # List projects from last month
-  linctl project get PROJECT-ID            # Get project details
-  linctl project create                    # Create a new project`,
+  linctl project view PROJECT              # Get project details
+  linctl project issues PROJECT            # List the project's issues
+  linctl project create "Q3 Launch" --team ENG --lead me --target-date 2025-09-30
+  linctl project update PROJECT --status "In Progress" --description-file brief.md
+  linctl project archive PROJECT`,
 }
 
 var projectListCmd = &cobra.Command{
@@ -56,11 +61,15 @@ var projectListCmd = &cobra.Command{
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 
+		format, err := parseDelimitedFormat(cmd, projectColumns)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
 		// Get auth header
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
 		}
 
 		// Create API client
@@ -78,8 +87,7 @@ var projectListCmd = &cobra.Command{
 			// Get team ID from key
 			team, err := client.GetTeam(context.Background(), teamKey)
 			if err != nil {
-				output.Error(fmt.Sprintf("Failed to find team '%s': %v", teamKey, err), plaintext, jsonOut)
-				os.Exit(1)
+				exitWithError(err, fmt.Sprintf("Failed to find team '%s': %v", teamKey, err), plaintext, jsonOut)
 			}
 			filter["team"] = map[string]interface{}{"id": team.ID}
 		}
@@ -96,8 +104,7 @@ var projectListCmd = &cobra.Command{
 		newerThan, _ := cmd.Flags().GetString("newer-than")
 		createdAt, err := utils.ParseTimeExpression(newerThan)
 		if err != nil {
-			output.Error(fmt.Sprintf("Invalid newer-than value: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Invalid newer-than value: %v", err), plaintext, jsonOut)
 		}
 		if createdAt != "" {
 			filter["createdAt"] = map[string]interface{}{"gte": createdAt}
@@ -117,15 +124,21 @@ var projectListCmd = &cobra.Command{
 				orderBy = ""
 			default:
 				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 
 		// Get projects
 		projects, err := client.GetProjects(context.Background(), filter, limit, "", orderBy)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to list projects: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to list projects: %v", err), plaintext, jsonOut)
+		}
+
+		if format != nil {
+			if err := writeDelimited(format, projects.Nodes, projectColumns); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write output: %v", err), plaintext, jsonOut)
+			}
+			return
 		}
 
 		// Handle output
@@ -206,7 +219,7 @@ var projectListCmd = &cobra.Command{
 				}
 
 				rows = append(rows, []string{
-					truncateString(project.Name, 25),
+					project.Name,
 					stateColor.Sprint(project.State),
 					lead,
 					teams,
@@ -231,31 +244,37 @@ var projectListCmd = &cobra.Command{
 }
 
 var projectGetCmd = &cobra.Command{
-	Use:     "get PROJECT-ID",
-	Aliases: []string{"show"},
+	Use:     "get [PROJECT]",
+	Aliases: []string{"show", "view"},
 	Short:   "Get project details",
-	Long:    `Get detailed information about a specific project.`,
-	Args:    cobra.ExactArgs(1),
+	Long:    `Get detailed information about a specific project, or one picked from a list when none is given.`,
+	Args:    argOrPick,
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
-		projectID := args[0]
+		projectID, err := argFromPick(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
 
 		// Get auth header
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
 		}
 
 		// Create API client
 		client := api.NewClient(authHeader)
 
-		// Get project details
+		// Get project details, falling back to a lookup by name
 		project, err := client.GetProject(context.Background(), projectID)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to get project: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			if resolved, resolveErr := resolveProjectID(context.Background(), client, projectID); resolveErr == nil {
+				project, err = client.GetProject(context.Background(), resolved)
+			}
+		}
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get project: %v", err), plaintext, jsonOut)
 		}
 
 		// Handle output
@@ -367,6 +386,19 @@ var projectGetCmd = &cobra.Command{
 				}
 			}
 
+			// Milestones
+			if project.ProjectMilestones != nil && len(project.ProjectMilestones.Nodes) > 0 {
+				fmt.Printf("\n## Milestones\n")
+				sortMilestones(project.ProjectMilestones.Nodes)
+				for _, milestone := range project.ProjectMilestones.Nodes {
+					fmt.Printf("- **%s**: %.0f%%", milestone.Name, milestone.Progress*100)
+					if milestone.TargetDate != nil {
+						fmt.Printf(" (target %s)", *milestone.TargetDate)
+					}
+					fmt.Println()
+				}
+			}
+
 			// Project Updates
 			if project.ProjectUpdates != nil && len(project.ProjectUpdates.Nodes) > 0 {
 				fmt.Printf("\n## Recent Project Updates\n")
@@ -529,6 +561,19 @@ var projectGetCmd = &cobra.Command{
 				}
 			}
 
+			// Show milestones with their progress
+			if project.ProjectMilestones != nil && len(project.ProjectMilestones.Nodes) > 0 {
+				fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("Milestones:"))
+				sortMilestones(project.ProjectMilestones.Nodes)
+				for _, milestone := range project.ProjectMilestones.Nodes {
+					target := ""
+					if milestone.TargetDate != nil {
+						target = color.New(color.FgWhite, color.Faint).Sprint(" → " + *milestone.TargetDate)
+					}
+					fmt.Printf("  • %s %s%s\n", milestone.Name, formatMilestoneProgress(milestone.Progress), target)
+				}
+			}
+
 			// Show sample issues if available
 			if project.Issues != nil && len(project.Issues.Nodes) > 0 {
 				fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("Recent Issues:"))
@@ -582,10 +627,380 @@ var projectGetCmd = &cobra.Command{
 	},
 }
 
+var projectCreateCmd = &cobra.Command{
+	Use:     "create NAME",
+	Aliases: []string{"new"},
+	Short:   "Create a project",
+	Long: `Create a project. At least one team is required (--team, or default-team from config).
+
+Examples:
+  linctl project create "Q3 Launch" --team ENG
+  linctl project create "Billing v2" --team ENG,WEB --lead jane@example.com --members me,bob@example.com
+  linctl project create "Mobile" --team MOB --status planned --target-date 2025-12-01 --description-file brief.md`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+		ctx := context.Background()
+
+		input, err := projectInputFromFlags(ctx, cmd, client)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		input["name"] = args[0]
+		if _, ok := input["teamIds"]; !ok {
+			output.Error("At least one team is required (--team)", plaintext, jsonOut)
+			exit(1)
+		}
+
+		project, err := client.CreateProject(ctx, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create project: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(project)
+		} else if plaintext {
+			fmt.Printf("Created project %s\n", project.Name)
+			fmt.Printf("ID: %s\n", project.ID)
+			fmt.Printf("URL: %s\n", project.URL)
+		} else {
+			fmt.Printf("%s Created project %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(project.Name))
+			if project.Lead != nil {
+				fmt.Printf("  Lead: %s\n", color.New(color.FgCyan).Sprint(project.Lead.Name))
+			}
+			fmt.Printf("  %s\n", color.New(color.FgBlue, color.Underline).Sprint(project.URL))
+		}
+	},
+}
+
+var projectUpdateCmd = &cobra.Command{
+	Use:     "update [PROJECT]",
+	Aliases: []string{"edit"},
+	Short:   "Update a project",
+	Long: `Update a project's name, summary, description, lead, members, teams, status, or dates.
+List flags (--team, --members) replace the current values. Use 'none' to clear
+the lead or a date. With no project given, pick one from a list.
+
+Examples:
+  linctl project update "Q3 Launch" --status "In Progress" --lead me
+  linctl project update PROJECT-ID --target-date 2025-10-15 --members me,jane@example.com
+  linctl project update PROJECT-ID --description-file brief.md`,
+	Args: argOrPick,
+	// --team replaces the project's teams, so it must not be filled from default-team
+	Annotations: map[string]string{noDefaultTeamAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+		ctx := context.Background()
+
+		projectRef, err := argFromPick(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		projectID, err := resolveProjectID(ctx, client, projectRef)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		input, err := projectInputFromFlags(ctx, cmd, client)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if cmd.Flags().Changed("name") {
+			name, _ := cmd.Flags().GetString("name")
+			input["name"] = name
+		}
+		if len(input) == 0 {
+			output.Error("No updates specified. Use flags to specify what to update.", plaintext, jsonOut)
+			exit(1)
+		}
+
+		project, err := client.UpdateProject(ctx, projectID, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to update project: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(project)
+		} else if plaintext {
+			fmt.Printf("Updated project %s\n", project.Name)
+		} else {
+			output.Success(fmt.Sprintf("Updated project %s", project.Name), plaintext, jsonOut)
+		}
+	},
+}
+
+var projectArchiveCmd = &cobra.Command{
+	Use:   "archive PROJECT...",
+	Short: "Archive projects",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+		ctx := context.Background()
+
+		archived := []string{}
+		var failures []string
+		var undoChanges []undoChange
+		for _, project := range args {
+			projectID, err := resolveProjectID(ctx, client, project)
+			if err == nil {
+				err = client.ArchiveProject(ctx, projectID)
+			}
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", project, err))
+				continue
+			}
+			archived = append(archived, project)
+			undoChanges = append(undoChanges, undoChange{
+				Kind:    undoProjectArchive,
+				Target:  projectID,
+				Label:   project,
+				Summary: "archived",
+			})
+		}
+		recordUndo(cmd, args, undoChanges)
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"archived": archived,
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			for _, project := range archived {
+				output.Success(fmt.Sprintf("Archived project %s", project), plaintext, jsonOut)
+			}
+			for _, failure := range failures {
+				output.Error(fmt.Sprintf("Failed to archive project %s", failure), plaintext, false)
+			}
+		}
+
+		exitOnFailures(len(archived), len(failures))
+	},
+}
+
+var projectIssuesCmd = &cobra.Command{
+	Use:   "issues [PROJECT]",
+	Short: "List the issues in a project",
+	Long: `List the issues in a project, or in one picked from a list when none is given.
+
+Examples:
+  linctl project issues "Q3 Launch"
+  linctl project issues PROJECT-ID --include-completed --all
+  linctl project issues PROJECT-ID --format csv`,
+	Args: argOrPick,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		format, err := parseDelimitedFormat(cmd, issueColumns)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		projectRef, err := argFromPick(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		projectID, err := resolveProjectID(context.Background(), client, projectRef)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		filter := map[string]interface{}{
+			"project": map[string]interface{}{"id": map[string]interface{}{"eq": projectID}},
+		}
+		if includeCompleted, _ := cmd.Flags().GetBool("include-completed"); !includeCompleted {
+			filter["state"] = map[string]interface{}{
+				"type": map[string]interface{}{"nin": []string{"completed", "canceled"}},
+			}
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		issues, err := fetchIssuePages(cmd, limit, func(ctx context.Context, first int, after string) (*api.Issues, error) {
+			return client.GetIssues(ctx, filter, first, after, "")
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to fetch issues: %v", err), plaintext, jsonOut)
+		}
+
+		if format != nil {
+			if err := writeDelimited(format, issues.Nodes, issueColumns); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write output: %v", err), plaintext, jsonOut)
+			}
+			return
+		}
+
+		renderIssueCollection(issues, plaintext, jsonOut, "No issues found in this project", "issues", "# Project Issues")
+	},
+}
+
+// projectInputFromFlags builds a project create/update input from the flags that were set
+func projectInputFromFlags(ctx context.Context, cmd *cobra.Command, client *api.Client) (map[string]interface{}, error) {
+	input := make(map[string]interface{})
+
+	if cmd.Flags().Changed("summary") {
+		summary, _ := cmd.Flags().GetString("summary")
+		input["description"] = summary
+	}
+
+	if cmd.Flags().Changed("description") && cmd.Flags().Changed("description-file") {
+		return nil, fmt.Errorf("use either --description or --description-file, not both")
+	}
+	if cmd.Flags().Changed("description") {
+		description, _ := cmd.Flags().GetString("description")
+		input["content"] = description
+	}
+	if path, _ := cmd.Flags().GetString("description-file"); path != "" {
+		content, err := readMarkdownInput(path)
+		if err != nil {
+			return nil, err
+		}
+		input["content"] = content
+	}
+
+	if teams, _ := cmd.Flags().GetString("team"); teams != "" {
+		var teamIDs []string
+		for _, key := range strings.Split(teams, ",") {
+			if key = strings.TrimSpace(key); key == "" {
+				continue
+			}
+			team, err := client.GetTeam(ctx, key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find team '%s': %v", key, err)
+			}
+			teamIDs = append(teamIDs, team.ID)
+		}
+		if len(teamIDs) > 0 {
+			input["teamIds"] = teamIDs
+		}
+	}
+
+	if cmd.Flags().Changed("lead") {
+		lead, _ := cmd.Flags().GetString("lead")
+		if strings.EqualFold(lead, "none") {
+			lead = "unassigned"
+		}
+		leadID, err := resolveAssigneeID(ctx, client, lead)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve lead: %v", err)
+		}
+		input["leadId"] = leadID
+	}
+
+	if cmd.Flags().Changed("members") {
+		members, _ := cmd.Flags().GetString("members")
+		memberIDs := []string{}
+		for _, member := range strings.Split(members, ",") {
+			if member = strings.TrimSpace(member); member == "" {
+				continue
+			}
+			memberID, err := resolveAssigneeID(ctx, client, member)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve member: %v", err)
+			}
+			if memberID != nil {
+				memberIDs = append(memberIDs, *memberID)
+			}
+		}
+		input["memberIds"] = memberIDs
+	}
+
+	if status, _ := cmd.Flags().GetString("status"); status != "" {
+		statusID, err := resolveProjectStatusID(ctx, client, status)
+		if err != nil {
+			return nil, err
+		}
+		input["statusId"] = statusID
+	}
+
+	for flag, field := range map[string]string{"start-date": "startDate", "target-date": "targetDate"} {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+		date, _ := cmd.Flags().GetString(flag)
+		if strings.EqualFold(date, "none") || date == "" {
+			input[field] = nil
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("invalid %s '%s' (expected YYYY-MM-DD)", flag, date)
+		}
+		input[field] = date
+	}
+
+	return input, nil
+}
+
+// resolveProjectStatusID matches a project status by name, or by type
+// (backlog, planned, started, paused, completed, canceled)
+func resolveProjectStatusID(ctx context.Context, client *api.Client, status string) (string, error) {
+	statuses, err := client.GetProjectStatuses(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch project statuses: %v", err)
+	}
+
+	for _, s := range statuses {
+		if strings.EqualFold(s.Name, status) {
+			return s.ID, nil
+		}
+	}
+	for _, s := range statuses {
+		if strings.EqualFold(s.Type, status) {
+			return s.ID, nil
+		}
+	}
+
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = s.Name
+	}
+	return "", fmt.Errorf("unknown project status '%s' (available: %s)", status, strings.Join(names, ", "))
+}
+
 func init() {
 	rootCmd.AddCommand(projectCmd)
 	projectCmd.AddCommand(projectListCmd)
 	projectCmd.AddCommand(projectGetCmd)
+	projectCmd.AddCommand(projectCreateCmd)
+	pickable("project", projectGetCmd, projectUpdateCmd, projectIssuesCmd)
+	projectCmd.AddCommand(projectUpdateCmd)
+	projectCmd.AddCommand(projectArchiveCmd)
+	projectCmd.AddCommand(projectIssuesCmd)
 
 	// List command flags
 	projectListCmd.Flags().StringP("team", "t", "", "Filter by team key")
@@ -594,4 +1009,26 @@ func init() {
 	projectListCmd.Flags().BoolP("include-completed", "c", false, "Include completed and canceled projects")
 	projectListCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
 	projectListCmd.Flags().StringP("newer-than", "n", "", "Show projects created after this time (default: 6_months_ago, use 'all_time' for no filter)")
+	addFormatFlags(projectListCmd, columnNames(projectColumns), defaultProjectColumns)
+
+	// Create and update flags
+	for _, c := range []*cobra.Command{projectCreateCmd, projectUpdateCmd} {
+		c.Flags().String("summary", "", "Short project summary")
+		c.Flags().StringP("description", "d", "", "Project description (markdown)")
+		c.Flags().StringP("description-file", "F", "", "Read the description from a markdown file ('-' for stdin)")
+		c.Flags().StringP("team", "t", "", "Team keys, comma-separated")
+		c.Flags().String("lead", "", "Project lead (email, name, or 'me')")
+		c.Flags().String("members", "", "Project members, comma-separated (email, name, or 'me')")
+		c.Flags().StringP("status", "s", "", "Project status name or type (e.g. planned, started, paused, completed)")
+		c.Flags().String("start-date", "", "Start date (YYYY-MM-DD)")
+		c.Flags().String("target-date", "", "Target date (YYYY-MM-DD)")
+	}
+	projectUpdateCmd.Flags().String("name", "", "New project name")
+
+	// Issues command flags
+	projectIssuesCmd.Flags().IntP("limit", "l", 50, "Maximum number of issues to fetch")
+	projectIssuesCmd.Flags().Bool("all", false, "Fetch all pages of results (--limit caps the total when given)")
+	projectIssuesCmd.Flags().Int("page-size", api.DefaultPageSize, "Number of issues to request per page when using --all (max 250)")
+	projectIssuesCmd.Flags().BoolP("include-completed", "c", false, "Include completed and canceled issues")
+	addFormatFlags(projectIssuesCmd, columnNames(issueColumns), defaultIssueColumns)
 }
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var projectStatusUpdateCmd = &cobra.Command{
+	Use:     "update-status [PROJECT]",
+	Aliases: []string{"post-update"},
+	Short:   "Post a project status update",
+	Long: `Post a status update to a project, with its health: on-track, at-risk, or
+off-track (or Linear's onTrack, atRisk, offTrack).
+
+The body is markdown from --body or --body-file. Images the file references by
+local path are uploaded, and --image files are uploaded and appended. With no
+project given, pick one from a list.
+
+Examples:
+  linctl project update-status "Q3 Launch" --health onTrack --body-file update.md
+  linctl project update-status PROJECT-ID --health at-risk --body "Blocked on the API review"
+  linctl project update-status PROJECT-ID --health on-track -F notes.md --image burndown.png`,
+	Args: argOrPick,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		input := map[string]interface{}{}
+		if health, _ := cmd.Flags().GetString("health"); health != "" {
+			value, err := parseHealth(health)
+			if err != nil {
+				output.Error(err.Error(), plaintext, jsonOut)
+				exit(1)
+			}
+			input["health"] = value
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		projectRef, err := argFromPick(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		projectID, err := resolveProjectID(ctx, client, projectRef)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		body, err := projectUpdateBody(ctx, cmd, client, plaintext, jsonOut)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		input["projectId"] = projectID
+		input["body"] = body
+
+		update, err := client.CreateProjectUpdate(ctx, input)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to post project update: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(update)
+		} else if plaintext {
+			fmt.Printf("Posted project update (%s)\n", formatHealth(update.Health))
+			if update.URL != "" {
+				fmt.Println(update.URL)
+			}
+		} else {
+			fmt.Printf("%s Posted project update %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				healthColor(update.Health).Sprint(formatHealth(update.Health)))
+			if update.URL != "" {
+				fmt.Printf("  %s\n", color.New(color.FgBlue, color.Underline).Sprint(update.URL))
+			}
+		}
+	},
+}
+
+var projectUpdatesCmd = &cobra.Command{
+	Use:   "updates [PROJECT]",
+	Short: "List a project's status updates, newest first",
+	Long: `List a project's status updates, newest first, with their health and
+markdown bodies. With no project given, pick one from a list.`,
+	Args:        argOrPick,
+	Annotations: map[string]string{pagerAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		projectRef, err := argFromPick(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		projectID, err := resolveProjectID(ctx, client, projectRef)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		updates, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: api.MaxPageSize}, func(ctx context.Context, first int, after string) ([]api.ProjectUpdate, api.PageInfo, error) {
+			page, err := client.GetProjectUpdates(ctx, projectID, first, after)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			if page.PageInfo == nil {
+				return page.Nodes, api.PageInfo{}, nil
+			}
+			return page.Nodes, *page.PageInfo, nil
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list project updates: %v", err), plaintext, jsonOut)
+		}
+		sort.SliceStable(updates, func(i, j int) bool { return updates[i].CreatedAt.After(updates[j].CreatedAt) })
+		if limit > 0 && len(updates) > limit {
+			updates = updates[:limit]
+		}
+
+		if jsonOut {
+			output.JSON(updates)
+			return
+		}
+		if len(updates) == 0 {
+			output.Info("No project updates found", plaintext, jsonOut)
+			return
+		}
+
+		for i, update := range updates {
+			author := "Unknown"
+			if update.User != nil {
+				author = update.User.Name
+			}
+			if plaintext {
+				fmt.Printf("## %s by %s\n", update.CreatedAt.Local().Format("2006-01-02 15:04"), author)
+				fmt.Printf("- **Health**: %s\n", formatHealth(update.Health))
+				fmt.Printf("\n%s\n\n", strings.TrimRight(update.Body, "\n"))
+				continue
+			}
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s %s %s\n",
+				healthColor(update.Health).Sprint("● "+formatHealth(update.Health)),
+				color.New(color.FgCyan).Sprint(author),
+				color.New(color.FgWhite, color.Faint).Sprintf("· %s (%s)", update.CreatedAt.Local().Format("2006-01-02 15:04"), formatTimeAgo(update.CreatedAt)))
+			for _, line := range strings.Split(strings.TrimRight(output.RenderMarkdown(update.Body), "\n"), "\n") {
+				fmt.Printf("  %s\n", line)
+			}
+		}
+	},
+}
+
+// parseHealth reads a project health: onTrack, atRisk, or offTrack, in any
+// case and with or without a space or dash
+func parseHealth(health string) (string, error) {
+	normalized := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(health))
+	for _, value := range []string{"onTrack", "atRisk", "offTrack"} {
+		if normalized == strings.ToLower(value) {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("invalid health '%s': use on-track, at-risk, or off-track", health)
+}
+
+// projectUpdateBody builds a project update's markdown from --body or
+// --body-file, uploading the images the file references by local path and
+// appending the --image files
+func projectUpdateBody(ctx context.Context, cmd *cobra.Command, client *api.Client, plaintext, jsonOut bool) (string, error) {
+	body, _ := cmd.Flags().GetString("body")
+	bodyFile, _ := cmd.Flags().GetString("body-file")
+	imagePaths, _ := cmd.Flags().GetStringArray("image")
+
+	if body != "" && bodyFile != "" {
+		return "", fmt.Errorf("--body and --body-file cannot be used together")
+	}
+	if bodyFile != "" {
+		content, err := readMarkdownInput(bodyFile)
+		if err != nil {
+			return "", err
+		}
+		baseDir := "."
+		if bodyFile != "-" {
+			baseDir = filepath.Dir(bodyFile)
+		}
+		body, err = uploadLocalImages(ctx, client, strings.TrimRight(content, "\n"), baseDir, plaintext, jsonOut)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	for _, imagePath := range imagePaths {
+		assetURL, err := uploadFile(ctx, client, imagePath, files.UploadOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to upload image %s: %v", imagePath, err)
+		}
+		body = files.InjectImageIntoMarkdown(body, assetURL, filepath.Base(imagePath))
+		if !jsonOut && !plaintext {
+			fmt.Printf("  ✓ Uploaded: %s\n", filepath.Base(imagePath))
+		}
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return "", fmt.Errorf("a project update needs a body (--body, --body-file, or --image)")
+	}
+	return body, nil
+}
+
+func init() {
+	projectCmd.AddCommand(projectStatusUpdateCmd)
+	projectCmd.AddCommand(projectUpdatesCmd)
+	pickable("project", projectStatusUpdateCmd, projectUpdatesCmd)
+
+	projectStatusUpdateCmd.Flags().String("health", "", "Project health: on-track, at-risk, or off-track")
+	projectStatusUpdateCmd.Flags().StringP("body", "b", "", "Update body (markdown)")
+	projectStatusUpdateCmd.Flags().StringP("body-file", "F", "", "Read the body from a markdown file ('-' for stdin); images it references by local path are uploaded")
+	projectStatusUpdateCmd.Flags().StringArrayP("image", "i", []string{}, "Path to image file(s) to upload and append (can be used multiple times)")
+	projectUpdatesCmd.Flags().IntP("limit", "l", 10, "Maximum number of updates to show (0 for all)")
+}
@@ -0,0 +1,571 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// queuedOp is a mutating command saved with --queue to be replayed by 'queue flush'
+type queuedOp struct {
+	ID       int       `json:"id"`
+	Command  string    `json:"command"`
+	Args     []string  `json:"args"`
+	Issue    string    `json:"issue,omitempty"`
+	Stdin    string    `json:"stdin,omitempty"`
+	QueuedAt time.Time `json:"queuedAt"`
+	// BaseUpdatedAt is the issue's updatedAt when the change was queued (from
+	// the local cache, or the queue time when the issue is not cached). A newer
+	// updatedAt at flush time means someone else changed the issue meanwhile.
+	BaseUpdatedAt *time.Time `json:"baseUpdatedAt,omitempty"`
+	Status        string     `json:"status"`
+	Error         string     `json:"error,omitempty"`
+}
+
+// Queued operation statuses
+const (
+	queueStatusPending  = "pending"
+	queueStatusFailed   = "failed"
+	queueStatusConflict = "conflict"
+)
+
+// queueSkippedFlags are not replayed: output format is chosen by 'queue flush',
+// the profile is the one the queue belongs to, and --current and --editor are
+// resolved when the change is queued
+var queueSkippedFlags = map[string]bool{
+	"queue": true, "json": true, "jsonl": true, "plaintext": true, "profile": true, "current": true, "editor": true,
+}
+
+// queuePathFlags name files; they are made absolute so a flush from another
+// directory reads the same files
+var queuePathFlags = map[string]bool{
+	"from-file": true, "body-file": true, "image": true,
+}
+
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Manage changes queued while offline",
+	Long: `Manage mutations saved with --queue on 'issue create', 'issue update', and
+'comment create'. Queued changes are stored in ~/.linctl/queue/<profile>.json
+and applied in order by 'queue flush'.
+
+Before replaying an update or comment, flush checks whether the issue changed
+since the change was queued (its updatedAt is newer than the cached copy, or
+than the queue time when the issue was not cached). Such conflicts are left in
+the queue unless --force is given.
+
+Examples:
+  linctl issue update ENG-123 --state Done --queue
+  linctl comment create ENG-123 --body "Fixed on the plane" --queue
+  linctl queue list
+  linctl queue flush
+  linctl queue drop 3`,
+}
+
+var queueListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List queued changes",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		ops, err := loadQueue()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(ops)
+			return
+		}
+		if len(ops) == 0 {
+			output.Info("No queued changes", plaintext, jsonOut)
+			return
+		}
+
+		if plaintext {
+			for _, op := range ops {
+				fmt.Printf("%d\t%s\t%s\t%s\t%s\n", op.ID, op.Status, op.QueuedAt.Format(time.RFC3339), describeQueuedOp(op), op.Error)
+			}
+			return
+		}
+
+		rows := make([][]string, len(ops))
+		for i, op := range ops {
+			status := op.Status
+			switch op.Status {
+			case queueStatusFailed:
+				status = color.New(color.FgRed).Sprint(status)
+			case queueStatusConflict:
+				status = color.New(color.FgYellow).Sprint(status)
+			}
+			rows[i] = []string{
+				strconv.Itoa(op.ID),
+				status,
+				formatTimeAgo(op.QueuedAt),
+				describeQueuedOp(op),
+				op.Error,
+			}
+		}
+		output.Table(output.TableData{
+			Headers: []string{"ID", "Status", "Queued", "Command", "Error"},
+			Rows:    rows,
+		}, false, false)
+		fmt.Printf("\n%s %d queued changes\n", color.New(color.FgGreen).Sprint("✓"), len(ops))
+	},
+}
+
+var queueFlushCmd = &cobra.Command{
+	Use:     "flush [ID...]",
+	Aliases: []string{"replay", "push"},
+	Short:   "Apply queued changes",
+	Long: `Apply queued changes in the order they were queued (or only the given IDs).
+
+Applied changes are removed from the queue. Failed and conflicting changes stay
+queued with their error; later changes to the same issue are held back so they
+are not applied out of order.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		force, _ := cmd.Flags().GetBool("force")
+		client := authenticatedClient(plaintext, jsonOut)
+
+		ops, err := loadQueue()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		selected, err := selectQueuedOps(ops, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		exe, err := os.Executable()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to find the linctl executable: %v", err), plaintext, jsonOut)
+		}
+
+		ctx := context.Background()
+		applied := []int{}
+		failures := []string{}
+		held := make(map[string]bool)
+		// latest records each issue's updatedAt after our own changes, so a
+		// change we just applied is not mistaken for a conflict
+		latest := make(map[string]time.Time)
+		remaining := []queuedOp{}
+
+		for i, op := range ops {
+			if !selected[op.ID] {
+				remaining = append(remaining, op)
+				continue
+			}
+			issueKey := strings.ToUpper(op.Issue)
+
+			fail := func(status, message string) {
+				op.Status = status
+				op.Error = message
+				remaining = append(remaining, op)
+				if issueKey != "" {
+					held[issueKey] = true
+				}
+				failures = append(failures, fmt.Sprintf("#%d %s: %s", op.ID, describeQueuedOp(op), message))
+				if !jsonOut {
+					output.Error(fmt.Sprintf("Failed to apply #%d %s: %s", op.ID, describeQueuedOp(op), message), plaintext, false)
+				}
+			}
+
+			if issueKey != "" && held[issueKey] {
+				fail(queueStatusPending, "held back by an earlier change to "+op.Issue+" that was not applied")
+				continue
+			}
+
+			if issueKey != "" && op.BaseUpdatedAt != nil && !force {
+				issue, err := client.GetIssue(ctx, op.Issue)
+				if err != nil {
+					fail(queueStatusFailed, fmt.Sprintf("failed to get issue: %v", err))
+					continue
+				}
+				base := *op.BaseUpdatedAt
+				if t, ok := latest[issueKey]; ok && t.After(base) {
+					base = t
+				}
+				if issue.UpdatedAt.After(base) {
+					fail(queueStatusConflict, fmt.Sprintf("%s changed %s, after this change was queued (use --force to apply anyway)",
+						op.Issue, formatTimeAgo(issue.UpdatedAt)))
+					continue
+				}
+			}
+
+			if err := replayQueuedOp(ctx, exe, op); err != nil {
+				status := queueStatusFailed
+				if replayErr, ok := err.(*queueReplayError); ok && replayErr.retryable {
+					status = queueStatusPending
+				}
+				fail(status, err.Error())
+				continue
+			}
+
+			// Save after every applied change so an interrupted flush never
+			// replays it a second time
+			applied = append(applied, op.ID)
+			if err := saveQueue(append(append([]queuedOp{}, remaining...), ops[i+1:]...)); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to save queue: %v", err), plaintext, jsonOut)
+			}
+			if issueKey != "" {
+				if issue, err := client.GetIssue(ctx, op.Issue); err == nil {
+					latest[issueKey] = issue.UpdatedAt
+				}
+			}
+			if !jsonOut {
+				if plaintext {
+					fmt.Printf("Applied #%d %s\n", op.ID, describeQueuedOp(op))
+				} else {
+					fmt.Printf("%s #%d %s\n", color.New(color.FgGreen).Sprint("✓"), op.ID, describeQueuedOp(op))
+				}
+			}
+		}
+
+		if err := saveQueue(remaining); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to save queue: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{
+				"applied":   applied,
+				"errors":    failures,
+				"remaining": len(remaining),
+			})
+		} else if plaintext {
+			fmt.Printf("\nApplied: %d, Failed: %d, Remaining: %d\n", len(applied), len(failures), len(remaining))
+		} else {
+			fmt.Printf("\n%s Applied %d queued changes", color.New(color.FgGreen).Sprint("✓"), len(applied))
+			if len(failures) > 0 {
+				fmt.Printf(", %s", color.New(color.FgRed).Sprintf("%d not applied", len(failures)))
+			}
+			fmt.Println()
+		}
+
+		exitOnFailures(len(applied), len(failures))
+	},
+}
+
+var queueDropCmd = &cobra.Command{
+	Use:     "drop [ID...]",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Discard queued changes",
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		all, _ := cmd.Flags().GetBool("all")
+		if all == (len(args) > 0) {
+			output.Error("Give the IDs of the changes to drop, or --all", plaintext, jsonOut)
+			exit(1)
+		}
+
+		ops, err := loadQueue()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		selected, err := selectQueuedOps(ops, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		dropped := []int{}
+		remaining := ops[:0]
+		for _, op := range ops {
+			if selected[op.ID] {
+				dropped = append(dropped, op.ID)
+			} else {
+				remaining = append(remaining, op)
+			}
+		}
+		if err := saveQueue(remaining); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to save queue: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"dropped": dropped})
+			return
+		}
+		output.Success(fmt.Sprintf("Dropped %d queued changes", len(dropped)), plaintext, jsonOut)
+	},
+}
+
+// queueIfRequested saves the running command to the offline queue instead of
+// running it when --queue is set, and reports whether it did. issueRef is the
+// issue the command changes, if any, for conflict detection.
+func queueIfRequested(cmd *cobra.Command, args []string, issueRef string) bool {
+	plaintext := viper.GetBool("plaintext")
+	jsonOut := viper.GetBool("json")
+
+	if queued, _ := cmd.Flags().GetBool("queue"); !queued {
+		return false
+	}
+
+	op, err := newQueuedOp(cmd, args, issueRef)
+	if err != nil {
+		exitWithError(err, err.Error(), plaintext, jsonOut)
+	}
+
+	ops, err := loadQueue()
+	if err != nil {
+		exitWithError(err, err.Error(), plaintext, jsonOut)
+	}
+	for _, existing := range ops {
+		if existing.ID >= op.ID {
+			op.ID = existing.ID + 1
+		}
+	}
+	if err := saveQueue(append(ops, *op)); err != nil {
+		exitWithError(err, fmt.Sprintf("Failed to save queue: %v", err), plaintext, jsonOut)
+	}
+
+	if jsonOut {
+		output.JSON(op)
+	} else if plaintext {
+		fmt.Printf("Queued #%d %s\n", op.ID, describeQueuedOp(*op))
+	} else {
+		fmt.Printf("%s Queued #%d %s\n  %s\n",
+			color.New(color.FgGreen).Sprint("✓"),
+			op.ID,
+			describeQueuedOp(*op),
+			color.New(color.FgWhite, color.Faint).Sprint("Run 'linctl queue flush' when back online"))
+	}
+	return true
+}
+
+// newQueuedOp records cmd's arguments and changed flags so the command can be
+// run again later. Input read from stdin is captured now.
+func newQueuedOp(cmd *cobra.Command, args []string, issueRef string) (*queuedOp, error) {
+	op := &queuedOp{
+		ID:       1,
+		Command:  strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+		Issue:    issueRef,
+		QueuedAt: time.Now(),
+		Status:   queueStatusPending,
+	}
+	op.Args = append(strings.Fields(op.Command), args...)
+
+	var flagErr error
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if queueSkippedFlags[f.Name] || flagErr != nil {
+			return
+		}
+		values := []string{f.Value.String()}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			values = slice.GetSlice()
+		}
+		for _, value := range values {
+			if queuePathFlags[f.Name] {
+				if value == "-" {
+					data, err := readMarkdownInput("-")
+					if err != nil {
+						flagErr = err
+						return
+					}
+					op.Stdin = data
+				} else if abs, err := filepath.Abs(value); err == nil {
+					value = abs
+				}
+			}
+			op.Args = append(op.Args, "--"+f.Name+"="+value)
+		}
+	})
+	if flagErr != nil {
+		return nil, flagErr
+	}
+
+	if issueRef != "" {
+		base := op.QueuedAt
+		if store, err := loadCache(); err == nil {
+			if issue, err := store.Issue(issueRef); err == nil && issue != nil {
+				base = issue.UpdatedAt
+			}
+			store.Close()
+		}
+		op.BaseUpdatedAt = &base
+	}
+	return op, nil
+}
+
+// replayQueuedOp runs a queued command with this linctl binary
+func replayQueuedOp(ctx context.Context, exe string, op queuedOp) error {
+	args := append(append([]string{}, op.Args...), "--json", "--profile="+auth.Profile())
+	run := exec.CommandContext(ctx, exe, args...)
+	run.Stdin = strings.NewReader(op.Stdin)
+	var stderr bytes.Buffer
+	run.Stdout = io.Discard
+	run.Stderr = &stderr
+
+	err := run.Run()
+	if err == nil {
+		return nil
+	}
+	replayErr := &queueReplayError{message: err.Error()}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		code := exitErr.ExitCode()
+		replayErr.retryable = code == exitRateLimited || code == exitUnavailable
+	}
+
+	// With --json the command reports its failure as an error object on stderr
+	var result struct {
+		Error string `json:"error"`
+		Code  string `json:"code"`
+	}
+	if json.Unmarshal(stderr.Bytes(), &result) == nil && result.Error != "" {
+		replayErr.message = result.Error
+		switch result.Code {
+		case string(api.KindRateLimited), string(api.KindServer), errorCodeNetwork:
+			replayErr.retryable = true
+		}
+	} else if message := strings.TrimSpace(stderr.String()); message != "" {
+		replayErr.message = message
+	}
+	return replayErr
+}
+
+// queueReplayError is why a replayed command failed. Retryable failures
+// (rate limited, or Linear unreachable) may succeed on a later flush; others
+// need the change fixed or dropped.
+type queueReplayError struct {
+	message   string
+	retryable bool
+}
+
+func (e *queueReplayError) Error() string {
+	return e.message
+}
+
+// selectQueuedOps returns the set of IDs to act on: those given, or all
+func selectQueuedOps(ops []queuedOp, ids []string) (map[int]bool, error) {
+	selected := make(map[int]bool)
+	if len(ids) == 0 {
+		for _, op := range ops {
+			selected[op.ID] = true
+		}
+		return selected, nil
+	}
+
+	known := make(map[int]bool)
+	for _, op := range ops {
+		known[op.ID] = true
+	}
+	for _, value := range ids {
+		id, err := strconv.Atoi(strings.TrimPrefix(value, "#"))
+		if err != nil || !known[id] {
+			return nil, fmt.Errorf("no queued change with ID %s (see 'linctl queue list')", value)
+		}
+		selected[id] = true
+	}
+	return selected, nil
+}
+
+// describeQueuedOp formats a queued command as it would be typed
+func describeQueuedOp(op queuedOp) string {
+	parts := make([]string, len(op.Args))
+	for i, arg := range op.Args {
+		name, value, isFlag := strings.Cut(arg, "=")
+		if !isFlag || !strings.HasPrefix(name, "--") {
+			name, value = "", arg
+		}
+		if strings.ContainsAny(value, " \t\"'") {
+			value = strconv.Quote(value)
+		}
+		if name != "" {
+			value = name + "=" + value
+		}
+		parts[i] = value
+	}
+	return strings.Join(parts, " ")
+}
+
+// queuePath is ~/.linctl/queue/PROFILE.json
+func queuePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl", "queue", auth.Profile()+".json"), nil
+}
+
+// loadQueue reads the active profile's queue (empty when there is none)
+func loadQueue() ([]queuedOp, error) {
+	path, err := queuePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []queuedOp{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queue: %w", err)
+	}
+	ops := []queuedOp{}
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("failed to parse queue %s: %w", path, err)
+	}
+	return ops, nil
+}
+
+// saveQueue replaces the active profile's queue, removing the file when empty
+func saveQueue(ops []queuedOp) error {
+	path, err := queuePath()
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(ops, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func init() {
+	rootCmd.AddCommand(queueCmd)
+	queueCmd.AddCommand(queueListCmd)
+	queueCmd.AddCommand(queueFlushCmd)
+	queueCmd.AddCommand(queueDropCmd)
+
+	queueFlushCmd.Flags().Bool("force", false, "Apply changes even if the issue changed after they were queued")
+	queueDropCmd.Flags().Bool("all", false, "Drop every queued change")
+
+	for _, c := range []*cobra.Command{issueCreateCmd, issueUpdateCmd, commentCreateCmd} {
+		c.Flags().Bool("queue", false, "Save the change to the offline queue instead of sending it (see 'linctl queue')")
+	}
+}
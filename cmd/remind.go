@@ -0,0 +1,462 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// reminder is an issue to come back to, saved by 'linctl remind'
+type reminder struct {
+	ID         int        `json:"id"`
+	Issue      string     `json:"issue"`
+	Title      string     `json:"title"`
+	URL        string     `json:"url"`
+	Note       string     `json:"note,omitempty"`
+	At         time.Time  `json:"at"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	InLinear   bool       `json:"inLinear,omitempty"`
+	NotifiedAt *time.Time `json:"notifiedAt,omitempty"`
+}
+
+// due reports whether the reminder's time has come
+func (r reminder) due(now time.Time) bool {
+	return !r.At.After(now)
+}
+
+// reminderHour is the time of day of reminders set for a date
+const reminderHour = 9
+
+// reminderHoursPattern matches offsets in hours and minutes, which
+// utils.ParseDayOffset doesn't take ("m" is months there)
+var reminderHoursPattern = regexp.MustCompile(`^(\d+)\s*(h|hr|hrs|hours?|min|mins|minutes?)$`)
+
+var remindCmd = &cobra.Command{
+	Use:   "remind ISSUE-ID",
+	Short: "Set a reminder to come back to an issue",
+	Long: `Set a reminder to come back to an issue later, with an optional note.
+
+Reminders are kept in ~/.linctl/reminders/<profile>.json. --linear also sets
+the reminder in Linear, which notifies you in its inbox and apps.
+
+'remind check' shows the reminders that are due and can send desktop
+notifications (run it from cron or a login script), and 'remind check
+--prompt' prints a short marker for your shell prompt without calling Linear:
+
+  PS1='$(linctl remind check --prompt)'$PS1           # bash
+  precmd() { RPROMPT=$(linctl remind check --prompt) }  # zsh
+
+Examples:
+  linctl remind ENG-123 --in 3d --note "check with design"
+  linctl remind ENG-123 --in 2h
+  linctl remind ENG-123 --at friday --linear
+  linctl remind ENG-123 --at "2025-07-01 14:30"
+  linctl remind list
+  linctl remind done 3`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		in, _ := cmd.Flags().GetString("in")
+		at, _ := cmd.Flags().GetString("at")
+		when, err := parseReminderTime(in, at, time.Now())
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+		issue, err := client.ResolveIssue(ctx, args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		note, _ := cmd.Flags().GetString("note")
+		r := reminder{
+			Issue:     issue.Identifier,
+			Title:     issue.Title,
+			URL:       issue.URL,
+			Note:      note,
+			At:        when,
+			CreatedAt: time.Now(),
+		}
+		if inLinear, _ := cmd.Flags().GetBool("linear"); inLinear {
+			if err := client.SetIssueReminder(ctx, issue.ID, when); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to set the reminder in Linear: %v", err), plaintext, jsonOut)
+			}
+			r.InLinear = true
+		}
+
+		reminders, err := loadReminders()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		for _, existing := range reminders {
+			if existing.ID >= r.ID {
+				r.ID = existing.ID + 1
+			}
+		}
+		if r.ID == 0 {
+			r.ID = 1
+		}
+		if err := saveReminders(append(reminders, r)); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to save reminder: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(r)
+			return
+		}
+		output.Success(fmt.Sprintf("Reminder #%d set for %s %s (%s)", r.ID, r.Issue, formatReminderTime(r.At), formatTimeUntil(r.At)), plaintext, jsonOut)
+	},
+}
+
+var remindListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List reminders",
+	Long:    `List reminders, soonest first. ◆ marks those also set in Linear.`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		reminders, err := loadReminders()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if dueOnly, _ := cmd.Flags().GetBool("due"); dueOnly {
+			reminders = dueReminders(reminders, time.Now())
+		}
+		renderReminders(reminders, plaintext, jsonOut)
+	},
+}
+
+var remindDoneCmd = &cobra.Command{
+	Use:     "done ID...",
+	Aliases: []string{"dismiss", "rm", "delete"},
+	Short:   "Remove reminders",
+	Long: `Remove reminders, by the ID 'remind list' shows or by issue identifier.
+Reminders set in Linear with --linear stay there.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		reminders, err := loadReminders()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		selected := map[int]bool{}
+		for _, value := range args {
+			matched := false
+			id, convErr := strconv.Atoi(strings.TrimPrefix(value, "#"))
+			for _, r := range reminders {
+				if (convErr == nil && r.ID == id) || strings.EqualFold(r.Issue, value) {
+					selected[r.ID] = true
+					matched = true
+				}
+			}
+			if !matched {
+				output.Error(fmt.Sprintf("No reminder %s (see 'linctl remind list')", value), plaintext, jsonOut)
+				exit(1)
+			}
+		}
+
+		removed := []int{}
+		remaining := reminders[:0]
+		for _, r := range reminders {
+			if selected[r.ID] {
+				removed = append(removed, r.ID)
+			} else {
+				remaining = append(remaining, r)
+			}
+		}
+		if err := saveReminders(remaining); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to save reminders: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"removed": removed})
+			return
+		}
+		output.Success(fmt.Sprintf("Removed %d reminders", len(removed)), plaintext, jsonOut)
+	},
+}
+
+var remindCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Show reminders that are due",
+	Long: `Show the reminders whose time has come. --notify also sends a desktop
+notification for each one not notified before, so running 'remind check
+--notify' every few minutes from cron notifies once per reminder.
+
+--prompt prints only a short marker such as "⏰2 " when reminders are due, and
+nothing otherwise, for use in a shell prompt.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		reminders, err := loadReminders()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		now := time.Now()
+		due := dueReminders(reminders, now)
+
+		if prompt, _ := cmd.Flags().GetBool("prompt"); prompt {
+			if len(due) > 0 {
+				fmt.Printf("⏰%d ", len(due))
+			}
+			return
+		}
+
+		if notify, _ := cmd.Flags().GetBool("notify"); notify {
+			if _, err := notifyReminders(reminders, now); err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			due = dueReminders(reminders, now)
+		}
+
+		renderReminders(due, plaintext, jsonOut)
+	},
+}
+
+// notifyReminders sends a desktop notification for each due reminder not
+// notified before, and saves when they were notified
+func notifyReminders(reminders []reminder, now time.Time) (notified int, err error) {
+	for i := range reminders {
+		r := &reminders[i]
+		if !r.due(now) || r.NotifiedAt != nil {
+			continue
+		}
+		message := r.Title
+		if r.Note != "" {
+			message += "\n" + r.Note
+		}
+		if err = utils.Notify("Reminder: "+r.Issue, message); err != nil {
+			err = fmt.Errorf("failed to send notification: %w", err)
+			break
+		}
+		r.NotifiedAt = &now
+		notified++
+	}
+	if notified > 0 {
+		if saveErr := saveReminders(reminders); saveErr != nil {
+			return notified, fmt.Errorf("failed to save reminders: %w", saveErr)
+		}
+	}
+	return notified, err
+}
+
+// renderReminders prints reminders, soonest first
+func renderReminders(reminders []reminder, plaintext, jsonOut bool) {
+	if jsonOut {
+		output.JSON(reminders)
+		return
+	}
+	if len(reminders) == 0 {
+		output.Info("No reminders", plaintext, jsonOut)
+		return
+	}
+
+	if plaintext {
+		for _, r := range reminders {
+			fmt.Printf("%d\t%s\t%s\t%s\t%s\n", r.ID, r.Issue, r.At.Format(time.RFC3339), r.Title, r.Note)
+		}
+		return
+	}
+
+	now := time.Now()
+	rows := make([][]string, len(reminders))
+	for i, r := range reminders {
+		when := formatReminderTime(r.At) + " (" + formatTimeUntil(r.At) + ")"
+		if r.due(now) {
+			when = output.ThemeColor("due.overdue").Sprint(when)
+		}
+		issue := r.Issue
+		if r.InLinear {
+			issue += " ◆"
+		}
+		rows[i] = []string{
+			strconv.Itoa(r.ID),
+			color.New(color.FgCyan, color.Bold).Sprint(issue),
+			when,
+			r.Title,
+			r.Note,
+		}
+	}
+	output.Table(output.TableData{
+		Headers: []string{"ID", "Issue", "When", "Title", "Note"},
+		Rows:    rows,
+	}, false, false)
+	fmt.Printf("\n%s %d reminders\n", color.New(color.FgGreen).Sprint("✓"), len(reminders))
+}
+
+// dueReminders are the reminders whose time has come
+func dueReminders(reminders []reminder, now time.Time) []reminder {
+	due := []reminder{}
+	for _, r := range reminders {
+		if r.due(now) {
+			due = append(due, r)
+		}
+	}
+	return due
+}
+
+// parseReminderTime is when a reminder set with --in or --at goes off. --in
+// takes an offset from now (3d, 2w, 4h, 30min); --at a date (YYYY-MM-DD,
+// friday, next week, ...) at 9:00, a time today or tomorrow (14:30), or both
+// ("2025-07-01 14:30").
+func parseReminderTime(in, at string, now time.Time) (time.Time, error) {
+	in = strings.TrimSpace(in)
+	at = strings.TrimSpace(at)
+	if (in == "") == (at == "") {
+		return time.Time{}, fmt.Errorf("give when to remind you with --in or --at")
+	}
+
+	if in != "" {
+		expr := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(in), "in "), "+")
+		if match := reminderHoursPattern.FindStringSubmatch(expr); match != nil {
+			n, _ := strconv.Atoi(match[1])
+			if strings.HasPrefix(match[2], "h") {
+				return now.Add(time.Duration(n) * time.Hour), nil
+			}
+			return now.Add(time.Duration(n) * time.Minute), nil
+		}
+		offset, err := utils.ParseDayOffset(expr)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --in '%s' (use 3d, 2w, 4h, or 30min)", in)
+		}
+		return offset(now), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, at); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", at, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("15:04", at, now.Location()); err == nil {
+		today := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !today.After(now) {
+			today = today.AddDate(0, 0, 1)
+		}
+		return today, nil
+	}
+	day, err := utils.ParseDueDate(at, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --at '%s' (use a date like friday or 2025-07-01, a time like 14:30, or both)", at)
+	}
+	t, _ := time.ParseInLocation("2006-01-02", day, now.Location())
+	return t.Add(reminderHour * time.Hour), nil
+}
+
+// formatReminderTime formats a reminder's time in local time
+func formatReminderTime(t time.Time) string {
+	return t.Local().Format("Mon Jan 2 15:04")
+}
+
+// formatTimeUntil says how far off t is: "in 3 days", or "due" once passed
+func formatTimeUntil(t time.Time) string {
+	duration := time.Until(t)
+	switch {
+	case duration <= 0:
+		return "due " + formatTimeAgo(t)
+	case duration < time.Hour:
+		return fmt.Sprintf("in %d minutes", int(duration.Minutes())+1)
+	case duration < 24*time.Hour:
+		return fmt.Sprintf("in %d hours", int(duration.Hours()+0.5))
+	default:
+		return fmt.Sprintf("in %d days", int(duration.Hours()/24+0.5))
+	}
+}
+
+// remindersPath is ~/.linctl/reminders/PROFILE.json
+func remindersPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl", "reminders", auth.Profile()+".json"), nil
+}
+
+// loadReminders reads the active profile's reminders, soonest first
+func loadReminders() ([]reminder, error) {
+	path, err := remindersPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []reminder{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reminders: %w", err)
+	}
+	reminders := []reminder{}
+	if err := json.Unmarshal(data, &reminders); err != nil {
+		return nil, fmt.Errorf("failed to parse reminders %s: %w", path, err)
+	}
+	return reminders, nil
+}
+
+// saveReminders replaces the active profile's reminders, keeping them in
+// time order and removing the file when there are none
+func saveReminders(reminders []reminder) error {
+	path, err := remindersPath()
+	if err != nil {
+		return err
+	}
+	if len(reminders) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	sort.SliceStable(reminders, func(i, j int) bool { return reminders[i].At.Before(reminders[j].At) })
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(reminders, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func init() {
+	rootCmd.AddCommand(remindCmd)
+	remindCmd.AddCommand(remindListCmd)
+	remindCmd.AddCommand(remindDoneCmd)
+	remindCmd.AddCommand(remindCheckCmd)
+
+	remindCmd.Flags().String("in", "", "Remind after this long: 3d, 2w, 4h, 30min")
+	remindCmd.Flags().String("at", "", "Remind at this date or time: friday, 2025-07-01, 14:30, \"2025-07-01 14:30\"")
+	remindCmd.Flags().String("note", "", "What to remember")
+	remindCmd.Flags().Bool("linear", false, "Also set the reminder in Linear")
+	remindListCmd.Flags().Bool("due", false, "Only reminders that are due")
+	remindCheckCmd.Flags().Bool("notify", false, "Send a desktop notification for each due reminder, once")
+	remindCheckCmd.Flags().Bool("prompt", false, "Only print a short marker when reminders are due, for a shell prompt")
+}
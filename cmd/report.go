@@ -0,0 +1,776 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/slack"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var reportCmd = &cobra.Command{
+	Use:     "report",
+	Aliases: []string{"reports", "analytics"},
+	Short:   "Delivery reports for cycles, teams, and initiatives",
+	Long: `Reports computed from a team's issues and their history: how a cycle went, how
+long issues take from creation and from starting work to completion, and how
+long they spend in each workflow state, and how well they were estimated. The
+health report rolls up initiatives' projects and their latest status updates.
+
+Reports print as a table, or as JSON (--json) or CSV/TSV (--format) for
+spreadsheets. --notify-slack also posts a summary to a Slack incoming webhook,
+so a report run from cron lands in a channel.
+
+Examples:
+  linctl report cycle --team ENG --cycle current
+  linctl report cycle --team ENG --cycle 41 --format csv > cycle-41.csv
+  linctl report lead-time --team ENG --since 90d
+  linctl report lead-time --team ENG --since 2025-01-01 --by assignee --json
+  linctl report time-in-state --team ENG --since 60d
+  linctl report estimates --team ENG --cycle last
+  linctl report health --format markdown
+  linctl report health --notify-slack leads`,
+}
+
+var reportCycleCmd = &cobra.Command{
+	Use:         "cycle",
+	Short:       "Throughput, scope change, and carry-over for a cycle",
+	Annotations: map[string]string{pagerAnnotation: "true"},
+	Long: `Report on a cycle: issues and points completed, how the scope changed since the
+cycle started, what carried over (or, for a cycle still running, what would
+carry over if it closed now), and a per-assignee breakdown.
+
+--format csv/tsv prints the per-assignee breakdown with a total row.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		teamKey := requireCycleTeam(cmd, plaintext, jsonOut)
+		separator, err := reportSeparator(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		webhookURL, err := slackWebhook(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		selector, _ := cmd.Flags().GetString("cycle")
+		cycle, err := resolveCycleSelector(ctx, client, teamKey, selector)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		cycle, err = client.GetCycleReport(ctx, cycle.ID)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get cycle: %v", err), plaintext, jsonOut)
+		}
+
+		report := buildCycleReport(cycle)
+		notifySlack(webhookURL, cycleReportMessage(cmd, report), plaintext, jsonOut)
+		switch {
+		case jsonOut:
+			output.JSON(report)
+		case separator != 0:
+			headers := []string{"assignee", "issues", "points", "completed", "completed_points", "carry_over", "completion"}
+			var rows [][]string
+			for _, row := range append(report.Assignees, report.Total) {
+				rows = append(rows, []string{
+					row.Name,
+					strconv.Itoa(row.Issues),
+					formatPoints(row.Points),
+					strconv.Itoa(row.Completed),
+					formatPoints(row.CompletedPoints),
+					strconv.Itoa(row.CarryOver),
+					strconv.FormatFloat(row.Completion, 'f', 2, 64),
+				})
+			}
+			if err := output.Delimited(os.Stdout, headers, rows, separator); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write report: %v", err), plaintext, jsonOut)
+			}
+		default:
+			printCycleReport(report, plaintext)
+		}
+	},
+}
+
+var reportLeadTimeCmd = &cobra.Command{
+	Use:         "lead-time",
+	Aliases:     []string{"cycle-time"},
+	Short:       "Lead and cycle time percentiles for completed issues",
+	Annotations: map[string]string{pagerAnnotation: "true"},
+	Long: `Report how long issues completed since --since took. Lead time runs from when an
+issue was created to when it was completed; cycle time runs from when work
+started (the first move into a started state in its history) to completion.
+Issues that were never started are left out of cycle time.
+
+--by splits the report by team, assignee, or priority. --json also includes
+the timings of every issue.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		separator, err := reportSeparator(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		webhookURL, err := slackWebhook(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		groupBy, _ := cmd.Flags().GetString("by")
+		groupBy = strings.ToLower(groupBy)
+		if _, ok := leadTimeGroupings[groupBy]; !ok {
+			output.Error(fmt.Sprintf("Invalid --by '%s' (expected none, team, assignee, or priority)", groupBy), plaintext, jsonOut)
+			exit(1)
+		}
+
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		since, err := utils.ParseTimeExpression(sinceFlag)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Invalid since value: %v", err), plaintext, jsonOut)
+		}
+		filter := map[string]interface{}{
+			"completedAt": map[string]interface{}{"null": false},
+		}
+		if since != "" {
+			filter["completedAt"] = map[string]interface{}{"gte": since}
+		}
+		teamKey, _ := cmd.Flags().GetString("team")
+		if teamKey != "" {
+			filter["team"] = map[string]interface{}{"key": map[string]interface{}{"eq": teamKey}}
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		issues, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: 100}, func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+			page, err := client.GetIssueTimings(ctx, filter, first, after)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issues: %v", err), plaintext, jsonOut)
+		}
+
+		report := buildLeadTimeReport(issues, leadTimeGroupings[groupBy])
+		report.Since = since
+		report.Team = teamKey
+		notifySlack(webhookURL, leadTimeReportMessage(cmd, report), plaintext, jsonOut)
+
+		if jsonOut {
+			output.JSON(report)
+			return
+		}
+		if len(report.Issues) == 0 {
+			output.Info("No completed issues found", plaintext, jsonOut)
+			return
+		}
+
+		grouped := groupBy != "" && groupBy != "none"
+		if separator != 0 {
+			headers := []string{"metric", "count", "mean_days", "p50_days", "p75_days", "p90_days", "p95_days"}
+			if grouped {
+				headers = append([]string{groupBy}, headers...)
+			}
+			days := func(d float64) string { return strconv.FormatFloat(d, 'f', 2, 64) }
+			var rows [][]string
+			for _, group := range report.Groups {
+				for _, metric := range group.metrics() {
+					row := []string{metric.name, strconv.Itoa(metric.stats.Count),
+						days(metric.stats.Mean), days(metric.stats.P50), days(metric.stats.P75), days(metric.stats.P90), days(metric.stats.P95)}
+					if grouped {
+						row = append([]string{group.Name}, row...)
+					}
+					rows = append(rows, row)
+				}
+			}
+			if err := output.Delimited(os.Stdout, headers, rows, separator); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write report: %v", err), plaintext, jsonOut)
+			}
+			return
+		}
+
+		headers := []string{"Metric", "Issues", "Mean", "P50", "P75", "P90", "P95"}
+		if grouped {
+			headers = append([]string{strings.ToUpper(groupBy[:1]) + groupBy[1:]}, headers...)
+		}
+		var rows [][]string
+		for _, group := range report.Groups {
+			for _, metric := range group.metrics() {
+				stats := metric.stats
+				row := []string{metric.name, strconv.Itoa(stats.Count),
+					formatDays(stats.Mean), formatDays(stats.P50), formatDays(stats.P75), formatDays(stats.P90), formatDays(stats.P95)}
+				if stats.Count == 0 {
+					row = []string{metric.name, "0", "", "", "", "", ""}
+				}
+				if grouped {
+					row = append([]string{group.Name}, row...)
+				}
+				rows = append(rows, row)
+			}
+		}
+
+		output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, jsonOut)
+		if !plaintext {
+			period := "all time"
+			if since != "" {
+				period = "since " + cycleDate(since)
+			}
+			fmt.Printf("\n%s %d issues completed %s\n", color.New(color.FgGreen).Sprint("✓"), len(report.Issues), period)
+		}
+	},
+}
+
+// reportSeparator validates --format, returning 0 for table output
+func reportSeparator(cmd *cobra.Command) (rune, error) {
+	format, _ := cmd.Flags().GetString("format")
+	switch strings.ToLower(format) {
+	case "", "table":
+		return 0, nil
+	case "csv":
+		return ',', nil
+	case "tsv":
+		return '\t', nil
+	default:
+		return 0, fmt.Errorf("invalid format: %s. Valid options are: table, csv, tsv", format)
+	}
+}
+
+// cycleReport summarizes how a cycle went
+type cycleReport struct {
+	Team       string              `json:"team"`
+	Number     int                 `json:"number"`
+	Name       string              `json:"name,omitempty"`
+	StartsAt   string              `json:"startsAt"`
+	EndsAt     string              `json:"endsAt"`
+	Status     string              `json:"status"`
+	Closed     bool                `json:"closed"`
+	Throughput cycleThroughput     `json:"throughput"`
+	Scope      cycleScope          `json:"scope"`
+	CarryOver  []reportIssue       `json:"carryOver"`
+	Assignees  []cycleAssigneeStat `json:"assignees"`
+	Total      cycleAssigneeStat   `json:"total"`
+}
+
+// cycleThroughput is the work a cycle finished
+type cycleThroughput struct {
+	IssuesCompleted int     `json:"issuesCompleted"`
+	PointsCompleted float64 `json:"pointsCompleted"`
+	IssuesCanceled  int     `json:"issuesCanceled"`
+	CompletionRate  float64 `json:"completionRate"`
+}
+
+// cycleAssigneeStat is one assignee's share of a cycle
+type cycleAssigneeStat struct {
+	Name            string  `json:"name"`
+	Issues          int     `json:"issues"`
+	Points          float64 `json:"points"`
+	Completed       int     `json:"completed"`
+	CompletedPoints float64 `json:"completedPoints"`
+	CarryOver       int     `json:"carryOver"`
+	Completion      float64 `json:"completion"`
+}
+
+// reportIssue is an issue listed in a report
+type reportIssue struct {
+	Identifier string   `json:"identifier"`
+	Title      string   `json:"title"`
+	State      string   `json:"state,omitempty"`
+	Assignee   string   `json:"assignee,omitempty"`
+	Estimate   *float64 `json:"estimate,omitempty"`
+}
+
+// buildCycleReport computes throughput, carry-over, and the assignee breakdown.
+// A closed cycle's carry-over is what Linear moved out when it closed; for an
+// open cycle it is every issue not yet completed or canceled.
+func buildCycleReport(cycle *api.Cycle) cycleReport {
+	report := cycleReport{
+		Number:    cycle.Number,
+		Name:      cycle.Name,
+		StartsAt:  cycle.StartsAt,
+		EndsAt:    cycle.EndsAt,
+		Status:    cycleStatus(*cycle),
+		Closed:    cycle.CompletedAt != nil,
+		Scope:     buildCycleSummary(cycle).Scope,
+		CarryOver: []reportIssue{},
+		Assignees: []cycleAssigneeStat{},
+		Total:     cycleAssigneeStat{Name: "Total"},
+	}
+	if cycle.Team != nil {
+		report.Team = cycle.Team.Key
+	}
+
+	var issues []api.Issue
+	if cycle.Issues != nil {
+		issues = append(issues, cycle.Issues.Nodes...)
+	}
+	carriedOver := make(map[string]bool)
+	if report.Closed {
+		if cycle.UncompletedIssuesUponClose != nil {
+			seen := make(map[string]bool)
+			for _, issue := range issues {
+				seen[issue.ID] = true
+			}
+			for _, issue := range cycle.UncompletedIssuesUponClose.Nodes {
+				carriedOver[issue.ID] = true
+				if !seen[issue.ID] {
+					issues = append(issues, issue)
+				}
+			}
+		}
+	} else {
+		for _, issue := range issues {
+			if issue.State == nil || (issue.State.Type != "completed" && issue.State.Type != "canceled") {
+				carriedOver[issue.ID] = true
+			}
+		}
+	}
+
+	assignees := make(map[string]*cycleAssigneeStat)
+	for _, issue := range issues {
+		points := 0.0
+		if issue.Estimate != nil {
+			points = *issue.Estimate
+		}
+		name := "Unassigned"
+		if issue.Assignee != nil {
+			name = issue.Assignee.Name
+		}
+		if assignees[name] == nil {
+			assignees[name] = &cycleAssigneeStat{Name: name}
+		}
+
+		for _, stat := range []*cycleAssigneeStat{assignees[name], &report.Total} {
+			stat.Issues++
+			stat.Points += points
+		}
+
+		state := ""
+		if issue.State != nil {
+			state = issue.State.Name
+		}
+		switch {
+		case carriedOver[issue.ID]:
+			for _, stat := range []*cycleAssigneeStat{assignees[name], &report.Total} {
+				stat.CarryOver++
+			}
+			report.CarryOver = append(report.CarryOver, reportIssue{
+				Identifier: issue.Identifier,
+				Title:      issue.Title,
+				State:      state,
+				Assignee:   name,
+				Estimate:   issue.Estimate,
+			})
+		case issue.State != nil && issue.State.Type == "completed":
+			for _, stat := range []*cycleAssigneeStat{assignees[name], &report.Total} {
+				stat.Completed++
+				stat.CompletedPoints += points
+			}
+		case issue.State != nil && issue.State.Type == "canceled":
+			report.Throughput.IssuesCanceled++
+		}
+	}
+
+	for _, stat := range assignees {
+		report.Assignees = append(report.Assignees, *stat)
+	}
+	sort.Slice(report.Assignees, func(i, j int) bool {
+		a, b := report.Assignees[i], report.Assignees[j]
+		if a.Issues != b.Issues {
+			return a.Issues > b.Issues
+		}
+		return a.Name < b.Name
+	})
+	for i := range report.Assignees {
+		report.Assignees[i].Completion = completionRate(report.Assignees[i])
+	}
+	report.Total.Completion = completionRate(report.Total)
+
+	report.Throughput.IssuesCompleted = report.Total.Completed
+	report.Throughput.PointsCompleted = report.Total.CompletedPoints
+	if committed := report.Total.Issues - report.Throughput.IssuesCanceled; committed > 0 {
+		report.Throughput.CompletionRate = float64(report.Total.Completed) / float64(committed)
+	}
+	return report
+}
+
+// completionRate is the fraction of an assignee's issues that were completed
+func completionRate(stat cycleAssigneeStat) float64 {
+	if stat.Issues == 0 {
+		return 0
+	}
+	return float64(stat.Completed) / float64(stat.Issues)
+}
+
+func printCycleReport(report cycleReport, plaintext bool) {
+	title := fmt.Sprintf("Cycle %d", report.Number)
+	if report.Name != "" {
+		title += ": " + report.Name
+	}
+	scope := report.Scope
+	throughput := fmt.Sprintf("%d issues, %s points completed (%d canceled); %.0f%% of committed issues",
+		report.Throughput.IssuesCompleted, formatPoints(report.Throughput.PointsCompleted),
+		report.Throughput.IssuesCanceled, report.Throughput.CompletionRate*100)
+	scopeChange := fmt.Sprintf("%d issues (%s since start), %s points (%s since start)",
+		scope.Issues, formatChange(float64(scope.Issues-scope.IssuesAtStart)),
+		formatPoints(scope.Points), formatChange(scope.Points-scope.PointsAtStart))
+	carryPoints := 0.0
+	for _, issue := range report.CarryOver {
+		if issue.Estimate != nil {
+			carryPoints += *issue.Estimate
+		}
+	}
+	carryOver := fmt.Sprintf("%d issues, %s points", len(report.CarryOver), formatPoints(carryPoints))
+	if !report.Closed {
+		carryOver += " still open"
+	}
+
+	headers := []string{"Assignee", "Issues", "Points", "Completed", "Carry-over", "Completion"}
+	var rows [][]string
+	for _, stat := range append(report.Assignees, report.Total) {
+		rows = append(rows, []string{
+			stat.Name,
+			strconv.Itoa(stat.Issues),
+			formatPoints(stat.Points),
+			fmt.Sprintf("%d (%s pts)", stat.Completed, formatPoints(stat.CompletedPoints)),
+			strconv.Itoa(stat.CarryOver),
+			fmt.Sprintf("%.0f%%", stat.Completion*100),
+		})
+	}
+
+	if plaintext {
+		fmt.Printf("# %s\n\n", title)
+		fmt.Printf("- **Team**: %s\n", report.Team)
+		fmt.Printf("- **Dates**: %s to %s (%s)\n", cycleDate(report.StartsAt), cycleDate(report.EndsAt), report.Status)
+		fmt.Printf("- **Throughput**: %s\n", throughput)
+		fmt.Printf("- **Scope**: %s\n", scopeChange)
+		fmt.Printf("- **Carry-over**: %s\n", carryOver)
+		fmt.Printf("\n## By Assignee\n")
+		output.Table(output.TableData{Headers: headers, Rows: rows}, true, false)
+		if len(report.CarryOver) > 0 {
+			fmt.Printf("\n## Carry-over\n")
+			for _, issue := range report.CarryOver {
+				fmt.Printf("- %s %s [%s] (%s)\n", issue.Identifier, issue.Title, issue.State, issue.Assignee)
+			}
+		}
+		return
+	}
+
+	bold := color.New(color.Bold)
+	faint := color.New(color.FgWhite, color.Faint)
+
+	fmt.Println()
+	fmt.Printf("%s %s %s\n",
+		color.New(color.FgCyan, color.Bold).Sprint("📊"),
+		color.New(color.FgCyan, color.Bold).Sprint(title),
+		faint.Sprintf("(%s, %s → %s, %s)", report.Team, cycleDate(report.StartsAt), cycleDate(report.EndsAt), report.Status))
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("%s %s\n", bold.Sprint("Throughput:"), throughput)
+	fmt.Printf("%s %s\n", bold.Sprint("Scope:     "), scopeChange)
+	fmt.Printf("%s %s\n\n", bold.Sprint("Carry-over:"), carryOver)
+	output.Table(output.TableData{Headers: headers, Rows: rows}, false, false)
+
+	if len(report.CarryOver) > 0 {
+		heading := "Carried over:"
+		if !report.Closed {
+			heading = "Still open:"
+		}
+		fmt.Printf("\n%s\n", bold.Sprint(heading))
+		for _, issue := range report.CarryOver {
+			fmt.Printf("  %s %s %s\n",
+				color.New(color.FgCyan).Sprintf("%-10s", issue.Identifier),
+				truncateString(issue.Title, 50),
+				faint.Sprintf("[%s] %s", issue.State, issue.Assignee))
+		}
+	}
+}
+
+// leadTimeGroupings maps --by values to the group an issue falls in
+var leadTimeGroupings = map[string]func(api.Issue) string{
+	"":     nil,
+	"none": nil,
+	"team": func(issue api.Issue) string {
+		if issue.Team == nil {
+			return "No team"
+		}
+		return issue.Team.Key
+	},
+	"assignee": func(issue api.Issue) string {
+		if issue.Assignee == nil {
+			return "Unassigned"
+		}
+		return issue.Assignee.Name
+	},
+	"priority": func(issue api.Issue) string {
+		return priorityToString(issue.Priority)
+	},
+}
+
+// leadTimeReport holds lead and cycle time statistics, overall and per group
+type leadTimeReport struct {
+	Since  string          `json:"since,omitempty"`
+	Team   string          `json:"team,omitempty"`
+	Groups []leadTimeGroup `json:"groups"`
+	Issues []issueTiming   `json:"issues"`
+}
+
+// leadTimeGroup is the statistics for one group of issues; the first group is all of them
+type leadTimeGroup struct {
+	Name      string        `json:"name"`
+	LeadTime  durationStats `json:"leadTime"`
+	CycleTime durationStats `json:"cycleTime"`
+}
+
+// leadTimeMetric names one of a group's statistics for display
+type leadTimeMetric struct {
+	name  string
+	stats durationStats
+}
+
+func (g leadTimeGroup) metrics() []leadTimeMetric {
+	return []leadTimeMetric{{"Lead time", g.LeadTime}, {"Cycle time", g.CycleTime}}
+}
+
+// durationStats summarizes durations in days
+type durationStats struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"meanDays"`
+	P50   float64 `json:"p50Days"`
+	P75   float64 `json:"p75Days"`
+	P90   float64 `json:"p90Days"`
+	P95   float64 `json:"p95Days"`
+}
+
+// issueTiming is how long one completed issue took
+type issueTiming struct {
+	Identifier    string     `json:"identifier"`
+	Title         string     `json:"title"`
+	Group         string     `json:"group,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	StartedAt     *time.Time `json:"startedAt"`
+	CompletedAt   time.Time  `json:"completedAt"`
+	LeadTimeDays  float64    `json:"leadTimeDays"`
+	CycleTimeDays *float64   `json:"cycleTimeDays"`
+}
+
+// buildLeadTimeReport computes each completed issue's timings and their
+// statistics, overall and per group when group is set
+func buildLeadTimeReport(issues []api.Issue, group func(api.Issue) string) leadTimeReport {
+	report := leadTimeReport{Groups: []leadTimeGroup{}, Issues: []issueTiming{}}
+
+	lead := map[string][]float64{}
+	cycle := map[string][]float64{}
+	var names []string
+	for _, issue := range issues {
+		if issue.CompletedAt == nil {
+			continue
+		}
+		timing := issueTiming{
+			Identifier:   issue.Identifier,
+			Title:        issue.Title,
+			CreatedAt:    issue.CreatedAt,
+			StartedAt:    issueStartedAt(issue),
+			CompletedAt:  *issue.CompletedAt,
+			LeadTimeDays: issue.CompletedAt.Sub(issue.CreatedAt).Hours() / 24,
+		}
+		if timing.StartedAt != nil && !timing.StartedAt.After(timing.CompletedAt) {
+			days := timing.CompletedAt.Sub(*timing.StartedAt).Hours() / 24
+			timing.CycleTimeDays = &days
+		}
+
+		keys := []string{"All"}
+		if group != nil {
+			timing.Group = group(issue)
+			keys = append(keys, timing.Group)
+		}
+		for _, key := range keys {
+			if _, ok := lead[key]; !ok {
+				names = append(names, key)
+			}
+			lead[key] = append(lead[key], timing.LeadTimeDays)
+			if timing.CycleTimeDays != nil {
+				cycle[key] = append(cycle[key], *timing.CycleTimeDays)
+			}
+		}
+		report.Issues = append(report.Issues, timing)
+	}
+
+	// All first, then the largest groups
+	sort.SliceStable(names, func(i, j int) bool {
+		if names[i] == "All" || names[j] == "All" {
+			return names[i] == "All"
+		}
+		if len(lead[names[i]]) != len(lead[names[j]]) {
+			return len(lead[names[i]]) > len(lead[names[j]])
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		report.Groups = append(report.Groups, leadTimeGroup{
+			Name:      name,
+			LeadTime:  computeDurationStats(lead[name]),
+			CycleTime: computeDurationStats(cycle[name]),
+		})
+	}
+	return report
+}
+
+// issueStartedAt is when work on an issue started: its first move into a
+// started state, or Linear's startedAt when the history doesn't show one
+func issueStartedAt(issue api.Issue) *time.Time {
+	started := issue.StartedAt
+	if issue.History != nil {
+		for _, entry := range issue.History.Nodes {
+			if entry.ToState == nil || entry.ToState.Type != "started" {
+				continue
+			}
+			if started == nil || entry.CreatedAt.Before(*started) {
+				at := entry.CreatedAt
+				started = &at
+			}
+		}
+	}
+	return started
+}
+
+// computeDurationStats returns the count, mean, and percentiles of durations in days
+func computeDurationStats(days []float64) durationStats {
+	stats := durationStats{Count: len(days)}
+	if len(days) == 0 {
+		return stats
+	}
+	sorted := append([]float64(nil), days...)
+	sort.Float64s(sorted)
+
+	total := 0.0
+	for _, d := range sorted {
+		total += d
+	}
+	stats.Mean = total / float64(len(sorted))
+	stats.P50 = percentile(sorted, 0.50)
+	stats.P75 = percentile(sorted, 0.75)
+	stats.P90 = percentile(sorted, 0.90)
+	stats.P95 = percentile(sorted, 0.95)
+	return stats
+}
+
+// percentile interpolates the p-th percentile (0-1) of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// formatDays shows a duration in days, or in hours when it is under a day
+func formatDays(days float64) string {
+	if days < 1 {
+		return fmt.Sprintf("%.1fh", days*24)
+	}
+	return fmt.Sprintf("%.1fd", days)
+}
+
+// cycleReportMessage is the Slack message for a cycle report: its throughput,
+// scope change, and carry-over
+func cycleReportMessage(cmd *cobra.Command, report cycleReport) slack.Message {
+	title := fmt.Sprintf("%s cycle %d", report.Team, report.Number)
+	if report.Name != "" {
+		title += ": " + report.Name
+	}
+	scope := report.Scope
+	message := slack.Message{
+		Text: title + " report",
+		Blocks: []slack.Block{
+			slack.Header("📊 " + title),
+			slack.Fields(
+				fmt.Sprintf("*Dates*\n%s to %s (%s)", cycleDate(report.StartsAt), cycleDate(report.EndsAt), report.Status),
+				fmt.Sprintf("*Completed*\n%d issues, %s points (%.0f%%)", report.Throughput.IssuesCompleted,
+					formatPoints(report.Throughput.PointsCompleted), report.Throughput.CompletionRate*100),
+				fmt.Sprintf("*Scope*\n%d issues (%s), %s points (%s)", scope.Issues, formatChange(float64(scope.Issues-scope.IssuesAtStart)),
+					formatPoints(scope.Points), formatChange(scope.Points-scope.PointsAtStart)),
+				fmt.Sprintf("*Carry-over*\n%d issues", len(report.CarryOver)),
+			),
+		},
+	}
+	if len(report.CarryOver) > 0 {
+		lines := []string{"*Carry-over*"}
+		for _, issue := range report.CarryOver {
+			lines = append(lines, fmt.Sprintf("• %s %s _%s, %s_", issue.Identifier, slack.Escape(issue.Title), slack.Escape(issue.State), slack.Escape(issue.Assignee)))
+		}
+		message.Blocks = append(message.Blocks, slack.Section(strings.Join(lines, "\n")))
+	}
+	message.Blocks = append(message.Blocks, slackFooter(cmd))
+	return message
+}
+
+// leadTimeReportMessage is the Slack message for a lead-time report: the
+// median and 90th percentile of each group's lead and cycle time
+func leadTimeReportMessage(cmd *cobra.Command, report leadTimeReport) slack.Message {
+	title := "Lead time"
+	if report.Team != "" {
+		title += " for " + report.Team
+	}
+	period := "all time"
+	if report.Since != "" {
+		period = "since " + cycleDate(report.Since)
+	}
+	message := slack.Message{
+		Text: fmt.Sprintf("%s, %s", title, period),
+		Blocks: []slack.Block{
+			slack.Header("⏱️ " + title),
+			slack.Section(fmt.Sprintf("%d issues completed %s", len(report.Issues), period)),
+		},
+	}
+	if len(report.Issues) > 0 {
+		for _, group := range report.Groups {
+			lines := []string{"*" + slack.Escape(group.Name) + "*"}
+			for _, metric := range group.metrics() {
+				stats := metric.stats
+				if stats.Count == 0 {
+					lines = append(lines, fmt.Sprintf("%s: no issues", metric.name))
+					continue
+				}
+				lines = append(lines, fmt.Sprintf("%s: median %s, p90 %s (%d issues)", metric.name, formatDays(stats.P50), formatDays(stats.P90), stats.Count))
+			}
+			message.Blocks = append(message.Blocks, slack.Section(strings.Join(lines, "\n")))
+		}
+	}
+	message.Blocks = append(message.Blocks, slackFooter(cmd))
+	return message
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportCycleCmd)
+	reportCmd.AddCommand(reportLeadTimeCmd)
+	reportCmd.PersistentFlags().String("notify-slack", "", slackWebhookHelp)
+
+	for _, c := range []*cobra.Command{reportCycleCmd, reportLeadTimeCmd} {
+		c.Flags().StringP("team", "t", "", "Team key (default: default-team from config)")
+		c.Flags().String("format", "table", "Output format: table, csv, tsv")
+	}
+	reportCycleCmd.Flags().StringP("cycle", "c", "current", "Cycle number, or 'current' or 'previous'")
+	reportLeadTimeCmd.Flags().String("since", "90d", "Issues completed since a date or time expression (e.g. 90d, 6_months_ago, 2025-01-01, all_time)")
+	reportLeadTimeCmd.Flags().String("by", "none", "Split the report by team, assignee, or priority")
+}
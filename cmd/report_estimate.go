@@ -0,0 +1,469 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/slack"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var reportEstimatesCmd = &cobra.Command{
+	Use:         "estimates",
+	Aliases:     []string{"estimate", "accuracy"},
+	Short:       "Estimates against actual cycle time for a cycle",
+	Annotations: map[string]string{pagerAnnotation: "true"},
+	Long: `Compare the estimates of a cycle's completed issues with their actual cycle time
+(from the first move into a started state to completion).
+
+The team's pace is its days per point across the cycle. Each issue's ratio is
+its cycle time against what its points would take at that pace, and each
+assignee and label gets the median ratio of its issues. Those at --threshold or
+more (issues take that many times longer than their points suggest) are
+flagged as underestimating; those at 1/--threshold or less as overestimating.
+Groups with fewer than --min-issues issues are not flagged.
+
+--format csv/tsv prints every issue's estimate, cycle time, and ratio.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		teamKey := requireCycleTeam(cmd, plaintext, jsonOut)
+		separator, err := reportSeparator(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		webhookURL, err := slackWebhook(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		threshold, _ := cmd.Flags().GetFloat64("threshold")
+		if threshold <= 1 {
+			output.Error("--threshold must be greater than 1", plaintext, jsonOut)
+			exit(1)
+		}
+		minIssues, _ := cmd.Flags().GetInt("min-issues")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		selector, _ := cmd.Flags().GetString("cycle")
+		cycle, err := resolveCycleSelector(ctx, client, teamKey, selector)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		filter := map[string]interface{}{
+			"cycle":       map[string]interface{}{"id": map[string]interface{}{"eq": cycle.ID}},
+			"completedAt": map[string]interface{}{"null": false},
+		}
+		issues, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: 100}, func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+			page, err := client.GetIssueTimings(ctx, filter, first, after)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issues: %v", err), plaintext, jsonOut)
+		}
+
+		report := buildEstimateReport(issues, threshold, minIssues)
+		report.Team = teamKey
+		report.Cycle = cycle.Number
+		report.Name = cycle.Name
+		report.StartsAt = cycle.StartsAt
+		report.EndsAt = cycle.EndsAt
+		notifySlack(webhookURL, estimateReportMessage(cmd, report), plaintext, jsonOut)
+
+		switch {
+		case jsonOut:
+			output.JSON(report)
+		case len(report.Issues) == 0:
+			output.Info(fmt.Sprintf("No completed issues with estimates and a start in cycle %d", cycle.Number), plaintext, jsonOut)
+		case separator != 0:
+			headers := []string{"issue", "title", "assignee", "labels", "estimate", "cycle_time_days", "expected_days", "ratio"}
+			var rows [][]string
+			for _, issue := range report.Issues {
+				rows = append(rows, []string{
+					issue.Identifier,
+					issue.Title,
+					issue.Assignee,
+					strings.Join(issue.Labels, ";"),
+					formatPoints(issue.Estimate),
+					strconv.FormatFloat(issue.CycleTimeDays, 'f', 2, 64),
+					strconv.FormatFloat(issue.ExpectedDays, 'f', 2, 64),
+					strconv.FormatFloat(issue.Ratio, 'f', 2, 64),
+				})
+			}
+			if err := output.Delimited(os.Stdout, headers, rows, separator); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write report: %v", err), plaintext, jsonOut)
+			}
+		default:
+			printEstimateReport(report, plaintext)
+		}
+	},
+}
+
+// estimateReport compares a cycle's estimates with the actual cycle times
+type estimateReport struct {
+	Team         string           `json:"team"`
+	Cycle        int              `json:"cycle"`
+	Name         string           `json:"name,omitempty"`
+	StartsAt     string           `json:"startsAt"`
+	EndsAt       string           `json:"endsAt"`
+	DaysPerPoint float64          `json:"daysPerPoint"`
+	Correlation  *float64         `json:"correlation"`
+	Unestimated  int              `json:"unestimated"`
+	NeverStarted int              `json:"neverStarted"`
+	ByEstimate   []estimateBucket `json:"byEstimate"`
+	Assignees    []estimateGroup  `json:"assignees"`
+	Labels       []estimateGroup  `json:"labels"`
+	Issues       []issueEstimate  `json:"issues"`
+}
+
+// estimateBucket is the cycle time of the issues with one estimate
+type estimateBucket struct {
+	Estimate  float64       `json:"estimate"`
+	CycleTime durationStats `json:"cycleTime"`
+}
+
+// estimateGroup is how well one assignee's or label's issues were estimated
+type estimateGroup struct {
+	Name         string  `json:"name"`
+	Issues       int     `json:"issues"`
+	Points       float64 `json:"points"`
+	Days         float64 `json:"days"`
+	DaysPerPoint float64 `json:"daysPerPoint"`
+	Ratio        float64 `json:"ratio"`
+	Verdict      string  `json:"verdict,omitempty"`
+}
+
+// issueEstimate is one issue's estimate against its cycle time
+type issueEstimate struct {
+	Identifier    string   `json:"identifier"`
+	Title         string   `json:"title"`
+	Assignee      string   `json:"assignee"`
+	Labels        []string `json:"labels"`
+	Estimate      float64  `json:"estimate"`
+	CycleTimeDays float64  `json:"cycleTimeDays"`
+	ExpectedDays  float64  `json:"expectedDays"`
+	Ratio         float64  `json:"ratio"`
+}
+
+// Estimate verdicts
+const (
+	verdictUnder = "underestimated"
+	verdictOver  = "overestimated"
+)
+
+// buildEstimateReport measures each estimated, completed issue's cycle time
+// against the team's pace, and flags the assignees and labels whose median
+// ratio is off by threshold or more
+func buildEstimateReport(issues []api.Issue, threshold float64, minIssues int) estimateReport {
+	report := estimateReport{
+		ByEstimate: []estimateBucket{},
+		Assignees:  []estimateGroup{},
+		Labels:     []estimateGroup{},
+		Issues:     []issueEstimate{},
+	}
+
+	totalPoints, totalDays := 0.0, 0.0
+	for _, issue := range issues {
+		if issue.CompletedAt == nil {
+			continue
+		}
+		if issue.Estimate == nil || *issue.Estimate <= 0 {
+			report.Unestimated++
+			continue
+		}
+		started := issueStartedAt(issue)
+		if started == nil || started.After(*issue.CompletedAt) {
+			report.NeverStarted++
+			continue
+		}
+		entry := issueEstimate{
+			Identifier:    issue.Identifier,
+			Title:         issue.Title,
+			Assignee:      "Unassigned",
+			Labels:        []string{},
+			Estimate:      *issue.Estimate,
+			CycleTimeDays: issue.CompletedAt.Sub(*started).Hours() / 24,
+		}
+		if issue.Assignee != nil {
+			entry.Assignee = issue.Assignee.Name
+		}
+		if issue.Labels != nil {
+			for _, label := range issue.Labels.Nodes {
+				entry.Labels = append(entry.Labels, label.Name)
+			}
+		}
+		totalPoints += entry.Estimate
+		totalDays += entry.CycleTimeDays
+		report.Issues = append(report.Issues, entry)
+	}
+	if totalPoints == 0 {
+		return report
+	}
+
+	report.DaysPerPoint = totalDays / totalPoints
+	byEstimate := map[float64][]float64{}
+	var estimates, days []float64
+	for i := range report.Issues {
+		issue := &report.Issues[i]
+		issue.ExpectedDays = issue.Estimate * report.DaysPerPoint
+		if issue.ExpectedDays > 0 {
+			issue.Ratio = issue.CycleTimeDays / issue.ExpectedDays
+		}
+		byEstimate[issue.Estimate] = append(byEstimate[issue.Estimate], issue.CycleTimeDays)
+		estimates = append(estimates, issue.Estimate)
+		days = append(days, issue.CycleTimeDays)
+	}
+	report.Correlation = pearson(estimates, days)
+
+	for estimate, values := range byEstimate {
+		report.ByEstimate = append(report.ByEstimate, estimateBucket{Estimate: estimate, CycleTime: computeDurationStats(values)})
+	}
+	sort.Slice(report.ByEstimate, func(i, j int) bool { return report.ByEstimate[i].Estimate < report.ByEstimate[j].Estimate })
+
+	report.Assignees = groupEstimates(report.Issues, func(issue issueEstimate) []string { return []string{issue.Assignee} }, threshold, minIssues)
+	report.Labels = groupEstimates(report.Issues, func(issue issueEstimate) []string { return issue.Labels }, threshold, minIssues)
+
+	// Worst estimated first
+	sort.SliceStable(report.Issues, func(i, j int) bool { return report.Issues[i].Ratio > report.Issues[j].Ratio })
+	return report
+}
+
+// groupEstimates sums the issues in each of the groups keys puts them in,
+// and judges each group by the median ratio of its issues
+func groupEstimates(issues []issueEstimate, keys func(issueEstimate) []string, threshold float64, minIssues int) []estimateGroup {
+	groups := map[string]*estimateGroup{}
+	ratios := map[string][]float64{}
+	for _, issue := range issues {
+		for _, key := range keys(issue) {
+			if groups[key] == nil {
+				groups[key] = &estimateGroup{Name: key}
+			}
+			group := groups[key]
+			group.Issues++
+			group.Points += issue.Estimate
+			group.Days += issue.CycleTimeDays
+			ratios[key] = append(ratios[key], issue.Ratio)
+		}
+	}
+
+	result := []estimateGroup{}
+	for key, group := range groups {
+		if group.Points > 0 {
+			group.DaysPerPoint = group.Days / group.Points
+		}
+		group.Ratio = computeDurationStats(ratios[key]).P50
+		if group.Issues >= minIssues {
+			switch {
+			case group.Ratio >= threshold:
+				group.Verdict = verdictUnder
+			case group.Ratio <= 1/threshold:
+				group.Verdict = verdictOver
+			}
+		}
+		result = append(result, *group)
+	}
+	// Furthest from the team's pace first
+	sort.Slice(result, func(i, j int) bool {
+		a, b := math.Abs(math.Log(result[i].Ratio)), math.Abs(math.Log(result[j].Ratio))
+		if a != b {
+			return a > b
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// pearson is the correlation between xs and ys, or nil when there are too
+// few values or either doesn't vary
+func pearson(xs, ys []float64) *float64 {
+	n := float64(len(xs))
+	if len(xs) < 3 {
+		return nil
+	}
+	meanX, meanY := 0.0, 0.0
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX, meanY = meanX/n, meanY/n
+	covariance, varX, varY := 0.0, 0.0, 0.0
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		covariance += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return nil
+	}
+	r := covariance / math.Sqrt(varX*varY)
+	return &r
+}
+
+// describeCorrelation puts a correlation into words
+func describeCorrelation(r *float64) string {
+	if r == nil {
+		return "not enough varied estimates to correlate"
+	}
+	strength := "weak"
+	switch {
+	case math.Abs(*r) >= 0.7:
+		strength = "strong"
+	case math.Abs(*r) >= 0.4:
+		strength = "moderate"
+	}
+	return fmt.Sprintf("%.2f (%s)", *r, strength)
+}
+
+// formatRatio shows a ratio against the team's pace as "1.8×"
+func formatRatio(ratio float64) string {
+	return fmt.Sprintf("%.1f×", ratio)
+}
+
+func printEstimateReport(report estimateReport, plaintext bool) {
+	title := fmt.Sprintf("Cycle %d", report.Cycle)
+	if report.Name != "" {
+		title += ": " + report.Name
+	}
+	measured := fmt.Sprintf("%d completed issues with estimates", len(report.Issues))
+	var left []string
+	if report.Unestimated > 0 {
+		left = append(left, fmt.Sprintf("%d without estimates", report.Unestimated))
+	}
+	if report.NeverStarted > 0 {
+		left = append(left, fmt.Sprintf("%d never started", report.NeverStarted))
+	}
+	if len(left) > 0 {
+		measured += " (left out: " + strings.Join(left, ", ") + ")"
+	}
+	pace := fmt.Sprintf("%s per point", formatDays(report.DaysPerPoint))
+
+	estimateRows := make([][]string, len(report.ByEstimate))
+	for i, bucket := range report.ByEstimate {
+		stats := bucket.CycleTime
+		estimateRows[i] = []string{formatPoints(bucket.Estimate), strconv.Itoa(stats.Count), formatDays(stats.P50), formatDays(stats.Mean), formatDays(stats.P90)}
+	}
+	estimateTable := output.TableData{Headers: []string{"Estimate", "Issues", "Median", "Mean", "P90"}, Rows: estimateRows}
+
+	var flagged []string
+	groupTable := func(header string, groups []estimateGroup) output.TableData {
+		rows := make([][]string, len(groups))
+		for i, group := range groups {
+			verdict := group.Verdict
+			if verdict != "" {
+				flagged = append(flagged, fmt.Sprintf("%s: %s (issues take %s as long as their points suggest)", group.Name, verdict, formatRatio(group.Ratio)))
+				if !plaintext {
+					verdict = color.New(color.FgRed).Sprint("⚠ " + verdict)
+				}
+			}
+			rows[i] = []string{group.Name, strconv.Itoa(group.Issues), formatPoints(group.Points), formatDays(group.DaysPerPoint), formatRatio(group.Ratio), verdict}
+		}
+		return output.TableData{Headers: []string{header, "Issues", "Points", "Per point", "vs team", "Verdict"}, Rows: rows}
+	}
+	assigneeTable := groupTable("Assignee", report.Assignees)
+	labelTable := groupTable("Label", report.Labels)
+
+	if plaintext {
+		fmt.Printf("# Estimates: %s\n\n", title)
+		fmt.Printf("- **Team**: %s\n", report.Team)
+		fmt.Printf("- **Dates**: %s to %s\n", cycleDate(report.StartsAt), cycleDate(report.EndsAt))
+		fmt.Printf("- **Issues**: %s\n", measured)
+		fmt.Printf("- **Pace**: %s\n", pace)
+		fmt.Printf("- **Correlation**: %s\n", describeCorrelation(report.Correlation))
+		fmt.Printf("\n## By Estimate\n")
+		output.Table(estimateTable, true, false)
+		fmt.Printf("\n## By Assignee\n")
+		output.Table(assigneeTable, true, false)
+		if len(report.Labels) > 0 {
+			fmt.Printf("\n## By Label\n")
+			output.Table(labelTable, true, false)
+		}
+		if len(flagged) > 0 {
+			fmt.Printf("\n## Flagged\n")
+			for _, flag := range flagged {
+				fmt.Printf("- %s\n", flag)
+			}
+		}
+		return
+	}
+
+	bold := color.New(color.Bold)
+	faint := color.New(color.FgWhite, color.Faint)
+
+	fmt.Println()
+	fmt.Printf("%s %s %s\n",
+		color.New(color.FgCyan, color.Bold).Sprint("📏"),
+		color.New(color.FgCyan, color.Bold).Sprint("Estimates: "+title),
+		faint.Sprintf("(%s, %s → %s)", report.Team, cycleDate(report.StartsAt), cycleDate(report.EndsAt)))
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("%s %s\n", bold.Sprint("Issues:     "), measured)
+	fmt.Printf("%s %s\n", bold.Sprint("Pace:       "), pace)
+	fmt.Printf("%s %s\n\n", bold.Sprint("Correlation:"), describeCorrelation(report.Correlation))
+	output.Table(estimateTable, false, false)
+	fmt.Println()
+	output.Table(assigneeTable, false, false)
+	if len(report.Labels) > 0 {
+		fmt.Println()
+		output.Table(labelTable, false, false)
+	}
+	if len(flagged) > 0 {
+		fmt.Printf("\n%s\n", bold.Sprint("Flagged:"))
+		for _, flag := range flagged {
+			fmt.Printf("  %s %s\n", color.New(color.FgRed).Sprint("⚠"), flag)
+		}
+	}
+}
+
+// estimateReportMessage is the Slack message for an estimates report: the
+// team's pace, how well estimates predicted cycle time, and the flagged groups
+func estimateReportMessage(cmd *cobra.Command, report estimateReport) slack.Message {
+	title := fmt.Sprintf("%s cycle %d estimates", report.Team, report.Cycle)
+	message := slack.Message{
+		Text: title,
+		Blocks: []slack.Block{
+			slack.Header("📏 " + title),
+			slack.Fields(
+				fmt.Sprintf("*Issues*\n%d completed with estimates", len(report.Issues)),
+				fmt.Sprintf("*Pace*\n%s per point", formatDays(report.DaysPerPoint)),
+				"*Correlation*\n"+describeCorrelation(report.Correlation),
+			),
+		},
+	}
+	var flagged []string
+	for _, group := range append(append([]estimateGroup{}, report.Assignees...), report.Labels...) {
+		if group.Verdict != "" {
+			flagged = append(flagged, fmt.Sprintf("• ⚠️ *%s*: %s (issues take %s as long as their points suggest)", slack.Escape(group.Name), group.Verdict, formatRatio(group.Ratio)))
+		}
+	}
+	if len(flagged) > 0 {
+		message.Blocks = append(message.Blocks, slack.Section("*Flagged*\n"+strings.Join(flagged, "\n")))
+	}
+	message.Blocks = append(message.Blocks, slackFooter(cmd))
+	return message
+}
+
+func init() {
+	reportCmd.AddCommand(reportEstimatesCmd)
+
+	reportEstimatesCmd.Flags().StringP("team", "t", "", "Team key (default: default-team from config)")
+	reportEstimatesCmd.Flags().StringP("cycle", "c", "previous", "Cycle number, or 'current' or 'previous' ('last')")
+	reportEstimatesCmd.Flags().String("format", "table", "Output format: table, csv, tsv")
+	reportEstimatesCmd.Flags().Float64("threshold", 1.5, "Flag assignees and labels whose issues take this many times longer (or shorter) than estimated")
+	reportEstimatesCmd.Flags().Int("min-issues", 3, "Fewest issues an assignee or label needs to be flagged")
+}
@@ -0,0 +1,558 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/slack"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// staleUpdateAge is how old a project's latest status update can get before
+// the health report flags it
+const staleUpdateAge = 7 * 24 * time.Hour
+
+var reportHealthCmd = &cobra.Command{
+	Use:         "health",
+	Aliases:     []string{"rollup"},
+	Short:       "Health rollup of initiatives and their projects",
+	Annotations: map[string]string{pagerAnnotation: "true"},
+	Long: `Roll up every initiative's projects with their health, progress, target date
+slip, and latest status update, worst health first.
+
+Slip is how many days a project is late against its target date: how late it
+finished, how far past the target it still is, or, for a project still ahead
+of its target, when it would finish at the pace of its progress so far.
+
+--format markdown (or --plaintext) prints a digest to paste into a weekly
+update, with the first paragraph of each project's latest update. Completed
+initiatives and finished projects are left out unless --include-completed.
+
+Examples:
+  linctl report health
+  linctl report health --initiative "Self-serve onboarding" --format markdown
+  linctl report health --format csv > health.csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		format, _ := cmd.Flags().GetString("format")
+		markdown := plaintext
+		var separator rune
+		switch strings.ToLower(format) {
+		case "markdown", "md":
+			markdown = true
+		default:
+			var err error
+			separator, err = reportSeparator(cmd)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("%v, markdown", err), plaintext, jsonOut)
+			}
+		}
+		webhookURL, err := slackWebhook(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		initiatives, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: 20}, func(ctx context.Context, first int, after string) ([]api.Initiative, api.PageInfo, error) {
+			page, err := client.GetInitiativesHealth(ctx, first, after)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get initiatives: %v", err), plaintext, jsonOut)
+		}
+
+		status, _ := cmd.Flags().GetString("status")
+		includeCompleted, _ := cmd.Flags().GetBool("include-completed")
+		initiatives, err = filterInitiatives(initiatives, status, includeCompleted)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if refs, _ := cmd.Flags().GetStringSlice("initiative"); len(refs) > 0 {
+			initiatives, err = selectInitiatives(initiatives, refs)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+		}
+
+		report := buildHealthReport(initiatives, includeCompleted, time.Now())
+		notifySlack(webhookURL, healthReportMessage(cmd, report), plaintext, jsonOut)
+		switch {
+		case jsonOut:
+			output.JSON(report)
+		case len(report.Initiatives) == 0:
+			output.Info("No initiatives found", plaintext, jsonOut)
+		case separator != 0:
+			headers := []string{"initiative", "project", "state", "health", "progress", "target_date", "slip_days", "slip_basis", "last_update", "last_update_by", "url"}
+			var rows [][]string
+			for _, initiative := range report.Initiatives {
+				for _, project := range initiative.Projects {
+					slip := ""
+					if project.SlipDays != nil {
+						slip = strconv.Itoa(*project.SlipDays)
+					}
+					updatedAt, updatedBy := "", ""
+					if update := project.LatestUpdate; update != nil {
+						updatedAt = update.CreatedAt.Format(time.RFC3339)
+						if update.User != nil {
+							updatedBy = update.User.Name
+						}
+					}
+					rows = append(rows, []string{
+						initiative.Name,
+						project.Name,
+						project.State,
+						project.Health,
+						strconv.FormatFloat(project.Progress, 'f', 2, 64),
+						optionalString(project.TargetDate),
+						slip,
+						project.SlipBasis,
+						updatedAt,
+						updatedBy,
+						project.URL,
+					})
+				}
+			}
+			if err := output.Delimited(os.Stdout, headers, rows, separator); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write report: %v", err), plaintext, jsonOut)
+			}
+		case markdown:
+			printHealthDigest(report)
+		default:
+			printHealthReport(report)
+		}
+	},
+}
+
+// healthReport rolls up the health of initiatives and their projects
+type healthReport struct {
+	GeneratedAt time.Time          `json:"generatedAt"`
+	Summary     healthSummary      `json:"summary"`
+	Initiatives []initiativeHealth `json:"initiatives"`
+}
+
+// healthSummary counts projects by health
+type healthSummary struct {
+	Projects int `json:"projects"`
+	OnTrack  int `json:"onTrack"`
+	AtRisk   int `json:"atRisk"`
+	OffTrack int `json:"offTrack"`
+	NoUpdate int `json:"noUpdate"`
+	Late     int `json:"late"`
+}
+
+// initiativeHealth is one initiative in the health report
+type initiativeHealth struct {
+	ID         string          `json:"id"`
+	Name       string          `json:"name"`
+	Status     string          `json:"status"`
+	Health     string          `json:"health,omitempty"`
+	Owner      string          `json:"owner,omitempty"`
+	TargetDate *string         `json:"targetDate,omitempty"`
+	Progress   float64         `json:"progress"`
+	URL        string          `json:"url,omitempty"`
+	Projects   []projectHealth `json:"projects"`
+}
+
+// projectHealth is one project's health, progress, and target date slip
+type projectHealth struct {
+	ID           string             `json:"id"`
+	Name         string             `json:"name"`
+	State        string             `json:"state"`
+	Health       string             `json:"health,omitempty"`
+	Progress     float64            `json:"progress"`
+	Lead         string             `json:"lead,omitempty"`
+	TargetDate   *string            `json:"targetDate,omitempty"`
+	SlipDays     *int               `json:"slipDays,omitempty"`
+	SlipBasis    string             `json:"slipBasis,omitempty"`
+	URL          string             `json:"url,omitempty"`
+	LatestUpdate *api.ProjectUpdate `json:"latestUpdate,omitempty"`
+}
+
+// healthRank orders healths worst first, with no health last
+var healthRank = map[string]int{"offTrack": 0, "atRisk": 1, "onTrack": 2}
+
+// buildHealthReport rolls up initiatives' projects, leaving out finished
+// projects unless includeFinished
+func buildHealthReport(initiatives []api.Initiative, includeFinished bool, now time.Time) healthReport {
+	report := healthReport{GeneratedAt: now, Initiatives: []initiativeHealth{}}
+	for _, initiative := range initiatives {
+		entry := initiativeHealth{
+			ID:         initiative.ID,
+			Name:       initiative.Name,
+			Status:     initiative.Status,
+			Health:     initiative.Health,
+			TargetDate: initiative.TargetDate,
+			URL:        initiative.URL,
+			Projects:   []projectHealth{},
+		}
+		if initiative.Owner != nil {
+			entry.Owner = initiative.Owner.Name
+		}
+
+		var projects []api.Project
+		if initiative.Projects != nil {
+			projects = initiative.Projects.Nodes
+		}
+		progress, counted := 0.0, 0
+		for _, project := range projects {
+			if project.CanceledAt != nil || project.State == "canceled" {
+				continue
+			}
+			progress += project.Progress
+			counted++
+			finished := project.CompletedAt != nil || project.State == "completed"
+			if finished && !includeFinished {
+				continue
+			}
+			entry.Projects = append(entry.Projects, buildProjectHealth(project, now))
+		}
+		if counted > 0 {
+			entry.Progress = progress / float64(counted)
+		}
+
+		sort.SliceStable(entry.Projects, func(i, j int) bool {
+			a, b := entry.Projects[i], entry.Projects[j]
+			if rankHealth(a.Health) != rankHealth(b.Health) {
+				return rankHealth(a.Health) < rankHealth(b.Health)
+			}
+			return dateBefore(a.TargetDate, b.TargetDate)
+		})
+		for _, project := range entry.Projects {
+			report.Summary.Projects++
+			switch project.Health {
+			case "onTrack":
+				report.Summary.OnTrack++
+			case "atRisk":
+				report.Summary.AtRisk++
+			case "offTrack":
+				report.Summary.OffTrack++
+			}
+			if project.LatestUpdate == nil {
+				report.Summary.NoUpdate++
+			}
+			if project.SlipDays != nil && *project.SlipDays > 0 {
+				report.Summary.Late++
+			}
+		}
+		report.Initiatives = append(report.Initiatives, entry)
+	}
+
+	// Worst health first, then the nearest target
+	sort.SliceStable(report.Initiatives, func(i, j int) bool {
+		a, b := report.Initiatives[i], report.Initiatives[j]
+		if rankHealth(a.Health) != rankHealth(b.Health) {
+			return rankHealth(a.Health) < rankHealth(b.Health)
+		}
+		return dateBefore(a.TargetDate, b.TargetDate)
+	})
+	return report
+}
+
+// buildProjectHealth computes a project's slip and picks its latest update
+func buildProjectHealth(project api.Project, now time.Time) projectHealth {
+	entry := projectHealth{
+		ID:         project.ID,
+		Name:       project.Name,
+		State:      project.State,
+		Health:     project.Health,
+		Progress:   project.Progress,
+		TargetDate: project.TargetDate,
+		URL:        project.URL,
+	}
+	if project.Lead != nil {
+		entry.Lead = project.Lead.Name
+	}
+	if project.ProjectUpdates != nil && len(project.ProjectUpdates.Nodes) > 0 {
+		update := project.ProjectUpdates.Nodes[0]
+		entry.LatestUpdate = &update
+		if entry.Health == "" {
+			entry.Health = update.Health
+		}
+	}
+	entry.SlipDays, entry.SlipBasis = projectSlip(project, now)
+	return entry
+}
+
+// projectSlip is how many days a project is late against its target date,
+// and what that is based on: when it completed, how far past the target it
+// still is, or a forecast from the pace of its progress since it started
+func projectSlip(project api.Project, now time.Time) (*int, string) {
+	target, ok := parseRoadmapDate(project.TargetDate)
+	if !ok {
+		return nil, ""
+	}
+	days := func(t time.Time) *int {
+		slip := int(t.Sub(target).Hours() / 24)
+		if slip < 0 {
+			slip = 0
+		}
+		return &slip
+	}
+
+	if project.CompletedAt != nil {
+		return days(*project.CompletedAt), "completed"
+	}
+	if now.After(target.Add(24 * time.Hour)) {
+		return days(now), "overdue"
+	}
+	start, ok := parseRoadmapDate(project.StartDate)
+	if !ok || project.Progress <= 0 || !now.After(start) {
+		return nil, ""
+	}
+	if project.Progress >= 1 {
+		return days(now), "forecast"
+	}
+	elapsed := now.Sub(start)
+	finish := start.Add(time.Duration(float64(elapsed) / project.Progress))
+	return days(finish), "forecast"
+}
+
+// rankHealth sorts a health, worst first
+func rankHealth(health string) int {
+	if rank, ok := healthRank[health]; ok {
+		return rank
+	}
+	return len(healthRank)
+}
+
+// formatSlip shows a slip as "+12d (forecast)", or "on time"
+func formatSlip(days *int, basis string) string {
+	if days == nil {
+		return ""
+	}
+	if *days == 0 {
+		return "on time"
+	}
+	return fmt.Sprintf("+%dd (%s)", *days, basis)
+}
+
+// selectInitiatives keeps the initiatives named or identified by refs
+func selectInitiatives(initiatives []api.Initiative, refs []string) ([]api.Initiative, error) {
+	selected := []api.Initiative{}
+	for _, ref := range refs {
+		found := false
+		for _, initiative := range initiatives {
+			if initiative.ID == ref || strings.EqualFold(initiative.Name, ref) {
+				selected = append(selected, initiative)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("initiative not found: %s", ref)
+		}
+	}
+	return selected, nil
+}
+
+func printHealthReport(report healthReport) {
+	faint := color.New(color.FgWhite, color.Faint)
+	summary := report.Summary
+
+	fmt.Println()
+	fmt.Printf("%s %s %s\n",
+		color.New(color.FgCyan, color.Bold).Sprint("🩺"),
+		color.New(color.FgCyan, color.Bold).Sprint("Health report"),
+		faint.Sprintf("(%s)", report.GeneratedAt.Format("2006-01-02")))
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("%d projects: %s, %s, %s; %d late, %d without updates\n",
+		summary.Projects,
+		healthColor("onTrack").Sprintf("%d on track", summary.OnTrack),
+		healthColor("atRisk").Sprintf("%d at risk", summary.AtRisk),
+		healthColor("offTrack").Sprintf("%d off track", summary.OffTrack),
+		summary.Late, summary.NoUpdate)
+
+	for _, initiative := range report.Initiatives {
+		fmt.Printf("\n%s %s %s\n",
+			healthColor(initiative.Health).Sprint("●"),
+			color.New(color.Bold).Sprint(initiative.Name),
+			faint.Sprint(initiativeHealthDetails(initiative)))
+		if len(initiative.Projects) == 0 {
+			fmt.Printf("  %s\n", faint.Sprint("No projects"))
+			continue
+		}
+
+		rows := make([][]string, len(initiative.Projects))
+		for i, project := range initiative.Projects {
+			health := healthColor(project.Health).Sprint(formatHealth(project.Health))
+			slip := formatSlip(project.SlipDays, project.SlipBasis)
+			if project.SlipDays != nil && *project.SlipDays > 0 {
+				slip = color.New(color.FgRed).Sprint(slip)
+			}
+			updated := faint.Sprint("never")
+			if update := project.LatestUpdate; update != nil {
+				updated = formatTimeAgo(update.CreatedAt)
+				if update.User != nil {
+					updated += " by " + update.User.Name
+				}
+				if report.GeneratedAt.Sub(update.CreatedAt) > staleUpdateAge {
+					updated = color.New(color.FgYellow).Sprint(updated)
+				}
+			}
+			rows[i] = []string{
+				project.Name,
+				health,
+				progressBar(project.Progress, 10) + fmt.Sprintf(" %3.0f%%", project.Progress*100),
+				optionalString(project.TargetDate),
+				slip,
+				updated,
+			}
+		}
+		output.Table(output.TableData{
+			Headers: []string{"Project", "Health", "Progress", "Target", "Slip", "Last update"},
+			Rows:    rows,
+		}, false, false)
+	}
+}
+
+// printHealthDigest prints the report as markdown to paste into an update
+func printHealthDigest(report healthReport) {
+	summary := report.Summary
+	fmt.Printf("# Health report (%s)\n\n", report.GeneratedAt.Format("2006-01-02"))
+	fmt.Printf("**%d projects**: %d on track, %d at risk, %d off track; %d late, %d without updates\n",
+		summary.Projects, summary.OnTrack, summary.AtRisk, summary.OffTrack, summary.Late, summary.NoUpdate)
+
+	for _, initiative := range report.Initiatives {
+		fmt.Printf("\n## %s %s\n\n", healthEmoji(initiative.Health), initiative.Name)
+		fmt.Printf("%s\n", strings.TrimPrefix(initiativeHealthDetails(initiative), "· "))
+		if len(initiative.Projects) == 0 {
+			fmt.Printf("\nNo projects.\n")
+			continue
+		}
+
+		fmt.Printf("\n| Project | Health | Progress | Target | Slip | Last update |\n")
+		fmt.Printf("| --- | --- | --- | --- | --- | --- |\n")
+		var notes []string
+		for _, project := range initiative.Projects {
+			updated := "never"
+			if update := project.LatestUpdate; update != nil {
+				updated = update.CreatedAt.Local().Format("2006-01-02")
+				if update.User != nil {
+					updated += " by " + update.User.Name
+				}
+				if note := firstParagraph(update.Body); note != "" {
+					notes = append(notes, fmt.Sprintf("- **%s**: %s", project.Name, note))
+				}
+			}
+			name := project.Name
+			if project.URL != "" {
+				name = fmt.Sprintf("[%s](%s)", project.Name, project.URL)
+			}
+			cells := []string{
+				name,
+				strings.TrimSpace(healthEmoji(project.Health) + " " + formatHealth(project.Health)),
+				fmt.Sprintf("%.0f%%", project.Progress*100),
+				optionalString(project.TargetDate),
+				formatSlip(project.SlipDays, project.SlipBasis),
+				updated,
+			}
+			for i, cell := range cells {
+				cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+			}
+			fmt.Printf("| %s |\n", strings.Join(cells, " | "))
+		}
+		if len(notes) > 0 {
+			fmt.Printf("\n%s\n", strings.Join(notes, "\n"))
+		}
+	}
+}
+
+// initiativeHealthDetails summarizes an initiative's status, health, progress,
+// owner, and target
+func initiativeHealthDetails(initiative initiativeHealth) string {
+	details := []string{initiative.Status}
+	if initiative.Health != "" {
+		details = append(details, formatHealth(initiative.Health))
+	}
+	details = append(details, fmt.Sprintf("%.0f%% complete", initiative.Progress*100))
+	if initiative.Owner != "" {
+		details = append(details, "owner "+initiative.Owner)
+	}
+	if initiative.TargetDate != nil {
+		details = append(details, "target "+*initiative.TargetDate)
+	}
+	return "· " + strings.Join(details, " · ")
+}
+
+// healthEmoji marks a health in markdown, where there is no color
+func healthEmoji(health string) string {
+	switch health {
+	case "onTrack":
+		return "🟢"
+	case "atRisk":
+		return "🟡"
+	case "offTrack":
+		return "🔴"
+	}
+	return "⚪"
+}
+
+// firstParagraph is a markdown body's first paragraph on one line, shortened
+// for a digest
+func firstParagraph(body string) string {
+	for _, paragraph := range strings.Split(strings.TrimSpace(body), "\n\n") {
+		paragraph = strings.Join(strings.Fields(paragraph), " ")
+		if paragraph != "" && !strings.HasPrefix(paragraph, "![") {
+			return truncateString(paragraph, 200)
+		}
+	}
+	return ""
+}
+
+// healthReportMessage is the Slack message for a health report: the summary,
+// then each initiative with its projects, worst health first
+func healthReportMessage(cmd *cobra.Command, report healthReport) slack.Message {
+	summary := report.Summary
+	title := fmt.Sprintf("Health report (%s)", report.GeneratedAt.Format("2006-01-02"))
+	message := slack.Message{
+		Text: title,
+		Blocks: []slack.Block{
+			slack.Header("🩺 " + title),
+			slack.Section(fmt.Sprintf("*%d projects*: %d on track, %d at risk, %d off track; %d late, %d without updates",
+				summary.Projects, summary.OnTrack, summary.AtRisk, summary.OffTrack, summary.Late, summary.NoUpdate)),
+		},
+	}
+	for _, initiative := range report.Initiatives {
+		lines := []string{fmt.Sprintf("%s *%s* %s", healthEmoji(initiative.Health), slack.Link(initiative.URL, initiative.Name),
+			slack.Escape(initiativeHealthDetails(initiative)))}
+		for _, project := range initiative.Projects {
+			line := fmt.Sprintf("• %s %s, %.0f%%", healthEmoji(project.Health), slack.Link(project.URL, project.Name), project.Progress*100)
+			if slip := formatSlip(project.SlipDays, project.SlipBasis); slip != "" && slip != "on time" {
+				line += ", " + slip
+			}
+			if project.LatestUpdate == nil {
+				line += ", no updates"
+			}
+			lines = append(lines, line)
+		}
+		message.Blocks = append(message.Blocks, slack.Section(strings.Join(lines, "\n")))
+	}
+	message.Blocks = append(message.Blocks, slackFooter(cmd))
+	return message
+}
+
+func init() {
+	reportCmd.AddCommand(reportHealthCmd)
+
+	reportHealthCmd.Flags().StringSlice("initiative", nil, "Only these initiatives (names or IDs, comma-separated)")
+	reportHealthCmd.Flags().StringP("status", "s", "", "Only initiatives with this status (planned, active, completed)")
+	reportHealthCmd.Flags().BoolP("include-completed", "c", false, "Include completed initiatives and finished projects")
+	reportHealthCmd.Flags().String("format", "table", "Output format: table, markdown, csv, tsv")
+}
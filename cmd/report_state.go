@@ -0,0 +1,431 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/slack"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var reportTimeInStateCmd = &cobra.Command{
+	Use:         "time-in-state",
+	Aliases:     []string{"states", "bottlenecks"},
+	Short:       "How long issues spend in each workflow state",
+	Annotations: map[string]string{pagerAnnotation: "true"},
+	Long: `Report how long issues spend in each workflow state since --since, from the
+state changes in their history. Each issue's time in a state adds up every
+visit to it within the period, including the time so far in its current state.
+Completed and canceled states are left out.
+
+The bottleneck is the started state issues spend the most time in altogether.
+--by team splits the report per team; --issues lists every issue's time in
+each state instead.
+
+Examples:
+  linctl report time-in-state --team ENG --since 60d
+  linctl report time-in-state --team @any --by team --format csv > states.csv
+  linctl report time-in-state --team ENG --issues`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		separator, err := reportSeparator(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		webhookURL, err := slackWebhook(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		groupBy, _ := cmd.Flags().GetString("by")
+		groupBy = strings.ToLower(groupBy)
+		if groupBy != "" && groupBy != "none" && groupBy != "team" {
+			output.Error(fmt.Sprintf("Invalid --by '%s' (expected none or team)", groupBy), plaintext, jsonOut)
+			exit(1)
+		}
+
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		since, err := utils.ParseTimeExpression(sinceFlag)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Invalid since value: %v", err), plaintext, jsonOut)
+		}
+		var from time.Time
+		filter := map[string]interface{}{}
+		if since != "" {
+			from, _ = time.Parse(time.RFC3339, since)
+			filter["updatedAt"] = map[string]interface{}{"gte": since}
+		}
+		teamFlag, _ := cmd.Flags().GetString("team")
+		teamKey, err := resolveTeamKey(teamFlag)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if teamKey != "" {
+			filter["team"] = map[string]interface{}{"key": map[string]interface{}{"eq": teamKey}}
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		issues, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: 100}, func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+			page, err := client.GetIssueTimings(ctx, filter, first, after)
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issues: %v", err), plaintext, jsonOut)
+		}
+
+		report := buildTimeInStateReport(issues, from, time.Now(), groupBy == "team")
+		report.Since = since
+		report.Team = teamKey
+		notifySlack(webhookURL, timeInStateReportMessage(cmd, report), plaintext, jsonOut)
+
+		if jsonOut {
+			output.JSON(report)
+			return
+		}
+		if len(report.Issues) == 0 {
+			output.Info("No issues with time in an open state found", plaintext, jsonOut)
+			return
+		}
+
+		perIssue, _ := cmd.Flags().GetBool("issues")
+		grouped := groupBy == "team"
+		if separator != 0 {
+			var headers []string
+			var rows [][]string
+			if perIssue {
+				headers = []string{"issue", "title", "team", "state", "state_type", "days"}
+				for _, issue := range report.Issues {
+					for _, state := range issue.States {
+						rows = append(rows, []string{issue.Identifier, issue.Title, issue.Team, state.Name, state.Type, strconv.FormatFloat(state.Days, 'f', 2, 64)})
+					}
+				}
+			} else {
+				headers = []string{"state", "state_type", "issues", "mean_days", "p50_days", "p90_days", "total_days", "share", "bottleneck"}
+				if grouped {
+					headers = append([]string{"team"}, headers...)
+				}
+				days := func(d float64) string { return strconv.FormatFloat(d, 'f', 2, 64) }
+				for _, group := range report.Groups {
+					for _, state := range group.States {
+						row := []string{state.Name, state.Type, strconv.Itoa(state.Time.Count),
+							days(state.Time.Mean), days(state.Time.P50), days(state.Time.P90), days(state.TotalDays),
+							strconv.FormatFloat(state.Share, 'f', 3, 64), strconv.FormatBool(state.Bottleneck)}
+						if grouped {
+							row = append([]string{group.Name}, row...)
+						}
+						rows = append(rows, row)
+					}
+				}
+			}
+			if err := output.Delimited(os.Stdout, headers, rows, separator); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write report: %v", err), plaintext, jsonOut)
+			}
+			return
+		}
+
+		if perIssue {
+			var rows [][]string
+			for _, issue := range report.Issues {
+				for i, state := range issue.States {
+					identifier, title := issue.Identifier, truncateString(issue.Title, 40)
+					if i > 0 {
+						identifier, title = "", ""
+					}
+					rows = append(rows, []string{identifier, title, state.Name, formatDays(state.Days)})
+				}
+			}
+			output.Table(output.TableData{Headers: []string{"Issue", "Title", "State", "Time"}, Rows: rows}, plaintext, jsonOut)
+			return
+		}
+
+		headers := []string{"State", "Issues", "Mean", "Median", "P90", "Share"}
+		if grouped {
+			headers = append([]string{"Team"}, headers...)
+		}
+		var rows [][]string
+		var bottlenecks []string
+		for _, group := range report.Groups {
+			for _, state := range group.States {
+				name := state.Name
+				if state.Bottleneck {
+					name += " ⚠"
+					if !plaintext {
+						name = color.New(color.FgRed, color.Bold).Sprint(name)
+					}
+					bottleneck := fmt.Sprintf("%s holds %.0f%% of the time in open states (median %s)", state.Name, state.Share*100, formatDays(state.Time.P50))
+					if grouped {
+						bottleneck = group.Name + ": " + bottleneck
+					}
+					bottlenecks = append(bottlenecks, bottleneck)
+				}
+				row := []string{name, strconv.Itoa(state.Time.Count),
+					formatDays(state.Time.Mean), formatDays(state.Time.P50), formatDays(state.Time.P90),
+					fmt.Sprintf("%.0f%%", state.Share*100)}
+				if grouped {
+					row = append([]string{group.Name}, row...)
+				}
+				rows = append(rows, row)
+			}
+		}
+
+		output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, jsonOut)
+		for _, bottleneck := range bottlenecks {
+			if plaintext {
+				fmt.Printf("\nBottleneck: %s\n", bottleneck)
+			} else {
+				fmt.Printf("\n%s %s\n", color.New(color.FgRed).Sprint("⚠ Bottleneck:"), bottleneck)
+			}
+		}
+		if !plaintext {
+			period := "all time"
+			if since != "" {
+				period = "since " + cycleDate(since)
+			}
+			fmt.Printf("\n%s %d issues %s\n", color.New(color.FgGreen).Sprint("✓"), len(report.Issues), period)
+		}
+	},
+}
+
+// timeInStateReport holds how long issues spent in each workflow state,
+// overall and per group, and per issue
+type timeInStateReport struct {
+	Since  string             `json:"since,omitempty"`
+	Team   string             `json:"team,omitempty"`
+	Groups []timeInStateGroup `json:"groups"`
+	Issues []issueStateTimes  `json:"issues"`
+}
+
+// timeInStateGroup is the time in each state for one group of issues; the
+// first group is all of them
+type timeInStateGroup struct {
+	Name   string           `json:"name"`
+	States []stateTimeStats `json:"states"`
+}
+
+// stateTimeStats is how long issues spent in one state
+type stateTimeStats struct {
+	Name       string        `json:"name"`
+	Type       string        `json:"type"`
+	Time       durationStats `json:"time"`
+	TotalDays  float64       `json:"totalDays"`
+	Share      float64       `json:"share"`
+	Bottleneck bool          `json:"bottleneck"`
+}
+
+// issueStateTimes is how long one issue spent in each state
+type issueStateTimes struct {
+	Identifier string      `json:"identifier"`
+	Title      string      `json:"title"`
+	Team       string      `json:"team,omitempty"`
+	States     []stateTime `json:"states"`
+}
+
+// stateTime is an issue's time in one state, in days
+type stateTime struct {
+	Name string  `json:"name"`
+	Type string  `json:"type"`
+	Days float64 `json:"days"`
+}
+
+// buildTimeInStateReport adds up each issue's time in its open states between
+// from (no limit when zero) and now, and their statistics overall and, when
+// byTeam, per team
+func buildTimeInStateReport(issues []api.Issue, from, now time.Time, byTeam bool) timeInStateReport {
+	report := timeInStateReport{Groups: []timeInStateGroup{}, Issues: []issueStateTimes{}}
+
+	type stateKey struct{ group, state string }
+	days := map[stateKey][]float64{}
+	types := map[string]string{}
+	var groups []string
+	seenGroups := map[string]bool{}
+	for _, issue := range issues {
+		times := issueTimeInStates(issue, from, now)
+		if len(times) == 0 {
+			continue
+		}
+		entry := issueStateTimes{Identifier: issue.Identifier, Title: issue.Title, States: times}
+		if issue.Team != nil {
+			entry.Team = issue.Team.Key
+		}
+		report.Issues = append(report.Issues, entry)
+
+		keys := []string{"All"}
+		if byTeam {
+			team := entry.Team
+			if team == "" {
+				team = "No team"
+			}
+			keys = append(keys, team)
+		}
+		for _, key := range keys {
+			if !seenGroups[key] {
+				seenGroups[key] = true
+				groups = append(groups, key)
+			}
+			for _, state := range times {
+				types[state.Name] = state.Type
+				days[stateKey{key, state.Name}] = append(days[stateKey{key, state.Name}], state.Days)
+			}
+		}
+	}
+
+	for _, group := range groups {
+		entry := timeInStateGroup{Name: group, States: []stateTimeStats{}}
+		total := 0.0
+		for key, values := range days {
+			if key.group != group {
+				continue
+			}
+			stats := stateTimeStats{Name: key.state, Type: types[key.state], Time: computeDurationStats(values)}
+			for _, d := range values {
+				stats.TotalDays += d
+			}
+			total += stats.TotalDays
+			entry.States = append(entry.States, stats)
+		}
+
+		for i := range entry.States {
+			if total > 0 {
+				entry.States[i].Share = entry.States[i].TotalDays / total
+			}
+		}
+
+		sort.SliceStable(entry.States, func(i, j int) bool {
+			a, b := entry.States[i], entry.States[j]
+			if stateTypeOrder[a.Type] != stateTypeOrder[b.Type] {
+				return stateTypeOrder[a.Type] < stateTypeOrder[b.Type]
+			}
+			if a.TotalDays != b.TotalDays {
+				return a.TotalDays > b.TotalDays
+			}
+			return a.Name < b.Name
+		})
+		// The states of each type are sorted by total time, most first
+		for i := range entry.States {
+			if entry.States[i].Type == "started" {
+				entry.States[i].Bottleneck = true
+				break
+			}
+		}
+		report.Groups = append(report.Groups, entry)
+	}
+
+	// All first, then the teams
+	sort.SliceStable(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Name == "All" || report.Groups[j].Name == "All" {
+			return report.Groups[i].Name == "All"
+		}
+		return report.Groups[i].Name < report.Groups[j].Name
+	})
+	return report
+}
+
+// issueTimeInStates walks an issue's state changes and adds up its time in
+// each open state between from and now, in the order it first entered them
+func issueTimeInStates(issue api.Issue, from, now time.Time) []stateTime {
+	var changes []api.IssueHistoryEntry
+	if issue.History != nil {
+		for _, entry := range issue.History.Nodes {
+			if entry.ToState != nil {
+				changes = append(changes, entry)
+			}
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].CreatedAt.Before(changes[j].CreatedAt) })
+
+	// The state the issue was created in is where its first change came from
+	state := issue.State
+	if len(changes) > 0 && changes[0].FromState != nil {
+		state = changes[0].FromState
+	}
+	enteredAt := issue.CreatedAt
+
+	var order []stateTime
+	index := map[string]int{}
+	add := func(state *api.State, start, end time.Time) {
+		if state == nil || state.Type == "completed" || state.Type == "canceled" {
+			return
+		}
+		if !from.IsZero() && start.Before(from) {
+			start = from
+		}
+		if !end.After(start) {
+			return
+		}
+		i, ok := index[state.Name]
+		if !ok {
+			i = len(order)
+			index[state.Name] = i
+			order = append(order, stateTime{Name: state.Name, Type: state.Type})
+		}
+		order[i].Days += end.Sub(start).Hours() / 24
+	}
+	for _, change := range changes {
+		add(state, enteredAt, change.CreatedAt)
+		state, enteredAt = change.ToState, change.CreatedAt
+	}
+	add(state, enteredAt, now)
+	return order
+}
+
+// timeInStateReportMessage is the Slack message for a time-in-state report:
+// each group's bottleneck and its time in each state
+func timeInStateReportMessage(cmd *cobra.Command, report timeInStateReport) slack.Message {
+	title := "Time in state"
+	if report.Team != "" {
+		title += " for " + report.Team
+	}
+	period := "all time"
+	if report.Since != "" {
+		period = "since " + cycleDate(report.Since)
+	}
+	message := slack.Message{
+		Text: fmt.Sprintf("%s, %s", title, period),
+		Blocks: []slack.Block{
+			slack.Header("⏳ " + title),
+			slack.Section(fmt.Sprintf("%d issues %s", len(report.Issues), period)),
+		},
+	}
+	if len(report.Issues) > 0 {
+		for _, group := range report.Groups {
+			lines := []string{"*" + slack.Escape(group.Name) + "*"}
+			for _, state := range group.States {
+				line := fmt.Sprintf("%s: median %s, p90 %s, %.0f%% of the time", slack.Escape(state.Name), formatDays(state.Time.P50), formatDays(state.Time.P90), state.Share*100)
+				if state.Bottleneck {
+					line = "🐢 *" + line + "* (bottleneck)"
+				}
+				lines = append(lines, "• "+line)
+			}
+			message.Blocks = append(message.Blocks, slack.Section(strings.Join(lines, "\n")))
+		}
+	}
+	message.Blocks = append(message.Blocks, slackFooter(cmd))
+	return message
+}
+
+func init() {
+	reportCmd.AddCommand(reportTimeInStateCmd)
+
+	reportTimeInStateCmd.Flags().StringP("team", "t", "", "Team key (default: default-team from config; '@any' for every team)")
+	reportTimeInStateCmd.Flags().String("format", "table", "Output format: table, csv, tsv")
+	reportTimeInStateCmd.Flags().String("since", "60d", "Time spent since a date or time expression (e.g. 60d, 3_months_ago, 2025-01-01, all_time)")
+	reportTimeInStateCmd.Flags().String("by", "none", "Split the report by team")
+	reportTimeInStateCmd.Flags().Bool("issues", false, "List each issue's time in each state instead")
+}
@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var roadmapCmd = &cobra.Command{
+	Use:         "roadmap [INITIATIVE...]",
+	Short:       "Show initiatives and their projects as a roadmap",
+	Annotations: map[string]string{pagerAnnotation: "true"},
+	Long: `Show initiatives with the projects under them: status, health, progress, and
+dates. Give initiative names or IDs to show only those; completed initiatives
+are left out unless --include-completed or --status is given.
+
+--gantt draws a timeline instead, one bar per project from its start date
+(or creation, when it has none) to its target date, filled in as far as the
+project has progressed, with today marked.
+
+Examples:
+  linctl roadmap
+  linctl roadmap "Self-serve onboarding" --gantt
+  linctl roadmap --gantt --width 100 --plaintext > roadmap.txt`,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		var initiatives []api.Initiative
+		if len(args) > 0 {
+			for _, ref := range args {
+				initiative, err := resolveInitiative(ctx, client, ref)
+				if err != nil {
+					exitWithError(err, err.Error(), plaintext, jsonOut)
+				}
+				initiatives = append(initiatives, *initiative)
+			}
+		} else {
+			all, err := loadInitiatives(ctx, client)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to list initiatives: %v", err), plaintext, jsonOut)
+			}
+			status, _ := cmd.Flags().GetString("status")
+			includeCompleted, _ := cmd.Flags().GetBool("include-completed")
+			initiatives, err = filterInitiatives(all, status, includeCompleted)
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+		}
+		sortRoadmap(initiatives)
+
+		if jsonOut {
+			output.JSON(initiatives)
+			return
+		}
+		if len(initiatives) == 0 {
+			output.Info("No initiatives found", plaintext, jsonOut)
+			return
+		}
+
+		if gantt, _ := cmd.Flags().GetBool("gantt"); gantt {
+			width, _ := cmd.Flags().GetInt("width")
+			if width < 20 {
+				output.Error("--width must be at least 20", plaintext, jsonOut)
+				exit(1)
+			}
+			fmt.Print(renderGantt(roadmapRows(initiatives), width, time.Now(), plaintext))
+			return
+		}
+
+		printRoadmap(initiatives, plaintext)
+	},
+}
+
+// sortRoadmap orders initiatives, and the projects under each, by target date;
+// those without one come last
+func sortRoadmap(initiatives []api.Initiative) {
+	sort.SliceStable(initiatives, func(i, j int) bool {
+		return dateBefore(initiatives[i].TargetDate, initiatives[j].TargetDate)
+	})
+	for _, initiative := range initiatives {
+		if initiative.Projects == nil {
+			continue
+		}
+		projects := initiative.Projects.Nodes
+		sort.SliceStable(projects, func(i, j int) bool {
+			return dateBefore(projects[i].TargetDate, projects[j].TargetDate)
+		})
+	}
+}
+
+// dateBefore compares optional YYYY-MM-DD dates, placing missing ones last
+func dateBefore(a, b *string) bool {
+	if a == nil || b == nil {
+		return a != nil
+	}
+	return *a < *b
+}
+
+// printRoadmap lists each initiative followed by a table of its projects
+func printRoadmap(initiatives []api.Initiative, plaintext bool) {
+	headers := []string{"Project", "State", "Progress", "Health", "Start", "Target", "Lead"}
+	for i, initiative := range initiatives {
+		details := []string{initiative.Status}
+		if initiative.Health != "" {
+			details = append(details, formatHealth(initiative.Health))
+		}
+		if initiative.TargetDate != nil {
+			details = append(details, "target "+*initiative.TargetDate)
+		}
+
+		if i > 0 {
+			fmt.Println()
+		}
+		if plaintext {
+			fmt.Printf("## %s (%s)\n", initiative.Name, strings.Join(details, ", "))
+		} else {
+			fmt.Printf("%s %s\n",
+				color.New(color.FgCyan, color.Bold).Sprint("🎯 "+initiative.Name),
+				color.New(color.FgWhite, color.Faint).Sprint(strings.Join(details, " · ")))
+		}
+
+		if initiative.Projects == nil || len(initiative.Projects.Nodes) == 0 {
+			fmt.Println("  No projects")
+			continue
+		}
+
+		rows := make([][]string, len(initiative.Projects.Nodes))
+		for j, project := range initiative.Projects.Nodes {
+			lead := ""
+			if project.Lead != nil {
+				lead = project.Lead.Name
+			}
+			health := formatHealth(project.Health)
+			if !plaintext && health != "" {
+				health = healthColor(project.Health).Sprint(health)
+			}
+			rows[j] = []string{
+				project.Name,
+				project.State,
+				fmt.Sprintf("%.0f%%", project.Progress*100),
+				health,
+				optionalString(project.StartDate),
+				optionalString(project.TargetDate),
+				lead,
+			}
+		}
+		output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, false)
+	}
+}
+
+// ganttRow is one line of the roadmap timeline: an initiative heading or one
+// of its projects. A row without dates is listed with no bar.
+type ganttRow struct {
+	label    string
+	heading  bool
+	start    time.Time
+	end      time.Time
+	dated    bool
+	progress float64
+	health   string
+	note     string
+}
+
+// roadmapRows lays initiatives and their projects out as timeline rows. An
+// initiative spans its projects, ending at its own target date when it has one.
+func roadmapRows(initiatives []api.Initiative) []ganttRow {
+	var rows []ganttRow
+	for _, initiative := range initiatives {
+		heading := ganttRow{label: initiative.Name, heading: true, health: initiative.Health}
+		var projectRows []ganttRow
+		if initiative.Projects != nil {
+			for _, project := range initiative.Projects.Nodes {
+				row := ganttRow{label: project.Name, progress: project.Progress, health: project.Health}
+				start := project.CreatedAt
+				if date, ok := parseRoadmapDate(project.StartDate); ok {
+					start = date
+				}
+				if end, ok := parseRoadmapDate(project.TargetDate); ok {
+					if end.Before(start) {
+						start = end
+					}
+					row.start, row.end, row.dated = start, end, true
+				} else {
+					row.note = "no target date"
+				}
+				projectRows = append(projectRows, row)
+
+				if row.dated {
+					if !heading.dated || row.start.Before(heading.start) {
+						heading.start = row.start
+					}
+					if !heading.dated || row.end.After(heading.end) {
+						heading.end = row.end
+					}
+					heading.dated = true
+				}
+			}
+		}
+		if end, ok := parseRoadmapDate(initiative.TargetDate); ok {
+			if !heading.dated {
+				heading.start = end
+				if initiative.CreatedAt != nil && initiative.CreatedAt.Before(end) {
+					heading.start = *initiative.CreatedAt
+				}
+			}
+			heading.end, heading.dated = end, true
+			if heading.start.After(end) {
+				heading.start = end
+			}
+		}
+		if len(projectRows) > 0 {
+			total := 0.0
+			for _, row := range projectRows {
+				total += row.progress
+			}
+			heading.progress = total / float64(len(projectRows))
+		}
+		rows = append(rows, heading)
+		rows = append(rows, projectRows...)
+	}
+	return rows
+}
+
+// parseRoadmapDate parses an optional YYYY-MM-DD date
+func parseRoadmapDate(date *string) (time.Time, bool) {
+	if date == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("2006-01-02", *date)
+	return t, err == nil
+}
+
+// renderGantt draws rows as a timeline width columns wide, with month labels
+// across the top and today marked. Plaintext output sticks to ASCII.
+func renderGantt(rows []ganttRow, width int, now time.Time, plaintext bool) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from, to := today, today
+	for _, row := range rows {
+		if !row.dated {
+			continue
+		}
+		if row.start.Before(from) {
+			from = row.start
+		}
+		if row.end.After(to) {
+			to = row.end
+		}
+	}
+	// Start on the first of the month so the first label lines up
+	from = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to = to.AddDate(0, 0, 7)
+
+	span := to.Sub(from)
+	column := func(t time.Time) int {
+		c := int(float64(t.Sub(from)) / float64(span) * float64(width-1))
+		if c < 0 {
+			return 0
+		}
+		if c >= width {
+			return width - 1
+		}
+		return c
+	}
+
+	labelWidth := 0
+	for _, row := range rows {
+		label := row.label
+		if !row.heading {
+			label = "  " + label
+		}
+		if n := len([]rune(label)); n > labelWidth {
+			labelWidth = n
+		}
+	}
+	if labelWidth > 32 {
+		labelWidth = 32
+	}
+
+	done, todo, marker, edge := "█", "░", "│", "│"
+	if plaintext {
+		done, todo, marker, edge = "#", "=", "|", "|"
+	}
+	faint := color.New(color.FgWhite, color.Faint)
+
+	var b strings.Builder
+
+	// Month labels, skipping any that would run into the previous one
+	ruler := []rune(strings.Repeat(" ", width))
+	next := 0
+	for month := from; month.Before(to); month = month.AddDate(0, 1, 0) {
+		label := month.Format("Jan")
+		if month.Month() == time.January || month.Equal(from) {
+			label = month.Format("Jan 2006")
+		}
+		c := column(month)
+		if c < next || c+len(label) > width {
+			continue
+		}
+		copy(ruler[c:], []rune(label))
+		next = c + len(label) + 1
+	}
+	fmt.Fprintf(&b, "%-*s %s%s%s\n", labelWidth, "", edge, string(ruler), edge)
+
+	todayColumn := column(today)
+	for _, row := range rows {
+		label := row.label
+		if !row.heading {
+			label = "  " + label
+		}
+		label = truncateString(label, labelWidth)
+		padded := fmt.Sprintf("%-*s", labelWidth, label)
+		if !plaintext {
+			if row.heading {
+				padded = color.New(color.FgCyan, color.Bold).Sprint(padded)
+			}
+		}
+
+		cells := make([]string, width)
+		for i := range cells {
+			cells[i] = " "
+		}
+		if row.dated {
+			first, last := column(row.start), column(row.end)
+			filled := first + int(row.progress*float64(last-first+1)+0.5)
+			for i := first; i <= last; i++ {
+				cell := todo
+				if i < filled {
+					cell = done
+				}
+				if !plaintext {
+					cell = healthColor(row.health).Sprint(cell)
+				}
+				cells[i] = cell
+			}
+		}
+		if cells[todayColumn] == " " {
+			cells[todayColumn] = marker
+			if !plaintext {
+				cells[todayColumn] = color.New(color.FgRed).Sprint(marker)
+			}
+		}
+
+		var details []string
+		if row.dated {
+			details = append(details, fmt.Sprintf("%3.0f%%", row.progress*100), row.end.Format("2006-01-02"))
+		}
+		if row.health != "" {
+			details = append(details, formatHealth(row.health))
+		}
+		if row.note != "" {
+			details = append(details, row.note)
+		}
+		info := strings.Join(details, "  ")
+		if !plaintext {
+			info = faint.Sprint(info)
+		}
+		line := fmt.Sprintf("%s %s%s%s %s", padded, edge, strings.Join(cells, ""), edge, info)
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	legend := fmt.Sprintf("%s today (%s)   %s done   %s remaining", marker, today.Format("2006-01-02"), done, todo)
+	if !plaintext {
+		legend = faint.Sprint(legend)
+	}
+	fmt.Fprintf(&b, "\n%-*s %s\n", labelWidth, "", legend)
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(roadmapCmd)
+
+	roadmapCmd.Flags().Bool("gantt", false, "Draw a timeline of the projects under each initiative")
+	roadmapCmd.Flags().Int("width", 60, "Width of the --gantt timeline in columns")
+	roadmapCmd.Flags().StringP("status", "s", "", "Only initiatives with this status (planned, active, completed)")
+	roadmapCmd.Flags().BoolP("include-completed", "c", false, "Include completed initiatives")
+}
@@ -3,8 +3,16 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/debug"
+	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/httpclient"
+	"github.com/dorkitude/linctl/pkg/output"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -14,6 +22,9 @@ var (
 	cfgFile   string
 	plaintext bool
 	jsonOut   bool
+
+	// quietConfig keeps initConfig from announcing the config files it reads
+	quietConfig bool
 )
 
 // version is set at build time via -ldflags
@@ -70,14 +81,51 @@ var rootCmd = &cobra.Command{
 	Short:   "A comprehensive Linear CLI tool",
 	Long:    color.New(color.FgCyan).Sprintf("%s\nA comprehensive CLI tool for Linear's API featuring:\n• Issue management (create, list, update, archive)\n• Project tracking and collaboration  \n• Team and user management\n• Comments and attachments\n• Webhook configuration\n• Table/plaintext/JSON output formats\n", generateHeader()),
 	Version: version,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		startCommandTelemetry(cmd)
+		applyResponseCache(cmd)
+		normalizeLinearURLs(cmd, args)
+		if err := normalizeIssueRefs(cmd, args); err != nil {
+			output.Error(err.Error(), viper.GetBool("plaintext"), viper.GetBool("json"))
+			exit(1)
+		}
+		// A saved view is applied first so its team wins over default-team
+		if err := applyView(cmd); err != nil {
+			output.Error(err.Error(), viper.GetBool("plaintext"), viper.GetBool("json"))
+			exit(1)
+		}
+		applyDefaultTeam(cmd)
+		if err := normalizeTeamFlag(cmd); err != nil {
+			output.Error(err.Error(), viper.GetBool("plaintext"), viper.GetBool("json"))
+			exit(1)
+		}
+		// A command about to ask for its argument pages once it has it
+		if !pickPending(cmd, args) {
+			startPager(cmd)
+		}
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
-	err := rootCmd.Execute()
+	setupCompletion()
+	args, err := expandAlias(os.Args[1:])
+	if err != nil {
+		output.Error(err.Error(), false, false)
+		exit(1)
+	}
+	runExtension(args)
+	rootCmd.SetArgs(args)
+	// Commands report their own failures; what cobra returns is always an
+	// invalid command line, reported by exitUsageError
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	cmd, err := rootCmd.ExecuteC()
 	if err != nil {
-		os.Exit(1)
+		exitUsageError(cmd, err, args)
 	}
+	stopPager()
+	finishTelemetry(0)
 }
 
 // GetRootCmd returns the root command for testing
@@ -92,10 +140,59 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.linctl.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&plaintext, "plaintext", "p", false, "plaintext output (non-interactive)")
 	rootCmd.PersistentFlags().BoolVarP(&jsonOut, "json", "j", false, "JSON output")
+	rootCmd.PersistentFlags().Bool("jsonl", false, "JSON Lines output (one JSON object per line, for streaming lists)")
+	rootCmd.PersistentFlags().String("profile", "", "Configuration profile to use (default from LINCTL_PROFILE, then 'default')")
+	rootCmd.PersistentFlags().CountP("verbose", "v", "Log API requests, timing, rate limits, and retries to stderr (-vv adds variables and responses)")
+	rootCmd.PersistentFlags().Int("max-retries", api.DefaultRetryPolicy.MaxRetries, "Maximum retries for rate-limited or failed API requests")
+	rootCmd.PersistentFlags().Duration("retry-wait", api.DefaultRetryPolicy.Wait, "Base wait between API retries (doubles on each retry)")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Bypass the API response cache")
+	rootCmd.PersistentFlags().Duration("cache-ttl", defaultCacheTTL, "How long team, user, label, and state lists may be reused from the cache, and so may miss changes made elsewhere (0 disables the cache)")
+	rootCmd.PersistentFlags().Duration("connect-timeout", httpclient.DefaultSettings.ConnectTimeout, "Maximum time to establish a connection, including the TLS handshake")
+	rootCmd.PersistentFlags().Duration("timeout", httpclient.DefaultSettings.Timeout, "Maximum time for an API request (0 for none; file transfers are not limited)")
+	rootCmd.PersistentFlags().String("proxy", "", "Proxy URL for all requests (default from HTTPS_PROXY/HTTP_PROXY/NO_PROXY; 'none' to connect directly)")
+	rootCmd.PersistentFlags().String("ca-bundle", "", "PEM file of extra certificate authorities to trust")
+	rootCmd.PersistentFlags().Bool("no-http2", false, "Use HTTP/1.1 only")
+	rootCmd.PersistentFlags().Bool("no-keepalive", false, "Open a new connection for every request")
+	rootCmd.PersistentFlags().String("color", "auto", "When to color output: auto (terminals, unless NO_COLOR is set), always, or never")
+	rootCmd.PersistentFlags().Bool("no-truncate", false, "Print table values in full instead of fitting tables to the terminal width")
+	rootCmd.PersistentFlags().StringSlice("max-width", nil, "Maximum width of table columns by header, e.g. title=60,url=40")
+	rootCmd.PersistentFlags().Bool("no-pager", false, "Don't page long output through $PAGER (default less -FRX)")
+	rootCmd.PersistentFlags().Bool("no-progress", false, "Don't draw progress bars for uploads, downloads, and bulk operations")
+	rootCmd.PersistentFlags().Bool("force-upload", false, "Upload files even when the same contents were uploaded before")
+	rootCmd.PersistentFlags().Bool("optimize-images", false, "Scale down and re-encode large images before uploading them")
+	rootCmd.PersistentFlags().Int("image-max-dimension", 2048, "Largest width or height kept when optimizing images")
+	rootCmd.PersistentFlags().Int("image-quality", 85, "JPEG quality (1-100) used when optimizing images")
+	rootCmd.PersistentFlags().Int64("image-size-threshold", 1<<20, "Size in bytes above which images are re-encoded when optimizing, even if they fit")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("plaintext", rootCmd.PersistentFlags().Lookup("plaintext"))
 	_ = viper.BindPFlag("json", rootCmd.PersistentFlags().Lookup("json"))
+	_ = viper.BindPFlag("jsonl", rootCmd.PersistentFlags().Lookup("jsonl"))
+	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+	_ = viper.BindEnv("profile", "LINCTL_PROFILE")
+	_ = viper.BindEnv("credential-store", "LINCTL_CREDENTIAL_STORE")
+	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	_ = viper.BindPFlag("max-retries", rootCmd.PersistentFlags().Lookup("max-retries"))
+	_ = viper.BindPFlag("retry-wait", rootCmd.PersistentFlags().Lookup("retry-wait"))
+	_ = viper.BindPFlag("no-cache", rootCmd.PersistentFlags().Lookup("no-cache"))
+	_ = viper.BindPFlag("cache-ttl", rootCmd.PersistentFlags().Lookup("cache-ttl"))
+	_ = viper.BindPFlag("connect-timeout", rootCmd.PersistentFlags().Lookup("connect-timeout"))
+	_ = viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	_ = viper.BindPFlag("proxy", rootCmd.PersistentFlags().Lookup("proxy"))
+	_ = viper.BindPFlag("ca-bundle", rootCmd.PersistentFlags().Lookup("ca-bundle"))
+	_ = viper.BindPFlag("no-http2", rootCmd.PersistentFlags().Lookup("no-http2"))
+	_ = viper.BindPFlag("no-keepalive", rootCmd.PersistentFlags().Lookup("no-keepalive"))
+	_ = viper.BindEnv("ca-bundle", "LINCTL_CA_BUNDLE")
+	_ = viper.BindPFlag("color", rootCmd.PersistentFlags().Lookup("color"))
+	_ = viper.BindPFlag("no-truncate", rootCmd.PersistentFlags().Lookup("no-truncate"))
+	_ = viper.BindPFlag("max-width", rootCmd.PersistentFlags().Lookup("max-width"))
+	_ = viper.BindPFlag("no-pager", rootCmd.PersistentFlags().Lookup("no-pager"))
+	_ = viper.BindPFlag("no-progress", rootCmd.PersistentFlags().Lookup("no-progress"))
+	_ = viper.BindPFlag("force-upload", rootCmd.PersistentFlags().Lookup("force-upload"))
+	_ = viper.BindPFlag("optimize-images", rootCmd.PersistentFlags().Lookup("optimize-images"))
+	_ = viper.BindPFlag("image-max-dimension", rootCmd.PersistentFlags().Lookup("image-max-dimension"))
+	_ = viper.BindPFlag("image-quality", rootCmd.PersistentFlags().Lookup("image-quality"))
+	_ = viper.BindPFlag("image-size-threshold", rootCmd.PersistentFlags().Lookup("image-size-threshold"))
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -114,12 +211,233 @@ func initConfig() {
 		viper.SetConfigName(".linctl")
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	// LINCTL_ variables override settings: LINCTL_DEFAULT_TEAM for default-team
+	viper.SetEnvPrefix("linctl")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
 
 	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		if !plaintext && !jsonOut {
+	configErr := viper.ReadInConfig()
+
+	// A repository's .linctl.yml overrides the config file
+	project, err := loadProjectConfig()
+	cobra.CheckErr(err)
+	if len(project) > 0 {
+		cobra.CheckErr(viper.MergeConfigMap(project))
+	}
+
+	// Select the profile: its credentials file and its settings from the
+	// "profiles.<name>" section, which override top-level config values but
+	// not the repository's
+	profile := viper.GetString("profile")
+	cobra.CheckErr(auth.SetProfile(profile))
+	cobra.CheckErr(auth.SetCredentialStore(viper.GetString("credential-store")))
+	if profile != "" && profile != auth.DefaultProfile {
+		settings := viper.GetStringMap("profiles." + profile)
+		if len(settings) > 0 {
+			cobra.CheckErr(viper.MergeConfigMap(settings))
+			if len(project) > 0 {
+				cobra.CheckErr(viper.MergeConfigMap(project))
+			}
+		}
+	}
+
+	// The output setting is the format used when no flag picks one
+	flags := rootCmd.PersistentFlags()
+	if !flags.Changed("plaintext") && !flags.Changed("json") && !flags.Changed("jsonl") {
+		switch format := viper.GetString("output"); format {
+		case "", "table":
+		case "plaintext":
+			plaintext = true
+			viper.Set("plaintext", true)
+		case "json", "jsonl":
+			jsonOut = true
+			viper.Set(format, true)
+		default:
+			cobra.CheckErr(fmt.Errorf("invalid output '%s' (use table, plaintext, json, or jsonl)", format))
+		}
+	}
+
+	// --jsonl is JSON output written one value per line
+	if viper.GetBool("jsonl") {
+		jsonOut = true
+		viper.Set("json", true)
+		output.SetJSONLines(true)
+	}
+
+	// Color terminals unless NO_COLOR is set, or as --color says; the theme
+	// and its colors come from the config file
+	cobra.CheckErr(output.SetColorMode(viper.GetString("color")))
+	cobra.CheckErr(output.SetTheme(viper.GetString("theme"), themeColorOverrides()))
+
+	if configErr == nil && !quietConfig {
+		if !viper.GetBool("plaintext") && !viper.GetBool("json") {
 			fmt.Fprintln(os.Stderr, color.New(color.FgGreen).Sprintf("✅ Using config file: %s", viper.ConfigFileUsed()))
 		}
 	}
+	if projectConfigPath != "" && !quietConfig && !viper.GetBool("plaintext") && !viper.GetBool("json") {
+		fmt.Fprintln(os.Stderr, color.New(color.FgGreen).Sprintf("✅ Using repository config: %s", projectConfigPath))
+	}
+
+	// Log API traffic to stderr with -v/-vv, or LINCTL_DEBUG=1/2 when the
+	// command line can't be changed (e.g. linctl run by another tool)
+	verbosity := viper.GetInt("verbose")
+	if level := debug.ParseLevel(os.Getenv("LINCTL_DEBUG")); level > verbosity {
+		verbosity = level
+	}
+	debug.SetLevel(verbosity)
+
+	// Apply connection settings from flags or config to every HTTP request
+	caBundle := viper.GetString("ca-bundle")
+	if strings.HasPrefix(caBundle, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			caBundle = filepath.Join(home, caBundle[2:])
+		}
+	}
+	cobra.CheckErr(httpclient.Configure(httpclient.Settings{
+		ConnectTimeout:    viper.GetDuration("connect-timeout"),
+		Timeout:           viper.GetDuration("timeout"),
+		Proxy:             viper.GetString("proxy"),
+		CABundle:          caBundle,
+		DisableHTTP2:      viper.GetBool("no-http2"),
+		DisableKeepAlives: viper.GetBool("no-keepalive"),
+	}))
+
+	// Send traces and metrics to an OTLP collector when OTEL_* says where
+	initTelemetry()
+
+	// Apply retry settings from flags or config to every API client
+	api.DefaultRetryPolicy = api.RetryPolicy{
+		MaxRetries: viper.GetInt("max-retries"),
+		Wait:       viper.GetDuration("retry-wait"),
+	}
+
+	// Fit tables to the terminal: titles wrap and other long values are
+	// truncated, unless --no-truncate; max-width caps columns either way
+	maxWidths, err := tableMaxWidths()
+	cobra.CheckErr(err)
+	output.SetTableOptions(output.TableOptions{
+		NoTruncate: viper.GetBool("no-truncate"),
+		MaxWidths:  maxWidths,
+	})
+
+	// Progress bars go to stderr, only when it is a terminal, and like other
+	// status messages not with --plaintext or --json
+	output.SetProgressEnabled(!viper.GetBool("no-progress") && !viper.GetBool("plaintext") && !viper.GetBool("json"))
+
+	// Shrink large images before every upload when enabled
+	files.DefaultImageOptions = nil
+	if viper.GetBool("optimize-images") {
+		files.DefaultImageOptions = &files.ImageOptions{
+			MaxDimension: viper.GetInt("image-max-dimension"),
+			Quality:      viper.GetInt("image-quality"),
+			Threshold:    viper.GetInt64("image-size-threshold"),
+		}
+	}
+
+	// Reuse the asset URLs of files uploaded before, per profile since
+	// assets belong to a workspace
+	api.DefaultUploadCache = nil
+	if home, err := os.UserHomeDir(); err == nil {
+		api.DefaultUploadCache = &api.UploadCache{
+			Dir:    filepath.Join(home, ".linctl", "uploads", auth.Profile()),
+			Bypass: viper.GetBool("force-upload"),
+		}
+	}
+
+	// Cache query responses unless disabled by flag or config; mutations
+	// empty the cache either way
+	api.DefaultResponseCache = nil
+	if dir, err := responseCacheDir(); err == nil {
+		api.DefaultResponseCache = &api.ResponseCache{
+			Dir:    dir,
+			TTL:    viper.GetDuration("cache-ttl"),
+			Bypass: !responseCacheEnabled(),
+		}
+	}
+}
+
+// themeColorOverrides reads the colors: section of the config file, where
+// roles are keys like "state.started" or nested maps like state: {started: ...}
+func themeColorOverrides() map[string]string {
+	overrides := map[string]string{}
+	var flatten func(prefix string, values map[string]interface{})
+	flatten = func(prefix string, values map[string]interface{}) {
+		for key, value := range values {
+			if nested, ok := value.(map[string]interface{}); ok {
+				flatten(prefix+key+".", nested)
+				continue
+			}
+			overrides[prefix+key] = fmt.Sprint(value)
+		}
+	}
+	flatten("", viper.GetStringMap("colors"))
+	return overrides
+}
+
+// tableMaxWidths reads max-width: "header=width" entries from the flag or a
+// config list, or a header: width map in the config file
+func tableMaxWidths() (map[string]int, error) {
+	var entries []string
+	for _, entry := range viper.GetStringSlice("max-width") {
+		entries = append(entries, strings.Split(entry, ",")...)
+	}
+	for header, width := range viper.GetStringMapString("max-width") {
+		entries = append(entries, header+"="+width)
+	}
+
+	widths := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		header, value, found := strings.Cut(entry, "=")
+		width, err := strconv.Atoi(strings.TrimSpace(value))
+		if !found || err != nil || width < 1 {
+			return nil, fmt.Errorf("invalid max-width '%s' (use header=width, e.g. title=60)", entry)
+		}
+		widths[strings.ToLower(strings.TrimSpace(header))] = width
+	}
+	return widths, nil
+}
+
+// noDefaultTeamAnnotation marks commands whose --team flag must not be filled
+// from default-team (e.g. because setting it replaces existing values)
+const noDefaultTeamAnnotation = "linctl/no-default-team"
+
+// applyDefaultTeam fills an unset --team flag from the "default-team" setting
+func applyDefaultTeam(cmd *cobra.Command) {
+	if cmd.Annotations[noDefaultTeamAnnotation] == "true" {
+		return
+	}
+	defaultTeam := viper.GetString("default-team")
+	if defaultTeam == "" {
+		return
+	}
+	flag := cmd.Flags().Lookup("team")
+	if flag == nil || flag.Changed {
+		return
+	}
+	_ = cmd.Flags().Set("team", defaultTeam)
+}
+
+// defaultProject is the "default-project" setting, which a repository's
+// .linctl.yml uses to bind it to a project: issues created in the default
+// team (or any team, without one) go to it
+func defaultProject(teamKey string) string {
+	defaultTeam := viper.GetString("default-team")
+	if defaultTeam != "" && !strings.EqualFold(teamKey, defaultTeam) {
+		return ""
+	}
+	return viper.GetString("default-project")
+}
+
+// authenticatedClient returns an API client for the stored credentials, exiting
+// with an error when linctl is not authenticated
+func authenticatedClient(plaintext, jsonOut bool) *api.Client {
+	authHeader, err := auth.GetAuthHeader()
+	if err != nil {
+		exitNotAuthenticated(plaintext, jsonOut)
+	}
+	return api.NewClient(authHeader)
 }
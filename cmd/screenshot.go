@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var screenshotCmd = &cobra.Command{
+	Use:   "screenshot",
+	Short: "Take a screenshot and add it to an issue",
+	Long: `Take a screenshot with the platform's screenshot tool, then upload it and add
+it to an issue: appended to the description, or posted as a new comment with
+--comment. You select a region of the screen unless --full is given.
+
+The issue defaults to the one named by the current git branch. Screenshots are
+taken with screencapture on macOS, the Snipping Tool on Windows, grim and slurp
+on Wayland, and gnome-screenshot, scrot, maim, or ImageMagick's import on X11.
+
+Examples:
+  linctl screenshot --issue ENG-123
+  linctl screenshot --issue ENG-123 --comment --alt "Broken layout on Safari"
+  linctl screenshot --full`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		issueID, err := issueFlagOrBranch(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		client := authenticatedClient(plaintext, jsonOut)
+
+		result, err := captureScreenshot(cmd, client, issueID)
+		if errors.Is(err, utils.ErrScreenshotCanceled) {
+			output.Error("Screenshot canceled; nothing was uploaded", plaintext, jsonOut)
+			exit(1)
+		}
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		printIssueImage(result, plaintext, jsonOut)
+	},
+}
+
+// captureScreenshot takes a screenshot into a temporary file and adds it to
+// the issue
+func captureScreenshot(cmd *cobra.Command, client *api.Client, issueID string) (issueImage, error) {
+	fullScreen, _ := cmd.Flags().GetBool("full")
+
+	dir, err := os.MkdirTemp("", "linctl-screenshot-")
+	if err != nil {
+		return issueImage{}, fmt.Errorf("Failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, fmt.Sprintf("screenshot-%s.png", time.Now().Format("20060102-150405")))
+	if err := utils.CaptureScreenshot(path, fullScreen); err != nil {
+		return issueImage{}, err
+	}
+	return addImageToIssue(cmd, client, issueID, path)
+}
+
+func init() {
+	rootCmd.AddCommand(screenshotCmd)
+
+	screenshotCmd.Flags().StringP("issue", "i", "", "Issue to add the screenshot to (default: from the current git branch)")
+	markIssueRefFlags(screenshotCmd.Flags(), "issue")
+	screenshotCmd.Flags().BoolP("comment", "c", false, "Post the screenshot as a new comment instead of appending it to the description")
+	screenshotCmd.Flags().String("alt", "", "Alt text for the screenshot (default: the file name)")
+	screenshotCmd.Flags().Bool("full", false, "Capture the whole screen instead of a selected region (not on Windows)")
+}
@@ -0,0 +1,419 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/slack"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// SLA statuses of an open issue
+const (
+	slaBreachedStatus = "breached"
+	slaAtRiskStatus   = "at risk"
+	slaOnTrackStatus  = "on track"
+)
+
+var slaCmd = &cobra.Command{
+	Use:   "sla",
+	Short: "Monitor issue SLAs",
+	Long: `Monitor the SLAs Linear sets on issues (for example from a team's triage
+rules), and post breaches to Slack from cron.
+
+Examples:
+  linctl sla status --team SUP
+  linctl sla status --team SUP --within 4h --sort priority,sla
+  linctl sla status --team SUP --breached --notify-slack https://hooks.slack.com/services/...`,
+}
+
+var slaStatusCmd = &cobra.Command{
+	Use:         "status",
+	Aliases:     []string{"ls", "list"},
+	Short:       "List open issues approaching or past their SLA",
+	Annotations: map[string]string{pagerAnnotation: "true"},
+	Long: `List open issues whose SLA has breached or breaches within --within (default
+24h), soonest first, with the time remaining and how much of the SLA window
+has been used. --all lists every open issue with an SLA.
+
+--sort takes the issue sort keys (sla, priority, assignee, team, created, ...;
+a leading - reverses one), and --assignee, --priority, --label, and --filter
+narrow the issues like they do for issue list.
+
+--notify-slack posts breaches to a Slack incoming webhook, given as its URL or
+as a name from the slack.webhooks setting. Each breach is posted once: linctl remembers what it posted in ~/.linctl/sla/, so running
+from cron only posts new breaches (--renotify posts them all again).
+
+Cron example (every 15 minutes):
+  */15 * * * * linctl sla status --team SUP --breached --notify-slack https://hooks.slack.com/services/...`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		within, _ := cmd.Flags().GetDuration("within")
+		all, _ := cmd.Flags().GetBool("all")
+		breachedOnly, _ := cmd.Flags().GetBool("breached")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		keys, err := parseIssueSortKeys(sortBy)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("%v. Valid keys are: %s", err, issueSortKeyNames), plaintext, jsonOut)
+		}
+
+		teamFlag, _ := cmd.Flags().GetString("team")
+		teamKey, err := resolveTeamKey(teamFlag)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		now := time.Now()
+		filter, err := slaFilter(cmd, teamKey, now, within, all, breachedOnly)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		webhookURL, err := slackWebhook(cmd)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		issues, _, err := api.Paginate(ctx, api.PaginateOptions{PageSize: 100}, func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+			page, err := client.GetIssues(ctx, filter, first, after, "")
+			if err != nil {
+				return nil, api.PageInfo{}, err
+			}
+			return page.Nodes, page.PageInfo, nil
+		})
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get issues: %v", err), plaintext, jsonOut)
+		}
+		(&issueOrder{Keys: keys}).apply(&api.Issues{Nodes: issues})
+
+		entries := make([]slaEntry, len(issues))
+		var breaches []slaEntry
+		for i := range issues {
+			entries[i] = newSLAEntry(issues[i], now, within)
+			if entries[i].Status == slaBreachedStatus {
+				breaches = append(breaches, entries[i])
+			}
+		}
+
+		var notified int
+		var notifyErr error
+		if webhookURL != "" {
+			renotify, _ := cmd.Flags().GetBool("renotify")
+			notified, notifyErr = notifySLABreaches(cmd, webhookURL, teamKey, breaches, renotify)
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"issues":   entries,
+				"breached": len(breaches),
+			}
+			if cmd.Flags().Changed("notify-slack") {
+				result["notified"] = notified
+			}
+			if notifyErr != nil {
+				result["error"] = notifyErr.Error()
+			}
+			output.JSON(result)
+		} else {
+			printSLAEntries(entries, plaintext, len(breaches))
+			if notified > 0 {
+				output.Info(fmt.Sprintf("Posted %d SLA breaches to Slack", notified), plaintext, false)
+			}
+		}
+		if notifyErr != nil {
+			exitWithError(notifyErr, fmt.Sprintf("Failed to post to Slack: %v", notifyErr), plaintext, jsonOut)
+		}
+	},
+}
+
+// slaEntry is an open issue with an SLA, as sla status shows it
+type slaEntry struct {
+	Issue     api.Issue `json:"issue"`
+	Status    string    `json:"status"`
+	Remaining string    `json:"remaining"`
+	// Used is the fraction of the SLA window that has passed; over 1 once breached
+	Used *float64 `json:"used,omitempty"`
+}
+
+func newSLAEntry(issue api.Issue, now time.Time, within time.Duration) slaEntry {
+	entry := slaEntry{Issue: issue, Status: slaOnTrackStatus}
+	breachesAt := *issue.SLABreachesAt
+	switch left := breachesAt.Sub(now); {
+	case left <= 0:
+		entry.Status = slaBreachedStatus
+		entry.Remaining = "breached " + formatTimeAgo(breachesAt)
+	case left <= within:
+		entry.Status = slaAtRiskStatus
+		entry.Remaining = formatRemaining(left) + " left"
+	default:
+		entry.Remaining = formatRemaining(left) + " left"
+	}
+	if issue.SLAStartedAt != nil && breachesAt.After(*issue.SLAStartedAt) {
+		used := float64(now.Sub(*issue.SLAStartedAt)) / float64(breachesAt.Sub(*issue.SLAStartedAt))
+		entry.Used = &used
+	}
+	return entry
+}
+
+// slaFilter matches open issues with an SLA, breaching before now+within
+// unless all, or already breached when breachedOnly, narrowed by the filter
+// flags
+func slaFilter(cmd *cobra.Command, teamKey string, now time.Time, within time.Duration, all, breachedOnly bool) (map[string]interface{}, error) {
+	breaches := map[string]interface{}{"null": false}
+	switch {
+	case breachedOnly:
+		breaches["lte"] = now.UTC().Format(time.RFC3339)
+	case !all:
+		breaches["lte"] = now.Add(within).UTC().Format(time.RFC3339)
+	}
+	filter := map[string]interface{}{
+		"slaBreachesAt": breaches,
+		"state":         map[string]interface{}{"type": map[string]interface{}{"nin": []string{"completed", "canceled"}}},
+	}
+	if teamKey != "" {
+		filter["team"] = map[string]interface{}{"key": map[string]interface{}{"eq": teamKey}}
+	}
+	if assignee, _ := cmd.Flags().GetString("assignee"); assignee != "" {
+		assigneeFilter, err := userFilter(context.Background(), assignee)
+		if err != nil {
+			return nil, err
+		}
+		filter["assignee"] = assigneeFilter
+	}
+	if priority := getPriorityFlag(cmd); priority != -1 {
+		filter["priority"] = map[string]interface{}{"eq": priority}
+	}
+
+	var and []interface{}
+	if labels, _ := cmd.Flags().GetStringSlice("label"); len(labels) > 0 {
+		for _, label := range labels {
+			if label = strings.TrimSpace(label); label != "" {
+				and = append(and, map[string]interface{}{
+					"labels": map[string]interface{}{
+						"some": map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": label}},
+					},
+				})
+			}
+		}
+	}
+	if text, _ := cmd.Flags().GetString("filter"); strings.TrimSpace(text) != "" {
+		node, err := parseIssueFilterExpr(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %v", err)
+		}
+		expression, err := compileIssueFilter(context.Background(), node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %v", err)
+		}
+		and = append(and, expression)
+	}
+	if len(and) > 0 {
+		filter["and"] = and
+	}
+	return filter, nil
+}
+
+// formatRemaining shows a duration to the minute: "45m", "3h 20m", "2d 4h"
+func formatRemaining(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+func printSLAEntries(entries []slaEntry, plaintext bool, breached int) {
+	if len(entries) == 0 {
+		output.Info("No open issues approaching their SLA", plaintext, false)
+		return
+	}
+
+	rows := make([][]string, len(entries))
+	for i, entry := range entries {
+		issue := entry.Issue
+		assignee := "Unassigned"
+		if issue.Assignee != nil {
+			assignee = issue.Assignee.Name
+		}
+		used := ""
+		if entry.Used != nil {
+			used = fmt.Sprintf("%.0f%%", *entry.Used*100)
+		}
+		status, remaining := entry.Status, entry.Remaining
+		if !plaintext {
+			statusColor := color.New(color.FgGreen)
+			switch entry.Status {
+			case slaBreachedStatus:
+				statusColor = color.New(color.FgRed, color.Bold)
+			case slaAtRiskStatus:
+				statusColor = color.New(color.FgYellow)
+			}
+			status, remaining = statusColor.Sprint(status), statusColor.Sprint(remaining)
+		}
+		rows[i] = []string{
+			issue.Identifier,
+			issue.Title,
+			priorityToString(issue.Priority),
+			assignee,
+			status,
+			remaining,
+			used,
+		}
+	}
+	output.Table(output.TableData{
+		Headers: []string{"Issue", "Title", "Priority", "Assignee", "SLA", "Remaining", "Used"},
+		Rows:    rows,
+	}, plaintext, false)
+
+	if !plaintext {
+		fmt.Printf("\n%s %d issues, %d breached\n", color.New(color.FgGreen).Sprint("✓"), len(entries), breached)
+	}
+}
+
+// notifySLABreaches posts the breaches not posted before (all of them with
+// renotify) to a Slack incoming webhook, returning how many it posted
+func notifySLABreaches(cmd *cobra.Command, webhookURL, teamKey string, breaches []slaEntry, renotify bool) (int, error) {
+	posted, err := loadSLANotified()
+	if err != nil {
+		return 0, err
+	}
+
+	var fresh []slaEntry
+	current := map[string]time.Time{}
+	for _, entry := range breaches {
+		breachesAt := *entry.Issue.SLABreachesAt
+		current[entry.Issue.ID] = breachesAt
+		if at, ok := posted[entry.Issue.ID]; renotify || !ok || !at.Equal(breachesAt) {
+			fresh = append(fresh, entry)
+		}
+	}
+	if len(fresh) == 0 {
+		return 0, saveSLANotified(current)
+	}
+
+	if err := slack.Post(context.Background(), webhookURL, slaBreachMessage(cmd, teamKey, fresh)); err != nil {
+		return 0, err
+	}
+	return len(fresh), saveSLANotified(current)
+}
+
+// slaBreachMessage is the Slack message announcing breaches: a section per
+// issue with how long ago it breached, its priority, and its assignee
+func slaBreachMessage(cmd *cobra.Command, teamKey string, breaches []slaEntry) slack.Message {
+	where := ""
+	if teamKey != "" {
+		where = " in " + teamKey
+	}
+	title := fmt.Sprintf("%d SLA %s%s", len(breaches), pluralize("breach", "breaches", len(breaches)), where)
+	message := slack.Message{
+		Text:   title,
+		Blocks: []slack.Block{slack.Header("🚨 " + title)},
+	}
+	for _, entry := range breaches {
+		issue := entry.Issue
+		assignee := "Unassigned"
+		if issue.Assignee != nil {
+			assignee = issue.Assignee.Name
+		}
+		message.Blocks = append(message.Blocks, slack.Section(fmt.Sprintf("*%s* %s\n%s · %s · %s",
+			slack.Link(issue.URL, issue.Identifier), slack.Escape(issue.Title),
+			entry.Remaining, priorityToString(issue.Priority), slack.Escape(assignee))))
+	}
+	message.Blocks = append(message.Blocks, slackFooter(cmd))
+	return message
+}
+
+// pluralize picks the singular or plural of a word for n
+func pluralize(singular, plural string, n int) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// slaNotifiedPath is ~/.linctl/sla/PROFILE.json
+func slaNotifiedPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl", "sla", auth.Profile()+".json"), nil
+}
+
+// loadSLANotified reads when each breach posted to Slack breached, by issue ID
+func loadSLANotified() (map[string]time.Time, error) {
+	path, err := slaNotifiedPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]time.Time{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read posted SLA breaches: %w", err)
+	}
+	posted := map[string]time.Time{}
+	if err := json.Unmarshal(data, &posted); err != nil {
+		return nil, fmt.Errorf("failed to parse posted SLA breaches %s: %w", path, err)
+	}
+	return posted, nil
+}
+
+// saveSLANotified replaces the posted breaches with those still breached, so
+// an issue that breaches again is posted again
+func saveSLANotified(posted map[string]time.Time) error {
+	path, err := slaNotifiedPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(posted, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func init() {
+	rootCmd.AddCommand(slaCmd)
+	slaCmd.AddCommand(slaStatusCmd)
+
+	slaStatusCmd.Flags().StringP("team", "t", "", "Team key (default: default-team from config; '@any' for every team)")
+	slaStatusCmd.Flags().Duration("within", 24*time.Hour, "List issues whose SLA breaches within this long")
+	slaStatusCmd.Flags().Bool("all", false, "List every open issue with an SLA")
+	slaStatusCmd.Flags().Bool("breached", false, "Only list issues past their SLA")
+	slaStatusCmd.Flags().StringP("sort", "o", "sla", "Sort keys, e.g. sla or priority,sla (see 'issue list --help')")
+	slaStatusCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (email, name, @me, @none, or @any)")
+	slaStatusCmd.Flags().VarP(newPriorityFlag(-1), "priority", "r", "Filter by priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low, or the name)")
+	slaStatusCmd.Flags().StringSlice("label", []string{}, "Filter by label name (repeat or comma-separate to require several)")
+	slaStatusCmd.Flags().String("filter", "", "Filter expression, e.g. 'priority:>=high AND label:bug' (see 'issue list --help')")
+	slaStatusCmd.Flags().String("notify-slack", "", "Post new breaches to a Slack incoming webhook: its URL, or a name from the slack.webhooks setting")
+	slaStatusCmd.Flags().Bool("renotify", false, "With --notify-slack, post every breach, including ones posted before")
+}
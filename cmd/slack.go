@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/slack"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// slackWebhookHelp describes --notify-slack for the commands that take it
+const slackWebhookHelp = "Post the results to a Slack incoming webhook: its URL, or a name from the slack.webhooks setting"
+
+// slackWebhook resolves --notify-slack to a webhook URL: the flag is a URL,
+// or the name of one in the slack.webhooks setting. It is empty without the flag.
+func slackWebhook(cmd *cobra.Command) (string, error) {
+	value, _ := cmd.Flags().GetString("notify-slack")
+	value = strings.TrimSpace(value)
+	if value == "" || isWebhookURL(value) {
+		return value, nil
+	}
+	name := strings.ToLower(value)
+	if webhookURL := viper.GetString("slack.webhooks." + name); webhookURL != "" {
+		return webhookURL, nil
+	}
+	return "", fmt.Errorf("unknown Slack webhook '%s': give a webhook URL, or set one with 'linctl config set slack.webhooks.%s URL'", value, name)
+}
+
+// isWebhookURL reports whether value is an http(s) URL rather than a webhook name
+func isWebhookURL(value string) bool {
+	return strings.HasPrefix(value, "https://") || strings.HasPrefix(value, "http://")
+}
+
+// validateSlackWebhook checks a slack.webhooks entry
+func validateSlackWebhook(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || !isWebhookURL(value) || parsed.Host == "" {
+		return fmt.Errorf("invalid Slack webhook '%s' (expected an https:// URL)", value)
+	}
+	return nil
+}
+
+// notifySlack posts a message to the --notify-slack webhook, when there is
+// one, and exits if Slack does not take it
+func notifySlack(webhookURL string, message slack.Message, plaintext, jsonOut bool) {
+	if webhookURL == "" {
+		return
+	}
+	if err := slack.Post(context.Background(), webhookURL, message); err != nil {
+		exitWithError(err, fmt.Sprintf("Failed to post to Slack: %v", err), plaintext, jsonOut)
+	}
+}
+
+// slackFooter is the context line closing a message, naming the command that posted it
+func slackFooter(cmd *cobra.Command, details ...string) slack.Block {
+	return slack.Context(append([]string{"`" + cmd.CommandPath() + "`"}, details...)...)
+}
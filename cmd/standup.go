@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var standupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "Summarize your recent work for a standup",
+	Long: `Summarize what a user did since the last working day: issues they completed,
+issues they moved between states, issues they commented on, and what they
+have in progress, grouped by project. The summary is markdown, ready to paste
+into Slack.
+
+--since takes 'yesterday' (the start of the last working day, so Friday on a
+Monday), 'today', a date, or a time expression such as 24h or 3_days_ago.
+
+Examples:
+  linctl standup
+  linctl standup --since 2_days_ago --links
+  linctl standup --user jane@example.com --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		since, err := parseStandupSince(sinceFlag, time.Now())
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		userRef, _ := cmd.Flags().GetString("user")
+		if userShortcut(userRef) == meShortcut {
+			userRef = "me"
+		}
+		user, err := resolveUser(ctx, client, userRef)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		summary, err := loadStandup(ctx, client, user, since)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to load activity: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(summary)
+			return
+		}
+		links, _ := cmd.Flags().GetBool("links")
+		fmt.Print(formatStandup(summary, links))
+	},
+}
+
+// standupSummary is a user's activity since a point in time, grouped by project
+type standupSummary struct {
+	User     string           `json:"user"`
+	Since    time.Time        `json:"since"`
+	Projects []standupProject `json:"projects"`
+}
+
+type standupProject struct {
+	Name  string        `json:"name"`
+	Items []standupItem `json:"items"`
+}
+
+// standupItem is what happened to one issue. An issue can be completed,
+// moved, commented on, and in progress in any combination.
+type standupItem struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	State      string `json:"state"`
+	Completed  bool   `json:"completed"`
+	MovedFrom  string `json:"movedFrom,omitempty"`
+	MovedTo    string `json:"movedTo,omitempty"`
+	InProgress bool   `json:"inProgress"`
+	Comments   int    `json:"comments"`
+	project    string
+}
+
+// parseStandupSince turns --since into a time: 'yesterday' is the start of the
+// previous working day, 'today' the start of today, and anything else a time
+// expression
+func parseStandupSince(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "today":
+		return today, nil
+	case "", "yesterday":
+		day := today.AddDate(0, 0, -1)
+		for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			day = day.AddDate(0, 0, -1)
+		}
+		return day, nil
+	}
+
+	parsed, err := utils.ParseTimeExpression(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since value: %v", err)
+	}
+	if parsed == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, parsed)
+}
+
+// loadStandup collects the user's assigned issues that changed or are in
+// progress, and the comments they wrote, since the given time
+func loadStandup(ctx context.Context, client *api.Client, user *api.User, since time.Time) (standupSummary, error) {
+	summary := standupSummary{User: user.Name, Since: since, Projects: []standupProject{}}
+	sinceValue := since.UTC().Format(time.RFC3339)
+	pageAll := api.PaginateOptions{PageSize: api.MaxPageSize}
+
+	issueFilter := map[string]interface{}{
+		"assignee": map[string]interface{}{"id": map[string]interface{}{"eq": user.ID}},
+		"or": []interface{}{
+			map[string]interface{}{"updatedAt": map[string]interface{}{"gte": sinceValue}},
+			map[string]interface{}{"state": map[string]interface{}{"type": map[string]interface{}{"eq": "started"}}},
+		},
+	}
+	issues, _, err := api.Paginate(ctx, pageAll, func(ctx context.Context, first int, after string) ([]api.Issue, api.PageInfo, error) {
+		page, err := client.GetIssueActivity(ctx, issueFilter, first, after)
+		if err != nil {
+			return nil, api.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return summary, err
+	}
+
+	commentFilter := map[string]interface{}{
+		"user":      map[string]interface{}{"id": map[string]interface{}{"eq": user.ID}},
+		"createdAt": map[string]interface{}{"gte": sinceValue},
+	}
+	comments, _, err := api.Paginate(ctx, pageAll, func(ctx context.Context, first int, after string) ([]api.Comment, api.PageInfo, error) {
+		page, err := client.GetComments(ctx, commentFilter, first, after)
+		if err != nil {
+			return nil, api.PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return summary, err
+	}
+
+	items := make(map[string]*standupItem)
+	var order []string
+	itemFor := func(issue api.Issue) *standupItem {
+		if item, ok := items[issue.ID]; ok {
+			return item
+		}
+		item := &standupItem{Identifier: issue.Identifier, Title: issue.Title, URL: issue.URL, project: "No project"}
+		if issue.State != nil {
+			item.State = issue.State.Name
+		}
+		if issue.Project != nil {
+			item.project = issue.Project.Name
+		}
+		items[issue.ID] = item
+		order = append(order, issue.ID)
+		return item
+	}
+
+	for _, issue := range issues {
+		completed := issue.CompletedAt != nil && !issue.CompletedAt.Before(since)
+		inProgress := issue.State != nil && issue.State.Type == "started"
+
+		// The state changes this user made in the window, oldest first
+		var moves []api.IssueHistoryEntry
+		if issue.History != nil {
+			for _, entry := range issue.History.Nodes {
+				if entry.ToState == nil || entry.CreatedAt.Before(since) || entry.Actor == nil || entry.Actor.ID != user.ID {
+					continue
+				}
+				moves = append(moves, entry)
+			}
+		}
+		sort.SliceStable(moves, func(i, j int) bool { return moves[i].CreatedAt.Before(moves[j].CreatedAt) })
+
+		if !completed && !inProgress && len(moves) == 0 {
+			continue
+		}
+		item := itemFor(issue)
+		item.Completed = completed
+		item.InProgress = inProgress && !completed
+		if len(moves) > 0 && !completed {
+			item.MovedFrom = historyState(moves[0].FromState)
+			item.MovedTo = historyState(moves[len(moves)-1].ToState)
+		}
+	}
+	for _, comment := range comments {
+		if comment.Issue != nil {
+			itemFor(*comment.Issue).Comments++
+		}
+	}
+
+	byProject := make(map[string]*standupProject)
+	for _, id := range order {
+		item := items[id]
+		if byProject[item.project] == nil {
+			byProject[item.project] = &standupProject{Name: item.project}
+		}
+		byProject[item.project].Items = append(byProject[item.project].Items, *item)
+	}
+	for _, project := range byProject {
+		sort.SliceStable(project.Items, func(i, j int) bool {
+			return standupItemOrder(project.Items[i]) < standupItemOrder(project.Items[j])
+		})
+		summary.Projects = append(summary.Projects, *project)
+	}
+	sort.Slice(summary.Projects, func(i, j int) bool {
+		a, b := summary.Projects[i].Name, summary.Projects[j].Name
+		if a == "No project" || b == "No project" {
+			return b == "No project" && a != b
+		}
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+	return summary, nil
+}
+
+// standupItemOrder lists completed work first, then moves, work in progress,
+// and issues that were only commented on
+func standupItemOrder(item standupItem) int {
+	switch {
+	case item.Completed:
+		return 0
+	case item.MovedTo != "":
+		return 1
+	case item.InProgress:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// formatStandup renders the summary as markdown, one section per project
+func formatStandup(summary standupSummary, links bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Standup: %s** (since %s)\n", summary.User, summary.Since.Local().Format("Mon Jan 2"))
+	if len(summary.Projects) == 0 {
+		b.WriteString("\nNo activity.\n")
+		return b.String()
+	}
+
+	var completed, moved, inProgress, commented int
+	for _, project := range summary.Projects {
+		fmt.Fprintf(&b, "\n**%s**\n", project.Name)
+		for _, item := range project.Items {
+			issue := item.Identifier
+			if links && item.URL != "" {
+				issue = fmt.Sprintf("[%s](%s)", item.Identifier, item.URL)
+			}
+
+			var line string
+			switch {
+			case item.Completed:
+				completed++
+				line = fmt.Sprintf("✅ Completed %s %s", issue, item.Title)
+			case item.MovedTo != "":
+				moved++
+				line = fmt.Sprintf("🔀 %s %s (%s → %s)", issue, item.Title, firstNonEmpty(item.MovedFrom, "none"), item.MovedTo)
+			case item.InProgress:
+				inProgress++
+				line = fmt.Sprintf("🚧 %s %s (%s)", issue, item.Title, item.State)
+			default:
+				line = fmt.Sprintf("💬 Commented on %s %s", issue, item.Title)
+			}
+			if item.Comments > 0 {
+				commented++
+				if standupItemOrder(item) < 3 && item.Comments == 1 {
+					line += " · 1 comment"
+				} else if standupItemOrder(item) < 3 {
+					line += fmt.Sprintf(" · %d comments", item.Comments)
+				}
+			}
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n_%d completed · %d moved · %d in progress · %d commented on_\n", completed, moved, inProgress, commented)
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(standupCmd)
+
+	standupCmd.Flags().StringP("user", "u", meShortcut, "User to summarize (email, name, or @me)")
+	standupCmd.Flags().StringP("since", "s", "yesterday", "Activity since: yesterday, today, a date, or a time expression (24h, 3_days_ago)")
+	standupCmd.Flags().Bool("links", false, "Link issue IDs to Linear in the markdown")
+}
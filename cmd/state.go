@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// maxStateTypos is the edit distance tolerated when matching a mistyped state name
+const maxStateTypos = 2
+
+// stateCmd represents the state command
+var stateCmd = &cobra.Command{
+	Use:     "state",
+	Aliases: []string{"states"},
+	Short:   "Inspect workflow states",
+	Long: `Inspect a team's workflow states.
+
+State names given to 'issue update --state' are checked against these states.
+Partial names ("in prog"), squashed names ("inprogress"), state types
+("started"), and small typos ("Doen") are accepted when they match exactly one state.
+
+Examples:
+  linctl state list --team ENG
+  linctl state list --team ENG --json`,
+}
+
+var stateListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List a team's workflow states",
+	Long:    `List a team's workflow states in workflow order, with their types and positions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		teamKey, _ := cmd.Flags().GetString("team")
+		if teamKey == "" {
+			output.Error("A team is required: use --team TEAM-KEY (or set default-team in config)", plaintext, jsonOut)
+			exit(1)
+		}
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		states, err := client.GetTeamStates(context.Background(), teamKey)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get workflow states: %v", err), plaintext, jsonOut)
+		}
+
+		renderWorkflowStates(states, teamKey, plaintext, jsonOut)
+	},
+}
+
+// renderWorkflowStates prints a team's states in workflow order
+func renderWorkflowStates(states []api.WorkflowState, teamKey string, plaintext, jsonOut bool) {
+	sortWorkflowStates(states)
+
+	if jsonOut {
+		output.JSON(states)
+		return
+	}
+	if plaintext {
+		fmt.Println("Name\tType\tPosition\tColor\tID")
+		for _, state := range states {
+			fmt.Printf("%s\t%s\t%g\t%s\t%s\n", state.Name, state.Type, state.Position, state.Color, state.ID)
+		}
+		return
+	}
+
+	rows := make([][]string, len(states))
+	for i, state := range states {
+		rows[i] = []string{
+			state.Name,
+			stateTypeColor(state.Type, state.Color).Sprint(state.Type),
+			fmt.Sprintf("%g", state.Position),
+			state.Color,
+			state.ID,
+		}
+	}
+	output.Table(output.TableData{
+		Headers: []string{"Name", "Type", "Position", "Color", "ID"},
+		Rows:    rows,
+	}, plaintext, jsonOut)
+	fmt.Printf("\n%s %d states in team %s\n",
+		color.New(color.FgGreen).Sprint("✓"),
+		len(states),
+		color.New(color.FgCyan).Sprint(teamKey))
+}
+
+// matchWorkflowState finds the state a user meant. It tries, in order: the
+// exact name, the name ignoring spaces and punctuation, word prefixes
+// ("in prog"), the state type ("started"), and finally near-misses within
+// maxStateTypos edits. Each step must match a single state to be accepted.
+func matchWorkflowState(states []api.WorkflowState, teamKey, input string) (*api.WorkflowState, error) {
+	sorted := make([]api.WorkflowState, len(states))
+	copy(sorted, states)
+	sortWorkflowStates(sorted)
+
+	query := strings.TrimSpace(input)
+	squashed := squashStateName(query)
+	words := strings.Fields(strings.ToLower(query))
+
+	matchers := []func(api.WorkflowState) bool{
+		func(state api.WorkflowState) bool { return strings.EqualFold(state.Name, query) },
+		func(state api.WorkflowState) bool { return squashStateName(state.Name) == squashed },
+		func(state api.WorkflowState) bool { return wordPrefixMatch(state.Name, words) },
+		func(state api.WorkflowState) bool { return strings.EqualFold(state.Type, query) },
+	}
+
+	for _, matches := range matchers {
+		var found []api.WorkflowState
+		for _, state := range sorted {
+			if matches(state) {
+				found = append(found, state)
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return &found[0], nil
+		default:
+			return nil, fmt.Errorf("state '%s' is ambiguous in %s: matches %s", input, teamKey, workflowStateNames(found))
+		}
+	}
+
+	// Typos: accept the single closest state within the tolerance
+	best, bestDistance, tied := -1, maxStateTypos+1, false
+	for i, state := range sorted {
+		distance := editDistance(squashed, squashStateName(state.Name))
+		switch {
+		case distance < bestDistance:
+			best, bestDistance, tied = i, distance, false
+		case distance == bestDistance:
+			tied = true
+		}
+	}
+	if best >= 0 && !tied && len(squashed) >= 3 {
+		return &sorted[best], nil
+	}
+
+	return nil, fmt.Errorf("state '%s' not found in %s. Valid states: %s", input, teamKey, workflowStateNames(sorted))
+}
+
+// workflowStateNames joins state names for error messages
+func workflowStateNames(states []api.WorkflowState) string {
+	names := make([]string, len(states))
+	for i, state := range states {
+		names[i] = state.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// squashStateName lowercases a name and drops everything but letters and digits
+func squashStateName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// wordPrefixMatch reports whether each query word prefixes a later word of
+// name, in order, so "in prog" matches "In Progress" and "rev" matches "In Review"
+func wordPrefixMatch(name string, words []string) bool {
+	if len(words) == 0 {
+		return false
+	}
+	nameWords := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	next := 0
+	for _, word := range words {
+		for next < len(nameWords) && !strings.HasPrefix(nameWords[next], word) {
+			next++
+		}
+		if next == len(nameWords) {
+			return false
+		}
+		next++
+	}
+	return true
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// sortWorkflowStates orders states by type (triage → canceled), then position
+func sortWorkflowStates(states []api.WorkflowState) {
+	sort.SliceStable(states, func(i, j int) bool {
+		if stateTypeOrder[states[i].Type] != stateTypeOrder[states[j].Type] {
+			return stateTypeOrder[states[i].Type] < stateTypeOrder[states[j].Type]
+		}
+		return states[i].Position < states[j].Position
+	})
+}
+
+// stateTypeColor is the theme's color for a workflow state of a type; hex is
+// the state's own color in Linear, or ""
+func stateTypeColor(stateType, hex string) *color.Color {
+	return output.StateColor(stateType, hex)
+}
+
+// stateIcon is the icon for issues in a state: ✓ done, ◐ in progress, ✗
+// canceled, ○ otherwise
+func stateIcon(state *api.State) string {
+	if state == nil {
+		return "○"
+	}
+	icon := "○"
+	switch state.Type {
+	case "completed":
+		icon = "✓"
+	case "started":
+		icon = "◐"
+	case "canceled":
+		icon = "✗"
+	}
+	return stateTypeColor(state.Type, state.Color).Sprint(icon)
+}
+
+func init() {
+	rootCmd.AddCommand(stateCmd)
+	stateCmd.AddCommand(stateListCmd)
+
+	stateListCmd.Flags().StringP("team", "t", "", "Team key")
+}
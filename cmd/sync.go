@@ -0,0 +1,358 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/cache"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// cacheStaleAfter is how old a sync can get before cached results are flagged
+const cacheStaleAfter = 24 * time.Hour
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync issues into the local cache",
+	Long: `Download issues into a local cache under ~/.linctl/cache so that
+'issue list --cached' and 'issue search --cached' answer instantly, even offline.
+
+The first sync fetches every issue; later syncs only fetch issues updated since
+the previous one. Use --full to rebuild the cache from scratch.
+
+Examples:
+  linctl sync
+  linctl sync --full
+  linctl sync status
+  linctl issue list --cached --assignee me`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		client.SetResponseCache(nil)
+		full, _ := cmd.Flags().GetBool("full")
+		pageSize, _ := cmd.Flags().GetInt("page-size")
+
+		store := openCache(false, plaintext, jsonOut)
+		defer store.Close()
+
+		if full {
+			if err := store.Reset(); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to reset cache: %v", err), plaintext, jsonOut)
+			}
+		}
+		startedAt := time.Now()
+		fetched, incremental, err := syncIssues(context.Background(), client, store, pageSize, func(fetched int) {
+			if !jsonOut {
+				fmt.Fprintf(os.Stderr, "Fetched %d issues...\r", fetched)
+			}
+		})
+		if !jsonOut && fetched > 0 {
+			fmt.Fprintln(os.Stderr)
+		}
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to sync: %v", err), plaintext, jsonOut)
+		}
+
+		status, err := store.Status()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to read cache: %v", err), plaintext, jsonOut)
+		}
+
+		mode := "full"
+		if incremental {
+			mode = "incremental"
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{
+				"mode":     mode,
+				"fetched":  fetched,
+				"issues":   status.Issues,
+				"syncedAt": status.SyncedAt,
+				"duration": time.Since(startedAt).Round(time.Millisecond).String(),
+			})
+		} else if plaintext {
+			fmt.Printf("Synced %d issues (%s), %d in cache\n", fetched, mode, status.Issues)
+		} else {
+			fmt.Printf("%s Synced %d issues (%s) in %s, %d in cache\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				fetched,
+				mode,
+				time.Since(startedAt).Round(100*time.Millisecond),
+				status.Issues)
+		}
+	},
+}
+
+var syncStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show what the local cache holds",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		store, err := loadCache()
+		if errors.Is(err, cache.ErrNotSynced) {
+			if jsonOut {
+				output.JSON(map[string]interface{}{"issues": 0, "synced": false})
+				return
+			}
+			output.Info("The local cache is empty. Run 'linctl sync' to populate it.", plaintext, jsonOut)
+			return
+		}
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		defer store.Close()
+
+		status, err := store.Status()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to read cache: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(status)
+			return
+		}
+
+		synced := "never"
+		if !status.SyncedAt.IsZero() {
+			synced = fmt.Sprintf("%s (%s)", status.SyncedAt.Local().Format("2006-01-02 15:04"), formatTimeAgo(status.SyncedAt))
+		}
+
+		if plaintext {
+			fmt.Printf("Path: %s\n", status.Path)
+			fmt.Printf("Issues: %d\n", status.Issues)
+			fmt.Printf("Synced: %s\n", synced)
+			fmt.Printf("Size: %d bytes\n", status.Size)
+			return
+		}
+
+		fmt.Printf("%s %s\n", color.New(color.FgWhite, color.Faint).Sprint("Path:  "), status.Path)
+		fmt.Printf("%s %d\n", color.New(color.FgWhite, color.Faint).Sprint("Issues:"), status.Issues)
+		syncedText := synced
+		if status.SyncedAt.IsZero() || time.Since(status.SyncedAt) > cacheStaleAfter {
+			syncedText = color.New(color.FgYellow).Sprint(synced)
+		}
+		fmt.Printf("%s %s\n", color.New(color.FgWhite, color.Faint).Sprint("Synced:"), syncedText)
+		fmt.Printf("%s %.1f MB\n", color.New(color.FgWhite, color.Faint).Sprint("Size:  "), float64(status.Size)/(1024*1024))
+	},
+}
+
+var syncClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete the local cache",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		path, err := cache.Path(auth.Profile())
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			exitWithError(err, fmt.Sprintf("Failed to delete cache: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"cleared": true, "path": path})
+			return
+		}
+		output.Success("Local cache cleared", plaintext, jsonOut)
+	},
+}
+
+// syncIssues fetches the issues updated since the cache's last sync (every
+// issue on the first) into store, calling progress after each page
+func syncIssues(ctx context.Context, client *api.Client, store *cache.Store, pageSize int, progress func(fetched int)) (fetched int, incremental bool, err error) {
+	_, cursor, err := store.IssuesSyncedAt()
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read cache: %w", err)
+	}
+	incremental = !cursor.IsZero()
+
+	// Issues touched during the sync are picked up by the next one, since
+	// the cursor only advances to the newest updatedAt actually seen
+	var filter map[string]interface{}
+	if incremental {
+		filter = map[string]interface{}{
+			"updatedAt": map[string]interface{}{"gte": cursor.Format(time.RFC3339Nano)},
+		}
+	}
+
+	startedAt := time.Now()
+	after := ""
+	for {
+		page, err := client.GetSyncIssues(ctx, filter, pageSize, after)
+		if err != nil {
+			return fetched, incremental, fmt.Errorf("failed to fetch issues: %w", err)
+		}
+		if err := store.PutIssues(page.Nodes); err != nil {
+			return fetched, incremental, fmt.Errorf("failed to write cache: %w", err)
+		}
+		for _, issue := range page.Nodes {
+			if issue.UpdatedAt.After(cursor) {
+				cursor = issue.UpdatedAt
+			}
+		}
+		fetched += len(page.Nodes)
+		if progress != nil {
+			progress(fetched)
+		}
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			break
+		}
+		after = page.PageInfo.EndCursor
+	}
+
+	if cursor.IsZero() {
+		cursor = startedAt
+	}
+	if err := store.SetIssuesSyncedAt(time.Now(), cursor); err != nil {
+		return fetched, incremental, fmt.Errorf("failed to write cache: %w", err)
+	}
+	return fetched, incremental, nil
+}
+
+// openCache opens the active profile's cache, exiting on failure
+func openCache(readOnly, plaintext, jsonOut bool) *cache.Store {
+	path, err := cache.Path(auth.Profile())
+	if err != nil {
+		exitWithError(err, err.Error(), plaintext, jsonOut)
+	}
+	store, err := cache.Open(path, readOnly)
+	if err != nil {
+		exitWithError(err, err.Error(), plaintext, jsonOut)
+	}
+	return store
+}
+
+// loadCache opens the active profile's cache read-only
+func loadCache() (*cache.Store, error) {
+	path, err := cache.Path(auth.Profile())
+	if err != nil {
+		return nil, err
+	}
+	return cache.Open(path, true)
+}
+
+// cachedIssues answers list and search from the local cache: issues matching
+// filter and every word of query, sorted like the API's orderBy, and limited
+// like fetchIssuePages. It also returns when the cache was last synced.
+func cachedIssues(cmd *cobra.Command, filter map[string]interface{}, query, orderBy string, limit int) (*api.Issues, time.Time, error) {
+	store, err := loadCache()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer store.Close()
+
+	syncedAt, _, err := store.IssuesSyncedAt()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if syncedAt.IsZero() {
+		return nil, time.Time{}, cache.ErrNotSynced
+	}
+
+	all, err := store.Issues()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	terms := strings.Fields(strings.ToLower(query))
+	var matched []api.Issue
+	for _, issue := range all {
+		if !issueMatchesTerms(issue, terms) {
+			continue
+		}
+		ok, err := cache.Match(issue, filter)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("unsupported filter for cached results: %v", err)
+		}
+		if ok {
+			matched = append(matched, issue)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		if orderBy == "updatedAt" {
+			return matched[i].UpdatedAt.After(matched[j].UpdatedAt)
+		}
+		return matched[i].CreatedAt.After(matched[j].CreatedAt)
+	})
+
+	maxResults := limit
+	if fetchAll, _ := cmd.Flags().GetBool("all"); fetchAll && !cmd.Flags().Changed("limit") {
+		maxResults = 0
+	}
+	issues := &api.Issues{Nodes: matched}
+	if maxResults > 0 && len(matched) > maxResults {
+		issues.Nodes = matched[:maxResults]
+		issues.PageInfo.HasNextPage = true
+	}
+	if issues.Nodes == nil {
+		issues.Nodes = []api.Issue{}
+	}
+	return issues, syncedAt, nil
+}
+
+// issueMatchesTerms reports whether every term appears in the issue's
+// identifier, title, or description
+func issueMatchesTerms(issue api.Issue, terms []string) bool {
+	if len(terms) == 0 {
+		return true
+	}
+	text := strings.ToLower(issue.Identifier + "\n" + issue.Title + "\n" + issue.Description)
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// printCacheNotice tells the user results came from the cache and how old it
+// is. It goes to stderr so piped output is unaffected.
+func printCacheNotice(syncedAt time.Time, plaintext, jsonOut bool) {
+	if jsonOut {
+		return
+	}
+	notice := fmt.Sprintf("From local cache, synced %s", formatTimeAgo(syncedAt))
+	stale := time.Since(syncedAt) > cacheStaleAfter
+	if stale {
+		notice += " (run 'linctl sync' to refresh)"
+	}
+	if plaintext {
+		fmt.Fprintln(os.Stderr, notice)
+		return
+	}
+	c := color.New(color.FgWhite, color.Faint)
+	if stale {
+		c = color.New(color.FgYellow)
+	}
+	fmt.Fprintln(os.Stderr, c.Sprint(notice))
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.AddCommand(syncStatusCmd)
+	syncCmd.AddCommand(syncClearCmd)
+
+	syncCmd.Flags().Bool("full", false, "Discard the cache and fetch every issue again")
+	syncCmd.Flags().Int("page-size", api.DefaultPageSize, "Number of issues to request per page (max 250)")
+}
@@ -3,7 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
+	"sort"
 	"strings"
 
 	"github.com/dorkitude/linctl/pkg/api"
@@ -20,10 +20,14 @@ var teamCmd = &cobra.Command{
 	Short: "Manage Linear teams",
 	Long: `Manage Linear teams including listing teams, viewing team details, and listing team members.
 
+The team key may be omitted when default-team is set in config.
+
 Examples:
   linctl team list              # List all teams
   linctl team get ENG           # Get team details
-  linctl team members ENG       # List team members`,
+  linctl team members ENG       # List team members
+  linctl team states ENG        # List workflow states
+  linctl team labels ENG        # List team labels`,
 }
 
 var teamListCmd = &cobra.Command{
@@ -35,11 +39,15 @@ var teamListCmd = &cobra.Command{
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 
+		format, err := parseDelimitedFormat(cmd, teamColumns)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
 		// Get auth header
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
 		}
 
 		// Create API client
@@ -62,15 +70,21 @@ var teamListCmd = &cobra.Command{
 				orderBy = ""
 			default:
 				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 
 		// Get teams
 		teams, err := client.GetTeams(context.Background(), limit, "", orderBy)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to list teams: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to list teams: %v", err), plaintext, jsonOut)
+		}
+
+		if format != nil {
+			if err := writeDelimited(format, teams.Nodes, teamColumns); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write output: %v", err), plaintext, jsonOut)
+			}
+			return
 		}
 
 		// Handle output
@@ -133,21 +147,20 @@ var teamListCmd = &cobra.Command{
 }
 
 var teamGetCmd = &cobra.Command{
-	Use:     "get TEAM-KEY",
-	Aliases: []string{"show"},
+	Use:     "get [TEAM-KEY]",
+	Aliases: []string{"show", "view"},
 	Short:   "Get team details",
 	Long:    `Get detailed information about a specific team.`,
-	Args:    cobra.ExactArgs(1),
+	Args:    cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
-		teamKey := args[0]
+		teamKey := teamKeyFromArgs(args, plaintext, jsonOut)
 
 		// Get auth header
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
 		}
 
 		// Create API client
@@ -156,8 +169,7 @@ var teamGetCmd = &cobra.Command{
 		// Get team details
 		team, err := client.GetTeam(context.Background(), teamKey)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to get team: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to get team: %v", err), plaintext, jsonOut)
 		}
 
 		// Handle output
@@ -198,20 +210,19 @@ var teamGetCmd = &cobra.Command{
 }
 
 var teamMembersCmd = &cobra.Command{
-	Use:   "members TEAM-KEY",
+	Use:   "members [TEAM-KEY]",
 	Short: "List team members",
 	Long:  `List all members of a specific team.`,
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
-		teamKey := args[0]
+		teamKey := teamKeyFromArgs(args, plaintext, jsonOut)
 
 		// Get auth header
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
 		}
 
 		// Create API client
@@ -220,8 +231,7 @@ var teamMembersCmd = &cobra.Command{
 		// Get team members
 		members, err := client.GetTeamMembers(context.Background(), teamKey)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to get team members: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to get team members: %v", err), plaintext, jsonOut)
 		}
 
 		// Handle output
@@ -286,13 +296,121 @@ var teamMembersCmd = &cobra.Command{
 	},
 }
 
+var teamStatesCmd = &cobra.Command{
+	Use:     "states [TEAM-KEY]",
+	Aliases: []string{"workflow"},
+	Short:   "List a team's workflow states",
+	Long:    `List a team's workflow states in workflow order, with their types and positions.`,
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		teamKey := teamKeyFromArgs(args, plaintext, jsonOut)
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		states, err := client.GetTeamStates(context.Background(), teamKey)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get workflow states: %v", err), plaintext, jsonOut)
+		}
+		renderWorkflowStates(states, teamKey, plaintext, jsonOut)
+	},
+}
+
+var teamLabelsCmd = &cobra.Command{
+	Use:   "labels [TEAM-KEY]",
+	Short: "List a team's labels",
+	Long:  `List the labels scoped to a team (workspace labels are listed with 'linctl label list').`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		teamKey := teamKeyFromArgs(args, plaintext, jsonOut)
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		labels, err := client.GetTeamLabels(context.Background(), teamKey)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to get team labels: %v", err), plaintext, jsonOut)
+		}
+		sort.Slice(labels, func(i, j int) bool {
+			return strings.ToLower(labelPath(labels[i])) < strings.ToLower(labelPath(labels[j]))
+		})
+
+		if jsonOut {
+			output.JSON(labels)
+		} else if plaintext {
+			fmt.Println("Name\tColor\tDescription\tID")
+			for _, label := range labels {
+				fmt.Printf("%s\t%s\t%s\t%s\n", labelPath(label), label.Color, optionalString(label.Description), label.ID)
+			}
+		} else {
+			if len(labels) == 0 {
+				output.Info(fmt.Sprintf("No labels in team %s", teamKey), plaintext, jsonOut)
+				return
+			}
+			rows := make([][]string, len(labels))
+			for i, label := range labels {
+				rows[i] = []string{
+					labelPath(label),
+					label.Color,
+					optionalString(label.Description),
+					label.ID,
+				}
+			}
+			output.Table(output.TableData{
+				Headers: []string{"Name", "Color", "Description", "ID"},
+				Rows:    rows,
+			}, plaintext, jsonOut)
+			fmt.Printf("\n%s %d labels in team %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				len(labels),
+				color.New(color.FgCyan).Sprint(teamKey))
+		}
+	},
+}
+
+// teamKeyFromArgs returns the team key argument, falling back to default-team
+func teamKeyFromArgs(args []string, plaintext, jsonOut bool) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	if teamKey := viper.GetString("default-team"); teamKey != "" {
+		return teamKey
+	}
+	output.Error("A team key is required (or set default-team in config)", plaintext, jsonOut)
+	exit(1)
+	return ""
+}
+
+// labelPath shows grouped labels as "Group/Label"
+func labelPath(label api.Label) string {
+	if label.Parent != nil && label.Parent.Name != "" {
+		return label.Parent.Name + "/" + label.Name
+	}
+	return label.Name
+}
+
 func init() {
 	rootCmd.AddCommand(teamCmd)
 	teamCmd.AddCommand(teamListCmd)
 	teamCmd.AddCommand(teamGetCmd)
 	teamCmd.AddCommand(teamMembersCmd)
+	teamCmd.AddCommand(teamStatesCmd)
+	teamCmd.AddCommand(teamLabelsCmd)
 
 	// List command flags
 	teamListCmd.Flags().IntP("limit", "l", 50, "Maximum number of teams to return")
 	teamListCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
+	addFormatFlags(teamListCmd, columnNames(teamColumns), defaultTeamColumns)
 }
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/telemetry"
+	"github.com/spf13/cobra"
+)
+
+// telemetryFlushTimeout bounds how long exiting waits to send telemetry
+const telemetryFlushTimeout = 5 * time.Second
+
+var (
+	// commandSpan times the running command; see startCommandTelemetry
+	commandSpan    *telemetry.Span
+	commandName    string
+	commandStarted time.Time
+)
+
+// initTelemetry turns on OTLP export when OTEL_EXPORTER_OTLP_ENDPOINT (or a
+// signal-specific endpoint) is set
+func initTelemetry() {
+	cfg, err := telemetry.ConfigFromEnv(version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: telemetry disabled: %v\n", err)
+		return
+	}
+	telemetry.Init(cfg)
+}
+
+// startCommandTelemetry starts the span that every API call, upload, and
+// download of the command is recorded under
+func startCommandTelemetry(cmd *cobra.Command) {
+	commandName = strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	commandStarted = time.Now()
+	commandSpan = telemetry.StartCommand("linctl "+commandName,
+		telemetry.String("linctl.command", commandName),
+		telemetry.String("linctl.profile", auth.Profile()))
+}
+
+// finishTelemetry ends the command's span with its exit code and sends
+// everything recorded
+func finishTelemetry(code int) {
+	if !telemetry.Enabled() {
+		return
+	}
+	if commandName != "" {
+		commandSpan.SetAttributes(telemetry.Int("process.exit.code", int64(code)))
+		if code != 0 {
+			commandSpan.SetError(fmt.Errorf("exit code %d", code))
+		} else {
+			commandSpan.SetError(nil)
+		}
+		commandSpan.End()
+		telemetry.RecordDuration("linctl.command.duration", time.Since(commandStarted),
+			telemetry.String("linctl.command", commandName),
+			telemetry.Int("process.exit.code", int64(code)))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryFlushTimeout)
+	defer cancel()
+	telemetry.Shutdown(ctx)
+}
+
+// exit ends linctl with code. Use it instead of os.Exit so paged output is
+// shown and the command's telemetry is sent first.
+func exit(code int) {
+	stopPager()
+	finishTelemetry(code)
+	os.Exit(code)
+}
@@ -0,0 +1,654 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// titlePlaceholder in a template title is replaced with the --title given at creation
+const titlePlaceholder = "{{title}}"
+
+// datePlaceholder in a template title is replaced with today's date (YYYY-MM-DD)
+const datePlaceholder = "{{date}}"
+
+// Template sources
+const (
+	localTemplateSource  = "local"
+	linearTemplateSource = "linear"
+)
+
+// issueTemplate is an issue template read from a local file or from Linear
+type issueTemplate struct {
+	Name        string   `json:"name"`
+	Source      string   `json:"source"`
+	ID          string   `json:"id,omitempty"`
+	Path        string   `json:"path,omitempty"`
+	About       string   `json:"about,omitempty"`
+	Team        string   `json:"team,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	LabelIDs    []string `json:"labelIds,omitempty"`
+	Estimate    *int     `json:"estimate,omitempty"`
+	Priority    *int     `json:"priority,omitempty"`
+}
+
+// localTemplateFile is the frontmatter of a markdown template, or the whole of
+// a YAML template
+type localTemplateFile struct {
+	About       string         `yaml:"about"`
+	Team        string         `yaml:"team"`
+	Title       string         `yaml:"title"`
+	Description string         `yaml:"description"`
+	Labels      templateLabels `yaml:"labels"`
+	Estimate    *int           `yaml:"estimate"`
+	Priority    *int           `yaml:"priority"`
+}
+
+// templateLabels accepts either a YAML list or a comma-separated string
+type templateLabels []string
+
+func (l *templateLabels) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = nil
+		for _, name := range strings.Split(node.Value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				*l = append(*l, name)
+			}
+		}
+		return nil
+	}
+	var names []string
+	if err := node.Decode(&names); err != nil {
+		return err
+	}
+	*l = names
+	return nil
+}
+
+// linearTemplateData is the part of a Linear issue template's data linctl reads
+type linearTemplateData struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	LabelIDs    []string `json:"labelIds"`
+	Estimate    *float64 `json:"estimate"`
+	Priority    *int     `json:"priority"`
+}
+
+// templateCmd represents the template command
+var templateCmd = &cobra.Command{
+	Use:     "template",
+	Aliases: []string{"templates"},
+	Short:   "List and apply issue templates",
+	Long: `List and apply issue templates, both the workspace's Linear templates and
+local templates, and create issues from them with 'linctl issue create --template NAME'.
+
+Local templates are files in ~/.linctl-templates (or the "template-dir" config
+setting), named after the file: bug-report.md is the template "bug-report".
+A markdown template is the description skeleton, with optional YAML frontmatter;
+a .yaml/.yml template sets the description with a "description" key:
+
+  ---
+  about: Something is broken
+  team: ENG
+  title: "Bug: {{title}}"
+  labels: [Bug, Triage]
+  estimate: 2
+  priority: 2
+  ---
+  ## Steps to reproduce
+
+  ## Expected behavior
+
+In the title, {{title}} is replaced with --title and {{date}} with today's date.
+A title without {{title}} is used as-is unless --title is given. Flags given
+on the command line override the template's values.
+
+Examples:
+  linctl template list
+  linctl template show bug-report
+  linctl issue create --template bug-report --title "Login fails"
+  linctl template apply bug-report ENG-123`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List local and Linear issue templates",
+	Long: `List local templates and the workspace's Linear issue templates. With --team,
+Linear templates of other teams are left out.
+
+Linear templates are skipped when linctl is not authenticated.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		teamKey, _ := cmd.Flags().GetString("team")
+
+		templates, err := localTemplates()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if authHeader, err := auth.GetAuthHeader(); err == nil {
+			linear, err := linearIssueTemplates(context.Background(), api.NewClient(authHeader))
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to fetch templates: %v", err), plaintext, jsonOut)
+			}
+			for _, tmpl := range linear {
+				if teamKey == "" || tmpl.Team == "" || strings.EqualFold(tmpl.Team, teamKey) {
+					templates = append(templates, tmpl)
+				}
+			}
+		} else if !jsonOut && !plaintext {
+			fmt.Fprintln(os.Stderr, "Not authenticated; showing local templates only.")
+		}
+
+		if jsonOut {
+			output.JSON(templates)
+			return
+		}
+		if len(templates) == 0 {
+			dir, _ := templateDir()
+			output.Info(fmt.Sprintf("No templates found. Add markdown or YAML templates to %s", dir), plaintext, jsonOut)
+			return
+		}
+
+		rows := make([][]string, len(templates))
+		for i, tmpl := range templates {
+			team := tmpl.Team
+			if team == "" {
+				team = "-"
+			}
+			rows[i] = []string{tmpl.Name, tmpl.Source, team, tmpl.About}
+		}
+
+		if plaintext {
+			fmt.Println("Name\tSource\tTeam\tAbout")
+			for _, row := range rows {
+				fmt.Println(strings.Join(row, "\t"))
+			}
+			return
+		}
+
+		for _, row := range rows {
+			if row[1] == localTemplateSource {
+				row[1] = color.New(color.FgGreen).Sprint(row[1])
+			} else {
+				row[1] = color.New(color.FgBlue).Sprint(row[1])
+			}
+		}
+		output.Table(output.TableData{
+			Headers: []string{"Name", "Source", "Team", "About"},
+			Rows:    rows,
+		}, plaintext, jsonOut)
+
+		fmt.Printf("\n%s %d templates\n",
+			color.New(color.FgGreen).Sprint("✓"),
+			len(templates))
+	},
+}
+
+var templateShowCmd = &cobra.Command{
+	Use:     "show TEMPLATE",
+	Aliases: []string{"get", "view"},
+	Short:   "Show what a template fills in",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		teamKey, _ := cmd.Flags().GetString("team")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		tmpl, err := findIssueTemplate(context.Background(), client, teamKey, args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(tmpl)
+			return
+		}
+
+		fields := [][2]string{
+			{"Source", tmpl.Source},
+			{"Team", tmpl.Team},
+			{"Title", tmpl.Title},
+			{"Labels", strings.Join(tmpl.Labels, ", ")},
+		}
+		if tmpl.Path != "" {
+			fields = append(fields, [2]string{"Path", tmpl.Path})
+		}
+		if len(tmpl.LabelIDs) > 0 {
+			fields = append(fields, [2]string{"Labels", fmt.Sprintf("%d label(s)", len(tmpl.LabelIDs))})
+		}
+		if tmpl.Estimate != nil {
+			fields = append(fields, [2]string{"Estimate", fmt.Sprintf("%d", *tmpl.Estimate)})
+		}
+		if tmpl.Priority != nil {
+			fields = append(fields, [2]string{"Priority", priorityToString(*tmpl.Priority)})
+		}
+
+		if plaintext {
+			fmt.Printf("# %s\n\n", tmpl.Name)
+			if tmpl.About != "" {
+				fmt.Printf("%s\n\n", tmpl.About)
+			}
+			for _, field := range fields {
+				if field[1] != "" {
+					fmt.Printf("- **%s**: %s\n", field[0], field[1])
+				}
+			}
+			if tmpl.Description != "" {
+				fmt.Printf("\n## Description\n%s\n", tmpl.Description)
+			}
+			return
+		}
+
+		fmt.Printf("%s\n", color.New(color.FgCyan, color.Bold).Sprint(tmpl.Name))
+		if tmpl.About != "" {
+			fmt.Printf("%s\n", tmpl.About)
+		}
+		fmt.Println()
+		for _, field := range fields {
+			if field[1] != "" {
+				fmt.Printf("%s %s\n", color.New(color.Bold).Sprintf("%s:", field[0]), field[1])
+			}
+		}
+		if tmpl.Description != "" {
+			fmt.Printf("\n%s\n%s\n", color.New(color.Bold).Sprint("Description:"), tmpl.Description)
+		}
+	},
+}
+
+var templateApplyCmd = &cobra.Command{
+	Use:   "apply TEMPLATE ISSUE-ID...",
+	Short: "Fill in existing issues from a template",
+	Long: `Apply a template to existing issues. The template's labels are added, and its
+description, estimate, and priority fill in fields the issue does not have yet.
+With --force they replace the issue's values. Titles are left alone.
+
+Examples:
+  linctl template apply bug-report ENG-123
+  linctl template apply bug-report ENG-123 ENG-124 --force`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		force, _ := cmd.Flags().GetBool("force")
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		tmpl, err := findIssueTemplate(ctx, client, teamKeyFromIdentifier(args[1]), args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		applied := []string{}
+		var failures []string
+		for _, ref := range args[1:] {
+			issue, err := client.GetIssue(ctx, ref)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", ref, err))
+				continue
+			}
+
+			input, err := templateUpdateInput(ctx, client, tmpl, issue, force)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", issue.Identifier, err))
+				continue
+			}
+			if len(input) == 0 {
+				applied = append(applied, issue.Identifier)
+				continue
+			}
+			if _, err := client.UpdateIssue(ctx, issue.ID, input); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", issue.Identifier, err))
+				continue
+			}
+			applied = append(applied, issue.Identifier)
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"template": tmpl.Name,
+				"applied":  applied,
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			for _, identifier := range applied {
+				message := fmt.Sprintf("Applied %s to %s", tmpl.Name, identifier)
+				if plaintext {
+					fmt.Println(message)
+				} else {
+					fmt.Printf("%s %s\n", color.New(color.FgGreen).Sprint("✓"), message)
+				}
+			}
+			for _, failure := range failures {
+				output.Error(fmt.Sprintf("Failed to apply template to %s", failure), plaintext, false)
+			}
+		}
+
+		exitOnFailures(len(applied), len(failures))
+	},
+}
+
+// templateUpdateInput builds the update that applies a template to an issue,
+// leaving fields the issue already has unless force is set
+func templateUpdateInput(ctx context.Context, client *api.Client, tmpl *issueTemplate, issue *api.Issue, force bool) (map[string]interface{}, error) {
+	input := map[string]interface{}{}
+
+	if tmpl.Description != "" && (force || strings.TrimSpace(issue.Description) == "") {
+		input["description"] = tmpl.Description
+	}
+	if tmpl.Estimate != nil && (force || issue.Estimate == nil) {
+		input["estimate"] = *tmpl.Estimate
+	}
+	if tmpl.Priority != nil && (force || issue.Priority == 0) {
+		input["priority"] = *tmpl.Priority
+	}
+
+	labelIDs := tmpl.LabelIDs
+	if len(tmpl.Labels) > 0 && issue.Team != nil {
+		ids, err := resolveLabelIDs(ctx, client, issue.Team.Key, strings.Join(tmpl.Labels, ","))
+		if err != nil {
+			return nil, err
+		}
+		labelIDs = ids
+	}
+	if len(labelIDs) > 0 {
+		merged := []string{}
+		seen := map[string]bool{}
+		if issue.Labels != nil {
+			for _, label := range issue.Labels.Nodes {
+				merged = append(merged, label.ID)
+				seen[label.ID] = true
+			}
+		}
+		added := false
+		for _, id := range labelIDs {
+			if !seen[id] {
+				merged = append(merged, id)
+				seen[id] = true
+				added = true
+			}
+		}
+		if added {
+			input["labelIds"] = merged
+		}
+	}
+
+	return input, nil
+}
+
+// expandTitle applies the template's title pattern to the title given on the
+// command line. It returns "" when the pattern needs a title and none was given.
+func (t *issueTemplate) expandTitle(title string) string {
+	if t.Title == "" {
+		return title
+	}
+	pattern := strings.ReplaceAll(t.Title, datePlaceholder, time.Now().Format("2006-01-02"))
+	if !strings.Contains(pattern, titlePlaceholder) {
+		if title != "" {
+			return title
+		}
+		return pattern
+	}
+	if title == "" {
+		return ""
+	}
+	return strings.ReplaceAll(pattern, titlePlaceholder, title)
+}
+
+// templateDir is the "template-dir" setting, or ~/.linctl-templates
+func templateDir() (string, error) {
+	if dir := viper.GetString("template-dir"); dir != "" {
+		if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			return filepath.Join(home, rest), nil
+		}
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl-templates"), nil
+}
+
+// isTemplateFile reports whether a file name has a template extension
+func isTemplateFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md", ".markdown", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// localTemplates reads every template in the template directory, sorted by name
+func localTemplates() ([]issueTemplate, error) {
+	dir, err := templateDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []issueTemplate{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates from %s: %v", dir, err)
+	}
+
+	templates := []issueTemplate{}
+	for _, entry := range entries {
+		if entry.IsDir() || !isTemplateFile(entry.Name()) {
+			continue
+		}
+		tmpl, err := loadLocalTemplate(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *tmpl)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// loadLocalTemplate parses a markdown or YAML template file
+func loadLocalTemplate(path string) (*issueTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %v", path, err)
+	}
+
+	var file localTemplateFile
+	description := ""
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+		description = file.Description
+	default:
+		front, body, ok := splitFrontmatter(string(data))
+		if ok {
+			err = yaml.Unmarshal([]byte(front), &file)
+		}
+		description = strings.TrimSpace(body)
+		if description == "" {
+			description = file.Description
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %s: %v", path, err)
+	}
+	if file.Priority != nil && (*file.Priority < 0 || *file.Priority > 4) {
+		return nil, fmt.Errorf("invalid template %s: priority must be 0-4", path)
+	}
+
+	base := filepath.Base(path)
+	return &issueTemplate{
+		Name:        strings.TrimSuffix(base, filepath.Ext(base)),
+		Source:      localTemplateSource,
+		Path:        path,
+		About:       file.About,
+		Team:        strings.ToUpper(strings.TrimSpace(file.Team)),
+		Title:       file.Title,
+		Description: description,
+		Labels:      file.Labels,
+		Estimate:    file.Estimate,
+		Priority:    file.Priority,
+	}, nil
+}
+
+// splitFrontmatter separates a leading "---" delimited YAML block from the
+// markdown that follows it. ok is false when the content has no frontmatter.
+func splitFrontmatter(content string) (front, body string, ok bool) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	firstLine, rest, found := strings.Cut(content, "\n")
+	if !found || strings.TrimSpace(firstLine) != "---" {
+		return "", content, false
+	}
+
+	lines := strings.SplitAfter(rest, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "---" {
+			return strings.Join(lines[:i], ""), strings.Join(lines[i+1:], ""), true
+		}
+	}
+	return "", content, false
+}
+
+// linearIssueTemplates returns the workspace's Linear issue templates
+func linearIssueTemplates(ctx context.Context, client *api.Client) ([]issueTemplate, error) {
+	all, err := client.GetTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := []issueTemplate{}
+	for _, t := range all {
+		if t.Type != "issue" {
+			continue
+		}
+		templates = append(templates, linearTemplate(t))
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// linearTemplate converts a Linear template. Its data may be a JSON object or
+// a string holding one; fields linctl cannot read are left to Linear, which
+// applies the whole template when an issue is created from it.
+func linearTemplate(t api.Template) issueTemplate {
+	tmpl := issueTemplate{
+		Name:   t.Name,
+		Source: linearTemplateSource,
+		ID:     t.ID,
+		About:  t.Description,
+	}
+	if t.Team != nil {
+		tmpl.Team = t.Team.Key
+	}
+
+	raw := []byte(t.TemplateData)
+	var encoded string
+	if json.Unmarshal(raw, &encoded) == nil {
+		raw = []byte(encoded)
+	}
+	var data linearTemplateData
+	if json.Unmarshal(raw, &data) == nil {
+		tmpl.Title = data.Title
+		tmpl.Description = data.Description
+		tmpl.LabelIDs = data.LabelIDs
+		tmpl.Priority = data.Priority
+		if data.Estimate != nil {
+			estimate := int(*data.Estimate)
+			tmpl.Estimate = &estimate
+		}
+	}
+	return tmpl
+}
+
+// findIssueTemplate resolves a template by file path, local template name, or
+// Linear template name or ID. Local templates win over Linear ones of the same
+// name, and a Linear template of teamKey wins over other teams' templates.
+func findIssueTemplate(ctx context.Context, client *api.Client, teamKey, ref string) (*issueTemplate, error) {
+	if isTemplateFile(ref) {
+		if _, err := os.Stat(ref); err == nil {
+			return loadLocalTemplate(ref)
+		}
+	}
+
+	local, err := localTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for i := range local {
+		if strings.EqualFold(local[i].Name, ref) {
+			return &local[i], nil
+		}
+	}
+
+	if uuidPattern.MatchString(ref) {
+		t, err := client.GetTemplate(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("template not found: %s", ref)
+		}
+		tmpl := linearTemplate(*t)
+		return &tmpl, nil
+	}
+
+	linear, err := linearIssueTemplates(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch templates: %v", err)
+	}
+	var matches []issueTemplate
+	for _, tmpl := range linear {
+		if strings.EqualFold(tmpl.Name, ref) {
+			matches = append(matches, tmpl)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("template not found: %s (see 'linctl template list')", ref)
+	case 1:
+		return &matches[0], nil
+	}
+	for i := range matches {
+		if teamKey != "" && strings.EqualFold(matches[i].Team, teamKey) {
+			return &matches[i], nil
+		}
+	}
+	return nil, fmt.Errorf("multiple templates named '%s'; use --team or the template ID", ref)
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateShowCmd)
+	templateCmd.AddCommand(templateApplyCmd)
+
+	templateListCmd.Flags().StringP("team", "t", "", "Only show Linear templates of this team (and workspace templates)")
+	templateShowCmd.Flags().StringP("team", "t", "", "Prefer this team's template when several share a name")
+	templateApplyCmd.Flags().Bool("force", false, "Replace the issue's description, estimate, and priority")
+}
@@ -0,0 +1,522 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// undoJournalLimit is how many operations the undo journal keeps
+const undoJournalLimit = 50
+
+// Kinds of change 'linctl undo' knows how to revert
+const (
+	undoIssueUpdate         = "issue-update"
+	undoProjectArchive      = "project-archive"
+	undoNotificationArchive = "notification-archive"
+	undoRelationDelete      = "relation-delete"
+	undoIssueArchive        = "issue-archive"
+	undoIssueUnarchive      = "issue-unarchive"
+	undoIssueDelete         = "issue-delete"
+	undoIssueRestore        = "issue-restore"
+	undoIssueSubscribe      = "issue-subscribe"
+	undoIssueUnsubscribe    = "issue-unsubscribe"
+)
+
+// undoOp is one command recorded in the undo journal; 'linctl undo' reverts
+// all of its changes together
+type undoOp struct {
+	ID      int          `json:"id"`
+	Command string       `json:"command"`
+	At      time.Time    `json:"at"`
+	Changes []undoChange `json:"changes"`
+}
+
+// undoChange records how to revert one change made by an operation
+type undoChange struct {
+	Kind string `json:"kind"`
+	// Target is the ID of the changed entity, Label how it is shown
+	Target  string `json:"target"`
+	Label   string `json:"label"`
+	Summary string `json:"summary"`
+	// Restore is what the revert sends: an issue's previous field values,
+	// the issues and type of a deleted relation, or the user of a subscription
+	Restore map[string]interface{} `json:"restore,omitempty"`
+	// UpdatedAt is the issue's updatedAt right after the change; a newer one
+	// at undo time means someone changed the issue since
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// issueUndoFields read an issue's value for each IssueUpdateInput field that
+// can be reverted, as the input expects it
+var issueUndoFields = map[string]func(*api.Issue) interface{}{
+	"title":       func(i *api.Issue) interface{} { return i.Title },
+	"description": func(i *api.Issue) interface{} { return i.Description },
+	"priority":    func(i *api.Issue) interface{} { return i.Priority },
+	"stateId": func(i *api.Issue) interface{} {
+		if i.State == nil {
+			return nil
+		}
+		return i.State.ID
+	},
+	"assigneeId": func(i *api.Issue) interface{} {
+		if i.Assignee == nil {
+			return nil
+		}
+		return i.Assignee.ID
+	},
+	"dueDate": func(i *api.Issue) interface{} {
+		if i.DueDate == nil {
+			return nil
+		}
+		return *i.DueDate
+	},
+	"estimate": func(i *api.Issue) interface{} {
+		if i.Estimate == nil {
+			return nil
+		}
+		return *i.Estimate
+	},
+	"parentId": func(i *api.Issue) interface{} {
+		if i.Parent == nil {
+			return nil
+		}
+		return i.Parent.ID
+	},
+	"cycleId": func(i *api.Issue) interface{} {
+		if i.Cycle == nil {
+			return nil
+		}
+		return i.Cycle.ID
+	},
+	"projectId": func(i *api.Issue) interface{} {
+		if i.Project == nil {
+			return nil
+		}
+		return i.Project.ID
+	},
+	"projectMilestoneId": func(i *api.Issue) interface{} {
+		if i.ProjectMilestone == nil {
+			return nil
+		}
+		return i.ProjectMilestone.ID
+	},
+	"labelIds": func(i *api.Issue) interface{} {
+		ids := []string{}
+		if i.Labels != nil {
+			for _, label := range i.Labels.Nodes {
+				ids = append(ids, label.ID)
+			}
+		}
+		return ids
+	},
+}
+
+// issueUndoFieldNames name the input fields in summaries, in a stable order
+var issueUndoFieldNames = []struct{ field, name string }{
+	{"stateId", "state"}, {"assigneeId", "assignee"}, {"priority", "priority"},
+	{"title", "title"}, {"description", "description"}, {"labelIds", "labels"},
+	{"dueDate", "due date"}, {"estimate", "estimate"}, {"parentId", "parent"},
+	{"cycleId", "cycle"}, {"projectId", "project"}, {"projectMilestoneId", "milestone"},
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [ID]",
+	Short: "Revert the last change made with linctl",
+	Long: `Revert the most recent reversible operation, or the one with the given ID
+from 'linctl history'.
+
+linctl records the previous values of what it changes in a local journal,
+~/.linctl/undo/<profile>.json, which keeps the last 50 operations:
+
+  - issue update, assign, move, and bulk-update: the changed fields (state,
+    assignee, priority, labels, cycle, ...) are set back
+  - issue archive, unarchive, delete, and restore: the issues are put back
+  - project archive and inbox archive: the archived items are restored
+  - issue unrelate: the removed relations are created again
+  - issue subscribe and unsubscribe: the subscriptions are reversed
+
+An issue that changed again after the operation is left alone unless --force
+is given, so undo never overwrites someone else's edit.
+
+Examples:
+  linctl issue update ENG-123 --state Done
+  linctl undo            # ENG-123 is back in its previous state
+  linctl history
+  linctl undo 4`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		force, _ := cmd.Flags().GetBool("force")
+
+		ops, err := loadUndoJournal()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if len(ops) == 0 {
+			output.Error("Nothing to undo", plaintext, jsonOut)
+			exit(1)
+		}
+
+		index := len(ops) - 1
+		if len(args) == 1 {
+			index = -1
+			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			for i, op := range ops {
+				if err == nil && op.ID == id {
+					index = i
+				}
+			}
+			if index < 0 {
+				output.Error(fmt.Sprintf("No operation with ID %s (see 'linctl history')", args[0]), plaintext, jsonOut)
+				exit(1)
+			}
+		}
+		op := ops[index]
+
+		client := authenticatedClient(plaintext, jsonOut)
+		ctx := context.Background()
+
+		reverted := []undoChange{}
+		remaining := []undoChange{}
+		failures := []string{}
+		for _, change := range op.Changes {
+			if err := revertChange(ctx, client, change, force); err != nil {
+				remaining = append(remaining, change)
+				failures = append(failures, fmt.Sprintf("%s: %v", change.Label, err))
+				continue
+			}
+			reverted = append(reverted, change)
+		}
+
+		// Changes that could not be reverted stay in the journal to retry
+		if len(remaining) == 0 {
+			ops = append(ops[:index], ops[index+1:]...)
+		} else {
+			ops[index].Changes = remaining
+		}
+		if err := saveUndoJournal(ops); err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to save undo journal: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"id":       op.ID,
+				"command":  op.Command,
+				"reverted": reverted,
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			for _, change := range reverted {
+				if plaintext {
+					fmt.Printf("Reverted %s: %s\n", change.Label, change.Summary)
+				} else {
+					fmt.Printf("%s Reverted %s %s\n",
+						color.New(color.FgGreen).Sprint("✓"),
+						color.New(color.FgCyan, color.Bold).Sprint(change.Label),
+						color.New(color.FgWhite, color.Faint).Sprint(change.Summary))
+				}
+			}
+			for _, failure := range failures {
+				output.Error(fmt.Sprintf("Failed to revert %s", failure), plaintext, false)
+			}
+			if len(failures) == 0 {
+				output.Success(fmt.Sprintf("Undid #%d %s", op.ID, op.Command), plaintext, jsonOut)
+			}
+		}
+
+		exitOnFailures(len(reverted), len(failures))
+	},
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List recent operations that 'linctl undo' can revert",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		ops, err := loadUndoJournal()
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		// Newest first, as undo takes them
+		for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+			ops[i], ops[j] = ops[j], ops[i]
+		}
+		if limit, _ := cmd.Flags().GetInt("limit"); limit > 0 && len(ops) > limit {
+			ops = ops[:limit]
+		}
+
+		if jsonOut {
+			output.JSON(ops)
+			return
+		}
+		if len(ops) == 0 {
+			output.Info("No operations to undo", plaintext, jsonOut)
+			return
+		}
+
+		if plaintext {
+			for _, op := range ops {
+				fmt.Printf("%d\t%s\t%s\t%s\n", op.ID, op.At.Format(time.RFC3339), op.Command, describeUndoChanges(op.Changes))
+			}
+			return
+		}
+
+		rows := make([][]string, len(ops))
+		for i, op := range ops {
+			rows[i] = []string{
+				strconv.Itoa(op.ID),
+				formatTimeAgo(op.At),
+				op.Command,
+				describeUndoChanges(op.Changes),
+			}
+		}
+		output.Table(output.TableData{
+			Headers: []string{"ID", "When", "Command", "Changes"},
+			Rows:    rows,
+		}, false, false)
+		fmt.Printf("\n%s %d operations can be undone (newest first)\n", color.New(color.FgGreen).Sprint("✓"), len(ops))
+	},
+}
+
+// revertChange applies the reverse of one recorded change
+func revertChange(ctx context.Context, client *api.Client, change undoChange, force bool) error {
+	switch change.Kind {
+	case undoIssueUpdate:
+		if change.UpdatedAt != nil && !force {
+			issue, err := client.GetIssue(ctx, change.Target)
+			if err != nil {
+				return fmt.Errorf("failed to get issue: %v", err)
+			}
+			if issue.UpdatedAt.After(*change.UpdatedAt) {
+				return fmt.Errorf("changed %s, after this operation (use --force to undo anyway)", formatTimeAgo(issue.UpdatedAt))
+			}
+		}
+		_, err := client.UpdateIssue(ctx, change.Target, change.Restore)
+		return err
+	case undoIssueArchive, undoIssueDelete:
+		return client.UnarchiveIssue(ctx, change.Target)
+	case undoIssueUnarchive:
+		return client.ArchiveIssue(ctx, change.Target)
+	case undoIssueRestore:
+		return client.DeleteIssue(ctx, change.Target)
+	case undoProjectArchive:
+		return client.UnarchiveProject(ctx, change.Target)
+	case undoNotificationArchive:
+		return client.UnarchiveNotification(ctx, change.Target)
+	case undoIssueSubscribe:
+		userID, _ := change.Restore["userId"].(string)
+		return client.UnsubscribeFromIssue(ctx, change.Target, userID)
+	case undoIssueUnsubscribe:
+		userID, _ := change.Restore["userId"].(string)
+		return client.SubscribeToIssue(ctx, change.Target, userID)
+	case undoRelationDelete:
+		issueID, _ := change.Restore["issueId"].(string)
+		relatedID, _ := change.Restore["relatedIssueId"].(string)
+		relationType, _ := change.Restore["type"].(string)
+		_, err := client.CreateIssueRelation(ctx, issueID, relatedID, relationType)
+		return err
+	}
+	return fmt.Errorf("unknown change kind %q", change.Kind)
+}
+
+// issueUndoChange records how to revert an update of before with input;
+// after is the issue the update returned. It reports false when none of the
+// changed fields can be reverted.
+func issueUndoChange(before, after *api.Issue, input map[string]interface{}) (undoChange, bool) {
+	restore := make(map[string]interface{})
+	var changed []string
+	for _, field := range issueUndoFieldNames {
+		if _, ok := input[field.field]; !ok {
+			continue
+		}
+		restore[field.field] = issueUndoFields[field.field](before)
+
+		switch field.field {
+		case "stateId":
+			changed = append(changed, fmt.Sprintf("state %s → %s", stateName(before.State), stateName(after.State)))
+		case "assigneeId":
+			changed = append(changed, fmt.Sprintf("assignee %s → %s", assigneeName(before.Assignee), assigneeName(after.Assignee)))
+		case "priority":
+			changed = append(changed, fmt.Sprintf("priority %s → %s", priorityToString(before.Priority), priorityToString(after.Priority)))
+		default:
+			changed = append(changed, field.name)
+		}
+	}
+	if len(restore) == 0 {
+		return undoChange{}, false
+	}
+
+	change := undoChange{
+		Kind:    undoIssueUpdate,
+		Target:  before.ID,
+		Label:   before.Identifier,
+		Summary: strings.Join(changed, ", "),
+		Restore: restore,
+	}
+	if after != nil && !after.UpdatedAt.IsZero() {
+		updatedAt := after.UpdatedAt
+		change.UpdatedAt = &updatedAt
+	}
+	return change, true
+}
+
+// stateName is a workflow state's name for undo summaries
+func stateName(state *api.State) string {
+	if state == nil {
+		return "none"
+	}
+	return state.Name
+}
+
+// assigneeName is an assignee's name for undo summaries
+func assigneeName(user *api.User) string {
+	if user == nil {
+		return "unassigned"
+	}
+	return user.Name
+}
+
+// recordUndo adds an operation made by cmd to the undo journal. Failing to
+// record is reported but never fails the command, whose change already happened.
+func recordUndo(cmd *cobra.Command, args []string, changes []undoChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	err := func() error {
+		ops, err := loadUndoJournal()
+		if err != nil {
+			return err
+		}
+		op := undoOp{
+			ID:      1,
+			Command: undoCommandLine(cmd, args),
+			At:      time.Now(),
+			Changes: changes,
+		}
+		for _, existing := range ops {
+			if existing.ID >= op.ID {
+				op.ID = existing.ID + 1
+			}
+		}
+		ops = append(ops, op)
+		if len(ops) > undoJournalLimit {
+			ops = ops[len(ops)-undoJournalLimit:]
+		}
+		return saveUndoJournal(ops)
+	}()
+	if err != nil && !viper.GetBool("json") {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record the change for 'linctl undo': %v\n", err)
+	}
+}
+
+// undoCommandLine formats the running command as it was typed, without
+// output flags
+func undoCommandLine(cmd *cobra.Command, args []string) string {
+	parts := append(strings.Fields(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")), args...)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if !queueSkippedFlags[f.Name] {
+			parts = append(parts, "--"+f.Name+"="+f.Value.String())
+		}
+	})
+	return describeQueuedOp(queuedOp{Args: parts})
+}
+
+// describeUndoChanges summarizes an operation's changes for 'linctl history'
+func describeUndoChanges(changes []undoChange) string {
+	if len(changes) == 1 {
+		return changes[0].Label + ": " + changes[0].Summary
+	}
+	labels := make([]string, len(changes))
+	for i, change := range changes {
+		labels[i] = change.Label
+	}
+	return fmt.Sprintf("%d changes: %s", len(changes), strings.Join(labels, ", "))
+}
+
+// undoJournalPath is ~/.linctl/undo/PROFILE.json
+func undoJournalPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl", "undo", auth.Profile()+".json"), nil
+}
+
+// loadUndoJournal reads the active profile's undo journal, oldest first
+func loadUndoJournal() ([]undoOp, error) {
+	path, err := undoJournalPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []undoOp{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read undo journal: %w", err)
+	}
+	ops := []undoOp{}
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("failed to parse undo journal %s: %w", path, err)
+	}
+	return ops, nil
+}
+
+// saveUndoJournal replaces the active profile's undo journal, removing the
+// file when empty
+func saveUndoJournal(ops []undoOp) error {
+	path, err := undoJournalPath()
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(ops, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(historyCmd)
+
+	undoCmd.Flags().Bool("force", false, "Revert issues even if they changed after the operation")
+	historyCmd.Flags().IntP("limit", "l", 0, "Maximum number of operations to show (0 for all)")
+}
@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/dorkitude/linctl/pkg/api"
@@ -35,11 +34,15 @@ var userListCmd = &cobra.Command{
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 
+		format, err := parseDelimitedFormat(cmd, userColumns)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
 		// Get auth header
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
 		}
 
 		// Create API client
@@ -63,15 +66,14 @@ var userListCmd = &cobra.Command{
 				orderBy = ""
 			default:
 				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 
 		// Get users
 		users, err := client.GetUsers(context.Background(), limit, "", orderBy)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to list users: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to list users: %v", err), plaintext, jsonOut)
 		}
 
 		// Filter active users if requested
@@ -86,6 +88,13 @@ var userListCmd = &cobra.Command{
 			filteredUsers = activeUsers
 		}
 
+		if format != nil {
+			if err := writeDelimited(format, filteredUsers, userColumns); err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to write output: %v", err), plaintext, jsonOut)
+			}
+			return
+		}
+
 		// Handle output
 		if jsonOut {
 			output.JSON(filteredUsers)
@@ -148,31 +157,38 @@ var userListCmd = &cobra.Command{
 }
 
 var userGetCmd = &cobra.Command{
-	Use:     "get EMAIL",
-	Aliases: []string{"show"},
+	Use:     "get [USER]",
+	Aliases: []string{"show", "view"},
 	Short:   "Get user details",
-	Long:    `Get detailed information about a specific user by email.`,
-	Args:    cobra.ExactArgs(1),
+	Long: `Get detailed information about a user by email, name, display name, or ID.
+With no user given, pick one from a list.
+
+Examples:
+  linctl user view jane@example.com
+  linctl user view "Jane Doe"
+  linctl user view jane --json | jq -r .id`,
+	Args: argOrPick,
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
-		email := args[0]
 
 		// Get auth header
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
 		}
 
 		// Create API client
 		client := api.NewClient(authHeader)
 
 		// Get user details
-		user, err := client.GetUser(context.Background(), email)
+		ref, err := argFromPick(cmd, args)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		user, err := resolveUser(context.Background(), client, ref)
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to get user: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to get user: %v", err), plaintext, jsonOut)
 		}
 
 		// Handle output
@@ -181,6 +197,9 @@ var userGetCmd = &cobra.Command{
 		} else if plaintext {
 			fmt.Printf("ID: %s\n", user.ID)
 			fmt.Printf("Name: %s\n", user.Name)
+			if user.DisplayName != "" {
+				fmt.Printf("Display Name: %s\n", user.DisplayName)
+			}
 			fmt.Printf("Email: %s\n", user.Email)
 			fmt.Printf("Admin: %v\n", user.Admin)
 			fmt.Printf("Active: %v\n", user.Active)
@@ -195,6 +214,9 @@ var userGetCmd = &cobra.Command{
 				user.Name)
 			fmt.Println(strings.Repeat("─", 50))
 
+			if user.DisplayName != "" {
+				fmt.Printf("\n%s %s", color.New(color.Bold).Sprint("Display Name:"), user.DisplayName)
+			}
 			fmt.Printf("\n%s %s\n", color.New(color.Bold).Sprint("Email:"),
 				color.New(color.FgCyan).Sprint(user.Email))
 			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("ID:"), user.ID)
@@ -237,8 +259,7 @@ var userMeCmd = &cobra.Command{
 		// Get auth header
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
-			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
 		}
 
 		// Create API client
@@ -247,8 +268,7 @@ var userMeCmd = &cobra.Command{
 		// Get current user
 		user, err := client.GetViewer(context.Background())
 		if err != nil {
-			output.Error(fmt.Sprintf("Failed to get current user: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			exitWithError(err, fmt.Sprintf("Failed to get current user: %v", err), plaintext, jsonOut)
 		}
 
 		// Handle output
@@ -298,14 +318,68 @@ var userMeCmd = &cobra.Command{
 	},
 }
 
+// resolveUser finds a user by "me", ID, email, name, or display name (all
+// case-insensitive). Active users win over deactivated ones with the same name.
+func resolveUser(ctx context.Context, client *api.Client, ref string) (*api.User, error) {
+	ref = strings.TrimSpace(ref)
+	switch strings.ToLower(ref) {
+	case "":
+		return nil, fmt.Errorf("user is empty")
+	case "me":
+		return client.GetViewer(ctx)
+	}
+
+	var filter map[string]interface{}
+	if uuidPattern.MatchString(ref) {
+		filter = map[string]interface{}{"id": map[string]interface{}{"eq": ref}}
+	} else {
+		match := map[string]interface{}{"eqIgnoreCase": ref}
+		filter = map[string]interface{}{
+			"or": []interface{}{
+				map[string]interface{}{"email": match},
+				map[string]interface{}{"name": match},
+				map[string]interface{}{"displayName": match},
+			},
+		}
+	}
+
+	users, err := client.FindUsers(ctx, filter, 10)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user '%s': %w", ref, err)
+	}
+
+	var active []api.User
+	for _, user := range users {
+		if user.Active {
+			active = append(active, user)
+		}
+	}
+	switch {
+	case len(users) == 0:
+		return nil, fmt.Errorf("user not found: %s", ref)
+	case len(users) == 1:
+		return &users[0], nil
+	case len(active) == 1:
+		return &active[0], nil
+	}
+
+	matches := make([]string, len(users))
+	for i, user := range users {
+		matches[i] = fmt.Sprintf("%s <%s>", user.Name, user.Email)
+	}
+	return nil, fmt.Errorf("user '%s' is ambiguous: %s; use an email or ID", ref, strings.Join(matches, ", "))
+}
+
 func init() {
 	rootCmd.AddCommand(userCmd)
 	userCmd.AddCommand(userListCmd)
 	userCmd.AddCommand(userGetCmd)
 	userCmd.AddCommand(userMeCmd)
+	pickable("user", userGetCmd)
 
 	// List command flags
 	userListCmd.Flags().IntP("limit", "l", 50, "Maximum number of users to return")
 	userListCmd.Flags().BoolP("active", "a", false, "Show only active users")
 	userListCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
+	addFormatFlags(userListCmd, columnNames(userColumns), defaultUserColumns)
 }
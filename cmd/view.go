@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// viewKeys are the 'issue list' flags a saved view may set
+var viewKeys = []string{
+	"assignee", "creator", "state", "team", "priority", "label", "created-after", "updated-after",
+	"newer-than", "include-completed", "has-parent", "no-parent", "parent-issue", "sort", "group-by", "limit",
+	"overdue", "due-within", "filter",
+}
+
+// viewSetting is one key=value pair of a saved view
+type viewSetting struct {
+	Key   string
+	Value string
+}
+
+// savedView is a named filter stored under "views" in the config file
+type savedView struct {
+	Name   string `json:"name"`
+	Filter string `json:"filter"`
+}
+
+// viewCmd represents the view command
+var viewCmd = &cobra.Command{
+	Use:     "view",
+	Aliases: []string{"views"},
+	Short:   "Manage saved issue filters",
+	Long: `Manage named issue filters ("views") stored in the config file, and run them
+with 'linctl issue list --view NAME'.
+
+A view is a list of key=value pairs using the names of the 'issue list' flags:
+` + strings.Join(viewKeys, ", ") + `.
+Use @me for yourself, @none or @any for users, and @team for default-team. Values with spaces must be quoted; boolean keys may be
+given without a value. A state that is a workflow state type (triage, backlog,
+unstarted, started, completed, canceled) matches every state of that type.
+
+Flags given on the command line override the view's values.
+
+Examples:
+  linctl view add my-bugs assignee=@me label=bug state=started
+  linctl view add eng-review 'team=ENG state="In Review"' sort=updated
+  linctl issue list --view my-bugs
+  linctl view list
+  linctl view delete my-bugs`,
+}
+
+var viewAddCmd = &cobra.Command{
+	Use:     "add NAME FILTER...",
+	Aliases: []string{"set", "save"},
+	Short:   "Save a named filter (replacing any view with the same name)",
+	Args:    cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		name, err := normalizeViewName(args[0])
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		filter := strings.Join(args[1:], " ")
+		if _, err := parseViewFilter(filter); err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		path, err := configFilePath()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to locate config file: %v", err), plaintext, jsonOut)
+		}
+		doc, err := loadConfigDocument(path)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		setConfigValue(doc, []string{"views", name}, filter)
+		if err := saveConfigDocument(path, doc); err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(savedView{Name: name, Filter: filter})
+		} else if plaintext {
+			fmt.Printf("Saved view %s: %s\n", name, filter)
+		} else {
+			fmt.Printf("%s Saved view %s: %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(name),
+				filter)
+			fmt.Printf("  Run it with: linctl issue list --view %s\n", name)
+		}
+	},
+}
+
+var viewListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List saved views",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		views := savedViews()
+
+		if jsonOut {
+			output.JSON(views)
+			return
+		}
+		if len(views) == 0 {
+			output.Info("No saved views. Create one with 'linctl view add NAME FILTER...'", plaintext, jsonOut)
+			return
+		}
+
+		rows := make([][]string, len(views))
+		for i, view := range views {
+			rows[i] = []string{view.Name, view.Filter}
+		}
+
+		if plaintext {
+			fmt.Println("Name\tFilter")
+			for _, row := range rows {
+				fmt.Printf("%s\t%s\n", row[0], row[1])
+			}
+			return
+		}
+
+		output.Table(output.TableData{
+			Headers: []string{"Name", "Filter"},
+			Rows:    rows,
+		}, plaintext, jsonOut)
+	},
+}
+
+var viewDeleteCmd = &cobra.Command{
+	Use:     "delete NAME",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Delete a saved view",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		name := strings.ToLower(args[0])
+
+		path, err := configFilePath()
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to locate config file: %v", err), plaintext, jsonOut)
+		}
+		doc, err := loadConfigDocument(path)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+		if !deleteConfigValue(doc, []string{"views", name}) {
+			output.Error(fmt.Sprintf("View '%s' not found in %s", name, path), plaintext, jsonOut)
+			exit(1)
+		}
+		if err := saveConfigDocument(path, doc); err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{"deleted": name})
+		} else {
+			output.Success(fmt.Sprintf("Deleted view %s", name), plaintext, jsonOut)
+		}
+	},
+}
+
+// savedViews returns the views in the config file, sorted by name
+func savedViews() []savedView {
+	views := []savedView{}
+	for name, filter := range viper.GetStringMapString("views") {
+		views = append(views, savedView{Name: name, Filter: filter})
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].Name < views[j].Name })
+	return views
+}
+
+// normalizeViewName lowercases a view name (config keys are case-insensitive)
+// and checks that it is a valid config key
+func normalizeViewName(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("view name cannot be empty")
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return "", fmt.Errorf("invalid view name %q (use letters, digits, '-' and '_')", name)
+		}
+	}
+	return strings.ToLower(name), nil
+}
+
+// parseViewFilter splits a view into key=value settings. Values may be quoted
+// with single or double quotes; a key without a value means "true".
+func parseViewFilter(filter string) ([]viewSetting, error) {
+	tokens, err := splitQuoted(filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid view filter: %v", err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("view filter is empty")
+	}
+
+	settings := make([]viewSetting, 0, len(tokens))
+	for _, token := range tokens {
+		key, value, hasValue := strings.Cut(token, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "labels" {
+			key = "label"
+		}
+		if !hasValue {
+			value = "true"
+		}
+
+		valid := false
+		for _, known := range viewKeys {
+			if key == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown view key %q (valid: %s)", key, strings.Join(viewKeys, ", "))
+		}
+		settings = append(settings, viewSetting{Key: key, Value: value})
+	}
+	return settings, nil
+}
+
+// splitQuoted splits on whitespace outside of quotes and removes the quotes
+func splitQuoted(text string) ([]string, error) {
+	var (
+		tokens  []string
+		current strings.Builder
+		quote   rune
+		inToken bool
+	)
+	for _, r := range text {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inToken = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// applyView sets the flags of the view named by --view. Flags given on the
+// command line keep their values.
+func applyView(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("view")
+	if flag == nil || flag.Value.String() == "" {
+		return nil
+	}
+
+	name := strings.ToLower(flag.Value.String())
+	filter, ok := viper.GetStringMapString("views")[name]
+	if !ok {
+		return fmt.Errorf("view '%s' not found (see 'linctl view list')", name)
+	}
+	settings, err := parseViewFilter(filter)
+	if err != nil {
+		return fmt.Errorf("invalid view '%s': %v", name, err)
+	}
+
+	// Decide up front so repeated keys (e.g. two labels) all apply
+	explicit := make(map[string]bool)
+	for _, setting := range settings {
+		if f := cmd.Flags().Lookup(setting.Key); f != nil {
+			explicit[setting.Key] = f.Changed
+		}
+	}
+
+	for _, setting := range settings {
+		isSet, ok := explicit[setting.Key]
+		if !ok || isSet {
+			continue
+		}
+		if err := cmd.Flags().Set(setting.Key, setting.Value); err != nil {
+			return fmt.Errorf("invalid value for %s in view '%s': %v", setting.Key, name, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(viewCmd)
+	viewCmd.AddCommand(viewAddCmd)
+	viewCmd.AddCommand(viewListCmd)
+	viewCmd.AddCommand(viewDeleteCmd)
+}
@@ -0,0 +1,777 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/auth"
+	"github.com/dorkitude/linctl/pkg/httpclient"
+	"github.com/dorkitude/linctl/pkg/output"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	// webhookSignatureHeader carries the hex HMAC-SHA256 of the request body
+	webhookSignatureHeader = "Linear-Signature"
+	// webhookSecretEnv supplies the signing secret for 'webhook listen'
+	webhookSecretEnv = "LINCTL_WEBHOOK_SECRET"
+	// webhookMaxAge rejects deliveries whose timestamp is too old to be fresh
+	webhookMaxAge = time.Minute
+	// webhookMaxBody bounds the size of a delivery
+	webhookMaxBody = 5 << 20
+)
+
+// webhookResourceTypes maps the names accepted on the command line to Linear's resource types
+var webhookResourceTypes = map[string]string{
+	"issue":          "Issue",
+	"comment":        "Comment",
+	"label":          "IssueLabel",
+	"issuelabel":     "IssueLabel",
+	"reaction":       "Reaction",
+	"project":        "Project",
+	"projectupdate":  "ProjectUpdate",
+	"project-update": "ProjectUpdate",
+	"cycle":          "Cycle",
+	"attachment":     "Attachment",
+	"document":       "Document",
+	"initiative":     "Initiative",
+	"user":           "User",
+	"sla":            "IssueSLA",
+	"issuesla":       "IssueSLA",
+	"customer":       "Customer",
+	"customerneed":   "CustomerNeed",
+}
+
+// defaultWebhookResourceTypes are subscribed to when no filter is given
+var defaultWebhookResourceTypes = []string{"Issue", "Comment", "IssueLabel", "Reaction", "Project", "Cycle"}
+
+// webhookCmd represents the webhook command
+var webhookCmd = &cobra.Command{
+	Use:     "webhook",
+	Aliases: []string{"webhooks"},
+	Short:   "Work with Linear webhooks",
+	Long: `Manage Linear webhooks and listen for their deliveries.
+
+Examples:
+  linctl webhook list
+  linctl webhook create --url https://example.com/linear --resource-types issue,comment --team ENG
+  linctl webhook test <webhook-id>
+  linctl webhook delete <webhook-id>
+  linctl webhook listen --port 8080 --url https://my-tunnel.example.com --filter issue,comment`,
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List webhooks",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		webhooks, err := client.GetWebhooks(context.Background())
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to list webhooks: %v", err), plaintext, jsonOut)
+		}
+		if !showSecrets {
+			for i := range webhooks {
+				webhooks[i].Secret = nil
+			}
+		}
+
+		if jsonOut {
+			output.JSON(webhooks)
+			return
+		}
+		if len(webhooks) == 0 {
+			output.Info("No webhooks found", plaintext, jsonOut)
+			return
+		}
+
+		headers := []string{"ID", "Label", "URL", "Resources", "Team", "Enabled"}
+		if showSecrets {
+			headers = append(headers, "Secret")
+		}
+		rows := make([][]string, len(webhooks))
+		for i, webhook := range webhooks {
+			enabled := "yes"
+			if !webhook.Enabled {
+				enabled = "no"
+			}
+			rows[i] = []string{
+				webhook.ID,
+				optionalString(webhook.Label),
+				webhook.URL,
+				strings.Join(webhook.ResourceTypes, ","),
+				webhookScope(webhook),
+				enabled,
+			}
+			if showSecrets {
+				rows[i] = append(rows[i], optionalString(webhook.Secret))
+			}
+		}
+
+		if plaintext {
+			fmt.Println(strings.Join(headers, "\t"))
+			for _, row := range rows {
+				fmt.Println(strings.Join(row, "\t"))
+			}
+			return
+		}
+
+		for _, row := range rows {
+			if row[5] == "no" {
+				row[5] = color.New(color.FgYellow).Sprint(row[5])
+			} else {
+				row[5] = color.New(color.FgGreen).Sprint(row[5])
+			}
+		}
+		output.Table(output.TableData{Headers: headers, Rows: rows}, plaintext, jsonOut)
+		fmt.Printf("\n%s %d webhooks\n", color.New(color.FgGreen).Sprint("✓"), len(webhooks))
+	},
+}
+
+var webhookCreateCmd = &cobra.Command{
+	Use:     "create",
+	Aliases: []string{"add", "new"},
+	Short:   "Create a webhook",
+	Long: `Create a webhook that delivers events for the given resource types to a URL.
+
+A signing secret is generated when --secret is not given; it is printed once,
+so store it where your receiver can read it.
+
+Examples:
+  linctl webhook create --url https://example.com/linear
+  linctl webhook create --url https://example.com/linear --resource-types issue,comment --team ENG --label "CI bot"`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		url, _ := cmd.Flags().GetString("url")
+		label, _ := cmd.Flags().GetString("label")
+		secret, _ := cmd.Flags().GetString("secret")
+		teamKey, _ := cmd.Flags().GetString("team")
+		typeNames, _ := cmd.Flags().GetStringSlice("resource-types")
+		disabled, _ := cmd.Flags().GetBool("disabled")
+
+		if url == "" {
+			output.Error("--url is required", plaintext, jsonOut)
+			exit(1)
+		}
+		resourceTypes, err := parseWebhookResourceTypes(typeNames)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		webhook, err := createWebhook(context.Background(), client, url, label, secret, teamKey, resourceTypes, !disabled)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to create webhook: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(webhook)
+		} else if plaintext {
+			fmt.Printf("Created webhook %s\n", webhook.ID)
+			fmt.Printf("URL: %s\n", webhook.URL)
+			fmt.Printf("Resources: %s\n", strings.Join(webhook.ResourceTypes, ","))
+			fmt.Printf("Scope: %s\n", webhookScope(*webhook))
+			fmt.Printf("Secret: %s\n", optionalString(webhook.Secret))
+		} else {
+			fmt.Printf("%s Created webhook %s\n",
+				color.New(color.FgGreen).Sprint("✓"),
+				color.New(color.FgCyan, color.Bold).Sprint(webhook.ID))
+			fmt.Printf("  URL:       %s\n", webhook.URL)
+			fmt.Printf("  Resources: %s\n", strings.Join(webhook.ResourceTypes, ", "))
+			fmt.Printf("  Scope:     %s\n", webhookScope(*webhook))
+			fmt.Printf("  Secret:    %s\n", color.New(color.FgYellow).Sprint(optionalString(webhook.Secret)))
+		}
+	},
+}
+
+var webhookDeleteCmd = &cobra.Command{
+	Use:     "delete WEBHOOK-ID...",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Delete webhooks by ID",
+	Args:    cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		deleted := []string{}
+		var failures []string
+		for _, id := range args {
+			if err := client.DeleteWebhook(context.Background(), id); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", id, err))
+				continue
+			}
+			deleted = append(deleted, id)
+		}
+
+		if jsonOut {
+			result := map[string]interface{}{
+				"deleted": deleted,
+			}
+			if len(failures) > 0 {
+				result["errors"] = failures
+			}
+			output.JSON(result)
+		} else {
+			for _, id := range deleted {
+				if plaintext {
+					fmt.Printf("Deleted webhook %s\n", id)
+				} else {
+					fmt.Printf("%s Deleted webhook %s\n", color.New(color.FgGreen).Sprint("✓"), id)
+				}
+			}
+			for _, failure := range failures {
+				output.Error(fmt.Sprintf("Failed to delete webhook %s", failure), plaintext, false)
+			}
+		}
+
+		exitOnFailures(len(deleted), len(failures))
+	},
+}
+
+var webhookTestCmd = &cobra.Command{
+	Use:   "test WEBHOOK-ID",
+	Short: "Send a signed test delivery to a webhook's URL",
+	Long: `Send a sample event to a webhook's URL, signed with its secret the way Linear
+signs real deliveries, and report how the receiver responded.
+
+Examples:
+  linctl webhook test <webhook-id>
+  linctl webhook test <webhook-id> --type comment`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		secret, _ := cmd.Flags().GetString("secret")
+		typeName, _ := cmd.Flags().GetString("type")
+
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			exitNotAuthenticated(plaintext, jsonOut)
+		}
+
+		client := api.NewClient(authHeader)
+
+		webhook, err := findWebhook(context.Background(), client, args[0])
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Failed to find webhook: %v", err), plaintext, jsonOut)
+		}
+		if secret == "" && webhook.Secret != nil {
+			secret = *webhook.Secret
+		}
+
+		resourceType := "Issue"
+		if typeName != "" {
+			types, err := parseWebhookResourceTypes([]string{typeName})
+			if err != nil {
+				exitWithError(err, err.Error(), plaintext, jsonOut)
+			}
+			resourceType = types[0]
+		} else if len(webhook.ResourceTypes) > 0 {
+			resourceType = webhook.ResourceTypes[0]
+		}
+
+		result, err := sendWebhookTest(webhook, resourceType, secret)
+		if err != nil {
+			exitWithError(err, fmt.Sprintf("Test delivery failed: %v", err), plaintext, jsonOut)
+		}
+
+		if jsonOut {
+			output.JSON(result)
+		} else if plaintext {
+			fmt.Printf("%s %s %d (%s)\n", result.Event, result.URL, result.Status, result.Duration)
+		} else {
+			mark := color.New(color.FgGreen).Sprint("✓")
+			if result.Status >= 300 {
+				mark = color.New(color.FgRed).Sprint("✗")
+			}
+			fmt.Printf("%s Sent %s test delivery to %s\n", mark, result.Event, result.URL)
+			fmt.Printf("  Response: %d in %s\n", result.Status, result.Duration)
+			if result.Body != "" {
+				fmt.Printf("  Body: %s\n", truncateString(result.Body, 200))
+			}
+		}
+		if !result.Signed && !jsonOut {
+			fmt.Fprintln(os.Stderr, "Warning: the webhook has no known secret, so the delivery was not signed (use --secret)")
+		}
+
+		if result.Status >= 300 {
+			exit(1)
+		}
+	},
+}
+
+// webhookTestResult reports how a receiver answered a test delivery
+type webhookTestResult struct {
+	WebhookID string `json:"webhookId"`
+	URL       string `json:"url"`
+	Event     string `json:"event"`
+	Status    int    `json:"status"`
+	Duration  string `json:"duration"`
+	Signed    bool   `json:"signed"`
+	Body      string `json:"body,omitempty"`
+}
+
+// sendWebhookTest posts a sample event of the given resource type to the webhook's URL
+func sendWebhookTest(webhook *api.Webhook, resourceType, secret string) (*webhookTestResult, error) {
+	now := time.Now().UTC()
+	deliveryID := make([]byte, 16)
+	if _, err := rand.Read(deliveryID); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"action":    "create",
+		"type":      resourceType,
+		"createdAt": now.Format(time.RFC3339Nano),
+		"url":       "https://linear.app",
+		"data": map[string]interface{}{
+			"id":        "linctl-test-" + hex.EncodeToString(deliveryID[:4]),
+			"title":     "Test delivery from linctl",
+			"body":      "Test delivery from linctl",
+			"createdAt": now.Format(time.RFC3339Nano),
+			"updatedAt": now.Format(time.RFC3339Nano),
+		},
+		"webhookId":        webhook.ID,
+		"webhookTimestamp": now.UnixMilli(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "linctl")
+	req.Header.Set("Linear-Delivery", hex.EncodeToString(deliveryID))
+	req.Header.Set("Linear-Event", resourceType)
+	if secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(payload)
+		req.Header.Set(webhookSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	start := time.Now()
+	resp, err := httpclient.New().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	return &webhookTestResult{
+		WebhookID: webhook.ID,
+		URL:       webhook.URL,
+		Event:     resourceType,
+		Status:    resp.StatusCode,
+		Duration:  time.Since(start).Round(time.Millisecond).String(),
+		Signed:    secret != "",
+		Body:      strings.TrimSpace(string(body)),
+	}, nil
+}
+
+// webhookScope describes which teams a webhook covers
+func webhookScope(webhook api.Webhook) string {
+	if webhook.Team != nil {
+		return webhook.Team.Key
+	}
+	if webhook.AllPublicTeams {
+		return "all public teams"
+	}
+	return "-"
+}
+
+var webhookListenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "Receive webhook deliveries and stream them to stdout as JSON",
+	Long: `Start a local server that receives Linear webhook deliveries, verifies their
+signatures, and writes each event to stdout as one JSON object per line.
+
+Linear must be able to reach the server, so expose the port with a tunnel
+(ngrok, cloudflared, ...) and pass the public URL with --url. linctl then
+reuses the webhook registered for that URL or creates one, and deletes a
+webhook it created when you stop listening (unless --keep is given).
+Use --webhook-id to listen for an existing webhook instead.
+
+Without --url or --webhook-id nothing is registered; deliveries are verified
+with --secret or $LINCTL_WEBHOOK_SECRET.
+
+Status messages go to stderr, so stdout can be piped to jq or a script.
+
+Examples:
+  linctl webhook listen --port 8080 --url https://abc123.ngrok.app
+  linctl webhook listen --url https://abc123.ngrok.app --filter issue,comment --team ENG
+  linctl webhook listen --webhook-id <id> | jq 'select(.action == "create")'
+  LINCTL_WEBHOOK_SECRET=... linctl webhook listen --port 9000`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		port, _ := cmd.Flags().GetInt("port")
+		path, _ := cmd.Flags().GetString("path")
+		publicURL, _ := cmd.Flags().GetString("url")
+		webhookID, _ := cmd.Flags().GetString("webhook-id")
+		secret, _ := cmd.Flags().GetString("secret")
+		teamKey, _ := cmd.Flags().GetString("team")
+		filterFlag, _ := cmd.Flags().GetStringSlice("filter")
+		keep, _ := cmd.Flags().GetBool("keep")
+
+		if publicURL != "" && webhookID != "" {
+			output.Error("Use either --url or --webhook-id, not both", plaintext, jsonOut)
+			exit(1)
+		}
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		if secret == "" {
+			secret = os.Getenv(webhookSecretEnv)
+		}
+
+		resourceTypes, err := parseWebhookResourceTypes(filterFlag)
+		if err != nil {
+			exitWithError(err, err.Error(), plaintext, jsonOut)
+		}
+
+		status := func(format string, args ...interface{}) {
+			if jsonOut {
+				return
+			}
+			message := fmt.Sprintf(format, args...)
+			if plaintext {
+				fmt.Fprintln(os.Stderr, message)
+			} else {
+				fmt.Fprintln(os.Stderr, color.New(color.FgWhite, color.Faint).Sprint(message))
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		// Register (or look up) the webhook so we know its signing secret
+		var created *api.Webhook
+		var client *api.Client
+		if publicURL != "" || webhookID != "" {
+			authHeader, err := auth.GetAuthHeader()
+			if err != nil {
+				exitNotAuthenticated(plaintext, jsonOut)
+			}
+			client = api.NewClient(authHeader)
+
+			webhook, isNew, err := ensureListenWebhook(ctx, client, publicURL, webhookID, secret, teamKey, resourceTypes)
+			if err != nil {
+				exitWithError(err, fmt.Sprintf("Failed to set up webhook: %v", err), plaintext, jsonOut)
+			}
+			if isNew {
+				created = webhook
+				status("Created webhook %s for %s", webhook.ID, webhook.URL)
+			} else {
+				status("Using webhook %s (%s)", webhook.ID, webhook.URL)
+			}
+			if secret == "" && webhook.Secret != nil {
+				secret = *webhook.Secret
+			}
+		}
+		if secret == "" {
+			fmt.Fprintf(os.Stderr, "Warning: no signing secret (use --secret or $%s); signatures will not be verified\n", webhookSecretEnv)
+		}
+
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			cleanupListenWebhook(client, created, keep, status)
+			exitWithError(err, fmt.Sprintf("Failed to listen on port %d: %v", port, err), plaintext, jsonOut)
+		}
+
+		var mu sync.Mutex
+		mux := http.NewServeMux()
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			event, err := readWebhookDelivery(r, secret)
+			if err != nil {
+				status("Rejected delivery: %v", err)
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+
+			if len(resourceTypes) > 0 && !containsString(resourceTypes, event.Type) {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			os.Stdout.Write(append(event.raw, '\n'))
+		})
+
+		server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() { _ = server.Serve(listener) }()
+		status("Listening for webhook deliveries on http://localhost:%d%s (Ctrl+C to stop)", port, path)
+
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+		cleanupListenWebhook(client, created, keep, status)
+	},
+}
+
+// webhookEvent is a verified webhook delivery
+type webhookEvent struct {
+	Action           string `json:"action"`
+	Type             string `json:"type"`
+	WebhookTimestamp int64  `json:"webhookTimestamp"`
+	raw              []byte
+}
+
+// readWebhookDelivery reads a delivery and verifies its signature and timestamp
+func readWebhookDelivery(r *http.Request, secret string) (*webhookEvent, error) {
+	if r.Method != http.MethodPost {
+		return nil, fmt.Errorf("unexpected method %s", r.Method)
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, webhookMaxBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %v", err)
+	}
+
+	if secret != "" {
+		signature, err := hex.DecodeString(r.Header.Get(webhookSignatureHeader))
+		if err != nil || len(signature) == 0 {
+			return nil, fmt.Errorf("missing or malformed %s header", webhookSignatureHeader)
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return nil, fmt.Errorf("invalid signature")
+		}
+	}
+
+	var event webhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("invalid JSON payload: %v", err)
+	}
+	if secret != "" && event.WebhookTimestamp != 0 {
+		age := time.Since(time.UnixMilli(event.WebhookTimestamp))
+		if age > webhookMaxAge || age < -webhookMaxAge {
+			return nil, fmt.Errorf("stale delivery (timestamp %s old)", age.Round(time.Second))
+		}
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, body); err != nil {
+		return nil, fmt.Errorf("invalid JSON payload: %v", err)
+	}
+	event.raw = compact.Bytes()
+	return &event, nil
+}
+
+// ensureListenWebhook returns the webhook to listen for: the one with the given
+// ID, the one already registered for url, or a newly created one (isNew)
+func ensureListenWebhook(ctx context.Context, client *api.Client, url, id, secret, teamKey string, resourceTypes []string) (webhook *api.Webhook, isNew bool, err error) {
+	webhooks, err := client.GetWebhooks(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range webhooks {
+		if (id != "" && webhooks[i].ID == id) || (id == "" && webhooks[i].URL == url) {
+			return &webhooks[i], false, nil
+		}
+	}
+	if id != "" {
+		return nil, false, fmt.Errorf("webhook %s not found", id)
+	}
+
+	webhook, err = createWebhook(ctx, client, url, "linctl webhook listen", secret, teamKey, resourceTypes, true)
+	if err != nil {
+		return nil, false, err
+	}
+	return webhook, true, nil
+}
+
+// createWebhook registers a webhook scoped to a team, or to all public teams when
+// teamKey is empty. A random secret is generated when none is given.
+func createWebhook(ctx context.Context, client *api.Client, url, label, secret, teamKey string, resourceTypes []string, enabled bool) (*api.Webhook, error) {
+	if secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		secret = hex.EncodeToString(buf)
+	}
+	if len(resourceTypes) == 0 {
+		resourceTypes = defaultWebhookResourceTypes
+	}
+
+	input := map[string]interface{}{
+		"url":           url,
+		"resourceTypes": resourceTypes,
+		"secret":        secret,
+		"enabled":       enabled,
+	}
+	if label != "" {
+		input["label"] = label
+	}
+	if teamKey != "" {
+		team, err := client.GetTeam(ctx, teamKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find team '%s': %v", teamKey, err)
+		}
+		input["teamId"] = team.ID
+	} else {
+		input["allPublicTeams"] = true
+	}
+
+	webhook, err := client.CreateWebhook(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	// The secret isn't always echoed back
+	if webhook.Secret == nil || *webhook.Secret == "" {
+		webhook.Secret = &secret
+	}
+	return webhook, nil
+}
+
+// findWebhook looks up a webhook by ID
+func findWebhook(ctx context.Context, client *api.Client, id string) (*api.Webhook, error) {
+	webhooks, err := client.GetWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		if webhooks[i].ID == id {
+			return &webhooks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("webhook %s not found", id)
+}
+
+// cleanupListenWebhook deletes a webhook created by 'webhook listen'
+func cleanupListenWebhook(client *api.Client, created *api.Webhook, keep bool, status func(string, ...interface{})) {
+	if created == nil {
+		return
+	}
+	if keep {
+		status("Keeping webhook %s", created.ID)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := client.DeleteWebhook(ctx, created.ID); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to delete webhook %s: %v\n", created.ID, err)
+		return
+	}
+	status("Deleted webhook %s", created.ID)
+}
+
+// parseWebhookResourceTypes converts names like "issue,comment" to Linear resource types
+func parseWebhookResourceTypes(names []string) ([]string, error) {
+	var types []string
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" {
+			continue
+		}
+		resourceType, ok := webhookResourceTypes[key]
+		if !ok {
+			resourceType, ok = webhookResourceTypes[strings.TrimSuffix(key, "s")]
+		}
+		if !ok {
+			valid := make([]string, 0, len(webhookResourceTypes))
+			for name := range webhookResourceTypes {
+				valid = append(valid, name)
+			}
+			sort.Strings(valid)
+			return nil, fmt.Errorf("unknown resource type %q (valid: %s)", name, strings.Join(valid, ", "))
+		}
+		if !containsString(types, resourceType) {
+			types = append(types, resourceType)
+		}
+	}
+	return types, nil
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(webhookCmd)
+	webhookCmd.AddCommand(webhookListCmd)
+	webhookCmd.AddCommand(webhookCreateCmd)
+	webhookCmd.AddCommand(webhookDeleteCmd)
+	webhookCmd.AddCommand(webhookTestCmd)
+	webhookCmd.AddCommand(webhookListenCmd)
+
+	webhookListCmd.Flags().Bool("show-secrets", false, "Include signing secrets in the output")
+
+	webhookCreateCmd.Flags().StringP("url", "u", "", "URL that receives deliveries (required)")
+	webhookCreateCmd.Flags().StringSlice("resource-types", nil, "Resource types to deliver, e.g. issue,comment (default: "+strings.Join(defaultWebhookResourceTypes, ",")+")")
+	webhookCreateCmd.Flags().StringP("team", "t", "", "Team key to scope the webhook to (default: all public teams)")
+	webhookCreateCmd.Flags().String("secret", "", "Signing secret (default: generated)")
+	webhookCreateCmd.Flags().StringP("label", "l", "", "Label shown in Linear's settings")
+	webhookCreateCmd.Flags().Bool("disabled", false, "Create the webhook disabled")
+
+	webhookTestCmd.Flags().String("type", "", "Resource type of the sample event (default: the webhook's first resource type)")
+	webhookTestCmd.Flags().String("secret", "", "Signing secret (default: the webhook's secret)")
+
+	webhookListenCmd.Flags().Int("port", 8080, "Local port to listen on")
+	webhookListenCmd.Flags().String("path", "/", "URL path to receive deliveries on")
+	webhookListenCmd.Flags().String("url", "", "Public URL that forwards to the local port; registers a webhook for it")
+	webhookListenCmd.Flags().String("webhook-id", "", "Listen for an existing webhook")
+	webhookListenCmd.Flags().String("secret", "", "Signing secret (default: the webhook's secret or $"+webhookSecretEnv+")")
+	webhookListenCmd.Flags().StringP("team", "t", "", "Team key to scope a newly created webhook to (default: all public teams)")
+	webhookListenCmd.Flags().StringSlice("filter", nil, "Only print these resource types (e.g. issue,comment)")
+	webhookListenCmd.Flags().Bool("keep", false, "Don't delete a webhook created by this command on exit")
+}
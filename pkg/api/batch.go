@@ -0,0 +1,276 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Batch sends several lookups as one GraphQL request, each under its own
+// alias, so a command that needs a team, its states, and its labels pays for
+// one round trip instead of three:
+//
+//	var team Team
+//	var states []WorkflowState
+//	batch := client.Batch()
+//	teamLookup := batch.Team("ENG", &team)
+//	batch.TeamStates("ENG", &states)
+//	if err := batch.Execute(ctx); err != nil {
+//		if teamLookup.Err != nil { ... }
+//	}
+//
+// Batches hold queries only; mutations are sent one at a time.
+type Batch struct {
+	client *Client
+	fields []*BatchField
+}
+
+// BatchField is one lookup in a Batch
+type BatchField struct {
+	alias  string
+	field  string
+	vars   []BatchVar
+	decode func(json.RawMessage) error
+
+	// Err is set by Execute when this lookup failed
+	Err error
+}
+
+// BatchVar is a variable used by a BatchField, declared with its GraphQL type
+type BatchVar struct {
+	Name  string
+	Type  string
+	Value interface{}
+}
+
+// Var declares a variable for Batch.Add
+func Var(name, graphQLType string, value interface{}) BatchVar {
+	return BatchVar{Name: name, Type: graphQLType, Value: value}
+}
+
+// Batch starts an empty batch of lookups on this client
+func (c *Client) Batch() *Batch {
+	return &Batch{client: c}
+}
+
+// Add queues a root field, written with its arguments and selection set, such
+// as `team(id: $key) { id name }`. Its $variables are declared by vars, and
+// its value is unmarshaled into result when the batch runs. Variables are
+// renamed per field, so fields can use the same names.
+func (b *Batch) Add(result interface{}, field string, vars ...BatchVar) *BatchField {
+	return b.add(field, vars, func(data json.RawMessage) error {
+		return json.Unmarshal(data, result)
+	})
+}
+
+func (b *Batch) add(field string, vars []BatchVar, decode func(json.RawMessage) error) *BatchField {
+	f := &BatchField{
+		alias:  fmt.Sprintf("b%d", len(b.fields)),
+		field:  strings.TrimSpace(field),
+		vars:   vars,
+		decode: decode,
+	}
+	b.fields = append(b.fields, f)
+	return f
+}
+
+// Len is the number of queued lookups
+func (b *Batch) Len() int {
+	return len(b.fields)
+}
+
+// query builds the batched document and its variables
+func (b *Batch) query() (string, map[string]interface{}) {
+	var declarations []string
+	var body strings.Builder
+	variables := map[string]interface{}{}
+	for _, f := range b.fields {
+		field := f.field
+		for _, v := range f.vars {
+			name := f.alias + "_" + v.Name
+			pattern := regexp.MustCompile(`\$` + regexp.QuoteMeta(v.Name) + `\b`)
+			field = pattern.ReplaceAllLiteralString(field, "$"+name)
+			declarations = append(declarations, "$"+name+": "+v.Type)
+			variables[name] = v.Value
+		}
+		fmt.Fprintf(&body, "\t%s: %s\n", f.alias, field)
+	}
+
+	query := "query Batch"
+	if len(declarations) > 0 {
+		query += "(" + strings.Join(declarations, ", ") + ")"
+	}
+	return query + " {\n" + body.String() + "}", variables
+}
+
+// Execute sends every queued lookup in one request and fills in their
+// results. It returns the first error, and sets Err on each lookup that
+// failed: all of them when the request itself failed, or those GraphQL
+// reported errors for. The other lookups' results are filled in regardless.
+func (b *Batch) Execute(ctx context.Context) error {
+	if len(b.fields) == 0 {
+		return nil
+	}
+
+	query, variables := b.query()
+	body, err := b.client.post(ctx, query, variables)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	var response struct {
+		Data   map[string]json.RawMessage `json:"data"`
+		Errors []GraphQLError             `json:"errors,omitempty"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return b.fail(fmt.Errorf("failed to parse response: %w", err))
+	}
+
+	// Errors are attributed to a lookup by the alias at the start of their path
+	fieldErrors := map[string][]GraphQLError{}
+	var unattributed []GraphQLError
+	for _, gqlErr := range response.Errors {
+		alias := ""
+		if len(gqlErr.Path) > 0 {
+			alias, _ = gqlErr.Path[0].(string)
+		}
+		if alias == "" {
+			unattributed = append(unattributed, gqlErr)
+			continue
+		}
+		fieldErrors[alias] = append(fieldErrors[alias], gqlErr)
+	}
+	if len(unattributed) > 0 {
+		return b.fail(graphQLError(unattributed))
+	}
+
+	var first error
+	for _, f := range b.fields {
+		if errs := fieldErrors[f.alias]; len(errs) > 0 {
+			f.Err = graphQLError(errs)
+		} else if data := response.Data[f.alias]; len(data) > 0 && string(data) != "null" {
+			if err := f.decode(data); err != nil {
+				f.Err = fmt.Errorf("failed to unmarshal data: %w", err)
+			}
+		}
+		if first == nil {
+			first = f.Err
+		}
+	}
+	return first
+}
+
+// fail records err on every lookup
+func (b *Batch) fail(err error) error {
+	for _, f := range b.fields {
+		f.Err = err
+	}
+	return err
+}
+
+// Team queues a lookup of the team with the given key or ID
+func (b *Batch) Team(key string, team *Team) *BatchField {
+	return b.Add(team, `team(id: $key) {
+		id
+		key
+		name
+		description
+		private
+		issueCount
+		issueEstimationType
+		issueEstimationExtended
+	}`, Var("key", "String!", key))
+}
+
+// TeamStates queues a lookup of a team's workflow states
+func (b *Batch) TeamStates(teamKey string, states *[]WorkflowState) *BatchField {
+	return b.add(`team(id: $key) {
+		states(first: 100) {
+			nodes {
+				id
+				name
+				type
+				color
+				description
+				position
+			}
+		}
+	}`, []BatchVar{Var("key", "String!", teamKey)}, func(data json.RawMessage) error {
+		var team struct {
+			States struct {
+				Nodes []WorkflowState `json:"nodes"`
+			} `json:"states"`
+		}
+		if err := json.Unmarshal(data, &team); err != nil {
+			return err
+		}
+		*states = team.States.Nodes
+		return nil
+	})
+}
+
+// TeamLabels queues a lookup of a team's labels
+func (b *Batch) TeamLabels(teamKey string, labels *[]Label) *BatchField {
+	return b.add(`team(id: $key) {
+		labels(first: 250) {
+			nodes {
+				id
+				name
+				color
+				description
+				parent {
+					id
+					name
+				}
+			}
+		}
+	}`, []BatchVar{Var("key", "String!", teamKey)}, func(data json.RawMessage) error {
+		var team struct {
+			Labels Labels `json:"labels"`
+		}
+		if err := json.Unmarshal(data, &team); err != nil {
+			return err
+		}
+		*labels = team.Labels.Nodes
+		return nil
+	})
+}
+
+// OrganizationLabels queues a lookup of the workspace-wide labels
+func (b *Batch) OrganizationLabels(labels *[]Label) *BatchField {
+	return b.add(`organization {
+		labels {
+			nodes {
+				id
+				name
+				color
+				description
+			}
+		}
+	}`, nil, func(data json.RawMessage) error {
+		var organization struct {
+			Labels Labels `json:"labels"`
+		}
+		if err := json.Unmarshal(data, &organization); err != nil {
+			return err
+		}
+		*labels = organization.Labels.Nodes
+		return nil
+	})
+}
+
+// UserByEmail queues a lookup of the user with the given email
+func (b *Batch) UserByEmail(email string, user *User) *BatchField {
+	return b.Add(user, `user(email: $email) {
+		id
+		name
+		displayName
+		email
+		avatarUrl
+		isMe
+		active
+		admin
+	}`, Var("email", "String!", email))
+}
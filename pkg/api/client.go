@@ -4,10 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sync"
 	"time"
+
+	"github.com/dorkitude/linctl/pkg/debug"
+	"github.com/dorkitude/linctl/pkg/httpclient"
+	"github.com/dorkitude/linctl/pkg/telemetry"
 )
 
 const (
@@ -18,6 +25,13 @@ type Client struct {
 	httpClient *http.Client
 	authHeader string
 	baseURL    string
+	retry      RetryPolicy
+	cache      *ResponseCache
+	uploads    *UploadCache
+
+	// rateLimit holds the most recent X-RateLimit-* values seen from Linear
+	mu        sync.Mutex
+	rateLimit *RateLimit
 }
 
 type GraphQLRequest struct {
@@ -34,6 +48,8 @@ type GraphQLError struct {
 	Message   string                 `json:"message"`
 	Locations []GraphQLErrorLocation `json:"locations,omitempty"`
 	Path      []interface{}          `json:"path,omitempty"`
+	// Extensions carry Linear's error code, type, and user-presentable message
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
 }
 
 type GraphQLErrorLocation struct {
@@ -49,16 +65,106 @@ func NewClient(authHeader string) *Client {
 // NewClientWithURL creates a new Linear API client with custom URL
 func NewClientWithURL(baseURL, authHeader string) *Client {
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		httpClient: httpclient.New(),
 		authHeader: authHeader,
 		baseURL:    baseURL,
+		retry:      DefaultRetryPolicy,
+		cache:      DefaultResponseCache,
+		uploads:    DefaultUploadCache,
 	}
 }
 
 // Execute performs a GraphQL request
 func (c *Client) Execute(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	body, err := c.post(ctx, query, variables)
+	if err != nil {
+		return err
+	}
+
+	var gqlResp GraphQLResponse
+	if err := json.Unmarshal(body, &gqlResp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(gqlResp.Errors) > 0 {
+		return graphQLError(gqlResp.Errors)
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(gqlResp.Data, result); err != nil {
+			return fmt.Errorf("failed to unmarshal data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RawQuery performs a GraphQL request and returns the full JSON response body.
+// GraphQL errors are left in the body; a non-nil error is also returned so callers
+// can exit non-zero while still showing the response. That includes the 4xx
+// responses Linear sends for invalid queries, whose body holds the errors.
+func (c *Client) RawQuery(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	body, err := c.post(ctx, query, variables)
+	if err != nil {
+		var apiErr *Error
+		if errors.As(err, &apiErr) && apiErr.Status >= 400 && apiErr.Status < 500 && len(apiErr.Body) > 0 {
+			return apiErr.Body, err
+		}
+		return nil, err
+	}
+
+	var gqlResp GraphQLResponse
+	if err := json.Unmarshal(body, &gqlResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(gqlResp.Errors) > 0 {
+		return body, graphQLError(gqlResp.Errors)
+	}
+
+	return body, nil
+}
+
+// post sends a GraphQL request and returns the raw response body, retrying
+// rate-limited and transient failures according to the client's RetryPolicy.
+// Queries are answered from the response cache when it holds them, and
+// mutations empty it, since they can change any cached answer.
+func (c *Client) post(ctx context.Context, query string, variables map[string]interface{}) ([]byte, error) {
+	return c.request(ctx, query, variables, c.cache)
+}
+
+// request is post with an explicit response cache (nil to bypass it)
+func (c *Client) request(ctx context.Context, query string, variables map[string]interface{}, cache *ResponseCache) (_ []byte, err error) {
+	mutation := isMutation(query)
+	operation := operationName(query)
+	opType, opName := operationParts(query)
+	ctx, span := telemetry.StartClient(ctx, operation,
+		telemetry.String("graphql.operation.type", opType),
+		telemetry.String("graphql.operation.name", opName),
+		telemetry.String("server.address", serverAddress(c.baseURL)))
+	started := time.Now()
+	status, retries, cacheResult := 0, 0, ""
+	defer func() {
+		span.SetAttributes(telemetry.Int("linctl.retries", int64(retries)))
+		if status != 0 {
+			span.SetAttributes(telemetry.Int("http.response.status_code", int64(status)))
+		}
+		if cacheResult != "" {
+			span.SetAttributes(telemetry.String("linctl.cache", cacheResult))
+		}
+		span.SetError(err)
+		span.End()
+		outcome := "ok"
+		if err != nil {
+			outcome = "error"
+		}
+		telemetry.Add("linctl.api.requests", "{request}", 1, telemetry.String("graphql.operation.name", opName), telemetry.String("outcome", outcome))
+		telemetry.RecordDuration("linctl.api.request.duration", time.Since(started), telemetry.String("graphql.operation.name", opName))
+		if retries > 0 {
+			telemetry.Add("linctl.api.retries", "{retry}", int64(retries), telemetry.String("graphql.operation.name", opName))
+		}
+	}()
+
 	reqBody := GraphQLRequest{
 		Query:     query,
 		Variables: variables,
@@ -66,60 +172,181 @@ func (c *Client) Execute(ctx context.Context, query string, variables map[string
 
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	if debug.Enabled(debug.Bodies) && len(variables) > 0 {
+		debug.Logf(debug.Bodies, "%s variables: %s", operation, debug.JSON(variables))
+	}
+	var key string
+	var cached *cachedResponse
+	conditional := http.Header{}
+	if cache != nil && !cache.Bypass && !mutation {
+		key = cache.key(c.authHeader, c.baseURL, jsonBody)
+		if entry, ok := cache.load(key); ok {
+			if time.Now().Before(entry.Expires) {
+				debug.Logf(debug.Requests, "%s: cached response (fresh for %s)", operation, time.Until(entry.Expires).Round(time.Second))
+				cacheResult = "hit"
+				return entry.Body, nil
+			}
+			if entry.ETag != "" || entry.LastModified != "" {
+				cached = entry
+				if entry.ETag != "" {
+					conditional.Set("If-None-Match", entry.ETag)
+				}
+				if entry.LastModified != "" {
+					conditional.Set("If-Modified-Since", entry.LastModified)
+				}
+			}
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		retries = attempt
+		start := time.Now()
+		body, code, header, err := c.send(ctx, jsonBody, conditional)
+		status = code
+		if err != nil {
+			debug.Logf(debug.Requests, "%s: %v after %s", operation, err, time.Since(start).Round(time.Millisecond))
+			return nil, err
+		}
+		logResponse(operation, status, header, body, time.Since(start))
+
+		if status == http.StatusNotModified && cached != nil {
+			// Still current: keep the cached body, with the new freshness
+			if header.Get("ETag") == "" {
+				header.Set("ETag", cached.ETag)
+			}
+			if header.Get("Last-Modified") == "" {
+				header.Set("Last-Modified", cached.LastModified)
+			}
+			cache.save(key, cached.Body, header)
+			cacheResult = "revalidated"
+			return cached.Body, nil
+		}
+
+		if status == http.StatusOK {
+			if cache != nil {
+				if mutation {
+					_, _ = cache.Clear()
+				} else if !cache.Bypass && !hasGraphQLErrors(body) {
+					cache.save(key, body, header)
+				}
+			}
+			return body, nil
+		}
+
+		// Decide whether this failure is worth another attempt
+		rateLimited := isRateLimited(status, body)
+		retryable := rateLimited || (isTransient(status) && !mutation)
+		if !retryable || attempt >= c.retry.MaxRetries {
+			if retryable {
+				debug.Logf(debug.Requests, "%s: giving up after %d retries", operation, attempt)
+			}
+			return nil, statusError(status, body)
+		}
+
+		wait := c.retry.backoff(attempt + 1)
+		if after, ok := retryAfter(header); ok {
+			wait = after
+		} else if rateLimited {
+			if rateLimit := parseRateLimit(header); rateLimit != nil && rateLimit.Remaining == 0 && !rateLimit.Reset.IsZero() {
+				if untilReset := time.Until(rateLimit.Reset); untilReset > wait {
+					wait = untilReset
+				}
+			}
+		}
+		if wait > maxBackoff {
+			// Rather than sleep silently until a distant reset, say when to come back
+			debug.Logf(debug.Requests, "%s: not retrying, asked to wait %s", operation, wait.Round(time.Second))
+			apiErr := statusError(status, body)
+			if rateLimited {
+				apiErr.Kind = KindRateLimited
+			}
+			apiErr.Message = fmt.Sprintf("%s (retry after %s, in %s)", apiErr.Message,
+				time.Now().Add(wait).Local().Format("15:04:05"), wait.Round(time.Second))
+			return nil, apiErr
+		}
+		reason := fmt.Sprintf("HTTP %d", status)
+		if rateLimited {
+			reason = "rate limited"
+		}
+		debug.Logf(debug.Requests, "%s: %s, retry %d/%d in %s", operation, reason, attempt+1, c.retry.MaxRetries, wait.Round(time.Millisecond))
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request failed: %w", ctx.Err())
+		case <-time.After(wait):
+		}
 	}
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL, bytes.NewBuffer(jsonBody))
+// serverAddress is the host of an API URL, for telemetry
+func serverAddress(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		return u.Hostname()
+	}
+	return ""
+}
+
+// send performs a single HTTP round trip, with any extra headers, and
+// records rate-limit headers
+func (c *Client) send(ctx context.Context, jsonBody []byte, extra http.Header) ([]byte, int, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL, bytes.NewReader(jsonBody))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", c.authHeader)
 	req.Header.Set("User-Agent", "linctl/0.1.0")
+	for name, values := range extra {
+		req.Header[name] = values
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, 0, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	if rateLimit := parseRateLimit(resp.Header); rateLimit != nil {
+		c.mu.Lock()
+		c.rateLimit = rateLimit
+		c.mu.Unlock()
 	}
 
-	var gqlResp GraphQLResponse
-	if err := json.Unmarshal(body, &gqlResp); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
-	}
+	return body, resp.StatusCode, resp.Header, nil
+}
 
-	if len(gqlResp.Errors) > 0 {
-		return fmt.Errorf("GraphQL errors: %v", gqlResp.Errors)
-	}
+// GetRateLimit returns the rate-limit status reported by Linear on the most recent response.
+// Before any request has been made it performs a lightweight query to populate it.
+func (c *Client) GetRateLimit(ctx context.Context) (*RateLimit, error) {
+	c.mu.Lock()
+	rateLimit := c.rateLimit
+	c.mu.Unlock()
 
-	if result != nil {
-		if err := json.Unmarshal(gqlResp.Data, result); err != nil {
-			return fmt.Errorf("failed to unmarshal data: %w", err)
+	if rateLimit == nil {
+		// Bypass the response cache: only a real response carries the headers
+		if _, err := c.request(ctx, "query RateLimit { viewer { id } }", nil, nil); err != nil {
+			return nil, err
 		}
+		c.mu.Lock()
+		rateLimit = c.rateLimit
+		c.mu.Unlock()
 	}
 
-	return nil
-}
+	if rateLimit == nil {
+		return nil, fmt.Errorf("rate limit information not available")
+	}
 
-// Rate limiting helper
-func (c *Client) GetRateLimit(ctx context.Context) (*RateLimit, error) {
-	// This would query Linear's rate limiting info
-	// For now, we'll return a placeholder
-	return &RateLimit{
-		Limit:     5000,
-		Remaining: 4999,
-		Reset:     time.Now().Add(time.Hour),
-	}, nil
+	result := *rateLimit
+	return &result, nil
 }
 
 type RateLimit struct {
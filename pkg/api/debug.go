@@ -0,0 +1,74 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/debug"
+)
+
+// operationPattern matches the start of a named GraphQL operation
+var operationPattern = regexp.MustCompile(`^\s*(query|mutation|subscription)\s+([A-Za-z_][A-Za-z0-9_]*)`)
+
+// operationName names a GraphQL request in the debug log, e.g. "query IssueGet"
+func operationName(query string) string {
+	kind, name := operationParts(query)
+	if name == "" {
+		return "anonymous " + kind
+	}
+	return kind + " " + name
+}
+
+// operationParts returns a GraphQL request's operation type and its name,
+// which is empty for anonymous operations
+func operationParts(query string) (string, string) {
+	if match := operationPattern.FindStringSubmatch(query); match != nil {
+		return match[1], match[2]
+	}
+	if isMutation(query) {
+		return "mutation", ""
+	}
+	return "query", ""
+}
+
+// logResponse logs one API response: its status, timing, size, and the
+// rate-limit budget Linear reports, and at the Bodies level the body itself
+func logResponse(operation string, status int, header http.Header, body []byte, elapsed time.Duration) {
+	if !debug.Enabled(debug.Requests) {
+		return
+	}
+	line := fmt.Sprintf("%s: %d %s in %s, %d bytes", operation, status, http.StatusText(status), elapsed.Round(time.Millisecond), len(body))
+	if rateLimit := parseRateLimit(header); rateLimit != nil {
+		line += fmt.Sprintf(", %d/%d requests left", rateLimit.Remaining, rateLimit.Limit)
+		if !rateLimit.Reset.IsZero() {
+			line += fmt.Sprintf(" (resets %s)", rateLimit.Reset.Local().Format("15:04:05"))
+		}
+	}
+	if complexity := header.Get("X-Complexity"); complexity != "" {
+		line += ", complexity " + complexity
+		if remaining := header.Get("X-RateLimit-Complexity-Remaining"); remaining != "" {
+			line += fmt.Sprintf(" (%s left)", remaining)
+		}
+	}
+	if requestID := firstHeader(header, "X-Request-Id", "Cf-Ray"); requestID != "" {
+		line += ", request " + requestID
+	}
+	debug.Logf(debug.Requests, "%s", line)
+
+	if len(body) > 0 {
+		debug.Logf(debug.Bodies, "%s response: %s", operation, strings.TrimSpace(debug.Body(body)))
+	}
+}
+
+// firstHeader returns the first of the named headers that is set
+func firstHeader(header http.Header, names ...string) string {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
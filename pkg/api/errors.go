@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorKind classifies a failed API request
+type ErrorKind string
+
+const (
+	KindNotFound         ErrorKind = "not_found"
+	KindAuthentication   ErrorKind = "authentication"
+	KindPermissionDenied ErrorKind = "permission_denied"
+	KindRateLimited      ErrorKind = "rate_limited"
+	KindValidation       ErrorKind = "validation"
+	KindServer           ErrorKind = "server"
+	KindUnknown          ErrorKind = "unknown"
+)
+
+// Sentinels for errors.Is; any *Error of the same kind matches them:
+//
+//	if errors.Is(err, api.ErrNotFound) { ... }
+var (
+	ErrNotFound         = &Error{Kind: KindNotFound, Message: "not found"}
+	ErrAuthentication   = &Error{Kind: KindAuthentication, Message: "authentication failed"}
+	ErrPermissionDenied = &Error{Kind: KindPermissionDenied, Message: "permission denied"}
+	ErrRateLimited      = &Error{Kind: KindRateLimited, Message: "rate limited"}
+	ErrValidation       = &Error{Kind: KindValidation, Message: "invalid input"}
+)
+
+// Error is a request Linear rejected, either with an HTTP error status or with
+// GraphQL errors. Kind says why; use errors.Is with the Err* sentinels, or
+// errors.As to read the details.
+type Error struct {
+	Kind    ErrorKind
+	Message string
+	// Path is the GraphQL path of the failing field, such as ["issueUpdate"]
+	Path []string
+	// Field is the input field a validation error is about, when Linear names it
+	Field string
+	// Status is the HTTP status code, when the request itself failed
+	Status int
+	// Errors are the GraphQL errors Linear returned
+	Errors []GraphQLError
+	// Body is the response of a request that failed with a JSON body, such as
+	// a 400 carrying GraphQL validation errors
+	Body json.RawMessage
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors of the same kind, such as the Err* sentinels
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind
+}
+
+// ErrorKindOf is the kind of an API error anywhere in err's chain, or "" when
+// err did not come from the API
+func ErrorKindOf(err error) ErrorKind {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind
+	}
+	return ""
+}
+
+// graphQLError builds an Error from the GraphQL errors of a response. The
+// first error decides the kind; the message lists them all.
+func graphQLError(errs []GraphQLError) *Error {
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		message := e.Message
+		if presentable, ok := e.Extensions["userPresentableMessage"].(string); ok && presentable != "" && presentable != message {
+			message += " (" + presentable + ")"
+		}
+		messages = append(messages, message)
+	}
+
+	apiErr := &Error{
+		Kind:    KindUnknown,
+		Message: strings.Join(messages, "; "),
+		Errors:  errs,
+	}
+	if len(errs) == 0 {
+		return apiErr
+	}
+
+	first := errs[0]
+	apiErr.Kind = classifyGraphQLError(first)
+	for _, segment := range first.Path {
+		apiErr.Path = append(apiErr.Path, fmt.Sprint(segment))
+	}
+	for _, key := range []string{"argumentPath", "field", "property"} {
+		switch value := first.Extensions[key].(type) {
+		case string:
+			apiErr.Field = value
+		case []interface{}:
+			parts := make([]string, len(value))
+			for i, part := range value {
+				parts[i] = fmt.Sprint(part)
+			}
+			apiErr.Field = strings.Join(parts, ".")
+		}
+		if apiErr.Field != "" {
+			break
+		}
+	}
+	return apiErr
+}
+
+// classifyGraphQLError reads Linear's extensions.code and extensions.type,
+// falling back to the message
+func classifyGraphQLError(e GraphQLError) ErrorKind {
+	code, _ := e.Extensions["code"].(string)
+	errType, _ := e.Extensions["type"].(string)
+	message := strings.ToLower(e.Message)
+
+	switch {
+	case code == "RATELIMITED" || errType == "ratelimited":
+		return KindRateLimited
+	case code == "AUTHENTICATION_ERROR" || errType == "authentication error":
+		return KindAuthentication
+	case code == "FORBIDDEN" || errType == "forbidden" || strings.Contains(message, "permission"):
+		return KindPermissionDenied
+	case strings.Contains(message, "not found") || strings.Contains(message, "could not find"):
+		return KindNotFound
+	case code == "GRAPHQL_VALIDATION_FAILED" || code == "BAD_USER_INPUT" || code == "INVALID_INPUT" ||
+		errType == "invalid input" || errType == "graphql error" || errType == "user error":
+		return KindValidation
+	case code == "INTERNAL_SERVER_ERROR" || errType == "internal error":
+		return KindServer
+	}
+	return KindUnknown
+}
+
+// statusError builds an Error from a failed HTTP response, using its GraphQL
+// errors when the body carries them
+func statusError(status int, body []byte) *Error {
+	var resp struct {
+		Errors []GraphQLError `json:"errors"`
+	}
+	if json.Unmarshal(body, &resp) == nil && len(resp.Errors) > 0 {
+		apiErr := graphQLError(resp.Errors)
+		apiErr.Status = status
+		if apiErr.Kind == KindUnknown {
+			apiErr.Kind = statusKind(status)
+		}
+		apiErr.Message = fmt.Sprintf("API request failed with status %d: %s", status, apiErr.Message)
+		apiErr.Body = body
+		return apiErr
+	}
+
+	apiErr := &Error{
+		Kind:    statusKind(status),
+		Message: fmt.Sprintf("API request failed with status %d: %s", status, strings.TrimSpace(string(body))),
+		Status:  status,
+	}
+	if json.Valid(body) {
+		apiErr.Body = body
+	}
+	return apiErr
+}
+
+// statusKind classifies an HTTP error status
+func statusKind(status int) ErrorKind {
+	switch {
+	case status == http.StatusUnauthorized:
+		return KindAuthentication
+	case status == http.StatusForbidden:
+		return KindPermissionDenied
+	case status == http.StatusNotFound:
+		return KindNotFound
+	case status == http.StatusTooManyRequests:
+		return KindRateLimited
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return KindValidation
+	case status >= 500:
+		return KindServer
+	}
+	return KindUnknown
+}
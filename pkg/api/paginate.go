@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+)
+
+const (
+	// DefaultPageSize is the number of nodes requested per page when none is given
+	DefaultPageSize = 50
+	// MaxPageSize is the largest page Linear will return for a connection
+	MaxPageSize = 250
+)
+
+// PageFetcher fetches a single page of a connection starting after the given cursor
+type PageFetcher[T any] func(ctx context.Context, first int, after string) ([]T, PageInfo, error)
+
+// PaginateOptions controls how Paginate walks a connection
+type PaginateOptions struct {
+	// PageSize is the number of nodes requested per page (default 50, max 250)
+	PageSize int
+	// MaxResults caps the total number of nodes returned (0 means no cap)
+	MaxResults int
+}
+
+// Paginate follows pageInfo.hasNextPage/endCursor until the connection is exhausted
+// or MaxResults nodes have been collected. The returned PageInfo is the one from the
+// last page fetched, so callers can tell whether more results were left behind.
+func Paginate[T any](ctx context.Context, opts PaginateOptions, fetch PageFetcher[T]) ([]T, PageInfo, error) {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	var (
+		results  []T
+		pageInfo PageInfo
+		after    string
+	)
+
+	for {
+		first := pageSize
+		if opts.MaxResults > 0 {
+			remaining := opts.MaxResults - len(results)
+			if remaining <= 0 {
+				break
+			}
+			if remaining < first {
+				first = remaining
+			}
+		}
+
+		nodes, info, err := fetch(ctx, first, after)
+		if err != nil {
+			return results, pageInfo, err
+		}
+
+		results = append(results, nodes...)
+		pageInfo = info
+
+		// Stop when Linear reports no more pages, or when it gives us nothing to continue from
+		if !info.HasNextPage || info.EndCursor == "" || info.EndCursor == after {
+			break
+		}
+		after = info.EndCursor
+	}
+
+	return results, pageInfo, nil
+}
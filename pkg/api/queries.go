@@ -3,6 +3,8 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -33,6 +35,9 @@ type Team struct {
 	CycleStartDay      int     `json:"cycleStartDay"`
 	CycleDuration      int     `json:"cycleDuration"`
 	UpcomingCycleCount int     `json:"upcomingCycleCount"`
+	// IssueEstimationType is notUsed, exponential, fibonacci, linear, or tShirt
+	IssueEstimationType     string `json:"issueEstimationType,omitempty"`
+	IssueEstimationExtended bool   `json:"issueEstimationExtended,omitempty"`
 }
 
 // Issue represents a Linear issue
@@ -46,6 +51,8 @@ type Issue struct {
 	CreatedAt           time.Time    `json:"createdAt"`
 	UpdatedAt           time.Time    `json:"updatedAt"`
 	DueDate             *string      `json:"dueDate"`
+	SLAStartedAt        *time.Time   `json:"slaStartedAt,omitempty"`
+	SLABreachesAt       *time.Time   `json:"slaBreachesAt,omitempty"`
 	State               *State       `json:"state"`
 	Assignee            *User        `json:"assignee"`
 	Team                *Team        `json:"team"`
@@ -56,9 +63,11 @@ type Issue struct {
 	BranchName          string       `json:"branchName"`
 	Cycle               *Cycle       `json:"cycle"`
 	Project             *Project     `json:"project"`
+	ProjectMilestone    *Milestone   `json:"projectMilestone,omitempty"`
 	Attachments         *Attachments `json:"attachments"`
 	Comments            *Comments    `json:"comments"`
 	SnoozedUntilAt      *time.Time   `json:"snoozedUntilAt"`
+	StartedAt           *time.Time   `json:"startedAt,omitempty"`
 	CompletedAt         *time.Time   `json:"completedAt"`
 	CanceledAt          *time.Time   `json:"canceledAt"`
 	ArchivedAt          *time.Time   `json:"archivedAt"`
@@ -120,6 +129,7 @@ type Project struct {
 	LastAppliedTemplate *Template       `json:"lastAppliedTemplate"`
 	ProjectUpdates      *ProjectUpdates `json:"projectUpdates"`
 	Documents           *Documents      `json:"documents"`
+	ProjectMilestones   *Milestones     `json:"projectMilestones,omitempty"`
 	Health              string          `json:"health"`
 	Scope               int             `json:"scope"`
 	SlackNewIssue       bool            `json:"slackNewIssue"`
@@ -163,6 +173,8 @@ type Label struct {
 	Color       string  `json:"color"`
 	Description *string `json:"description"`
 	Parent      *Label  `json:"parent"`
+	// Team is nil for workspace labels
+	Team *Team `json:"team,omitempty"`
 }
 
 // Cycle represents a Linear cycle (sprint)
@@ -176,6 +188,19 @@ type Cycle struct {
 	Progress     float64    `json:"progress"`
 	CompletedAt  *time.Time `json:"completedAt"`
 	ScopeHistory []float64  `json:"scopeHistory"`
+	// Daily history of issue counts and completed/in-progress scope
+	IssueCountHistory          []float64 `json:"issueCountHistory,omitempty"`
+	CompletedScopeHistory      []float64 `json:"completedScopeHistory,omitempty"`
+	CompletedIssueCountHistory []float64 `json:"completedIssueCountHistory,omitempty"`
+	InProgressScopeHistory     []float64 `json:"inProgressScopeHistory,omitempty"`
+	IsActive                   bool      `json:"isActive"`
+	IsNext                     bool      `json:"isNext"`
+	IsPrevious                 bool      `json:"isPrevious"`
+	IsFuture                   bool      `json:"isFuture"`
+	IsPast                     bool      `json:"isPast"`
+	Team                       *Team     `json:"team,omitempty"`
+	Issues                     *Issues   `json:"issues,omitempty"`
+	UncompletedIssuesUponClose *Issues   `json:"uncompletedIssuesUponClose,omitempty"`
 }
 
 // Attachment represents a file attachment or link
@@ -230,9 +255,25 @@ type Attachments struct {
 
 // Initiative represents a Linear initiative
 type Initiative struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Content     string     `json:"content,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	Health      string     `json:"health,omitempty"`
+	TargetDate  *string    `json:"targetDate,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	Owner       *User      `json:"owner,omitempty"`
+	Projects    *Projects  `json:"projects,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// Initiatives represents a paginated list of initiatives
+type Initiatives struct {
+	Nodes    []Initiative `json:"nodes"`
+	PageInfo PageInfo     `json:"pageInfo"`
 }
 
 type PageInfo struct {
@@ -253,7 +294,8 @@ type IssueRelation struct {
 }
 
 type IssueHistory struct {
-	Nodes []IssueHistoryEntry `json:"nodes"`
+	Nodes    []IssueHistoryEntry `json:"nodes"`
+	PageInfo *PageInfo           `json:"pageInfo,omitempty"`
 }
 
 type IssueHistoryEntry struct {
@@ -276,6 +318,17 @@ type IssueHistoryEntry struct {
 	ToProject       *Project  `json:"toProject"`
 	AddedLabelIds   []string  `json:"addedLabelIds"`
 	RemovedLabelIds []string  `json:"removedLabelIds"`
+	// Additional fields
+	AddedLabels        []Label  `json:"addedLabels,omitempty"`
+	RemovedLabels      []Label  `json:"removedLabels,omitempty"`
+	FromEstimate       *float64 `json:"fromEstimate,omitempty"`
+	ToEstimate         *float64 `json:"toEstimate,omitempty"`
+	FromDueDate        *string  `json:"fromDueDate,omitempty"`
+	ToDueDate          *string  `json:"toDueDate,omitempty"`
+	FromParent         *Issue   `json:"fromParent,omitempty"`
+	ToParent           *Issue   `json:"toParent,omitempty"`
+	UpdatedDescription bool     `json:"updatedDescription,omitempty"`
+	Archived           *bool    `json:"archived,omitempty"`
 }
 
 type Reaction struct {
@@ -303,18 +356,69 @@ type CustomerTicket struct {
 	ExternalId string    `json:"externalId"`
 }
 
+// Customer represents a customer tracked with Linear's customer requests
+type Customer struct {
+	ID                   string        `json:"id"`
+	Name                 string        `json:"name"`
+	Domains              []string      `json:"domains"`
+	Revenue              *float64      `json:"revenue"`
+	Size                 *float64      `json:"size"`
+	ApproximateNeedCount float64       `json:"approximateNeedCount"`
+	Tier                 *CustomerMeta `json:"tier"`
+	Status               *CustomerMeta `json:"status"`
+}
+
+// CustomerMeta is a customer's tier or status
+type CustomerMeta struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Customers struct {
+	Nodes    []Customer `json:"nodes"`
+	PageInfo PageInfo   `json:"pageInfo"`
+}
+
+// CustomerNeed is a customer request: a customer asking for an issue
+type CustomerNeed struct {
+	ID        string    `json:"id"`
+	Body      *string   `json:"body"`
+	Priority  float64   `json:"priority"`
+	CreatedAt time.Time `json:"createdAt"`
+	Customer  *Customer `json:"customer"`
+	Issue     *Issue    `json:"issue"`
+	Creator   *User     `json:"creator"`
+}
+
+type CustomerNeeds struct {
+	Nodes    []CustomerNeed `json:"nodes"`
+	PageInfo PageInfo       `json:"pageInfo"`
+}
+
 type Template struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	Type         string          `json:"type,omitempty"`
+	TemplateData json.RawMessage `json:"templateData,omitempty"`
+	Team         *Team           `json:"team,omitempty"`
 }
 
+// Milestone represents a project milestone
 type Milestone struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	TargetDate  *string   `json:"targetDate"`
-	Projects    *Projects `json:"projects"`
+	Progress    float64   `json:"progress"`
+	SortOrder   float64   `json:"sortOrder,omitempty"`
+	Project     *Project  `json:"project,omitempty"`
+	Projects    *Projects `json:"projects,omitempty"`
+}
+
+// Milestones represents a list of project milestones
+type Milestones struct {
+	Nodes []Milestone `json:"nodes"`
 }
 
 type Roadmaps struct {
@@ -331,7 +435,8 @@ type Roadmap struct {
 }
 
 type ProjectUpdates struct {
-	Nodes []ProjectUpdate `json:"nodes"`
+	Nodes    []ProjectUpdate `json:"nodes"`
+	PageInfo *PageInfo       `json:"pageInfo,omitempty"`
 }
 
 type ProjectUpdate struct {
@@ -342,10 +447,12 @@ type ProjectUpdate struct {
 	UpdatedAt time.Time  `json:"updatedAt"`
 	EditedAt  *time.Time `json:"editedAt"`
 	Health    string     `json:"health"`
+	URL       string     `json:"url,omitempty"`
 }
 
 type Documents struct {
-	Nodes []Document `json:"nodes"`
+	Nodes    []Document `json:"nodes"`
+	PageInfo *PageInfo  `json:"pageInfo,omitempty"`
 }
 
 type Document struct {
@@ -358,6 +465,9 @@ type Document struct {
 	UpdatedBy *User     `json:"updatedBy"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
+	SlugID    string    `json:"slugId,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	Project   *Project  `json:"project,omitempty"`
 }
 
 type ProjectLinks struct {
@@ -380,6 +490,7 @@ func (c *Client) GetViewer(ctx context.Context) (*User, error) {
 			viewer {
 				id
 				name
+				displayName
 				email
 				avatarUrl
 				isMe
@@ -401,51 +512,119 @@ func (c *Client) GetViewer(ctx context.Context) (*User, error) {
 	return &response.Viewer, nil
 }
 
+// issueListFields is the selection set of issues returned by GetIssues and IssueSearch
+const issueListFields = `
+	id
+	identifier
+	title
+	description
+	priority
+	estimate
+	createdAt
+	updatedAt
+	dueDate
+	slaStartedAt
+	slaBreachesAt
+	url
+	archivedAt
+	state {
+		id
+		name
+		type
+		color
+	}
+	assignee {
+		id
+		name
+		email
+	}
+	team {
+		id
+		key
+		name
+	}
+	labels {
+		nodes {
+			id
+			name
+			color
+		}
+	}
+	parent {
+		id
+		identifier
+		title
+	}
+	project {
+		id
+		name
+	}
+	cycle {
+		id
+		number
+		name
+	}
+`
+
+// IssueFieldSelections maps the issue fields that list and search results can
+// be narrowed to (see IssueSelection) to their GraphQL selections
+var IssueFieldSelections = map[string]string{
+	"id":          "id",
+	"identifier":  "identifier",
+	"title":       "title",
+	"description": "description",
+	"priority":    "priority",
+	"estimate":    "estimate",
+	"created":     "createdAt",
+	"updated":     "updatedAt",
+	"due":         "dueDate",
+	"sla":         "slaStartedAt slaBreachesAt",
+	"url":         "url",
+	"archived":    "archivedAt",
+	"state":       "state { id name type color }",
+	"assignee":    "assignee { id name email }",
+	"team":        "team { id key name }",
+	"labels":      "labels { nodes { id name color } }",
+	"parent":      "parent { id identifier title }",
+	"project":     "project { id name }",
+	"cycle":       "cycle { id number name }",
+}
+
+// IssueSelection builds a selection set for GetIssuesWithFields and
+// IssueSearchWithFields holding only the given fields (keys of
+// IssueFieldSelections). The id is always selected.
+func IssueSelection(fields []string) (string, error) {
+	selections := []string{"id"}
+	seen := map[string]bool{"id": true}
+	for _, field := range fields {
+		selection, ok := IssueFieldSelections[field]
+		if !ok {
+			return "", fmt.Errorf("unknown issue field '%s'", field)
+		}
+		if !seen[field] {
+			seen[field] = true
+			selections = append(selections, selection)
+		}
+	}
+	return strings.Join(selections, "\n"), nil
+}
+
 // GetIssues returns a list of issues with optional filtering
 func (c *Client) GetIssues(ctx context.Context, filter map[string]interface{}, first int, after string, orderBy string) (*Issues, error) {
+	return c.GetIssuesWithFields(ctx, issueListFields, filter, first, after, orderBy, false)
+}
+
+// GetIssuesWithFields is GetIssues selecting only fields (see IssueSelection),
+// which keeps large listings small; "" selects the same fields as GetIssues.
+// includeArchived adds archived and trashed issues.
+func (c *Client) GetIssuesWithFields(ctx context.Context, fields string, filter map[string]interface{}, first int, after string, orderBy string, includeArchived bool) (*Issues, error) {
+	if fields == "" {
+		fields = issueListFields
+	}
 	query := `
-		query Issues($filter: IssueFilter, $first: Int, $after: String, $orderBy: PaginationOrderBy) {
-			issues(filter: $filter, first: $first, after: $after, orderBy: $orderBy) {
-				nodes {
-					id
-					identifier
-					title
-					description
-					priority
-					estimate
-					createdAt
-					updatedAt
-					dueDate
-					url
-					state {
-						id
-						name
-						type
-						color
-					}
-					assignee {
-						id
-						name
-						email
-					}
-					team {
-						id
-						key
-						name
-					}
-					labels {
-						nodes {
-							id
-							name
-							color
-						}
-					}
-					parent {
-						id
-						identifier
-						title
-					}
-				}
+		query Issues($filter: IssueFilter, $first: Int, $after: String, $orderBy: PaginationOrderBy, $includeArchived: Boolean) {
+			issues(filter: $filter, first: $first, after: $after, orderBy: $orderBy, includeArchived: $includeArchived) {
+				nodes {` + fields + `}
 				pageInfo {
 					hasNextPage
 					endCursor
@@ -455,7 +634,8 @@ func (c *Client) GetIssues(ctx context.Context, filter map[string]interface{}, f
 	`
 
 	variables := map[string]interface{}{
-		"first": first,
+		"first":           first,
+		"includeArchived": includeArchived,
 	}
 	if filter != nil {
 		variables["filter"] = filter
@@ -481,49 +661,19 @@ func (c *Client) GetIssues(ctx context.Context, filter map[string]interface{}, f
 
 // IssueSearch returns issues that match a full-text query
 func (c *Client) IssueSearch(ctx context.Context, term string, filter map[string]interface{}, first int, after string, orderBy string, includeArchived bool) (*Issues, error) {
+	return c.IssueSearchWithFields(ctx, issueListFields, term, filter, first, after, orderBy, includeArchived)
+}
+
+// IssueSearchWithFields is IssueSearch selecting only fields (see IssueSelection);
+// "" selects the same fields as IssueSearch
+func (c *Client) IssueSearchWithFields(ctx context.Context, fields string, term string, filter map[string]interface{}, first int, after string, orderBy string, includeArchived bool) (*Issues, error) {
+	if fields == "" {
+		fields = issueListFields
+	}
 	query := `
 		query IssueSearch($term: String!, $filter: IssueFilter, $first: Int, $after: String, $orderBy: PaginationOrderBy, $includeArchived: Boolean) {
 			searchIssues(term: $term, filter: $filter, first: $first, after: $after, orderBy: $orderBy, includeArchived: $includeArchived) {
-				nodes {
-					id
-					identifier
-					title
-					description
-					priority
-					estimate
-					createdAt
-					updatedAt
-					dueDate
-					url
-					state {
-						id
-						name
-						type
-						color
-					}
-					assignee {
-						id
-						name
-						email
-					}
-					team {
-						id
-						key
-						name
-					}
-					labels {
-						nodes {
-							id
-							name
-							color
-						}
-					}
-					parent {
-						id
-						identifier
-						title
-					}
-				}
+				nodes {` + fields + `}
 				pageInfo {
 					hasNextPage
 					endCursor
@@ -583,6 +733,8 @@ func (c *Client) GetIssue(ctx context.Context, id string) (*Issue, error) {
 				createdAt
 				updatedAt
 				dueDate
+				slaStartedAt
+				slaBreachesAt
 				url
 				branchName
 				snoozedUntilAt
@@ -695,6 +847,11 @@ func (c *Client) GetIssue(ctx context.Context, id string) (*Issue, error) {
 						email
 					}
 				}
+				projectMilestone {
+					id
+					name
+					targetDate
+				}
 				attachments(first: 20) {
 					nodes {
 						id
@@ -1057,6 +1214,16 @@ func (c *Client) GetProject(ctx context.Context, id string) (*Project, error) {
 						}
 					}
 				}
+				projectMilestones {
+					nodes {
+						id
+						name
+						description
+						targetDate
+						progress
+						sortOrder
+					}
+				}
 				documents(first: 20) {
 					nodes {
 						id
@@ -1168,53 +1335,54 @@ func (c *Client) UpdateIssue(ctx context.Context, id string, input map[string]in
 	return &response.IssueUpdate.Issue, nil
 }
 
-// CreateIssue creates a new issue
-func (c *Client) CreateIssue(ctx context.Context, input map[string]interface{}) (*Issue, error) {
+// SetIssueReminder has Linear notify the viewer about an issue at a time
+func (c *Client) SetIssueReminder(ctx context.Context, id string, at time.Time) error {
 	query := `
-		mutation CreateIssue($input: IssueCreateInput!) {
-			issueCreate(input: $input) {
-				issue {
-					id
-					identifier
-					title
-					description
-					priority
-					estimate
-					createdAt
-					updatedAt
-					dueDate
-					state {
-						id
-						name
-						type
-						color
-					}
-					assignee {
+		mutation IssueReminder($id: String!, $reminderAt: DateTime!) {
+			issueReminder(id: $id, reminderAt: $reminderAt) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":         id,
+		"reminderAt": at.UTC().Format(time.RFC3339),
+	}
+
+	var response struct {
+		IssueReminder struct {
+			Success bool `json:"success"`
+		} `json:"issueReminder"`
+	}
+
+	if err := c.Execute(ctx, query, variables, &response); err != nil {
+		return err
+	}
+	if !response.IssueReminder.Success {
+		return fmt.Errorf("reminder was not set")
+	}
+	return nil
+}
+
+// GetIssueSubscribers returns an issue with the users subscribed to its
+// notifications
+func (c *Client) GetIssueSubscribers(ctx context.Context, id string) (*Issue, error) {
+	query := `
+		query IssueSubscribers($id: String!) {
+			issue(id: $id) {
+				id
+				identifier
+				title
+				url
+				subscribers(first: 250) {
+					nodes {
 						id
 						name
+						displayName
 						email
-					}
-					team {
-						id
-						key
-						name
-					}
-					labels {
-						nodes {
-							id
-							name
-							color
-						}
-					}
-					cycle {
-						id
-						number
-						name
-					}
-					parent {
-						id
-						identifier
-						title
+						isMe
+						active
 					}
 				}
 			}
@@ -1222,69 +1390,300 @@ func (c *Client) CreateIssue(ctx context.Context, input map[string]interface{})
 	`
 
 	variables := map[string]interface{}{
-		"input": input,
+		"id": id,
 	}
 
 	var response struct {
-		IssueCreate struct {
-			Issue Issue `json:"issue"`
-		} `json:"issueCreate"`
+		Issue Issue `json:"issue"`
 	}
 
-	err := c.Execute(ctx, query, variables, &response)
-	if err != nil {
+	if err := c.Execute(ctx, query, variables, &response); err != nil {
 		return nil, err
 	}
-
-	return &response.IssueCreate.Issue, nil
+	return &response.Issue, nil
 }
 
-// GetTeam returns a single team by key
-func (c *Client) GetTeam(ctx context.Context, key string) (*Team, error) {
+// SubscribeToIssue subscribes a user to an issue's notifications, or the
+// viewer when userID is empty
+func (c *Client) SubscribeToIssue(ctx context.Context, id, userID string) error {
 	query := `
-		query Team($key: String!) {
-			team(id: $key) {
-				id
-				key
-				name
-				description
-				private
-				issueCount
+		mutation IssueSubscribe($id: String!, $userId: String) {
+			issueSubscribe(id: $id, userId: $userId) {
+				success
 			}
 		}
 	`
 
 	variables := map[string]interface{}{
-		"key": key,
+		"id": id,
+	}
+	if userID != "" {
+		variables["userId"] = userID
 	}
 
 	var response struct {
-		Team Team `json:"team"`
+		IssueSubscribe struct {
+			Success bool `json:"success"`
+		} `json:"issueSubscribe"`
 	}
 
-	err := c.Execute(ctx, query, variables, &response)
-	if err != nil {
-		return nil, err
+	if err := c.Execute(ctx, query, variables, &response); err != nil {
+		return err
 	}
-
-	return &response.Team, nil
-}
-
-// Comment represents a Linear comment
-type Comment struct {
-	ID        string     `json:"id"`
-	Body      string     `json:"body"`
-	CreatedAt time.Time  `json:"createdAt"`
-	UpdatedAt time.Time  `json:"updatedAt"`
-	EditedAt  *time.Time `json:"editedAt"`
-	User      *User      `json:"user"`
-	Parent    *Comment   `json:"parent"`
-	Children  *Comments  `json:"children"`
+	if !response.IssueSubscribe.Success {
+		return fmt.Errorf("subscription was not added")
+	}
+	return nil
 }
 
-// Comments represents a paginated list of comments
-type Comments struct {
-	Nodes    []Comment `json:"nodes"`
+// UnsubscribeFromIssue unsubscribes a user from an issue's notifications, or
+// the viewer when userID is empty
+func (c *Client) UnsubscribeFromIssue(ctx context.Context, id, userID string) error {
+	query := `
+		mutation IssueUnsubscribe($id: String!, $userId: String) {
+			issueUnsubscribe(id: $id, userId: $userId) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+	if userID != "" {
+		variables["userId"] = userID
+	}
+
+	var response struct {
+		IssueUnsubscribe struct {
+			Success bool `json:"success"`
+		} `json:"issueUnsubscribe"`
+	}
+
+	if err := c.Execute(ctx, query, variables, &response); err != nil {
+		return err
+	}
+	if !response.IssueUnsubscribe.Success {
+		return fmt.Errorf("subscription was not removed")
+	}
+	return nil
+}
+
+// ArchiveIssue archives an issue
+func (c *Client) ArchiveIssue(ctx context.Context, id string) error {
+	query := `
+		mutation ArchiveIssue($id: String!) {
+			issueArchive(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		IssueArchive struct {
+			Success bool `json:"success"`
+		} `json:"issueArchive"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.IssueArchive.Success {
+		return fmt.Errorf("issue was not archived")
+	}
+
+	return nil
+}
+
+// UnarchiveIssue restores an archived issue, or one in the trash
+func (c *Client) UnarchiveIssue(ctx context.Context, id string) error {
+	query := `
+		mutation UnarchiveIssue($id: String!) {
+			issueUnarchive(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		IssueUnarchive struct {
+			Success bool `json:"success"`
+		} `json:"issueUnarchive"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.IssueUnarchive.Success {
+		return fmt.Errorf("issue was not unarchived")
+	}
+
+	return nil
+}
+
+// DeleteIssue moves an issue to the trash, from which UnarchiveIssue restores it
+// until it is permanently deleted
+func (c *Client) DeleteIssue(ctx context.Context, id string) error {
+	query := `
+		mutation DeleteIssue($id: String!) {
+			issueDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		IssueDelete struct {
+			Success bool `json:"success"`
+		} `json:"issueDelete"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.IssueDelete.Success {
+		return fmt.Errorf("issue was not deleted")
+	}
+
+	return nil
+}
+
+// CreateIssue creates a new issue
+func (c *Client) CreateIssue(ctx context.Context, input map[string]interface{}) (*Issue, error) {
+	query := `
+		mutation CreateIssue($input: IssueCreateInput!) {
+			issueCreate(input: $input) {
+				issue {
+					id
+					identifier
+					title
+					description
+					priority
+					estimate
+					createdAt
+					updatedAt
+					dueDate
+					state {
+						id
+						name
+						type
+						color
+					}
+					assignee {
+						id
+						name
+						email
+					}
+					team {
+						id
+						key
+						name
+					}
+					labels {
+						nodes {
+							id
+							name
+							color
+						}
+					}
+					cycle {
+						id
+						number
+						name
+					}
+					parent {
+						id
+						identifier
+						title
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		IssueCreate struct {
+			Issue Issue `json:"issue"`
+		} `json:"issueCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.IssueCreate.Issue, nil
+}
+
+// GetTeam returns a single team by key
+func (c *Client) GetTeam(ctx context.Context, key string) (*Team, error) {
+	query := `
+		query Team($key: String!) {
+			team(id: $key) {
+				id
+				key
+				name
+				description
+				private
+				issueCount
+				issueEstimationType
+				issueEstimationExtended
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"key": key,
+	}
+
+	var response struct {
+		Team Team `json:"team"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Team, nil
+}
+
+// Comment represents a Linear comment
+type Comment struct {
+	ID        string     `json:"id"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	EditedAt  *time.Time `json:"editedAt"`
+	User      *User      `json:"user"`
+	Parent    *Comment   `json:"parent"`
+	Children  *Comments  `json:"children"`
+	Issue     *Issue     `json:"issue,omitempty"`
+	Reactions []Reaction `json:"reactions,omitempty"`
+}
+
+// Comments represents a paginated list of comments
+type Comments struct {
+	Nodes    []Comment `json:"nodes"`
 	PageInfo PageInfo  `json:"pageInfo"`
 }
 
@@ -1303,7 +1702,7 @@ func (c *Client) GetTeamStates(ctx context.Context, teamKey string) ([]WorkflowS
 	query := `
 		query TeamStates($key: String!) {
 			team(id: $key) {
-				states {
+				states(first: 100) {
 					nodes {
 						id
 						name
@@ -1387,6 +1786,7 @@ func (c *Client) GetUsers(ctx context.Context, first int, after string, orderBy
 				nodes {
 					id
 					name
+					displayName
 					email
 					avatarUrl
 					isMe
@@ -1430,6 +1830,7 @@ func (c *Client) GetUser(ctx context.Context, email string) (*User, error) {
 			user(email: $email) {
 				id
 				name
+				displayName
 				email
 				avatarUrl
 				isMe
@@ -1455,22 +1856,50 @@ func (c *Client) GetUser(ctx context.Context, email string) (*User, error) {
 	return &response.User, nil
 }
 
+// FindUsers returns the users matching filter (a UserFilter), including inactive ones
+func (c *Client) FindUsers(ctx context.Context, filter map[string]interface{}, first int) ([]User, error) {
+	query := `
+		query FindUsers($filter: UserFilter, $first: Int) {
+			users(filter: $filter, first: $first, includeDisabled: true) {
+				nodes {
+					id
+					name
+					displayName
+					email
+					avatarUrl
+					isMe
+					active
+					admin
+					createdAt
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"filter": filter,
+		"first":  first,
+	}
+
+	var response struct {
+		Users Users `json:"users"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Users.Nodes, nil
+}
+
 // GetTeamCycles returns cycles for a specific team
 func (c *Client) GetTeamCycles(ctx context.Context, teamKey string, first int, filter map[string]interface{}) (*Cycles, error) {
 	query := `
 		query TeamCycles($teamKey: String!, $first: Int, $filter: CycleFilter) {
 			team(id: $teamKey) {
 				cycles(first: $first, filter: $filter) {
-					nodes {
-						id
-						number
-						name
-						description
-						startsAt
-						endsAt
-						progress
-						completedAt
-					}
+					nodes {` + cycleFields + `}
 					pageInfo {
 						hasNextPage
 						endCursor
@@ -1502,20 +1931,96 @@ func (c *Client) GetTeamCycles(ctx context.Context, teamKey string, first int, f
 	return &response.Team.Cycles, nil
 }
 
-// GetCycleByNumber returns a specific cycle by number within a team
-func (c *Client) GetCycleByNumber(ctx context.Context, teamKey string, cycleNumber int) (*Cycle, error) {
-	// Get cycles for the team (get a reasonable amount to search through)
-	cycles, err := c.GetTeamCycles(ctx, teamKey, 100, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// Search for the cycle with the matching number
-	for _, cycle := range cycles.Nodes {
-		if cycle.Number == cycleNumber {
-			return &cycle, nil
-		}
-	}
+// cycleFields is the selection set shared by cycle queries
+const cycleFields = `
+	id
+	number
+	name
+	description
+	startsAt
+	endsAt
+	progress
+	completedAt
+	isActive
+	isNext
+	isPrevious
+	isFuture
+	isPast
+	scopeHistory
+	issueCountHistory
+	completedScopeHistory
+	completedIssueCountHistory
+	inProgressScopeHistory
+`
+
+// GetCycle returns a cycle with its team and issues
+func (c *Client) GetCycle(ctx context.Context, id string) (*Cycle, error) {
+	query := `
+		query Cycle($id: String!) {
+			cycle(id: $id) {` + cycleFields + `
+				team {
+					id
+					key
+					name
+				}
+				issues(first: 250) {
+					nodes {
+						id
+						identifier
+						title
+						priority
+						estimate
+						url
+						state {
+							id
+							name
+							type
+						}
+						assignee {
+							id
+							name
+							email
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		Cycle Cycle `json:"cycle"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Cycle, nil
+}
+
+// GetCycleByNumber returns a specific cycle by number within a team
+func (c *Client) GetCycleByNumber(ctx context.Context, teamKey string, cycleNumber int) (*Cycle, error) {
+	// Get cycles for the team (get a reasonable amount to search through)
+	cycles, err := c.GetTeamCycles(ctx, teamKey, 100, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Search for the cycle with the matching number
+	for _, cycle := range cycles.Nodes {
+		if cycle.Number == cycleNumber {
+			return &cycle, nil
+		}
+	}
 
 	return nil, nil
 }
@@ -1531,11 +2036,23 @@ func (c *Client) GetIssueComments(ctx context.Context, issueID string, first int
 						body
 						createdAt
 						updatedAt
+						editedAt
 						user {
 							id
 							name
 							email
 						}
+						parent {
+							id
+						}
+						reactions {
+							id
+							emoji
+							user {
+								id
+								name
+							}
+						}
 					}
 					pageInfo {
 						hasNextPage
@@ -1571,31 +2088,61 @@ func (c *Client) GetIssueComments(ctx context.Context, issueID string, first int
 	return &response.Issue.Comments, nil
 }
 
+// commentFields is the selection set returned by comment queries and mutations
+const commentFields = `
+	id
+	body
+	createdAt
+	updatedAt
+	editedAt
+	user {
+		id
+		name
+		email
+	}
+	parent {
+		id
+	}
+	issue {
+		id
+		identifier
+	}
+	reactions {
+		id
+		emoji
+		user {
+			id
+			name
+		}
+	}
+`
+
 // CreateComment creates a new comment on an issue
 func (c *Client) CreateComment(ctx context.Context, issueID string, body string) (*Comment, error) {
+	return c.createComment(ctx, map[string]interface{}{
+		"issueId": issueID,
+		"body":    body,
+	})
+}
+
+// CreateReply creates a reply to an existing comment
+func (c *Client) CreateReply(ctx context.Context, issueID string, parentID string, body string) (*Comment, error) {
+	return c.createComment(ctx, map[string]interface{}{
+		"issueId":  issueID,
+		"parentId": parentID,
+		"body":     body,
+	})
+}
+
+func (c *Client) createComment(ctx context.Context, input map[string]interface{}) (*Comment, error) {
 	query := `
 		mutation CreateComment($input: CommentCreateInput!) {
 			commentCreate(input: $input) {
-				comment {
-					id
-					body
-					createdAt
-					updatedAt
-					user {
-						id
-						name
-						email
-					}
-				}
+				comment {` + commentFields + `}
 			}
 		}
 	`
 
-	input := map[string]interface{}{
-		"issueId": issueID,
-		"body":    body,
-	}
-
 	variables := map[string]interface{}{
 		"input": input,
 	}
@@ -1614,6 +2161,164 @@ func (c *Client) CreateComment(ctx context.Context, issueID string, body string)
 	return &response.CommentCreate.Comment, nil
 }
 
+// GetComment returns a single comment by ID, including the issue it belongs to
+func (c *Client) GetComment(ctx context.Context, id string) (*Comment, error) {
+	query := `
+		query Comment($id: String!) {
+			comment(id: $id) {` + commentFields + `}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		Comment Comment `json:"comment"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Comment, nil
+}
+
+// UpdateComment replaces the body of a comment
+func (c *Client) UpdateComment(ctx context.Context, id string, body string) (*Comment, error) {
+	query := `
+		mutation UpdateComment($id: String!, $input: CommentUpdateInput!) {
+			commentUpdate(id: $id, input: $input) {
+				comment {` + commentFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+		"input": map[string]interface{}{
+			"body": body,
+		},
+	}
+
+	var response struct {
+		CommentUpdate struct {
+			Comment Comment `json:"comment"`
+		} `json:"commentUpdate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.CommentUpdate.Comment, nil
+}
+
+// DeleteComment deletes a comment by ID
+func (c *Client) DeleteComment(ctx context.Context, id string) error {
+	query := `
+		mutation DeleteComment($id: String!) {
+			commentDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		CommentDelete struct {
+			Success bool `json:"success"`
+		} `json:"commentDelete"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.CommentDelete.Success {
+		return fmt.Errorf("comment was not deleted")
+	}
+
+	return nil
+}
+
+// CreateReaction reacts to a comment with an emoji, given by the name Linear
+// stores it under ("+1", "tada")
+func (c *Client) CreateReaction(ctx context.Context, commentID, emoji string) (*Reaction, error) {
+	query := `
+		mutation CreateReaction($input: ReactionCreateInput!) {
+			reactionCreate(input: $input) {
+				success
+				reaction {
+					id
+					emoji
+					createdAt
+					user {
+						id
+						name
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"commentId": commentID,
+			"emoji":     emoji,
+		},
+	}
+
+	var response struct {
+		ReactionCreate struct {
+			Success  bool     `json:"success"`
+			Reaction Reaction `json:"reaction"`
+		} `json:"reactionCreate"`
+	}
+
+	if err := c.Execute(ctx, query, variables, &response); err != nil {
+		return nil, err
+	}
+	if !response.ReactionCreate.Success {
+		return nil, fmt.Errorf("reaction was not added")
+	}
+	return &response.ReactionCreate.Reaction, nil
+}
+
+// DeleteReaction removes a reaction
+func (c *Client) DeleteReaction(ctx context.Context, id string) error {
+	query := `
+		mutation DeleteReaction($id: String!) {
+			reactionDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		ReactionDelete struct {
+			Success bool `json:"success"`
+		} `json:"reactionDelete"`
+	}
+
+	if err := c.Execute(ctx, query, variables, &response); err != nil {
+		return err
+	}
+	if !response.ReactionDelete.Success {
+		return fmt.Errorf("reaction was not removed")
+	}
+	return nil
+}
+
 // UploadFileHeader represents a header for file upload
 type UploadFileHeader struct {
 	Key   string `json:"key"`
@@ -1677,12 +2382,16 @@ func (c *Client) GetTeamLabels(ctx context.Context, teamKey string) ([]Label, er
 	query := `
 		query TeamLabels($key: String!) {
 			team(id: $key) {
-				labels {
+				labels(first: 250) {
 					nodes {
 						id
 						name
 						color
 						description
+						parent {
+							id
+							name
+						}
 					}
 				}
 			}
@@ -1737,3 +2446,2494 @@ func (c *Client) GetOrganizationLabels(ctx context.Context) ([]Label, error) {
 
 	return response.Organization.Labels.Nodes, nil
 }
+
+// attachmentFields is the selection set shared by attachment queries and mutations
+const attachmentFields = `
+	id
+	title
+	subtitle
+	url
+	metadata
+	createdAt
+	creator {
+		id
+		name
+		email
+	}
+`
+
+// GetIssueAttachments returns the attachments on an issue
+func (c *Client) GetIssueAttachments(ctx context.Context, issueID string) ([]Attachment, error) {
+	query := `
+		query IssueAttachments($id: String!) {
+			issue(id: $id) {
+				attachments {
+					nodes {` + attachmentFields + `}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": issueID,
+	}
+
+	var response struct {
+		Issue struct {
+			Attachments Attachments `json:"attachments"`
+		} `json:"issue"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Issue.Attachments.Nodes, nil
+}
+
+// CreateAttachment links a URL to an issue. Input accepts issueId (ID or identifier),
+// url, title, subtitle, and metadata.
+func (c *Client) CreateAttachment(ctx context.Context, input map[string]interface{}) (*Attachment, error) {
+	query := `
+		mutation CreateAttachment($input: AttachmentCreateInput!) {
+			attachmentCreate(input: $input) {
+				success
+				attachment {` + attachmentFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		AttachmentCreate struct {
+			Success    bool       `json:"success"`
+			Attachment Attachment `json:"attachment"`
+		} `json:"attachmentCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.AttachmentCreate.Success {
+		return nil, fmt.Errorf("attachment was not created")
+	}
+
+	return &response.AttachmentCreate.Attachment, nil
+}
+
+// DeleteAttachment removes an attachment by ID
+func (c *Client) DeleteAttachment(ctx context.Context, id string) error {
+	query := `
+		mutation DeleteAttachment($id: String!) {
+			attachmentDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		AttachmentDelete struct {
+			Success bool `json:"success"`
+		} `json:"attachmentDelete"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.AttachmentDelete.Success {
+		return fmt.Errorf("attachment was not deleted")
+	}
+
+	return nil
+}
+
+// Webhook is a Linear webhook subscription
+type Webhook struct {
+	ID             string    `json:"id"`
+	Label          *string   `json:"label"`
+	URL            string    `json:"url"`
+	Enabled        bool      `json:"enabled"`
+	Secret         *string   `json:"secret,omitempty"`
+	ResourceTypes  []string  `json:"resourceTypes"`
+	AllPublicTeams bool      `json:"allPublicTeams"`
+	Team           *Team     `json:"team"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// webhookFields is the selection set shared by webhook queries and mutations
+const webhookFields = `
+	id
+	label
+	url
+	enabled
+	secret
+	resourceTypes
+	allPublicTeams
+	team {
+		id
+		key
+		name
+	}
+	createdAt
+	updatedAt
+`
+
+// GetWebhooks returns the webhooks of the workspace
+func (c *Client) GetWebhooks(ctx context.Context) ([]Webhook, error) {
+	query := `
+		query Webhooks {
+			webhooks(first: 250) {
+				nodes {` + webhookFields + `}
+			}
+		}
+	`
+
+	var response struct {
+		Webhooks struct {
+			Nodes []Webhook `json:"nodes"`
+		} `json:"webhooks"`
+	}
+
+	err := c.Execute(ctx, query, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Webhooks.Nodes, nil
+}
+
+// CreateWebhook creates a webhook. Input accepts url, resourceTypes, teamId or
+// allPublicTeams, secret, label, and enabled.
+func (c *Client) CreateWebhook(ctx context.Context, input map[string]interface{}) (*Webhook, error) {
+	query := `
+		mutation CreateWebhook($input: WebhookCreateInput!) {
+			webhookCreate(input: $input) {
+				success
+				webhook {` + webhookFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		WebhookCreate struct {
+			Success bool    `json:"success"`
+			Webhook Webhook `json:"webhook"`
+		} `json:"webhookCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.WebhookCreate.Success {
+		return nil, fmt.Errorf("webhook was not created")
+	}
+
+	return &response.WebhookCreate.Webhook, nil
+}
+
+// DeleteWebhook removes a webhook by ID
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	query := `
+		mutation DeleteWebhook($id: String!) {
+			webhookDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		WebhookDelete struct {
+			Success bool `json:"success"`
+		} `json:"webhookDelete"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.WebhookDelete.Success {
+		return fmt.Errorf("webhook was not deleted")
+	}
+
+	return nil
+}
+
+// ProjectStatus is a workspace-defined project status such as "In Progress"
+type ProjectStatus struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Color    string  `json:"color"`
+	Position float64 `json:"position"`
+}
+
+// projectFields is the selection set returned by project mutations
+const projectFields = `
+	id
+	name
+	description
+	state
+	progress
+	startDate
+	targetDate
+	url
+	createdAt
+	updatedAt
+	lead {
+		id
+		name
+		email
+	}
+	teams {
+		nodes {
+			id
+			key
+			name
+		}
+	}
+	members {
+		nodes {
+			id
+			name
+			email
+		}
+	}
+`
+
+// GetProjectStatuses returns the project statuses of the workspace
+func (c *Client) GetProjectStatuses(ctx context.Context) ([]ProjectStatus, error) {
+	query := `
+		query ProjectStatuses {
+			projectStatuses {
+				nodes {
+					id
+					name
+					type
+					color
+					position
+				}
+			}
+		}
+	`
+
+	var response struct {
+		ProjectStatuses struct {
+			Nodes []ProjectStatus `json:"nodes"`
+		} `json:"projectStatuses"`
+	}
+
+	err := c.Execute(ctx, query, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ProjectStatuses.Nodes, nil
+}
+
+// CreateProject creates a project. Input accepts name, teamIds, description,
+// content, leadId, memberIds, statusId, startDate, and targetDate.
+func (c *Client) CreateProject(ctx context.Context, input map[string]interface{}) (*Project, error) {
+	query := `
+		mutation CreateProject($input: ProjectCreateInput!) {
+			projectCreate(input: $input) {
+				success
+				project {` + projectFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		ProjectCreate struct {
+			Success bool    `json:"success"`
+			Project Project `json:"project"`
+		} `json:"projectCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.ProjectCreate.Success {
+		return nil, fmt.Errorf("project was not created")
+	}
+
+	return &response.ProjectCreate.Project, nil
+}
+
+// UpdateProject updates a project; input accepts the same fields as CreateProject
+func (c *Client) UpdateProject(ctx context.Context, id string, input map[string]interface{}) (*Project, error) {
+	query := `
+		mutation UpdateProject($id: String!, $input: ProjectUpdateInput!) {
+			projectUpdate(id: $id, input: $input) {
+				success
+				project {` + projectFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    id,
+		"input": input,
+	}
+
+	var response struct {
+		ProjectUpdate struct {
+			Success bool    `json:"success"`
+			Project Project `json:"project"`
+		} `json:"projectUpdate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.ProjectUpdate.Success {
+		return nil, fmt.Errorf("project was not updated")
+	}
+
+	return &response.ProjectUpdate.Project, nil
+}
+
+// projectUpdateFields is the selection set of a project update
+const projectUpdateFields = `
+	id
+	body
+	health
+	url
+	createdAt
+	updatedAt
+	editedAt
+	user {
+		id
+		name
+		email
+	}
+`
+
+// GetProjectUpdates returns a page of a project's status updates
+func (c *Client) GetProjectUpdates(ctx context.Context, projectID string, first int, after string) (*ProjectUpdates, error) {
+	query := `
+		query ProjectUpdates($id: String!, $first: Int, $after: String) {
+			project(id: $id) {
+				projectUpdates(first: $first, after: $after) {
+					nodes {` + projectUpdateFields + `}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    projectID,
+		"first": first,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Project struct {
+			ProjectUpdates ProjectUpdates `json:"projectUpdates"`
+		} `json:"project"`
+	}
+
+	if err := c.Execute(ctx, query, variables, &response); err != nil {
+		return nil, err
+	}
+	return &response.Project.ProjectUpdates, nil
+}
+
+// CreateProjectUpdate posts a status update to a project. Input takes
+// projectId, body (markdown), and health (onTrack, atRisk, or offTrack).
+func (c *Client) CreateProjectUpdate(ctx context.Context, input map[string]interface{}) (*ProjectUpdate, error) {
+	query := `
+		mutation CreateProjectUpdate($input: ProjectUpdateCreateInput!) {
+			projectUpdateCreate(input: $input) {
+				success
+				projectUpdate {` + projectUpdateFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		ProjectUpdateCreate struct {
+			Success       bool          `json:"success"`
+			ProjectUpdate ProjectUpdate `json:"projectUpdate"`
+		} `json:"projectUpdateCreate"`
+	}
+
+	if err := c.Execute(ctx, query, variables, &response); err != nil {
+		return nil, err
+	}
+	if !response.ProjectUpdateCreate.Success {
+		return nil, fmt.Errorf("project update was not posted")
+	}
+	return &response.ProjectUpdateCreate.ProjectUpdate, nil
+}
+
+// ArchiveProject archives a project
+func (c *Client) ArchiveProject(ctx context.Context, id string) error {
+	query := `
+		mutation ArchiveProject($id: String!) {
+			projectArchive(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		ProjectArchive struct {
+			Success bool `json:"success"`
+		} `json:"projectArchive"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.ProjectArchive.Success {
+		return fmt.Errorf("project was not archived")
+	}
+
+	return nil
+}
+
+// UnarchiveProject restores an archived project
+func (c *Client) UnarchiveProject(ctx context.Context, id string) error {
+	query := `
+		mutation UnarchiveProject($id: String!) {
+			projectUnarchive(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		ProjectUnarchive struct {
+			Success bool `json:"success"`
+		} `json:"projectUnarchive"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.ProjectUnarchive.Success {
+		return fmt.Errorf("project was not unarchived")
+	}
+
+	return nil
+}
+
+// labelFields is the selection set shared by label queries and mutations
+const labelFields = `
+	id
+	name
+	color
+	description
+	parent {
+		id
+		name
+	}
+	team {
+		id
+		key
+		name
+	}
+`
+
+// GetLabels returns workspace and team labels matching filter (an IssueLabelFilter)
+func (c *Client) GetLabels(ctx context.Context, filter map[string]interface{}) ([]Label, error) {
+	query := `
+		query Labels($filter: IssueLabelFilter) {
+			issueLabels(filter: $filter, first: 250) {
+				nodes {` + labelFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{}
+	if filter != nil {
+		variables["filter"] = filter
+	}
+
+	var response struct {
+		IssueLabels Labels `json:"issueLabels"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.IssueLabels.Nodes, nil
+}
+
+// CreateLabel creates a label. Input accepts name, color, description, teamId
+// (omit for a workspace label), and parentId.
+func (c *Client) CreateLabel(ctx context.Context, input map[string]interface{}) (*Label, error) {
+	query := `
+		mutation CreateLabel($input: IssueLabelCreateInput!) {
+			issueLabelCreate(input: $input) {
+				success
+				issueLabel {` + labelFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		IssueLabelCreate struct {
+			Success    bool  `json:"success"`
+			IssueLabel Label `json:"issueLabel"`
+		} `json:"issueLabelCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.IssueLabelCreate.Success {
+		return nil, fmt.Errorf("label was not created")
+	}
+
+	return &response.IssueLabelCreate.IssueLabel, nil
+}
+
+// UpdateLabel updates a label's name, color, description, or parentId
+func (c *Client) UpdateLabel(ctx context.Context, id string, input map[string]interface{}) (*Label, error) {
+	query := `
+		mutation UpdateLabel($id: String!, $input: IssueLabelUpdateInput!) {
+			issueLabelUpdate(id: $id, input: $input) {
+				success
+				issueLabel {` + labelFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    id,
+		"input": input,
+	}
+
+	var response struct {
+		IssueLabelUpdate struct {
+			Success    bool  `json:"success"`
+			IssueLabel Label `json:"issueLabel"`
+		} `json:"issueLabelUpdate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.IssueLabelUpdate.Success {
+		return nil, fmt.Errorf("label was not updated")
+	}
+
+	return &response.IssueLabelUpdate.IssueLabel, nil
+}
+
+// DeleteLabel removes a label by ID, detaching it from all issues
+func (c *Client) DeleteLabel(ctx context.Context, id string) error {
+	query := `
+		mutation DeleteLabel($id: String!) {
+			issueLabelDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		IssueLabelDelete struct {
+			Success bool `json:"success"`
+		} `json:"issueLabelDelete"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.IssueLabelDelete.Success {
+		return fmt.Errorf("label was not deleted")
+	}
+
+	return nil
+}
+
+// relationIssueFields is the issue summary selected on both ends of a relation
+const relationIssueFields = `
+	id
+	identifier
+	title
+	state {
+		name
+		type
+	}
+`
+
+// relationFields is the selection set shared by issue relation queries and mutations
+const relationFields = `
+	id
+	type
+	issue {` + relationIssueFields + `}
+	relatedIssue {` + relationIssueFields + `}
+`
+
+// GetIssueRelations returns an issue together with its relations (where the
+// issue is the source, e.g. "blocks") and inverse relations (where it is the
+// target, e.g. "blocked by")
+func (c *Client) GetIssueRelations(ctx context.Context, id string) (*Issue, []IssueRelation, error) {
+	query := `
+		query IssueRelations($id: String!) {
+			issue(id: $id) {` + relationIssueFields + `
+				relations(first: 100) {
+					nodes {` + relationFields + `}
+				}
+				inverseRelations(first: 100) {
+					nodes {` + relationFields + `}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		Issue struct {
+			Issue
+			InverseRelations IssueRelations `json:"inverseRelations"`
+		} `json:"issue"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issue := response.Issue.Issue
+	return &issue, response.Issue.InverseRelations.Nodes, nil
+}
+
+// CreateIssueRelation relates two issues by ID. relationType is blocks,
+// duplicate, related, or similar, read as "issue <type> relatedIssue".
+func (c *Client) CreateIssueRelation(ctx context.Context, issueID, relatedIssueID, relationType string) (*IssueRelation, error) {
+	query := `
+		mutation CreateIssueRelation($input: IssueRelationCreateInput!) {
+			issueRelationCreate(input: $input) {
+				success
+				issueRelation {` + relationFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"issueId":        issueID,
+			"relatedIssueId": relatedIssueID,
+			"type":           relationType,
+		},
+	}
+
+	var response struct {
+		IssueRelationCreate struct {
+			Success       bool          `json:"success"`
+			IssueRelation IssueRelation `json:"issueRelation"`
+		} `json:"issueRelationCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.IssueRelationCreate.Success {
+		return nil, fmt.Errorf("relation was not created")
+	}
+
+	return &response.IssueRelationCreate.IssueRelation, nil
+}
+
+// DeleteIssueRelation removes a relation by ID
+func (c *Client) DeleteIssueRelation(ctx context.Context, id string) error {
+	query := `
+		mutation DeleteIssueRelation($id: String!) {
+			issueRelationDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		IssueRelationDelete struct {
+			Success bool `json:"success"`
+		} `json:"issueRelationDelete"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.IssueRelationDelete.Success {
+		return fmt.Errorf("relation was not deleted")
+	}
+
+	return nil
+}
+
+// treeIssueFields is the issue summary selected for sub-issue trees
+const treeIssueFields = `
+	id
+	identifier
+	title
+	priority
+	estimate
+	subIssueSortOrder
+	url
+	state {
+		name
+		type
+		color
+	}
+	assignee {
+		id
+		name
+		email
+	}
+	team {
+		id
+		key
+	}
+`
+
+// GetIssueTreeNode returns an issue with its parent and direct children. Each
+// child carries at most one of its own children, enough to tell leaves apart.
+func (c *Client) GetIssueTreeNode(ctx context.Context, id string) (*Issue, error) {
+	query := `
+		query IssueTreeNode($id: String!) {
+			issue(id: $id) {` + treeIssueFields + `
+				parent {
+					id
+					identifier
+					title
+				}
+				children(first: 250) {
+					nodes {` + treeIssueFields + `
+						children(first: 1) {
+							nodes {
+								id
+							}
+						}
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		Issue Issue `json:"issue"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Issue, nil
+}
+
+// templateFields is the selection set for templates
+const templateFields = `
+	id
+	name
+	description
+	type
+	templateData
+	team {
+		id
+		key
+		name
+	}
+`
+
+// GetTemplates returns the workspace's templates of every type
+func (c *Client) GetTemplates(ctx context.Context) ([]Template, error) {
+	query := `
+		query Templates {
+			templates {` + templateFields + `
+			}
+		}
+	`
+
+	var response struct {
+		Templates []Template `json:"templates"`
+	}
+
+	err := c.Execute(ctx, query, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Templates, nil
+}
+
+// GetTemplate returns a single template by ID
+func (c *Client) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	query := `
+		query Template($id: String!) {
+			template(id: $id) {` + templateFields + `
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		Template Template `json:"template"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Template, nil
+}
+
+// historyFields is the selection set for issue history entries
+const historyFields = `
+	id
+	createdAt
+	updatedAt
+	actor {
+		id
+		name
+		email
+	}
+	fromAssignee {
+		id
+		name
+	}
+	toAssignee {
+		id
+		name
+	}
+	fromState {
+		id
+		name
+		type
+	}
+	toState {
+		id
+		name
+		type
+	}
+	fromPriority
+	toPriority
+	fromTitle
+	toTitle
+	fromCycle {
+		id
+		number
+		name
+	}
+	toCycle {
+		id
+		number
+		name
+	}
+	fromProject {
+		id
+		name
+	}
+	toProject {
+		id
+		name
+	}
+	fromEstimate
+	toEstimate
+	fromDueDate
+	toDueDate
+	fromParent {
+		id
+		identifier
+	}
+	toParent {
+		id
+		identifier
+	}
+	addedLabelIds
+	removedLabelIds
+	addedLabels {
+		id
+		name
+	}
+	removedLabels {
+		id
+		name
+	}
+	updatedDescription
+	archived
+`
+
+// exportIssueFields is the selection set for a full issue backup. Comments and
+// history are capped per issue; callers page through the rest when
+// pageInfo.hasNextPage is set.
+const exportIssueFields = `
+	id
+	identifier
+	number
+	title
+	description
+	priority
+	estimate
+	createdAt
+	updatedAt
+	dueDate
+	url
+	branchName
+	completedAt
+	canceledAt
+	archivedAt
+	state {
+		id
+		name
+		type
+	}
+	assignee {
+		id
+		name
+		email
+	}
+	creator {
+		id
+		name
+		email
+	}
+	team {
+		id
+		key
+		name
+	}
+	labels {
+		nodes {
+			id
+			name
+		}
+	}
+	parent {
+		id
+		identifier
+	}
+	project {
+		id
+		name
+	}
+	cycle {
+		id
+		number
+		name
+	}
+	attachments(first: 50) {
+		nodes {
+			id
+			title
+			subtitle
+			url
+			createdAt
+		}
+	}
+	comments(first: 50) {
+		nodes {
+			id
+			body
+			createdAt
+			updatedAt
+			editedAt
+			user {
+				id
+				name
+				email
+			}
+			parent {
+				id
+			}
+		}
+		pageInfo {
+			hasNextPage
+			endCursor
+		}
+	}
+	history(first: 50) {
+		nodes {` + historyFields + `}
+		pageInfo {
+			hasNextPage
+			endCursor
+		}
+	}
+`
+
+// GetExportIssues returns a page of issues with their comments, history, and
+// attachments, oldest first
+func (c *Client) GetExportIssues(ctx context.Context, filter map[string]interface{}, first int, after string, includeArchived bool) (*Issues, error) {
+	query := `
+		query ExportIssues($filter: IssueFilter, $first: Int, $after: String, $includeArchived: Boolean) {
+			issues(filter: $filter, first: $first, after: $after, includeArchived: $includeArchived, orderBy: createdAt) {
+				nodes {` + exportIssueFields + `}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first":           first,
+		"includeArchived": includeArchived,
+	}
+	if filter != nil {
+		variables["filter"] = filter
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Issues Issues `json:"issues"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Issues, nil
+}
+
+// GetIssueHistory returns a page of an issue's history, oldest first
+func (c *Client) GetIssueHistory(ctx context.Context, issueID string, first int, after string) (*IssueHistory, error) {
+	query := `
+		query IssueHistory($id: String!, $first: Int, $after: String) {
+			issue(id: $id) {
+				history(first: $first, after: $after) {
+					nodes {` + historyFields + `}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    issueID,
+		"first": first,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Issue struct {
+			History IssueHistory `json:"history"`
+		} `json:"issue"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Issue.History, nil
+}
+
+// GetCycles returns a page of cycles across all teams
+func (c *Client) GetCycles(ctx context.Context, first int, after string) (*Cycles, error) {
+	query := `
+		query Cycles($first: Int, $after: String) {
+			cycles(first: $first, after: $after) {
+				nodes {` + cycleFields + `
+					team {
+						id
+						key
+						name
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Cycles Cycles `json:"cycles"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Cycles, nil
+}
+
+// GetLabelsPage returns a page of workspace and team labels
+func (c *Client) GetLabelsPage(ctx context.Context, first int, after string) ([]Label, PageInfo, error) {
+	query := `
+		query LabelsPage($first: Int, $after: String) {
+			issueLabels(first: $first, after: $after) {
+				nodes {` + labelFields + `}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		IssueLabels struct {
+			Nodes    []Label  `json:"nodes"`
+			PageInfo PageInfo `json:"pageInfo"`
+		} `json:"issueLabels"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	return response.IssueLabels.Nodes, response.IssueLabels.PageInfo, nil
+}
+
+// GetSyncIssues returns a page of issues, archived ones included, ordered by
+// updatedAt, with the fields the local cache needs to answer list and search
+func (c *Client) GetSyncIssues(ctx context.Context, filter map[string]interface{}, first int, after string) (*Issues, error) {
+	query := `
+		query SyncIssues($filter: IssueFilter, $first: Int, $after: String) {
+			issues(filter: $filter, first: $first, after: $after, orderBy: updatedAt, includeArchived: true) {
+				nodes {
+					id
+					identifier
+					title
+					description
+					priority
+					estimate
+					createdAt
+					updatedAt
+					completedAt
+					archivedAt
+					dueDate
+					slaStartedAt
+					slaBreachesAt
+					url
+					state {
+						id
+						name
+						type
+						color
+					}
+					assignee {
+						id
+						name
+						displayName
+						email
+					}
+					creator {
+						id
+						name
+						displayName
+						email
+					}
+					team {
+						id
+						key
+						name
+					}
+					labels {
+						nodes {
+							id
+							name
+							color
+						}
+					}
+					parent {
+						id
+						identifier
+						title
+					}
+					project {
+						id
+						name
+					}
+					cycle {
+						id
+						number
+						name
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if filter != nil {
+		variables["filter"] = filter
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Issues Issues `json:"issues"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Issues, nil
+}
+
+// Organization represents the Linear workspace
+type Organization struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	URLKey string `json:"urlKey"`
+}
+
+// GetOrganization returns the authenticated user's workspace
+func (c *Client) GetOrganization(ctx context.Context) (*Organization, error) {
+	query := `
+		query Organization {
+			organization {
+				id
+				name
+				urlKey
+			}
+		}
+	`
+
+	var response struct {
+		Organization Organization `json:"organization"`
+	}
+
+	err := c.Execute(ctx, query, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Organization, nil
+}
+
+// Notification is an entry in the user's inbox. Issue and Comment are set for
+// issue notifications.
+type Notification struct {
+	ID             string     `json:"id"`
+	Type           string     `json:"type"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	ReadAt         *time.Time `json:"readAt"`
+	ArchivedAt     *time.Time `json:"archivedAt"`
+	SnoozedUntilAt *time.Time `json:"snoozedUntilAt"`
+	Actor          *User      `json:"actor"`
+	Issue          *Issue     `json:"issue,omitempty"`
+	Comment        *Comment   `json:"comment,omitempty"`
+}
+
+// Notifications is a page of inbox notifications
+type Notifications struct {
+	Nodes    []Notification `json:"nodes"`
+	PageInfo PageInfo       `json:"pageInfo"`
+}
+
+// GetNotifications returns a page of the user's inbox notifications
+func (c *Client) GetNotifications(ctx context.Context, first int, after string, includeArchived bool) (*Notifications, error) {
+	query := `
+		query Notifications($first: Int, $after: String, $includeArchived: Boolean) {
+			notifications(first: $first, after: $after, includeArchived: $includeArchived) {
+				nodes {
+					id
+					type
+					createdAt
+					readAt
+					archivedAt
+					snoozedUntilAt
+					actor {
+						id
+						name
+						email
+					}
+					... on IssueNotification {
+						issue {
+							id
+							identifier
+							title
+							url
+							state {
+								id
+								name
+								type
+							}
+						}
+						comment {
+							id
+							body
+						}
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first":           first,
+		"includeArchived": includeArchived,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Notifications Notifications `json:"notifications"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Notifications, nil
+}
+
+// UpdateNotification updates a notification. Input accepts readAt (a
+// timestamp, or nil to mark it unread) and snoozedUntilAt.
+func (c *Client) UpdateNotification(ctx context.Context, id string, input map[string]interface{}) error {
+	query := `
+		mutation UpdateNotification($id: String!, $input: NotificationUpdateInput!) {
+			notificationUpdate(id: $id, input: $input) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    id,
+		"input": input,
+	}
+
+	var response struct {
+		NotificationUpdate struct {
+			Success bool `json:"success"`
+		} `json:"notificationUpdate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.NotificationUpdate.Success {
+		return fmt.Errorf("notification was not updated")
+	}
+
+	return nil
+}
+
+// ArchiveNotification archives a notification, removing it from the inbox
+func (c *Client) ArchiveNotification(ctx context.Context, id string) error {
+	query := `
+		mutation ArchiveNotification($id: String!) {
+			notificationArchive(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		NotificationArchive struct {
+			Success bool `json:"success"`
+		} `json:"notificationArchive"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.NotificationArchive.Success {
+		return fmt.Errorf("notification was not archived")
+	}
+
+	return nil
+}
+
+// UnarchiveNotification restores an archived notification to the inbox
+func (c *Client) UnarchiveNotification(ctx context.Context, id string) error {
+	query := `
+		mutation UnarchiveNotification($id: String!) {
+			notificationUnarchive(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		NotificationUnarchive struct {
+			Success bool `json:"success"`
+		} `json:"notificationUnarchive"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.NotificationUnarchive.Success {
+		return fmt.Errorf("notification was not unarchived")
+	}
+
+	return nil
+}
+
+// initiativeFields is the selection set shared by initiative queries and
+// mutations, including the projects the roadmap lays out
+const initiativeFields = `
+	id
+	name
+	description
+	content
+	status
+	health
+	targetDate
+	url
+	createdAt
+	updatedAt
+	completedAt
+	owner {
+		id
+		name
+		email
+	}
+	projects(first: 100) {
+		nodes {
+			id
+			name
+			state
+			progress
+			health
+			startDate
+			targetDate
+			createdAt
+			completedAt
+			url
+			lead {
+				id
+				name
+			}
+		}
+	}
+`
+
+// GetInitiatives returns a page of initiatives with their projects
+func (c *Client) GetInitiatives(ctx context.Context, filter map[string]interface{}, first int, after string) (*Initiatives, error) {
+	query := `
+		query Initiatives($filter: InitiativeFilter, $first: Int, $after: String) {
+			initiatives(filter: $filter, first: $first, after: $after) {
+				nodes {` + initiativeFields + `}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if filter != nil {
+		variables["filter"] = filter
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Initiatives Initiatives `json:"initiatives"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Initiatives, nil
+}
+
+// GetInitiative returns a single initiative by ID, with its projects
+func (c *Client) GetInitiative(ctx context.Context, id string) (*Initiative, error) {
+	query := `
+		query Initiative($id: String!) {
+			initiative(id: $id) {` + initiativeFields + `}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		Initiative Initiative `json:"initiative"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Initiative, nil
+}
+
+// initiativeHealthFields selects an initiative with its projects and each
+// project's latest status update
+const initiativeHealthFields = `
+	id
+	name
+	status
+	health
+	targetDate
+	url
+	owner {
+		id
+		name
+	}
+	projects(first: 100) {
+		nodes {
+			id
+			name
+			state
+			progress
+			health
+			startDate
+			targetDate
+			completedAt
+			canceledAt
+			url
+			lead {
+				id
+				name
+			}
+			projectUpdates(first: 1) {
+				nodes {` + projectUpdateFields + `}
+			}
+		}
+	}
+`
+
+// GetInitiativesHealth returns a page of initiatives with their projects'
+// progress, health, and latest status update
+func (c *Client) GetInitiativesHealth(ctx context.Context, first int, after string) (*Initiatives, error) {
+	query := `
+		query InitiativesHealth($first: Int, $after: String) {
+			initiatives(first: $first, after: $after) {
+				nodes {` + initiativeHealthFields + `}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Initiatives Initiatives `json:"initiatives"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Initiatives, nil
+}
+
+// CreateInitiative creates an initiative. Input accepts name, description,
+// content, ownerId, status, and targetDate.
+func (c *Client) CreateInitiative(ctx context.Context, input map[string]interface{}) (*Initiative, error) {
+	query := `
+		mutation CreateInitiative($input: InitiativeCreateInput!) {
+			initiativeCreate(input: $input) {
+				success
+				initiative {` + initiativeFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		InitiativeCreate struct {
+			Success    bool       `json:"success"`
+			Initiative Initiative `json:"initiative"`
+		} `json:"initiativeCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.InitiativeCreate.Success {
+		return nil, fmt.Errorf("initiative was not created")
+	}
+
+	return &response.InitiativeCreate.Initiative, nil
+}
+
+// UpdateInitiative updates an initiative; input accepts the same fields as CreateInitiative
+func (c *Client) UpdateInitiative(ctx context.Context, id string, input map[string]interface{}) (*Initiative, error) {
+	query := `
+		mutation UpdateInitiative($id: String!, $input: InitiativeUpdateInput!) {
+			initiativeUpdate(id: $id, input: $input) {
+				success
+				initiative {` + initiativeFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    id,
+		"input": input,
+	}
+
+	var response struct {
+		InitiativeUpdate struct {
+			Success    bool       `json:"success"`
+			Initiative Initiative `json:"initiative"`
+		} `json:"initiativeUpdate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.InitiativeUpdate.Success {
+		return nil, fmt.Errorf("initiative was not updated")
+	}
+
+	return &response.InitiativeUpdate.Initiative, nil
+}
+
+// AddProjectToInitiative links a project to an initiative
+func (c *Client) AddProjectToInitiative(ctx context.Context, initiativeID, projectID string) error {
+	query := `
+		mutation AddProjectToInitiative($input: InitiativeToProjectCreateInput!) {
+			initiativeToProjectCreate(input: $input) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"initiativeId": initiativeID,
+			"projectId":    projectID,
+		},
+	}
+
+	var response struct {
+		InitiativeToProjectCreate struct {
+			Success bool `json:"success"`
+		} `json:"initiativeToProjectCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+	if !response.InitiativeToProjectCreate.Success {
+		return fmt.Errorf("project was not added to the initiative")
+	}
+
+	return nil
+}
+
+// milestoneFields is the selection set shared by milestone queries and mutations
+const milestoneFields = `
+	id
+	name
+	description
+	targetDate
+	progress
+	sortOrder
+	project {
+		id
+		name
+	}
+`
+
+// GetProjectMilestones returns a project's milestones
+func (c *Client) GetProjectMilestones(ctx context.Context, projectID string) ([]Milestone, error) {
+	query := `
+		query ProjectMilestones($id: String!) {
+			project(id: $id) {
+				projectMilestones(first: 250) {
+					nodes {` + milestoneFields + `}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": projectID,
+	}
+
+	var response struct {
+		Project struct {
+			ProjectMilestones Milestones `json:"projectMilestones"`
+		} `json:"project"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Project.ProjectMilestones.Nodes, nil
+}
+
+// CreateMilestone creates a project milestone. Input accepts projectId, name,
+// description, and targetDate.
+func (c *Client) CreateMilestone(ctx context.Context, input map[string]interface{}) (*Milestone, error) {
+	query := `
+		mutation CreateMilestone($input: ProjectMilestoneCreateInput!) {
+			projectMilestoneCreate(input: $input) {
+				success
+				projectMilestone {` + milestoneFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		ProjectMilestoneCreate struct {
+			Success          bool      `json:"success"`
+			ProjectMilestone Milestone `json:"projectMilestone"`
+		} `json:"projectMilestoneCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.ProjectMilestoneCreate.Success {
+		return nil, fmt.Errorf("milestone was not created")
+	}
+
+	return &response.ProjectMilestoneCreate.ProjectMilestone, nil
+}
+
+// UpdateMilestone updates a project milestone; input accepts name,
+// description, and targetDate
+func (c *Client) UpdateMilestone(ctx context.Context, id string, input map[string]interface{}) (*Milestone, error) {
+	query := `
+		mutation UpdateMilestone($id: String!, $input: ProjectMilestoneUpdateInput!) {
+			projectMilestoneUpdate(id: $id, input: $input) {
+				success
+				projectMilestone {` + milestoneFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    id,
+		"input": input,
+	}
+
+	var response struct {
+		ProjectMilestoneUpdate struct {
+			Success          bool      `json:"success"`
+			ProjectMilestone Milestone `json:"projectMilestone"`
+		} `json:"projectMilestoneUpdate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.ProjectMilestoneUpdate.Success {
+		return nil, fmt.Errorf("milestone was not updated")
+	}
+
+	return &response.ProjectMilestoneUpdate.ProjectMilestone, nil
+}
+
+// documentFields is the selection set shared by document queries and mutations
+const documentFields = `
+	id
+	slugId
+	title
+	icon
+	color
+	url
+	createdAt
+	updatedAt
+	creator {
+		id
+		name
+		email
+	}
+	updatedBy {
+		id
+		name
+		email
+	}
+	project {
+		id
+		name
+	}
+`
+
+// GetDocuments returns a page of documents, without their content
+func (c *Client) GetDocuments(ctx context.Context, filter map[string]interface{}, first int, after string) (*Documents, error) {
+	query := `
+		query Documents($filter: DocumentFilter, $first: Int, $after: String) {
+			documents(filter: $filter, first: $first, after: $after, orderBy: updatedAt) {
+				nodes {` + documentFields + `}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if filter != nil {
+		variables["filter"] = filter
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Documents Documents `json:"documents"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Documents, nil
+}
+
+// GetDocument returns a document with its content, by ID or slug ID
+func (c *Client) GetDocument(ctx context.Context, id string) (*Document, error) {
+	query := `
+		query Document($id: String!) {
+			document(id: $id) {` + documentFields + `
+				content
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		Document Document `json:"document"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Document, nil
+}
+
+// CreateDocument creates a document. Input accepts title, content, icon, and
+// projectId.
+func (c *Client) CreateDocument(ctx context.Context, input map[string]interface{}) (*Document, error) {
+	query := `
+		mutation CreateDocument($input: DocumentCreateInput!) {
+			documentCreate(input: $input) {
+				success
+				document {` + documentFields + `
+					content
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		DocumentCreate struct {
+			Success  bool     `json:"success"`
+			Document Document `json:"document"`
+		} `json:"documentCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.DocumentCreate.Success {
+		return nil, fmt.Errorf("document was not created")
+	}
+
+	return &response.DocumentCreate.Document, nil
+}
+
+// UpdateDocument updates a document; input accepts the same fields as CreateDocument
+func (c *Client) UpdateDocument(ctx context.Context, id string, input map[string]interface{}) (*Document, error) {
+	query := `
+		mutation UpdateDocument($id: String!, $input: DocumentUpdateInput!) {
+			documentUpdate(id: $id, input: $input) {
+				success
+				document {` + documentFields + `
+					content
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    id,
+		"input": input,
+	}
+
+	var response struct {
+		DocumentUpdate struct {
+			Success  bool     `json:"success"`
+			Document Document `json:"document"`
+		} `json:"documentUpdate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.DocumentUpdate.Success {
+		return nil, fmt.Errorf("document was not updated")
+	}
+
+	return &response.DocumentUpdate.Document, nil
+}
+
+// customerFields is the selection set shared by customer queries
+const customerFields = `
+	id
+	name
+	domains
+	revenue
+	size
+	approximateNeedCount
+	tier {
+		id
+		name
+	}
+	status {
+		id
+		name
+	}
+`
+
+// customerNeedFields is the selection set shared by customer request queries and mutations
+const customerNeedFields = `
+	id
+	body
+	priority
+	createdAt
+	customer {` + customerFields + `}
+	issue {
+		id
+		identifier
+		title
+		url
+		state {
+			name
+			type
+		}
+	}
+	creator {
+		id
+		name
+		email
+	}
+`
+
+// GetCustomers returns a page of customers
+func (c *Client) GetCustomers(ctx context.Context, first int, after string) (*Customers, error) {
+	query := `
+		query Customers($first: Int, $after: String) {
+			customers(first: $first, after: $after) {
+				nodes {` + customerFields + `}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Customers Customers `json:"customers"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Customers, nil
+}
+
+// GetCustomerNeeds returns a page of customer requests matching filter
+func (c *Client) GetCustomerNeeds(ctx context.Context, filter map[string]interface{}, first int, after string) (*CustomerNeeds, error) {
+	query := `
+		query CustomerNeeds($filter: CustomerNeedFilter, $first: Int, $after: String) {
+			customerNeeds(filter: $filter, first: $first, after: $after) {
+				nodes {` + customerNeedFields + `}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if filter != nil {
+		variables["filter"] = filter
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		CustomerNeeds CustomerNeeds `json:"customerNeeds"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.CustomerNeeds, nil
+}
+
+// CreateCustomerNeed records a customer request. Input accepts customerId,
+// issueId, body, priority (1 for important), and attachmentUrl.
+func (c *Client) CreateCustomerNeed(ctx context.Context, input map[string]interface{}) (*CustomerNeed, error) {
+	query := `
+		mutation CreateCustomerNeed($input: CustomerNeedCreateInput!) {
+			customerNeedCreate(input: $input) {
+				success
+				need {` + customerNeedFields + `}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		CustomerNeedCreate struct {
+			Success bool         `json:"success"`
+			Need    CustomerNeed `json:"need"`
+		} `json:"customerNeedCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+	if !response.CustomerNeedCreate.Success {
+		return nil, fmt.Errorf("customer request was not created")
+	}
+
+	return &response.CustomerNeedCreate.Need, nil
+}
+
+// reportIssueFields is the selection set for issues in cycle and lead-time reports
+const reportIssueFields = `
+	id
+	identifier
+	title
+	priority
+	estimate
+	createdAt
+	startedAt
+	completedAt
+	canceledAt
+	state {
+		id
+		name
+		type
+	}
+	assignee {
+		id
+		name
+		email
+	}
+	team {
+		id
+		key
+		name
+	}
+`
+
+// GetCycleReport returns a cycle with the timings of its issues and, once the
+// cycle has closed, the issues it left unfinished
+func (c *Client) GetCycleReport(ctx context.Context, id string) (*Cycle, error) {
+	query := `
+		query CycleReport($id: String!) {
+			cycle(id: $id) {` + cycleFields + `
+				team {
+					id
+					key
+					name
+				}
+				issues(first: 250) {
+					nodes {` + reportIssueFields + `}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+				uncompletedIssuesUponClose(first: 250) {
+					nodes {` + reportIssueFields + `}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		Cycle Cycle `json:"cycle"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Cycle, nil
+}
+
+// GetIssueTimings returns a page of issues with the state changes in their
+// history and their labels, for lead and cycle time, time-in-state, and
+// estimate reports
+func (c *Client) GetIssueTimings(ctx context.Context, filter map[string]interface{}, first int, after string) (*Issues, error) {
+	query := `
+		query IssueTimings($filter: IssueFilter, $first: Int, $after: String) {
+			issues(filter: $filter, first: $first, after: $after) {
+				nodes {` + reportIssueFields + `
+					labels {
+						nodes {
+							id
+							name
+						}
+					}
+					history(first: 50) {
+						nodes {
+							createdAt
+							fromState {
+								id
+								name
+								type
+							}
+							toState {
+								id
+								name
+								type
+							}
+						}
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if filter != nil {
+		variables["filter"] = filter
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Issues Issues `json:"issues"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Issues, nil
+}
+
+// GetIssueActivity returns a page of issues with the state changes in their
+// history and who made them, for activity summaries
+func (c *Client) GetIssueActivity(ctx context.Context, filter map[string]interface{}, first int, after string) (*Issues, error) {
+	query := `
+		query IssueActivity($filter: IssueFilter, $first: Int, $after: String) {
+			issues(filter: $filter, first: $first, after: $after) {
+				nodes {
+					id
+					identifier
+					title
+					url
+					priority
+					updatedAt
+					completedAt
+					state {
+						id
+						name
+						type
+					}
+					project {
+						id
+						name
+					}
+					history(first: 50) {
+						nodes {
+							createdAt
+							actor {
+								id
+								name
+							}
+							fromState {
+								id
+								name
+								type
+							}
+							toState {
+								id
+								name
+								type
+							}
+						}
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if filter != nil {
+		variables["filter"] = filter
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Issues Issues `json:"issues"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Issues, nil
+}
+
+// GetComments returns a page of comments across all issues, with the issue each is on
+func (c *Client) GetComments(ctx context.Context, filter map[string]interface{}, first int, after string) (*Comments, error) {
+	query := `
+		query Comments($filter: CommentFilter, $first: Int, $after: String) {
+			comments(filter: $filter, first: $first, after: $after) {
+				nodes {
+					id
+					body
+					createdAt
+					updatedAt
+					user {
+						id
+						name
+						email
+					}
+					issue {
+						id
+						identifier
+						title
+						url
+						state {
+							id
+							name
+							type
+						}
+						project {
+							id
+							name
+						}
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if filter != nil {
+		variables["filter"] = filter
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		Comments Comments `json:"comments"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Comments, nil
+}
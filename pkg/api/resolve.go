@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// IssueRefFormats lists the ways ResolveIssue accepts an issue to be named
+const IssueRefFormats = "an identifier (ENG-123), a Linear issue URL, an issue UUID, or a git branch name"
+
+var (
+	// issueIdentifierPattern matches an issue identifier such as ENG-123
+	issueIdentifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*-\d+$`)
+	// issueUUIDPattern matches the UUID Linear gives every issue
+	issueUUIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	// issueURLPattern matches an issue link copied from Linear, with or
+	// without the title slug, capturing the identifier
+	issueURLPattern = regexp.MustCompile(`^(?:https?://)?(?:www\.)?linear\.app/[^/]+/issue/([A-Za-z][A-Za-z0-9_]*-\d+)(?:[/?#].*)?$`)
+	// branchIdentifierPattern finds an issue identifier such as ENG-123
+	// inside a branch name like "kyle/eng-123-fix-login"
+	branchIdentifierPattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])([a-z][a-z0-9_]*-\d+)(?:$|[^0-9])`)
+)
+
+// IssueRef is an issue a reference resolved to
+type IssueRef struct {
+	ID         string `json:"id"`
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+}
+
+// issueRefs caches resolved references for the life of the process, per
+// set of credentials, so an issue named many times is looked up once
+var issueRefs = struct {
+	sync.Mutex
+	refs map[string]*IssueRef
+}{refs: map[string]*IssueRef{}}
+
+// ParseIssueRef recognizes the issue references that need no lookup: an
+// identifier (returned upper-cased), a UUID (returned lower-cased), or a
+// Linear issue URL (returned as its identifier). ok is false for anything
+// else, such as a branch name.
+func ParseIssueRef(ref string) (id string, ok bool) {
+	ref = strings.TrimSpace(ref)
+	switch {
+	case issueIdentifierPattern.MatchString(ref):
+		return strings.ToUpper(ref), true
+	case issueUUIDPattern.MatchString(ref):
+		return strings.ToLower(ref), true
+	}
+	if match := issueURLPattern.FindStringSubmatch(ref); match != nil {
+		return strings.ToUpper(match[1]), true
+	}
+	return "", false
+}
+
+// IdentifierFromBranch extracts an issue identifier from a branch name,
+// preferring a path segment that starts with one ("kyle/eng-123-fix"), or
+// returns ""
+func IdentifierFromBranch(branch string) string {
+	segments := strings.Split(branch, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if match := branchIdentifierPattern.FindStringSubmatch(segments[i]); match != nil && strings.HasPrefix(strings.ToLower(segments[i]), strings.ToLower(match[1])) {
+			return strings.ToUpper(match[1])
+		}
+	}
+	if match := branchIdentifierPattern.FindStringSubmatch(branch); match != nil {
+		return strings.ToUpper(match[1])
+	}
+	return ""
+}
+
+// ResolveIssue finds the issue a reference names, given as an identifier
+// (ENG-123), a Linear issue URL, an issue UUID, or a git branch name. Branch
+// names are matched the way Linear links branches to issues, falling back to
+// an identifier inside the name. When nothing matches, the error lists the
+// formats accepted.
+func (c *Client) ResolveIssue(ctx context.Context, ref string) (*IssueRef, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("no issue given; name one as %s", IssueRefFormats)
+	}
+
+	key := c.authHeader + "\x00" + ref
+	issueRefs.Lock()
+	cached := issueRefs.refs[key]
+	issueRefs.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	var issue *IssueRef
+	var err error
+	if id, ok := ParseIssueRef(ref); ok {
+		issue, err = c.issueRef(ctx, id)
+	} else {
+		issue, err = c.issueByBranch(ctx, ref)
+		if issue == nil && err == nil {
+			if id := IdentifierFromBranch(ref); id != "" {
+				issue, err = c.issueRef(ctx, id)
+			}
+		}
+	}
+	if errors.Is(err, ErrNotFound) || (issue == nil && err == nil) {
+		return nil, &Error{
+			Kind:    KindNotFound,
+			Message: fmt.Sprintf("no issue matches '%s'; name an issue as %s", ref, IssueRefFormats),
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	issueRefs.Lock()
+	issueRefs.refs[key] = issue
+	issueRefs.refs[c.authHeader+"\x00"+issue.Identifier] = issue
+	issueRefs.refs[c.authHeader+"\x00"+issue.ID] = issue
+	issueRefs.Unlock()
+	return issue, nil
+}
+
+// issueRef looks up an issue by identifier or UUID, fetching only what
+// ResolveIssue returns
+func (c *Client) issueRef(ctx context.Context, id string) (*IssueRef, error) {
+	query := `
+		query IssueRef($id: String!) {
+			issue(id: $id) {
+				id
+				identifier
+				title
+				url
+			}
+		}
+	`
+	var response struct {
+		Issue *IssueRef `json:"issue"`
+	}
+	if err := c.Execute(ctx, query, map[string]interface{}{"id": id}, &response); err != nil {
+		return nil, err
+	}
+	return response.Issue, nil
+}
+
+// issueByBranch finds the issue Linear links to a git branch, or nil
+func (c *Client) issueByBranch(ctx context.Context, branch string) (*IssueRef, error) {
+	query := `
+		query IssueByBranch($branchName: String!) {
+			issueVcsBranchSearch(branchName: $branchName) {
+				id
+				identifier
+				title
+				url
+			}
+		}
+	`
+	var response struct {
+		Issue *IssueRef `json:"issueVcsBranchSearch"`
+	}
+	if err := c.Execute(ctx, query, map[string]interface{}{"branchName": branch}, &response); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return response.Issue, nil
+}
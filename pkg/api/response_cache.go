@@ -0,0 +1,202 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResponseCache stores query responses on disk so repeated reads skip the
+// network. Entries are keyed by credentials, endpoint, query, and variables.
+// They are fresh for the server's Cache-Control max-age, or TTL when it sends
+// none; stale entries with an ETag or Last-Modified are revalidated with a
+// conditional request instead of being fetched again.
+type ResponseCache struct {
+	Dir string
+	TTL time.Duration
+	// Bypass neither answers from nor adds to the cache, but mutations still
+	// empty it, for commands that must see current data
+	Bypass bool
+}
+
+// DefaultResponseCache is applied to every client created by NewClient and
+// NewClientWithURL; nil disables caching
+var DefaultResponseCache *ResponseCache
+
+// cachedResponse is one cache file
+type cachedResponse struct {
+	StoredAt     time.Time       `json:"storedAt"`
+	Expires      time.Time       `json:"expires"`
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"lastModified,omitempty"`
+	Body         json.RawMessage `json:"body"`
+}
+
+// CacheStats summarizes what a ResponseCache holds
+type CacheStats struct {
+	Path    string `json:"path"`
+	Entries int    `json:"entries"`
+	Fresh   int    `json:"fresh"`
+	Size    int64  `json:"size"`
+}
+
+// SetResponseCache overrides the response cache for this client (nil disables it)
+func (c *Client) SetResponseCache(cache *ResponseCache) {
+	c.cache = cache
+}
+
+// key hashes everything that identifies a response, so different credentials
+// never share an entry
+func (rc *ResponseCache) key(authHeader, baseURL string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(authHeader))
+	h.Write([]byte{0})
+	h.Write([]byte(baseURL))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (rc *ResponseCache) path(key string) string {
+	return filepath.Join(rc.Dir, key+".json")
+}
+
+// load returns the entry for key; unreadable entries count as missing
+func (rc *ResponseCache) load(key string) (*cachedResponse, bool) {
+	data, err := os.ReadFile(rc.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry cachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil || len(entry.Body) == 0 {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// store writes the entry for key, replacing the file atomically so
+// concurrent linctl processes never read half an entry
+func (rc *ResponseCache) store(key string, entry *cachedResponse) error {
+	if err := os.MkdirAll(rc.Dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(rc.Dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), rc.path(key))
+}
+
+// save caches a successful response according to its Cache-Control header
+func (rc *ResponseCache) save(key string, body []byte, header http.Header) {
+	noStore, maxAge := cacheControl(header, rc.TTL)
+	if noStore {
+		_ = os.Remove(rc.path(key))
+		return
+	}
+	now := time.Now()
+	_ = rc.store(key, &cachedResponse{
+		StoredAt:     now,
+		Expires:      now.Add(maxAge),
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		Body:         body,
+	})
+}
+
+// Clear deletes every cached response and returns how many there were
+func (rc *ResponseCache) Clear() (int, error) {
+	entries, err := os.ReadDir(rc.Dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(rc.Dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Stats counts the cached responses and their size on disk
+func (rc *ResponseCache) Stats() (CacheStats, error) {
+	stats := CacheStats{Path: rc.Dir}
+	entries, err := os.ReadDir(rc.Dir)
+	if os.IsNotExist(err) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			stats.Size += info.Size()
+		}
+		stats.Entries++
+		if cached, ok := rc.load(strings.TrimSuffix(entry.Name(), ".json")); ok && now.Before(cached.Expires) {
+			stats.Fresh++
+		}
+	}
+	return stats, nil
+}
+
+// cacheControl reads a response's Cache-Control header: whether it must not
+// be stored, and how long it stays fresh (ttl when the server does not say).
+// no-cache is stored but revalidated on every use.
+func cacheControl(header http.Header, ttl time.Duration) (bool, time.Duration) {
+	maxAge := ttl
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.ToLower(strings.TrimSpace(directive)), "=")
+		switch name {
+		case "no-store":
+			return true, 0
+		case "no-cache":
+			maxAge = 0
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil && seconds >= 0 && maxAge > 0 {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return false, maxAge
+}
+
+// hasGraphQLErrors reports whether a response body carries GraphQL errors,
+// which are never cached
+func hasGraphQLErrors(body []byte) bool {
+	var resp struct {
+		Errors []json.RawMessage `json:"errors"`
+	}
+	return json.Unmarshal(body, &resp) != nil || len(resp.Errors) > 0
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxBackoff caps a single wait between retries; when Linear asks for a
+	// longer wait (Retry-After or a rate-limit reset), the request fails instead
+	maxBackoff = 30 * time.Second
+)
+
+// RetryPolicy controls how the client retries rate-limited and transient failures
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt (0 disables retries)
+	MaxRetries int
+	// Wait is the base delay; it doubles on each retry and gets random jitter added
+	Wait time.Duration
+}
+
+// DefaultRetryPolicy is applied to every client created by NewClient and NewClientWithURL
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	Wait:       time.Second,
+}
+
+// SetRetryPolicy overrides the retry policy for this client
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
+// backoff returns the delay before the given retry (1-based), with jitter
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.Wait
+	if wait <= 0 {
+		wait = time.Second
+	}
+	delay := wait << (retry - 1)
+	if delay <= 0 || delay > maxBackoff {
+		delay = maxBackoff
+	}
+	// Use between 50% and 100% of the delay so concurrent clients spread out
+	half := int64(delay / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// isRateLimited reports whether a response signals that Linear throttled the request.
+// Linear answers with 429, or with 400 and a RATELIMITED error code.
+func isRateLimited(status int, body []byte) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	if status != http.StatusBadRequest {
+		return false
+	}
+
+	var resp struct {
+		Errors []struct {
+			Extensions struct {
+				Code string `json:"code"`
+			} `json:"extensions"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return false
+	}
+	for _, e := range resp.Errors {
+		if e.Extensions.Code == "RATELIMITED" {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransient reports whether a status code is a server-side failure worth retrying
+func isTransient(status int) bool {
+	switch status {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isMutation reports whether a GraphQL document is a mutation. Mutations are not
+// retried on server errors because the first attempt may already have been applied.
+func isMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
+
+// parseRateLimit reads Linear's X-RateLimit-Requests-* headers
+func parseRateLimit(header http.Header) *RateLimit {
+	limit, errLimit := strconv.Atoi(header.Get("X-RateLimit-Requests-Limit"))
+	remaining, errRemaining := strconv.Atoi(header.Get("X-RateLimit-Requests-Remaining"))
+	if errLimit != nil || errRemaining != nil {
+		return nil
+	}
+
+	rateLimit := &RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+	}
+	// Reset is a UTC epoch timestamp in milliseconds
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Requests-Reset"), 10, 64); err == nil {
+		rateLimit.Reset = time.UnixMilli(reset)
+	}
+	return rateLimit
+}
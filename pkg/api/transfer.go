@@ -0,0 +1,109 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/debug"
+	"github.com/dorkitude/linctl/pkg/telemetry"
+)
+
+// TransferClient returns an HTTP client for uploading and downloading files.
+// It shares this client's connection pool, adds the API credentials to
+// requests for files hosted by Linear (and only those), and retries
+// downloads that are rate limited or fail transiently, following the
+// client's retry policy. It has no overall timeout; bound transfers with
+// their context.
+func (c *Client) TransferClient() *http.Client {
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &http.Client{Transport: &transferTransport{
+		base:       base,
+		authHeader: c.authHeader,
+		retry:      c.retry,
+	}}
+}
+
+// transferTransport is the RoundTripper behind TransferClient
+type transferTransport struct {
+	base       http.RoundTripper
+	authHeader string
+	retry      RetryPolicy
+}
+
+func (t *transferTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.authHeader != "" && req.Header.Get("Authorization") == "" && isLinearHost(req.URL.Hostname()) {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", t.authHeader)
+	}
+
+	// Only requests without a body can be sent again as they are; uploads
+	// retry themselves, resuming where the server left off
+	if req.Body != nil && req.Body != http.NoBody {
+		return t.roundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.roundTrip(req)
+		retryable := err != nil || resp.StatusCode == http.StatusTooManyRequests || isTransient(resp.StatusCode)
+		if !retryable || attempt >= t.retry.MaxRetries || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		wait := t.retry.backoff(attempt + 1)
+		if resp != nil {
+			if after, ok := retryAfter(resp.Header); ok {
+				wait = after
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			_ = resp.Body.Close()
+		}
+
+		debug.Logf(debug.Requests, "%s %s: retry %d/%d in %s", req.Method, debug.URL(req.URL.String()), attempt+1, t.retry.MaxRetries, wait.Round(time.Millisecond))
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// roundTrip sends a single request, logging and tracing it
+func (t *transferTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	_, span := telemetry.StartClient(req.Context(), "HTTP "+req.Method,
+		telemetry.String("http.request.method", req.Method),
+		telemetry.String("server.address", req.URL.Hostname()),
+		telemetry.String("url.full", debug.URL(req.URL.String())))
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.SetError(err)
+	} else {
+		span.SetAttributes(telemetry.Int("http.response.status_code", int64(resp.StatusCode)))
+		if resp.StatusCode >= 400 {
+			span.SetError(fmt.Errorf("HTTP %d %s", resp.StatusCode, http.StatusText(resp.StatusCode)))
+		}
+	}
+	span.End()
+	if debug.Enabled(debug.Requests) {
+		elapsed := time.Since(start).Round(time.Millisecond)
+		if err != nil {
+			debug.Logf(debug.Requests, "%s %s: %v after %s", req.Method, debug.URL(req.URL.String()), err, elapsed)
+		} else {
+			debug.Logf(debug.Requests, "%s %s: %d %s in %s", req.Method, debug.URL(req.URL.String()), resp.StatusCode, http.StatusText(resp.StatusCode), elapsed)
+		}
+	}
+	return resp, err
+}
+
+// isLinearHost reports whether host serves Linear's API or uploaded files
+func isLinearHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "linear.app" || strings.HasSuffix(host, ".linear.app")
+}
@@ -3,24 +3,79 @@ package api
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/dorkitude/linctl/pkg/files"
+	"github.com/dorkitude/linctl/pkg/telemetry"
 )
 
-// UploadFileToLinear uploads a file to Linear's cloud storage and returns the asset URL
+// UploadFileToLinear uploads a file to Linear's cloud storage and returns the asset URL.
+// With an upload cache, a file whose contents were uploaded before is not uploaded
+// again; the earlier asset URL is returned instead.
 func (c *Client) UploadFileToLinear(ctx context.Context, filePath string) (string, error) {
+	return c.UploadFileToLinearWithOptions(ctx, filePath, files.UploadOptions{})
+}
+
+// UploadFileToLinearWithOptions uploads a file like UploadFileToLinear, streaming it from disk
+// with the given retry and progress options
+func (c *Client) UploadFileToLinearWithOptions(ctx context.Context, filePath string, opts files.UploadOptions) (_ string, err error) {
+	ctx, span := telemetry.Start(ctx, "upload", telemetry.String("file.name", filepath.Base(filePath)))
+	started := time.Now()
+	reused := false
+	defer func() {
+		span.SetAttributes(telemetry.Bool("linctl.upload.reused", reused))
+		span.SetError(err)
+		span.End()
+		telemetry.RecordDuration("linctl.upload.duration", time.Since(started), telemetry.Bool("linctl.upload.reused", reused))
+	}()
+
+	if opts.Client == nil {
+		opts.Client = c.TransferClient()
+	}
+
+	// Shrink large images first; if that fails, upload the original
+	imageOpts := opts.Image
+	if imageOpts == nil {
+		imageOpts = files.DefaultImageOptions
+	}
+	if imageOpts != nil {
+		if optimized, cleanup, err := files.OptimizeImage(filePath, *imageOpts); err == nil {
+			defer cleanup()
+			filePath = optimized
+		}
+	}
+
 	// Get file metadata
 	size, contentType, err := files.GetFileInfo(filePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to get file info: %w", err)
 	}
+	if opts.ContentType != "" {
+		contentType = opts.ContentType
+	}
+	span.SetAttributes(telemetry.Int("file.size", size), telemetry.String("file.content_type", contentType))
 
-	// Read file content
-	fileContent, err := files.ReadFile(filePath)
+	// Open file for streaming
+	file, err := os.Open(filePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read file: %w", err)
 	}
+	defer file.Close()
+
+	// Reuse the asset from an earlier upload of the same contents
+	var sum string
+	if c.uploads != nil {
+		sum, err = contentSHA256(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file: %w", err)
+		}
+		if assetURL, ok := c.uploads.lookup(sum, contentType); ok {
+			reused = true
+			return assetURL, nil
+		}
+	}
 
 	// Get filename
 	filename := filepath.Base(filePath)
@@ -46,10 +101,20 @@ func (c *Client) UploadFileToLinear(ctx context.Context, filePath string) (strin
 		Size:        size,
 	}
 
-	err = files.UploadToPresignedURL(ctx, uploadFileInfo, fileContent)
+	err = files.UploadStreamToPresignedURL(ctx, uploadFileInfo, file, opts)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload file: %w", err)
 	}
+	telemetry.Add("linctl.upload.bytes", "By", size)
+
+	if c.uploads != nil {
+		_ = c.uploads.store(sum, uploadedAsset{
+			AssetURL:    uploadInfo.AssetURL,
+			ContentType: contentType,
+			Size:        size,
+			UploadedAt:  time.Now(),
+		})
+	}
 
 	// Return the asset URL that can be used in markdown
 	return uploadInfo.AssetURL, nil
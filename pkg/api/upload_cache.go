@@ -0,0 +1,98 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// UploadCache remembers the asset URL of every file uploaded, keyed by the
+// SHA-256 of its contents, so uploading the same file again (a logo used in
+// many issues, say) reuses the existing asset instead of storing a copy
+type UploadCache struct {
+	// Dir holds one entry per uploaded file; asset URLs belong to a
+	// workspace, so each set of credentials needs its own
+	Dir string
+	// Bypass uploads every file anyway, still recording the new asset URLs
+	Bypass bool
+}
+
+// DefaultUploadCache is applied to every client created by NewClient and
+// NewClientWithURL; nil uploads every file
+var DefaultUploadCache *UploadCache
+
+// uploadedAsset is one upload cache entry
+type uploadedAsset struct {
+	AssetURL    string    `json:"assetUrl"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	UploadedAt  time.Time `json:"uploadedAt"`
+}
+
+// SetUploadCache overrides the upload cache for this client (nil disables it)
+func (c *Client) SetUploadCache(cache *UploadCache) {
+	c.uploads = cache
+}
+
+// lookup returns the asset URL of an earlier upload of the same contents with
+// the same content type
+func (uc *UploadCache) lookup(sum, contentType string) (string, bool) {
+	if uc.Bypass {
+		return "", false
+	}
+	data, err := os.ReadFile(uc.path(sum))
+	if err != nil {
+		return "", false
+	}
+	var entry uploadedAsset
+	if err := json.Unmarshal(data, &entry); err != nil || entry.AssetURL == "" || entry.ContentType != contentType {
+		return "", false
+	}
+	return entry.AssetURL, true
+}
+
+// store records an upload, replacing the file atomically so concurrent
+// linctl processes never read half an entry
+func (uc *UploadCache) store(sum string, entry uploadedAsset) error {
+	if err := os.MkdirAll(uc.Dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(uc.Dir, sum+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), uc.path(sum))
+}
+
+func (uc *UploadCache) path(sum string) string {
+	return filepath.Join(uc.Dir, sum+".json")
+}
+
+// contentSHA256 hashes content from the start and rewinds it
+func contentSHA256(content io.ReadSeeker) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
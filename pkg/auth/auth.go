@@ -4,60 +4,109 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/dorkitude/linctl/pkg/api"
 	"github.com/fatih/color"
 )
 
 type User struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	AvatarURL string `json:"avatarUrl,omitempty"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+	Email       string `json:"email"`
+	AvatarURL   string `json:"avatarUrl,omitempty"`
 }
 
 type AuthConfig struct {
-	APIKey string `json:"api_key,omitempty"`
+	APIKey string      `json:"api_key,omitempty"`
+	OAuth  *OAuthToken `json:"oauth,omitempty"`
 }
 
-// getConfigPath returns the path to the auth config file
-func getConfigPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
+// AuthInfo describes the stored credentials without exposing secrets
+type AuthInfo struct {
+	Method    string     `json:"method"`
+	Scope     string     `json:"scope,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// DefaultProfile is the profile used when none is selected
+const DefaultProfile = "default"
+
+// profile is the active credential profile
+var profile = DefaultProfile
+
+// SetProfile selects which stored credentials are used. Profile names may
+// contain letters, digits, '-' and '_'.
+func SetProfile(name string) error {
+	if name == "" {
+		name = DefaultProfile
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("invalid profile name %q (use letters, digits, '-' and '_')", name)
+		}
 	}
-	return filepath.Join(homeDir, ".linctl-auth.json"), nil
+	profile = name
+	return nil
+}
+
+// Profile returns the active credential profile
+func Profile() string {
+	return profile
 }
 
-// saveAuth saves authentication credentials
+// saveAuth saves authentication credentials to the configured credential store
 func saveAuth(config AuthConfig) error {
-	configPath, err := getConfigPath()
+	store, err := credentialStore()
 	if err != nil {
 		return err
 	}
 
-	data, err := json.MarshalIndent(config, "", "  ")
+	data, err := json.Marshal(config)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(configPath, data, 0600)
+	if err := store.Set(profile, data); err != nil {
+		return err
+	}
+	// New credentials may belong to someone else
+	_ = clearViewerCache()
+
+	// Don't leave a plaintext copy behind once credentials live somewhere safer
+	if store.Name() != "plaintext" {
+		_ = plaintextFileStore{}.Delete(profile)
+	}
+	return nil
 }
 
-// loadAuth loads authentication credentials
+// loadAuth loads authentication credentials, migrating a legacy plaintext
+// ~/.linctl-auth.json into the configured store when one is found
 func loadAuth() (*AuthConfig, error) {
-	configPath, err := getConfigPath()
+	store, err := credentialStore()
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := os.ReadFile(configPath)
+	data, err := store.Get(profile)
+	if errors.Is(err, ErrCredentialNotFound) && store.Name() != "plaintext" {
+		data, err = plaintextFileStore{}.Get(profile)
+		if err == nil {
+			if migrateErr := store.Set(profile, data); migrateErr == nil {
+				_ = plaintextFileStore{}.Delete(profile)
+			}
+		}
+	}
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, ErrCredentialNotFound) {
+			if profile != DefaultProfile {
+				return nil, fmt.Errorf("not authenticated (profile %s)", profile)
+			}
 			return nil, fmt.Errorf("not authenticated")
 		}
 		return nil, err
@@ -79,6 +128,15 @@ func GetAuthHeader() (string, error) {
 		return "", err
 	}
 
+	if config.OAuth != nil && config.OAuth.AccessToken != "" {
+		if config.OAuth.expired() {
+			if err := refreshOAuthToken(config); err != nil {
+				return "", err
+			}
+		}
+		return bearer(config.OAuth.AccessToken), nil
+	}
+
 	if config.APIKey != "" {
 		return config.APIKey, nil
 	}
@@ -86,6 +144,28 @@ func GetAuthHeader() (string, error) {
 	return "", fmt.Errorf("no valid authentication found")
 }
 
+// GetAuthInfo reports how linctl is authenticated
+func GetAuthInfo() (*AuthInfo, error) {
+	config, err := loadAuth()
+	if err != nil {
+		return nil, err
+	}
+
+	if config.OAuth != nil && config.OAuth.AccessToken != "" {
+		info := &AuthInfo{Method: "oauth", Scope: config.OAuth.Scope}
+		if !config.OAuth.ExpiresAt.IsZero() {
+			expiresAt := config.OAuth.ExpiresAt
+			info.ExpiresAt = &expiresAt
+		}
+		return info, nil
+	}
+	if config.APIKey != "" {
+		return &AuthInfo{Method: "api_key"}, nil
+	}
+
+	return nil, fmt.Errorf("no valid authentication found")
+}
+
 // Login handles the authentication flow
 func Login(plaintext, jsonOut bool) error {
 	return loginWithAPIKey(plaintext, jsonOut)
@@ -97,9 +177,10 @@ func loginWithAPIKey(plaintext, jsonOut bool) error {
 		fmt.Println("\n" + color.New(color.FgYellow).Sprint("📝 Personal API Key Authentication"))
 		fmt.Println("Get your API key from: https://linear.app/settings/api")
 
-		// Get the config path to show to the user
-		configPath, _ := getConfigPath()
-		fmt.Printf("Your credentials will be stored in: %s\n", color.New(color.FgCyan).Sprint(configPath))
+		// Show the user where the credentials will be stored
+		if store, err := credentialStore(); err == nil {
+			fmt.Printf("Your credentials will be stored in: %s\n", color.New(color.FgCyan).Sprint(store.Location(profile)))
+		}
 		fmt.Print("\nEnter your Personal API Key: ")
 	}
 
@@ -129,6 +210,7 @@ func loginWithAPIKey(plaintext, jsonOut bool) error {
 	if err != nil {
 		return err
 	}
+	_ = saveViewerCache(userFromAPI(user))
 
 	if !plaintext && !jsonOut {
 		fmt.Printf("\n%s Authenticated as %s (%s)\n",
@@ -140,7 +222,8 @@ func loginWithAPIKey(plaintext, jsonOut bool) error {
 	return nil
 }
 
-// GetCurrentUser returns the current authenticated user
+// GetCurrentUser fetches the current authenticated user and refreshes the
+// cached viewer used by Viewer
 func GetCurrentUser() (*User, error) {
 	authHeader, err := GetAuthHeader()
 	if err != nil {
@@ -153,26 +236,25 @@ func GetCurrentUser() (*User, error) {
 		return nil, err
 	}
 
-	// Convert api.User to auth.User
-	return &User{
-		ID:        apiUser.ID,
-		Name:      apiUser.Name,
-		Email:     apiUser.Email,
-		AvatarURL: apiUser.AvatarURL,
-	}, nil
+	user := userFromAPI(apiUser)
+	_ = saveViewerCache(user)
+	return user, nil
 }
 
-// Logout clears stored credentials
+// Logout clears stored credentials, revoking OAuth tokens first
 func Logout() error {
-	configPath, err := getConfigPath()
+	// Revocation is best effort; the local credentials are removed either way
+	if config, err := loadAuth(); err == nil && config.OAuth != nil {
+		_ = revokeOAuthToken(config.OAuth)
+	}
+
+	store, err := credentialStore()
 	if err != nil {
 		return err
 	}
-
-	err = os.Remove(configPath)
-	if err != nil && !os.IsNotExist(err) {
+	if err := store.Delete(profile); err != nil {
 		return err
 	}
-
-	return nil
+	_ = clearViewerCache()
+	return plaintextFileStore{}.Delete(profile)
 }
@@ -0,0 +1,76 @@
+//go:build darwin
+
+package auth
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// macKeychainStore stores credentials in the macOS login keychain via security(1)
+type macKeychainStore struct{}
+
+func newKeychainStore() (CredentialStore, bool) {
+	if _, err := exec.LookPath("security"); err != nil {
+		return nil, false
+	}
+	return macKeychainStore{}, true
+}
+
+func (macKeychainStore) Name() string { return "keychain" }
+
+func (macKeychainStore) Location(profile string) string {
+	return fmt.Sprintf("macOS Keychain (service %q, account %q)", keychainService, keychainAccount(profile))
+}
+
+func (macKeychainStore) Get(profile string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("security", "find-generic-password", "-s", keychainService, "-a", keychainAccount(profile), "-w")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// Exit status 44 is errSecItemNotFound
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 44 {
+			return nil, ErrCredentialNotFound
+		}
+		return nil, fmt.Errorf("keychain lookup failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return bytes.TrimRight(out, "\n"), nil
+}
+
+func (macKeychainStore) Set(profile string, data []byte) error {
+	// security(1) only takes the password as an argument, so the command is
+	// sent to its interactive mode on stdin to keep the secret out of the
+	// process list; -X takes the password as hex, which needs no quoting
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -l %s -X %s\n",
+		securityQuote(keychainService), securityQuote(keychainAccount(profile)),
+		securityQuote("linctl credentials ("+profile+")"), hex.EncodeToString(data))
+	var stderr bytes.Buffer
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(command)
+	cmd.Stderr = &stderr
+	// Interactive mode exits 0 when a command fails, and reports it on stderr
+	if err := cmd.Run(); err != nil || stderr.Len() > 0 {
+		return fmt.Errorf("failed to store credentials in keychain: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// securityQuote quotes an argument for a command line of security's interactive mode
+func securityQuote(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
+
+func (macKeychainStore) Delete(profile string) error {
+	cmd := exec.Command("security", "delete-generic-password", "-s", keychainService, "-a", keychainAccount(profile))
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 44 {
+			return nil
+		}
+		return fmt.Errorf("failed to delete credentials from keychain: %w", err)
+	}
+	return nil
+}
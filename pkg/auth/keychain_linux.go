@@ -0,0 +1,71 @@
+//go:build linux
+
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// secretServiceStore stores credentials with the freedesktop Secret Service
+// (GNOME Keyring, KWallet) via secret-tool(1)
+type secretServiceStore struct{}
+
+func newKeychainStore() (CredentialStore, bool) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return nil, false
+	}
+	// The Secret Service lives on the session bus; without one there is no keyring to talk to
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return nil, false
+	}
+	return secretServiceStore{}, true
+}
+
+func (secretServiceStore) Name() string { return "keychain" }
+
+func (secretServiceStore) Location(profile string) string {
+	return fmt.Sprintf("Secret Service (service %q, account %q)", keychainService, keychainAccount(profile))
+}
+
+func (secretServiceStore) Get(profile string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", "lookup", "service", keychainService, "account", keychainAccount(profile))
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// secret-tool exits 1 with no output when nothing matches
+		if stderr.Len() == 0 {
+			return nil, ErrCredentialNotFound
+		}
+		return nil, fmt.Errorf("secret service lookup failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	if len(out) == 0 {
+		return nil, ErrCredentialNotFound
+	}
+	return out, nil
+}
+
+func (secretServiceStore) Set(profile string, data []byte) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", "store", "--label=linctl credentials ("+profile+")",
+		"service", keychainService, "account", keychainAccount(profile))
+	// The secret is read from stdin so it never appears in the process list
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to store credentials in secret service: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (secretServiceStore) Delete(profile string) error {
+	cmd := exec.Command("secret-tool", "clear", "service", keychainService, "account", keychainAccount(profile))
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to delete credentials from secret service: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build !darwin && !linux && !windows
+
+package auth
+
+// newKeychainStore reports that no OS keychain is supported on this platform
+func newKeychainStore() (CredentialStore, bool) {
+	return nil, false
+}
@@ -0,0 +1,119 @@
+//go:build windows
+
+package auth
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW  = advapi32.NewProc("CredReadW")
+	procCredWriteW = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+// winCredential mirrors the Win32 CREDENTIALW structure
+type winCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// credentialManagerStore stores credentials in the Windows Credential Manager
+type credentialManagerStore struct{}
+
+func newKeychainStore() (CredentialStore, bool) {
+	if err := advapi32.Load(); err != nil {
+		return nil, false
+	}
+	return credentialManagerStore{}, true
+}
+
+func credentialTarget(profile string) string {
+	return keychainService + ":" + keychainAccount(profile)
+}
+
+func (credentialManagerStore) Name() string { return "keychain" }
+
+func (credentialManagerStore) Location(profile string) string {
+	return fmt.Sprintf("Windows Credential Manager (%s)", credentialTarget(profile))
+}
+
+func (credentialManagerStore) Get(profile string) ([]byte, error) {
+	target, err := syscall.UTF16PtrFromString(credentialTarget(profile))
+	if err != nil {
+		return nil, err
+	}
+
+	var cred *winCredential
+	ret, _, callErr := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		if callErr == errorNotFound {
+			return nil, ErrCredentialNotFound
+		}
+		return nil, fmt.Errorf("credential manager lookup failed: %w", callErr)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	blob := unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)
+	return append([]byte(nil), blob...), nil
+}
+
+func (credentialManagerStore) Set(profile string, data []byte) error {
+	target, err := syscall.UTF16PtrFromString(credentialTarget(profile))
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(keychainAccount(profile))
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("refusing to store empty credentials")
+	}
+
+	cred := winCredential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(data)),
+		CredentialBlob:     &data[0],
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	ret, _, callErr := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ret == 0 {
+		return fmt.Errorf("failed to store credentials in credential manager: %w", callErr)
+	}
+	return nil
+}
+
+func (credentialManagerStore) Delete(profile string) error {
+	target, err := syscall.UTF16PtrFromString(credentialTarget(profile))
+	if err != nil {
+		return err
+	}
+	ret, _, callErr := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if ret == 0 && callErr != errorNotFound {
+		return fmt.Errorf("failed to delete credentials from credential manager: %w", callErr)
+	}
+	return nil
+}
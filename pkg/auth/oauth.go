@@ -0,0 +1,312 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	"github.com/dorkitude/linctl/pkg/httpclient"
+	"github.com/dorkitude/linctl/pkg/utils"
+	"github.com/fatih/color"
+)
+
+const (
+	oauthAuthorizeURL = "https://linear.app/oauth/authorize"
+	oauthTokenURL     = "https://api.linear.app/oauth/token"
+	oauthRevokeURL    = "https://api.linear.app/oauth/revoke"
+
+	// DefaultOAuthPort is the local port the callback server listens on
+	DefaultOAuthPort = 8976
+	// DefaultOAuthScopes are requested when no scopes are given
+	DefaultOAuthScopes = "read,write"
+
+	// refreshMargin refreshes tokens slightly before they expire
+	refreshMargin = time.Minute
+	// callbackTimeout bounds how long we wait for the browser to come back
+	callbackTimeout = 5 * time.Minute
+)
+
+// OAuthToken is an OAuth access token and what is needed to refresh it
+type OAuthToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenType    string    `json:"token_type,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+}
+
+// expired reports whether the token is expired or about to expire
+func (t *OAuthToken) expired() bool {
+	return !t.ExpiresAt.IsZero() && time.Now().Add(refreshMargin).After(t.ExpiresAt)
+}
+
+// OAuthOptions configures the OAuth login flow
+type OAuthOptions struct {
+	// ClientID of the Linear OAuth application (required)
+	ClientID string
+	// ClientSecret is optional; PKCE is used so public clients work without it
+	ClientSecret string
+	// Port for the local callback server, which must match the app's redirect URI
+	Port int
+	// Scopes is a comma-separated scope list (default "read,write")
+	Scopes string
+	// NoBrowser prints the authorization URL instead of opening a browser
+	NoBrowser bool
+}
+
+// tokenResponse is the body returned by Linear's token endpoint
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+	Error        string `json:"error"`
+	Description  string `json:"error_description"`
+}
+
+// LoginWithOAuth runs the OAuth authorization code flow with PKCE: it opens the
+// browser, waits for Linear to redirect back to a local callback server, and
+// exchanges the code for tokens
+func LoginWithOAuth(opts OAuthOptions, plaintext, jsonOut bool) error {
+	if opts.ClientID == "" {
+		return fmt.Errorf("an OAuth client ID is required (--client-id or oauth.client_id in config)")
+	}
+	if opts.Port == 0 {
+		opts.Port = DefaultOAuthPort
+	}
+	if opts.Scopes == "" {
+		opts.Scopes = DefaultOAuthScopes
+	}
+
+	state, err := randomString(24)
+	if err != nil {
+		return err
+	}
+	verifier, err := randomString(48)
+	if err != nil {
+		return err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	redirectURI := fmt.Sprintf("http://localhost:%d/callback", opts.Port)
+	authorizeURL := oauthAuthorizeURL + "?" + url.Values{
+		"client_id":             {opts.ClientID},
+		"redirect_uri":          {redirectURI},
+		"response_type":         {"code"},
+		"scope":                 {opts.Scopes},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+		"prompt":                {"consent"},
+	}.Encode()
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", opts.Port))
+	if err != nil {
+		return fmt.Errorf("failed to start callback server on port %d: %w", opts.Port, err)
+	}
+
+	type callbackResult struct {
+		code string
+		err  error
+	}
+	results := make(chan callbackResult, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		result := callbackResult{code: query.Get("code")}
+		switch {
+		case query.Get("error") != "":
+			result.err = fmt.Errorf("authorization denied: %s", query.Get("error"))
+		case query.Get("state") != state:
+			result.err = fmt.Errorf("state mismatch in OAuth callback")
+		case result.code == "":
+			result.err = fmt.Errorf("no authorization code in OAuth callback")
+		}
+
+		if result.err != nil {
+			http.Error(w, "linctl: "+result.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "linctl is now authenticated with Linear. You can close this window.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	if !plaintext && !jsonOut {
+		fmt.Println("\n" + color.New(color.FgYellow).Sprint("🌐 OAuth Authentication"))
+	}
+	openErr := errors.New("browser disabled")
+	if !opts.NoBrowser {
+		openErr = utils.OpenBrowser(authorizeURL)
+	}
+	if !jsonOut {
+		if openErr != nil {
+			fmt.Printf("Open this URL in your browser to authorize linctl:\n\n  %s\n\n", authorizeURL)
+		} else if !plaintext {
+			fmt.Println("Opened your browser to authorize linctl. Waiting for the redirect...")
+		}
+	}
+
+	var result callbackResult
+	select {
+	case result = <-results:
+	case <-time.After(callbackTimeout):
+		return fmt.Errorf("timed out waiting for the OAuth callback")
+	}
+	if result.err != nil {
+		return result.err
+	}
+
+	token, err := requestToken(url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {result.code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {opts.ClientID},
+		"code_verifier": {verifier},
+	}, opts.ClientSecret)
+	if err != nil {
+		return err
+	}
+	token.ClientID = opts.ClientID
+	token.ClientSecret = opts.ClientSecret
+
+	// Verify the token before storing it
+	client := api.NewClient(bearer(token.AccessToken))
+	user, err := client.GetViewer(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to verify OAuth token: %v", err)
+	}
+
+	if err := saveAuth(AuthConfig{OAuth: token}); err != nil {
+		return err
+	}
+	_ = saveViewerCache(userFromAPI(user))
+
+	if !plaintext && !jsonOut {
+		fmt.Printf("\n%s Authenticated as %s (%s)\n",
+			color.New(color.FgGreen).Sprint("✅"),
+			color.New(color.FgCyan).Sprint(user.Name),
+			color.New(color.FgCyan).Sprint(user.Email))
+	}
+
+	return nil
+}
+
+// refreshOAuthToken exchanges the refresh token for a new access token and saves it
+func refreshOAuthToken(config *AuthConfig) error {
+	current := config.OAuth
+	if current.RefreshToken == "" {
+		return fmt.Errorf("OAuth token expired; run 'linctl auth login --oauth' again")
+	}
+
+	token, err := requestToken(url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {current.RefreshToken},
+		"client_id":     {current.ClientID},
+	}, current.ClientSecret)
+	if err != nil {
+		return fmt.Errorf("failed to refresh OAuth token: %w", err)
+	}
+	token.ClientID = current.ClientID
+	token.ClientSecret = current.ClientSecret
+	if token.RefreshToken == "" {
+		token.RefreshToken = current.RefreshToken
+	}
+
+	config.OAuth = token
+	return saveAuth(*config)
+}
+
+// revokeOAuthToken asks Linear to invalidate an access token
+func revokeOAuthToken(token *OAuthToken) error {
+	req, err := http.NewRequest(http.MethodPost, oauthRevokeURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", bearer(token.AccessToken))
+
+	resp, err := httpclient.New().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("token revocation failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// requestToken posts a form to Linear's token endpoint
+func requestToken(form url.Values, clientSecret string) (*OAuthToken, error) {
+	if clientSecret != "" {
+		form.Set("client_secret", clientSecret)
+	}
+
+	resp, err := httpclient.New().PostForm(oauthTokenURL, form)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode token response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		message := body.Description
+		if message == "" {
+			message = body.Error
+		}
+		if message == "" {
+			message = resp.Status
+		}
+		return nil, fmt.Errorf("token request failed: %s", message)
+	}
+
+	token := &OAuthToken{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		TokenType:    body.TokenType,
+		Scope:        body.Scope,
+	}
+	if body.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// bearer formats an OAuth access token as an Authorization header value
+func bearer(accessToken string) string {
+	return "Bearer " + accessToken
+}
+
+// randomString returns a URL-safe random string built from n random bytes
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random data: %w", err)
+	}
+	return strings.TrimRight(base64.RawURLEncoding.EncodeToString(buf), "="), nil
+}
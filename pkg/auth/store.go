@@ -0,0 +1,276 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// ErrCredentialNotFound is returned by a CredentialStore when a profile has no stored credentials
+var ErrCredentialNotFound = errors.New("credentials not found")
+
+// CredentialStore persists the serialized credentials of a profile
+type CredentialStore interface {
+	// Name identifies the backend (e.g. "keychain", "file")
+	Name() string
+	// Location describes where credentials live, for display to the user
+	Location(profile string) string
+	Get(profile string) ([]byte, error)
+	Set(profile string, data []byte) error
+	Delete(profile string) error
+}
+
+const (
+	// keychainService is the service name credentials are stored under
+	keychainService = "linctl"
+	// passphraseEnv supplies the passphrase for the encrypted file store
+	passphraseEnv = "LINCTL_CREDENTIAL_PASSPHRASE"
+	// kdfIterations is the PBKDF2 iteration count for the encrypted file store
+	kdfIterations = 200000
+)
+
+// storeName is the configured backend: auto, keychain, file, or plaintext
+var storeName = "auto"
+
+// SetCredentialStore selects the credential backend: "auto" (OS keychain when
+// available, else an encrypted file), "keychain", "file" (encrypted), or "plaintext"
+func SetCredentialStore(name string) error {
+	switch name {
+	case "":
+		name = "auto"
+	case "auto", "keychain", "file", "plaintext":
+	default:
+		return fmt.Errorf("invalid credential store %q (valid: auto, keychain, file, plaintext)", name)
+	}
+	storeName = name
+	return nil
+}
+
+// credentialStore returns the configured backend
+func credentialStore() (CredentialStore, error) {
+	switch storeName {
+	case "plaintext":
+		return plaintextFileStore{}, nil
+	case "file":
+		return encryptedFileStore{}, nil
+	case "keychain":
+		store, ok := newKeychainStore()
+		if !ok {
+			return nil, fmt.Errorf("no OS keychain is available on this system")
+		}
+		return store, nil
+	default:
+		if store, ok := newKeychainStore(); ok {
+			return store, nil
+		}
+		return encryptedFileStore{}, nil
+	}
+}
+
+// keychainAccount is the account name a profile is stored under in the OS keychain
+func keychainAccount(profile string) string {
+	return "profile:" + profile
+}
+
+// plaintextFileStore is the original ~/.linctl-auth.json format
+type plaintextFileStore struct{}
+
+func (plaintextFileStore) Name() string { return "plaintext" }
+
+func (plaintextFileStore) Location(profile string) string {
+	path, _ := credentialFilePath(profile, ".json")
+	return path
+}
+
+func (plaintextFileStore) Get(profile string) ([]byte, error) {
+	path, err := credentialFilePath(profile, ".json")
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrCredentialNotFound
+	}
+	return data, err
+}
+
+func (plaintextFileStore) Set(profile string, data []byte) error {
+	path, err := credentialFilePath(profile, ".json")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (plaintextFileStore) Delete(profile string) error {
+	path, err := credentialFilePath(profile, ".json")
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// encryptedFileStore keeps credentials in an AES-256-GCM encrypted file. The key is
+// derived from $LINCTL_CREDENTIAL_PASSPHRASE, or from a random key file created on
+// first use (~/.linctl-key) when no passphrase is set. The key file sits next to
+// the credentials, so without a passphrase the encryption only keeps them out of
+// casual view: anyone who can read the home directory can decrypt them.
+type encryptedFileStore struct{}
+
+// encryptedFile is the on-disk format of the encrypted file store
+type encryptedFile struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+func (encryptedFileStore) Name() string { return "file" }
+
+func (encryptedFileStore) Location(profile string) string {
+	path, _ := credentialFilePath(profile, ".enc")
+	if os.Getenv(passphraseEnv) == "" {
+		return path + " (encrypted with ~/.linctl-key; readable by anyone who can read your home directory)"
+	}
+	return path + " (encrypted with $" + passphraseEnv + ")"
+}
+
+func (encryptedFileStore) Get(profile string) ([]byte, error) {
+	path, err := credentialFilePath(profile, ".enc")
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrCredentialNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("corrupt credentials file %s: %w", path, err)
+	}
+
+	gcm, err := credentialCipher(file.Salt, false)
+	if err != nil {
+		return nil, err
+	}
+	data, err := gcm.Open(nil, file.Nonce, file.Data, []byte(profile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s (wrong %s?)", path, passphraseEnv)
+	}
+	return data, nil
+}
+
+func (encryptedFileStore) Set(profile string, data []byte) error {
+	path, err := credentialFilePath(profile, ".enc")
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return err
+	}
+	gcm, err := credentialCipher(salt, true)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(encryptedFile{
+		Version: 1,
+		Salt:    salt,
+		Nonce:   nonce,
+		Data:    gcm.Seal(nil, nonce, data, []byte(profile)),
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0600)
+}
+
+func (encryptedFileStore) Delete(profile string) error {
+	path, err := credentialFilePath(profile, ".enc")
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// credentialCipher derives the AES-GCM cipher for the encrypted file store
+func credentialCipher(salt []byte, create bool) (cipher.AEAD, error) {
+	secret := []byte(os.Getenv(passphraseEnv))
+	if len(secret) == 0 {
+		var err error
+		secret, err = credentialKeyFile(create)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key(secret, salt, kdfIterations, 32, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// credentialKeyFile returns the random key used when no passphrase is set,
+// creating it when create is true
+func credentialKeyFile(create bool) ([]byte, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(homeDir, ".linctl-key")
+
+	key, err := os.ReadFile(path)
+	if err == nil {
+		return key, nil
+	}
+	if !os.IsNotExist(err) || !create {
+		return nil, fmt.Errorf("failed to read credential key %s: %w", path, err)
+	}
+
+	key = make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, key, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write credential key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// credentialFilePath returns ~/.linctl-auth[-<profile>]<ext>
+func credentialFilePath(profile, ext string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if profile != DefaultProfile {
+		return filepath.Join(homeDir, fmt.Sprintf(".linctl-auth-%s%s", profile, ext)), nil
+	}
+	return filepath.Join(homeDir, ".linctl-auth"+ext), nil
+}
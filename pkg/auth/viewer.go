@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+)
+
+// viewerCacheTTL bounds how long the cached viewer is trusted before it is refetched
+const viewerCacheTTL = 24 * time.Hour
+
+// viewerCache is the on-disk record of who the active profile is logged in as
+type viewerCache struct {
+	User     User      `json:"user"`
+	CachedAt time.Time `json:"cachedAt"`
+}
+
+// Viewer returns the authenticated user, from the local cache when it is fresh.
+// Commands use it to resolve "me" without a round trip on every invocation.
+func Viewer(ctx context.Context) (*User, error) {
+	if cache, err := loadViewerCache(); err == nil && time.Since(cache.CachedAt) < viewerCacheTTL {
+		return &cache.User, nil
+	}
+
+	authHeader, err := GetAuthHeader()
+	if err != nil {
+		return nil, err
+	}
+	apiUser, err := api.NewClient(authHeader).GetViewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user := userFromAPI(apiUser)
+	_ = saveViewerCache(user)
+	return user, nil
+}
+
+// userFromAPI converts an api.User to the subset kept by auth
+func userFromAPI(apiUser *api.User) *User {
+	return &User{
+		ID:          apiUser.ID,
+		Name:        apiUser.Name,
+		DisplayName: apiUser.DisplayName,
+		Email:       apiUser.Email,
+		AvatarURL:   apiUser.AvatarURL,
+	}
+}
+
+// viewerCachePath is ~/.linctl-viewer.json, or ~/.linctl-viewer-PROFILE.json
+func viewerCachePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if profile != DefaultProfile {
+		return filepath.Join(homeDir, fmt.Sprintf(".linctl-viewer-%s.json", profile)), nil
+	}
+	return filepath.Join(homeDir, ".linctl-viewer.json"), nil
+}
+
+func loadViewerCache() (*viewerCache, error) {
+	path, err := viewerCachePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cache viewerCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+	if cache.User.ID == "" {
+		return nil, fmt.Errorf("viewer cache is empty")
+	}
+	return &cache, nil
+}
+
+func saveViewerCache(user *User) error {
+	path, err := viewerCachePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(viewerCache{User: *user, CachedAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// clearViewerCache forgets the cached viewer, e.g. when credentials change
+func clearViewerCache() error {
+	path, err := viewerCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+// Package cache keeps a local copy of Linear data in a bbolt database so
+// read-only commands can run without the network.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/api"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	issuesBucket = []byte("issues")
+	metaBucket   = []byte("meta")
+)
+
+// Meta keys recording the state of the last issue sync
+const (
+	// issuesSyncedAtKey is when the last sync finished
+	issuesSyncedAtKey = "issues.syncedAt"
+	// issuesCursorKey is the latest updatedAt seen; the next sync fetches from there
+	issuesCursorKey = "issues.cursor"
+)
+
+// ErrNotSynced is returned when the cache has never been populated
+var ErrNotSynced = errors.New("the local cache is empty; run 'linctl sync' first")
+
+// Store is an open cache database
+type Store struct {
+	db *bolt.DB
+}
+
+// Status summarizes what the cache holds
+type Status struct {
+	Path     string    `json:"path"`
+	Issues   int       `json:"issues"`
+	SyncedAt time.Time `json:"syncedAt,omitempty"`
+	Cursor   time.Time `json:"cursor,omitempty"`
+	Size     int64     `json:"size"`
+}
+
+// Path is ~/.linctl/cache/PROFILE.db
+func Path(profile string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl", "cache", profile+".db"), nil
+}
+
+// Open opens (creating if needed) the cache at path. A read-only store
+// shares the file with other readers; a writable one waits briefly for them.
+func Open(path string, readOnly bool) (*Store, error) {
+	if readOnly {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return nil, ErrNotSynced
+		}
+	} else if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 2 * time.Second, ReadOnly: readOnly})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("the local cache is in use by another linctl process")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache %s: %w", path, err)
+	}
+
+	if !readOnly {
+		err = db.Update(func(tx *bolt.Tx) error {
+			for _, name := range [][]byte{issuesBucket, metaBucket} {
+				if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// PutIssues stores issues by ID, replacing older copies. Archived issues are
+// removed instead, since list and search leave them out.
+func (s *Store) PutIssues(issues []api.Issue) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(issuesBucket)
+		for _, issue := range issues {
+			if issue.ArchivedAt != nil {
+				if err := bucket.Delete([]byte(issue.ID)); err != nil {
+					return err
+				}
+				continue
+			}
+			data, err := json.Marshal(issue)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put([]byte(issue.ID), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Issues returns every cached issue, in no particular order
+func (s *Store) Issues() ([]api.Issue, error) {
+	var issues []api.Issue
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(issuesBucket)
+		if bucket == nil {
+			return ErrNotSynced
+		}
+		return bucket.ForEach(func(_, data []byte) error {
+			var issue api.Issue
+			if err := json.Unmarshal(data, &issue); err != nil {
+				return err
+			}
+			issues = append(issues, issue)
+			return nil
+		})
+	})
+	return issues, err
+}
+
+// Issue returns the cached issue with the given ID or identifier, or nil
+func (s *Store) Issue(ref string) (*api.Issue, error) {
+	issues, err := s.Issues()
+	if err != nil {
+		return nil, err
+	}
+	for i := range issues {
+		if issues[i].ID == ref || strings.EqualFold(issues[i].Identifier, ref) {
+			return &issues[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// IssuesSyncedAt returns when issues were last synced and the updatedAt
+// cursor the next sync should start from. Both are zero before the first sync.
+func (s *Store) IssuesSyncedAt() (syncedAt, cursor time.Time, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(metaBucket)
+		if bucket == nil {
+			return nil
+		}
+		syncedAt = parseTime(bucket.Get([]byte(issuesSyncedAtKey)))
+		cursor = parseTime(bucket.Get([]byte(issuesCursorKey)))
+		return nil
+	})
+	return syncedAt, cursor, err
+}
+
+// SetIssuesSyncedAt records a finished sync
+func (s *Store) SetIssuesSyncedAt(syncedAt, cursor time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(metaBucket)
+		if err := bucket.Put([]byte(issuesSyncedAtKey), []byte(syncedAt.UTC().Format(time.RFC3339Nano))); err != nil {
+			return err
+		}
+		return bucket.Put([]byte(issuesCursorKey), []byte(cursor.UTC().Format(time.RFC3339Nano)))
+	})
+}
+
+// Reset deletes everything in the cache
+func (s *Store) Reset() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{issuesBucket, metaBucket} {
+			if err := tx.DeleteBucket(name); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+				return err
+			}
+			if _, err := tx.CreateBucket(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Status reports the cache's size and sync state
+func (s *Store) Status() (*Status, error) {
+	status := &Status{Path: s.db.Path()}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if bucket := tx.Bucket(issuesBucket); bucket != nil {
+			status.Issues = bucket.Stats().KeyN
+		}
+		status.Size = tx.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	status.SyncedAt, status.Cursor, err = s.IssuesSyncedAt()
+	return status, err
+}
+
+func parseTime(value []byte) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(value))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
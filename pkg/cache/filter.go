@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Match reports whether v (any value that marshals to a JSON object, such as
+// an api.Issue) satisfies a Linear GraphQL filter, so filters built for the
+// API can be applied to cached data. It supports and/or, nested objects,
+// connections with some/every, and the eq, neq, in, nin, eqIgnoreCase,
+// neqIgnoreCase, contains, notContains, containsIgnoreCase,
+// notContainsIgnoreCase, startsWith, gt, gte, lt, lte, and null comparators.
+func Match(v interface{}, filter map[string]interface{}) (bool, error) {
+	doc, err := normalize(v)
+	if err != nil {
+		return false, err
+	}
+	normalized, err := normalize(filter)
+	if err != nil {
+		return false, err
+	}
+	f, _ := normalized.(map[string]interface{})
+	return matchObject(doc, f)
+}
+
+// normalize converts v to the generic form encoding/json decodes into
+func normalize(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	err = json.Unmarshal(data, &out)
+	return out, err
+}
+
+// matchObject applies a filter object to a value
+func matchObject(value interface{}, filter map[string]interface{}) (bool, error) {
+	for key, condition := range filter {
+		ok, err := matchCondition(value, key, condition)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchCondition(value interface{}, key string, condition interface{}) (bool, error) {
+	switch key {
+	case "and", "or":
+		conditions, ok := condition.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("'%s' must be a list of filters", key)
+		}
+		for _, c := range conditions {
+			sub, _ := c.(map[string]interface{})
+			ok, err := matchObject(value, sub)
+			if err != nil {
+				return false, err
+			}
+			if key == "or" && ok {
+				return true, nil
+			}
+			if key == "and" && !ok {
+				return false, nil
+			}
+		}
+		return key == "and", nil
+	case "null":
+		want, _ := condition.(bool)
+		return (value == nil) == want, nil
+	case "some", "every":
+		sub, _ := condition.(map[string]interface{})
+		nodes := connectionNodes(value)
+		for _, node := range nodes {
+			ok, err := matchObject(node, sub)
+			if err != nil {
+				return false, err
+			}
+			if key == "some" && ok {
+				return true, nil
+			}
+			if key == "every" && !ok {
+				return false, nil
+			}
+		}
+		return key == "every", nil
+	}
+
+	if isComparator(key) {
+		return compare(value, key, condition)
+	}
+
+	// A field: descend into the value, which must be an object
+	object, _ := value.(map[string]interface{})
+	var field interface{}
+	if object != nil {
+		field = object[key]
+	}
+	sub, ok := condition.(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("filter for '%s' must be an object", key)
+	}
+	return matchObject(field, sub)
+}
+
+// connectionNodes returns the nodes of a connection ({"nodes": [...]}) or list
+func connectionNodes(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		nodes, _ := v["nodes"].([]interface{})
+		return nodes
+	}
+	return nil
+}
+
+func isComparator(key string) bool {
+	switch key {
+	case "eq", "neq", "in", "nin", "eqIgnoreCase", "neqIgnoreCase", "contains", "notContains",
+		"containsIgnoreCase", "notContainsIgnoreCase", "startsWith", "endsWith", "gt", "gte", "lt", "lte":
+		return true
+	}
+	return false
+}
+
+func compare(value interface{}, comparator string, operand interface{}) (bool, error) {
+	switch comparator {
+	case "eq":
+		return equal(value, operand), nil
+	case "neq":
+		return !equal(value, operand), nil
+	case "in", "nin":
+		list, ok := operand.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("'%s' needs a list", comparator)
+		}
+		found := false
+		for _, item := range list {
+			if equal(value, item) {
+				found = true
+				break
+			}
+		}
+		return found == (comparator == "in"), nil
+	case "eqIgnoreCase", "neqIgnoreCase":
+		matched := value != nil && strings.EqualFold(fmt.Sprint(value), fmt.Sprint(operand))
+		return matched == (comparator == "eqIgnoreCase"), nil
+	case "contains", "notContains":
+		matched := value != nil && strings.Contains(fmt.Sprint(value), fmt.Sprint(operand))
+		return matched == (comparator == "contains"), nil
+	case "containsIgnoreCase", "notContainsIgnoreCase":
+		matched := value != nil && strings.Contains(strings.ToLower(fmt.Sprint(value)), strings.ToLower(fmt.Sprint(operand)))
+		return matched == (comparator == "containsIgnoreCase"), nil
+	case "startsWith":
+		return value != nil && strings.HasPrefix(fmt.Sprint(value), fmt.Sprint(operand)), nil
+	case "endsWith":
+		return value != nil && strings.HasSuffix(fmt.Sprint(value), fmt.Sprint(operand)), nil
+	}
+
+	// Ordering comparators: numbers, or times given as RFC 3339 or YYYY-MM-DD
+	if value == nil {
+		return false, nil
+	}
+	order, err := orderOf(value, operand)
+	if err != nil {
+		return false, err
+	}
+	switch comparator {
+	case "gt":
+		return order > 0, nil
+	case "gte":
+		return order >= 0, nil
+	case "lt":
+		return order < 0, nil
+	default:
+		return order <= 0, nil
+	}
+}
+
+func equal(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if x, ok := a.(float64); ok {
+		y, ok := b.(float64)
+		return ok && x == y
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// orderOf returns -1, 0, or 1 as a is less than, equal to, or greater than b
+func orderOf(a, b interface{}) (int, error) {
+	if x, ok := a.(float64); ok {
+		y, ok := b.(float64)
+		if !ok {
+			return 0, fmt.Errorf("cannot compare %v with %v", a, b)
+		}
+		switch {
+		case x < y:
+			return -1, nil
+		case x > y:
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	x, errA := parseFilterTime(fmt.Sprint(a))
+	y, errB := parseFilterTime(fmt.Sprint(b))
+	if errA != nil || errB != nil {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b)), nil
+	}
+	return x.Compare(y), nil
+}
+
+func parseFilterTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -0,0 +1,162 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Off logs nothing
+	Off = 0
+	// Requests logs each API operation with its status, timing, and
+	// rate-limit headers, plus retries, cache hits, and file transfers
+	Requests = 1
+	// Bodies also logs request variables and response bodies, with secrets
+	// redacted
+	Bodies = 2
+)
+
+// maxBodyLog caps how much of a response body is logged
+const maxBodyLog = 4 << 10
+
+var (
+	mu     sync.Mutex
+	level            = Off
+	writer io.Writer = os.Stderr
+)
+
+// SetLevel sets how much is logged (Off, Requests, or Bodies)
+func SetLevel(l int) {
+	mu.Lock()
+	defer mu.Unlock()
+	level = l
+}
+
+// SetOutput sets where log lines are written (default stderr)
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	writer = w
+}
+
+// Enabled reports whether messages at level l are logged
+func Enabled(l int) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return l > Off && level >= l
+}
+
+// Logf writes a timestamped line when messages at level l are logged
+func Logf(l int, format string, args ...interface{}) {
+	mu.Lock()
+	defer mu.Unlock()
+	if l <= Off || level < l {
+		return
+	}
+	fmt.Fprintf(writer, "%s debug: %s\n", time.Now().Format("15:04:05.000"), fmt.Sprintf(format, args...))
+}
+
+// ParseLevel reads a level from LINCTL_DEBUG: a number, or a boolean
+// ("true", "yes", "on") for Requests
+func ParseLevel(value string) int {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if n, err := strconv.Atoi(value); err == nil {
+		if n < Off {
+			return Off
+		}
+		return n
+	}
+	switch value {
+	case "true", "yes", "on":
+		return Requests
+	}
+	return Off
+}
+
+// secretKey matches the names of variables and fields that hold credentials
+var secretKey = regexp.MustCompile(`(?i)(token|secret|password|passphrase|authorization|api_?key|credential|signature)`)
+
+// secretValue matches Linear API keys and OAuth tokens wherever they appear
+var secretValue = regexp.MustCompile(`lin_(api|oauth)_[A-Za-z0-9]+`)
+
+// Redact returns a copy of a JSON-like value with the values of secret
+// fields, and anything that looks like a Linear credential, replaced
+func Redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			if secretKey.MatchString(key) {
+				redacted[key] = "[REDACTED]"
+			} else {
+				redacted[key] = Redact(field)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = Redact(item)
+		}
+		return redacted
+	case []string:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = Redact(item)
+		}
+		return redacted
+	case string:
+		return secretValue.ReplaceAllString(v, "[REDACTED]")
+	default:
+		return v
+	}
+}
+
+// JSON renders a value for the log, redacted and on one line
+func JSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	// Round trip so structs and typed maps are redacted too
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err == nil {
+		if redacted, err := json.Marshal(Redact(generic)); err == nil {
+			data = redacted
+		}
+	}
+	return string(data)
+}
+
+// Body renders a response body for the log, redacted and truncated
+func Body(body []byte) string {
+	var generic interface{}
+	text := string(body)
+	if err := json.Unmarshal(body, &generic); err == nil {
+		if redacted, err := json.Marshal(Redact(generic)); err == nil {
+			text = string(redacted)
+		}
+	} else {
+		text = secretValue.ReplaceAllString(text, "[REDACTED]")
+	}
+	if len(text) > maxBodyLog {
+		return fmt.Sprintf("%s... (%d bytes)", text[:maxBodyLog], len(body))
+	}
+	return text
+}
+
+// URL renders a URL for the log without its query string, which for
+// pre-signed upload and download URLs holds the signature
+func URL(rawURL string) string {
+	if i := strings.IndexAny(rawURL, "?#"); i >= 0 {
+		return rawURL[:i]
+	}
+	return rawURL
+}
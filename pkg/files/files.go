@@ -3,13 +3,20 @@ package files
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/httpclient"
 )
 
 // ImageInfo represents information about an image found in markdown
@@ -57,6 +64,12 @@ func ExtractImagesFromMarkdown(markdown string) []ImageInfo {
 // DownloadImage downloads an image from a URL and saves it to the specified path
 // authHeader is optional and will be used for authentication if provided (e.g., for Linear URLs)
 func DownloadImage(ctx context.Context, url string, outputPath string, authHeader string) error {
+	return DownloadFile(ctx, nil, url, outputPath, authHeader)
+}
+
+// DownloadFile downloads a URL to outputPath like DownloadImage, with the given
+// HTTP client (nil for one on the shared transport)
+func DownloadFile(ctx context.Context, client *http.Client, url string, outputPath string, authHeader string) error {
 	// Create HTTP request with context
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -69,8 +82,7 @@ func DownloadImage(ctx context.Context, url string, outputPath string, authHeade
 	}
 
 	// Execute request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := clientOrDefault(client).Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to download image: %w", err)
 	}
@@ -103,6 +115,110 @@ func DownloadImage(ctx context.Context, url string, outputPath string, authHeade
 	return nil
 }
 
+// DownloadOptions controls how DownloadImages fetches a batch of images
+type DownloadOptions struct {
+	// Workers is the maximum number of concurrent downloads (default 4)
+	Workers int
+	// AuthHeader is sent with each request when set (e.g., for Linear URLs)
+	AuthHeader string
+	// Client makes the requests (default: a client on the shared transport).
+	// api.Client.TransferClient adds Linear credentials and retries itself.
+	Client *http.Client
+	// Progress is called once per image as it finishes, successfully or not.
+	// Calls are serialized, so the callback does not need its own locking.
+	Progress func(result DownloadResult)
+}
+
+// DownloadResult describes the outcome of downloading a single image
+type DownloadResult struct {
+	Image ImageInfo
+	Path  string
+	Err   error
+}
+
+// DownloadImages downloads images into destDir using a bounded worker pool.
+// Results are returned in the same order as images; the returned error joins
+// every per-file failure and is nil only if all downloads succeeded.
+func DownloadImages(ctx context.Context, images []ImageInfo, destDir string, opts DownloadOptions) ([]DownloadResult, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+	if workers > len(images) {
+		workers = len(images)
+	}
+
+	// Assign file names up front so concurrent workers never race on the same path
+	results := make([]DownloadResult, len(images))
+	used := make(map[string]bool)
+	for i, img := range images {
+		name := ImageFilename(img, i)
+		ext := filepath.Ext(name)
+		base := strings.TrimSuffix(name, ext)
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%s-%d%s", base, n, ext)
+		}
+		used[name] = true
+		results[i] = DownloadResult{Image: img, Path: filepath.Join(destDir, name)}
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := &results[i]
+				result.Err = DownloadFile(ctx, opts.Client, result.Image.URL, result.Path, opts.AuthHeader)
+				if result.Err != nil {
+					result.Err = fmt.Errorf("failed to download %s: %w", result.Image.URL, result.Err)
+				}
+				if opts.Progress != nil {
+					progressMu.Lock()
+					opts.Progress(*result)
+					progressMu.Unlock()
+				}
+			}
+		}()
+	}
+
+schedule:
+	for i := range images {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			// Mark everything not yet scheduled as canceled
+			for j := i; j < len(images); j++ {
+				results[j].Err = fmt.Errorf("failed to download %s: %w", images[j].URL, ctx.Err())
+			}
+			break schedule
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// ImageFilename derives a local file name for an image, preferring its alt text
+func ImageFilename(img ImageInfo, index int) string {
+	ext := filepath.Ext(strings.SplitN(img.URL, "?", 2)[0])
+	if img.AltText != "" {
+		return SanitizeFilename(img.AltText) + ext
+	}
+	return fmt.Sprintf("image-%d%s", index+1, ext)
+}
+
 // UploadFileInfo contains information needed to upload a file to Linear
 type UploadFileInfo struct {
 	UploadURL   string
@@ -112,13 +228,93 @@ type UploadFileInfo struct {
 	Size        int64
 }
 
+// ProgressReporter receives progress updates while a transfer is running
+type ProgressReporter interface {
+	// Progress is called with the number of bytes transferred so far and the total size
+	Progress(transferred, total int64)
+}
+
+// UploadOptions controls streaming uploads to pre-signed URLs
+type UploadOptions struct {
+	// MaxAttempts is the number of times an upload is tried before giving up (default 3)
+	MaxAttempts int
+	// Progress receives byte-level progress updates when set
+	Progress ProgressReporter
+	// ContentType overrides the content type detected from the file, when set
+	ContentType string
+	// Image shrinks large images before they are uploaded (default DefaultImageOptions)
+	Image *ImageOptions
+	// Client makes the requests (default: a client on the shared transport)
+	Client *http.Client
+}
+
 // UploadToPresignedURL uploads file content to a pre-signed URL
 func UploadToPresignedURL(ctx context.Context, info *UploadFileInfo, fileContent []byte) error {
+	sized := *info
+	sized.Size = int64(len(fileContent))
+	return UploadStreamToPresignedURL(ctx, &sized, bytes.NewReader(fileContent), UploadOptions{})
+}
+
+// UploadStreamToPresignedURL streams content to a pre-signed URL without buffering it in memory.
+// info.Size must be the exact number of bytes in content. Failed attempts are retried; when the
+// target is a resumable upload session, only the bytes the server has not yet acknowledged are
+// re-sent, otherwise the upload restarts from the beginning.
+func UploadStreamToPresignedURL(ctx context.Context, info *UploadFileInfo, content io.ReadSeeker, opts UploadOptions) error {
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	resumable := supportsRangedUpload(info.UploadURL)
+
+	var offset int64
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			// Back off before retrying
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt-1) * time.Second):
+			}
+
+			offset = 0
+			if resumable {
+				if committed, err := queryUploadOffset(ctx, opts.Client, info); err == nil {
+					offset = committed
+				}
+			}
+		}
+
+		retry, err := uploadRange(ctx, opts.Client, info, content, offset, opts.Progress)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+
+	return lastErr
+}
+
+// uploadRange sends content from offset to the end and reports whether a failure is worth retrying
+func uploadRange(ctx context.Context, client *http.Client, info *UploadFileInfo, content io.ReadSeeker, offset int64, progress ProgressReporter) (bool, error) {
+	if _, err := content.Seek(offset, io.SeekStart); err != nil {
+		return false, fmt.Errorf("failed to rewind upload content: %w", err)
+	}
+
+	var body io.Reader = io.LimitReader(content, info.Size-offset)
+	if progress != nil {
+		body = &progressReader{reader: body, transferred: offset, total: info.Size, progress: progress}
+	}
+
 	// Create PUT request
-	req, err := http.NewRequestWithContext(ctx, "PUT", info.UploadURL, bytes.NewReader(fileContent))
+	req, err := http.NewRequestWithContext(ctx, "PUT", info.UploadURL, body)
 	if err != nil {
-		return fmt.Errorf("failed to create upload request: %w", err)
+		return false, fmt.Errorf("failed to create upload request: %w", err)
 	}
+	req.ContentLength = info.Size - offset
 
 	// Set required headers
 	req.Header.Set("Content-Type", info.ContentType)
@@ -129,21 +325,95 @@ func UploadToPresignedURL(ctx context.Context, info *UploadFileInfo, fileContent
 		req.Header.Set(key, value)
 	}
 
+	if offset > 0 {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, info.Size-1, info.Size))
+	}
+
 	// Execute upload
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := clientOrDefault(client).Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to upload file: %w", err)
+		return ctx.Err() == nil, fmt.Errorf("failed to upload file: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("upload failed with status %s: %s", resp.Status, string(body))
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		respBody, _ := io.ReadAll(resp.Body)
+		retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+		return retry, fmt.Errorf("upload failed with status %s: %s", resp.Status, string(respBody))
 	}
 
-	return nil
+	return false, nil
+}
+
+// supportsRangedUpload reports whether the upload URL is a resumable session
+// (e.g., a GCS resumable upload) that accepts Content-Range continuation requests.
+// Plain pre-signed PUT URLs must always be re-uploaded from the start.
+func supportsRangedUpload(uploadURL string) bool {
+	parsed, err := url.Parse(uploadURL)
+	if err != nil {
+		return false
+	}
+	return parsed.Query().Get("upload_id") != ""
+}
+
+// queryUploadOffset asks a resumable upload session how many bytes it has committed
+func queryUploadOffset(ctx context.Context, client *http.Client, info *UploadFileInfo) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, "PUT", info.UploadURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.ContentLength = 0
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", info.Size))
+
+	resp, err := clientOrDefault(client).Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// 308 Resume Incomplete carries a Range header like "bytes=0-12345"
+	if resp.StatusCode != http.StatusPermanentRedirect {
+		return 0, fmt.Errorf("upload session status: %s", resp.Status)
+	}
+	rangeHeader := resp.Header.Get("Range")
+	if rangeHeader == "" {
+		return 0, nil
+	}
+	_, last, found := strings.Cut(strings.TrimPrefix(rangeHeader, "bytes="), "-")
+	if !found {
+		return 0, fmt.Errorf("invalid Range header: %s", rangeHeader)
+	}
+	end, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Range header: %s", rangeHeader)
+	}
+	return end + 1, nil
+}
+
+// clientOrDefault returns client, or a client on the shared transport when it is nil
+func clientOrDefault(client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+	return httpclient.NewTransfer()
+}
+
+// progressReader reports bytes read through a ProgressReporter
+type progressReader struct {
+	reader      io.Reader
+	transferred int64
+	total       int64
+	progress    ProgressReporter
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.transferred += int64(n)
+		r.progress.Progress(r.transferred, r.total)
+	}
+	return n, err
 }
 
 // ReadFile reads a file from the filesystem
@@ -155,6 +425,56 @@ func ReadFile(filePath string) ([]byte, error) {
 	return data, nil
 }
 
+// ContentTypes maps lowercase file extensions to content types. It is
+// consulted when a file's first bytes don't identify it, and for formats
+// (SVG, Office documents, etc.) that sniffing reports only generically.
+// Use RegisterContentType to add to it.
+var ContentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".svg":  "image/svg+xml",
+	".bmp":  "image/bmp",
+	".ico":  "image/x-icon",
+	".heic": "image/heic",
+	".avif": "image/avif",
+	".mp4":  "video/mp4",
+	".webm": "video/webm",
+	".mov":  "video/quicktime",
+	".mp3":  "audio/mpeg",
+	".wav":  "audio/wav",
+	".pdf":  "application/pdf",
+	".zip":  "application/zip",
+	".gz":   "application/gzip",
+	".tar":  "application/x-tar",
+	".json": "application/json",
+	".xml":  "application/xml",
+	".yaml": "application/yaml",
+	".yml":  "application/yaml",
+	".csv":  "text/csv",
+	".md":   "text/markdown",
+	".txt":  "text/plain",
+	".log":  "text/plain",
+	".html": "text/html",
+	".css":  "text/css",
+	".js":   "text/javascript",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+// RegisterContentType maps a file extension (with or without the leading
+// dot) to a content type, replacing any existing mapping
+func RegisterContentType(ext, contentType string) {
+	ext = strings.ToLower(ext)
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	ContentTypes[ext] = contentType
+}
+
 // GetFileInfo returns file metadata
 func GetFileInfo(filePath string) (size int64, contentType string, err error) {
 	info, err := os.Stat(filePath)
@@ -164,36 +484,45 @@ func GetFileInfo(filePath string) (size int64, contentType string, err error) {
 
 	size = info.Size()
 
-	// Determine content type from extension
-	ext := strings.ToLower(filepath.Ext(filePath))
-	switch ext {
-	case ".jpg", ".jpeg":
-		contentType = "image/jpeg"
-	case ".png":
-		contentType = "image/png"
-	case ".gif":
-		contentType = "image/gif"
-	case ".webp":
-		contentType = "image/webp"
-	case ".svg":
-		contentType = "image/svg+xml"
-	case ".bmp":
-		contentType = "image/bmp"
-	case ".mp4":
-		contentType = "video/mp4"
-	case ".webm":
-		contentType = "video/webm"
-	case ".mov":
-		contentType = "video/quicktime"
-	case ".pdf":
-		contentType = "application/pdf"
-	default:
-		contentType = "application/octet-stream"
+	contentType, err = DetectContentType(filePath)
+	if err != nil {
+		return 0, "", err
 	}
 
 	return size, contentType, nil
 }
 
+// DetectContentType determines a file's content type from its first 512
+// bytes, falling back to the extension table when sniffing only finds
+// generic text or binary data, and to application/octet-stream when neither
+// knows the file
+func DetectContentType(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	sniffed := http.DetectContentType(head[:n])
+	byExtension := ContentTypes[strings.ToLower(filepath.Ext(filePath))]
+
+	switch mediaType, _, _ := strings.Cut(sniffed, ";"); mediaType {
+	case "application/octet-stream", "application/zip", "text/plain", "text/xml":
+		// Too generic to trust over the extension: Office documents are zip
+		// files, SVG is XML, and Markdown, CSV, and JSON all sniff as text
+		if byExtension != "" {
+			return byExtension, nil
+		}
+	}
+	return sniffed, nil
+}
+
 // SanitizeFilename creates a safe filename from a URL or alt text
 func SanitizeFilename(name string) string {
 	// Replace invalid characters with underscores
@@ -208,6 +537,29 @@ func SanitizeFilename(name string) string {
 	return safe
 }
 
+// IsLocalImagePath reports whether an image reference points at the local filesystem
+// rather than a remote URL (http, https, data URIs, etc.)
+func IsLocalImagePath(ref string) bool {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return false
+	}
+	// Windows drive letters and file:// URLs are local even though they look like schemes
+	if filepath.VolumeName(ref) != "" || strings.HasPrefix(strings.ToLower(ref), "file://") {
+		return true
+	}
+	return !regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`).MatchString(ref)
+}
+
+// RewriteImageURLs replaces image URLs in markdown using the given old -> new mapping
+func RewriteImageURLs(markdown string, replacements map[string]string) string {
+	for oldURL, newURL := range replacements {
+		markdown = strings.ReplaceAll(markdown, "]("+oldURL+")", "]("+newURL+")")
+		markdown = strings.ReplaceAll(markdown, `src="`+oldURL+`"`, `src="`+newURL+`"`)
+	}
+	return markdown
+}
+
 // InjectImageIntoMarkdown adds an image reference to markdown content
 func InjectImageIntoMarkdown(markdown, imageURL, altText string) string {
 	if altText == "" {
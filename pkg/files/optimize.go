@@ -0,0 +1,260 @@
+package files
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImageOptions controls how OptimizeImage shrinks images before upload
+type ImageOptions struct {
+	// MaxDimension is the largest width or height kept; bigger images are
+	// scaled down to fit (default 2048)
+	MaxDimension int
+	// Quality is the JPEG quality, from 1 to 100, used when re-encoding (default 85)
+	Quality int
+	// Threshold is the file size in bytes above which images that already fit
+	// MaxDimension are re-encoded anyway (default 1 MiB)
+	Threshold int64
+}
+
+// DefaultImageOptions is used by uploads that don't set their own image
+// options; nil leaves images untouched
+var DefaultImageOptions *ImageOptions
+
+// OptimizeImage writes a smaller copy of a JPEG or PNG image to a temporary
+// directory and returns its path, along with a function that removes it.
+// Images larger than opts.MaxDimension are scaled down to fit, and images
+// that fit but are larger than opts.Threshold are re-encoded. Opaque PNGs are
+// re-encoded as JPEG (changing the file's extension); PNGs with transparency
+// stay PNG. JPEG orientation metadata is applied to the pixels, since
+// re-encoding drops it.
+//
+// Other files, images that need no work, and images the re-encode would make
+// larger are returned unchanged, with a cleanup function that does nothing.
+func OptimizeImage(filePath string, opts ImageOptions) (string, func(), error) {
+	noop := func() {}
+	if opts.MaxDimension <= 0 {
+		opts.MaxDimension = 2048
+	}
+	if opts.Quality <= 0 || opts.Quality > 100 {
+		opts.Quality = 85
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = 1 << 20
+	}
+
+	contentType, err := DetectContentType(filePath)
+	if err != nil {
+		return "", noop, err
+	}
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return filePath, noop, nil
+	}
+
+	data, err := ReadFile(filePath)
+	if err != nil {
+		return "", noop, err
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to read image: %w", err)
+	}
+	oversized := config.Width > opts.MaxDimension || config.Height > opts.MaxDimension
+	if !oversized && int64(len(data)) <= opts.Threshold {
+		return filePath, noop, nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to decode image: %w", err)
+	}
+	img := toRGBA(src)
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+	if oversized {
+		img = fitWithin(img, opts.MaxDimension)
+	}
+
+	var encoded bytes.Buffer
+	ext := ".jpg"
+	if contentType == "image/png" && !img.Opaque() {
+		ext = ".png"
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		err = encoder.Encode(&encoded, img)
+	} else {
+		err = jpeg.Encode(&encoded, img, &jpeg.Options{Quality: opts.Quality})
+	}
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to encode image: %w", err)
+	}
+	if !oversized && encoded.Len() >= len(data) {
+		return filePath, noop, nil
+	}
+
+	dir, err := os.MkdirTemp("", "linctl-image-")
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	// Keep the original name so the upload is named the same, apart from
+	// the extension when the format changed
+	base := filepath.Base(filePath)
+	name := strings.TrimSuffix(base, filepath.Ext(base)) + ext
+	if contentType == "image/jpeg" {
+		name = base
+	}
+	optimized := filepath.Join(dir, name)
+	if err := os.WriteFile(optimized, encoded.Bytes(), 0600); err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("failed to write optimized image: %w", err)
+	}
+	return optimized, cleanup, nil
+}
+
+// toRGBA converts any image to an RGBA image with its origin at (0, 0)
+func toRGBA(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+// fitWithin scales img down, keeping its aspect ratio, so that neither side
+// is larger than maxDimension. Each output pixel is the average of the input
+// pixels it covers, which keeps downscaled screenshots and photos smooth.
+func fitWithin(img *image.RGBA, maxDimension int) *image.RGBA {
+	srcW, srcH := img.Bounds().Dx(), img.Bounds().Dy()
+	dstW, dstH := maxDimension, maxDimension
+	if srcW >= srcH {
+		dstH = max(1, srcH*maxDimension/srcW)
+	} else {
+		dstW = max(1, srcW*maxDimension/srcH)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := y*srcH/dstH, max((y+1)*srcH/dstH, y*srcH/dstH+1)
+		for x := 0; x < dstW; x++ {
+			x0, x1 := x*srcW/dstW, max((x+1)*srcW/dstW, x*srcW/dstW+1)
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := img.Pix[sy*img.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// applyOrientation turns img upright according to an EXIF orientation (1-8)
+func applyOrientation(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // flip horizontally
+				dx, dy = w-1-x, y
+			case 3: // rotate 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // flip vertically
+				dx, dy = x, h-1-y
+			case 5: // transpose
+				dx, dy = y, x
+			case 6: // rotate 90° clockwise
+				dx, dy = h-1-y, x
+			case 7: // transverse
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotate 90° counter-clockwise
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dy*dst.Stride+dx*4:dy*dst.Stride+dx*4+4], img.Pix[y*img.Stride+x*4:y*img.Stride+x*4+4])
+		}
+	}
+	return dst
+}
+
+// jpegOrientation reads the EXIF orientation tag from JPEG data, returning 1
+// (upright) when there is none
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data) && data[i] == 0xFF; {
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if marker == 0xDA || length < 2 || i+2+length > len(data) {
+			// Image data starts; metadata comes before it
+			break
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation finds the orientation tag (0x0112) in the first IFD of
+// TIFF-formatted EXIF data
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < count; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 1
+}
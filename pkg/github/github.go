@@ -0,0 +1,276 @@
+// Package github is a small client for the parts of the GitHub REST API that
+// linctl's importer reads: a repository's issues, their comments, and the
+// images uploaded to them.
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/httpclient"
+)
+
+// BaseURL is the public GitHub API; GITHUB_API_URL points the client at GitHub Enterprise
+const BaseURL = "https://api.github.com"
+
+// Client reads from the GitHub REST API with a personal access token
+type Client struct {
+	httpClient *http.Client
+	token      string
+	baseURL    string
+}
+
+// User is a GitHub account
+type User struct {
+	Login string `json:"login"`
+}
+
+// Label is a GitHub issue label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// Milestone is a GitHub milestone
+type Milestone struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// Issue is a GitHub issue. Pull requests, which the issues API also returns,
+// have PullRequest set.
+type Issue struct {
+	ID          int64            `json:"id"`
+	Number      int              `json:"number"`
+	Title       string           `json:"title"`
+	Body        string           `json:"body"`
+	State       string           `json:"state"`
+	StateReason string           `json:"state_reason"`
+	HTMLURL     string           `json:"html_url"`
+	User        *User            `json:"user"`
+	Assignees   []User           `json:"assignees"`
+	Labels      []Label          `json:"labels"`
+	Milestone   *Milestone       `json:"milestone"`
+	Comments    int              `json:"comments"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	ClosedAt    *time.Time       `json:"closed_at"`
+	PullRequest *json.RawMessage `json:"pull_request,omitempty"`
+}
+
+// Comment is a comment on a GitHub issue
+type Comment struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	HTMLURL   string    `json:"html_url"`
+	User      *User     `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IssueOptions filters ListIssues
+type IssueOptions struct {
+	State     string // open, closed, or all
+	Labels    []string
+	Milestone string
+	Since     *time.Time
+	Limit     int // 0 for no limit
+}
+
+// NewClient returns a client authenticating with token
+func NewClient(token string) *Client {
+	baseURL := BaseURL
+	if custom := os.Getenv("GITHUB_API_URL"); custom != "" {
+		baseURL = strings.TrimRight(custom, "/")
+	}
+	return &Client{
+		httpClient: httpclient.New(),
+		token:      token,
+		baseURL:    baseURL,
+	}
+}
+
+// TokenFromEnv returns the token in GITHUB_TOKEN or GH_TOKEN
+func TokenFromEnv() string {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+	return os.Getenv("GH_TOKEN")
+}
+
+// ListIssues returns the issues of repo ("owner/name"), oldest first, leaving
+// out pull requests
+func (c *Client) ListIssues(ctx context.Context, repo string, opts IssueOptions) ([]Issue, error) {
+	query := url.Values{}
+	query.Set("state", opts.State)
+	if opts.State == "" {
+		query.Set("state", "open")
+	}
+	query.Set("sort", "created")
+	query.Set("direction", "asc")
+	query.Set("per_page", "100")
+	if len(opts.Labels) > 0 {
+		query.Set("labels", strings.Join(opts.Labels, ","))
+	}
+	if opts.Milestone != "" {
+		query.Set("milestone", opts.Milestone)
+	}
+	if opts.Since != nil {
+		query.Set("since", opts.Since.UTC().Format(time.RFC3339))
+	}
+
+	var issues []Issue
+	next := fmt.Sprintf("%s/repos/%s/issues?%s", c.baseURL, repo, query.Encode())
+	for next != "" {
+		var page []Issue
+		var err error
+		next, err = c.get(ctx, next, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range page {
+			if issue.PullRequest != nil {
+				continue
+			}
+			issues = append(issues, issue)
+			if opts.Limit > 0 && len(issues) >= opts.Limit {
+				return issues, nil
+			}
+		}
+	}
+	return issues, nil
+}
+
+// ListComments returns the comments on an issue, oldest first
+func (c *Client) ListComments(ctx context.Context, repo string, number int) ([]Comment, error) {
+	var comments []Comment
+	next := fmt.Sprintf("%s/repos/%s/issues/%d/comments?per_page=100", c.baseURL, repo, number)
+	for next != "" {
+		var page []Comment
+		var err error
+		next, err = c.get(ctx, next, &page)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, page...)
+	}
+	return comments, nil
+}
+
+// Download saves a file uploaded to GitHub (such as an image in an issue body)
+// to path and returns its content type. The token is sent so images in
+// private repositories can be read.
+func (c *Client) Download(ctx context.Context, fileURL, path string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return "", err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", fileURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download %s: HTTP %d", fileURL, resp.StatusCode)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to download %s: %w", fileURL, err)
+	}
+	return resp.Header.Get("Content-Type"), file.Close()
+}
+
+// assetHosts are where GitHub stores files attached to issues and comments
+var assetHosts = []string{
+	"user-images.githubusercontent.com",
+	"private-user-images.githubusercontent.com",
+	"github.com/user-attachments/",
+}
+
+// IsAssetURL reports whether u is a file uploaded to GitHub
+func IsAssetURL(u string) bool {
+	for _, host := range assetHosts {
+		if strings.Contains(u, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlImagePattern matches the <img src="..."> tags GitHub writes for pasted images
+var htmlImagePattern = regexp.MustCompile(`<img[^>]*\ssrc="([^"]+)"[^>]*>`)
+
+// htmlAltPattern matches an <img> tag's alt text
+var htmlAltPattern = regexp.MustCompile(`\salt="([^"]*)"`)
+
+// ConvertHTMLImages rewrites <img> tags as markdown images, since Linear
+// doesn't render HTML in descriptions
+func ConvertHTMLImages(body string) string {
+	return htmlImagePattern.ReplaceAllStringFunc(body, func(tag string) string {
+		src := htmlImagePattern.FindStringSubmatch(tag)[1]
+		alt := "image"
+		if match := htmlAltPattern.FindStringSubmatch(tag); match != nil && match[1] != "" {
+			alt = match[1]
+		}
+		return fmt.Sprintf("![%s](%s)", alt, src)
+	})
+}
+
+// linkPattern pulls the next page out of a Link header
+var linkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// get decodes a JSON response into v and returns the URL of the next page, if any
+func (c *Client) get(ctx context.Context, u string, v interface{}) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("GitHub request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read GitHub response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return "", fmt.Errorf("GitHub API error (HTTP %d): %s", resp.StatusCode, apiErr.Message)
+		}
+		return "", fmt.Errorf("GitHub API error (HTTP %d)", resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return "", fmt.Errorf("failed to parse GitHub response: %w", err)
+	}
+
+	if match := linkPattern.FindStringSubmatch(resp.Header.Get("Link")); match != nil {
+		return match[1], nil
+	}
+	return "", nil
+}
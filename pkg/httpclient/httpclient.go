@@ -0,0 +1,143 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Settings configure every HTTP connection linctl makes: to the Linear API,
+// for file uploads and downloads, and to GitHub and Jira when importing
+type Settings struct {
+	// ConnectTimeout bounds establishing a connection, including the TLS handshake
+	ConnectTimeout time.Duration
+	// Timeout bounds a whole API request, response included (0 for none).
+	// File transfers are not subject to it, since large files take a while.
+	Timeout time.Duration
+	// Proxy is the proxy URL for all requests. Empty uses the HTTPS_PROXY,
+	// HTTP_PROXY, and NO_PROXY environment variables; "none" connects directly.
+	Proxy string
+	// CABundle is a PEM file of certificate authorities trusted in addition
+	// to the system's, e.g. for a TLS-inspecting corporate proxy
+	CABundle string
+	// DisableHTTP2 talks HTTP/1.1 only
+	DisableHTTP2 bool
+	// DisableKeepAlives opens a new connection for every request
+	DisableKeepAlives bool
+}
+
+// DefaultSettings are used until Configure is called
+var DefaultSettings = Settings{
+	ConnectTimeout: 10 * time.Second,
+	Timeout:        30 * time.Second,
+}
+
+var (
+	mu        sync.Mutex
+	settings  = DefaultSettings
+	transport *http.Transport
+)
+
+// Configure validates settings and applies them to the clients returned
+// from then on
+func Configure(s Settings) error {
+	t, err := NewTransport(s)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	settings = s
+	transport = t
+	return nil
+}
+
+// Transport returns the transport shared by every client, so connections
+// to the same host are pooled and reused
+func Transport() *http.Transport {
+	mu.Lock()
+	defer mu.Unlock()
+	if transport == nil {
+		// DefaultSettings can't fail: there is no proxy URL or CA bundle to read
+		transport, _ = NewTransport(settings)
+	}
+	return transport
+}
+
+// New returns a client for API requests, bounded by the configured Timeout
+func New() *http.Client {
+	mu.Lock()
+	timeout := settings.Timeout
+	mu.Unlock()
+	return &http.Client{Transport: Transport(), Timeout: timeout}
+}
+
+// NewTransfer returns a client for file uploads and downloads, which have
+// no overall timeout; they are bounded by their context instead
+func NewTransfer() *http.Client {
+	return &http.Client{Transport: Transport()}
+}
+
+// NewTransport builds a transport from settings
+func NewTransport(s Settings) (*http.Transport, error) {
+	connectTimeout := s.ConnectTimeout
+	if connectTimeout <= 0 {
+		connectTimeout = DefaultSettings.ConnectTimeout
+	}
+	dialer := &net.Dialer{
+		Timeout:   connectTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	t := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     !s.DisableHTTP2,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   connectTimeout,
+		ExpectContinueTimeout: time.Second,
+		DisableKeepAlives:     s.DisableKeepAlives,
+	}
+	if s.DisableHTTP2 {
+		// A non-nil, empty map stops the transport from upgrading to HTTP/2
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	switch proxy := strings.TrimSpace(s.Proxy); strings.ToLower(proxy) {
+	case "":
+	case "none", "direct":
+		t.Proxy = nil
+	default:
+		proxyURL, err := url.Parse(proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL '%s'", proxy)
+		}
+		t.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if s.CABundle != "" {
+		pem, err := os.ReadFile(s.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", s.CABundle)
+		}
+		t.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,134 @@
+// Package ical writes iCalendar (RFC 5545) files that calendar apps such as
+// Google Calendar and Apple Calendar can import or subscribe to.
+package ical
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest content line RFC 5545 allows before folding
+const maxLineOctets = 75
+
+// Calendar is a VCALENDAR of events
+type Calendar struct {
+	Name   string
+	ProdID string
+	Events []Event
+}
+
+// Event is a VEVENT. All-day events use only the date of Start and End; End
+// is the last day of the event, inclusive.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	URL         string
+	Categories  []string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+}
+
+// Write encodes the calendar, stamping every event with now
+func (c *Calendar) Write(w io.Writer, now time.Time) error {
+	e := &encoder{w: w}
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", c.ProdID)
+	e.line("CALSCALE", "GREGORIAN")
+	e.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		e.line("X-WR-CALNAME", escape(c.Name))
+	}
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, event := range c.Events {
+		e.line("BEGIN", "VEVENT")
+		e.line("UID", escape(event.UID))
+		e.line("DTSTAMP", stamp)
+		if event.AllDay {
+			end := event.End
+			if end.IsZero() {
+				end = event.Start
+			}
+			// DTEND is exclusive for all-day events
+			e.line("DTSTART;VALUE=DATE", event.Start.Format("20060102"))
+			e.line("DTEND;VALUE=DATE", end.AddDate(0, 0, 1).Format("20060102"))
+			e.line("TRANSP", "TRANSPARENT")
+		} else {
+			e.line("DTSTART", event.Start.UTC().Format("20060102T150405Z"))
+			if !event.End.IsZero() {
+				e.line("DTEND", event.End.UTC().Format("20060102T150405Z"))
+			}
+		}
+		e.line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			e.line("DESCRIPTION", escape(event.Description))
+		}
+		if event.URL != "" {
+			e.line("URL", event.URL)
+		}
+		if len(event.Categories) > 0 {
+			categories := make([]string, len(event.Categories))
+			for i, category := range event.Categories {
+				categories[i] = escape(category)
+			}
+			e.line("CATEGORIES", strings.Join(categories, ","))
+		}
+		e.line("END", "VEVENT")
+	}
+
+	e.line("END", "VCALENDAR")
+	return e.err
+}
+
+// escape escapes a TEXT value
+func escape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(text)
+}
+
+// encoder writes content lines, folding long ones and keeping the first error
+type encoder struct {
+	w   io.Writer
+	err error
+}
+
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = io.WriteString(e.w, fold(name+":"+value)+"\r\n")
+}
+
+// fold splits a content line into lines of at most maxLineOctets octets,
+// never inside a UTF-8 sequence; continuation lines start with a space
+func fold(line string) string {
+	if len(line) <= maxLineOctets {
+		return line
+	}
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space counts towards the next line's length
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -0,0 +1,312 @@
+// Package jira reads Jira issues from the XML that Jira's "Export XML" search
+// view produces, either from a saved file or fetched from a Jira site, and
+// converts Jira wiki markup and rendered HTML to markdown.
+package jira
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/httpclient"
+)
+
+// Issue is one <item> of an XML export
+type Issue struct {
+	Key            string
+	Summary        string
+	Description    string // markdown
+	Type           string
+	Status         string
+	StatusCategory string // new, indeterminate, or done
+	Resolution     string
+	Priority       string
+	Assignee       *User
+	Reporter       *User
+	Labels         []string
+	Parent         string // key of the parent issue, for sub-tasks
+	EpicLink       string // key of the epic
+	Sprints        []string
+	StoryPoints    *float64
+	Due            *time.Time
+	Link           string
+	Created        time.Time
+	Updated        time.Time
+	Comments       []Comment
+	Attachments    []Attachment
+}
+
+// IsEpic reports whether the issue is an epic
+func (i Issue) IsEpic() bool {
+	return strings.EqualFold(i.Type, "Epic")
+}
+
+// BaseURL is the Jira site the issue was exported from
+func (i Issue) BaseURL() string {
+	if before, _, found := strings.Cut(i.Link, "/browse/"); found {
+		return before
+	}
+	return ""
+}
+
+// User is a Jira user as exported: a display name plus an account ID or username
+type User struct {
+	Name     string
+	Username string
+}
+
+// Comment is a comment on a Jira issue
+type Comment struct {
+	ID      string
+	Author  string
+	Created time.Time
+	Body    string // markdown
+}
+
+// Attachment is a file attached to a Jira issue
+type Attachment struct {
+	ID   string
+	Name string
+}
+
+// xmlUser is an <assignee> or <reporter> element
+type xmlUser struct {
+	Name      string `xml:",chardata"`
+	Username  string `xml:"username,attr"`
+	AccountID string `xml:"accountid,attr"`
+}
+
+type xmlItem struct {
+	Link        string `xml:"link"`
+	Key         string `xml:"key"`
+	Summary     string `xml:"summary"`
+	Description string `xml:"description"`
+	Type        string `xml:"type"`
+	Status      string `xml:"status"`
+	Category    struct {
+		Key string `xml:"key,attr"`
+	} `xml:"statusCategory"`
+	Resolution string   `xml:"resolution"`
+	Priority   string   `xml:"priority"`
+	Assignee   xmlUser  `xml:"assignee"`
+	Reporter   xmlUser  `xml:"reporter"`
+	Labels     []string `xml:"labels>label"`
+	Parent     string   `xml:"parent"`
+	Created    string   `xml:"created"`
+	Updated    string   `xml:"updated"`
+	Due        string   `xml:"due"`
+	Comments   []struct {
+		ID      string `xml:"id,attr"`
+		Author  string `xml:"author,attr"`
+		Created string `xml:"created,attr"`
+		Body    string `xml:",chardata"`
+	} `xml:"comments>comment"`
+	Attachments []struct {
+		ID   string `xml:"id,attr"`
+		Name string `xml:"name,attr"`
+	} `xml:"attachments>attachment"`
+	CustomFields []struct {
+		Key    string   `xml:"key,attr"`
+		Name   string   `xml:"customfieldname"`
+		Values []string `xml:"customfieldvalues>customfieldvalue"`
+	} `xml:"customfields>customfield"`
+}
+
+// ParseXML reads the issues of an XML export
+func ParseXML(r io.Reader) ([]Issue, error) {
+	var doc struct {
+		Items []xmlItem `xml:"channel>item"`
+	}
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse Jira XML: %w", err)
+	}
+
+	issues := make([]Issue, 0, len(doc.Items))
+	for _, item := range doc.Items {
+		issue := Issue{
+			Key:            strings.TrimSpace(item.Key),
+			Summary:        strings.TrimSpace(item.Summary),
+			Type:           strings.TrimSpace(item.Type),
+			Status:         strings.TrimSpace(item.Status),
+			StatusCategory: item.Category.Key,
+			Resolution:     strings.TrimSpace(item.Resolution),
+			Priority:       strings.TrimSpace(item.Priority),
+			Assignee:       item.Assignee.user(),
+			Reporter:       item.Reporter.user(),
+			Labels:         item.Labels,
+			Parent:         strings.TrimSpace(item.Parent),
+			Link:           strings.TrimSpace(item.Link),
+			Created:        parseTime(item.Created),
+			Updated:        parseTime(item.Updated),
+			Description:    ToMarkdown(item.Description),
+		}
+		if due := parseTime(item.Due); !due.IsZero() {
+			issue.Due = &due
+		}
+		for _, comment := range item.Comments {
+			issue.Comments = append(issue.Comments, Comment{
+				ID:      comment.ID,
+				Author:  comment.Author,
+				Created: parseTime(comment.Created),
+				Body:    ToMarkdown(comment.Body),
+			})
+		}
+		for _, attachment := range item.Attachments {
+			issue.Attachments = append(issue.Attachments, Attachment{ID: attachment.ID, Name: attachment.Name})
+		}
+
+		for _, field := range item.CustomFields {
+			if len(field.Values) == 0 {
+				continue
+			}
+			name := strings.ToLower(strings.TrimSpace(field.Name))
+			switch {
+			case strings.HasSuffix(field.Key, ":gh-sprint") || name == "sprint":
+				for _, sprint := range field.Values {
+					issue.Sprints = append(issue.Sprints, strings.TrimSpace(sprint))
+				}
+			case strings.HasSuffix(field.Key, ":gh-epic-link") || name == "epic link":
+				issue.EpicLink = strings.TrimSpace(field.Values[0])
+			case name == "story points" || name == "story point estimate":
+				var points float64
+				if _, err := fmt.Sscan(field.Values[0], &points); err == nil {
+					issue.StoryPoints = &points
+				}
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+func (u xmlUser) user() *User {
+	name := strings.TrimSpace(u.Name)
+	if name == "" || strings.EqualFold(name, "Unassigned") {
+		return nil
+	}
+	return &User{Name: name, Username: firstNonEmpty(u.Username, u.AccountID)}
+}
+
+// parseTime reads Jira's RFC 1123 style dates, such as "Mon, 6 Jan 2025 10:00:00 +0000"
+func parseTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"Mon, 2 Jan 2006 15:04:05 -0700", time.RFC1123Z, time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Client downloads XML exports and attachments from a Jira site. Jira Cloud
+// takes an email and API token; Jira Server and Data Center take a personal
+// access token.
+type Client struct {
+	httpClient *http.Client
+	email      string
+	token      string
+}
+
+// NewClientFromEnv returns a client using JIRA_EMAIL and JIRA_API_TOKEN, or
+// JIRA_TOKEN as a bearer token
+func NewClientFromEnv() *Client {
+	client := &Client{httpClient: &http.Client{Transport: httpclient.Transport(), Timeout: 60 * time.Second}}
+	if token := os.Getenv("JIRA_API_TOKEN"); token != "" {
+		client.email = os.Getenv("JIRA_EMAIL")
+		client.token = token
+	} else {
+		client.token = os.Getenv("JIRA_TOKEN")
+	}
+	return client
+}
+
+// SearchURL is the XML export of the issues matching jql on the site at baseURL
+func SearchURL(baseURL, jql string, max int) string {
+	query := url.Values{}
+	query.Set("jqlQuery", jql)
+	query.Set("tempMax", fmt.Sprint(max))
+	return strings.TrimRight(baseURL, "/") + "/sr/jira.issueviews:searchrequest-xml/temp/SearchRequest.xml?" + query.Encode()
+}
+
+// FetchXML downloads and parses an XML export
+func (c *Client) FetchXML(ctx context.Context, exportURL string) ([]Issue, error) {
+	resp, err := c.get(ctx, exportURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ParseXML(resp.Body)
+}
+
+// Download saves an attachment to path and returns its content type
+func (c *Client) Download(ctx context.Context, fileURL, path string) (string, error) {
+	resp, err := c.get(ctx, fileURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to download %s: %w", fileURL, err)
+	}
+	return resp.Header.Get("Content-Type"), file.Close()
+}
+
+// get sends an authenticated GET, failing on anything but 200
+func (c *Client) get(ctx context.Context, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c.email != "" && c.token != "":
+		req.SetBasicAuth(c.email, c.token)
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Jira request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("Jira request to %s failed: HTTP %d", u, resp.StatusCode)
+	}
+	return resp, nil
+}
+
+// AttachmentURL is where the issue's attachment with the given file name can
+// be downloaded, or "" when the issue has no such attachment
+func (i Issue) AttachmentURL(name string) string {
+	base := i.BaseURL()
+	for _, attachment := range i.Attachments {
+		if attachment.Name == name && base != "" {
+			return fmt.Sprintf("%s/secure/attachment/%s/%s", base, attachment.ID, url.PathEscape(attachment.Name))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,225 @@
+package jira
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// htmlTagPattern spots text that Jira exported as rendered HTML rather than wiki markup
+var htmlTagPattern = regexp.MustCompile(`(?i)<(p|br|div|span|a|b|i|em|strong|ul|ol|li|pre|tt|code|h[1-6]|img|table)\b[^>]*>`)
+
+// ToMarkdown converts a description or comment, in either wiki markup or the
+// HTML Jira renders it to, to markdown
+func ToMarkdown(text string) string {
+	if htmlTagPattern.MatchString(text) {
+		return HTMLToMarkdown(text)
+	}
+	return WikiToMarkdown(text)
+}
+
+var (
+	wikiHeadingPattern  = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	wikiListPattern     = regexp.MustCompile(`^([*#-]+)\s+(.*)$`)
+	wikiCodePattern     = regexp.MustCompile(`^\{(code|noformat)(?::([^}|]*))?[^}]*\}(.*)$`)
+	wikiBoldPattern     = regexp.MustCompile(`(^|[^\w*])\*([^*\s](?:[^*]*[^*\s])?)\*($|[^\w*])`)
+	wikiStrikePattern   = regexp.MustCompile(`(^|\s)-([^-\s](?:[^-]*[^-\s])?)-($|\s)`)
+	wikiMonoPattern     = regexp.MustCompile(`\{\{(.+?)\}\}`)
+	wikiLinkPattern     = regexp.MustCompile(`\[([^\[\]|]+)\|([^\[\]]+)\]`)
+	wikiBareLinkPattern = regexp.MustCompile(`\[((?:https?|mailto):[^\[\]\s]+)\]`)
+	wikiMentionPattern  = regexp.MustCompile(`\[~(?:accountid:)?([^\]]+)\]`)
+	wikiImagePattern    = regexp.MustCompile(`!([^!\s|][^!|\n]*?\.(?i:png|jpe?g|gif|webp|svg|bmp)|https?://[^!\s|]+)(?:\|[^!]*)?!`)
+	wikiColorPattern    = regexp.MustCompile(`\{color(?::[^}]*)?\}`)
+)
+
+// WikiToMarkdown converts Jira wiki markup to markdown: headings, lists,
+// code and quote blocks, tables, text effects, links, mentions, and images.
+// Images keep the attachment name as their URL; see Issue.AttachmentURL.
+func WikiToMarkdown(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var out []string
+	inCode, inQuote, inTable := "", false, false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		// Code blocks are copied through untouched
+		if inCode != "" {
+			if idx := strings.Index(line, "{"+inCode+"}"); idx >= 0 {
+				if before := line[:idx]; strings.TrimSpace(before) != "" {
+					out = append(out, before)
+				}
+				out = append(out, "```")
+				inCode = ""
+			} else {
+				out = append(out, line)
+			}
+			continue
+		}
+		if match := wikiCodePattern.FindStringSubmatch(trimmed); match != nil {
+			out = append(out, "```"+strings.TrimSpace(match[2]))
+			rest := match[3]
+			if idx := strings.Index(rest, "{"+match[1]+"}"); idx >= 0 {
+				// A one-line block: {code}x = 1{code}
+				out = append(out, rest[:idx], "```")
+				continue
+			}
+			if rest != "" {
+				out = append(out, rest)
+			}
+			inCode = match[1]
+			continue
+		}
+
+		if trimmed == "{quote}" {
+			inQuote = !inQuote
+			continue
+		}
+
+		// Tables: a ||header|| row gets a separator row after it
+		if strings.HasPrefix(trimmed, "||") || (strings.HasPrefix(trimmed, "|") && inTable) || (strings.HasPrefix(trimmed, "|") && strings.HasSuffix(trimmed, "|") && len(trimmed) > 1) {
+			header := strings.HasPrefix(trimmed, "||")
+			sep := "|"
+			if header {
+				sep = "||"
+			}
+			cells := strings.Split(strings.Trim(trimmed, "|"), sep)
+			for i, cell := range cells {
+				cells[i] = wikiInline(strings.TrimSpace(cell))
+			}
+			if !inTable && !header {
+				// Markdown tables need a header; give a header-less table an empty one
+				out = append(out, "|"+strings.Repeat("   |", len(cells)), "|"+strings.Repeat(" --- |", len(cells)))
+			}
+			out = append(out, "| "+strings.Join(cells, " | ")+" |")
+			if header {
+				out = append(out, "|"+strings.Repeat(" --- |", len(cells)))
+			}
+			inTable = true
+			continue
+		}
+		inTable = false
+
+		var converted string
+		switch {
+		case trimmed == "----":
+			converted = "---"
+		case strings.HasPrefix(trimmed, "bq. "):
+			converted = "> " + wikiInline(strings.TrimPrefix(trimmed, "bq. "))
+		default:
+			if match := wikiHeadingPattern.FindStringSubmatch(trimmed); match != nil {
+				converted = strings.Repeat("#", int(match[1][0]-'0')) + " " + wikiInline(match[2])
+			} else if match := wikiListPattern.FindStringSubmatch(trimmed); match != nil && (match[1][0] != '-' || len(match[1]) == 1) {
+				depth := len(match[1]) - 1
+				marker := "-"
+				if strings.HasSuffix(match[1], "#") {
+					marker = "1."
+				}
+				converted = strings.Repeat("  ", depth) + marker + " " + wikiInline(match[2])
+			} else {
+				converted = wikiInline(line)
+			}
+		}
+		if inQuote {
+			converted = "> " + converted
+		}
+		out = append(out, converted)
+	}
+	if inCode != "" {
+		out = append(out, "```")
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// wikiInline converts the text effects, links, and images within a line
+func wikiInline(text string) string {
+	// Monospace first, so nothing inside it is converted
+	var spans []string
+	text = wikiMonoPattern.ReplaceAllStringFunc(text, func(match string) string {
+		spans = append(spans, "`"+wikiMonoPattern.FindStringSubmatch(match)[1]+"`")
+		return fmt.Sprintf("\x00%d\x00", len(spans)-1)
+	})
+
+	text = wikiColorPattern.ReplaceAllString(text, "")
+	text = wikiMentionPattern.ReplaceAllString(text, "@$1")
+	text = wikiImagePattern.ReplaceAllString(text, "![$1]($1)")
+	text = wikiLinkPattern.ReplaceAllString(text, "[$1]($2)")
+	text = wikiBareLinkPattern.ReplaceAllString(text, "<$1>")
+	text = wikiBoldPattern.ReplaceAllString(text, "$1**$2**$3")
+	text = wikiStrikePattern.ReplaceAllString(text, "$1~~$2~~$3")
+
+	for i, span := range spans {
+		text = strings.Replace(text, fmt.Sprintf("\x00%d\x00", i), span, 1)
+	}
+	return text
+}
+
+var (
+	htmlPrePattern     = regexp.MustCompile(`(?is)<pre[^>]*>(.*?)</pre>`)
+	htmlLinkPattern    = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	htmlImgPattern     = regexp.MustCompile(`(?is)<img\s[^>]*src="([^"]*)"[^>]*>`)
+	htmlAltPattern     = regexp.MustCompile(`(?i)\salt="([^"]*)"`)
+	htmlHeadingPattern = regexp.MustCompile(`(?is)<h([1-6])[^>]*>(.*?)</h[1-6]>`)
+	htmlAnyTagPattern  = regexp.MustCompile(`(?s)<[^>]+>`)
+	blankLinesPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlReplacements turn simple tags into their markdown equivalents
+var htmlReplacements = []struct {
+	pattern *regexp.Regexp
+	with    string
+}{
+	{regexp.MustCompile(`(?i)<br\s*/?>`), "\n"},
+	{regexp.MustCompile(`(?i)</p>|</div>|</ul>|</ol>|</table>`), "\n\n"},
+	{regexp.MustCompile(`(?i)</?(b|strong)>`), "**"},
+	{regexp.MustCompile(`(?i)</?(i|em)>`), "_"},
+	{regexp.MustCompile(`(?i)</?(del|s)>`), "~~"},
+	{regexp.MustCompile(`(?i)</?(tt|code)>`), "`"},
+	{regexp.MustCompile(`(?i)<li[^>]*>`), "- "},
+	{regexp.MustCompile(`(?i)</li>|</tr>`), "\n"},
+	{regexp.MustCompile(`(?i)<t[hd][^>]*>`), " | "},
+	{regexp.MustCompile(`(?i)<hr\s*/?>`), "\n---\n"},
+	{regexp.MustCompile(`(?i)<blockquote[^>]*>`), "\n> "},
+}
+
+// HTMLToMarkdown converts the HTML Jira renders descriptions and comments to
+// into markdown, keeping paragraphs, text effects, lists, links, images, and
+// preformatted blocks and dropping everything else
+func HTMLToMarkdown(text string) string {
+	var blocks []string
+	text = htmlPrePattern.ReplaceAllStringFunc(text, func(match string) string {
+		code := htmlAnyTagPattern.ReplaceAllString(htmlPrePattern.FindStringSubmatch(match)[1], "")
+		blocks = append(blocks, "```\n"+strings.Trim(html.UnescapeString(code), "\n")+"\n```")
+		return fmt.Sprintf("\x00%d\x00", len(blocks)-1)
+	})
+
+	text = strings.NewReplacer("\r\n", " ", "\n", " ").Replace(text)
+	text = htmlHeadingPattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := htmlHeadingPattern.FindStringSubmatch(match)
+		return "\n\n" + strings.Repeat("#", int(parts[1][0]-'0')) + " " + parts[2] + "\n\n"
+	})
+	text = htmlImgPattern.ReplaceAllStringFunc(text, func(tag string) string {
+		src := htmlImgPattern.FindStringSubmatch(tag)[1]
+		alt := "image"
+		if match := htmlAltPattern.FindStringSubmatch(tag); match != nil && match[1] != "" {
+			alt = match[1]
+		}
+		return fmt.Sprintf("![%s](%s)", alt, src)
+	})
+	text = htmlLinkPattern.ReplaceAllString(text, "[$2]($1)")
+	for _, r := range htmlReplacements {
+		text = r.pattern.ReplaceAllString(text, r.with)
+	}
+	text = html.UnescapeString(htmlAnyTagPattern.ReplaceAllString(text, ""))
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = strings.Join(lines, "\n")
+	for i, block := range blocks {
+		text = strings.Replace(text, fmt.Sprintf("\x00%d\x00", i), "\n\n"+block+"\n\n", 1)
+	}
+	return strings.TrimSpace(blankLinesPattern.ReplaceAllString(text, "\n\n"))
+}
@@ -0,0 +1,232 @@
+package output
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdTableRowPattern = regexp.MustCompile(`^\s*\|.*\|\s*$`)
+	mdTableSepPattern = regexp.MustCompile(`^\s*\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?\s*$`)
+	mdSafeURLPattern  = regexp.MustCompile(`(?i)^(https?:|mailto:|#|[^:]*$)`)
+)
+
+// mdList is an open <ul> or <ol> while rendering HTML
+type mdList struct {
+	indent int
+	tag    string
+}
+
+// MarkdownToHTML renders markdown as an HTML fragment: headings, paragraphs,
+// lists, task lists, quotes, code blocks, tables, rules, and inline emphasis,
+// code, links, and images. All text is escaped, and links other than http,
+// mailto, and relative ones are dropped.
+func MarkdownToHTML(markdown string) string {
+	var b strings.Builder
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	var paragraph, quote []string
+	var lists []mdList
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + strings.Join(paragraph, "<br>\n") + "</p>\n")
+			paragraph = nil
+		}
+	}
+	flushQuote := func() {
+		if len(quote) > 0 {
+			b.WriteString("<blockquote><p>" + strings.Join(quote, "<br>\n") + "</p></blockquote>\n")
+			quote = nil
+		}
+	}
+	closeLists := func(indent int) {
+		for len(lists) > 0 && lists[len(lists)-1].indent >= indent {
+			b.WriteString("</li></" + lists[len(lists)-1].tag + ">\n")
+			lists = lists[:len(lists)-1]
+		}
+	}
+	flush := func() {
+		flushParagraph()
+		flushQuote()
+		closeLists(0)
+	}
+	listItem := func(indent int, tag, body string) {
+		flushParagraph()
+		flushQuote()
+		if len(lists) > 0 && lists[len(lists)-1].indent > indent {
+			closeLists(indent + 1)
+		}
+		top := len(lists) - 1
+		switch {
+		case top >= 0 && lists[top].indent == indent && lists[top].tag == tag:
+			b.WriteString("</li>\n<li>")
+		case top >= 0 && lists[top].indent == indent:
+			closeLists(indent)
+			fallthrough
+		default:
+			b.WriteString("<" + tag + ">\n<li>")
+			lists = append(lists, mdList{indent: indent, tag: tag})
+		}
+		b.WriteString(body)
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		// Code blocks are copied verbatim, escaped
+		if match := mdFencePattern.FindStringSubmatch(line); match != nil {
+			flush()
+			class := ""
+			if match[2] != "" {
+				class = fmt.Sprintf(` class="language-%s"`, html.EscapeString(match[2]))
+			}
+			var code []string
+			for i++; i < len(lines); i++ {
+				if end := mdFencePattern.FindStringSubmatch(lines[i]); end != nil && end[1] == match[1] {
+					break
+				}
+				code = append(code, html.EscapeString(lines[i]))
+			}
+			fmt.Fprintf(&b, "<pre><code%s>%s</code></pre>\n", class, strings.Join(code, "\n"))
+			continue
+		}
+
+		// A table is a row of cells followed by a separator row
+		if mdTableRowPattern.MatchString(line) && i+1 < len(lines) && mdTableSepPattern.MatchString(lines[i+1]) {
+			flush()
+			b.WriteString("<table>\n<thead><tr>")
+			for _, cell := range tableCells(line) {
+				b.WriteString("<th>" + htmlInline(cell) + "</th>")
+			}
+			b.WriteString("</tr></thead>\n<tbody>\n")
+			for i += 2; i < len(lines) && mdTableRowPattern.MatchString(lines[i]); i++ {
+				b.WriteString("<tr>")
+				for _, cell := range tableCells(lines[i]) {
+					b.WriteString("<td>" + htmlInline(cell) + "</td>")
+				}
+				b.WriteString("</tr>\n")
+			}
+			b.WriteString("</tbody>\n</table>\n")
+			i--
+			continue
+		}
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			flushParagraph()
+			flushQuote()
+		case mdHeadingPattern.MatchString(line):
+			flush()
+			match := mdHeadingPattern.FindStringSubmatch(line)
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", len(match[1]), htmlInline(match[2]), len(match[1]))
+		case mdRulePattern.MatchString(line):
+			flush()
+			b.WriteString("<hr>\n")
+		case mdQuotePattern.MatchString(line):
+			flushParagraph()
+			closeLists(0)
+			quote = append(quote, htmlInline(mdQuotePattern.FindStringSubmatch(line)[1]))
+		case mdTaskPattern.MatchString(line):
+			match := mdTaskPattern.FindStringSubmatch(line)
+			checked := ""
+			if match[2] != " " {
+				checked = " checked"
+			}
+			listItem(len(match[1]), "ul", fmt.Sprintf(`<input type="checkbox" disabled%s> %s`, checked, htmlInline(match[3])))
+		case mdBulletPattern.MatchString(line):
+			match := mdBulletPattern.FindStringSubmatch(line)
+			listItem(len(match[1]), "ul", htmlInline(match[2]))
+		case mdOrderedPattern.MatchString(line):
+			match := mdOrderedPattern.FindStringSubmatch(line)
+			listItem(len(match[1]), "ol", htmlInline(match[3]))
+		default:
+			if len(lists) > 0 && strings.HasPrefix(line, " ") {
+				// An indented line continues the list item above it
+				b.WriteString("<br>\n" + htmlInline(strings.TrimSpace(line)))
+				continue
+			}
+			flushQuote()
+			closeLists(0)
+			paragraph = append(paragraph, htmlInline(line))
+		}
+	}
+	flush()
+
+	return b.String()
+}
+
+// tableCells splits a markdown table row into its cells
+func tableCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	cells := strings.Split(row, "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return cells
+}
+
+// htmlInline escapes a line and renders its code spans, links, images, and
+// emphasis. Code spans and URLs are left untouched by the emphasis rules.
+func htmlInline(text string) string {
+	parts := strings.Split(text, "`")
+	if len(parts)%2 == 0 {
+		// An unmatched backtick is literal
+		parts[len(parts)-2] += "`" + parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+
+	var b strings.Builder
+	for i, part := range parts {
+		if i%2 == 1 {
+			b.WriteString("<code>" + html.EscapeString(part) + "</code>")
+			continue
+		}
+
+		// Links and images become placeholders until emphasis is done
+		var tags []string
+		hold := func(tag string) string {
+			tags = append(tags, tag)
+			return fmt.Sprintf("\x00%d\x00", len(tags)-1)
+		}
+		part = mdImagePattern.ReplaceAllStringFunc(part, func(m string) string {
+			match := mdImagePattern.FindStringSubmatch(m)
+			if !mdSafeURLPattern.MatchString(match[2]) {
+				return hold(html.EscapeString(match[1]))
+			}
+			return hold(fmt.Sprintf(`<img src="%s" alt="%s">`, html.EscapeString(match[2]), html.EscapeString(match[1])))
+		})
+		part = mdLinkPattern.ReplaceAllStringFunc(part, func(m string) string {
+			match := mdLinkPattern.FindStringSubmatch(m)
+			if !mdSafeURLPattern.MatchString(match[2]) {
+				return hold(html.EscapeString(match[1]))
+			}
+			return hold(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(match[2]), html.EscapeString(match[1])))
+		})
+		part = mdAutoLinkPattern.ReplaceAllStringFunc(part, func(m string) string {
+			link := html.EscapeString(mdAutoLinkPattern.FindStringSubmatch(m)[1])
+			return hold(fmt.Sprintf(`<a href="%s">%s</a>`, link, link))
+		})
+
+		part = html.EscapeString(part)
+		part = mdBoldPattern.ReplaceAllStringFunc(part, func(m string) string {
+			match := mdBoldPattern.FindStringSubmatch(m)
+			return "<strong>" + match[1] + match[2] + "</strong>"
+		})
+		part = mdItalicPattern.ReplaceAllStringFunc(part, func(m string) string {
+			match := mdItalicPattern.FindStringSubmatch(m)
+			return match[1] + match[3] + "<em>" + match[2] + match[4] + "</em>"
+		})
+		part = mdStrikePattern.ReplaceAllStringFunc(part, func(m string) string {
+			return "<del>" + mdStrikePattern.FindStringSubmatch(m)[1] + "</del>"
+		})
+
+		for i, tag := range tags {
+			part = strings.Replace(part, fmt.Sprintf("\x00%d\x00", i), tag, 1)
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
@@ -0,0 +1,150 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// markdownRuleWidth is the width of a rendered horizontal rule
+const markdownRuleWidth = 60
+
+var (
+	mdHeadingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdFencePattern    = regexp.MustCompile("^\\s*(```|~~~)\\s*(\\S*)")
+	mdRulePattern     = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	mdQuotePattern    = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdTaskPattern     = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	mdBulletPattern   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdOrderedPattern  = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	mdImagePattern    = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)[^)]*\)`)
+	mdLinkPattern     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	mdAutoLinkPattern = regexp.MustCompile(`<(https?://[^>]+)>`)
+	mdBoldPattern     = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdItalicPattern   = regexp.MustCompile(`(^|[^*\w])\*([^*\s][^*]*)\*|(^|[^_\w])_([^_\s][^_]*)_`)
+	mdStrikePattern   = regexp.MustCompile(`~~([^~]+)~~`)
+)
+
+// RenderMarkdown formats markdown for the terminal: headings, lists, task
+// lists, quotes, code blocks, rules, and inline emphasis, code, and links.
+// Colors are dropped automatically when output is not a terminal.
+func RenderMarkdown(markdown string) string {
+	var b strings.Builder
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	codeColor := color.New(color.FgYellow)
+	faint := color.New(color.FgWhite, color.Faint)
+	fence := ""
+
+	for _, line := range lines {
+		// Code blocks are printed verbatim, indented
+		if match := mdFencePattern.FindStringSubmatch(line); match != nil && (fence == "" || match[1] == fence) {
+			if fence == "" {
+				fence = match[1]
+				if match[2] != "" {
+					b.WriteString(faint.Sprint("  " + match[2]))
+					b.WriteString("\n")
+				}
+			} else {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			b.WriteString("  " + codeColor.Sprint(line) + "\n")
+			continue
+		}
+
+		switch {
+		case mdHeadingPattern.MatchString(line):
+			match := mdHeadingPattern.FindStringSubmatch(line)
+			text := renderInline(match[2])
+			switch len(match[1]) {
+			case 1:
+				b.WriteString(color.New(color.FgCyan, color.Bold, color.Underline).Sprint(text))
+			case 2:
+				b.WriteString(color.New(color.FgCyan, color.Bold).Sprint(text))
+			default:
+				b.WriteString(color.New(color.Bold).Sprint(text))
+			}
+		case mdRulePattern.MatchString(line):
+			b.WriteString(faint.Sprint(strings.Repeat("─", markdownRuleWidth)))
+		case mdQuotePattern.MatchString(line):
+			match := mdQuotePattern.FindStringSubmatch(line)
+			b.WriteString(faint.Sprint("│ ") + color.New(color.Italic).Sprint(renderInline(match[1])))
+		case mdTaskPattern.MatchString(line):
+			match := mdTaskPattern.FindStringSubmatch(line)
+			box := "☐"
+			if match[2] != " " {
+				box = color.New(color.FgGreen).Sprint("☑")
+			}
+			b.WriteString(match[1] + "  " + box + " " + renderInline(match[3]))
+		case mdBulletPattern.MatchString(line):
+			match := mdBulletPattern.FindStringSubmatch(line)
+			b.WriteString(match[1] + "  • " + renderInline(match[2]))
+		case mdOrderedPattern.MatchString(line):
+			match := mdOrderedPattern.FindStringSubmatch(line)
+			b.WriteString(match[1] + "  " + match[2] + " " + renderInline(match[3]))
+		default:
+			b.WriteString(renderInline(line))
+		}
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// renderInline formats code spans, links, images, and emphasis within a line.
+// Code spans are left untouched by the other rules.
+func renderInline(text string) string {
+	parts := strings.Split(text, "`")
+	if len(parts)%2 == 0 {
+		// An unmatched backtick is literal
+		parts[len(parts)-2] += "`" + parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+
+	codeColor := color.New(color.FgYellow)
+	linkColor := color.New(color.FgBlue, color.Underline)
+	faint := color.New(color.FgWhite, color.Faint)
+
+	var b strings.Builder
+	for i, part := range parts {
+		if i%2 == 1 {
+			b.WriteString(codeColor.Sprint(part))
+			continue
+		}
+		part = mdImagePattern.ReplaceAllStringFunc(part, func(m string) string {
+			match := mdImagePattern.FindStringSubmatch(m)
+			label := match[1]
+			if label == "" {
+				label = "image"
+			}
+			return "🖼  " + label + " " + faint.Sprint("("+match[2]+")")
+		})
+		part = mdLinkPattern.ReplaceAllStringFunc(part, func(m string) string {
+			match := mdLinkPattern.FindStringSubmatch(m)
+			if match[1] == match[2] {
+				return linkColor.Sprint(match[2])
+			}
+			return linkColor.Sprint(match[1]) + " " + faint.Sprint("("+match[2]+")")
+		})
+		part = mdAutoLinkPattern.ReplaceAllStringFunc(part, func(m string) string {
+			return linkColor.Sprint(mdAutoLinkPattern.FindStringSubmatch(m)[1])
+		})
+		part = mdBoldPattern.ReplaceAllStringFunc(part, func(m string) string {
+			match := mdBoldPattern.FindStringSubmatch(m)
+			return color.New(color.Bold).Sprint(match[1] + match[2])
+		})
+		part = mdItalicPattern.ReplaceAllStringFunc(part, func(m string) string {
+			match := mdItalicPattern.FindStringSubmatch(m)
+			return match[1] + match[3] + color.New(color.Italic).Sprint(match[2]+match[4])
+		})
+		part = mdStrikePattern.ReplaceAllStringFunc(part, func(m string) string {
+			return color.New(color.CrossedOut).Sprint(mdStrikePattern.FindStringSubmatch(m)[1])
+		})
+		b.WriteString(part)
+	}
+	return b.String()
+}
@@ -1,23 +1,44 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
 	"strings"
 
 	"github.com/fatih/color"
-	"github.com/olekukonko/tablewriter"
 )
 
 // TableData represents data for table output
 type TableData struct {
 	Headers []string
 	Rows    [][]string
+	// Wrap names the columns (by header) whose long values wrap onto more
+	// lines instead of being truncated; nil means a Title column
+	Wrap []string
 }
 
-// JSON outputs data as JSON
+// jsonLines switches JSON output to newline-delimited JSON
+var jsonLines bool
+
+// SetJSONLines enables newline-delimited JSON output: lists are written one
+// compact element per line and any other value as a single compact line
+func SetJSONLines(enabled bool) {
+	jsonLines = enabled
+}
+
+// JSON outputs data as JSON. Nil lists are written as empty arrays.
 func JSON(data interface{}) {
+	data = normalizeList(data)
+
+	if jsonLines {
+		JSONLines(data)
+		return
+	}
+
 	jsonData, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
@@ -26,12 +47,49 @@ func JSON(data interface{}) {
 	fmt.Println(string(jsonData))
 }
 
+// JSONLines writes each element of a list as one compact JSON line; non-list
+// values are written as a single line
+func JSONLines(data interface{}) {
+	encoder := json.NewEncoder(os.Stdout)
+	value := reflect.ValueOf(data)
+	if isList(value) {
+		for i := 0; i < value.Len(); i++ {
+			if err := encoder.Encode(value.Index(i).Interface()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		return
+	}
+	if err := encoder.Encode(data); err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// isList reports whether a value is a slice or array other than raw bytes
+// (json.RawMessage is a []byte and must be written as-is)
+func isList(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		return value.Type().Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
+
+// normalizeList replaces a nil slice with an empty one so lists always encode as []
+func normalizeList(data interface{}) interface{} {
+	value := reflect.ValueOf(data)
+	if value.Kind() == reflect.Slice && value.IsNil() && isList(value) {
+		return reflect.MakeSlice(value.Type(), 0, 0).Interface()
+	}
+	return data
+}
+
 // Error outputs an error message
 func Error(message string, plaintext, jsonOut bool) {
 	if jsonOut {
-		JSON(map[string]interface{}{
-			"error": message,
-		})
+		ErrorDetail(message, nil, plaintext, jsonOut)
 	} else if plaintext {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", message)
 	} else {
@@ -39,6 +97,34 @@ func Error(message string, plaintext, jsonOut bool) {
 	}
 }
 
+// ErrorDetail outputs an error message; JSON output also carries details,
+// such as the error's code, next to "error". JSON errors go to stderr like
+// the others, so stdout only ever holds results; "code" is "error" unless
+// details say otherwise.
+func ErrorDetail(message string, details map[string]interface{}, plaintext, jsonOut bool) {
+	if !jsonOut {
+		Error(message, plaintext, jsonOut)
+		return
+	}
+	data := map[string]interface{}{"error": message, "code": "error"}
+	for key, value := range details {
+		data[key] = value
+	}
+
+	var jsonData []byte
+	var err error
+	if jsonLines {
+		jsonData, err = json.Marshal(data)
+	} else {
+		jsonData, err = json.MarshalIndent(data, "", "  ")
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", message)
+		return
+	}
+	fmt.Fprintln(os.Stderr, string(jsonData))
+}
+
 // Success outputs a success message
 func Success(message string, plaintext, jsonOut bool) {
 	if jsonOut {
@@ -62,7 +148,7 @@ func Table(data TableData, plaintext, jsonOut bool) {
 			item := make(map[string]interface{})
 			for j, header := range data.Headers {
 				if j < len(row) {
-					item[strings.ToLower(header)] = row[j]
+					item[strings.ToLower(header)] = ansiPattern.ReplaceAllString(row[j], "")
 				}
 			}
 			jsonData[i] = item
@@ -76,38 +162,31 @@ func Table(data TableData, plaintext, jsonOut bool) {
 		if len(data.Headers) > 0 {
 			fmt.Println(strings.Join(data.Headers, "\t"))
 		}
+		// Cells may be colored for the terminal; plaintext never is
 		for _, row := range data.Rows {
-			fmt.Println(strings.Join(row, "\t"))
+			fmt.Println(ansiPattern.ReplaceAllString(strings.Join(row, "\t"), ""))
 		}
 		return
 	}
 
-	// Rich table output
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader(data.Headers)
-	table.SetAutoWrapText(false)
-	table.SetAutoFormatHeaders(true)
-	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.SetCenterSeparator("")
-	table.SetColumnSeparator("")
-	table.SetRowSeparator("")
-	table.SetHeaderLine(false)
-	table.SetBorder(false)
-	table.SetTablePadding("   ")
-	table.SetNoWhiteSpace(true)
-
-	// Add color to headers
-	coloredHeaders := make([]string, len(data.Headers))
-	for i, header := range data.Headers {
-		coloredHeaders[i] = color.New(color.FgCyan, color.Bold).Sprint(header)
-	}
-	table.SetHeader(coloredHeaders)
-
-	for _, row := range data.Rows {
-		table.Append(row)
-	}
-	table.Render()
+	// Rich table output, fitted to the terminal
+	renderTable(os.Stdout, data, TerminalWidth())
+}
+
+// Delimited writes rows as CSV (or TSV when sep is a tab), quoting fields per RFC 4180.
+// The header row is skipped when headers is empty.
+func Delimited(w io.Writer, headers []string, rows [][]string, sep rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = sep
+	if len(headers) > 0 {
+		if err := writer.Write(headers); err != nil {
+			return err
+		}
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
 }
 
 // Info outputs an informational message
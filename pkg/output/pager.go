@@ -0,0 +1,96 @@
+package output
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// terminalStdout is the stdout linctl started with. A pager takes over
+// os.Stdout, but tables are still fitted to this terminal.
+var terminalStdout = os.Stdout
+
+// pager is the running pager, if any
+var pager struct {
+	cmd      *exec.Cmd
+	pipe     *os.File
+	colorOut io.Writer
+}
+
+// StdoutIsTerminal reports whether stdout is a terminal rather than a file or
+// pipe
+func StdoutIsTerminal() bool {
+	stat, err := terminalStdout.Stat()
+	return err == nil && (stat.Mode()&os.ModeCharDevice) != 0
+}
+
+// PagerCommand is the pager to use: the setting (from config) when given,
+// else $PAGER, else less. "" and "cat" mean no pager.
+func PagerCommand(setting string) string {
+	if setting != "" {
+		return setting
+	}
+	if env, ok := os.LookupEnv("PAGER"); ok {
+		return env
+	}
+	return "less"
+}
+
+// StartPager sends stdout through a pager until StopPager, like git and gh:
+// only when stdout is a terminal, and with less quitting straight away when
+// everything fits on one screen (LESS=FRX unless $LESS is set). When the
+// pager can't be started, output isn't paged and the error says why.
+func StartPager(command string) error {
+	if pager.cmd != nil || !StdoutIsTerminal() {
+		return nil
+	}
+	args := strings.Fields(command)
+	if len(args) == 0 || args[0] == "cat" {
+		return nil
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+	if _, ok := os.LookupEnv("LV"); !ok {
+		cmd.Env = append(cmd.Env, "LV=-c")
+	}
+	cmd.Stdout = terminalStdout
+	cmd.Stderr = os.Stderr
+
+	reader, pipe, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = reader
+	err = cmd.Start()
+	reader.Close()
+	if err != nil {
+		pipe.Close()
+		return err
+	}
+
+	pager.cmd = cmd
+	pager.pipe = pipe
+	pager.colorOut = color.Output
+	os.Stdout = pager.pipe
+	color.Output = pager.pipe
+	return nil
+}
+
+// StopPager closes the pager's input and waits for the user to quit it
+func StopPager() {
+	if pager.cmd == nil {
+		return
+	}
+	os.Stdout = terminalStdout
+	color.Output = pager.colorOut
+	_ = pager.pipe.Close()
+	_ = pager.cmd.Wait()
+	pager.cmd = nil
+}
@@ -0,0 +1,346 @@
+package output
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// ErrPickCanceled is returned by Pick when the user quits without choosing
+var ErrPickCanceled = errors.New("nothing picked")
+
+// pickerRows is how many choices the picker shows at once
+const pickerRows = 10
+
+// PickerItem is one choice offered by Pick
+type PickerItem struct {
+	// Label is shown and matched against, e.g. "ENG-123  Fix login"
+	Label string
+	// Detail is shown faint after the label and matched too, e.g. a state
+	Detail string
+}
+
+// pickerMatch is an item that matches the query, with the positions of the
+// label's runes that matched
+type pickerMatch struct {
+	index     int
+	score     int
+	positions map[int]bool
+}
+
+// picker is the state of a Pick session
+type picker struct {
+	prompt  string
+	items   []PickerItem
+	query   []rune
+	matches []pickerMatch
+	cursor  int
+	top     int
+	out     io.Writer
+}
+
+// Pick lets the user choose one of items on the terminal, like fzf: typing
+// narrows the list to fuzzy matches, the arrow keys (or Ctrl-P/Ctrl-N) move,
+// Enter chooses, and Esc or Ctrl-C cancels with ErrPickCanceled. It returns
+// the index of the chosen item. The picker is drawn on stderr and reads
+// stdin; where the terminal can't be put into raw mode, it asks for a number
+// instead.
+func Pick(prompt string, items []PickerItem) (int, error) {
+	if len(items) == 0 {
+		return -1, fmt.Errorf("nothing to pick from")
+	}
+	p := &picker{prompt: prompt, items: items, out: os.Stderr}
+	p.filter()
+
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return p.pickByNumber(bufio.NewReader(os.Stdin))
+	}
+	defer restore()
+	defer fmt.Fprint(p.out, "\r\x1b[J")
+
+	buf := make([]byte, 64)
+	for {
+		p.render()
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return -1, err
+		}
+		if done, index, err := p.handleKeys(buf[:n]); done {
+			return index, err
+		}
+	}
+}
+
+// handleKeys applies what one read from the terminal typed. done is true
+// once an item is chosen or the picker is canceled.
+func (p *picker) handleKeys(keys []byte) (done bool, index int, err error) {
+	if len(keys) == 1 && keys[0] == 0x1b {
+		return true, -1, ErrPickCanceled
+	}
+	for len(keys) > 0 {
+		// Arrow and paging keys arrive as escape sequences: ESC [ or ESC O,
+		// then parameters, then a final byte from @ to ~
+		if keys[0] == 0x1b {
+			end := min(2, len(keys))
+			if end == 2 && (keys[1] == '[' || keys[1] == 'O') {
+				for end < len(keys) && (keys[end] < '@' || keys[end] > '~') {
+					end++
+				}
+				end = min(end+1, len(keys))
+			}
+			switch string(keys[:end]) {
+			case "\x1b[A", "\x1bOA":
+				p.move(-1)
+			case "\x1b[B", "\x1bOB":
+				p.move(1)
+			case "\x1b[5~":
+				p.move(-pickerRows)
+			case "\x1b[6~":
+				p.move(pickerRows)
+			}
+			keys = keys[end:]
+			continue
+		}
+
+		r, size := utf8.DecodeRune(keys)
+		keys = keys[size:]
+		switch r {
+		case '\r', '\n':
+			if len(p.matches) == 0 {
+				continue
+			}
+			return true, p.matches[p.cursor].index, nil
+		case 0x03, 0x07: // Ctrl-C, Ctrl-G
+			return true, -1, ErrPickCanceled
+		case 0x10, 0x0b: // Ctrl-P, Ctrl-K
+			p.move(-1)
+		case 0x0e, 0x09: // Ctrl-N, Tab
+			p.move(1)
+		case 0x7f, 0x08: // Backspace
+			if len(p.query) > 0 {
+				p.query = p.query[:len(p.query)-1]
+				p.filter()
+			}
+		case 0x15: // Ctrl-U
+			p.query = nil
+			p.filter()
+		case 0x17: // Ctrl-W
+			q := strings.TrimRightFunc(string(p.query), unicode.IsSpace)
+			p.query = []rune(q[:strings.LastIndexFunc(q, unicode.IsSpace)+1])
+			p.filter()
+		default:
+			if unicode.IsPrint(r) {
+				p.query = append(p.query, r)
+				p.filter()
+			}
+		}
+	}
+	return false, -1, nil
+}
+
+// move moves the cursor by delta matches, keeping it in the visible rows
+func (p *picker) move(delta int) {
+	p.cursor += delta
+	if p.cursor >= len(p.matches) {
+		p.cursor = len(p.matches) - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+	if p.cursor < p.top {
+		p.top = p.cursor
+	}
+	if p.cursor >= p.top+pickerRows {
+		p.top = p.cursor - pickerRows + 1
+	}
+}
+
+// filter matches every item against the query, best matches first
+func (p *picker) filter() {
+	terms := strings.Fields(strings.ToLower(string(p.query)))
+	p.matches = p.matches[:0]
+	for i, item := range p.items {
+		if match, ok := fuzzyMatch(terms, item); ok {
+			match.index = i
+			p.matches = append(p.matches, match)
+		}
+	}
+	sort.SliceStable(p.matches, func(i, j int) bool { return p.matches[i].score > p.matches[j].score })
+	p.cursor, p.top = 0, 0
+}
+
+// render draws the prompt line, the visible matches, and a match count
+// below the cursor, then puts the cursor back at the end of the query
+func (p *picker) render() {
+	width := stdoutWidth()
+	if width <= 0 {
+		width = 80
+	}
+
+	var b strings.Builder
+	prompt := color.New(color.FgCyan, color.Bold).Sprint(p.prompt+"> ") + string(p.query)
+	b.WriteString("\r\x1b[J")
+	b.WriteString(Truncate(prompt, width-1))
+
+	lines := 0
+	for i := p.top; i < len(p.matches) && i < p.top+pickerRows; i++ {
+		b.WriteString("\r\n")
+		b.WriteString(Truncate(p.row(p.matches[i], i == p.cursor), width-1))
+		lines++
+	}
+	b.WriteString("\r\n")
+	b.WriteString(color.New(color.Faint).Sprintf("  %d/%d", len(p.matches), len(p.items)))
+	lines++
+
+	fmt.Fprintf(&b, "\x1b[%dA\r", lines)
+	if column := DisplayWidth(prompt); column > 0 && column < width {
+		fmt.Fprintf(&b, "\x1b[%dC", column)
+	}
+	fmt.Fprint(p.out, b.String())
+}
+
+// row formats a match, highlighting the matched characters and marking the
+// one under the cursor
+func (p *picker) row(match pickerMatch, selected bool) string {
+	item := p.items[match.index]
+	highlight := color.New(color.FgYellow, color.Bold)
+	var b strings.Builder
+	if selected {
+		b.WriteString(color.New(color.FgCyan, color.Bold).Sprint("▌ "))
+	} else {
+		b.WriteString("  ")
+	}
+	plain := color.New()
+	if selected {
+		plain.Add(color.Bold)
+	} else {
+		plain.DisableColor()
+	}
+	// Runs of matched and unmatched characters, each colored once
+	label := []rune(item.Label)
+	for start := 0; start < len(label); {
+		end := start + 1
+		for end < len(label) && match.positions[end] == match.positions[start] {
+			end++
+		}
+		if match.positions[start] {
+			b.WriteString(highlight.Sprint(string(label[start:end])))
+		} else {
+			b.WriteString(plain.Sprint(string(label[start:end])))
+		}
+		start = end
+	}
+	if item.Detail != "" {
+		b.WriteString("  ")
+		b.WriteString(color.New(color.Faint).Sprint(item.Detail))
+	}
+	return b.String()
+}
+
+// pickByNumber is the picker for terminals without raw mode: it lists the
+// matches numbered, and takes a number to choose or text to narrow the list
+func (p *picker) pickByNumber(reader *bufio.Reader) (int, error) {
+	for {
+		for i, match := range p.matches {
+			if i == pickerRows*2 {
+				fmt.Fprintf(p.out, "  … %d more; type to narrow the list\n", len(p.matches)-i)
+				break
+			}
+			fmt.Fprintf(p.out, "%3d  %s\n", i+1, p.row(match, false)[2:])
+		}
+		fmt.Fprintf(p.out, "%s (number, or text to filter): ", p.prompt)
+
+		line, _ := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return -1, ErrPickCanceled
+		}
+		if n, convErr := strconv.Atoi(line); convErr == nil && n >= 1 && n <= len(p.matches) {
+			return p.matches[n-1].index, nil
+		}
+		p.query = []rune(line)
+		p.filter()
+		if len(p.matches) == 0 {
+			fmt.Fprintf(p.out, "Nothing matches '%s'\n", line)
+			p.query = nil
+			p.filter()
+		}
+	}
+}
+
+// fuzzyMatch matches an item against the query's terms, each of which must
+// appear in order (not necessarily together) in the label or detail. Matches
+// at the start of words and runs of consecutive characters score higher.
+func fuzzyMatch(terms []string, item PickerItem) (pickerMatch, bool) {
+	match := pickerMatch{positions: map[int]bool{}}
+	label := []rune(strings.ToLower(item.Label))
+	text := append(append(label, ' ', ' '), []rune(strings.ToLower(item.Detail))...)
+	for _, term := range terms {
+		score, positions, ok := matchTerm(text, []rune(term))
+		if !ok {
+			return match, false
+		}
+		match.score += score
+		for _, pos := range positions {
+			if pos < len(label) {
+				match.positions[pos] = true
+			}
+		}
+	}
+	return match, true
+}
+
+// matchTerm finds the best-scoring occurrence of term's characters, in order,
+// in text
+func matchTerm(text, term []rune) (best int, bestPositions []int, found bool) {
+	if len(term) == 0 {
+		return 0, nil, true
+	}
+	for start, r := range text {
+		if r != term[0] {
+			continue
+		}
+		score, positions, ok := 0, make([]int, 0, len(term)), true
+		t := start
+		for _, want := range term {
+			for t < len(text) && text[t] != want {
+				t++
+			}
+			if t == len(text) {
+				ok = false
+				break
+			}
+			score += 16
+			if t == 0 || !unicode.IsLetter(text[t-1]) && !unicode.IsDigit(text[t-1]) {
+				score += 8
+			}
+			if n := len(positions); n > 0 {
+				if positions[n-1] == t-1 {
+					score += 12
+				} else {
+					score -= t - positions[n-1] - 1
+				}
+			}
+			positions = append(positions, t)
+			t++
+		}
+		if !ok {
+			// Later starts can't match either
+			break
+		}
+		if !found || score > best {
+			best, bestPositions, found = score, positions, true
+		}
+	}
+	return best, bestPositions, found
+}
@@ -0,0 +1,177 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// progressEnabled is whether progress bars are drawn; see SetProgressEnabled
+var progressEnabled = true
+
+// progressInterval limits how often a progress line is redrawn
+const progressInterval = 100 * time.Millisecond
+
+// SetProgressEnabled turns progress bars on or off. They are only drawn
+// when stderr is a terminal, whatever this is set to.
+func SetProgressEnabled(enabled bool) {
+	progressEnabled = enabled
+}
+
+// Progress draws a single, updating progress line on stderr for work that
+// takes a while: bytes for file transfers, or items for bulk operations and
+// exports. With a known total it shows a bar, a percentage, and the time
+// remaining; otherwise a running count and rate. It draws nothing when
+// progress is disabled or stderr is not a terminal, so it is always safe to
+// use. It is safe for concurrent use.
+type Progress struct {
+	mu       sync.Mutex
+	label    string
+	bytes    bool
+	total    int64
+	current  int64
+	start    time.Time
+	drawn    time.Time
+	visible  bool
+	disabled bool
+}
+
+// NewProgress starts a progress line counting items; total may be 0 when
+// the number of items isn't known up front
+func NewProgress(label string, total int) *Progress {
+	return newProgress(label, int64(total), false)
+}
+
+// NewByteProgress starts a progress line counting bytes; it can be passed
+// as the Progress of a files.UploadOptions
+func NewByteProgress(label string, total int64) *Progress {
+	return newProgress(label, total, true)
+}
+
+func newProgress(label string, total int64, bytes bool) *Progress {
+	return &Progress{
+		label:    label,
+		bytes:    bytes,
+		total:    total,
+		start:    time.Now(),
+		disabled: !progressEnabled || !stderrIsTerminal(),
+	}
+}
+
+// Add counts n more items or bytes as done
+func (p *Progress) Add(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current += int64(n)
+	p.draw(false)
+}
+
+// Progress sets the bytes transferred so far and the total, as a
+// files.ProgressReporter
+func (p *Progress) Progress(transferred, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current = transferred
+	p.total = total
+	p.draw(false)
+}
+
+// SetLabel changes the text shown before the bar
+func (p *Progress) SetLabel(label string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.label = label
+	p.draw(true)
+}
+
+// Done erases the progress line, leaving the terminal ready for output
+func (p *Progress) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.visible {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+		p.visible = false
+	}
+	p.disabled = true
+}
+
+// draw redraws the line, at most every progressInterval unless forced or
+// the work is complete
+func (p *Progress) draw(force bool) {
+	if p.disabled {
+		return
+	}
+	now := time.Now()
+	complete := p.total > 0 && p.current >= p.total
+	if !force && !complete && now.Sub(p.drawn) < progressInterval {
+		return
+	}
+	p.drawn = now
+	p.visible = true
+
+	elapsed := now.Sub(p.start)
+	var line strings.Builder
+	line.WriteString(p.label)
+	if p.total > 0 {
+		fraction := float64(p.current) / float64(p.total)
+		if fraction > 1 {
+			fraction = 1
+		}
+		filled := int(fraction * 20)
+		fmt.Fprintf(&line, " [%s%s] %3.0f%% %s/%s", strings.Repeat("█", filled), strings.Repeat("░", 20-filled),
+			fraction*100, p.format(p.current), p.format(p.total))
+		if p.current > 0 && !complete && elapsed > time.Second {
+			remaining := time.Duration(float64(elapsed) * (1/fraction - 1))
+			fmt.Fprintf(&line, " ETA %s", formatETA(remaining))
+		}
+	} else {
+		fmt.Fprintf(&line, " %s", p.format(p.current))
+	}
+	if seconds := elapsed.Seconds(); seconds >= 1 && p.bytes {
+		fmt.Fprintf(&line, " (%s/s)", formatBytes(int64(float64(p.current)/seconds)))
+	} else if seconds >= 1 && p.total == 0 {
+		fmt.Fprintf(&line, " (%.0f/s)", float64(p.current)/seconds)
+	}
+	fmt.Fprintf(os.Stderr, "\r\033[K%s", line.String())
+}
+
+// format shows an amount as bytes or as a plain count
+func (p *Progress) format(n int64) string {
+	if p.bytes {
+		return formatBytes(n)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// formatBytes shows a byte count in B, KB, MB, or GB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, suffix := float64(n)/unit, "KB"
+	for _, next := range []string{"MB", "GB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
+
+// formatETA shows a duration as m:ss, or h:mm:ss when it is an hour or more
+func formatETA(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// stderrIsTerminal reports whether stderr is a terminal rather than a file or pipe
+func stderrIsTerminal() bool {
+	stat, err := os.Stderr.Stat()
+	return err == nil && (stat.Mode()&os.ModeCharDevice) != 0
+}
@@ -0,0 +1,284 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+)
+
+// TableOptions controls how tables are fitted to the terminal
+type TableOptions struct {
+	// NoTruncate prints every value in full, however wide the table gets
+	NoTruncate bool
+	// MaxWidths caps the width of columns, keyed by lowercase header
+	MaxWidths map[string]int
+}
+
+// tableOptions are the options every table is rendered with
+var tableOptions TableOptions
+
+// SetTableOptions sets how tables are fitted to the terminal
+func SetTableOptions(opts TableOptions) {
+	tableOptions = opts
+}
+
+const (
+	// tablePadding separates columns
+	tablePadding = "   "
+	// minColumnWidth is the narrowest a column is truncated to, and
+	// minWrapWidth the narrowest a wrapped column gets
+	minColumnWidth = 8
+	minWrapWidth   = 20
+)
+
+// ansiPattern matches the color escape sequences cells may contain
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// DisplayWidth is the number of terminal columns s takes, ignoring colors
+func DisplayWidth(s string) int {
+	return runewidth.StringWidth(ansiPattern.ReplaceAllString(s, ""))
+}
+
+// Truncate shortens s to at most width terminal columns, ending it with "…"
+// when it is cut. Colors are kept and reset after the cut.
+func Truncate(s string, width int) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	used := 0
+	colored := false
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "\x1b[") {
+			if loc := ansiPattern.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+				b.WriteString(s[i : i+loc[1]])
+				i += loc[1]
+				colored = true
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := runewidth.RuneWidth(r)
+		if used+w > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+		i += size
+	}
+	b.WriteString("…")
+	if colored {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// wrapText breaks s into lines of at most width columns at spaces, splitting
+// words that are wider than a line
+func wrapText(s string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		for runewidth.StringWidth(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			head := runewidth.Truncate(word, width, "")
+			lines = append(lines, head)
+			word = word[len(head):]
+		}
+		switch {
+		case word == "":
+		case line == "":
+			line = word
+		case runewidth.StringWidth(line)+1+runewidth.StringWidth(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// TerminalWidth is the width tables are fitted to: $COLUMNS when set, else
+// the width of the terminal stdout writes to, or 0 (no limit) when stdout
+// isn't a terminal
+func TerminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return stdoutWidth()
+}
+
+// renderTable writes a table fitted to width columns (0 for no limit).
+// Columns named in data.Wrap (by default, a Title column) wrap onto more
+// lines; other values that don't fit are truncated.
+func renderTable(w io.Writer, data TableData, width int) {
+	columns := len(data.Headers)
+	for _, row := range data.Rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	if columns == 0 {
+		return
+	}
+
+	headers := make([]string, columns)
+	for i := range headers {
+		if i < len(data.Headers) {
+			headers[i] = strings.ToUpper(strings.ReplaceAll(data.Headers[i], "_", " "))
+		}
+	}
+	wrapped := make([]bool, columns)
+	wrapNames := data.Wrap
+	if wrapNames == nil {
+		wrapNames = []string{"title"}
+	}
+	for i, header := range headers {
+		for _, name := range wrapNames {
+			if strings.EqualFold(header, name) {
+				wrapped[i] = true
+			}
+		}
+	}
+
+	// Cells are one line; wrapping adds lines
+	rows := make([][]string, len(data.Rows))
+	for i, row := range data.Rows {
+		rows[i] = make([]string, columns)
+		for j, cell := range row {
+			if strings.ContainsAny(cell, "\r\n\t") {
+				cell = strings.Join(strings.Fields(cell), " ")
+			}
+			rows[i][j] = cell
+		}
+	}
+
+	widths := make([]int, columns)
+	floors := make([]int, columns)
+	for i, header := range headers {
+		widths[i] = DisplayWidth(header)
+		for _, row := range rows {
+			if cellWidth := DisplayWidth(row[i]); cellWidth > widths[i] {
+				widths[i] = cellWidth
+			}
+		}
+	}
+
+	if !tableOptions.NoTruncate {
+		for i, header := range headers {
+			if limit, ok := tableOptions.MaxWidths[strings.ToLower(header)]; ok && limit > 0 && widths[i] > limit {
+				widths[i] = limit
+			}
+			floor := minColumnWidth
+			if wrapped[i] {
+				floor = minWrapWidth
+			}
+			if headerWidth := DisplayWidth(header); headerWidth > floor {
+				floor = headerWidth
+			}
+			floors[i] = min(widths[i], floor)
+		}
+		if width > 0 {
+			fitColumns(widths, floors, wrapped, width-len(tablePadding)*(columns-1))
+		}
+	}
+
+	writeLine := func(cells []string) {
+		var line strings.Builder
+		for i, cell := range cells {
+			if i > 0 {
+				line.WriteString(tablePadding)
+			}
+			line.WriteString(cell)
+			if i < len(cells)-1 {
+				line.WriteString(strings.Repeat(" ", max(0, widths[i]-DisplayWidth(cell))))
+			}
+		}
+		fmt.Fprintln(w, strings.TrimRight(line.String(), " "))
+	}
+
+	headerCells := make([]string, columns)
+	for i, header := range headers {
+		header = Truncate(header, widths[i])
+		headerCells[i] = color.New(color.FgCyan, color.Bold).Sprint(header) + strings.Repeat(" ", max(0, widths[i]-DisplayWidth(header)))
+	}
+	writeLine(headerCells)
+
+	for _, row := range rows {
+		lines := make([][]string, columns)
+		height := 1
+		for i, cell := range row {
+			switch {
+			case DisplayWidth(cell) <= widths[i]:
+				lines[i] = []string{cell}
+			case wrapped[i] && !ansiPattern.MatchString(cell):
+				lines[i] = wrapText(cell, widths[i])
+			default:
+				lines[i] = []string{Truncate(cell, widths[i])}
+			}
+			height = max(height, len(lines[i]))
+		}
+		for l := 0; l < height; l++ {
+			cells := make([]string, columns)
+			for i := range cells {
+				if l < len(lines[i]) {
+					cells[i] = lines[i][l]
+				}
+			}
+			writeLine(cells)
+		}
+	}
+}
+
+// fitColumns narrows columns until their widths add up to at most total:
+// wrapped columns first, since they lose nothing, then the widest of the
+// others, but none below its floor
+func fitColumns(widths, floors []int, wrapped []bool, total int) {
+	overflow := -total
+	for _, width := range widths {
+		overflow += width
+	}
+
+	for i := range widths {
+		if overflow <= 0 {
+			return
+		}
+		if wrapped[i] {
+			cut := min(overflow, widths[i]-floors[i])
+			widths[i] -= cut
+			overflow -= cut
+		}
+	}
+
+	for overflow > 0 {
+		widest := -1
+		for i := range widths {
+			if widths[i] > floors[i] && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+		overflow--
+	}
+}
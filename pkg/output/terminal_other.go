@@ -0,0 +1,18 @@
+//go:build !unix && !windows
+
+package output
+
+import (
+	"errors"
+	"os"
+)
+
+// stdoutWidth reports that the terminal width is unknown on this platform
+func stdoutWidth() int {
+	return 0
+}
+
+// makeRaw reports that raw terminal input isn't supported on this platform
+func makeRaw(f *os.File) (func(), error) {
+	return nil, errors.New("raw terminal input is not supported on this platform")
+}
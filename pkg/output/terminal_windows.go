@@ -0,0 +1,47 @@
+//go:build windows
+
+package output
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// stdoutWidth returns the width of the console stdout writes to, or 0 when
+// stdout isn't a console
+func stdoutWidth() int {
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(windows.Handle(terminalStdout.Fd()), &info); err != nil {
+		return 0
+	}
+	return int(info.Window.Right - info.Window.Left + 1)
+}
+
+// makeRaw puts the console f reads from into raw mode, so keys arrive one at
+// a time as terminal escape sequences without being echoed, and has stderr
+// understand escape sequences; the returned function restores both
+func makeRaw(f *os.File) (func(), error) {
+	in := windows.Handle(f.Fd())
+	var inMode uint32
+	if err := windows.GetConsoleMode(in, &inMode); err != nil {
+		return nil, err
+	}
+	raw := inMode&^(windows.ENABLE_ECHO_INPUT|windows.ENABLE_PROCESSED_INPUT|windows.ENABLE_LINE_INPUT) | windows.ENABLE_VIRTUAL_TERMINAL_INPUT
+	if err := windows.SetConsoleMode(in, raw); err != nil {
+		return nil, err
+	}
+
+	out := windows.Handle(os.Stderr.Fd())
+	var outMode uint32
+	outErr := windows.GetConsoleMode(out, &outMode)
+	if outErr == nil {
+		_ = windows.SetConsoleMode(out, outMode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING)
+	}
+	return func() {
+		_ = windows.SetConsoleMode(in, inMode)
+		if outErr == nil {
+			_ = windows.SetConsoleMode(out, outMode)
+		}
+	}, nil
+}
@@ -0,0 +1,299 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// autoNoColor is whether color was off before any --color setting: off when
+// NO_COLOR is set, TERM is dumb, or stdout isn't a terminal
+var autoNoColor = color.NoColor
+
+// SetColorMode turns color on or off: "auto" colors only a terminal and
+// honors NO_COLOR, "always" colors even pipes and files, and "never" never
+// colors
+func SetColorMode(mode string) error {
+	switch strings.ToLower(mode) {
+	case "", "auto":
+		color.NoColor = autoNoColor
+	case "always", "force":
+		// Colors created while NO_COLOR is set stay plain, so an explicit
+		// request for color drops it
+		os.Unsetenv("NO_COLOR")
+		color.NoColor = false
+	case "never", "none", "off":
+		color.NoColor = true
+	default:
+		return fmt.Errorf("invalid color mode '%s' (use auto, always, or never)", mode)
+	}
+	return nil
+}
+
+// themeRoles are the meanings a theme colors
+var themeRoles = []string{
+	"state.triage", "state.backlog", "state.unstarted", "state.started", "state.completed", "state.canceled",
+	"priority.urgent", "priority.high", "priority.normal", "priority.low", "priority.none",
+	"due", "due.soon", "due.overdue",
+}
+
+// themes are the built-in color themes. Each maps roles to color specs (see
+// parseColorSpec); "" leaves text as is.
+var themes = map[string]map[string]string{
+	// Linear's own colors; a state's color in Linear wins over its type's
+	"linear": {
+		"state.triage":    "#fc7840",
+		"state.backlog":   "#bec2c8",
+		"state.unstarted": "#e2e2e2",
+		"state.started":   "#f2c94c",
+		"state.completed": "#5e6ad2",
+		"state.canceled":  "#95a2b3",
+		"priority.urgent": "#eb5757 bold",
+		"priority.high":   "#f2994a",
+		"priority.normal": "",
+		"priority.low":    "faint",
+		"priority.none":   "faint",
+		"due":             "",
+		"due.soon":        "#f2c94c",
+		"due.overdue":     "#eb5757 bold",
+	},
+	// The 16 basic terminal colors, for terminals and palettes where
+	// Linear's colors read poorly
+	"basic": {
+		"state.triage":    "magenta",
+		"state.backlog":   "cyan",
+		"state.unstarted": "white",
+		"state.started":   "blue",
+		"state.completed": "green",
+		"state.canceled":  "red",
+		"priority.urgent": "red bold",
+		"priority.high":   "yellow",
+		"priority.normal": "",
+		"priority.low":    "faint",
+		"priority.none":   "faint",
+		"due":             "",
+		"due.soon":        "yellow",
+		"due.overdue":     "red bold",
+	},
+	// No hues, only weight: for monochrome terminals and color blindness
+	"mono": {
+		"state.triage":    "underline",
+		"state.backlog":   "faint",
+		"state.unstarted": "",
+		"state.started":   "bold",
+		"state.completed": "faint",
+		"state.canceled":  "faint",
+		"priority.urgent": "bold underline",
+		"priority.high":   "bold",
+		"priority.normal": "",
+		"priority.low":    "faint",
+		"priority.none":   "faint",
+		"due":             "",
+		"due.soon":        "bold",
+		"due.overdue":     "bold underline",
+	},
+}
+
+// DefaultTheme is the theme used unless the theme setting names another
+const DefaultTheme = "linear"
+
+// dueSoonDays is how many days ahead a due date counts as soon
+const dueSoonDays = 3
+
+var (
+	// themeName and themeColors are the current theme and its parsed
+	// colors, and themeOverrides the roles the config file sets
+	themeName      = DefaultTheme
+	themeColors    map[string][]color.Attribute
+	themeOverrides map[string]bool
+)
+
+// ThemeNames lists the built-in themes
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetTheme selects a built-in theme ("" for the default) and overrides some
+// of its roles with color specs, e.g. {"state.started": "yellow bold"}
+func SetTheme(name string, overrides map[string]string) error {
+	if name == "" {
+		name = DefaultTheme
+	}
+	specs, ok := themes[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown theme '%s' (available: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+
+	colors := make(map[string][]color.Attribute, len(themeRoles))
+	set := make(map[string]bool, len(overrides))
+	for _, role := range themeRoles {
+		attrs, err := parseColorSpec(specs[role])
+		if err != nil {
+			return err
+		}
+		colors[role] = attrs
+	}
+	for role, spec := range overrides {
+		role = strings.ToLower(role)
+		if _, ok := colors[role]; !ok {
+			return fmt.Errorf("unknown color '%s' (available: %s)", role, strings.Join(themeRoles, ", "))
+		}
+		attrs, err := parseColorSpec(spec)
+		if err != nil {
+			return fmt.Errorf("color %s: %w", role, err)
+		}
+		colors[role] = attrs
+		set[role] = true
+	}
+
+	themeName = strings.ToLower(name)
+	themeColors = colors
+	themeOverrides = set
+	return nil
+}
+
+// ThemeColor is the color of a role, e.g. "due.overdue", in the current theme
+func ThemeColor(role string) *color.Color {
+	if themeColors == nil {
+		_ = SetTheme(themeName, nil)
+	}
+	c := color.New(themeColors[role]...)
+	if len(themeColors[role]) == 0 {
+		// Plain text, without empty escape sequences around it
+		c.DisableColor()
+	}
+	return c
+}
+
+// StateColor is the color of a workflow state: its own color in Linear
+// (hex, may be "") with the linear theme, else its type's
+func StateColor(stateType, hex string) *color.Color {
+	role := "state." + strings.ToLower(stateType)
+	if themeName == "linear" && !themeOverrides[role] {
+		if attrs, err := parseHexColor(strings.ToLower(hex)); err == nil {
+			return color.New(attrs...)
+		}
+	}
+	return ThemeColor(role)
+}
+
+// PriorityColor is the color of a priority, 0 (none) to 4 (low)
+func PriorityColor(priority int) *color.Color {
+	switch priority {
+	case 1:
+		return ThemeColor("priority.urgent")
+	case 2:
+		return ThemeColor("priority.high")
+	case 3:
+		return ThemeColor("priority.normal")
+	case 4:
+		return ThemeColor("priority.low")
+	}
+	return ThemeColor("priority.none")
+}
+
+// DueColor is the color of a due date (YYYY-MM-DD): overdue before today, soon
+// within a few days, else plain. Due dates of finished issues are never
+// overdue; pass done for those.
+func DueColor(due string, done bool, now time.Time) *color.Color {
+	day, err := time.ParseInLocation("2006-01-02", due, now.Location())
+	if err != nil || done {
+		return ThemeColor("due")
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case day.Before(today):
+		return ThemeColor("due.overdue")
+	case !day.After(today.AddDate(0, 0, dueSoonDays)):
+		return ThemeColor("due.soon")
+	}
+	return ThemeColor("due")
+}
+
+// colorNames are the names color specs may use
+var colorNames = map[string]color.Attribute{
+	"black":          color.FgBlack,
+	"red":            color.FgRed,
+	"green":          color.FgGreen,
+	"yellow":         color.FgYellow,
+	"blue":           color.FgBlue,
+	"magenta":        color.FgMagenta,
+	"cyan":           color.FgCyan,
+	"white":          color.FgWhite,
+	"gray":           color.FgHiBlack,
+	"grey":           color.FgHiBlack,
+	"bright-red":     color.FgHiRed,
+	"bright-green":   color.FgHiGreen,
+	"bright-yellow":  color.FgHiYellow,
+	"bright-blue":    color.FgHiBlue,
+	"bright-magenta": color.FgHiMagenta,
+	"bright-cyan":    color.FgHiCyan,
+	"bright-white":   color.FgHiWhite,
+	"bold":           color.Bold,
+	"faint":          color.Faint,
+	"dim":            color.Faint,
+	"italic":         color.Italic,
+	"underline":      color.Underline,
+}
+
+// parseColorSpec parses a color spec: space- or +-separated color names,
+// styles (bold, faint, italic, underline), and #rrggbb colors, or "" or
+// "default" for none
+func parseColorSpec(spec string) ([]color.Attribute, error) {
+	var attrs []color.Attribute
+	for _, word := range strings.FieldsFunc(strings.ToLower(spec), func(r rune) bool { return r == ' ' || r == '+' || r == ',' }) {
+		if word == "default" || word == "none" {
+			continue
+		}
+		if attr, ok := colorNames[word]; ok {
+			attrs = append(attrs, attr)
+			continue
+		}
+		rgb, err := parseHexColor(word)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color '%s' (use a color name, bold, faint, italic, underline, or #rrggbb)", word)
+		}
+		attrs = append(attrs, rgb...)
+	}
+	return attrs, nil
+}
+
+// parseHexColor turns #rrggbb into a 24-bit color, or the nearest of the 256
+// colors when the terminal doesn't say it has 24-bit color
+func parseHexColor(hex string) ([]color.Attribute, error) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return nil, fmt.Errorf("invalid hex color")
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, err
+	}
+	r, g, b := int(value>>16), int(value>>8&0xff), int(value&0xff)
+
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return []color.Attribute{38, 2, color.Attribute(r), color.Attribute(g), color.Attribute(b)}, nil
+	}
+	// The 6x6x6 color cube of the 256-color palette
+	cube := func(c int) int {
+		if c < 48 {
+			return 0
+		}
+		if c < 115 {
+			return 1
+		}
+		return (c - 35) / 40
+	}
+	return []color.Attribute{38, 5, color.Attribute(16 + 36*cube(r) + 6*cube(g) + cube(b))}, nil
+}
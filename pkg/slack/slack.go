@@ -0,0 +1,133 @@
+// Package slack posts Block Kit messages to Slack incoming webhooks, which is
+// how linctl's reports, watches, and SLA checks alert a channel.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dorkitude/linctl/pkg/httpclient"
+)
+
+// Block Kit limits that a webhook rejects messages over
+const (
+	maxBlocks        = 50
+	maxHeaderChars   = 150
+	maxTextChars     = 3000
+	maxFields        = 10
+	maxFieldChars    = 2000
+	maxContextBlocks = 10
+)
+
+// Message is a webhook payload. Text is the notification and fallback text;
+// Blocks are what Slack shows.
+type Message struct {
+	Text   string  `json:"text"`
+	Blocks []Block `json:"blocks,omitempty"`
+}
+
+// Block is a Block Kit layout block
+type Block map[string]interface{}
+
+// text is a Block Kit text object
+func text(kind, content string, limit int) map[string]interface{} {
+	return map[string]interface{}{"type": kind, "text": truncate(content, limit)}
+}
+
+// Header is a large plain-text heading
+func Header(heading string) Block {
+	return Block{"type": "header", "text": text("plain_text", heading, maxHeaderChars)}
+}
+
+// Section is a paragraph of mrkdwn
+func Section(markdown string) Block {
+	return Block{"type": "section", "text": text("mrkdwn", markdown, maxTextChars)}
+}
+
+// Fields is a section of mrkdwn fields, shown two to a row
+func Fields(fields ...string) Block {
+	if len(fields) > maxFields {
+		fields = fields[:maxFields]
+	}
+	objects := make([]interface{}, len(fields))
+	for i, field := range fields {
+		objects[i] = text("mrkdwn", field, maxFieldChars)
+	}
+	return Block{"type": "section", "fields": objects}
+}
+
+// Context is a line of small mrkdwn text
+func Context(elements ...string) Block {
+	if len(elements) > maxContextBlocks {
+		elements = elements[:maxContextBlocks]
+	}
+	objects := make([]interface{}, len(elements))
+	for i, element := range elements {
+		objects[i] = text("mrkdwn", element, maxTextChars)
+	}
+	return Block{"type": "context", "elements": objects}
+}
+
+// Divider is a horizontal rule
+func Divider() Block {
+	return Block{"type": "divider"}
+}
+
+// Escape escapes the characters mrkdwn treats as markup
+func Escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// Link is a mrkdwn link, or just the escaped label without a URL
+func Link(url, label string) string {
+	if url == "" {
+		return Escape(label)
+	}
+	return fmt.Sprintf("<%s|%s>", url, Escape(label))
+}
+
+// truncate shortens s to limit characters, ending with an ellipsis
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:limit-1]) + "…"
+}
+
+// Post sends a message to an incoming webhook. Messages over Slack's block
+// limit are cut short with a note of how many blocks were left out.
+func Post(ctx context.Context, webhookURL string, message Message) error {
+	if len(message.Blocks) > maxBlocks {
+		left := len(message.Blocks) - (maxBlocks - 1)
+		message.Blocks = append(message.Blocks[:maxBlocks-1], Context(fmt.Sprintf("…and %d more", left)))
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "linctl")
+
+	resp, err := httpclient.New().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("slack returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dorkitude/linctl/pkg/debug"
+	"github.com/dorkitude/linctl/pkg/httpclient"
+)
+
+// scope names linctl as the instrumentation library
+var scope = map[string]interface{}{"name": "github.com/dorkitude/linctl"}
+
+// exportSpans sends spans to the traces endpoint as OTLP/HTTP JSON
+func exportSpans(cfg *Config, spans []*Span) {
+	if len(spans) == 0 || cfg.TracesEndpoint == "" {
+		return
+	}
+	encoded := make([]map[string]interface{}, 0, len(spans))
+	for _, span := range spans {
+		encoded = append(encoded, encodeSpan(span))
+	}
+	post(cfg, cfg.TracesEndpoint, map[string]interface{}{
+		"resourceSpans": []map[string]interface{}{{
+			"resource":   map[string]interface{}{"attributes": encodeAttrs(cfg.Resource)},
+			"scopeSpans": []map[string]interface{}{{"scope": scope, "spans": encoded}},
+		}},
+	})
+}
+
+// exportMetrics sends metrics to the metrics endpoint as OTLP/HTTP JSON,
+// with delta temporality since each linctl run starts from zero
+func exportMetrics(cfg *Config, all []*series) {
+	if len(all) == 0 || cfg.MetricsEndpoint == "" {
+		return
+	}
+	now := unixNano(time.Now())
+	var encoded []map[string]interface{}
+	byName := make(map[string]map[string]interface{})
+	for _, s := range all {
+		point := map[string]interface{}{
+			"attributes":        encodeAttrs(s.attrs),
+			"startTimeUnixNano": unixNano(s.start),
+			"timeUnixNano":      now,
+		}
+		metric, ok := byName[s.name]
+		if !ok {
+			metric = map[string]interface{}{"name": s.name, "unit": s.unit}
+			if s.kind == histogramMetric {
+				metric["histogram"] = map[string]interface{}{"aggregationTemporality": 1, "dataPoints": []map[string]interface{}{}}
+			} else {
+				metric["sum"] = map[string]interface{}{"aggregationTemporality": 1, "isMonotonic": true, "dataPoints": []map[string]interface{}{}}
+			}
+			byName[s.name] = metric
+			encoded = append(encoded, metric)
+		}
+
+		var data map[string]interface{}
+		if s.kind == histogramMetric {
+			counts := make([]string, len(s.counts))
+			for i, n := range s.counts {
+				counts[i] = strconv.FormatInt(n, 10)
+			}
+			point["count"] = strconv.FormatInt(s.count, 10)
+			point["sum"] = s.sum
+			point["min"] = s.min
+			point["max"] = s.max
+			point["bucketCounts"] = counts
+			point["explicitBounds"] = durationBounds
+			data = metric["histogram"].(map[string]interface{})
+		} else {
+			point["asInt"] = strconv.FormatInt(int64(s.sum), 10)
+			data = metric["sum"].(map[string]interface{})
+		}
+		data["dataPoints"] = append(data["dataPoints"].([]map[string]interface{}), point)
+	}
+
+	post(cfg, cfg.MetricsEndpoint, map[string]interface{}{
+		"resourceMetrics": []map[string]interface{}{{
+			"resource":     map[string]interface{}{"attributes": encodeAttrs(cfg.Resource)},
+			"scopeMetrics": []map[string]interface{}{{"scope": scope, "metrics": encoded}},
+		}},
+	})
+}
+
+func encodeSpan(span *Span) map[string]interface{} {
+	encoded := map[string]interface{}{
+		"traceId":           hex.EncodeToString(span.context.TraceID[:]),
+		"spanId":            hex.EncodeToString(span.context.SpanID[:]),
+		"name":              span.name,
+		"kind":              span.kind,
+		"startTimeUnixNano": unixNano(span.start),
+		"endTimeUnixNano":   unixNano(span.end),
+		"attributes":        encodeAttrs(span.attrs),
+	}
+	if span.parentID != [8]byte{} {
+		encoded["parentSpanId"] = hex.EncodeToString(span.parentID[:])
+	}
+	if span.status != 0 {
+		status := map[string]interface{}{"code": span.status}
+		if span.message != "" {
+			status["message"] = span.message
+		}
+		encoded["status"] = status
+	}
+	return encoded
+}
+
+// encodeAttrs encodes attributes as OTLP KeyValues
+func encodeAttrs(attrs []Attr) []map[string]interface{} {
+	encoded := make([]map[string]interface{}, 0, len(attrs))
+	for _, attr := range attrs {
+		var value map[string]interface{}
+		switch v := attr.Value.(type) {
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		case int64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]interface{}{"doubleValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": attrString(v)}
+		}
+		encoded = append(encoded, map[string]interface{}{"key": attr.Key, "value": value})
+	}
+	return encoded
+}
+
+func attrString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// post sends one export request. Failures are only logged: telemetry must
+// never make a command fail.
+func post(cfg *Config, endpoint string, payload interface{}) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		debug.Logf(debug.Requests, "telemetry: failed to encode export: %v", err)
+		return
+	}
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		debug.Logf(debug.Requests, "telemetry: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range cfg.Headers {
+		req.Header.Set(key, value)
+	}
+
+	client := &http.Client{Transport: httpclient.Transport(), Timeout: cfg.Timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		debug.Logf(debug.Requests, "telemetry: export to %s failed: %v", endpoint, err)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	debug.Logf(debug.Requests, "telemetry: exported %d bytes to %s: %d %s", len(body), endpoint, resp.StatusCode, http.StatusText(resp.StatusCode))
+}
+
+// unixNano encodes a time as OTLP JSON does: nanoseconds, as a string
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
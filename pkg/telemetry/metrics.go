@@ -0,0 +1,109 @@
+package telemetry
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// durationBounds are the histogram bucket bounds for durations, in
+// milliseconds
+var durationBounds = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000}
+
+// metricKind tells counters from histograms
+type metricKind int
+
+const (
+	counterMetric metricKind = iota
+	histogramMetric
+)
+
+// series is one metric aggregated for one set of attributes
+type series struct {
+	name   string
+	unit   string
+	kind   metricKind
+	attrs  []Attr
+	start  time.Time
+	count  int64
+	sum    float64
+	min    float64
+	max    float64
+	counts []int64
+}
+
+// metrics holds every series recorded since the last export, by name and
+// attributes
+var metrics = map[string]*series{}
+
+// RecordDuration records a duration in a histogram, in milliseconds
+func RecordDuration(name string, d time.Duration, attrs ...Attr) {
+	ms := float64(d) / float64(time.Millisecond)
+	record(name, "ms", histogramMetric, ms, attrs)
+}
+
+// Add adds n to a counter; unit is e.g. "By" for bytes or "{request}"
+func Add(name, unit string, n int64, attrs ...Attr) {
+	record(name, unit, counterMetric, float64(n), attrs)
+}
+
+func record(name, unit string, kind metricKind, value float64, attrs []Attr) {
+	mu.Lock()
+	defer mu.Unlock()
+	if config == nil || config.MetricsEndpoint == "" {
+		return
+	}
+
+	attrs = sortedAttrs(attrs)
+	key := seriesKey(name, attrs)
+	s, ok := metrics[key]
+	if !ok {
+		s = &series{name: name, unit: unit, kind: kind, attrs: attrs, start: time.Now(), min: value, max: value}
+		if kind == histogramMetric {
+			s.counts = make([]int64, len(durationBounds)+1)
+		}
+		metrics[key] = s
+	}
+
+	s.count++
+	s.sum += value
+	if value < s.min {
+		s.min = value
+	}
+	if value > s.max {
+		s.max = value
+	}
+	if kind == histogramMetric {
+		bucket := sort.SearchFloat64s(durationBounds, value)
+		s.counts[bucket]++
+	}
+}
+
+// takeMetrics removes and returns every series; the caller holds mu
+func takeMetrics() []*series {
+	taken := make([]*series, 0, len(metrics))
+	for _, s := range metrics {
+		taken = append(taken, s)
+	}
+	metrics = map[string]*series{}
+	sort.Slice(taken, func(i, j int) bool { return taken[i].name < taken[j].name })
+	return taken
+}
+
+func sortedAttrs(attrs []Attr) []Attr {
+	sorted := append([]Attr(nil), attrs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+	return sorted
+}
+
+func seriesKey(name string, attrs []Attr) string {
+	var key strings.Builder
+	key.WriteString(name)
+	for _, attr := range attrs {
+		key.WriteString("\x00")
+		key.WriteString(attr.Key)
+		key.WriteString("=")
+		key.WriteString(attrString(attr.Value))
+	}
+	return key.String()
+}
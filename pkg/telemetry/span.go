@@ -0,0 +1,157 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+)
+
+// Span times one piece of work: a command, an API call, an upload. A nil
+// *Span is valid and does nothing, which is what Start returns while
+// telemetry is off.
+type Span struct {
+	name     string
+	kind     int
+	context  SpanContext
+	parentID [8]byte
+	start    time.Time
+	end      time.Time
+	attrs    []Attr
+	status   int
+	message  string
+}
+
+type spanKey struct{}
+
+// StartCommand starts the span of the command linctl is running. Spans
+// started with a context that carries no span become its children, so API
+// calls made with context.Background() still show up under the command.
+func StartCommand(name string, attrs ...Attr) *Span {
+	span := start(nil, name, kindInternal, attrs)
+	mu.Lock()
+	root = span
+	mu.Unlock()
+	return span
+}
+
+// Start starts a span for work done by linctl itself, as a child of the
+// span in ctx
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	return startIn(ctx, name, kindInternal, attrs)
+}
+
+// StartClient starts a span for a request to another service, as a child
+// of the span in ctx
+func StartClient(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	return startIn(ctx, name, kindClient, attrs)
+}
+
+func startIn(ctx context.Context, name string, kind int, attrs []Attr) (context.Context, *Span) {
+	parent, _ := ctx.Value(spanKey{}).(*Span)
+	span := start(parent, name, kind, attrs)
+	if span == nil {
+		return ctx, nil
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func start(parent *Span, name string, kind int, attrs []Attr) *Span {
+	mu.Lock()
+	defer mu.Unlock()
+	if config == nil || config.TracesEndpoint == "" {
+		return nil
+	}
+	if parent == nil {
+		parent = root
+	}
+
+	span := &Span{name: name, kind: kind, start: time.Now(), attrs: attrs}
+	switch {
+	case parent != nil:
+		span.context.TraceID = parent.context.TraceID
+		span.context.Sampled = parent.context.Sampled
+		span.parentID = parent.context.SpanID
+	case config.Parent != nil:
+		span.context.TraceID = config.Parent.TraceID
+		span.context.Sampled = config.Parent.Sampled
+		span.parentID = config.Parent.SpanID
+	default:
+		randomID(span.context.TraceID[:])
+		span.context.Sampled = true
+	}
+	randomID(span.context.SpanID[:])
+	return span
+}
+
+// SetAttributes adds attributes to the span, replacing any with the same key
+func (s *Span) SetAttributes(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !s.end.IsZero() {
+		return
+	}
+	for _, attr := range attrs {
+		replaced := false
+		for i := range s.attrs {
+			if s.attrs[i].Key == attr.Key {
+				s.attrs[i] = attr
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			s.attrs = append(s.attrs, attr)
+		}
+	}
+}
+
+// SetError marks the span failed with err; nil marks it successful
+func (s *Span) SetError(err error) {
+	if s == nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !s.end.IsZero() {
+		return
+	}
+	if err != nil {
+		s.status = statusError
+		s.message = err.Error()
+	} else {
+		s.status = statusOK
+		s.message = ""
+	}
+}
+
+// End finishes the span and queues it to be sent
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !s.end.IsZero() {
+		return
+	}
+	s.end = time.Now()
+	if root == s {
+		root = nil
+	}
+	if !s.context.Sampled || config == nil {
+		return
+	}
+
+	finished = append(finished, s)
+	if len(finished) >= batchSize {
+		cfg, batch := config, finished
+		finished = nil
+		pending.Add(1)
+		go func() {
+			defer pending.Done()
+			exportSpans(cfg, batch)
+		}()
+	}
+}
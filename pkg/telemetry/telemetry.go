@@ -0,0 +1,242 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Span kinds, as numbered by OTLP
+const (
+	kindInternal = 1
+	kindClient   = 3
+)
+
+// Status codes, as numbered by OTLP
+const (
+	statusOK    = 1
+	statusError = 2
+)
+
+// batchSize is how many finished spans are buffered before they are sent;
+// the rest go out when Shutdown is called
+const batchSize = 512
+
+// Config says where traces and metrics are sent. It is read from the
+// standard OTEL_* environment variables by ConfigFromEnv.
+type Config struct {
+	// TracesEndpoint and MetricsEndpoint are OTLP/HTTP URLs; empty disables
+	// that signal
+	TracesEndpoint  string
+	MetricsEndpoint string
+	// Headers are sent with every export, e.g. an API key for the collector
+	Headers map[string]string
+	// Timeout bounds each export
+	Timeout time.Duration
+	// Resource describes this process: service.name, service.version, ...
+	Resource []Attr
+	// Parent is the span linctl runs under, from a W3C TRACEPARENT
+	Parent *SpanContext
+}
+
+// SpanContext identifies a span across processes
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// Attr is a span or metric attribute
+type Attr struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute
+func String(key, value string) Attr { return Attr{Key: key, Value: value} }
+
+// Int returns an integer attribute
+func Int(key string, value int64) Attr { return Attr{Key: key, Value: value} }
+
+// Bool returns a boolean attribute
+func Bool(key string, value bool) Attr { return Attr{Key: key, Value: value} }
+
+var (
+	mu       sync.Mutex
+	config   *Config
+	root     *Span
+	finished []*Span
+	pending  sync.WaitGroup
+)
+
+// ConfigFromEnv reads the standard OpenTelemetry environment variables.
+// It returns nil when no OTLP endpoint is set or the SDK is disabled, and an
+// error for settings linctl can't honor (only the http/json protocol is
+// supported).
+func ConfigFromEnv(serviceVersion string) (*Config, error) {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return nil, nil
+	}
+
+	cfg := &Config{
+		TracesEndpoint:  signalEndpoint("TRACES", "/v1/traces"),
+		MetricsEndpoint: signalEndpoint("METRICS", "/v1/metrics"),
+		Headers:         parseList(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")),
+		Timeout:         10 * time.Second,
+	}
+	if strings.EqualFold(os.Getenv("OTEL_TRACES_EXPORTER"), "none") {
+		cfg.TracesEndpoint = ""
+	}
+	if strings.EqualFold(os.Getenv("OTEL_METRICS_EXPORTER"), "none") {
+		cfg.MetricsEndpoint = ""
+	}
+	if cfg.TracesEndpoint == "" && cfg.MetricsEndpoint == "" {
+		return nil, nil
+	}
+
+	if protocol := os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"); protocol != "" && protocol != "http/json" {
+		return nil, fmt.Errorf("OTEL_EXPORTER_OTLP_PROTOCOL=%s is not supported; use http/json", protocol)
+	}
+	for _, endpoint := range []string{cfg.TracesEndpoint, cfg.MetricsEndpoint} {
+		if endpoint == "" {
+			continue
+		}
+		if u, err := url.Parse(endpoint); err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid OTLP endpoint '%s'", endpoint)
+		}
+	}
+	if ms, err := strconv.Atoi(os.Getenv("OTEL_EXPORTER_OTLP_TIMEOUT")); err == nil && ms > 0 {
+		cfg.Timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	resource := parseList(os.Getenv("OTEL_RESOURCE_ATTRIBUTES"))
+	if serviceName == "" {
+		serviceName = resource["service.name"]
+	}
+	if serviceName == "" {
+		serviceName = "linctl"
+	}
+	cfg.Resource = []Attr{
+		String("service.name", serviceName),
+		String("service.version", serviceVersion),
+		String("os.type", runtime.GOOS),
+		String("host.arch", runtime.GOARCH),
+	}
+	for key, value := range resource {
+		if key != "service.name" {
+			cfg.Resource = append(cfg.Resource, String(key, value))
+		}
+	}
+
+	if parent, ok := parseTraceparent(os.Getenv("TRACEPARENT")); ok {
+		cfg.Parent = &parent
+	}
+	return cfg, nil
+}
+
+// Init starts recording with cfg; nil leaves telemetry off, so every call
+// in this package does nothing
+func Init(cfg *Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	config = cfg
+}
+
+// Enabled reports whether telemetry is being recorded
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return config != nil
+}
+
+// Shutdown sends everything recorded and not yet sent, waiting at most
+// until ctx is done. Spans still open are not sent.
+func Shutdown(ctx context.Context) {
+	mu.Lock()
+	cfg := config
+	spans := finished
+	finished = nil
+	metrics := takeMetrics()
+	mu.Unlock()
+	if cfg == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		pending.Wait()
+		exportSpans(cfg, spans)
+		exportMetrics(cfg, metrics)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// signalEndpoint resolves the endpoint of one signal: its own variable as
+// given, or the shared one with the signal's path appended
+func signalEndpoint(signal, path string) string {
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_ENDPOINT"); endpoint != "" {
+		return endpoint
+	}
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/") + path
+	}
+	return ""
+}
+
+// parseList parses the key=value,key=value lists of OTEL_* variables, whose
+// values may be URL-encoded
+func parseList(value string) map[string]string {
+	list := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		if decoded, err := url.QueryUnescape(strings.TrimSpace(val)); err == nil {
+			val = decoded
+		}
+		list[key] = val
+	}
+	return list
+}
+
+// parseTraceparent parses a W3C traceparent: version-traceid-spanid-flags
+func parseTraceparent(value string) (SpanContext, bool) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, false
+	}
+	traceID, err1 := hex.DecodeString(parts[1])
+	spanID, err2 := hex.DecodeString(parts[2])
+	flags, err3 := strconv.ParseUint(parts[3], 16, 8)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return sc, false
+	}
+	copy(sc.TraceID[:], traceID)
+	copy(sc.SpanID[:], spanID)
+	sc.Sampled = flags&1 == 1
+	if sc.TraceID == [16]byte{} || sc.SpanID == [8]byte{} {
+		return sc, false
+	}
+	return sc, true
+}
+
+// randomID fills id with random bytes
+func randomID(id []byte) {
+	_, _ = rand.Read(id)
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrNoClipboardImage is returned when the clipboard holds no image
+var ErrNoClipboardImage = errors.New("the clipboard does not contain an image")
+
+// SaveClipboardImage writes the image on the system clipboard to path as a
+// PNG. It uses osascript on macOS, PowerShell on Windows, and wl-paste
+// (Wayland) or xclip (X11) elsewhere.
+func SaveClipboardImage(path string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf(`set f to open for access POSIX file %q with write permission
+try
+	write (the clipboard as «class PNGf») to f
+	close access f
+on error
+	close access f
+	error "no image"
+end try`, path)
+		if err := exec.Command("osascript", "-e", script).Run(); err != nil {
+			os.Remove(path)
+			return ErrNoClipboardImage
+		}
+		return nil
+	case "windows":
+		script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms
+$img = [System.Windows.Forms.Clipboard]::GetImage()
+if ($img -eq $null) { exit 2 }
+$img.Save('%s', [System.Drawing.Imaging.ImageFormat]::Png)`, strings.ReplaceAll(path, "'", "''"))
+		err := exec.Command("powershell", "-NoProfile", "-STA", "-Command", script).Run()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 2 {
+			return ErrNoClipboardImage
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the clipboard: %w", err)
+		}
+		return nil
+	default:
+		return saveClipboardImageUnix(path)
+	}
+}
+
+// saveClipboardImageUnix reads a PNG from the Wayland or X11 clipboard
+func saveClipboardImageUnix(path string) error {
+	var cmd *exec.Cmd
+	switch {
+	case os.Getenv("WAYLAND_DISPLAY") != "" && commandExists("wl-paste"):
+		// Check the offered types first, so a clipboard holding text is
+		// reported as having no image rather than pasted as one
+		types, err := exec.Command("wl-paste", "--list-types").Output()
+		if err != nil || !strings.Contains(string(types), "image/png") {
+			return ErrNoClipboardImage
+		}
+		cmd = exec.Command("wl-paste", "--no-newline", "--type", "image/png")
+	case commandExists("xclip"):
+		types, err := exec.Command("xclip", "-selection", "clipboard", "-t", "TARGETS", "-o").Output()
+		if err != nil || !strings.Contains(string(types), "image/png") {
+			return ErrNoClipboardImage
+		}
+		cmd = exec.Command("xclip", "-selection", "clipboard", "-t", "image/png", "-o")
+	default:
+		return fmt.Errorf("reading the clipboard needs wl-paste (wl-clipboard) or xclip")
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	data, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to read the clipboard: %s", strings.TrimSpace(stderr.String()))
+	}
+	if len(data) == 0 {
+		return ErrNoClipboardImage
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// commandExists reports whether name is an executable on PATH
+func commandExists(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// emojiGlyphs maps the names Linear stores reactions under, the shortcodes
+// GitHub and Slack use, to their emoji
+var emojiGlyphs = map[string]string{
+	"+1":                    "👍",
+	"-1":                    "👎",
+	"100":                   "💯",
+	"bug":                   "🐛",
+	"bulb":                  "💡",
+	"clap":                  "👏",
+	"confused":              "😕",
+	"cry":                   "😢",
+	"eyes":                  "👀",
+	"fire":                  "🔥",
+	"grinning":              "😀",
+	"heart":                 "❤️",
+	"heart_eyes":            "😍",
+	"heavy_check_mark":      "✔️",
+	"hourglass":             "⌛",
+	"joy":                   "😂",
+	"laughing":              "😆",
+	"memo":                  "📝",
+	"muscle":                "💪",
+	"ok_hand":               "👌",
+	"pray":                  "🙏",
+	"raised_hands":          "🙌",
+	"rocket":                "🚀",
+	"rotating_light":        "🚨",
+	"see_no_evil":           "🙈",
+	"skull":                 "💀",
+	"slightly_smiling_face": "🙂",
+	"smile":                 "😄",
+	"sob":                   "😭",
+	"sparkles":              "✨",
+	"star":                  "⭐",
+	"sweat_smile":           "😅",
+	"tada":                  "🎉",
+	"thinking_face":         "🤔",
+	"warning":               "⚠️",
+	"wave":                  "👋",
+	"white_check_mark":      "✅",
+	"wink":                  "😉",
+	"x":                     "❌",
+	"zap":                   "⚡",
+}
+
+// emojiAliases are other shortcodes for the names above
+var emojiAliases = map[string]string{
+	"thumbsup":    "+1",
+	"thumbs_up":   "+1",
+	"thumbsdown":  "-1",
+	"thumbs_down": "-1",
+	"check":       "white_check_mark",
+	"party":       "tada",
+	"thinking":    "thinking_face",
+	"lol":         "laughing",
+}
+
+// variationSelector asks for an emoji to be drawn as an emoji rather than as
+// text; some keyboards add it and some don't
+const variationSelector = "\uFE0F"
+
+// emojiNamePattern matches a shortcode, which may name a workspace's custom
+// emoji
+var emojiNamePattern = regexp.MustCompile(`^[a-z0-9_+-]+$`)
+
+// EmojiName turns an emoji given as a shortcode (":+1:" or "+1"), an alias
+// ("thumbsup"), or the emoji itself ("👍") into the name Linear stores
+// reactions under. Shortcodes it doesn't know are kept, for custom emoji.
+func EmojiName(emoji string) (string, error) {
+	emoji = strings.TrimSpace(emoji)
+	name := strings.ToLower(strings.Trim(emoji, ":"))
+	if alias, ok := emojiAliases[name]; ok {
+		return alias, nil
+	}
+	if emojiNamePattern.MatchString(name) {
+		return name, nil
+	}
+
+	// Emoji are compared without their variation selectors
+	glyph := strings.TrimSuffix(emoji, variationSelector)
+	for name, known := range emojiGlyphs {
+		if strings.TrimSuffix(known, variationSelector) == glyph {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("unknown emoji '%s'; give a shortcode such as :+1: or :tada:", emoji)
+}
+
+// EmojiGlyph is the emoji a reaction name stands for, or the name as a
+// :shortcode: when it isn't one linctl knows
+func EmojiGlyph(name string) string {
+	if glyph, ok := emojiGlyphs[name]; ok {
+		return glyph
+	}
+	return ":" + name + ":"
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrScreenshotCanceled is returned when the user cancels a screenshot
+var ErrScreenshotCanceled = errors.New("screenshot canceled")
+
+// CaptureScreenshot runs the platform's screenshot tool and waits for it to
+// write a PNG to path. The user selects a region unless fullScreen is set.
+// It uses screencapture on macOS, grim (with slurp for regions) on Wayland,
+// gnome-screenshot, scrot, maim, or ImageMagick's import on X11, and the
+// Snipping Tool on Windows, which supports only regions.
+func CaptureScreenshot(path string, fullScreen bool) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		args := []string{"-x"}
+		if !fullScreen {
+			args = append(args, "-i")
+		}
+		cmd = exec.Command("screencapture", append(args, path)...)
+	case "windows":
+		// The Snipping Tool copies its capture to the clipboard
+		if err := exec.Command("snippingtool", "/clip").Run(); err != nil {
+			return fmt.Errorf("failed to run the Snipping Tool: %w", err)
+		}
+		if err := SaveClipboardImage(path); err != nil {
+			if errors.Is(err, ErrNoClipboardImage) {
+				return ErrScreenshotCanceled
+			}
+			return err
+		}
+		return nil
+	default:
+		var err error
+		cmd, err = unixScreenshotCommand(path, fullScreen)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := cmd.Run(); err != nil {
+		// Most tools exit non-zero when the selection is canceled
+		if _, statErr := os.Stat(path); statErr != nil {
+			return ErrScreenshotCanceled
+		}
+		return fmt.Errorf("screenshot failed: %w", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+		return ErrScreenshotCanceled
+	}
+	return nil
+}
+
+// unixScreenshotCommand picks the first screenshot tool available for the
+// current Wayland or X11 session
+func unixScreenshotCommand(path string, fullScreen bool) (*exec.Cmd, error) {
+	if os.Getenv("WAYLAND_DISPLAY") != "" && commandExists("grim") {
+		if fullScreen || !commandExists("slurp") {
+			return exec.Command("grim", path), nil
+		}
+		geometry, err := exec.Command("slurp").Output()
+		if err != nil {
+			return nil, ErrScreenshotCanceled
+		}
+		return exec.Command("grim", "-g", strings.TrimSpace(string(geometry)), path), nil
+	}
+
+	switch {
+	case commandExists("gnome-screenshot"):
+		if fullScreen {
+			return exec.Command("gnome-screenshot", "-f", path), nil
+		}
+		return exec.Command("gnome-screenshot", "-a", "-f", path), nil
+	case commandExists("scrot"):
+		if fullScreen {
+			return exec.Command("scrot", "--overwrite", path), nil
+		}
+		return exec.Command("scrot", "--select", "--overwrite", path), nil
+	case commandExists("maim"):
+		if fullScreen {
+			return exec.Command("maim", path), nil
+		}
+		return exec.Command("maim", "--select", path), nil
+	case commandExists("import"):
+		if fullScreen {
+			return exec.Command("import", "-window", "root", path), nil
+		}
+		return exec.Command("import", path), nil
+	}
+	return nil, fmt.Errorf("taking a screenshot needs grim, gnome-screenshot, scrot, maim, or ImageMagick's import")
+}
@@ -7,6 +7,15 @@ import (
 	"time"
 )
 
+// compactTimeUnits maps the suffixes of compact offsets like "90d" to units
+var compactTimeUnits = map[string]string{
+	"h": "hours",
+	"d": "days",
+	"w": "weeks",
+	"m": "months",
+	"y": "years",
+}
+
 // ParseTimeExpression converts time expressions like "3_weeks_ago" into ISO8601 datetime strings
 // Returns empty string for "all_time"
 // Default is "6_months_ago" if empty string is provided
@@ -31,10 +40,17 @@ func ParseTimeExpression(expr string) (string, error) {
 		return expr, nil
 	}
 
+	// Compact offsets like "90d" mean the same as "90_days_ago"
+	if units, ok := compactTimeUnits[expr[len(expr)-1:]]; ok && len(expr) > 1 {
+		if _, err := strconv.Atoi(expr[:len(expr)-1]); err == nil {
+			expr = expr[:len(expr)-1] + "_" + units + "_ago"
+		}
+	}
+
 	// Parse relative time expressions
 	parts := strings.Split(expr, "_")
 	if len(parts) < 3 || parts[len(parts)-1] != "ago" {
-		return "", fmt.Errorf("invalid time expression: %s (expected format like '3_weeks_ago' or 'all_time')", expr)
+		return "", fmt.Errorf("invalid time expression: %s (expected format like '3_weeks_ago', '90d', or 'all_time')", expr)
 	}
 
 	// Get the number
@@ -70,3 +86,89 @@ func ParseTimeExpression(expr string) (string, error) {
 	// Return as ISO8601 string
 	return targetTime.Format(time.RFC3339), nil
 }
+
+// dueWeekdays maps weekday names and their abbreviations to time.Weekday
+var dueWeekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// ParseDueDate converts a due date given as YYYY-MM-DD, a relative offset
+// ("+3d", "2w", "in 3 days"), or words ("today", "tomorrow", "friday",
+// "next friday", "next week", "end of month") into a YYYY-MM-DD date, counted
+// from now. A weekday is its next occurrence after today; "next week" is the
+// coming Monday.
+func ParseDueDate(expr string, now time.Time) (string, error) {
+	expr = strings.ToLower(strings.Join(strings.Fields(expr), " "))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	format := func(t time.Time) (string, error) { return t.Format("2006-01-02"), nil }
+
+	if t, err := time.Parse("2006-01-02", expr); err == nil {
+		return format(t)
+	}
+
+	switch expr {
+	case "today":
+		return format(today)
+	case "tomorrow":
+		return format(today.AddDate(0, 0, 1))
+	case "next week":
+		return format(nextWeekday(today, time.Monday))
+	case "end of week", "eow":
+		return format(nextWeekday(today.AddDate(0, 0, -1), time.Friday))
+	case "next month":
+		return format(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()))
+	case "end of month", "eom":
+		return format(time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()))
+	}
+
+	if day, ok := dueWeekdays[strings.TrimPrefix(strings.TrimPrefix(expr, "next "), "this ")]; ok {
+		return format(nextWeekday(today, day))
+	}
+
+	if offset, err := ParseDayOffset(strings.TrimPrefix(expr, "in ")); err == nil {
+		return format(offset(today))
+	}
+
+	return "", fmt.Errorf("invalid date '%s' (use YYYY-MM-DD, +3d, 2w, tomorrow, friday, next week, or end of month)", expr)
+}
+
+// ParseDayOffset parses an offset in days, weeks, months, or years such as
+// "+3d", "7d", "2w", "1m", or "3 days" into a function that applies it to a date
+func ParseDayOffset(expr string) (func(time.Time) time.Time, error) {
+	expr = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(expr)), "+")
+	i := 0
+	for i < len(expr) && expr[i] >= '0' && expr[i] <= '9' {
+		i++
+	}
+	num, err := strconv.Atoi(expr[:i])
+	if err != nil {
+		return nil, fmt.Errorf("invalid offset '%s' (expected a number and unit, like 3d or 2w)", expr)
+	}
+
+	switch strings.TrimSuffix(strings.TrimSpace(expr[i:]), "s") {
+	case "d", "day":
+		return func(t time.Time) time.Time { return t.AddDate(0, 0, num) }, nil
+	case "w", "week":
+		return func(t time.Time) time.Time { return t.AddDate(0, 0, num*7) }, nil
+	case "m", "month":
+		return func(t time.Time) time.Time { return t.AddDate(0, num, 0) }, nil
+	case "y", "year":
+		return func(t time.Time) time.Time { return t.AddDate(num, 0, 0) }, nil
+	}
+	return nil, fmt.Errorf("invalid offset unit in '%s' (valid units: d, w, m, y)", expr)
+}
+
+// nextWeekday returns the first date after day that falls on weekday
+func nextWeekday(day time.Time, weekday time.Weekday) time.Time {
+	days := (int(weekday) - int(day.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return day.AddDate(0, 0, days)
+}